@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/lescactus/espressoapi-go/cmd/app"
+	"github.com/lescactus/espressoapi-go/internal/services/apikey"
+	"github.com/spf13/cobra"
+)
+
+// apikeysCmd represents the apikeys command
+var apikeysCmd = &cobra.Command{
+	Use:     "apikeys",
+	Aliases: []string{"apikey"},
+	Short:   "Administer api keys",
+	Long: `Create, list or revoke the api keys used to authenticate against the REST API.
+Only a hash of each key is stored: the key itself is printed once, at creation.`,
+}
+
+var apikeysCreateCmd = &cobra.Command{
+	Use:   "create <user>",
+	Short: "Create an api key for a user",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		scope, _ := cmd.Flags().GetString("scope")
+
+		key, secret, err := newAPIKeyService().CreateAPIKey(context.Background(), args[0], name, apikey.Scope(scope))
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to create api key")
+		}
+		app.App.Logger.Info().Int("id", key.Id).Msgf("Successfully created %s api key %q for user %q!", key.Scope, key.Name, key.UserName)
+		fmt.Fprintln(cmd.OutOrStdout(), secret)
+	},
+}
+
+var apikeysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the api keys",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		keys, err := newAPIKeyService().GetAllAPIKeys(context.Background())
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to list api keys")
+		}
+		if err := printAPIKeys(cmd.OutOrStdout(), keys); err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to list api keys")
+		}
+	},
+}
+
+var apikeysRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Revoke an api key",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Api key id must be an integer")
+		}
+		if err := newAPIKeyService().RevokeAPIKeyById(context.Background(), id); err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to revoke api key")
+		}
+		app.App.Logger.Info().Int("id", id).Msg("Successfully revoked api key!")
+	},
+}
+
+func init() {
+	apikeysCreateCmd.Flags().String("name", "", "Name of the api key, to tell keys apart")
+	apikeysCreateCmd.Flags().String("scope", string(apikey.ScopeRead), "Scope of the api key: read or write")
+	_ = apikeysCreateCmd.MarkFlagRequired("name")
+
+	apikeysCmd.AddCommand(apikeysCreateCmd)
+	apikeysCmd.AddCommand(apikeysListCmd)
+	apikeysCmd.AddCommand(apikeysRevokeCmd)
+}
+
+func newAPIKeyService() *apikey.APIKeyService {
+	repositories, err := newRepositorySet(app.App.Cfg.DatabaseType, app.App.Db)
+	if err != nil {
+		app.App.Logger.Fatal().Err(err).Msg("Failed to create repositories")
+	}
+	return apikey.New(repositories.apiKey, repositories.user)
+}
+
+// printAPIKeys writes api keys as a table, one per line.
+func printAPIKeys(w io.Writer, keys []apikey.APIKey) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tPREFIX\tNAME\tUSER\tSCOPE\tSTATUS\tLAST USED")
+	for _, k := range keys {
+		status := "active"
+		if k.RevokedAt != nil {
+			status = "revoked"
+		}
+		lastUsed := "never"
+		if k.LastUsedAt != nil {
+			lastUsed = k.LastUsedAt.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", k.Id, k.Prefix, k.Name, k.UserName, k.Scope, status, lastUsed)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/services/apikey"
+)
+
+func TestPrintAPIKeys(t *testing.T) {
+	used := time.Date(2026, time.October, 18, 16, 30, 0, 0, time.UTC)
+	keys := []apikey.APIKey{
+		{Id: 1, Prefix: "esp_abcdefgh", Name: "grafana", UserName: "alice", Scope: apikey.ScopeRead, LastUsedAt: &used},
+		{Id: 2, Prefix: "esp_ijklmnop", Name: "ci", UserName: "bob", Scope: apikey.ScopeWrite, RevokedAt: &used},
+	}
+
+	var buf bytes.Buffer
+	if err := printAPIKeys(&buf, keys); err != nil {
+		t.Fatalf("printAPIKeys() error = %v", err)
+	}
+
+	want := `ID  PREFIX        NAME     USER   SCOPE  STATUS   LAST USED
+1   esp_abcdefgh  grafana  alice  read   active   2026-10-18 16:30
+2   esp_ijklmnop  ci       bob    write  revoked  never
+`
+	if got := buf.String(); got != want {
+		t.Errorf("printAPIKeys() = %q, want %q", got, want)
+	}
+}
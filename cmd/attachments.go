@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/cmd/app"
+	"github.com/lescactus/espressoapi-go/internal/services/attachment"
+	"github.com/spf13/cobra"
+)
+
+// attachmentsCmd represents the attachments command
+var attachmentsCmd = &cobra.Command{
+	Use:     "attachments",
+	Aliases: []string{"attachment"},
+	Short:   "Administer the files of attachments",
+}
+
+var attachmentsSweepCmd = &cobra.Command{
+	Use:   "sweep",
+	Short: "Delete the files no attachment references anymore",
+	Long: `Delete the files and thumbnails of the blob store no attachment references
+anymore. Deleting a shot or beans deletes their attachments, but leaves their
+files behind, as do restoring a backup or resetting the seed data.
+
+Files written less than --older-than ago are kept, as an upload stores its
+files just before recording its attachment. Run it periodically, for example
+from cron:
+
+  espressoapi-go attachments sweep --older-than 24h`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		olderThan, _ := cmd.Flags().GetDuration("older-than")
+
+		deleted, err := newAttachmentService().SweepBlobs(context.Background(), time.Now().Add(-olderThan))
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Int("deleted", deleted).Msg("Failed to sweep attachment files")
+		}
+		app.App.Logger.Info().Int("deleted", deleted).Msg("Successfully swept attachment files!")
+	},
+}
+
+func init() {
+	attachmentsSweepCmd.Flags().Duration("older-than", time.Hour, "Only delete the files written at least this long ago")
+
+	attachmentsCmd.AddCommand(attachmentsSweepCmd)
+}
+
+func newAttachmentService() *attachment.AttachmentService {
+	repositories, err := newRepositorySet(app.App.Cfg.DatabaseType, app.App.Db)
+	if err != nil {
+		app.App.Logger.Fatal().Err(err).Msg("Failed to create repositories")
+	}
+	store, err := newBlobStore(app.App.Cfg)
+	if err != nil {
+		app.App.Logger.Fatal().Err(err).Msg("Failed to create blob store")
+	}
+	return attachment.New(repositories.attachment, store)
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/lescactus/espressoapi-go/cmd/app"
+	"github.com/lescactus/espressoapi-go/internal/services/backup"
+	"github.com/spf13/cobra"
+)
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the sheets, roasters, beans and shots",
+	Long: `Back up the sheets, roasters, beans and shots, with their ids,
+timestamps and owners, and the users owning them, into a versioned JSON document which does not depend on the
+database: a backup of a MySQL database can be restored into PostgreSQL and
+the other way around. The backup is gzipped when the --out file name ends
+with .gz.
+
+With --user, only the records of that user are backed up.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		userName, _ := cmd.Flags().GetString("user")
+		out, _ := cmd.Flags().GetString("out")
+
+		repositories, ctx := newUserRepositorySet(userName)
+		b, err := newBackupService(repositories).Backup(ctx)
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to back up")
+		}
+
+		w := cmd.OutOrStdout()
+		if out != "" {
+			f, err := os.Create(out)
+			if err != nil {
+				app.App.Logger.Fatal().Err(err).Msg("Failed to create backup file")
+			}
+			defer f.Close()
+			w = f
+		}
+		if err := backup.Write(w, b, strings.HasSuffix(out, ".gz")); err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to write backup")
+		}
+		app.App.Logger.Info().Int("sheets", len(b.Sheets)).Int("roasters", len(b.Roasters)).Int("beans", len(b.Beans)).Int("shots", len(b.Shots)).Msg("Successfully backed up!")
+	},
+}
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore a backup of the sheets, roasters, beans and shots",
+	Long: `Restore a backup written by the backup command, gzipped or not, keeping
+the ids and timestamps of its records, and move the id sequences past them.
+The restore runs in a single transaction: nothing is restored when any record
+fails.
+
+By default the database must hold no sheets, roasters, beans or shots. With
+--merge, the records of the backup are added to the existing ones: those
+whose id is held by the same record already are kept as they are and
+reported as skipped, and the restore fails when the id is held by another
+record.
+
+The records keep their owner, matched to the users of the database by name
+and created without credentials when missing. With --user, the restored
+records are owned by that user instead.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		userName, _ := cmd.Flags().GetString("user")
+		in, _ := cmd.Flags().GetString("in")
+		merge, _ := cmd.Flags().GetBool("merge")
+
+		r := cmd.InOrStdin()
+		if in != "" {
+			f, err := os.Open(in)
+			if err != nil {
+				app.App.Logger.Fatal().Err(err).Msg("Failed to open backup file")
+			}
+			defer f.Close()
+			r = f
+		}
+		b, err := backup.Read(r)
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to read backup")
+		}
+
+		repositories, ctx := newUserRepositorySet(userName)
+		report, err := newBackupService(repositories).Restore(ctx, b, merge)
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to restore backup")
+		}
+		if err := printRestoreReport(cmd.OutOrStdout(), report); err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to print restore report")
+		}
+		app.App.Logger.Info().Bool("merge", merge).Msg("Successfully restored backup!")
+	},
+}
+
+func init() {
+	backupCmd.Flags().StringP("out", "o", "", "File to write the backup to, gzipped when ending with .gz, instead of the standard output")
+	backupCmd.Flags().String("user", "", "Name of the user whose records are backed up")
+
+	restoreCmd.Flags().StringP("in", "i", "", "File to read the backup from, instead of the standard input")
+	restoreCmd.Flags().Bool("merge", false, "Add the records to the existing ones, skipping those which exist already")
+	restoreCmd.Flags().String("user", "", "Name of the user owning the restored records")
+}
+
+func newBackupService(repositories repositorySet) *backup.BackupService {
+	return backup.New(repositories.sheet, repositories.roaster, repositories.beans, repositories.shot, repositories.backup)
+}
+
+// printRestoreReport writes the number of records restored and skipped as a
+// table, one kind of record per line.
+func printRestoreReport(w io.Writer, report *backup.Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tRESTORED\tSKIPPED")
+	for _, row := range []struct {
+		kind  string
+		count backup.Count
+	}{
+		{"users", report.Users},
+		{"sheets", report.Sheets},
+		{"roasters", report.Roasters},
+		{"beans", report.Beans},
+		{"shots", report.Shots},
+	} {
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", row.kind, row.count.Restored, row.count.Skipped)
+	}
+	return tw.Flush()
+}
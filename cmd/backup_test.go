@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/lescactus/espressoapi-go/internal/services/backup"
+)
+
+func TestPrintRestoreReport(t *testing.T) {
+	report := &backup.Report{
+		Users:    backup.Count{Restored: 1, Skipped: 1},
+		Sheets:   backup.Count{Restored: 2},
+		Roasters: backup.Count{Restored: 1, Skipped: 1},
+		Beans:    backup.Count{Restored: 3},
+		Shots:    backup.Count{Restored: 120, Skipped: 14},
+	}
+
+	var buf bytes.Buffer
+	if err := printRestoreReport(&buf, report); err != nil {
+		t.Fatalf("printRestoreReport() error = %v", err)
+	}
+
+	want := `KIND      RESTORED  SKIPPED
+users     1         1
+sheets    2         0
+roasters  1         1
+beans     3         0
+shots     120       14
+`
+	if got := buf.String(); got != want {
+		t.Errorf("printRestoreReport() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/blobstore"
+	"github.com/lescactus/espressoapi-go/internal/blobstore/local"
+	"github.com/lescactus/espressoapi-go/internal/blobstore/s3"
+	"github.com/lescactus/espressoapi-go/internal/config"
+)
+
+// newBlobStore returns the store keeping the files of the attachments, as
+// configured in cfg.
+func newBlobStore(cfg *config.App) (blobstore.Store, error) {
+	switch cfg.BlobStoreType {
+	case config.BlobStoreTypeLocal, "":
+		return local.New(cfg.BlobStoreDir)
+	case config.BlobStoreTypeS3:
+		return s3.New(s3.Config{
+			Endpoint:        cfg.BlobStoreS3Endpoint,
+			Region:          cfg.BlobStoreS3Region,
+			Bucket:          cfg.BlobStoreS3Bucket,
+			AccessKeyID:     cfg.BlobStoreS3AccessKeyID,
+			SecretAccessKey: cfg.BlobStoreS3SecretAccessKey,
+			PathStyle:       cfg.BlobStoreS3PathStyle,
+		}, &http.Client{Timeout: 30 * time.Second})
+	default:
+		return nil, fmt.Errorf("unsupported blob store type %q", cfg.BlobStoreType)
+	}
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/lescactus/espressoapi-go/internal/blobstore"
+	"github.com/lescactus/espressoapi-go/internal/blobstore/local"
+	"github.com/lescactus/espressoapi-go/internal/blobstore/s3"
+	"github.com/lescactus/espressoapi-go/internal/config"
+)
+
+func TestNewBlobStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.App
+		assert  func(t *testing.T, store blobstore.Store)
+		wantErr bool
+	}{
+		{
+			name: "local",
+			cfg:  config.App{BlobStoreType: config.BlobStoreTypeLocal, BlobStoreDir: filepath.Join(t.TempDir(), "blobs")},
+			assert: func(t *testing.T, store blobstore.Store) {
+				if _, ok := store.(*local.Store); !ok {
+					t.Errorf("blob store = %T, want *local.Store", store)
+				}
+			},
+		},
+		{
+			name: "s3",
+			cfg: config.App{
+				BlobStoreType:       config.BlobStoreTypeS3,
+				BlobStoreS3Endpoint: "http://127.0.0.1:9000",
+				BlobStoreS3Region:   "us-east-1",
+				BlobStoreS3Bucket:   "espresso",
+			},
+			assert: func(t *testing.T, store blobstore.Store) {
+				if _, ok := store.(*s3.Store); !ok {
+					t.Errorf("blob store = %T, want *s3.Store", store)
+				}
+			},
+		},
+		{
+			name:    "unsupported",
+			cfg:     config.App{BlobStoreType: "gcs"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := newBlobStore(&tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newBlobStore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assert != nil {
+				tt.assert(t, store)
+			}
+		})
+	}
+}
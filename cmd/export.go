@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/lescactus/espressoapi-go/cmd/app"
+	"github.com/lescactus/espressoapi-go/internal/services/beanconqueror"
+	"github.com/spf13/cobra"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export data for other apps",
+}
+
+var exportBeanconquerorCmd = &cobra.Command{
+	Use:   "beanconqueror",
+	Short: "Export a Beanconqueror backup",
+	Long: `Export the sheets, beans and shots as a Beanconqueror.json backup, to be
+restored in the Beanconqueror app: sheets as portafilter preparations and
+shots as brews. The brews imported from Beanconqueror keep their uuid.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		userName, _ := cmd.Flags().GetString("user")
+		output, _ := cmd.Flags().GetString("output")
+
+		repositories, ctx := newUserRepositorySet(userName)
+		backup, err := newBeanconquerorService(repositories).Export(ctx)
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to export backup")
+		}
+
+		w := cmd.OutOrStdout()
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				app.App.Logger.Fatal().Err(err).Msg("Failed to create backup file")
+			}
+			defer f.Close()
+			w = f
+		}
+		if err := writeBackup(w, backup); err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to write backup")
+		}
+		app.App.Logger.Info().Int("beans", len(backup.Beans)).Int("brews", len(backup.Brews)).Int("preparations", len(backup.Preparations)).Msg("Successfully exported backup!")
+	},
+}
+
+func init() {
+	exportBeanconquerorCmd.Flags().String("user", "", "Name of the user whose records are exported")
+	exportBeanconquerorCmd.Flags().StringP("output", "o", "", "File to write the backup to, instead of the standard output")
+
+	exportCmd.AddCommand(exportBeanconquerorCmd)
+}
+
+// writeBackup writes backup as indented JSON.
+func writeBackup(w io.Writer, backup *beanconqueror.Backup) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(backup)
+}
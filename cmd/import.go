@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/lescactus/espressoapi-go/cmd/app"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/services/beanconqueror"
+	"github.com/lescactus/espressoapi-go/internal/services/decent"
+	"github.com/lescactus/espressoapi-go/internal/services/user"
+	"github.com/spf13/cobra"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import shots from other apps",
+}
+
+var importDecentCmd = &cobra.Command{
+	Use:   "decent <files...>",
+	Short: "Import shots from Decent Espresso .shot or visualizer.coffee files",
+	Long: `Import the shots of the .shot files written by the DE1 app, or of the JSON
+files downloaded from visualizer.coffee, along with their profile. The sheet,
+roaster and beans of each shot are created when missing.
+
+Shots are recognized by their start time: a shot imported before, from
+either format, is reported as a duplicate and skipped. With --dry-run,
+nothing is imported and the shots that would be are reported as new.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		sheet, _ := cmd.Flags().GetString("sheet")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		userName, _ := cmd.Flags().GetString("user")
+
+		files := make([]decent.File, len(args))
+		for i, path := range args {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				app.App.Logger.Fatal().Err(err).Msg("Failed to read file")
+			}
+			files[i] = decent.File{Name: filepath.Base(path), Data: data}
+		}
+
+		repositories, ctx := newUserRepositorySet(userName)
+		report, err := decent.New(repositories.shotImport).Import(ctx, files, decent.Options{Sheet: sheet, DryRun: dryRun})
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to import shots")
+		}
+		if err := printDecentReport(cmd.OutOrStdout(), report); err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to print import report")
+		}
+		app.App.Logger.Info().Bool("dry_run", report.DryRun).Int("imported", report.Imported).Int("duplicates", report.Duplicates).Int("failed", report.Failed).Msg("Successfully imported shots!")
+	},
+}
+
+var importBeanconquerorCmd = &cobra.Command{
+	Use:   "beanconqueror <backup>",
+	Short: "Import a Beanconqueror backup",
+	Long: `Import the preparations, beans and brews of a Beanconqueror.json backup, or
+of the zip archive holding it, as sheets, roasters, beans and shots. The
+roasters are the ones named by the beans, and the mill of a brew is kept in
+the notes of its shot.
+
+Preparations, roasters and beans matching existing sheets, roasters and beans
+by name are reused, and brews are imported once: importing the same backup
+again only reports existing records. The report maps every record of the
+backup onto the id it was imported as.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		userName, _ := cmd.Flags().GetString("user")
+
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to read backup")
+		}
+
+		repositories, ctx := newUserRepositorySet(userName)
+		report, err := newBeanconquerorService(repositories).Import(ctx, data)
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to import backup")
+		}
+		if err := printBeanconquerorReport(cmd.OutOrStdout(), report); err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to print import report")
+		}
+		app.App.Logger.Info().Int("created", report.Created).Int("existing", report.Existing).Int("failed", report.Failed).Msg("Successfully imported backup!")
+	},
+}
+
+func init() {
+	importDecentCmd.Flags().String("sheet", decent.DefaultSheetName, "Name of the sheet to import the shots in, created if missing")
+	importDecentCmd.Flags().Bool("dry-run", false, "Only report what would be imported")
+	importDecentCmd.Flags().String("user", "", "Name of the user owning the imported shots")
+
+	importBeanconquerorCmd.Flags().String("user", "", "Name of the user owning the imported records")
+
+	importCmd.AddCommand(importDecentCmd)
+	importCmd.AddCommand(importBeanconquerorCmd)
+}
+
+// newUserRepositorySet returns the repositories and the context to run a
+// command with on behalf of the user named userName, or of no user when
+// empty.
+func newUserRepositorySet(userName string) (repositorySet, context.Context) {
+	repositories, err := newRepositorySet(app.App.Cfg.DatabaseType, app.App.Db)
+	if err != nil {
+		app.App.Logger.Fatal().Err(err).Msg("Failed to create repositories")
+	}
+
+	ctx := context.Background()
+	if userName != "" {
+		u, err := user.New(repositories.user).GetUserByName(ctx, userName)
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msgf("Failed to get user %q", userName)
+		}
+		ctx = auth.NewContext(ctx, &auth.User{Id: u.Id, Name: u.Name, Role: u.Role})
+	}
+	return repositories, ctx
+}
+
+func newBeanconquerorService(repositories repositorySet) *beanconqueror.BeanconquerorService {
+	return beanconqueror.New(repositories.shotImport, repositories.sheet, repositories.beans, repositories.shot)
+}
+
+// printDecentReport writes the outcome of each imported file as a table, one
+// per line.
+func printDecentReport(w io.Writer, report *decent.Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FILE\tSTATUS\tSHOT\tERROR")
+	for _, f := range report.Files {
+		shotId := ""
+		if f.ShotId != nil {
+			shotId = fmt.Sprint(*f.ShotId)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", f.Name, f.Status, shotId, f.Error)
+	}
+	return tw.Flush()
+}
+
+// printBeanconquerorReport writes the mapping of each record of a backup as a
+// table, one per line.
+func printBeanconquerorReport(w io.Writer, report *beanconqueror.Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tUUID\tNAME\tSTATUS\tID\tERROR")
+	for _, m := range report.Mappings {
+		id := ""
+		if m.Id != nil {
+			id = fmt.Sprint(*m.Id)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", m.Kind, m.ExternalId, m.Name, m.Status, id, m.Error)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/lescactus/espressoapi-go/internal/services/beanconqueror"
+	"github.com/lescactus/espressoapi-go/internal/services/decent"
+)
+
+func TestPrintDecentReport(t *testing.T) {
+	imported, duplicate := 41, 7
+	report := &decent.Report{
+		Imported: 1, Duplicates: 1, Failed: 1,
+		Files: []decent.FileReport{
+			{Name: "0001.shot", Status: decent.StatusImported, ShotId: &imported},
+			{Name: "visualizer.json", Status: decent.StatusDuplicate, ShotId: &duplicate},
+			{Name: "notes.txt", Status: decent.StatusFailed, Error: "import file is invalid"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := printDecentReport(&buf, report); err != nil {
+		t.Fatalf("printDecentReport() error = %v", err)
+	}
+
+	want := `FILE             STATUS     SHOT  ERROR
+0001.shot        imported   41    
+visualizer.json  duplicate  7     
+notes.txt        failed           import file is invalid
+`
+	if got := buf.String(); got != want {
+		t.Errorf("printDecentReport() = %q, want %q", got, want)
+	}
+}
+
+func TestPrintBeanconquerorReport(t *testing.T) {
+	sheetId, roasterId := 3, 4
+	report := &beanconqueror.Report{
+		Created: 1, Existing: 1, Failed: 1,
+		Mappings: []beanconqueror.Mapping{
+			{Kind: beanconqueror.KindPreparation, ExternalId: "p-1", Name: "Linea Mini", Id: &sheetId, Status: beanconqueror.StatusExisting},
+			{Kind: beanconqueror.KindRoaster, Name: "Square Mile", Id: &roasterId, Status: beanconqueror.StatusCreated},
+			{Kind: beanconqueror.KindBrew, ExternalId: "w-3", Name: "Unknown beans", Status: beanconqueror.StatusFailed, Error: "brew is invalid"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := printBeanconquerorReport(&buf, report); err != nil {
+		t.Fatalf("printBeanconquerorReport() error = %v", err)
+	}
+
+	want := `KIND         UUID  NAME           STATUS    ID  ERROR
+preparation  p-1   Linea Mini     existing  3   
+roaster            Square Mile    created   4   
+brew         w-3   Unknown beans  failed        brew is invalid
+`
+	if got := buf.String(); got != want {
+		t.Errorf("printBeanconquerorReport() = %q, want %q", got, want)
+	}
+}
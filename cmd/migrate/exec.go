@@ -1,7 +1,10 @@
 package migrate
 
 import (
+	"database/sql"
+
 	"github.com/lescactus/espressoapi-go/cmd/app"
+	"github.com/lescactus/espressoapi-go/internal/config"
 	sqlmigrate "github.com/rubenv/sql-migrate"
 )
 
@@ -23,3 +26,13 @@ func execMigrations(direction sqlmigrate.MigrationDirection) (int, error) {
 
 	return n, nil
 }
+
+// ExecUp applies every pending migration to db, of type databaseType, which
+// need not be the database configured.
+func ExecUp(db *sql.DB, databaseType config.DatabaseType) (int, error) {
+	migrations, err := migrationSource(app.App.MigrationsFS, databaseType)
+	if err != nil {
+		return 0, err
+	}
+	return sqlmigrate.ExecMax(db, string(databaseType), migrations, sqlmigrate.Up, 0)
+}
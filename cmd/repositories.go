@@ -6,38 +6,108 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/lescactus/espressoapi-go/internal/config"
 	"github.com/lescactus/espressoapi-go/internal/repository"
+	mysqlapikey "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/apikey"
+	mysqlattachment "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/attachment"
+	mysqlbackup "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/backup"
 	mysqlbean "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/bean"
+	mysqlcupping "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/cupping"
+	mysqlgreencoffee "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/greencoffee"
+	mysqlmaintenance "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/maintenance"
+	mysqlreport "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/report"
+	mysqlroastbatch "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/roastbatch"
 	mysqlroaster "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/roaster"
+	mysqlseed "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/seed"
+	mysqlsession "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/session"
+	mysqlsharelink "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/sharelink"
 	mysqlsheet "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/sheet"
 	mysqlshot "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/shot"
+	mysqlshotimport "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/shotimport"
+	mysqlstats "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/stats"
+	mysqluser "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/user"
+	postgresapikey "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/apikey"
+	postgresattachment "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/attachment"
+	postgresbackup "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/backup"
 	postgresbean "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/bean"
+	postgrescupping "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/cupping"
+	postgresgreencoffee "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/greencoffee"
+	postgresmaintenance "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/maintenance"
+	postgresreport "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/report"
+	postgresroastbatch "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/roastbatch"
 	postgresroaster "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/roaster"
+	postgresseed "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/seed"
+	postgressession "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/session"
+	postgressharelink "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/sharelink"
 	postgressheet "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/sheet"
 	postgresshot "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/shot"
+	postgresshotimport "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/shotimport"
+	postgresstats "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/stats"
+	postgresuser "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/user"
 )
 
 type repositorySet struct {
-	sheet   repository.SheetRepository
-	roaster repository.RoasterRepository
-	beans   repository.BeansRepository
-	shot    repository.ShotRepository
+	sheet       repository.SheetRepository
+	roaster     repository.RoasterRepository
+	beans       repository.BeansRepository
+	shot        repository.ShotRepository
+	cupping     repository.CuppingRepository
+	roastBatch  repository.RoastBatchRepository
+	greenCoffee repository.GreenCoffeeRepository
+	report      repository.ReportRepository
+	stats       repository.StatsRepository
+	maintenance repository.MaintenanceTaskRepository
+	user        repository.UserRepository
+	apiKey      repository.APIKeyRepository
+	session     repository.SessionRepository
+	shareLink   repository.ShareLinkRepository
+	attachment  repository.AttachmentRepository
+	shotImport  repository.ShotImportRepository
+	backup      repository.BackupRepository
+	seed        repository.SeedRepository
 }
 
 func newRepositorySet(databaseType config.DatabaseType, db *sqlx.DB) (repositorySet, error) {
 	switch databaseType {
 	case config.DatabaseTypeMySQL:
 		return repositorySet{
-			sheet:   mysqlsheet.New(db),
-			roaster: mysqlroaster.New(db),
-			beans:   mysqlbean.New(db),
-			shot:    mysqlshot.New(db),
+			sheet:       mysqlsheet.New(db),
+			roaster:     mysqlroaster.New(db),
+			beans:       mysqlbean.New(db),
+			shot:        mysqlshot.New(db),
+			cupping:     mysqlcupping.New(db),
+			roastBatch:  mysqlroastbatch.New(db),
+			greenCoffee: mysqlgreencoffee.New(db),
+			report:      mysqlreport.New(db),
+			stats:       mysqlstats.New(db),
+			maintenance: mysqlmaintenance.New(db),
+			user:        mysqluser.New(db),
+			apiKey:      mysqlapikey.New(db),
+			session:     mysqlsession.New(db),
+			shareLink:   mysqlsharelink.New(db),
+			attachment:  mysqlattachment.New(db),
+			shotImport:  mysqlshotimport.New(db),
+			backup:      mysqlbackup.New(db),
+			seed:        mysqlseed.New(db),
 		}, nil
 	case config.DatabaseTypePostgres:
 		return repositorySet{
-			sheet:   postgressheet.New(db),
-			roaster: postgresroaster.New(db),
-			beans:   postgresbean.New(db),
-			shot:    postgresshot.New(db),
+			sheet:       postgressheet.New(db),
+			roaster:     postgresroaster.New(db),
+			beans:       postgresbean.New(db),
+			shot:        postgresshot.New(db),
+			cupping:     postgrescupping.New(db),
+			roastBatch:  postgresroastbatch.New(db),
+			greenCoffee: postgresgreencoffee.New(db),
+			report:      postgresreport.New(db),
+			stats:       postgresstats.New(db),
+			maintenance: postgresmaintenance.New(db),
+			user:        postgresuser.New(db),
+			apiKey:      postgresapikey.New(db),
+			session:     postgressession.New(db),
+			shareLink:   postgressharelink.New(db),
+			attachment:  postgresattachment.New(db),
+			shotImport:  postgresshotimport.New(db),
+			backup:      postgresbackup.New(db),
+			seed:        postgresseed.New(db),
 		}, nil
 	default:
 		return repositorySet{}, fmt.Errorf("unsupported database type %q", databaseType)
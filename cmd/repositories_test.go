@@ -5,14 +5,32 @@ import (
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lescactus/espressoapi-go/internal/config"
+	mysqlapikey "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/apikey"
+	mysqlattachment "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/attachment"
+	mysqlbackup "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/backup"
 	mysqlbean "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/bean"
+	mysqlcupping "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/cupping"
+	mysqlgreencoffee "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/greencoffee"
+	mysqlroastbatch "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/roastbatch"
 	mysqlroaster "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/roaster"
+	mysqlsession "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/session"
 	mysqlsheet "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/sheet"
 	mysqlshot "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/shot"
+	mysqlshotimport "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/shotimport"
+	mysqluser "github.com/lescactus/espressoapi-go/internal/repository/sql/mysql/user"
+	postgresapikey "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/apikey"
+	postgresattachment "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/attachment"
+	postgresbackup "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/backup"
 	postgresbean "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/bean"
+	postgrescupping "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/cupping"
+	postgresgreencoffee "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/greencoffee"
+	postgresroastbatch "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/roastbatch"
 	postgresroaster "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/roaster"
+	postgressession "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/session"
 	postgressheet "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/sheet"
 	postgresshot "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/shot"
+	postgresshotimport "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/shotimport"
+	postgresuser "github.com/lescactus/espressoapi-go/internal/repository/sql/postgresql/user"
 )
 
 func TestNewRepositorySet(t *testing.T) {
@@ -38,6 +56,33 @@ func TestNewRepositorySet(t *testing.T) {
 				if _, ok := repositories.shot.(*mysqlshot.Shot); !ok {
 					t.Errorf("shot repository = %T, want *mysqlshot.Shot", repositories.shot)
 				}
+				if _, ok := repositories.cupping.(*mysqlcupping.Cupping); !ok {
+					t.Errorf("cupping repository = %T, want *mysqlcupping.Cupping", repositories.cupping)
+				}
+				if _, ok := repositories.roastBatch.(*mysqlroastbatch.RoastBatch); !ok {
+					t.Errorf("roast batch repository = %T, want *mysqlroastbatch.RoastBatch", repositories.roastBatch)
+				}
+				if _, ok := repositories.greenCoffee.(*mysqlgreencoffee.GreenCoffee); !ok {
+					t.Errorf("green coffee repository = %T, want *mysqlgreencoffee.GreenCoffee", repositories.greenCoffee)
+				}
+				if _, ok := repositories.user.(*mysqluser.User); !ok {
+					t.Errorf("user repository = %T, want *mysqluser.User", repositories.user)
+				}
+				if _, ok := repositories.apiKey.(*mysqlapikey.APIKey); !ok {
+					t.Errorf("api key repository = %T, want *mysqlapikey.APIKey", repositories.apiKey)
+				}
+				if _, ok := repositories.session.(*mysqlsession.Session); !ok {
+					t.Errorf("session repository = %T, want *mysqlsession.Session", repositories.session)
+				}
+				if _, ok := repositories.attachment.(*mysqlattachment.Attachment); !ok {
+					t.Errorf("attachment repository = %T, want *mysqlattachment.Attachment", repositories.attachment)
+				}
+				if _, ok := repositories.shotImport.(*mysqlshotimport.ShotImport); !ok {
+					t.Errorf("shot import repository = %T, want *mysqlshotimport.ShotImport", repositories.shotImport)
+				}
+				if _, ok := repositories.backup.(*mysqlbackup.Backup); !ok {
+					t.Errorf("backup repository = %T, want *mysqlbackup.Backup", repositories.backup)
+				}
 			},
 		},
 		{
@@ -56,6 +101,33 @@ func TestNewRepositorySet(t *testing.T) {
 				if _, ok := repositories.shot.(*postgresshot.Shot); !ok {
 					t.Errorf("shot repository = %T, want *postgresshot.Shot", repositories.shot)
 				}
+				if _, ok := repositories.cupping.(*postgrescupping.Cupping); !ok {
+					t.Errorf("cupping repository = %T, want *postgrescupping.Cupping", repositories.cupping)
+				}
+				if _, ok := repositories.roastBatch.(*postgresroastbatch.RoastBatch); !ok {
+					t.Errorf("roast batch repository = %T, want *postgresroastbatch.RoastBatch", repositories.roastBatch)
+				}
+				if _, ok := repositories.greenCoffee.(*postgresgreencoffee.GreenCoffee); !ok {
+					t.Errorf("green coffee repository = %T, want *postgresgreencoffee.GreenCoffee", repositories.greenCoffee)
+				}
+				if _, ok := repositories.user.(*postgresuser.User); !ok {
+					t.Errorf("user repository = %T, want *postgresuser.User", repositories.user)
+				}
+				if _, ok := repositories.apiKey.(*postgresapikey.APIKey); !ok {
+					t.Errorf("api key repository = %T, want *postgresapikey.APIKey", repositories.apiKey)
+				}
+				if _, ok := repositories.session.(*postgressession.Session); !ok {
+					t.Errorf("session repository = %T, want *postgressession.Session", repositories.session)
+				}
+				if _, ok := repositories.attachment.(*postgresattachment.Attachment); !ok {
+					t.Errorf("attachment repository = %T, want *postgresattachment.Attachment", repositories.attachment)
+				}
+				if _, ok := repositories.shotImport.(*postgresshotimport.ShotImport); !ok {
+					t.Errorf("shot import repository = %T, want *postgresshotimport.ShotImport", repositories.shotImport)
+				}
+				if _, ok := repositories.backup.(*postgresbackup.Backup); !ok {
+					t.Errorf("backup repository = %T, want *postgresbackup.Backup", repositories.backup)
+				}
 			},
 		},
 		{
@@ -40,6 +40,15 @@ func init() {
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(migrateCmd)
 	rootCmd.AddCommand(healthCmd)
+	rootCmd.AddCommand(usersCmd)
+	rootCmd.AddCommand(apikeysCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+	rootCmd.AddCommand(transferCmd)
+	rootCmd.AddCommand(seedCmd)
+	rootCmd.AddCommand(attachmentsCmd)
 
 	cobra.OnInitialize(initConfig)
 }
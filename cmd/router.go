@@ -6,42 +6,122 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/julienschmidt/httprouter"
 	"github.com/justinas/alice"
+	"github.com/lescactus/espressoapi-go/internal/auth"
 	"github.com/lescactus/espressoapi-go/internal/controllers/rest"
 	"github.com/lescactus/espressoapi-go/internal/controllers/web"
 )
 
 // newRouter builds the complete HTTP route table for the REST API, the web
-// UI, and documentation endpoints. chain is applied to every route
-// registered here.
-func newRouter(restHandler *rest.Handler, webHandler *web.Handler, chain alice.Chain) http.Handler {
+// UI, and documentation endpoints. chain is applied to the REST API and
+// documentation routes, webChain to the web UI routes. Each REST API and web
+// UI route requires the permission of its resource and action, as granted to
+// the role of the user by auth.Role.Can.
+func newRouter(restHandler *rest.Handler, webHandler *web.Handler, chain, webChain alice.Chain) http.Handler {
 	r := httprouter.New()
 
+	api := func(resource auth.Resource, action auth.Action, handler http.HandlerFunc) http.Handler {
+		return chain.Append(restHandler.Authorize(resource, action)).ThenFunc(handler)
+	}
+	page := func(resource auth.Resource, action auth.Action, handler http.HandlerFunc) http.Handler {
+		return webChain.Append(webHandler.Authorize(resource, action)).ThenFunc(handler)
+	}
+
 	r.Handler(http.MethodGet, "/ping", chain.ThenFunc(restHandler.Ping))
 
-	r.Handler(http.MethodPost, "/rest/v1/sheets", chain.ThenFunc(restHandler.CreateSheet))
-	r.Handler(http.MethodGet, "/rest/v1/sheets/:id", chain.ThenFunc(restHandler.GetSheetById))
-	r.Handler(http.MethodGet, "/rest/v1/sheets", chain.ThenFunc(restHandler.GetAllSheets))
-	r.Handler(http.MethodPut, "/rest/v1/sheets/:id", chain.ThenFunc(restHandler.UpdateSheetById))
-	r.Handler(http.MethodDelete, "/rest/v1/sheets/:id", chain.ThenFunc(restHandler.DeleteSheetById))
-
-	r.Handler(http.MethodPost, "/rest/v1/roasters", chain.ThenFunc(restHandler.CreateRoaster))
-	r.Handler(http.MethodGet, "/rest/v1/roasters/:id", chain.ThenFunc(restHandler.GetRoasterById))
-	r.Handler(http.MethodGet, "/rest/v1/roasters", chain.ThenFunc(restHandler.GetAllRoasters))
-	r.Handler(http.MethodPut, "/rest/v1/roasters/:id", chain.ThenFunc(restHandler.UpdateRoasterById))
-	r.Handler(http.MethodDelete, "/rest/v1/roasters/:id", chain.ThenFunc(restHandler.DeleteRoasterById))
-
-	r.Handler(http.MethodPost, "/rest/v1/beans", chain.ThenFunc(restHandler.CreateBeans))
-	r.Handler(http.MethodGet, "/rest/v1/beans/:id", chain.ThenFunc(restHandler.GetBeansById))
-	r.Handler(http.MethodGet, "/rest/v1/beans", chain.ThenFunc(restHandler.GetAllBeans))
-	r.Handler(http.MethodPut, "/rest/v1/beans/:id", chain.ThenFunc(restHandler.UpdateBeanById))
-	r.Handler(http.MethodDelete, "/rest/v1/beans/:id", chain.ThenFunc(restHandler.DeleteBeansById))
-
-	r.Handler(http.MethodPost, "/rest/v1/shots", chain.ThenFunc(restHandler.CreateShot))
-	r.Handler(http.MethodGet, "/rest/v1/shots/:id", chain.ThenFunc(restHandler.GetShotById))
-	r.Handler(http.MethodGet, "/rest/v1/shots", chain.ThenFunc(restHandler.GetAllShots))
-	r.Handler(http.MethodPut, "/rest/v1/shots/:id", chain.ThenFunc(restHandler.UpdateShotById))
-	r.Handler(http.MethodDelete, "/rest/v1/shots/:id", chain.ThenFunc(restHandler.DeleteShotById))
-	r.Handler(http.MethodGet, "/rest/v1/sheets/:id/shots", chain.ThenFunc(restHandler.GetShotsBySheetId))
+	r.Handler(http.MethodPost, "/rest/v1/sheets", api(auth.ResourceSheets, auth.ActionCreate, restHandler.CreateSheet))
+	r.Handler(http.MethodGet, "/rest/v1/sheets/:id", api(auth.ResourceSheets, auth.ActionRead, restHandler.GetSheetById))
+	r.Handler(http.MethodGet, "/rest/v1/sheets", api(auth.ResourceSheets, auth.ActionRead, restHandler.GetAllSheets))
+	r.Handler(http.MethodGet, "/rest/v1/sheets.csv", api(auth.ResourceSheets, auth.ActionRead, restHandler.ExportSheetsCSV))
+	r.Handler(http.MethodPut, "/rest/v1/sheets/:id", api(auth.ResourceSheets, auth.ActionUpdate, restHandler.UpdateSheetById))
+	r.Handler(http.MethodDelete, "/rest/v1/sheets/:id", api(auth.ResourceSheets, auth.ActionDelete, restHandler.DeleteSheetById))
+	r.Handler(http.MethodPost, "/rest/v1/sheets/:id/clone", api(auth.ResourceSheets, auth.ActionCreate, restHandler.CloneSheet))
+
+	r.Handler(http.MethodPost, "/rest/v1/roasters", api(auth.ResourceRoasters, auth.ActionCreate, restHandler.CreateRoaster))
+	r.Handler(http.MethodGet, "/rest/v1/roasters/:id", api(auth.ResourceRoasters, auth.ActionRead, restHandler.GetRoasterById))
+	r.Handler(http.MethodGet, "/rest/v1/roasters", api(auth.ResourceRoasters, auth.ActionRead, restHandler.GetAllRoasters))
+	r.Handler(http.MethodGet, "/rest/v1/roasters.csv", api(auth.ResourceRoasters, auth.ActionRead, restHandler.ExportRoastersCSV))
+	r.Handler(http.MethodPut, "/rest/v1/roasters/:id", api(auth.ResourceRoasters, auth.ActionUpdate, restHandler.UpdateRoasterById))
+	r.Handler(http.MethodDelete, "/rest/v1/roasters/:id", api(auth.ResourceRoasters, auth.ActionDelete, restHandler.DeleteRoasterById))
+
+	r.Handler(http.MethodPost, "/rest/v1/beans", api(auth.ResourceBeans, auth.ActionCreate, restHandler.CreateBeans))
+	r.Handler(http.MethodGet, "/rest/v1/beans/:id", api(auth.ResourceBeans, auth.ActionRead, restHandler.GetBeansById))
+	r.Handler(http.MethodGet, "/rest/v1/beans", api(auth.ResourceBeans, auth.ActionRead, restHandler.GetAllBeans))
+	r.Handler(http.MethodGet, "/rest/v1/beans.csv", api(auth.ResourceBeans, auth.ActionRead, restHandler.ExportBeansCSV))
+	r.Handler(http.MethodPut, "/rest/v1/beans/:id", api(auth.ResourceBeans, auth.ActionUpdate, restHandler.UpdateBeanById))
+	r.Handler(http.MethodDelete, "/rest/v1/beans/:id", api(auth.ResourceBeans, auth.ActionDelete, restHandler.DeleteBeansById))
+
+	r.Handler(http.MethodPost, "/rest/v1/shots", api(auth.ResourceShots, auth.ActionCreate, restHandler.CreateShot))
+	// httprouter does not route /rest/v1/shots/compare next to
+	// /rest/v1/shots/:id, so the comparison is told apart by the id.
+	r.Handler(http.MethodGet, "/rest/v1/shots/:id", idOr("compare",
+		api(auth.ResourceShots, auth.ActionRead, restHandler.CompareShots),
+		api(auth.ResourceShots, auth.ActionRead, restHandler.GetShotById)))
+	r.Handler(http.MethodGet, "/rest/v1/shots", api(auth.ResourceShots, auth.ActionRead, restHandler.GetAllShots))
+	r.Handler(http.MethodGet, "/rest/v1/shots.csv", api(auth.ResourceShots, auth.ActionRead, restHandler.ExportShotsCSV))
+	r.Handler(http.MethodPut, "/rest/v1/shots/:id", api(auth.ResourceShots, auth.ActionUpdate, restHandler.UpdateShotById))
+	r.Handler(http.MethodDelete, "/rest/v1/shots/:id", api(auth.ResourceShots, auth.ActionDelete, restHandler.DeleteShotById))
+	r.Handler(http.MethodGet, "/rest/v1/shots/:id/profile", api(auth.ResourceShots, auth.ActionRead, restHandler.GetShotProfileById))
+	r.Handler(http.MethodPut, "/rest/v1/shots/:id/profile", api(auth.ResourceShots, auth.ActionUpdate, restHandler.UpdateShotProfileById))
+	r.Handler(http.MethodPost, "/rest/v1/shots/:id/duplicate", api(auth.ResourceShots, auth.ActionCreate, restHandler.DuplicateShot))
+	r.Handler(http.MethodGet, "/rest/v1/sheets/:id/shots", api(auth.ResourceShots, auth.ActionRead, restHandler.GetShotsBySheetId))
+	r.Handler(http.MethodPost, "/rest/v1/import/decent", api(auth.ResourceShots, auth.ActionCreate, restHandler.ImportDecentShots))
+	r.Handler(http.MethodPost, "/rest/v1/import/beanconqueror", api(auth.ResourceShots, auth.ActionCreate, restHandler.ImportBeanconquerorBackup))
+	r.Handler(http.MethodGet, "/rest/v1/export/beanconqueror", api(auth.ResourceShots, auth.ActionRead, restHandler.ExportBeanconquerorBackup))
+	r.Handler(http.MethodPost, "/rest/v1/import/sheets.csv", api(auth.ResourceSheets, auth.ActionCreate, restHandler.ImportSheetsCSV))
+	r.Handler(http.MethodPost, "/rest/v1/import/roasters.csv", api(auth.ResourceRoasters, auth.ActionCreate, restHandler.ImportRoastersCSV))
+	r.Handler(http.MethodPost, "/rest/v1/import/beans.csv", api(auth.ResourceBeans, auth.ActionCreate, restHandler.ImportBeansCSV))
+	r.Handler(http.MethodPost, "/rest/v1/import/shots.csv", api(auth.ResourceShots, auth.ActionCreate, restHandler.ImportShotsCSV))
+
+	r.Handler(http.MethodPost, "/rest/v1/sheets/:id/share_links", api(auth.ResourceShareLinks, auth.ActionCreate, restHandler.CreateShareLink))
+	r.Handler(http.MethodGet, "/rest/v1/sheets/:id/share_links", api(auth.ResourceShareLinks, auth.ActionRead, restHandler.GetShareLinksBySheetId))
+	r.Handler(http.MethodDelete, "/rest/v1/share_links/:id", api(auth.ResourceShareLinks, auth.ActionDelete, restHandler.RevokeShareLinkById))
+
+	r.Handler(http.MethodPost, "/rest/v1/shots/:id/attachments", api(auth.ResourceAttachments, auth.ActionCreate, restHandler.CreateShotAttachment))
+	r.Handler(http.MethodGet, "/rest/v1/shots/:id/attachments", api(auth.ResourceAttachments, auth.ActionRead, restHandler.GetAttachmentsByShotId))
+	r.Handler(http.MethodPost, "/rest/v1/beans/:id/attachments", api(auth.ResourceAttachments, auth.ActionCreate, restHandler.CreateBeansAttachment))
+	r.Handler(http.MethodGet, "/rest/v1/beans/:id/attachments", api(auth.ResourceAttachments, auth.ActionRead, restHandler.GetAttachmentsByBeansId))
+	r.Handler(http.MethodGet, "/rest/v1/attachments/:id", api(auth.ResourceAttachments, auth.ActionRead, restHandler.GetAttachmentById))
+	r.Handler(http.MethodGet, "/rest/v1/attachments/:id/content", api(auth.ResourceAttachments, auth.ActionRead, restHandler.GetAttachmentContent))
+	r.Handler(http.MethodGet, "/rest/v1/attachments/:id/thumbnail", api(auth.ResourceAttachments, auth.ActionRead, restHandler.GetAttachmentThumbnail))
+	r.Handler(http.MethodDelete, "/rest/v1/attachments/:id", api(auth.ResourceAttachments, auth.ActionDelete, restHandler.DeleteAttachmentById))
+
+	r.Handler(http.MethodPost, "/rest/v1/cupping_sessions", api(auth.ResourceCuppings, auth.ActionCreate, restHandler.CreateCuppingSession))
+	r.Handler(http.MethodGet, "/rest/v1/cupping_sessions/:id", api(auth.ResourceCuppings, auth.ActionRead, restHandler.GetCuppingSessionById))
+	r.Handler(http.MethodGet, "/rest/v1/cupping_sessions", api(auth.ResourceCuppings, auth.ActionRead, restHandler.GetAllCuppingSessions))
+	r.Handler(http.MethodPut, "/rest/v1/cupping_sessions/:id", api(auth.ResourceCuppings, auth.ActionUpdate, restHandler.UpdateCuppingSessionById))
+	r.Handler(http.MethodDelete, "/rest/v1/cupping_sessions/:id", api(auth.ResourceCuppings, auth.ActionDelete, restHandler.DeleteCuppingSessionById))
+	r.Handler(http.MethodPost, "/rest/v1/cupping_sessions/:id/scores", api(auth.ResourceCuppings, auth.ActionCreate, restHandler.CreateCuppingScore))
+	r.Handler(http.MethodGet, "/rest/v1/cupping_scores/:id", api(auth.ResourceCuppings, auth.ActionRead, restHandler.GetCuppingScoreById))
+	r.Handler(http.MethodPut, "/rest/v1/cupping_scores/:id", api(auth.ResourceCuppings, auth.ActionUpdate, restHandler.UpdateCuppingScoreById))
+	r.Handler(http.MethodDelete, "/rest/v1/cupping_scores/:id", api(auth.ResourceCuppings, auth.ActionDelete, restHandler.DeleteCuppingScoreById))
+	r.Handler(http.MethodGet, "/rest/v1/beans/:id/cupping_scores", api(auth.ResourceCuppings, auth.ActionRead, restHandler.GetCuppingScoresByBeansId))
+
+	r.Handler(http.MethodPost, "/rest/v1/roast_batches", api(auth.ResourceRoastBatches, auth.ActionCreate, restHandler.CreateRoastBatch))
+	r.Handler(http.MethodGet, "/rest/v1/roast_batches/:id", api(auth.ResourceRoastBatches, auth.ActionRead, restHandler.GetRoastBatchById))
+	r.Handler(http.MethodGet, "/rest/v1/roast_batches", api(auth.ResourceRoastBatches, auth.ActionRead, restHandler.GetAllRoastBatches))
+	r.Handler(http.MethodPut, "/rest/v1/roast_batches/:id", api(auth.ResourceRoastBatches, auth.ActionUpdate, restHandler.UpdateRoastBatchById))
+	r.Handler(http.MethodDelete, "/rest/v1/roast_batches/:id", api(auth.ResourceRoastBatches, auth.ActionDelete, restHandler.DeleteRoastBatchById))
+	r.Handler(http.MethodPost, "/rest/v1/roast_batches/:id/beans", api(auth.ResourceBeans, auth.ActionCreate, restHandler.CreateBeansFromRoastBatch))
+
+	r.Handler(http.MethodPost, "/rest/v1/green_coffees", api(auth.ResourceGreenCoffees, auth.ActionCreate, restHandler.CreateGreenCoffee))
+	r.Handler(http.MethodGet, "/rest/v1/green_coffees/:id", api(auth.ResourceGreenCoffees, auth.ActionRead, restHandler.GetGreenCoffeeById))
+	r.Handler(http.MethodGet, "/rest/v1/green_coffees", api(auth.ResourceGreenCoffees, auth.ActionRead, restHandler.GetAllGreenCoffees))
+	r.Handler(http.MethodPut, "/rest/v1/green_coffees/:id", api(auth.ResourceGreenCoffees, auth.ActionUpdate, restHandler.UpdateGreenCoffeeById))
+	r.Handler(http.MethodDelete, "/rest/v1/green_coffees/:id", api(auth.ResourceGreenCoffees, auth.ActionDelete, restHandler.DeleteGreenCoffeeById))
+
+	r.Handler(http.MethodGet, "/rest/v1/reports/spend", api(auth.ResourceReports, auth.ActionRead, restHandler.GetSpendReport))
+
+	r.Handler(http.MethodGet, "/rest/v1/stats/consumption", api(auth.ResourceStats, auth.ActionRead, restHandler.GetConsumption))
+	r.Handler(http.MethodGet, "/rest/v1/stats/beans/:id", api(auth.ResourceStats, auth.ActionRead, restHandler.GetBeansStats))
+	r.Handler(http.MethodGet, "/rest/v1/stats/roasters/:id", api(auth.ResourceStats, auth.ActionRead, restHandler.GetRoasterStats))
+	r.Handler(http.MethodGet, "/rest/v1/stats/sheets/:id", api(auth.ResourceStats, auth.ActionRead, restHandler.GetSheetStats))
+
+	r.Handler(http.MethodPost, "/rest/v1/maintenance_tasks", api(auth.ResourceMaintenanceTasks, auth.ActionCreate, restHandler.CreateMaintenanceTask))
+	r.Handler(http.MethodGet, "/rest/v1/maintenance_tasks/:id", api(auth.ResourceMaintenanceTasks, auth.ActionRead, restHandler.GetMaintenanceTaskById))
+	r.Handler(http.MethodGet, "/rest/v1/maintenance_tasks", api(auth.ResourceMaintenanceTasks, auth.ActionRead, restHandler.GetAllMaintenanceTasks))
+	r.Handler(http.MethodPut, "/rest/v1/maintenance_tasks/:id", api(auth.ResourceMaintenanceTasks, auth.ActionUpdate, restHandler.UpdateMaintenanceTaskById))
+	r.Handler(http.MethodDelete, "/rest/v1/maintenance_tasks/:id", api(auth.ResourceMaintenanceTasks, auth.ActionDelete, restHandler.DeleteMaintenanceTaskById))
+	r.Handler(http.MethodPost, "/rest/v1/maintenance_tasks/:id/complete", api(auth.ResourceMaintenanceTasks, auth.ActionUpdate, restHandler.CompleteMaintenanceTaskById))
 
 	redocOpts := middleware.RedocOpts{Path: "redoc", SpecURL: "swagger.json"}
 	swaggerUiOpts := middleware.SwaggerUIOpts{Path: "swagger", SpecURL: "swagger.json"}
@@ -50,39 +130,114 @@ func newRouter(restHandler *rest.Handler, webHandler *web.Handler, chain alice.C
 	r.Handler(http.MethodGet, "/swagger.json", chain.ThenFunc(restHandler.Swagger))
 
 	// Web UI
-	r.Handler(http.MethodGet, "/", chain.ThenFunc(webHandler.Home))
-
-	r.Handler(http.MethodGet, "/sheets", chain.ThenFunc(webHandler.ListSheets))
-	r.Handler(http.MethodGet, "/sheets/add", chain.ThenFunc(webHandler.AddSheetForm))
-	r.Handler(http.MethodPost, "/sheets/add", chain.ThenFunc(webHandler.CreateSheet))
-	r.Handler(http.MethodGet, "/sheets/get/:id", chain.ThenFunc(webHandler.GetSheet))
-	r.Handler(http.MethodGet, "/sheets/update/:id", chain.ThenFunc(webHandler.EditSheetForm))
-	r.Handler(http.MethodPut, "/sheets/update/:id", chain.ThenFunc(webHandler.UpdateSheet))
-	r.Handler(http.MethodDelete, "/sheets/delete/:id", chain.ThenFunc(webHandler.DeleteSheet))
-
-	r.Handler(http.MethodGet, "/roasters", chain.ThenFunc(webHandler.ListRoasters))
-	r.Handler(http.MethodGet, "/roasters/add", chain.ThenFunc(webHandler.AddRoasterForm))
-	r.Handler(http.MethodPost, "/roasters/add", chain.ThenFunc(webHandler.CreateRoaster))
-	r.Handler(http.MethodGet, "/roasters/get/:id", chain.ThenFunc(webHandler.GetRoaster))
-	r.Handler(http.MethodGet, "/roasters/update/:id", chain.ThenFunc(webHandler.EditRoasterForm))
-	r.Handler(http.MethodPut, "/roasters/update/:id", chain.ThenFunc(webHandler.UpdateRoaster))
-	r.Handler(http.MethodDelete, "/roasters/delete/:id", chain.ThenFunc(webHandler.DeleteRoaster))
-
-	r.Handler(http.MethodGet, "/beans", chain.ThenFunc(webHandler.ListBeans))
-	r.Handler(http.MethodGet, "/beans/add", chain.ThenFunc(webHandler.AddBeanForm))
-	r.Handler(http.MethodPost, "/beans/add", chain.ThenFunc(webHandler.CreateBean))
-	r.Handler(http.MethodGet, "/beans/get/:id", chain.ThenFunc(webHandler.GetBean))
-	r.Handler(http.MethodGet, "/beans/update/:id", chain.ThenFunc(webHandler.EditBeanForm))
-	r.Handler(http.MethodPut, "/beans/update/:id", chain.ThenFunc(webHandler.UpdateBean))
-	r.Handler(http.MethodDelete, "/beans/delete/:id", chain.ThenFunc(webHandler.DeleteBean))
-
-	r.Handler(http.MethodGet, "/shots", chain.ThenFunc(webHandler.ListShots))
-	r.Handler(http.MethodGet, "/shots/add", chain.ThenFunc(webHandler.AddShotForm))
-	r.Handler(http.MethodPost, "/shots/add", chain.ThenFunc(webHandler.CreateShot))
-	r.Handler(http.MethodGet, "/shots/get/:id", chain.ThenFunc(webHandler.GetShot))
-	r.Handler(http.MethodGet, "/shots/update/:id", chain.ThenFunc(webHandler.EditShotForm))
-	r.Handler(http.MethodPut, "/shots/update/:id", chain.ThenFunc(webHandler.UpdateShot))
-	r.Handler(http.MethodDelete, "/shots/delete/:id", chain.ThenFunc(webHandler.DeleteShot))
+	r.Handler(http.MethodGet, "/login", webChain.ThenFunc(webHandler.LoginForm))
+	r.Handler(http.MethodPost, "/login", webChain.ThenFunc(webHandler.Login))
+	r.Handler(http.MethodPost, "/logout", webChain.ThenFunc(webHandler.Logout))
+	r.Handler(http.MethodGet, "/login/oidc", webChain.ThenFunc(webHandler.OIDCLogin))
+	r.Handler(http.MethodGet, "/login/oidc/callback", webChain.ThenFunc(webHandler.OIDCCallback))
+
+	r.Handler(http.MethodGet, "/", page(auth.ResourceSheets, auth.ActionRead, webHandler.Home))
+
+	r.Handler(http.MethodGet, "/sheets", page(auth.ResourceSheets, auth.ActionRead, webHandler.ListSheets))
+	r.Handler(http.MethodGet, "/sheets/export", page(auth.ResourceSheets, auth.ActionRead, webHandler.ExportSheetsCSV))
+	r.Handler(http.MethodGet, "/sheets/add", page(auth.ResourceSheets, auth.ActionCreate, webHandler.AddSheetForm))
+	r.Handler(http.MethodPost, "/sheets/add", page(auth.ResourceSheets, auth.ActionCreate, webHandler.CreateSheet))
+	r.Handler(http.MethodGet, "/sheets/get/:id", page(auth.ResourceSheets, auth.ActionRead, webHandler.GetSheet))
+	r.Handler(http.MethodGet, "/sheets/update/:id", page(auth.ResourceSheets, auth.ActionUpdate, webHandler.EditSheetForm))
+	r.Handler(http.MethodPut, "/sheets/update/:id", page(auth.ResourceSheets, auth.ActionUpdate, webHandler.UpdateSheet))
+	r.Handler(http.MethodDelete, "/sheets/delete/:id", page(auth.ResourceSheets, auth.ActionDelete, webHandler.DeleteSheet))
+	r.Handler(http.MethodPost, "/sheets/share/:id", page(auth.ResourceShareLinks, auth.ActionCreate, webHandler.ShareSheet))
+	r.Handler(http.MethodDelete, "/share_links/delete/:id", page(auth.ResourceShareLinks, auth.ActionDelete, webHandler.RevokeShareLink))
+	// Shared sheets are public: the share link token is the credential.
+	r.Handler(http.MethodGet, "/share/:token", webChain.ThenFunc(webHandler.SharedSheet))
+
+	r.Handler(http.MethodPost, "/shots/attachments/:id", page(auth.ResourceAttachments, auth.ActionCreate, webHandler.UploadShotAttachment))
+	r.Handler(http.MethodPost, "/beans/attachments/:id", page(auth.ResourceAttachments, auth.ActionCreate, webHandler.UploadBeansAttachment))
+	r.Handler(http.MethodGet, "/attachments/content/:id", page(auth.ResourceAttachments, auth.ActionRead, webHandler.AttachmentContent))
+	r.Handler(http.MethodGet, "/attachments/thumbnail/:id", page(auth.ResourceAttachments, auth.ActionRead, webHandler.AttachmentThumbnail))
+	r.Handler(http.MethodDelete, "/attachments/delete/:id", page(auth.ResourceAttachments, auth.ActionDelete, webHandler.DeleteAttachment))
+
+	r.Handler(http.MethodGet, "/roasters", page(auth.ResourceRoasters, auth.ActionRead, webHandler.ListRoasters))
+	r.Handler(http.MethodGet, "/roasters/export", page(auth.ResourceRoasters, auth.ActionRead, webHandler.ExportRoastersCSV))
+	r.Handler(http.MethodGet, "/roasters/add", page(auth.ResourceRoasters, auth.ActionCreate, webHandler.AddRoasterForm))
+	r.Handler(http.MethodPost, "/roasters/add", page(auth.ResourceRoasters, auth.ActionCreate, webHandler.CreateRoaster))
+	r.Handler(http.MethodGet, "/roasters/get/:id", page(auth.ResourceRoasters, auth.ActionRead, webHandler.GetRoaster))
+	r.Handler(http.MethodGet, "/roasters/update/:id", page(auth.ResourceRoasters, auth.ActionUpdate, webHandler.EditRoasterForm))
+	r.Handler(http.MethodPut, "/roasters/update/:id", page(auth.ResourceRoasters, auth.ActionUpdate, webHandler.UpdateRoaster))
+	r.Handler(http.MethodDelete, "/roasters/delete/:id", page(auth.ResourceRoasters, auth.ActionDelete, webHandler.DeleteRoaster))
+
+	r.Handler(http.MethodGet, "/beans", page(auth.ResourceBeans, auth.ActionRead, webHandler.ListBeans))
+	r.Handler(http.MethodGet, "/beans/export", page(auth.ResourceBeans, auth.ActionRead, webHandler.ExportBeansCSV))
+	r.Handler(http.MethodGet, "/beans/add", page(auth.ResourceBeans, auth.ActionCreate, webHandler.AddBeanForm))
+	r.Handler(http.MethodPost, "/beans/add", page(auth.ResourceBeans, auth.ActionCreate, webHandler.CreateBean))
+	r.Handler(http.MethodGet, "/beans/get/:id", page(auth.ResourceBeans, auth.ActionRead, webHandler.GetBean))
+	r.Handler(http.MethodGet, "/beans/update/:id", page(auth.ResourceBeans, auth.ActionUpdate, webHandler.EditBeanForm))
+	r.Handler(http.MethodPut, "/beans/update/:id", page(auth.ResourceBeans, auth.ActionUpdate, webHandler.UpdateBean))
+	r.Handler(http.MethodDelete, "/beans/delete/:id", page(auth.ResourceBeans, auth.ActionDelete, webHandler.DeleteBean))
+	r.Handler(http.MethodGet, "/beans/cuppings/:id", page(auth.ResourceCuppings, auth.ActionRead, webHandler.BeanCuppings))
+
+	r.Handler(http.MethodGet, "/shots", page(auth.ResourceShots, auth.ActionRead, webHandler.ListShots))
+	r.Handler(http.MethodGet, "/shots/export", page(auth.ResourceShots, auth.ActionRead, webHandler.ExportShotsCSV))
+	r.Handler(http.MethodGet, "/shots/compare", page(auth.ResourceShots, auth.ActionRead, webHandler.CompareShots))
+	r.Handler(http.MethodGet, "/shots/add", page(auth.ResourceShots, auth.ActionCreate, webHandler.AddShotForm))
+	r.Handler(http.MethodPost, "/shots/add", page(auth.ResourceShots, auth.ActionCreate, webHandler.CreateShot))
+	r.Handler(http.MethodGet, "/shots/get/:id", page(auth.ResourceShots, auth.ActionRead, webHandler.GetShot))
+	r.Handler(http.MethodGet, "/shots/again/:id", page(auth.ResourceShots, auth.ActionCreate, webHandler.AgainShotForm))
+	r.Handler(http.MethodGet, "/shots/update/:id", page(auth.ResourceShots, auth.ActionUpdate, webHandler.EditShotForm))
+	r.Handler(http.MethodPut, "/shots/update/:id", page(auth.ResourceShots, auth.ActionUpdate, webHandler.UpdateShot))
+	r.Handler(http.MethodDelete, "/shots/delete/:id", page(auth.ResourceShots, auth.ActionDelete, webHandler.DeleteShot))
+
+	r.Handler(http.MethodGet, "/cuppings", page(auth.ResourceCuppings, auth.ActionRead, webHandler.ListCuppings))
+	r.Handler(http.MethodGet, "/cuppings/add", page(auth.ResourceCuppings, auth.ActionCreate, webHandler.AddCuppingForm))
+	r.Handler(http.MethodPost, "/cuppings/add", page(auth.ResourceCuppings, auth.ActionCreate, webHandler.CreateCupping))
+	r.Handler(http.MethodGet, "/cuppings/get/:id", page(auth.ResourceCuppings, auth.ActionRead, webHandler.GetCupping))
+	r.Handler(http.MethodGet, "/cuppings/update/:id", page(auth.ResourceCuppings, auth.ActionUpdate, webHandler.EditCuppingForm))
+	r.Handler(http.MethodPut, "/cuppings/update/:id", page(auth.ResourceCuppings, auth.ActionUpdate, webHandler.UpdateCupping))
+	r.Handler(http.MethodDelete, "/cuppings/delete/:id", page(auth.ResourceCuppings, auth.ActionDelete, webHandler.DeleteCupping))
+	r.Handler(http.MethodGet, "/cuppings/scores/add", page(auth.ResourceCuppings, auth.ActionCreate, webHandler.AddCuppingScoreForm))
+	r.Handler(http.MethodPost, "/cuppings/scores/add", page(auth.ResourceCuppings, auth.ActionCreate, webHandler.CreateCuppingScore))
+	r.Handler(http.MethodGet, "/cuppings/scores/update/:id", page(auth.ResourceCuppings, auth.ActionUpdate, webHandler.EditCuppingScoreForm))
+	r.Handler(http.MethodPut, "/cuppings/scores/update/:id", page(auth.ResourceCuppings, auth.ActionUpdate, webHandler.UpdateCuppingScore))
+	r.Handler(http.MethodDelete, "/cuppings/scores/delete/:id", page(auth.ResourceCuppings, auth.ActionDelete, webHandler.DeleteCuppingScore))
+
+	r.Handler(http.MethodGet, "/roasts", page(auth.ResourceRoastBatches, auth.ActionRead, webHandler.ListRoastBatches))
+	r.Handler(http.MethodGet, "/roasts/add", page(auth.ResourceRoastBatches, auth.ActionCreate, webHandler.AddRoastBatchForm))
+	r.Handler(http.MethodPost, "/roasts/add", page(auth.ResourceRoastBatches, auth.ActionCreate, webHandler.CreateRoastBatch))
+	r.Handler(http.MethodGet, "/roasts/get/:id", page(auth.ResourceRoastBatches, auth.ActionRead, webHandler.GetRoastBatch))
+	r.Handler(http.MethodGet, "/roasts/update/:id", page(auth.ResourceRoastBatches, auth.ActionUpdate, webHandler.EditRoastBatchForm))
+	r.Handler(http.MethodPut, "/roasts/update/:id", page(auth.ResourceRoastBatches, auth.ActionUpdate, webHandler.UpdateRoastBatch))
+	r.Handler(http.MethodDelete, "/roasts/delete/:id", page(auth.ResourceRoastBatches, auth.ActionDelete, webHandler.DeleteRoastBatch))
+	r.Handler(http.MethodPost, "/roasts/beans/:id", page(auth.ResourceBeans, auth.ActionCreate, webHandler.CreateBeansFromRoastBatch))
+
+	r.Handler(http.MethodGet, "/green_coffees", page(auth.ResourceGreenCoffees, auth.ActionRead, webHandler.ListGreenCoffees))
+	r.Handler(http.MethodGet, "/green_coffees/add", page(auth.ResourceGreenCoffees, auth.ActionCreate, webHandler.AddGreenCoffeeForm))
+	r.Handler(http.MethodPost, "/green_coffees/add", page(auth.ResourceGreenCoffees, auth.ActionCreate, webHandler.CreateGreenCoffee))
+	r.Handler(http.MethodGet, "/green_coffees/update/:id", page(auth.ResourceGreenCoffees, auth.ActionUpdate, webHandler.EditGreenCoffeeForm))
+	r.Handler(http.MethodPut, "/green_coffees/update/:id", page(auth.ResourceGreenCoffees, auth.ActionUpdate, webHandler.UpdateGreenCoffee))
+	r.Handler(http.MethodDelete, "/green_coffees/delete/:id", page(auth.ResourceGreenCoffees, auth.ActionDelete, webHandler.DeleteGreenCoffee))
+
+	r.Handler(http.MethodGet, "/reports", page(auth.ResourceReports, auth.ActionRead, webHandler.SpendReport))
+	r.Handler(http.MethodGet, "/stats", page(auth.ResourceStats, auth.ActionRead, webHandler.Consumption))
+
+	r.Handler(http.MethodGet, "/maintenance", page(auth.ResourceMaintenanceTasks, auth.ActionRead, webHandler.ListMaintenanceTasks))
+	r.Handler(http.MethodGet, "/maintenance/add", page(auth.ResourceMaintenanceTasks, auth.ActionCreate, webHandler.AddMaintenanceTaskForm))
+	r.Handler(http.MethodPost, "/maintenance/add", page(auth.ResourceMaintenanceTasks, auth.ActionCreate, webHandler.CreateMaintenanceTask))
+	r.Handler(http.MethodGet, "/maintenance/update/:id", page(auth.ResourceMaintenanceTasks, auth.ActionUpdate, webHandler.EditMaintenanceTaskForm))
+	r.Handler(http.MethodPut, "/maintenance/update/:id", page(auth.ResourceMaintenanceTasks, auth.ActionUpdate, webHandler.UpdateMaintenanceTask))
+	r.Handler(http.MethodDelete, "/maintenance/delete/:id", page(auth.ResourceMaintenanceTasks, auth.ActionDelete, webHandler.DeleteMaintenanceTask))
+	r.Handler(http.MethodPost, "/maintenance/done/:id", page(auth.ResourceMaintenanceTasks, auth.ActionUpdate, webHandler.CompleteMaintenanceTask))
 
 	return r
 }
+
+// idOr serves the requests whose :id parameter is name with static, and the
+// others with handler.
+func idOr(name string, static, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if httprouter.ParamsFromContext(r.Context()).ByName("id") == name {
+			static.ServeHTTP(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
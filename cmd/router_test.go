@@ -2,20 +2,37 @@ package cmd
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"testing/fstest"
 	"time"
 
 	"github.com/justinas/alice"
 	"github.com/lescactus/espressoapi-go/cmd/app"
+	"github.com/lescactus/espressoapi-go/internal/auth"
 	"github.com/lescactus/espressoapi-go/internal/controllers/rest"
 	"github.com/lescactus/espressoapi-go/internal/controllers/web"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	modelsql "github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/attachment"
 	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/beanconqueror"
+	"github.com/lescactus/espressoapi-go/internal/services/cupping"
+	"github.com/lescactus/espressoapi-go/internal/services/decent"
+	"github.com/lescactus/espressoapi-go/internal/services/greencoffee"
+	"github.com/lescactus/espressoapi-go/internal/services/maintenance"
+	"github.com/lescactus/espressoapi-go/internal/services/report"
+	"github.com/lescactus/espressoapi-go/internal/services/roastbatch"
 	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/session"
+	"github.com/lescactus/espressoapi-go/internal/services/share"
 	"github.com/lescactus/espressoapi-go/internal/services/sheet"
 	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/lescactus/espressoapi-go/internal/services/spreadsheet"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
 )
 
 // stubNow backs every stubbed CreatedAt/UpdatedAt so handler logging that
@@ -36,6 +53,9 @@ func TestMain(m *testing.M) {
 // stubSheetService is a minimal no-op sheet.Service used to exercise routing only.
 type stubSheetService struct{}
 
+func (stubSheetService) CreateSheet(context.Context, *sheet.Sheet) (*sheet.Sheet, error) {
+	return &sheet.Sheet{Id: 1, Name: "stub", CreatedAt: &stubNow, UpdatedAt: &stubNow}, nil
+}
 func (stubSheetService) CreateSheetByName(context.Context, string) (*sheet.Sheet, error) {
 	return &sheet.Sheet{Id: 1, Name: "stub", CreatedAt: &stubNow, UpdatedAt: &stubNow}, nil
 }
@@ -47,7 +67,10 @@ func (stubSheetService) UpdateSheetById(context.Context, int, *sheet.Sheet) (*sh
 	return &sheet.Sheet{Id: 1, Name: "stub", CreatedAt: &stubNow, UpdatedAt: &stubNow}, nil
 }
 func (stubSheetService) DeleteSheetById(context.Context, int) error { return nil }
-func (stubSheetService) Ping(context.Context) error                 { return nil }
+func (stubSheetService) CloneSheet(context.Context, int, string, bool) (*sheet.Sheet, error) {
+	return &sheet.Sheet{Id: 2, Name: "stub", CreatedAt: &stubNow, UpdatedAt: &stubNow}, nil
+}
+func (stubSheetService) Ping(context.Context) error { return nil }
 
 // stubRoasterService is a minimal no-op roaster.Service used to exercise routing only.
 type stubRoasterService struct{}
@@ -121,12 +144,254 @@ func (stubShotService) UpdateShotById(context.Context, int, *shot.Shot) (*shot.S
 	return stubShot(), nil
 }
 func (stubShotService) DeleteShotById(context.Context, int) error { return nil }
-func (stubShotService) Ping(context.Context) error                { return nil }
+func (stubShotService) GetShotProfileById(_ context.Context, id int) (*shot.Profile, error) {
+	return &shot.Profile{ShotId: id, Samples: []shot.ProfileSample{}}, nil
+}
+func (stubShotService) UpdateShotProfileById(_ context.Context, id int, _ *shot.Profile) (*shot.Profile, error) {
+	return &shot.Profile{ShotId: id, Samples: []shot.ProfileSample{}}, nil
+}
+func (stubShotService) CompareShots(_ context.Context, ids []int) (*shot.Comparison, error) {
+	return shot.Compare([]shot.Shot{*stubShot(), *stubShot()}), nil
+}
+func (stubShotService) DuplicateShot(context.Context, int, shot.Overrides) (*shot.Shot, error) {
+	return stubShot(), nil
+}
+func (stubShotService) Ping(context.Context) error { return nil }
+
+// stubCuppingService is a minimal no-op cupping.Service used to exercise routing only.
+type stubCuppingService struct{}
+
+func stubCuppingSession() *cupping.CuppingSession {
+	return &cupping.CuppingSession{Id: 1, SessionDate: &stubNow, CreatedAt: &stubNow, UpdatedAt: &stubNow}
+}
+
+func stubCuppingScore() *cupping.CuppingScore {
+	return &cupping.CuppingScore{Id: 1, SessionId: 1, SessionDate: &stubNow, Beans: stubBean(), CreatedAt: &stubNow, UpdatedAt: &stubNow}
+}
+
+func (stubCuppingService) CreateCuppingSession(context.Context, *cupping.CuppingSession) (*cupping.CuppingSession, error) {
+	return stubCuppingSession(), nil
+}
+func (stubCuppingService) GetCuppingSessionById(context.Context, int) (*cupping.CuppingSession, error) {
+	return stubCuppingSession(), nil
+}
+func (stubCuppingService) GetAllCuppingSessions(context.Context) ([]cupping.CuppingSession, error) {
+	return nil, nil
+}
+func (stubCuppingService) UpdateCuppingSessionById(context.Context, int, *cupping.CuppingSession) (*cupping.CuppingSession, error) {
+	return stubCuppingSession(), nil
+}
+func (stubCuppingService) DeleteCuppingSessionById(context.Context, int) error { return nil }
+func (stubCuppingService) CreateCuppingScore(context.Context, *cupping.CuppingScore) (*cupping.CuppingScore, error) {
+	return stubCuppingScore(), nil
+}
+func (stubCuppingService) GetCuppingScoreById(context.Context, int) (*cupping.CuppingScore, error) {
+	return stubCuppingScore(), nil
+}
+func (stubCuppingService) GetCuppingScoresByBeansId(context.Context, int) ([]cupping.CuppingScore, error) {
+	return nil, nil
+}
+func (stubCuppingService) UpdateCuppingScoreById(context.Context, int, *cupping.CuppingScore) (*cupping.CuppingScore, error) {
+	return stubCuppingScore(), nil
+}
+func (stubCuppingService) DeleteCuppingScoreById(context.Context, int) error { return nil }
+func (stubCuppingService) Ping(context.Context) error                        { return nil }
+
+// stubRoastBatchService is a minimal no-op roastbatch.Service used to exercise routing only.
+type stubRoastBatchService struct{}
+
+func stubRoastBatch() *roastbatch.RoastBatch {
+	return &roastbatch.RoastBatch{Id: 1, RoastDate: &stubNow, CreatedAt: &stubNow, UpdatedAt: &stubNow}
+}
+
+func (stubRoastBatchService) CreateRoastBatch(context.Context, *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error) {
+	return stubRoastBatch(), nil
+}
+func (stubRoastBatchService) GetRoastBatchById(context.Context, int) (*roastbatch.RoastBatch, error) {
+	return stubRoastBatch(), nil
+}
+func (stubRoastBatchService) GetAllRoastBatches(context.Context) ([]roastbatch.RoastBatch, error) {
+	return nil, nil
+}
+func (stubRoastBatchService) UpdateRoastBatchById(context.Context, int, *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error) {
+	return stubRoastBatch(), nil
+}
+func (stubRoastBatchService) DeleteRoastBatchById(context.Context, int) error { return nil }
+func (stubRoastBatchService) CreateBeansFromRoastBatch(context.Context, int) (*roastbatch.RoastBatch, error) {
+	return stubRoastBatch(), nil
+}
+func (stubRoastBatchService) Ping(context.Context) error { return nil }
+
+// stubGreenCoffeeService is a minimal no-op greencoffee.Service used to exercise routing only.
+type stubGreenCoffeeService struct{}
+
+func stubGreenCoffee() *greencoffee.GreenCoffee {
+	return &greencoffee.GreenCoffee{Id: 1, Origin: "Origin", PurchaseWeight: 1, CreatedAt: &stubNow, UpdatedAt: &stubNow}
+}
+
+func (stubGreenCoffeeService) CreateGreenCoffee(context.Context, *greencoffee.GreenCoffee) (*greencoffee.GreenCoffee, error) {
+	return stubGreenCoffee(), nil
+}
+func (stubGreenCoffeeService) GetGreenCoffeeById(context.Context, int) (*greencoffee.GreenCoffee, error) {
+	return stubGreenCoffee(), nil
+}
+func (stubGreenCoffeeService) GetAllGreenCoffees(context.Context) ([]greencoffee.GreenCoffee, error) {
+	return nil, nil
+}
+func (stubGreenCoffeeService) UpdateGreenCoffeeById(context.Context, int, *greencoffee.GreenCoffee) (*greencoffee.GreenCoffee, error) {
+	return stubGreenCoffee(), nil
+}
+func (stubGreenCoffeeService) DeleteGreenCoffeeById(context.Context, int) error { return nil }
+func (stubGreenCoffeeService) Ping(context.Context) error                       { return nil }
+
+// stubReportService is a minimal no-op report.Service used to exercise routing only.
+type stubReportService struct{}
+
+func (stubReportService) GetSpendReport(context.Context, *time.Time, *time.Time, report.GroupBy) (*report.SpendReport, error) {
+	return &report.SpendReport{GroupBy: report.GroupByMonth}, nil
+}
+func (stubReportService) Ping(context.Context) error { return nil }
+
+// stubStatsService is a minimal no-op stats.Service used to exercise routing only.
+type stubStatsService struct{}
+
+func (stubStatsService) GetConsumption(context.Context, *time.Time, *time.Time, string) (*stats.Consumption, error) {
+	return &stats.Consumption{TimeZone: "UTC"}, nil
+}
+func (stubStatsService) GetShotStats(context.Context, modelsql.ShotStatsSubject, int) (*stats.ShotStats, error) {
+	return &stats.ShotStats{}, nil
+}
+func (stubStatsService) Ping(context.Context) error { return nil }
+
+// stubMaintenanceService is a minimal no-op maintenance.Service used to exercise routing only.
+type stubMaintenanceService struct{}
+
+func stubMaintenanceTask() *maintenance.MaintenanceTask {
+	intervalDays := 30
+	return &maintenance.MaintenanceTask{Id: 1, Type: maintenance.TaskTypeDescale, IntervalDays: &intervalDays, CreatedAt: &stubNow, UpdatedAt: &stubNow}
+}
+
+func (stubMaintenanceService) CreateMaintenanceTask(context.Context, *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+	return stubMaintenanceTask(), nil
+}
+func (stubMaintenanceService) GetMaintenanceTaskById(context.Context, int) (*maintenance.MaintenanceTask, error) {
+	return stubMaintenanceTask(), nil
+}
+func (stubMaintenanceService) GetAllMaintenanceTasks(context.Context) ([]maintenance.MaintenanceTask, error) {
+	return nil, nil
+}
+func (stubMaintenanceService) GetDueMaintenanceTasks(context.Context) ([]maintenance.MaintenanceTask, error) {
+	return nil, nil
+}
+func (stubMaintenanceService) UpdateMaintenanceTaskById(context.Context, int, *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+	return stubMaintenanceTask(), nil
+}
+func (stubMaintenanceService) CompleteMaintenanceTaskById(context.Context, int) (*maintenance.MaintenanceTask, error) {
+	return stubMaintenanceTask(), nil
+}
+func (stubMaintenanceService) DeleteMaintenanceTaskById(context.Context, int) error { return nil }
+func (stubMaintenanceService) Ping(context.Context) error                           { return nil }
+
+// stubShareLinkService is a minimal no-op share.Service used to exercise routing only.
+type stubShareLinkService struct{}
+
+func (stubShareLinkService) CreateShareLink(context.Context, int, *time.Time) (*share.ShareLink, error) {
+	return &share.ShareLink{Id: 1, SheetId: 1, Token: "1.0.sig", ExpiresAt: stubNow, CreatedAt: &stubNow}, nil
+}
+func (stubShareLinkService) GetShareLinksBySheetId(context.Context, int) ([]share.ShareLink, error) {
+	return nil, nil
+}
+func (stubShareLinkService) RevokeShareLinkById(context.Context, int) error { return nil }
+func (stubShareLinkService) ResolveToken(context.Context, string) (*share.ShareLink, error) {
+	return &share.ShareLink{Id: 1, SheetId: 1, Token: "1.0.sig", ExpiresAt: stubNow, CreatedAt: &stubNow}, nil
+}
+func (stubShareLinkService) Ping(context.Context) error { return nil }
+
+// stubAttachmentService is a minimal attachment.Service used to exercise routing only.
+type stubAttachmentService struct{}
+
+func (stubAttachmentService) CreateShotAttachment(context.Context, int, string, []byte) (*attachment.Attachment, error) {
+	return &attachment.Attachment{Id: 1, CreatedAt: &stubNow}, nil
+}
+func (stubAttachmentService) CreateBeansAttachment(context.Context, int, string, []byte) (*attachment.Attachment, error) {
+	return &attachment.Attachment{Id: 1, CreatedAt: &stubNow}, nil
+}
+func (stubAttachmentService) GetAttachmentById(context.Context, int) (*attachment.Attachment, error) {
+	return &attachment.Attachment{Id: 1, CreatedAt: &stubNow}, nil
+}
+func (stubAttachmentService) GetAttachmentsByShotId(context.Context, int) ([]attachment.Attachment, error) {
+	return nil, nil
+}
+func (stubAttachmentService) GetAttachmentsByBeansId(context.Context, int) ([]attachment.Attachment, error) {
+	return nil, nil
+}
+func (stubAttachmentService) GetAttachmentContent(context.Context, int, bool) (*attachment.Content, error) {
+	return &attachment.Content{ReadCloser: io.NopCloser(strings.NewReader("picture")), ContentType: "image/jpeg", Filename: "crema.jpg"}, nil
+}
+func (stubAttachmentService) DeleteAttachmentById(context.Context, int) error { return nil }
+func (stubAttachmentService) Ping(context.Context) error                      { return nil }
+
+// stubBeanconquerorService is a minimal beanconqueror.Service used to exercise
+// routing only.
+type stubBeanconquerorService struct{}
+
+func (stubBeanconquerorService) Import(context.Context, []byte) (*beanconqueror.Report, error) {
+	return &beanconqueror.Report{}, nil
+}
+func (stubBeanconquerorService) Export(context.Context) (*beanconqueror.Backup, error) {
+	return &beanconqueror.Backup{}, nil
+}
+
+// stubSpreadsheetService is a minimal spreadsheet.Service used to exercise
+// routing only.
+type stubSpreadsheetService struct{}
+
+func (stubSpreadsheetService) Export(context.Context, spreadsheet.Resource, io.Writer) error {
+	return nil
+}
+func (stubSpreadsheetService) Import(context.Context, spreadsheet.Resource, io.Reader) (*spreadsheet.Report, error) {
+	return &spreadsheet.Report{}, nil
+}
+
+// stubDecentService is a minimal decent.Service used to exercise routing only.
+type stubDecentService struct{}
+
+func (stubDecentService) Import(context.Context, []decent.File, decent.Options) (*decent.Report, error) {
+	return &decent.Report{}, nil
+}
+
+// stubSessionService is a minimal session.Service used to exercise routing only.
+type stubSessionService struct{}
+
+func (stubSessionService) Login(context.Context, string, string) (*session.Session, string, error) {
+	return nil, "", domainerrors.ErrInvalidCredentials
+}
+func (stubSessionService) StartSession(context.Context, *auth.User) (*session.Session, string, error) {
+	return nil, "", domainerrors.ErrInvalidCredentials
+}
+func (stubSessionService) Authenticate(context.Context, string) (*session.Session, error) {
+	return nil, domainerrors.ErrSessionIsInvalid
+}
+func (stubSessionService) Logout(context.Context, string) error { return nil }
+func (stubSessionService) Ping(context.Context) error           { return nil }
+
+// stubSSOService is a minimal sso.Service used to exercise routing only.
+type stubSSOService struct{}
+
+func (stubSSOService) AuthCodeURL(string, string, string) string {
+	return "https://idp.example.com/authorize"
+}
+func (stubSSOService) Login(context.Context, string, string, string) (*auth.User, error) {
+	return nil, domainerrors.ErrOIDCLoginFailed
+}
+func (stubSSOService) AuthenticateToken(context.Context, string) (*auth.User, error) {
+	return nil, domainerrors.ErrOIDCTokenIsInvalid
+}
 
 func newTestRouter() http.Handler {
-	h := rest.NewHandler(stubSheetService{}, stubRoasterService{}, stubBeanService{}, stubShotService{}, 1<<20)
-	web := web.NewHandler(stubSheetService{}, stubRoasterService{}, stubBeanService{}, stubShotService{})
-	return newRouter(h, web, alice.New())
+	h := rest.NewHandler(stubSheetService{}, stubRoasterService{}, stubBeanService{}, stubShotService{}, stubCuppingService{}, stubRoastBatchService{}, stubGreenCoffeeService{}, stubReportService{}, stubStatsService{}, stubMaintenanceService{}, stubShareLinkService{}, stubAttachmentService{}, stubDecentService{}, stubBeanconquerorService{}, stubSpreadsheetService{}, 1<<20)
+	web := web.NewHandler(stubSheetService{}, stubRoasterService{}, stubBeanService{}, stubShotService{}, stubCuppingService{}, stubRoastBatchService{}, stubGreenCoffeeService{}, stubReportService{}, stubStatsService{}, stubMaintenanceService{}, stubShareLinkService{}, stubAttachmentService{}, stubSpreadsheetService{}, stubSessionService{}, stubSSOService{})
+	return newRouter(h, web, alice.New(), alice.New())
 }
 
 func TestNewRouter_RegistersAllExistingRoutes(t *testing.T) {
@@ -143,6 +408,7 @@ func TestNewRouter_RegistersAllExistingRoutes(t *testing.T) {
 		{"get all sheets", http.MethodGet, "/rest/v1/sheets"},
 		{"update sheet by id", http.MethodPut, "/rest/v1/sheets/1"},
 		{"delete sheet by id", http.MethodDelete, "/rest/v1/sheets/1"},
+		{"clone sheet", http.MethodPost, "/rest/v1/sheets/1/clone"},
 		{"create roaster", http.MethodPost, "/rest/v1/roasters"},
 		{"get roaster by id", http.MethodGet, "/rest/v1/roasters/1"},
 		{"get all roasters", http.MethodGet, "/rest/v1/roasters"},
@@ -158,19 +424,93 @@ func TestNewRouter_RegistersAllExistingRoutes(t *testing.T) {
 		{"get all shots", http.MethodGet, "/rest/v1/shots"},
 		{"update shot by id", http.MethodPut, "/rest/v1/shots/1"},
 		{"delete shot by id", http.MethodDelete, "/rest/v1/shots/1"},
+		{"get shot profile by id", http.MethodGet, "/rest/v1/shots/1/profile"},
+		{"update shot profile by id", http.MethodPut, "/rest/v1/shots/1/profile"},
+		{"compare shots", http.MethodGet, "/rest/v1/shots/compare?ids=1,2"},
+		{"duplicate shot", http.MethodPost, "/rest/v1/shots/1/duplicate"},
 		{"get shots by sheet id", http.MethodGet, "/rest/v1/sheets/1/shots"},
+		{"create cupping session", http.MethodPost, "/rest/v1/cupping_sessions"},
+		{"get cupping session by id", http.MethodGet, "/rest/v1/cupping_sessions/1"},
+		{"get all cupping sessions", http.MethodGet, "/rest/v1/cupping_sessions"},
+		{"update cupping session by id", http.MethodPut, "/rest/v1/cupping_sessions/1"},
+		{"delete cupping session by id", http.MethodDelete, "/rest/v1/cupping_sessions/1"},
+		{"create cupping score", http.MethodPost, "/rest/v1/cupping_sessions/1/scores"},
+		{"get cupping score by id", http.MethodGet, "/rest/v1/cupping_scores/1"},
+		{"update cupping score by id", http.MethodPut, "/rest/v1/cupping_scores/1"},
+		{"delete cupping score by id", http.MethodDelete, "/rest/v1/cupping_scores/1"},
+		{"get cupping scores by beans id", http.MethodGet, "/rest/v1/beans/1/cupping_scores"},
+		{"create roast batch", http.MethodPost, "/rest/v1/roast_batches"},
+		{"get roast batch by id", http.MethodGet, "/rest/v1/roast_batches/1"},
+		{"get all roast batches", http.MethodGet, "/rest/v1/roast_batches"},
+		{"update roast batch by id", http.MethodPut, "/rest/v1/roast_batches/1"},
+		{"delete roast batch by id", http.MethodDelete, "/rest/v1/roast_batches/1"},
+		{"create beans from roast batch", http.MethodPost, "/rest/v1/roast_batches/1/beans"},
+		{"create green coffee", http.MethodPost, "/rest/v1/green_coffees"},
+		{"get green coffee by id", http.MethodGet, "/rest/v1/green_coffees/1"},
+		{"get all green coffees", http.MethodGet, "/rest/v1/green_coffees"},
+		{"update green coffee by id", http.MethodPut, "/rest/v1/green_coffees/1"},
+		{"delete green coffee by id", http.MethodDelete, "/rest/v1/green_coffees/1"},
+		{"get spend report", http.MethodGet, "/rest/v1/reports/spend"},
+		{"get consumption stats", http.MethodGet, "/rest/v1/stats/consumption"},
+		{"get beans stats", http.MethodGet, "/rest/v1/stats/beans/1"},
+		{"get roaster stats", http.MethodGet, "/rest/v1/stats/roasters/1"},
+		{"get sheet stats", http.MethodGet, "/rest/v1/stats/sheets/1"},
+		{"create maintenance task", http.MethodPost, "/rest/v1/maintenance_tasks"},
+		{"get maintenance task by id", http.MethodGet, "/rest/v1/maintenance_tasks/1"},
+		{"get all maintenance tasks", http.MethodGet, "/rest/v1/maintenance_tasks"},
+		{"get due maintenance tasks", http.MethodGet, "/rest/v1/maintenance_tasks?due=true"},
+		{"update maintenance task by id", http.MethodPut, "/rest/v1/maintenance_tasks/1"},
+		{"delete maintenance task by id", http.MethodDelete, "/rest/v1/maintenance_tasks/1"},
+		{"complete maintenance task by id", http.MethodPost, "/rest/v1/maintenance_tasks/1/complete"},
+		{"create share link", http.MethodPost, "/rest/v1/sheets/1/share_links"},
+		{"get share links by sheet id", http.MethodGet, "/rest/v1/sheets/1/share_links"},
+		{"revoke share link by id", http.MethodDelete, "/rest/v1/share_links/1"},
+		{"create shot attachment", http.MethodPost, "/rest/v1/shots/1/attachments"},
+		{"get attachments by shot id", http.MethodGet, "/rest/v1/shots/1/attachments"},
+		{"import decent shots", http.MethodPost, "/rest/v1/import/decent"},
+		{"import beanconqueror backup", http.MethodPost, "/rest/v1/import/beanconqueror"},
+		{"export beanconqueror backup", http.MethodGet, "/rest/v1/export/beanconqueror"},
+		{"export sheets csv", http.MethodGet, "/rest/v1/sheets.csv"},
+		{"export roasters csv", http.MethodGet, "/rest/v1/roasters.csv"},
+		{"export beans csv", http.MethodGet, "/rest/v1/beans.csv"},
+		{"export shots csv", http.MethodGet, "/rest/v1/shots.csv"},
+		{"import sheets csv", http.MethodPost, "/rest/v1/import/sheets.csv"},
+		{"import roasters csv", http.MethodPost, "/rest/v1/import/roasters.csv"},
+		{"import beans csv", http.MethodPost, "/rest/v1/import/beans.csv"},
+		{"import shots csv", http.MethodPost, "/rest/v1/import/shots.csv"},
+		{"create beans attachment", http.MethodPost, "/rest/v1/beans/1/attachments"},
+		{"get attachments by beans id", http.MethodGet, "/rest/v1/beans/1/attachments"},
+		{"get attachment by id", http.MethodGet, "/rest/v1/attachments/1"},
+		{"get attachment content", http.MethodGet, "/rest/v1/attachments/1/content"},
+		{"get attachment thumbnail", http.MethodGet, "/rest/v1/attachments/1/thumbnail"},
+		{"delete attachment by id", http.MethodDelete, "/rest/v1/attachments/1"},
 		{"redoc", http.MethodGet, "/redoc"},
 		{"swagger ui", http.MethodGet, "/swagger"},
 		{"swagger json", http.MethodGet, "/swagger.json"},
+		{"web login form", http.MethodGet, "/login"},
+		{"web login", http.MethodPost, "/login"},
+		{"web logout", http.MethodPost, "/logout"},
+		{"web single sign-on", http.MethodGet, "/login/oidc"},
+		{"web single sign-on callback", http.MethodGet, "/login/oidc/callback"},
 		{"web home", http.MethodGet, "/"},
 		{"web list sheets", http.MethodGet, "/sheets"},
+		{"web export sheets csv", http.MethodGet, "/sheets/export"},
 		{"web add sheet form", http.MethodGet, "/sheets/add"},
 		{"web create sheet", http.MethodPost, "/sheets/add"},
 		{"web get sheet", http.MethodGet, "/sheets/get/1"},
 		{"web edit sheet form", http.MethodGet, "/sheets/update/1"},
 		{"web update sheet", http.MethodPut, "/sheets/update/1"},
 		{"web delete sheet", http.MethodDelete, "/sheets/delete/1"},
+		{"web share sheet", http.MethodPost, "/sheets/share/1"},
+		{"web revoke share link", http.MethodDelete, "/share_links/delete/1"},
+		{"web shared sheet", http.MethodGet, "/share/1.0.sig"},
+		{"web upload shot photo", http.MethodPost, "/shots/attachments/1"},
+		{"web upload beans photo", http.MethodPost, "/beans/attachments/1"},
+		{"web photo", http.MethodGet, "/attachments/content/1"},
+		{"web photo thumbnail", http.MethodGet, "/attachments/thumbnail/1"},
+		{"web delete photo", http.MethodDelete, "/attachments/delete/1"},
 		{"web list roasters", http.MethodGet, "/roasters"},
+		{"web export roasters csv", http.MethodGet, "/roasters/export"},
 		{"web add roaster form", http.MethodGet, "/roasters/add"},
 		{"web create roaster", http.MethodPost, "/roasters/add"},
 		{"web get roaster", http.MethodGet, "/roasters/get/1"},
@@ -178,19 +518,59 @@ func TestNewRouter_RegistersAllExistingRoutes(t *testing.T) {
 		{"web update roaster", http.MethodPut, "/roasters/update/1"},
 		{"web delete roaster", http.MethodDelete, "/roasters/delete/1"},
 		{"web list beans", http.MethodGet, "/beans"},
+		{"web export beans csv", http.MethodGet, "/beans/export"},
 		{"web add bean form", http.MethodGet, "/beans/add"},
 		{"web create bean", http.MethodPost, "/beans/add"},
 		{"web get bean", http.MethodGet, "/beans/get/1"},
 		{"web edit bean form", http.MethodGet, "/beans/update/1"},
 		{"web update bean", http.MethodPut, "/beans/update/1"},
 		{"web delete bean", http.MethodDelete, "/beans/delete/1"},
+		{"web bean cuppings", http.MethodGet, "/beans/cuppings/1"},
 		{"web list shots", http.MethodGet, "/shots"},
+		{"web export shots csv", http.MethodGet, "/shots/export"},
 		{"web add shot form", http.MethodGet, "/shots/add"},
 		{"web create shot", http.MethodPost, "/shots/add"},
 		{"web get shot", http.MethodGet, "/shots/get/1"},
+		{"web compare shots", http.MethodGet, "/shots/compare?ids=1,2"},
+		{"web pull shot again form", http.MethodGet, "/shots/again/1"},
 		{"web edit shot form", http.MethodGet, "/shots/update/1"},
 		{"web update shot", http.MethodPut, "/shots/update/1"},
 		{"web delete shot", http.MethodDelete, "/shots/delete/1"},
+		{"web list cuppings", http.MethodGet, "/cuppings"},
+		{"web add cupping form", http.MethodGet, "/cuppings/add"},
+		{"web create cupping", http.MethodPost, "/cuppings/add"},
+		{"web get cupping", http.MethodGet, "/cuppings/get/1"},
+		{"web edit cupping form", http.MethodGet, "/cuppings/update/1"},
+		{"web update cupping", http.MethodPut, "/cuppings/update/1"},
+		{"web delete cupping", http.MethodDelete, "/cuppings/delete/1"},
+		{"web add cupping score form", http.MethodGet, "/cuppings/scores/add?session_id=1"},
+		{"web create cupping score", http.MethodPost, "/cuppings/scores/add"},
+		{"web edit cupping score form", http.MethodGet, "/cuppings/scores/update/1"},
+		{"web update cupping score", http.MethodPut, "/cuppings/scores/update/1"},
+		{"web delete cupping score", http.MethodDelete, "/cuppings/scores/delete/1"},
+		{"web list roasts", http.MethodGet, "/roasts"},
+		{"web add roast form", http.MethodGet, "/roasts/add"},
+		{"web create roast", http.MethodPost, "/roasts/add"},
+		{"web get roast", http.MethodGet, "/roasts/get/1"},
+		{"web edit roast form", http.MethodGet, "/roasts/update/1"},
+		{"web update roast", http.MethodPut, "/roasts/update/1"},
+		{"web delete roast", http.MethodDelete, "/roasts/delete/1"},
+		{"web create beans from roast", http.MethodPost, "/roasts/beans/1"},
+		{"web list green coffees", http.MethodGet, "/green_coffees"},
+		{"web add green coffee form", http.MethodGet, "/green_coffees/add"},
+		{"web create green coffee", http.MethodPost, "/green_coffees/add"},
+		{"web edit green coffee form", http.MethodGet, "/green_coffees/update/1"},
+		{"web update green coffee", http.MethodPut, "/green_coffees/update/1"},
+		{"web delete green coffee", http.MethodDelete, "/green_coffees/delete/1"},
+		{"web spend report", http.MethodGet, "/reports"},
+		{"web consumption stats", http.MethodGet, "/stats"},
+		{"web list maintenance tasks", http.MethodGet, "/maintenance"},
+		{"web add maintenance task form", http.MethodGet, "/maintenance/add"},
+		{"web create maintenance task", http.MethodPost, "/maintenance/add"},
+		{"web edit maintenance task form", http.MethodGet, "/maintenance/update/1"},
+		{"web update maintenance task", http.MethodPut, "/maintenance/update/1"},
+		{"web delete maintenance task", http.MethodDelete, "/maintenance/delete/1"},
+		{"web complete maintenance task", http.MethodPost, "/maintenance/done/1"},
 	}
 
 	for _, tt := range tests {
@@ -208,6 +588,73 @@ func TestNewRouter_RegistersAllExistingRoutes(t *testing.T) {
 	}
 }
 
+func TestNewRouter_EnforcesRolePermissions(t *testing.T) {
+	tests := []struct {
+		name          string
+		role          auth.Role
+		method        string
+		path          string
+		wantForbidden bool
+	}{
+		{"viewer reads roasters", auth.RoleViewer, http.MethodGet, "/rest/v1/roasters", false},
+		{"viewer cannot delete a roaster", auth.RoleViewer, http.MethodDelete, "/rest/v1/roasters/1", true},
+		{"viewer cannot complete a maintenance task", auth.RoleViewer, http.MethodPost, "/rest/v1/maintenance_tasks/1/complete", true},
+		{"barista cannot delete a roaster", auth.RoleBarista, http.MethodDelete, "/rest/v1/roasters/1", true},
+		{"barista deletes a shot", auth.RoleBarista, http.MethodDelete, "/rest/v1/shots/1", false},
+		{"barista creates beans from a roast", auth.RoleBarista, http.MethodPost, "/rest/v1/roast_batches/1/beans", false},
+		{"admin deletes a roaster", auth.RoleAdmin, http.MethodDelete, "/rest/v1/roasters/1", false},
+		{"viewer pings", auth.RoleViewer, http.MethodGet, "/ping", false},
+		{"web viewer lists shots", auth.RoleViewer, http.MethodGet, "/shots", false},
+		{"web viewer cannot open the add shot form", auth.RoleViewer, http.MethodGet, "/shots/add", true},
+		{"web barista cannot delete a roaster", auth.RoleBarista, http.MethodDelete, "/roasters/delete/1", true},
+		{"web barista edits a roaster", auth.RoleBarista, http.MethodGet, "/roasters/update/1", false},
+		{"viewer cannot list share links", auth.RoleViewer, http.MethodGet, "/rest/v1/sheets/1/share_links", true},
+		{"barista creates a share link", auth.RoleBarista, http.MethodPost, "/rest/v1/sheets/1/share_links", false},
+		{"web viewer cannot share a sheet", auth.RoleViewer, http.MethodPost, "/sheets/share/1", true},
+		{"viewer reads an attachment thumbnail", auth.RoleViewer, http.MethodGet, "/rest/v1/attachments/1/thumbnail", false},
+		{"viewer cannot upload an attachment", auth.RoleViewer, http.MethodPost, "/rest/v1/shots/1/attachments", true},
+		{"barista deletes an attachment", auth.RoleBarista, http.MethodDelete, "/rest/v1/attachments/1", false},
+		{"web viewer cannot delete a photo", auth.RoleViewer, http.MethodDelete, "/attachments/delete/1", true},
+		{"viewer reads a shot profile", auth.RoleViewer, http.MethodGet, "/rest/v1/shots/1/profile", false},
+		{"viewer cannot update a shot profile", auth.RoleViewer, http.MethodPut, "/rest/v1/shots/1/profile", true},
+		{"viewer cannot duplicate a shot", auth.RoleViewer, http.MethodPost, "/rest/v1/shots/1/duplicate", true},
+		{"web viewer cannot pull a shot again", auth.RoleViewer, http.MethodGet, "/shots/again/1", true},
+		{"viewer cannot clone a sheet", auth.RoleViewer, http.MethodPost, "/rest/v1/sheets/1/clone", true},
+		{"viewer cannot import decent shots", auth.RoleViewer, http.MethodPost, "/rest/v1/import/decent", true},
+		{"barista imports decent shots", auth.RoleBarista, http.MethodPost, "/rest/v1/import/decent", false},
+		{"viewer cannot import a beanconqueror backup", auth.RoleViewer, http.MethodPost, "/rest/v1/import/beanconqueror", true},
+		{"viewer exports a beanconqueror backup", auth.RoleViewer, http.MethodGet, "/rest/v1/export/beanconqueror", false},
+		{"viewer exports shots as csv", auth.RoleViewer, http.MethodGet, "/rest/v1/shots.csv", false},
+		{"viewer cannot import roasters from csv", auth.RoleViewer, http.MethodPost, "/rest/v1/import/roasters.csv", true},
+		{"barista imports shots from csv", auth.RoleBarista, http.MethodPost, "/rest/v1/import/shots.csv", false},
+		{"web viewer exports beans as csv", auth.RoleViewer, http.MethodGet, "/beans/export", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asUser := func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					user := &auth.User{Id: 1, Name: "alice", Role: tt.role}
+					next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), user)))
+				})
+			}
+			h := rest.NewHandler(stubSheetService{}, stubRoasterService{}, stubBeanService{}, stubShotService{}, stubCuppingService{}, stubRoastBatchService{}, stubGreenCoffeeService{}, stubReportService{}, stubStatsService{}, stubMaintenanceService{}, stubShareLinkService{}, stubAttachmentService{}, stubDecentService{}, stubBeanconquerorService{}, stubSpreadsheetService{}, 1<<20)
+			web := web.NewHandler(stubSheetService{}, stubRoasterService{}, stubBeanService{}, stubShotService{}, stubCuppingService{}, stubRoastBatchService{}, stubGreenCoffeeService{}, stubReportService{}, stubStatsService{}, stubMaintenanceService{}, stubShareLinkService{}, stubAttachmentService{}, stubSpreadsheetService{}, stubSessionService{}, stubSSOService{})
+			r := newRouter(h, web, alice.New(asUser), alice.New(asUser))
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if got := rec.Code == http.StatusForbidden; got != tt.wantForbidden {
+				t.Errorf("%s %s as %s: status = %d, want forbidden %t", tt.method, tt.path, tt.role, rec.Code, tt.wantForbidden)
+			}
+		})
+	}
+}
+
 func TestNewRouter_UnknownPathReturns404(t *testing.T) {
 	r := newTestRouter()
 
@@ -2,26 +2,43 @@ package cmd
 
 import (
 	"context"
+	"crypto/rand"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gorilla/handlers"
 	"github.com/justinas/alice"
 	"github.com/lescactus/espressoapi-go/cmd/app"
+	"github.com/lescactus/espressoapi-go/internal/auth/oidc"
 	"github.com/lescactus/espressoapi-go/internal/config"
 	"github.com/lescactus/espressoapi-go/internal/controllers/rest"
 	"github.com/lescactus/espressoapi-go/internal/controllers/web"
 	"github.com/rs/zerolog/hlog"
 	"github.com/spf13/cobra"
 
+	svcapikey "github.com/lescactus/espressoapi-go/internal/services/apikey"
+	svcattachment "github.com/lescactus/espressoapi-go/internal/services/attachment"
 	svcbean "github.com/lescactus/espressoapi-go/internal/services/bean"
+	svcbeanconqueror "github.com/lescactus/espressoapi-go/internal/services/beanconqueror"
+	svccupping "github.com/lescactus/espressoapi-go/internal/services/cupping"
+	svcdecent "github.com/lescactus/espressoapi-go/internal/services/decent"
+	svcgreencoffee "github.com/lescactus/espressoapi-go/internal/services/greencoffee"
+	svcmaintenance "github.com/lescactus/espressoapi-go/internal/services/maintenance"
+	svcreport "github.com/lescactus/espressoapi-go/internal/services/report"
+	svcroastbatch "github.com/lescactus/espressoapi-go/internal/services/roastbatch"
 	svcroaster "github.com/lescactus/espressoapi-go/internal/services/roaster"
+	svcsession "github.com/lescactus/espressoapi-go/internal/services/session"
+	svcshare "github.com/lescactus/espressoapi-go/internal/services/share"
 	svcsheet "github.com/lescactus/espressoapi-go/internal/services/sheet"
 	svcshot "github.com/lescactus/espressoapi-go/internal/services/shot"
+	svcspreadsheet "github.com/lescactus/espressoapi-go/internal/services/spreadsheet"
+	svcsso "github.com/lescactus/espressoapi-go/internal/services/sso"
+	svcstats "github.com/lescactus/espressoapi-go/internal/services/stats"
 )
 
 // runCmd represents the run command
@@ -51,10 +68,52 @@ func runCmdMain(cmd *cobra.Command, args []string) {
 	svcRoaster := svcroaster.New(repositories.roaster)
 	svcBean := svcbean.New(repositories.beans)
 	svcShot := svcshot.New(repositories.shot)
+	svcCupping := svccupping.New(repositories.cupping)
+	svcRoastBatch := svcroastbatch.New(repositories.roastBatch)
+	svcGreenCoffee := svcgreencoffee.New(repositories.greenCoffee)
+	svcReport := svcreport.New(repositories.report)
+	svcStats := svcstats.New(repositories.stats)
+	svcMaintenance := svcmaintenance.New(repositories.maintenance)
+	svcAPIKey := svcapikey.New(repositories.apiKey, repositories.user)
+	svcSession := svcsession.New(repositories.session, repositories.user)
+
+	// Single sign-on is optional: svcSSO stays nil unless a provider is configured
+	var svcSSO svcsso.Service
+	if app.App.Cfg.OIDCEnabled() {
+		provider, err := oidc.New(context.Background(), oidc.Config{
+			IssuerURL:    app.App.Cfg.OIDCIssuerURL,
+			ClientID:     app.App.Cfg.OIDCClientID,
+			ClientSecret: app.App.Cfg.OIDCClientSecret,
+			RedirectURL:  app.App.Cfg.OIDCRedirectURL,
+			Audience:     app.App.Cfg.OIDCAudience,
+			Scopes:       strings.Fields(app.App.Cfg.OIDCScopes),
+		}, &http.Client{Timeout: 10 * time.Second})
+		if err != nil {
+			log.Fatalf("unable to set up single sign-on: %s", err)
+		}
+		svcSSO = svcsso.New(provider, repositories.user)
+	}
+
+	// Share links are signed with a random secret unless one is configured
+	shareLinkSecret := []byte(app.App.Cfg.ShareLinkSecret)
+	if len(shareLinkSecret) == 0 {
+		shareLinkSecret = []byte(rand.Text() + rand.Text())
+		app.App.Logger.Warn().Msg("No share link secret is configured: share links will not survive a restart")
+	}
+	svcShareLink := svcshare.New(repositories.shareLink, shareLinkSecret)
+
+	blobStore, err := newBlobStore(app.App.Cfg)
+	if err != nil {
+		log.Fatalf("unable to create blob store: %s", err)
+	}
+	svcAttachment := svcattachment.New(repositories.attachment, blobStore)
+	svcDecent := svcdecent.New(repositories.shotImport)
+	svcBeanconqueror := svcbeanconqueror.New(repositories.shotImport, repositories.sheet, repositories.beans, repositories.shot)
+	svcSpreadsheet := svcspreadsheet.New(svcSheet, svcRoaster, svcBean, svcShot)
 
 	// Create handlers and middleware chain
-	h := rest.NewHandler(svcSheet, svcRoaster, svcBean, svcShot, app.App.Cfg.ServerMaxRequestSize)
-	webHandler := web.NewHandler(svcSheet, svcRoaster, svcBean, svcShot)
+	h := rest.NewHandler(svcSheet, svcRoaster, svcBean, svcShot, svcCupping, svcRoastBatch, svcGreenCoffee, svcReport, svcStats, svcMaintenance, svcShareLink, svcAttachment, svcDecent, svcBeanconqueror, svcSpreadsheet, app.App.Cfg.ServerMaxRequestSize)
+	webHandler := web.NewHandler(svcSheet, svcRoaster, svcBean, svcShot, svcCupping, svcRoastBatch, svcGreenCoffee, svcReport, svcStats, svcMaintenance, svcShareLink, svcAttachment, svcSpreadsheet, svcSession, svcSSO)
 	c := alice.New()
 
 	// Logger fields
@@ -77,11 +136,25 @@ func runCmdMain(cmd *cobra.Command, args []string) {
 	c = c.Append(hlog.RemoteAddrHandler("remote_client"))
 	c = c.Append(hlog.UserAgentHandler("user_agent"))
 	c = c.Append(hlog.RequestIDHandler("req_id", "X-Request-ID"))
+
+	// Require an api key or an OpenID Connect access token on /rest/v1/* routes
+	if app.App.Cfg.AuthEnabled {
+		c = c.Append(h.APIKeyAuth(svcAPIKey, svcSSO))
+	} else {
+		app.App.Logger.Warn().Msg("Authentication is disabled: the REST API and the web UI are open to anyone")
+	}
+
 	c = c.Append(h.IdParameterLoggerHandler("id"))
 	c = c.Append(h.MaxReqSize())
 
+	// Reject cross-origin form submissions and require a session on the web UI
+	webChain := c.Append(webHandler.SameOrigin())
+	if app.App.Cfg.AuthEnabled {
+		webChain = webChain.Append(webHandler.RequireSession())
+	}
+
 	// Build the route table and server
-	r := newRouter(h, webHandler, c)
+	r := newRouter(h, webHandler, c, webChain)
 	s := &http.Server{
 		Addr:              app.App.Cfg.ServerAddr,
 		Handler:           handlers.RecoveryHandler(handlers.PrintRecoveryStack(true))(r), // recover from panics and print recovery stack
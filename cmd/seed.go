@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/cmd/app"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/seed"
+	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/spf13/cobra"
+)
+
+// seedCmd represents the seed command
+var seedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Fill the database with demo roasters, beans, sheets and shots",
+	Long: `Fill the database with realistic demo data: roasters, beans roasted in the
+last weeks, and sheets dialing in beans, whose shots move the grind setting
+towards a 28 seconds shot, their ratings rising along. The same --seed always
+generates the same records, with roast dates relative to the current day.
+
+The records are created as through the API, and validated alike. Nothing is
+created when a roaster or sheet to create already exists, as when seeding
+twice with the same --seed. With --reset, every cupping, roast, shot, beans,
+sheet and roaster is deleted first, in a single transaction.
+
+With --user, the records are created for that user, and --reset only deletes
+theirs.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		userName, _ := cmd.Flags().GetString("user")
+		reset, _ := cmd.Flags().GetBool("reset")
+		opts := seed.Options{Today: time.Now()}
+		opts.Seed, _ = cmd.Flags().GetInt64("seed")
+		opts.Roasters, _ = cmd.Flags().GetInt("roasters")
+		opts.BeansPerRoaster, _ = cmd.Flags().GetInt("beans-per-roaster")
+		opts.Sheets, _ = cmd.Flags().GetInt("sheets")
+		opts.ShotsPerSheet, _ = cmd.Flags().GetInt("shots-per-sheet")
+
+		repositories, ctx := newUserRepositorySet(userName)
+		report, err := newSeedService(repositories).Seed(ctx, opts, reset)
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to seed the database")
+		}
+		if err := printSeedReport(cmd.OutOrStdout(), report); err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to print seed report")
+		}
+		app.App.Logger.Info().Int64("seed", opts.Seed).Bool("reset", reset).Msg("Successfully seeded the database!")
+	},
+}
+
+func init() {
+	seedCmd.Flags().Int64("seed", seed.DefaultOptions.Seed, "Seed of the generator: the same seed generates the same records")
+	seedCmd.Flags().Int("roasters", seed.DefaultOptions.Roasters, "Number of roasters to create")
+	seedCmd.Flags().Int("beans-per-roaster", seed.DefaultOptions.BeansPerRoaster, "Number of beans to create for each roaster")
+	seedCmd.Flags().Int("sheets", seed.DefaultOptions.Sheets, "Number of sheets to create, each dialing in beans")
+	seedCmd.Flags().Int("shots-per-sheet", seed.DefaultOptions.ShotsPerSheet, "Number of shots of each sheet")
+	seedCmd.Flags().Bool("reset", false, "Delete every cupping, roast, shot, beans, sheet and roaster first")
+	seedCmd.Flags().String("user", "", "Name of the user owning the created records")
+}
+
+func newSeedService(repositories repositorySet) *seed.SeedService {
+	return seed.New(sheet.New(repositories.sheet), roaster.New(repositories.roaster), bean.New(repositories.beans), shot.New(repositories.shot), repositories.seed)
+}
+
+// printSeedReport writes the number of records deleted and created as a
+// table, one kind of record per line.
+func printSeedReport(w io.Writer, report *seed.Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tDELETED\tCREATED")
+	for _, row := range []struct {
+		kind             string
+		deleted, created int
+	}{
+		{"roasters", report.Deleted.Roasters, report.Created.Roasters},
+		{"beans", report.Deleted.Beans, report.Created.Beans},
+		{"sheets", report.Deleted.Sheets, report.Created.Sheets},
+		{"shots", report.Deleted.Shots, report.Created.Shots},
+		{"cuppings", report.Deleted.Cuppings, report.Created.Cuppings},
+		{"roasts", report.Deleted.Roasts, report.Created.Roasts},
+	} {
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", row.kind, row.deleted, row.created)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/lescactus/espressoapi-go/internal/services/seed"
+)
+
+func TestPrintSeedReport(t *testing.T) {
+	report := &seed.Report{
+		Deleted: seed.Count{Roasters: 1, Beans: 2, Shots: 16, Cuppings: 1},
+		Created: seed.Count{Roasters: 3, Beans: 6, Sheets: 4, Shots: 32},
+	}
+
+	var buf bytes.Buffer
+	if err := printSeedReport(&buf, report); err != nil {
+		t.Fatalf("printSeedReport() error = %v", err)
+	}
+
+	want := `KIND      DELETED  CREATED
+roasters  1        3
+beans     2        6
+sheets    0        4
+shots     16       32
+cuppings  1        0
+roasts    0        0
+`
+	if got := buf.String(); got != want {
+		t.Errorf("printSeedReport() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/cmd/app"
+	"github.com/lescactus/espressoapi-go/cmd/migrate"
+	"github.com/lescactus/espressoapi-go/internal/config"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/transfer"
+	"github.com/spf13/cobra"
+)
+
+// transferCmd represents the transfer command
+var transferCmd = &cobra.Command{
+	Use:   "transfer",
+	Short: "Copy every record of a database into another one",
+	Long: `Copy every record of a database into another one, which may be of another
+type, such as from MySQL to PostgreSQL. The migrations of the target are
+applied first, then every table is copied in batches, each after the tables
+it references, keeping the ids of the records, and the id sequences of the
+target are moved past them. The copy runs in a single transaction: nothing is
+copied when any record fails, and the target must hold no records.
+
+The row counts and the checksums of every table are then compared between
+both databases. The command fails when any of them differs.
+
+The source defaults to the configured database.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fromType, _ := cmd.Flags().GetString("from-type")
+		fromDSN, _ := cmd.Flags().GetString("from-dsn")
+		toType, _ := cmd.Flags().GetString("to-type")
+		toDSN, _ := cmd.Flags().GetString("to-dsn")
+		batchSize, _ := cmd.Flags().GetInt("batch-size")
+		if fromType == "" {
+			fromType = string(app.App.Cfg.DatabaseType)
+		}
+		if fromDSN == "" {
+			fromDSN = app.App.Cfg.DatabaseDatasourceName
+		}
+		if fromType == toType && fromDSN == toDSN {
+			app.App.Logger.Fatal().Msg("The source and target databases must differ")
+		}
+
+		from, err := openTransferDatabase(config.DatabaseType(fromType), fromDSN)
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to connect to the source database")
+		}
+		defer from.DB.Close()
+		to, err := openTransferDatabase(config.DatabaseType(toType), toDSN)
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to connect to the target database")
+		}
+		defer to.DB.Close()
+
+		n, err := migrate.ExecUp(to.DB.DB, config.DatabaseType(toType))
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to apply (up) migrations to the target database")
+		}
+		app.App.Logger.Info().Msgf("Successfully applied (up) %d migration(s) to the target database!", n)
+
+		ctx := context.Background()
+		t := transfer.New(*from, *to, batchSize)
+		copied, err := t.Copy(ctx)
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to copy records")
+		}
+		total := 0
+		for _, n := range copied {
+			total += n
+		}
+		app.App.Logger.Info().Int("rows", total).Msg("Successfully copied records!")
+
+		results, err := t.Verify(ctx)
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to verify records")
+		}
+		if err := printTransferReport(cmd.OutOrStdout(), results); err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to print transfer report")
+		}
+		for _, result := range results {
+			if !result.Matches() {
+				app.App.Logger.Fatal().Str("table", result.Table).Msg("Source and target databases differ")
+			}
+		}
+		app.App.Logger.Info().Str("from", fromType).Str("to", toType).Msg("Successfully transferred!")
+	},
+}
+
+func init() {
+	transferCmd.Flags().String("from-type", "", "Type of the source database, mysql or postgres, instead of the configured one")
+	transferCmd.Flags().String("from-dsn", "", "Data source name of the source database, instead of the configured one")
+	transferCmd.Flags().String("to-type", "", "Type of the target database, mysql or postgres")
+	transferCmd.Flags().String("to-dsn", "", "Data source name of the target database")
+	transferCmd.Flags().Int("batch-size", transfer.DefaultBatchSize, "Number of rows read and inserted at once")
+	_ = transferCmd.MarkFlagRequired("to-type")
+	_ = transferCmd.MarkFlagRequired("to-dsn")
+}
+
+// openTransferDatabase connects to the database of type databaseType. MySQL
+// timestamps are always parsed, so that they compare with PostgreSQL ones.
+func openTransferDatabase(databaseType config.DatabaseType, dsn string) (*transfer.Database, error) {
+	driverName, err := databaseDriverName(databaseType)
+	if err != nil {
+		return nil, err
+	}
+
+	dialect := adapters.PostgreSQL()
+	if databaseType == config.DatabaseTypeMySQL {
+		dialect = adapters.MySQL()
+		cfg, err := mysql.ParseDSN(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mysql data source name: %w", err)
+		}
+		cfg.ParseTime = true
+		dsn = cfg.FormatDSN()
+	}
+
+	db, err := sqlx.Connect(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to %s: %w", databaseType, err)
+	}
+	return &transfer.Database{DB: db, Dialect: dialect}, nil
+}
+
+// printTransferReport writes the row counts and the checksums of every table
+// on both databases as a table, one table per line.
+func printTransferReport(w io.Writer, results []transfer.TableResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TABLE\tSOURCE ROWS\tTARGET ROWS\tCHECKSUM\tSTATUS")
+	for _, result := range results {
+		status := "ok"
+		if !result.Matches() {
+			status = "MISMATCH"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\n", result.Table, result.SourceRows, result.TargetRows, shortChecksum(result.SourceChecksum), status)
+	}
+	return tw.Flush()
+}
+
+func shortChecksum(checksum string) string {
+	if len(checksum) > 12 {
+		return checksum[:12]
+	}
+	return checksum
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/transfer"
+)
+
+func TestPrintTransferReport(t *testing.T) {
+	results := []transfer.TableResult{
+		{Table: "sheets", SourceRows: 2, TargetRows: 2, SourceChecksum: "3f1c2a9b8e7d6c5b", TargetChecksum: "3f1c2a9b8e7d6c5b"},
+		{Table: "shots", SourceRows: 120, TargetRows: 119, SourceChecksum: "a0b1c2d3e4f5a6b7", TargetChecksum: "0f9e8d7c6b5a4f3e"},
+	}
+
+	var buf bytes.Buffer
+	if err := printTransferReport(&buf, results); err != nil {
+		t.Fatalf("printTransferReport() error = %v", err)
+	}
+
+	want := `TABLE   SOURCE ROWS  TARGET ROWS  CHECKSUM      STATUS
+sheets  2            2            3f1c2a9b8e7d  ok
+shots   120          119          a0b1c2d3e4f5  MISMATCH
+`
+	if got := buf.String(); got != want {
+		t.Errorf("printTransferReport() = %q, want %q", got, want)
+	}
+}
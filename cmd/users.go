@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/lescactus/espressoapi-go/cmd/app"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/services/user"
+	"github.com/spf13/cobra"
+)
+
+// usersCmd represents the users command
+var usersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Administer users",
+	Long: `Create, list or disable the users owning sheets, roasters, beans and shots.
+A disabled user keeps their data but can no longer authenticate.
+
+A user has one of the viewer, barista or admin roles. Viewers may only read,
+baristas may also create and update everything but only delete shots and
+cuppings, and admins may do everything.`,
+}
+
+var usersCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a user",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		role, _ := cmd.Flags().GetString("role")
+
+		u, err := newUserService().CreateUser(context.Background(), args[0], auth.Role(role))
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to create user")
+		}
+		app.App.Logger.Info().Int("id", u.Id).Str("role", string(u.Role)).Msgf("Successfully created user %q!", u.Name)
+	},
+}
+
+var usersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the users",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		users, err := newUserService().GetAllUsers(context.Background())
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to list users")
+		}
+		if err := printUsers(cmd.OutOrStdout(), users); err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to list users")
+		}
+	},
+}
+
+var usersDisableCmd = &cobra.Command{
+	Use:   "disable <name>",
+	Short: "Disable a user",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := newUserService().DisableUserByName(context.Background(), args[0]); err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to disable user")
+		}
+		app.App.Logger.Info().Msgf("Successfully disabled user %q!", args[0])
+	},
+}
+
+var usersRoleCmd = &cobra.Command{
+	Use:   "role <name> <role>",
+	Short: "Set the role of a user",
+	Long: `Set the role of a user: viewer, barista or admin. It applies to the sessions
+and api keys of the user from their next request.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := newUserService().SetUserRole(context.Background(), args[0], auth.Role(args[1])); err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to set role")
+		}
+		app.App.Logger.Info().Msgf("Successfully set the role of user %q to %s!", args[0], args[1])
+	},
+}
+
+var usersPasswdCmd = &cobra.Command{
+	Use:   "passwd <name>",
+	Short: "Set the password of a user",
+	Long: `Set the password a user logs in to the web UI with. The password is read
+from the first line of the standard input, for example:
+
+  echo 'my secret password' | espressoapi-go users passwd alice`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		password, err := readPassword(cmd.InOrStdin())
+		if err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to read password")
+		}
+		if err := newUserService().SetUserPassword(context.Background(), args[0], password); err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to set password")
+		}
+		app.App.Logger.Info().Msgf("Successfully set the password of user %q!", args[0])
+	},
+}
+
+var usersLinkCmd = &cobra.Command{
+	Use:   "link <name> <subject>",
+	Short: "Link a user to an OpenID Connect identity",
+	Long: `Link a user to the subject ("sub" claim) of their identity at the OpenID Connect
+provider, so that they can log in with single sign-on. Users are otherwise
+linked on their first login when the provider reports a verified email equal
+to their name.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := newUserService().LinkUserOIDCSubject(context.Background(), args[0], args[1]); err != nil {
+			app.App.Logger.Fatal().Err(err).Msg("Failed to link user")
+		}
+		app.App.Logger.Info().Msgf("Successfully linked user %q to subject %q!", args[0], args[1])
+	},
+}
+
+func init() {
+	usersCreateCmd.Flags().String("role", string(auth.RoleBarista), "Role of the user: viewer, barista or admin")
+
+	usersCmd.AddCommand(usersCreateCmd)
+	usersCmd.AddCommand(usersListCmd)
+	usersCmd.AddCommand(usersDisableCmd)
+	usersCmd.AddCommand(usersRoleCmd)
+	usersCmd.AddCommand(usersPasswdCmd)
+	usersCmd.AddCommand(usersLinkCmd)
+}
+
+func newUserService() *user.UserService {
+	repositories, err := newRepositorySet(app.App.Cfg.DatabaseType, app.App.Db)
+	if err != nil {
+		app.App.Logger.Fatal().Err(err).Msg("Failed to create repositories")
+	}
+	return user.New(repositories.user)
+}
+
+// readPassword returns the first line of r, without its line ending.
+func readPassword(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return "", errors.New("no password given on the standard input")
+	}
+	return line, nil
+}
+
+// printUsers writes users as a table, one per line.
+func printUsers(w io.Writer, users []user.User) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tROLE\tSTATUS\tCREATED")
+	for _, u := range users {
+		status := "active"
+		if u.Disabled {
+			status = "disabled"
+		}
+		created := ""
+		if u.CreatedAt != nil {
+			created = u.CreatedAt.Format("2006-01-02")
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", u.Id, u.Name, u.Role, status, created)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/services/user"
+)
+
+func TestPrintUsers(t *testing.T) {
+	created := time.Date(2026, time.October, 18, 15, 0, 0, 0, time.UTC)
+	users := []user.User{
+		{Id: 1, Name: "alice", Role: auth.RoleAdmin, CreatedAt: &created},
+		{Id: 12, Name: "bob", Role: auth.RoleViewer, Disabled: true},
+	}
+
+	var buf bytes.Buffer
+	if err := printUsers(&buf, users); err != nil {
+		t.Fatalf("printUsers() error = %v", err)
+	}
+
+	want := `ID  NAME   ROLE    STATUS    CREATED
+1   alice  admin   active    2026-10-18
+12  bob    viewer  disabled  
+`
+	if got := buf.String(); got != want {
+		t.Errorf("printUsers() = %q, want %q", got, want)
+	}
+}
+
+func TestReadPassword(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "first line", input: "s3cret pass\nignored\n", want: "s3cret pass"},
+		{name: "crlf line ending", input: "s3cret pass\r\n", want: "s3cret pass"},
+		{name: "no line ending", input: "s3cret pass", want: "s3cret pass"},
+		{name: "empty input", input: "", wantErr: true},
+		{name: "empty line", input: "\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readPassword(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("readPassword() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Package auth carries the user a request is authenticated as, and the CSRF
+// token of their session, through its context. It also hashes passwords and
+// holds the permission matrix of the user roles.
+package auth
+
+import "context"
+
+// User is the authenticated user a request runs for.
+type User struct {
+	Id   int
+	Name string
+	Role Role
+}
+
+type userKey struct{}
+
+// NewContext returns a copy of ctx carrying user.
+func NewContext(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// FromContext returns the user ctx is authenticated as, if any.
+func FromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userKey{}).(*User)
+	return user, ok && user != nil
+}
+
+type csrfTokenKey struct{}
+
+// NewCSRFContext returns a copy of ctx carrying the CSRF token of the session
+// a request runs in.
+func NewCSRFContext(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, csrfTokenKey{}, token)
+}
+
+// CSRFTokenFromContext returns the CSRF token carried by ctx, if any.
+func CSRFTokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(csrfTokenKey{}).(string)
+	return token, ok && token != ""
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Errorf("FromContext() ok = true for a context without user")
+	}
+	if _, ok := FromContext(NewContext(context.Background(), nil)); ok {
+		t.Errorf("FromContext() ok = true for a nil user")
+	}
+
+	want := &User{Id: 3, Name: "alice"}
+	got, ok := FromContext(NewContext(context.Background(), want))
+	if !ok || got != want {
+		t.Errorf("FromContext() = %v, %v, want %v, true", got, ok, want)
+	}
+}
+
+func TestCSRFTokenFromContext(t *testing.T) {
+	if _, ok := CSRFTokenFromContext(t.Context()); ok {
+		t.Errorf("CSRFTokenFromContext() ok = true for a context without token")
+	}
+	got, ok := CSRFTokenFromContext(NewCSRFContext(t.Context(), "token"))
+	if !ok || got != "token" {
+		t.Errorf("CSRFTokenFromContext() = %q, %v, want token, true", got, ok)
+	}
+}
+
+func TestRoleCan(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     Role
+		resource Resource
+		action   Action
+		want     bool
+	}{
+		{name: "viewer reads roasters", role: RoleViewer, resource: ResourceRoasters, action: ActionRead, want: true},
+		{name: "viewer cannot create shots", role: RoleViewer, resource: ResourceShots, action: ActionCreate, want: false},
+		{name: "barista updates roasters", role: RoleBarista, resource: ResourceRoasters, action: ActionUpdate, want: true},
+		{name: "barista cannot delete roasters", role: RoleBarista, resource: ResourceRoasters, action: ActionDelete, want: false},
+		{name: "barista deletes shots", role: RoleBarista, resource: ResourceShots, action: ActionDelete, want: true},
+		{name: "admin deletes roasters", role: RoleAdmin, resource: ResourceRoasters, action: ActionDelete, want: true},
+		{name: "admin cannot update reports", role: RoleAdmin, resource: ResourceReports, action: ActionUpdate, want: false},
+		{name: "viewer cannot read share links", role: RoleViewer, resource: ResourceShareLinks, action: ActionRead, want: false},
+		{name: "barista revokes share links", role: RoleBarista, resource: ResourceShareLinks, action: ActionDelete, want: true},
+		{name: "viewer reads attachments", role: RoleViewer, resource: ResourceAttachments, action: ActionRead, want: true},
+		{name: "viewer cannot upload attachments", role: RoleViewer, resource: ResourceAttachments, action: ActionCreate, want: false},
+		{name: "barista deletes attachments", role: RoleBarista, resource: ResourceAttachments, action: ActionDelete, want: true},
+		{name: "unknown role", role: "owner", resource: ResourceSheets, action: ActionRead, want: false},
+		{name: "unknown resource", role: RoleAdmin, resource: "users", action: ActionRead, want: false},
+		{name: "no action", role: RoleAdmin, resource: ResourceSheets, action: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.role.Can(tt.resource, tt.action); got != tt.want {
+				t.Errorf("Role(%q).Can(%q, %s) = %t, want %t", tt.role, tt.resource, tt.action, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoleIsValid(t *testing.T) {
+	for _, role := range Roles {
+		if !role.IsValid() {
+			t.Errorf("Role(%q).IsValid() = false, want true", role)
+		}
+	}
+	if Role("owner").IsValid() {
+		t.Errorf("Role(%q).IsValid() = true, want false", "owner")
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	if !Allowed(t.Context(), ResourceRoasters, ActionDelete) {
+		t.Errorf("Allowed() = false for an unauthenticated request")
+	}
+	viewer := NewContext(t.Context(), &User{Id: 1, Name: "guest", Role: RoleViewer})
+	if Allowed(viewer, ResourceRoasters, ActionDelete) {
+		t.Errorf("Allowed() = true for a viewer deleting a roaster")
+	}
+	if !Allowed(viewer, ResourceRoasters, ActionRead) {
+		t.Errorf("Allowed() = false for a viewer reading roasters")
+	}
+}
@@ -0,0 +1,216 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+)
+
+// leeway is the clock skew tolerated with the provider.
+const leeway = time.Minute
+
+// signingAlgorithms are the JWS algorithms accepted, with their hash.
+var signingAlgorithms = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+	"ES256": crypto.SHA256,
+	"ES384": crypto.SHA384,
+	"ES512": crypto.SHA512,
+}
+
+// Claims are the claims of a token the application relies on.
+type Claims struct {
+	Issuer          string
+	Subject         string
+	Audience        []string
+	AuthorizedParty string
+	ExpiresAt       time.Time
+	NotBefore       time.Time
+	IssuedAt        time.Time
+	Nonce           string
+
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+}
+
+// jwtClaims is the JSON payload of a token. aud is either a string or an
+// array of strings, and some providers send email_verified as a string.
+type jwtClaims struct {
+	Iss               string          `json:"iss"`
+	Sub               string          `json:"sub"`
+	Aud               json.RawMessage `json:"aud"`
+	Azp               string          `json:"azp"`
+	Exp               *float64        `json:"exp"`
+	Nbf               *float64        `json:"nbf"`
+	Iat               *float64        `json:"iat"`
+	Nonce             string          `json:"nonce"`
+	Email             string          `json:"email"`
+	EmailVerified     json.RawMessage `json:"email_verified"`
+	PreferredUsername string          `json:"preferred_username"`
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// verify checks the signature, issuer, audience and validity period of a
+// token and returns its claims. Any invalid token gives
+// errors.ErrOIDCTokenIsInvalid.
+func (p *Provider) verify(ctx context.Context, raw, audience string) (*Claims, error) {
+	invalid := func(format string, a ...any) error {
+		return fmt.Errorf("%w: %s", domainerrors.ErrOIDCTokenIsInvalid, fmt.Sprintf(format, a...))
+	}
+
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, invalid("malformed jwt")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, invalid("malformed jwt header")
+	}
+	hash, ok := signingAlgorithms[header.Alg]
+	if !ok {
+		return nil, invalid("unsupported signing algorithm %q", header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalid("malformed jwt signature")
+	}
+
+	key, err := p.keys.key(ctx, header.Kid)
+	if errors.Is(err, errKeyNotFound) {
+		return nil, invalid("no signing key matches kid %q", header.Kid)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, hash, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, invalid("%s", err)
+	}
+
+	var raws jwtClaims
+	if err := decodeSegment(parts[1], &raws); err != nil {
+		return nil, invalid("malformed jwt claims")
+	}
+	claims, err := raws.claims()
+	if err != nil {
+		return nil, invalid("%s", err)
+	}
+
+	t := now()
+	switch {
+	case claims.Issuer != p.metadata.Issuer:
+		return nil, invalid("issuer %q is not %q", claims.Issuer, p.metadata.Issuer)
+	case !slices.Contains(claims.Audience, audience):
+		return nil, invalid("audience %q does not contain %q", claims.Audience, audience)
+	case claims.Subject == "":
+		return nil, invalid("subject is empty")
+	case claims.ExpiresAt.IsZero():
+		return nil, invalid("expiration time is missing")
+	case !t.Before(claims.ExpiresAt.Add(leeway)):
+		return nil, invalid("token expired at %s", claims.ExpiresAt.Format(time.RFC3339))
+	case !claims.NotBefore.IsZero() && t.Before(claims.NotBefore.Add(-leeway)):
+		return nil, invalid("token is not valid before %s", claims.NotBefore.Format(time.RFC3339))
+	}
+	return claims, nil
+}
+
+func (c jwtClaims) claims() (*Claims, error) {
+	claims := &Claims{
+		Issuer:            c.Iss,
+		Subject:           c.Sub,
+		AuthorizedParty:   c.Azp,
+		Nonce:             c.Nonce,
+		Email:             c.Email,
+		PreferredUsername: c.PreferredUsername,
+		ExpiresAt:         numericDate(c.Exp),
+		NotBefore:         numericDate(c.Nbf),
+		IssuedAt:          numericDate(c.Iat),
+	}
+
+	if len(c.Aud) > 0 {
+		var single string
+		if err := json.Unmarshal(c.Aud, &single); err == nil {
+			claims.Audience = []string{single}
+		} else if err := json.Unmarshal(c.Aud, &claims.Audience); err != nil {
+			return nil, fmt.Errorf("malformed audience")
+		}
+	}
+
+	switch string(c.EmailVerified) {
+	case "true", `"true"`:
+		claims.EmailVerified = true
+	}
+	return claims, nil
+}
+
+// numericDate converts a JWT NumericDate, a number of seconds since the epoch.
+func numericDate(seconds *float64) time.Time {
+	if seconds == nil {
+		return time.Time{}
+	}
+	sec, frac := math.Modf(*seconds)
+	return time.Unix(int64(sec), int64(frac*float64(time.Second)))
+}
+
+func decodeSegment(segment string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// verifySignature checks the signature of the signed part of a token, with
+// the key of the type the algorithm requires.
+func verifySignature(alg string, hash crypto.Hash, key crypto.PublicKey, signed string, signature []byte) error {
+	h := hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("algorithm %s does not match a rsa key", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(key, hash, digest, signature); err != nil {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") {
+			return fmt.Errorf("algorithm %s does not match an ec key", alg)
+		}
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("invalid signature")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported key type %T", key)
+}
@@ -0,0 +1,234 @@
+// Package oidc implements the parts of OpenID Connect the application relies
+// on: provider discovery, the authorization code flow with PKCE, and the
+// validation of the JWTs signed by the provider, whose keys are fetched from
+// its JWKS endpoint and cached.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+)
+
+const (
+	// discoveryPath is appended to the issuer URL to get the provider metadata.
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// maxResponseSize limits the documents read from the provider.
+	maxResponseSize = 1 << 20
+)
+
+// DefaultScopes are the scopes requested when none are configured.
+var DefaultScopes = []string{"openid", "email", "profile"}
+
+// now is replaced in tests.
+var now = time.Now
+
+// Config configures a Provider.
+type Config struct {
+	// IssuerURL is the URL of the provider, as found in the iss claim of its
+	// tokens.
+	IssuerURL string
+
+	// ClientID and ClientSecret identify the application to the provider.
+	// ClientSecret is empty for public clients.
+	ClientID     string
+	ClientSecret string
+
+	// RedirectURL is the URL the provider sends the user back to, with an
+	// authorization code.
+	RedirectURL string
+
+	// Audience is the aud claim expected in the access tokens sent to the
+	// REST API. It defaults to ClientID.
+	Audience string
+
+	// Scopes are requested when logging in. They default to DefaultScopes.
+	Scopes []string
+}
+
+// metadata is the part of the provider metadata the application uses.
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider is an OpenID Connect provider the application is registered with.
+type Provider struct {
+	config   Config
+	metadata metadata
+	keys     *keySet
+	client   *http.Client
+}
+
+// New discovers the provider at config.IssuerURL. client is used for every
+// request to the provider; http.DefaultClient is used when it is nil.
+func New(ctx context.Context, config Config, client *http.Client) (*Provider, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if config.Audience == "" {
+		config.Audience = config.ClientID
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = DefaultScopes
+	}
+	if !slices.Contains(config.Scopes, "openid") {
+		config.Scopes = append([]string{"openid"}, config.Scopes...)
+	}
+
+	discoveryURL := strings.TrimSuffix(config.IssuerURL, "/") + discoveryPath
+	var md metadata
+	if err := getJSON(ctx, client, discoveryURL, &md); err != nil {
+		return nil, fmt.Errorf("failed to discover oidc provider %s: %w", config.IssuerURL, err)
+	}
+	if md.Issuer != config.IssuerURL {
+		return nil, fmt.Errorf("oidc provider issuer %q does not match the configured issuer %q", md.Issuer, config.IssuerURL)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc provider %s metadata lacks an authorization, token or jwks endpoint", config.IssuerURL)
+	}
+
+	return &Provider{
+		config:   config,
+		metadata: md,
+		keys:     newKeySet(md.JWKSURI, client),
+		client:   client,
+	}, nil
+}
+
+// AuthCodeURL returns the URL of the provider's login page. state and nonce
+// are checked back in the callback and the ID token, and codeChallenge is the
+// PKCE challenge of the verifier given to Exchange.
+func (p *Provider) AuthCodeURL(state, nonce, codeChallenge string) string {
+	v := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	sep := "?"
+	if strings.Contains(p.metadata.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.metadata.AuthorizationEndpoint + sep + v.Encode()
+}
+
+// Token is the response of the provider's token endpoint.
+type Token struct {
+	IDToken     string `json:"id_token"`
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// Exchange trades an authorization code for tokens. A code the provider
+// refuses gives errors.ErrOIDCLoginFailed.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (*Token, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"client_id":     {p.config.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oidc token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request oidc token: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read oidc token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var tokenErr struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		_ = json.Unmarshal(body, &tokenErr)
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+			return nil, fmt.Errorf("%w: token endpoint returned %s: %s %s", domainerrors.ErrOIDCLoginFailed, resp.Status, tokenErr.Error, tokenErr.Description)
+		}
+		return nil, fmt.Errorf("oidc token endpoint returned %s", resp.Status)
+	}
+
+	var token Token
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to decode oidc token response: %w", err)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: token response has no id_token", domainerrors.ErrOIDCLoginFailed)
+	}
+	return &token, nil
+}
+
+// VerifyIDToken validates an ID token issued to the application during the
+// login identified by nonce.
+func (p *Provider) VerifyIDToken(ctx context.Context, raw, nonce string) (*Claims, error) {
+	claims, err := p.verify(ctx, raw, p.config.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.config.ClientID {
+		return nil, fmt.Errorf("%w: authorized party %q is not the client", domainerrors.ErrOIDCTokenIsInvalid, claims.AuthorizedParty)
+	}
+	if nonce == "" || claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce does not match", domainerrors.ErrOIDCTokenIsInvalid)
+	}
+	return claims, nil
+}
+
+// VerifyAccessToken validates a JWT access token sent to the REST API.
+func (p *Provider) VerifyAccessToken(ctx context.Context, raw string) (*Claims, error) {
+	return p.verify(ctx, raw, p.config.Audience)
+}
+
+// getJSON decodes the JSON document at url into v.
+func getJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %s", url, resp.Status)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", url, err)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package oidc_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/auth/oidc"
+	"github.com/lescactus/espressoapi-go/internal/auth/oidc/oidctest"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+)
+
+const redirectURL = "https://espresso.example.com/login/oidc/callback"
+
+func newTestProvider(t *testing.T, mock *oidctest.Provider) *oidc.Provider {
+	t.Helper()
+	p, err := oidc.New(context.Background(), oidc.Config{
+		IssuerURL:    mock.Issuer(),
+		ClientID:     mock.ClientID,
+		ClientSecret: mock.ClientSecret,
+		RedirectURL:  redirectURL,
+	}, mock.Server.Client())
+	if err != nil {
+		t.Fatalf("oidc.New() error = %v", err)
+	}
+	return p
+}
+
+func TestNew_IssuerMismatch(t *testing.T) {
+	mock := oidctest.NewProvider(t, "espresso", "")
+
+	_, err := oidc.New(context.Background(), oidc.Config{
+		IssuerURL: mock.Issuer() + "/",
+		ClientID:  "espresso",
+	}, mock.Server.Client())
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("oidc.New() error = %v, want issuer mismatch", err)
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	mock := oidctest.NewProvider(t, "espresso", "")
+	p := newTestProvider(t, mock)
+
+	u, err := url.Parse(p.AuthCodeURL("the-state", "the-nonce", "the-challenge"))
+	if err != nil {
+		t.Fatalf("AuthCodeURL() is not a URL: %v", err)
+	}
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             "espresso",
+		"redirect_uri":          redirectURL,
+		"scope":                 "openid email profile",
+		"state":                 "the-state",
+		"nonce":                 "the-nonce",
+		"code_challenge":        "the-challenge",
+		"code_challenge_method": "S256",
+	}
+	for k, v := range want {
+		if got := u.Query().Get(k); got != v {
+			t.Errorf("AuthCodeURL() %s = %q, want %q", k, got, v)
+		}
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// RFC 7636, appendix B
+	got := oidc.CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"; got != want {
+		t.Errorf("CodeChallenge() = %q, want %q", got, want)
+	}
+}
+
+func TestLoginFlow(t *testing.T) {
+	tests := []struct {
+		name         string
+		clientSecret string
+		verifier     func(string) string
+		nonce        func(string) string
+		wantErr      error
+	}{
+		{name: "public client"},
+		{name: "confidential client", clientSecret: "s3cret"},
+		{
+			name:     "wrong code verifier",
+			verifier: func(string) string { return "another-verifier" },
+			wantErr:  domainerrors.ErrOIDCLoginFailed,
+		},
+		{
+			name:    "wrong nonce",
+			nonce:   func(string) string { return "another-nonce" },
+			wantErr: domainerrors.ErrOIDCTokenIsInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := oidctest.NewProvider(t, "espresso", tt.clientSecret)
+			mock.SetIdentity(oidctest.Identity{Subject: "sub-alice", Email: "alice@example.com", EmailVerified: true})
+			p := newTestProvider(t, mock)
+
+			state, _ := oidc.RandomString()
+			nonce, _ := oidc.RandomString()
+			verifier, _ := oidc.RandomString()
+
+			callback := mock.Authorize(t, p.AuthCodeURL(state, nonce, oidc.CodeChallenge(verifier)))
+			if got := callback.Query().Get("state"); got != state {
+				t.Fatalf("callback state = %q, want %q", got, state)
+			}
+
+			if tt.verifier != nil {
+				verifier = tt.verifier(verifier)
+			}
+			token, err := p.Exchange(context.Background(), callback.Query().Get("code"), verifier)
+			if err == nil {
+				if tt.nonce != nil {
+					nonce = tt.nonce(nonce)
+				}
+				var claims *oidc.Claims
+				claims, err = p.VerifyIDToken(context.Background(), token.IDToken, nonce)
+				if err == nil && (claims.Subject != "sub-alice" || claims.Email != "alice@example.com" || !claims.EmailVerified) {
+					t.Errorf("VerifyIDToken() = %+v, want alice's verified identity", claims)
+				}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("login error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyAccessToken(t *testing.T) {
+	mock := oidctest.NewProvider(t, "espresso", "")
+	other := oidctest.NewProvider(t, "espresso", "")
+	p := newTestProvider(t, mock)
+
+	with := func(changes map[string]any) map[string]any {
+		claims := mock.Claims("sub-alice")
+		for k, v := range changes {
+			if v == nil {
+				delete(claims, k)
+				continue
+			}
+			claims[k] = v
+		}
+		return claims
+	}
+	unsigned := func(alg string) string {
+		header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"` + alg + `","kid":"` + oidctest.KeyID + `"}`))
+		signed := mock.SignToken(mock.Claims("sub-alice"))
+		return header + "." + strings.Split(signed, ".")[1] + "."
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "valid", token: mock.SignToken(mock.Claims("sub-alice"))},
+		{name: "audience list", token: mock.SignToken(with(map[string]any{"aud": []string{"other", "espresso"}}))},
+		{name: "expired", token: mock.SignToken(with(map[string]any{"exp": time.Now().Add(-2 * time.Minute).Unix()})), wantErr: true},
+		{name: "expired within leeway", token: mock.SignToken(with(map[string]any{"exp": time.Now().Add(-30 * time.Second).Unix()}))},
+		{name: "no expiration", token: mock.SignToken(with(map[string]any{"exp": nil})), wantErr: true},
+		{name: "not yet valid", token: mock.SignToken(with(map[string]any{"nbf": time.Now().Add(time.Hour).Unix()})), wantErr: true},
+		{name: "other audience", token: mock.SignToken(with(map[string]any{"aud": "other"})), wantErr: true},
+		{name: "other issuer", token: mock.SignToken(with(map[string]any{"iss": "https://evil.example"})), wantErr: true},
+		{name: "no subject", token: mock.SignToken(with(map[string]any{"sub": ""})), wantErr: true},
+		{name: "signed by another key", token: other.SignToken(mock.Claims("sub-alice")), wantErr: true},
+		{name: "alg none", token: unsigned("none"), wantErr: true},
+		{name: "hmac alg", token: unsigned("HS256"), wantErr: true},
+		{name: "malformed", token: "not-a-jwt", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := p.VerifyAccessToken(context.Background(), tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, domainerrors.ErrOIDCTokenIsInvalid) {
+					t.Fatalf("VerifyAccessToken() error = %v, want %v", err, domainerrors.ErrOIDCTokenIsInvalid)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyAccessToken() error = %v", err)
+			}
+			if claims.Subject != "sub-alice" {
+				t.Errorf("VerifyAccessToken() subject = %q, want sub-alice", claims.Subject)
+			}
+		})
+	}
+}
+
+func TestVerifyAccessToken_CachesKeys(t *testing.T) {
+	mock := oidctest.NewProvider(t, "espresso", "")
+	p := newTestProvider(t, mock)
+	token := mock.SignToken(mock.Claims("sub-alice"))
+
+	for range 3 {
+		if _, err := p.VerifyAccessToken(context.Background(), token); err != nil {
+			t.Fatalf("VerifyAccessToken() error = %v", err)
+		}
+	}
+	if got := mock.JWKSRequests(); got != 1 {
+		t.Errorf("jwks requests = %d, want 1", got)
+	}
+
+	// A token signed by an unknown key does not fetch the keys again right away.
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","kid":"unknown"}`))
+	forged := header + token[strings.Index(token, "."):]
+	if _, err := p.VerifyAccessToken(context.Background(), forged); !errors.Is(err, domainerrors.ErrOIDCTokenIsInvalid) {
+		t.Fatalf("VerifyAccessToken() error = %v, want %v", err, domainerrors.ErrOIDCTokenIsInvalid)
+	}
+	if got := mock.JWKSRequests(); got != 1 {
+		t.Errorf("jwks requests after unknown kid = %d, want 1", got)
+	}
+}
@@ -0,0 +1,282 @@
+// Package oidctest provides an OpenID Connect provider served by a local
+// httptest server, to test the login flow and the validation of its tokens
+// without a real identity provider.
+package oidctest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// KeyID is the kid of the key the provider signs tokens with.
+const KeyID = "oidctest-key"
+
+// Identity is the user logging in to the provider.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+// authRequest is what an authorization code was issued for.
+type authRequest struct {
+	identity      Identity
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+}
+
+// Provider is an OpenID Connect provider supporting discovery, the
+// authorization code flow with PKCE (S256) and RS256-signed tokens.
+type Provider struct {
+	Server       *httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	key *rsa.PrivateKey
+
+	mu           sync.Mutex
+	identity     Identity
+	codes        map[string]authRequest
+	jwksRequests int
+}
+
+// NewProvider starts a provider which the client identified by clientID and
+// clientSecret is registered with. It is closed at the end of the test.
+func NewProvider(t testing.TB, clientID, clientSecret string) *Provider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate oidctest key: %v", err)
+	}
+
+	p := &Provider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		codes:        make(map[string]authRequest),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("GET /jwks", p.jwks)
+	mux.HandleFunc("GET /authorize", p.authorize)
+	mux.HandleFunc("POST /token", p.token)
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Server.Close)
+
+	return p
+}
+
+// Issuer returns the issuer URL of the provider.
+func (p *Provider) Issuer() string { return p.Server.URL }
+
+// SetIdentity sets who logs in at the next authorization requests.
+func (p *Provider) SetIdentity(identity Identity) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.identity = identity
+}
+
+// JWKSRequests returns how many times the keys of the provider were fetched.
+func (p *Provider) JWKSRequests() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.jwksRequests
+}
+
+// Authorize follows the provider's login page at authURL, as a browser would,
+// and returns the URL the provider redirects to.
+func (p *Provider) Authorize(t testing.TB, authURL string) *url.URL {
+	t.Helper()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("oidctest authorize: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("oidctest authorize: status = %d, want %d", resp.StatusCode, http.StatusFound)
+	}
+	location, err := resp.Location()
+	if err != nil {
+		t.Fatalf("oidctest authorize: %v", err)
+	}
+	return location
+}
+
+// SignToken returns a RS256 JWT of claims, signed with the provider key.
+func (p *Provider) SignToken(claims map[string]any) string {
+	return p.sign(map[string]any{"alg": "RS256", "typ": "JWT", "kid": KeyID}, claims)
+}
+
+// Claims returns valid claims for a token issued by the provider to its
+// client for subject, expiring in an hour.
+func (p *Provider) Claims(subject string) map[string]any {
+	now := time.Now()
+	return map[string]any{
+		"iss": p.Issuer(),
+		"sub": subject,
+		"aud": p.ClientID,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+}
+
+func (p *Provider) sign(header, claims map[string]any) string {
+	h, _ := json.Marshal(header)
+	c, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	if err != nil {
+		panic(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                p.Issuer(),
+		"authorization_endpoint":                p.Issuer() + "/authorize",
+		"token_endpoint":                        p.Issuer() + "/token",
+		"jwks_uri":                              p.Issuer() + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *Provider) jwks(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	p.jwksRequests++
+	p.mu.Unlock()
+
+	pub := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": KeyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// authorize logs the current identity in without any page, and redirects back
+// to the client with an authorization code.
+func (p *Provider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	switch {
+	case q.Get("client_id") != p.ClientID:
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	case q.Get("response_type") != "code":
+		http.Error(w, "unsupported response_type", http.StatusBadRequest)
+		return
+	case q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "":
+		http.Error(w, "pkce S256 code challenge required", http.StatusBadRequest)
+		return
+	case q.Get("redirect_uri") == "":
+		http.Error(w, "redirect_uri required", http.StatusBadRequest)
+		return
+	}
+
+	code := rand.Text()
+	p.mu.Lock()
+	p.codes[code] = authRequest{
+		identity:      p.identity,
+		redirectURI:   q.Get("redirect_uri"),
+		nonce:         q.Get("nonce"),
+		codeChallenge: q.Get("code_challenge"),
+	}
+	p.mu.Unlock()
+
+	redirect, _ := url.Parse(q.Get("redirect_uri"))
+	v := redirect.Query()
+	v.Set("code", code)
+	v.Set("state", q.Get("state"))
+	redirect.RawQuery = v.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// token exchanges an authorization code, once, for an ID token and a JWT
+// access token.
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+	if p.ClientSecret != "" {
+		id, secret, ok := r.BasicAuth()
+		id, _ = url.QueryUnescape(id)
+		secret, _ = url.QueryUnescape(secret)
+		if !ok || id != p.ClientID || subtle.ConstantTimeCompare([]byte(secret), []byte(p.ClientSecret)) != 1 {
+			writeTokenError(w, http.StatusUnauthorized, "invalid_client")
+			return
+		}
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeTokenError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	p.mu.Lock()
+	req, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	switch {
+	case !ok, req.redirectURI != r.PostForm.Get("redirect_uri"):
+		writeTokenError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	case base64.RawURLEncoding.EncodeToString(sum[:]) != req.codeChallenge:
+		writeTokenError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	idClaims := p.Claims(req.identity.Subject)
+	idClaims["nonce"] = req.nonce
+	if req.identity.Email != "" {
+		idClaims["email"] = req.identity.Email
+		idClaims["email_verified"] = req.identity.EmailVerified
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id_token":     p.SignToken(idClaims),
+		"access_token": p.SignToken(p.Claims(req.identity.Subject)),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	})
+}
+
+func writeTokenError(w http.ResponseWriter, status int, code string) {
+	writeJSON(w, status, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
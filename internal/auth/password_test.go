@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHashPassword(t *testing.T) {
+	passwordIterations = 1000
+	t.Cleanup(func() { passwordIterations = 600_000 })
+
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+	if !strings.HasPrefix(hash, "pbkdf2-sha256$1000$") {
+		t.Errorf("HashPassword() = %q, want a pbkdf2-sha256 hash with 1000 iterations", hash)
+	}
+	if other, _ := HashPassword("correct horse"); other == hash {
+		t.Errorf("HashPassword() returned the same hash twice, want a random salt")
+	}
+
+	if !CheckPassword(hash, "correct horse") {
+		t.Errorf("CheckPassword() = false for the right password")
+	}
+	if CheckPassword(hash, "battery staple") {
+		t.Errorf("CheckPassword() = true for a wrong password")
+	}
+}
+
+func TestCheckPasswordMalformedHash(t *testing.T) {
+	for _, encoded := range []string{
+		"",
+		"correct horse",
+		"bcrypt$1000$c2FsdA$a2V5",
+		"pbkdf2-sha256$-1$c2FsdA$a2V5",
+		"pbkdf2-sha256$1000$not base64$a2V5",
+		"pbkdf2-sha256$1000$c2FsdA$",
+	} {
+		if CheckPassword(encoded, "correct horse") {
+			t.Errorf("CheckPassword(%q) = true, want false", encoded)
+		}
+	}
+}
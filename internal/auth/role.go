@@ -0,0 +1,137 @@
+package auth
+
+import "context"
+
+// Role is what a user is allowed to do, according to the permission matrix.
+type Role string
+
+const (
+	// RoleViewer may only read.
+	RoleViewer Role = "viewer"
+	// RoleBarista may read everything, and create and update everything but
+	// only delete shots and cuppings.
+	RoleBarista Role = "barista"
+	// RoleAdmin may do everything.
+	RoleAdmin Role = "admin"
+)
+
+// Roles lists the roles, from the least to the most privileged.
+var Roles = []Role{RoleViewer, RoleBarista, RoleAdmin}
+
+// IsValid reports whether r is a known role.
+func (r Role) IsValid() bool {
+	_, ok := permissions[r]
+	return ok
+}
+
+// Resource is a kind of record permissions are granted on.
+type Resource string
+
+const (
+	ResourceSheets           Resource = "sheets"
+	ResourceRoasters         Resource = "roasters"
+	ResourceBeans            Resource = "beans"
+	ResourceShots            Resource = "shots"
+	ResourceCuppings         Resource = "cuppings"
+	ResourceRoastBatches     Resource = "roast_batches"
+	ResourceGreenCoffees     Resource = "green_coffees"
+	ResourceMaintenanceTasks Resource = "maintenance_tasks"
+	ResourceReports          Resource = "reports"
+	ResourceStats            Resource = "stats"
+	ResourceShareLinks       Resource = "share_links"
+	ResourceAttachments      Resource = "attachments"
+)
+
+// Action is a verb permissions are granted for. Actions are bit flags so that
+// the permission matrix can grant several at once.
+type Action uint8
+
+const (
+	ActionRead Action = 1 << iota
+	ActionCreate
+	ActionUpdate
+	ActionDelete
+)
+
+const (
+	readOnly  = ActionRead
+	readWrite = ActionRead | ActionCreate | ActionUpdate
+	all       = ActionRead | ActionCreate | ActionUpdate | ActionDelete
+)
+
+// String returns the name of the action.
+func (a Action) String() string {
+	switch a {
+	case ActionRead:
+		return "read"
+	case ActionCreate:
+		return "create"
+	case ActionUpdate:
+		return "update"
+	case ActionDelete:
+		return "delete"
+	}
+	return "unknown"
+}
+
+// permissions is the permission matrix: the actions each role is granted per
+// resource. Resources missing from a role grant it nothing.
+var permissions = map[Role]map[Resource]Action{
+	RoleViewer: {
+		ResourceSheets:           readOnly,
+		ResourceRoasters:         readOnly,
+		ResourceBeans:            readOnly,
+		ResourceShots:            readOnly,
+		ResourceCuppings:         readOnly,
+		ResourceRoastBatches:     readOnly,
+		ResourceGreenCoffees:     readOnly,
+		ResourceMaintenanceTasks: readOnly,
+		ResourceReports:          readOnly,
+		ResourceStats:            readOnly,
+		ResourceAttachments:      readOnly,
+	},
+	RoleBarista: {
+		ResourceSheets:           readWrite,
+		ResourceRoasters:         readWrite,
+		ResourceBeans:            readWrite,
+		ResourceShots:            all,
+		ResourceCuppings:         all,
+		ResourceRoastBatches:     readWrite,
+		ResourceGreenCoffees:     readWrite,
+		ResourceMaintenanceTasks: readWrite,
+		ResourceReports:          readOnly,
+		ResourceStats:            readOnly,
+		ResourceShareLinks:       all,
+		ResourceAttachments:      all,
+	},
+	RoleAdmin: {
+		ResourceSheets:           all,
+		ResourceRoasters:         all,
+		ResourceBeans:            all,
+		ResourceShots:            all,
+		ResourceCuppings:         all,
+		ResourceRoastBatches:     all,
+		ResourceGreenCoffees:     all,
+		ResourceMaintenanceTasks: all,
+		ResourceReports:          readOnly,
+		ResourceStats:            readOnly,
+		ResourceShareLinks:       all,
+		ResourceAttachments:      all,
+	},
+}
+
+// Can reports whether r is granted action on resource.
+func (r Role) Can(resource Resource, action Action) bool {
+	return permissions[r][resource]&action == action && action != 0
+}
+
+// Allowed reports whether the request ctx belongs to may perform action on
+// resource. Unauthenticated requests, only served when authentication is
+// disabled, are allowed everything.
+func Allowed(ctx context.Context, resource Resource, action Action) bool {
+	user, ok := FromContext(ctx)
+	if !ok {
+		return true
+	}
+	return user.Role.Can(resource, action)
+}
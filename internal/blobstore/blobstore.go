@@ -0,0 +1,40 @@
+// Package blobstore defines where the binary content of attachments is kept.
+// Rows in the database only hold the keys of their blobs.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrBlobDoesNotExist is returned when no blob is stored under a key.
+var ErrBlobDoesNotExist = errors.New("blob does not exist")
+
+// Store keeps blobs by key. Keys are slash separated relative paths, such as
+// "attachments/<random>", and are chosen by the caller.
+type Store interface {
+	// Put stores data under key, replacing any blob already stored there.
+	Put(ctx context.Context, key, contentType string, data []byte) error
+
+	// Get returns a reader of the blob stored under key, to be closed by the
+	// caller, or ErrBlobDoesNotExist.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the blob stored under key. Deleting a missing blob is
+	// not an error.
+	Delete(ctx context.Context, key string) error
+
+	// List returns the blobs whose key starts with prefix, in no particular
+	// order.
+	List(ctx context.Context, prefix string) ([]Blob, error)
+}
+
+// Blob describes a stored blob.
+type Blob struct {
+	Key string
+
+	// The last time the blob was written
+	ModTime time.Time
+}
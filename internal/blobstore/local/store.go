@@ -0,0 +1,129 @@
+// Package local implements a blobstore.Store on the local filesystem.
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lescactus/espressoapi-go/internal/blobstore"
+)
+
+var _ blobstore.Store = (*Store)(nil)
+
+// Store keeps every blob in a file under its root directory, at the path of
+// its key.
+type Store struct {
+	root string
+}
+
+// New returns a store under the directory root, which is created when it does
+// not exist.
+func New(root string) (*Store, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob store directory %q: %w", root, err)
+	}
+	return &Store{root: root}, nil
+}
+
+// Put writes data to a temporary file renamed to the path of key, so a blob is
+// never read half written.
+func (s *Store) Put(ctx context.Context, key, contentType string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory of blob %q: %w", key, err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create blob %q: %w", key, err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write blob %q: %w", key, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write blob %q: %w", key, err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to write blob %q: %w", key, err)
+	}
+	return nil
+}
+
+func (s *Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, blobstore.ErrBlobDoesNotExist
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob %q: %w", key, err)
+	}
+	return f, nil
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete blob %q: %w", key, err)
+	}
+	return nil
+}
+
+// List walks the whole root directory, leaving out the temporary files of the
+// blobs being written.
+func (s *Store) List(ctx context.Context, prefix string) ([]blobstore.Blob, error) {
+	blobs := make([]blobstore.Blob, 0)
+	err := filepath.WalkDir(s.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		blobs = append(blobs, blobstore.Blob{Key: key, ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blobs with prefix %q: %w", prefix, err)
+	}
+	return blobs, nil
+}
+
+// path returns the path of the file of key, which must stay under the root
+// directory.
+func (s *Store) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
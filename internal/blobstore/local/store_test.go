@@ -0,0 +1,100 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/lescactus/espressoapi-go/internal/blobstore"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	root := filepath.Join(t.TempDir(), "blobs")
+	s, err := New(root)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := s.Put(ctx, "attachments/a", "image/png", []byte("first")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := s.Put(ctx, "attachments/a", "image/png", []byte("second")); err != nil {
+		t.Fatalf("Put() replacing a blob error = %v", err)
+	}
+
+	r, err := s.Get(ctx, "attachments/a")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	r.Close()
+	if string(data) != "second" {
+		t.Errorf("Get() = %q, want %q", data, "second")
+	}
+
+	entries, _ := os.ReadDir(filepath.Join(root, "attachments"))
+	if len(entries) != 1 {
+		t.Errorf("expected no temporary file left behind, got %d files", len(entries))
+	}
+
+	if err := s.Delete(ctx, "attachments/a"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := s.Delete(ctx, "attachments/a"); err != nil {
+		t.Errorf("Delete() of a missing blob error = %v, want nil", err)
+	}
+	if _, err := s.Get(ctx, "attachments/a"); !errors.Is(err, blobstore.ErrBlobDoesNotExist) {
+		t.Errorf("Get() of a deleted blob error = %v, want %v", err, blobstore.ErrBlobDoesNotExist)
+	}
+}
+
+func TestStore_RejectsKeysOutsideOfRoot(t *testing.T) {
+	s, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for _, key := range []string{"../escape", "/etc/passwd", "attachments/../../escape", ""} {
+		if err := s.Put(context.Background(), key, "image/png", []byte("x")); err == nil {
+			t.Errorf("Put(%q) error = nil, want an invalid key error", key)
+		}
+	}
+}
+
+func TestStore_List(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	s, err := New(root)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	for _, key := range []string{"attachments/a", "attachments/a-thumbnail", "other/b"} {
+		if err := s.Put(ctx, key, "image/png", []byte("x")); err != nil {
+			t.Fatalf("Put(%q) error = %v", key, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "attachments", ".tmp-123"), []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	blobs, err := s.List(ctx, "attachments/")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	keys := make([]string, 0, len(blobs))
+	for _, b := range blobs {
+		if b.ModTime.IsZero() {
+			t.Errorf("List() blob %q has no modification time", b.Key)
+		}
+		keys = append(keys, b.Key)
+	}
+	slices.Sort(keys)
+	if want := []string{"attachments/a", "attachments/a-thumbnail"}; !slices.Equal(keys, want) {
+		t.Errorf("List() keys = %v, want %v", keys, want)
+	}
+}
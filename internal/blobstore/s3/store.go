@@ -0,0 +1,219 @@
+// Package s3 implements a blobstore.Store on an S3-compatible object storage,
+// such as Amazon S3, MinIO or Garage. Requests are signed with AWS Signature
+// Version 4.
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/blobstore"
+)
+
+var _ blobstore.Store = (*Store)(nil)
+
+// Config locates a bucket and holds the credentials to access it.
+type Config struct {
+	// URL of the S3 API, such as https://s3.eu-west-3.amazonaws.com or
+	// http://127.0.0.1:9000 for a local MinIO
+	Endpoint string
+
+	// Region of the bucket, us-east-1 for most S3-compatible storages
+	Region string
+
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// Whether the bucket is addressed in the path of the URLs rather than in
+	// their host name, as MinIO expects by default
+	PathStyle bool
+}
+
+// Store keeps every blob in an object of the bucket, named after its key.
+type Store struct {
+	cfg      Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// now is replaced in tests.
+var now = time.Now
+
+// New returns a store of the bucket of cfg, sending its requests with client.
+func New(cfg Config, client *http.Client) (*Store, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || !endpoint.IsAbs() || endpoint.Host == "" {
+		return nil, fmt.Errorf("s3 endpoint %q must be an absolute url", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("s3 bucket cannot be empty")
+	}
+	return &Store{cfg: cfg, endpoint: endpoint, client: client}, nil
+}
+
+func (s *Store) Put(ctx context.Context, key, contentType string, data []byte) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.do(req, data)
+	if err != nil {
+		return fmt.Errorf("failed to put blob %q: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to put blob %q: %w", key, responseError(resp))
+	}
+	return nil
+}
+
+func (s *Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob %q: %w", key, err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, blobstore.ErrBlobDoesNotExist
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("failed to get blob %q: %w", key, responseError(resp))
+	}
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete blob %q: %w", key, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("failed to delete blob %q: %w", key, responseError(resp))
+	}
+}
+
+// List pages through the objects of the bucket with ListObjectsV2.
+func (s *Store) List(ctx context.Context, prefix string) ([]blobstore.Blob, error) {
+	blobs := make([]blobstore.Blob, 0)
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL("")+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := s.listPage(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blobs with prefix %q: %w", prefix, err)
+		}
+		for _, object := range page.Contents {
+			blobs = append(blobs, blobstore.Blob{Key: object.Key, ModTime: object.LastModified})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return blobs, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// listPage is a page of the ListObjectsV2 response.
+type listPage struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *Store) listPage(req *http.Request) (*listPage, error) {
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+	var page listPage
+	if err := xml.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode list response: %w", err)
+	}
+	return &page, nil
+}
+
+// newRequest returns a request for the object of key.
+func (s *Store) newRequest(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return nil, fmt.Errorf("invalid blob key %q", key)
+	}
+	return http.NewRequestWithContext(ctx, method, s.objectURL(key), bytes.NewReader(body))
+}
+
+// objectURL returns the URL of the object of key, the bucket being either in
+// the path or in the host name of the endpoint.
+func (s *Store) objectURL(key string) string {
+	u := *s.endpoint
+	path := strings.TrimSuffix(u.Path, "/")
+	if s.cfg.PathStyle {
+		path += "/" + uriEncode(s.cfg.Bucket, false)
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+	}
+	u.Path = ""
+	u.RawPath = ""
+	return u.String() + path + "/" + uriEncode(key, true)
+}
+
+// do signs and sends req, whose body is payload.
+func (s *Store) do(req *http.Request, payload []byte) (*http.Response, error) {
+	hash := sha256.Sum256(payload)
+	s.sign(req, hex.EncodeToString(hash[:]), now())
+	return s.client.Do(req)
+}
+
+// responseError returns the error of an S3 error response, whose body is an
+// XML document with the code and the message of the error.
+func responseError(resp *http.Response) error {
+	var body struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err := xml.Unmarshal(data, &body); err != nil || body.Code == "" {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return fmt.Errorf("unexpected status %d: %s: %s", resp.StatusCode, body.Code, body.Message)
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -18,6 +19,13 @@ var (
 	DatabaseTypePostgres DatabaseType = "postgres"
 )
 
+type BlobStoreType string
+
+var (
+	BlobStoreTypeLocal BlobStoreType = "local"
+	BlobStoreTypeS3    BlobStoreType = "s3"
+)
+
 const (
 	// Name of the application
 	AppName = "espressoapi-go"
@@ -40,6 +48,16 @@ var (
 
 	defaultDatabaseType           = DatabaseTypeMySQL
 	defaultDatabaseDatasourceName = "root:root@tcp(127.0.0.1:3306)/espresso-api?parseTime=true"
+
+	defaultAuthEnabled = true
+
+	defaultOIDCScopes = "openid email profile"
+
+	minShareLinkSecretLength = 32
+
+	defaultBlobStoreType     = BlobStoreTypeLocal
+	defaultBlobStoreDir      = "data/blobs"
+	defaultBlobStoreS3Region = "us-east-1"
 )
 
 type App struct {
@@ -79,6 +97,60 @@ type App struct {
 	// - https://github.com/go-sql-driver/mysql/#usage for mysql syntax
 	// - https://github.com/jackc/pgx for postgres syntax
 	DatabaseDatasourceName string `json:"database_datasource_name" yaml:"database_datasource_name" mapstructure:"DATABASE_DATASOURCE_NAME"`
+
+	// Whether requests to /rest/v1/* must carry a valid api key
+	AuthEnabled bool `json:"auth_enabled" yaml:"auth_enabled" mapstructure:"AUTH_ENABLED"`
+
+	// URL of the OpenID Connect provider, as found in the iss claim of its tokens
+	// Leave empty to disable single sign-on
+	OIDCIssuerURL string `json:"oidc_issuer_url" yaml:"oidc_issuer_url" mapstructure:"OIDC_ISSUER_URL"`
+
+	// Client id and secret registered with the OpenID Connect provider
+	// The secret is left empty for a public client
+	OIDCClientID     string `json:"oidc_client_id" yaml:"oidc_client_id" mapstructure:"OIDC_CLIENT_ID"`
+	OIDCClientSecret string `json:"oidc_client_secret" yaml:"oidc_client_secret" mapstructure:"OIDC_CLIENT_SECRET"`
+
+	// URL the OpenID Connect provider redirects to after logging in
+	// Must be the /login/oidc/callback route of the web UI, for example https://espresso.example.com/login/oidc/callback
+	OIDCRedirectURL string `json:"oidc_redirect_url" yaml:"oidc_redirect_url" mapstructure:"OIDC_REDIRECT_URL"`
+
+	// Audience of the JWT access tokens accepted by the REST API
+	// Defaults to the client id
+	OIDCAudience string `json:"oidc_audience" yaml:"oidc_audience" mapstructure:"OIDC_AUDIENCE"`
+
+	// Space separated scopes requested when logging in
+	OIDCScopes string `json:"oidc_scopes" yaml:"oidc_scopes" mapstructure:"OIDC_SCOPES"`
+
+	// Secret signing the tokens of the sheet share links, at least 32 characters long
+	// Leave empty to generate one at startup, in which case share links do not survive a restart
+	ShareLinkSecret string `json:"share_link_secret" yaml:"share_link_secret" mapstructure:"SHARE_LINK_SECRET"`
+
+	// Name of the store keeping the files of the attachments
+	// Available: "local", "s3"
+	BlobStoreType BlobStoreType `json:"blob_store_type" yaml:"blob_store_type" mapstructure:"BLOB_STORE_TYPE"`
+
+	// Directory of the local blob store
+	BlobStoreDir string `json:"blob_store_dir" yaml:"blob_store_dir" mapstructure:"BLOB_STORE_DIR"`
+
+	// URL of the S3-compatible API of the s3 blob store, for example https://s3.eu-west-3.amazonaws.com
+	BlobStoreS3Endpoint string `json:"blob_store_s3_endpoint" yaml:"blob_store_s3_endpoint" mapstructure:"BLOB_STORE_S3_ENDPOINT"`
+
+	// Region and name of the bucket of the s3 blob store
+	BlobStoreS3Region string `json:"blob_store_s3_region" yaml:"blob_store_s3_region" mapstructure:"BLOB_STORE_S3_REGION"`
+	BlobStoreS3Bucket string `json:"blob_store_s3_bucket" yaml:"blob_store_s3_bucket" mapstructure:"BLOB_STORE_S3_BUCKET"`
+
+	// Credentials of the s3 blob store
+	BlobStoreS3AccessKeyID     string `json:"blob_store_s3_access_key_id" yaml:"blob_store_s3_access_key_id" mapstructure:"BLOB_STORE_S3_ACCESS_KEY_ID"`
+	BlobStoreS3SecretAccessKey string `json:"blob_store_s3_secret_access_key" yaml:"blob_store_s3_secret_access_key" mapstructure:"BLOB_STORE_S3_SECRET_ACCESS_KEY"`
+
+	// Whether the bucket is addressed in the path of the urls rather than in their host name, as MinIO expects
+	BlobStoreS3PathStyle bool `json:"blob_store_s3_path_style" yaml:"blob_store_s3_path_style" mapstructure:"BLOB_STORE_S3_PATH_STYLE"`
+}
+
+// OIDCEnabled reports whether single sign-on with an OpenID Connect provider
+// is configured.
+func (app *App) OIDCEnabled() bool {
+	return app.OIDCIssuerURL != ""
 }
 
 // New will retrieve the runtime configuration from either
@@ -143,6 +215,37 @@ func (app *App) validateConfig() error {
 		return fmt.Errorf("database datasource name cannot be empty")
 	}
 
+	if app.OIDCEnabled() {
+		if strings.TrimSpace(app.OIDCClientID) == "" {
+			return fmt.Errorf("oidc client id cannot be empty when an oidc issuer url is set")
+		}
+		for _, value := range []string{app.OIDCIssuerURL, app.OIDCRedirectURL} {
+			if u, err := url.Parse(value); err != nil || !u.IsAbs() || u.Host == "" {
+				return fmt.Errorf("oidc url %q must be an absolute url", value)
+			}
+		}
+	}
+
+	if app.ShareLinkSecret != "" && len(app.ShareLinkSecret) < minShareLinkSecretLength {
+		return fmt.Errorf("share link secret must be at least %d characters long", minShareLinkSecretLength)
+	}
+
+	switch app.BlobStoreType {
+	case "", BlobStoreTypeLocal:
+	case BlobStoreTypeS3:
+		if u, err := url.Parse(app.BlobStoreS3Endpoint); err != nil || !u.IsAbs() || u.Host == "" {
+			return fmt.Errorf("blob store s3 endpoint %q must be an absolute url", app.BlobStoreS3Endpoint)
+		}
+		if strings.TrimSpace(app.BlobStoreS3Bucket) == "" {
+			return fmt.Errorf("blob store s3 bucket cannot be empty")
+		}
+		if app.BlobStoreS3AccessKeyID == "" || app.BlobStoreS3SecretAccessKey == "" {
+			return fmt.Errorf("blob store s3 access key id and secret access key cannot be empty")
+		}
+	default:
+		return fmt.Errorf("unsupported blob store type %q", app.BlobStoreType)
+	}
+
 	return nil
 }
 
@@ -184,4 +287,12 @@ func (config *App) setDefaults() {
 
 	config.DatabaseType = defaultDatabaseType
 	config.DatabaseDatasourceName = defaultDatabaseDatasourceName
+
+	config.AuthEnabled = defaultAuthEnabled
+
+	config.OIDCScopes = defaultOIDCScopes
+
+	config.BlobStoreType = defaultBlobStoreType
+	config.BlobStoreDir = defaultBlobStoreDir
+	config.BlobStoreS3Region = defaultBlobStoreS3Region
 }
@@ -43,6 +43,21 @@ func TestNewWithDefaults(t *testing.T) {
 	if string(config.DatabaseDatasourceName) != string(defaultDatabaseDatasourceName) {
 		t.Errorf("Expected LoggerFormat to be %s, got %s", defaultDatabaseDatasourceName, config.DatabaseDatasourceName)
 	}
+	if config.AuthEnabled != defaultAuthEnabled {
+		t.Errorf("Expected AuthEnabled to be %v, got %v", defaultAuthEnabled, config.AuthEnabled)
+	}
+	if config.OIDCEnabled() {
+		t.Errorf("Expected OIDC to be disabled, got issuer %q", config.OIDCIssuerURL)
+	}
+	if config.OIDCScopes != defaultOIDCScopes {
+		t.Errorf("Expected OIDCScopes to be %s, got %s", defaultOIDCScopes, config.OIDCScopes)
+	}
+	if config.BlobStoreType != defaultBlobStoreType {
+		t.Errorf("Expected BlobStoreType to be %s, got %s", defaultBlobStoreType, config.BlobStoreType)
+	}
+	if config.BlobStoreDir != defaultBlobStoreDir {
+		t.Errorf("Expected BlobStoreDir to be %s, got %s", defaultBlobStoreDir, config.BlobStoreDir)
+	}
 }
 
 func TestNewWithConfigFile(t *testing.T) {
@@ -210,6 +225,103 @@ func TestAppValidateConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid oidc configuration",
+			app: App{
+				DatabaseType:           DatabaseTypeMySQL,
+				DatabaseDatasourceName: defaultDatabaseDatasourceName,
+				OIDCIssuerURL:          "https://accounts.example.com",
+				OIDCClientID:           "espresso",
+				OIDCRedirectURL:        "https://espresso.example.com/login/oidc/callback",
+			},
+			wantErr: false,
+		},
+		{
+			name: "oidc without client id",
+			app: App{
+				DatabaseType:           DatabaseTypeMySQL,
+				DatabaseDatasourceName: defaultDatabaseDatasourceName,
+				OIDCIssuerURL:          "https://accounts.example.com",
+				OIDCRedirectURL:        "https://espresso.example.com/login/oidc/callback",
+			},
+			wantErr: true,
+		},
+		{
+			name: "oidc with relative redirect url",
+			app: App{
+				DatabaseType:           DatabaseTypeMySQL,
+				DatabaseDatasourceName: defaultDatabaseDatasourceName,
+				OIDCIssuerURL:          "https://accounts.example.com",
+				OIDCClientID:           "espresso",
+				OIDCRedirectURL:        "/login/oidc/callback",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid share link secret",
+			app: App{
+				DatabaseType:           DatabaseTypeMySQL,
+				DatabaseDatasourceName: defaultDatabaseDatasourceName,
+				ShareLinkSecret:        "0123456789abcdef0123456789abcdef",
+			},
+			wantErr: false,
+		},
+		{
+			name: "share link secret too short",
+			app: App{
+				DatabaseType:           DatabaseTypeMySQL,
+				DatabaseDatasourceName: defaultDatabaseDatasourceName,
+				ShareLinkSecret:        "secret",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid s3 blob store",
+			app: App{
+				DatabaseType:               DatabaseTypeMySQL,
+				DatabaseDatasourceName:     defaultDatabaseDatasourceName,
+				BlobStoreType:              BlobStoreTypeS3,
+				BlobStoreS3Endpoint:        "http://127.0.0.1:9000",
+				BlobStoreS3Bucket:          "espresso",
+				BlobStoreS3AccessKeyID:     "minioadmin",
+				BlobStoreS3SecretAccessKey: "minioadmin",
+			},
+			wantErr: false,
+		},
+		{
+			name: "s3 blob store without bucket",
+			app: App{
+				DatabaseType:               DatabaseTypeMySQL,
+				DatabaseDatasourceName:     defaultDatabaseDatasourceName,
+				BlobStoreType:              BlobStoreTypeS3,
+				BlobStoreS3Endpoint:        "http://127.0.0.1:9000",
+				BlobStoreS3AccessKeyID:     "minioadmin",
+				BlobStoreS3SecretAccessKey: "minioadmin",
+			},
+			wantErr: true,
+		},
+		{
+			name: "s3 blob store with relative endpoint",
+			app: App{
+				DatabaseType:               DatabaseTypeMySQL,
+				DatabaseDatasourceName:     defaultDatabaseDatasourceName,
+				BlobStoreType:              BlobStoreTypeS3,
+				BlobStoreS3Endpoint:        "127.0.0.1:9000",
+				BlobStoreS3Bucket:          "espresso",
+				BlobStoreS3AccessKeyID:     "minioadmin",
+				BlobStoreS3SecretAccessKey: "minioadmin",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported blob store type",
+			app: App{
+				DatabaseType:           DatabaseTypeMySQL,
+				DatabaseDatasourceName: defaultDatabaseDatasourceName,
+				BlobStoreType:          "gcs",
+			},
+			wantErr: true,
+		},
 		{
 			name: "empty database type",
 			app: App{
@@ -0,0 +1,482 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/lescactus/espressoapi-go/internal/services/attachment"
+	"github.com/rs/zerolog/hlog"
+)
+
+// uploadFormField is the name of the multipart form field holding the
+// uploaded file.
+const uploadFormField = "file"
+
+var (
+	ErrUploadIsNotMultipart = NewErrorResponse(http.StatusUnsupportedMediaType, "Content-Type header is not multipart/form-data")
+	ErrUploadIsMalformed    = NewErrorResponse(http.StatusBadRequest, "request body is not a valid multipart form")
+	ErrUploadFileIsMissing  = NewErrorResponse(http.StatusBadRequest, "request body must contain a file in the \"file\" field")
+)
+
+// swagger:parameters createShotAttachment createBeansAttachment
+type AttachmentUploadParams struct {
+	// The JPEG, PNG or GIF image to attach. Its type is sniffed from its
+	// content, whatever the client declares.
+	// in: formData
+	// required: true
+	// swagger:file
+	File []byte `json:"file"`
+}
+
+// AttachmentResponse represents a photo attached to a shot or beans
+//
+// Its content is served by GET /rest/v1/attachments/{id}/content, and a JPEG
+// thumbnail fitting in 320x320 pixels by GET /rest/v1/attachments/{id}/thumbnail.
+//
+// swagger:response AttachmentResponse
+type AttachmentResponse struct {
+	// swagger:allOf
+	attachment.Attachment
+}
+
+// swagger:route POST /rest/v1/shots/{id}/attachments attachments createShotAttachment
+//
+// # Attach a photo to a shot
+//
+// This will attach the uploaded image to the shot with the given id. The request body is limited by the server maximum request size.
+//
+//	Consumes:
+//	- multipart/form-data
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the shot
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  201: AttachmentResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+//	  413: ErrorResponse
+//	  415: ErrorResponse
+func (h *Handler) CreateShotAttachment(w http.ResponseWriter, r *http.Request) {
+	h.createAttachment(w, r, h.AttachmentService.CreateShotAttachment)
+}
+
+// swagger:route POST /rest/v1/beans/{id}/attachments attachments createBeansAttachment
+//
+// # Attach a photo to beans
+//
+// This will attach the uploaded image to the beans with the given id. The request body is limited by the server maximum request size.
+//
+//	Consumes:
+//	- multipart/form-data
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the beans
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  201: AttachmentResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+//	  413: ErrorResponse
+//	  415: ErrorResponse
+func (h *Handler) CreateBeansAttachment(w http.ResponseWriter, r *http.Request) {
+	h.createAttachment(w, r, h.AttachmentService.CreateBeansAttachment)
+}
+
+// createAttachment attaches the uploaded file to the record whose id is in the
+// path, with create.
+func (h *Handler) createAttachment(w http.ResponseWriter, r *http.Request, create func(ctx context.Context, id int, filename string, data []byte) (*attachment.Attachment, error)) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	filename, data, err := readUploadedFile(r)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	created, err := create(r.Context(), id, filename, data)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("attachment_id", created.Id).Msg("attachment successfully created")
+
+	h.writeJSONResponse(w, http.StatusCreated, AttachmentResponse{*created})
+}
+
+// swagger:route GET /rest/v1/shots/{id}/attachments attachments getAttachmentsByShotId
+//
+// # Get the attachments of a shot
+//
+// This will show all attachments of the shot with the given id.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the shot
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: AttachmentResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetAttachmentsByShotId(w http.ResponseWriter, r *http.Request) {
+	h.getAttachments(w, r, h.AttachmentService.GetAttachmentsByShotId)
+}
+
+// swagger:route GET /rest/v1/beans/{id}/attachments attachments getAttachmentsByBeansId
+//
+// # Get the attachments of beans
+//
+// This will show all attachments of the beans with the given id.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the beans
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: AttachmentResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetAttachmentsByBeansId(w http.ResponseWriter, r *http.Request) {
+	h.getAttachments(w, r, h.AttachmentService.GetAttachmentsByBeansId)
+}
+
+func (h *Handler) getAttachments(w http.ResponseWriter, r *http.Request, get func(ctx context.Context, id int) ([]attachment.Attachment, error)) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	attachments, err := get(r.Context(), id)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	resp := make([]AttachmentResponse, len(attachments))
+	for k, v := range attachments {
+		resp[k] = AttachmentResponse{v}
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, &resp)
+}
+
+// swagger:route GET /rest/v1/attachments/{id} attachments getAttachmentById
+//
+// # Get an attachment by id
+//
+// This will show the details of the attachment with the given id.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the attachment
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: AttachmentResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetAttachmentById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	a, err := h.AttachmentService.GetAttachmentById(r.Context(), id)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, AttachmentResponse{*a})
+}
+
+// swagger:route GET /rest/v1/attachments/{id}/content attachments getAttachmentContent
+//
+// # Get the content of an attachment
+//
+// This will return the uploaded image of the attachment with the given id.
+//
+//	Produces:
+//	- image/jpeg
+//	- image/png
+//	- image/gif
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the attachment
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: description: The image
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetAttachmentContent(w http.ResponseWriter, r *http.Request) {
+	h.getAttachmentContent(w, r, false)
+}
+
+// swagger:route GET /rest/v1/attachments/{id}/thumbnail attachments getAttachmentThumbnail
+//
+// # Get the thumbnail of an attachment
+//
+// This will return a JPEG thumbnail, fitting in 320x320 pixels, of the attachment with the given id.
+//
+//	Produces:
+//	- image/jpeg
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the attachment
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: description: The thumbnail
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetAttachmentThumbnail(w http.ResponseWriter, r *http.Request) {
+	h.getAttachmentContent(w, r, true)
+}
+
+func (h *Handler) getAttachmentContent(w http.ResponseWriter, r *http.Request, thumbnail bool) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	content, err := h.AttachmentService.GetAttachmentContent(r.Context(), id, thumbnail)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	defer content.Close()
+
+	writeAttachmentContent(w, content)
+}
+
+// writeAttachmentContent writes the file of an attachment as the response
+// body. Files never change once uploaded, so they can be cached by the
+// client.
+func writeAttachmentContent(w http.ResponseWriter, content *attachment.Content) {
+	w.Header().Set("Content-Type", content.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": content.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=86400, immutable")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, content)
+}
+
+// swagger:route DELETE /rest/v1/attachments/{id} attachments deleteAttachmentById
+//
+// # Delete an attachment
+//
+// This will delete an attachment by its given id, along with its image and thumbnail.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the attachment to delete
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: ItemDeletedResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) DeleteAttachmentById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := h.AttachmentService.DeleteAttachmentById(r.Context(), id); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Msg("attachment successfully deleted")
+
+	h.writeJSONResponse(w, http.StatusOK, ItemDeletedResponse{
+		Id:  id,
+		Msg: fmt.Sprintf("attachment %d deleted successfully", id),
+	})
+}
+
+// readUploadedFile returns the name and the content of the file in the "file"
+// field of a multipart/form-data request body. The body is streamed rather than
+// parsed with ParseMultipartForm, so it is only ever held in memory once and
+// stays bounded by the maximum request size.
+func readUploadedFile(r *http.Request) (string, []byte, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return "", nil, ErrUploadIsNotMultipart
+	}
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return "", nil, ErrUploadIsMalformed
+	}
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return "", nil, ErrUploadFileIsMissing
+		}
+		if err != nil {
+			return "", nil, uploadError(err)
+		}
+		if part.FormName() != uploadFormField {
+			continue
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return "", nil, uploadError(err)
+		}
+		return part.FileName(), data, nil
+	}
+}
+
+// uploadError returns err when the body exceeded the maximum request size, for
+// SetErrorResponse to report it, and ErrUploadIsMalformed otherwise.
+func uploadError(err error) error {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		return err
+	}
+	return ErrUploadIsMalformed
+}
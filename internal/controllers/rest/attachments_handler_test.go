@@ -0,0 +1,278 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/attachment"
+)
+
+func testAttachment(id int) *attachment.Attachment {
+	shotId := 3
+	createdAt := time.Date(2026, time.October, 18, 21, 0, 0, 0, time.UTC)
+	return &attachment.Attachment{
+		Id: id, ShotId: &shotId, Filename: "crema.png", ContentType: "image/png",
+		Size: 1024, Width: 640, Height: 480, CreatedAt: &createdAt,
+	}
+}
+
+func newAttachmentTestHandler(t *testing.T) (*Handler, *fakeAttachmentService) {
+	t.Helper()
+
+	handler, _, _, _, _ := newTestHandler(t)
+
+	return handler, handler.AttachmentService.(*fakeAttachmentService)
+}
+
+// multipartBody returns a multipart/form-data body with data as the file of
+// field, and its Content-Type.
+func multipartBody(t *testing.T, field, filename string, data []byte) (string, string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if err := mw.WriteField("caption", "morning shot"); err != nil {
+		t.Fatalf("write field: %v", err)
+	}
+	fw, err := mw.CreateFormFile(field, filename)
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	fw.Write(data)
+	mw.Close()
+
+	return buf.String(), mw.FormDataContentType()
+}
+
+func TestAttachmentHandlersHappyPaths(t *testing.T) {
+	a := testAttachment(1)
+	body, contentType := multipartBody(t, "file", "crema.png", []byte("picture"))
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		body        string
+		contentType string
+		id          string
+		status      int
+		expected    any
+		configure   func(*testing.T, *fakeAttachmentService)
+		handler     controllerHandler
+	}{
+		{
+			name: "create for shot", method: http.MethodPost, target: "/rest/v1/shots/3/attachments", id: "3",
+			body: body, contentType: contentType,
+			status: http.StatusCreated, expected: AttachmentResponse{*a}, handler: (*Handler).CreateShotAttachment,
+			configure: func(t *testing.T, service *fakeAttachmentService) {
+				service.createShotAttachment = func(_ context.Context, shotId int, filename string, data []byte) (*attachment.Attachment, error) {
+					if shotId != 3 || filename != "crema.png" || string(data) != "picture" {
+						t.Errorf("CreateShotAttachment(%d, %q, %q), want shot 3, crema.png and the uploaded file", shotId, filename, data)
+					}
+					return a, nil
+				}
+			},
+		},
+		{
+			name: "create for beans", method: http.MethodPost, target: "/rest/v1/beans/2/attachments", id: "2",
+			body: body, contentType: contentType,
+			status: http.StatusCreated, expected: AttachmentResponse{*a}, handler: (*Handler).CreateBeansAttachment,
+			configure: func(t *testing.T, service *fakeAttachmentService) {
+				service.createBeansAttachment = func(_ context.Context, beansId int, _ string, _ []byte) (*attachment.Attachment, error) {
+					if beansId != 2 {
+						t.Errorf("CreateBeansAttachment(%d), want beans 2", beansId)
+					}
+					return a, nil
+				}
+			},
+		},
+		{
+			name: "get by shot", method: http.MethodGet, target: "/rest/v1/shots/3/attachments", id: "3",
+			status: http.StatusOK, expected: []AttachmentResponse{{*a}}, handler: (*Handler).GetAttachmentsByShotId,
+			configure: func(_ *testing.T, service *fakeAttachmentService) {
+				service.getAttachmentsByShotID = func(context.Context, int) ([]attachment.Attachment, error) {
+					return []attachment.Attachment{*a}, nil
+				}
+			},
+		},
+		{
+			name: "get by beans", method: http.MethodGet, target: "/rest/v1/beans/2/attachments", id: "2",
+			status: http.StatusOK, expected: []AttachmentResponse{}, handler: (*Handler).GetAttachmentsByBeansId,
+			configure: func(_ *testing.T, service *fakeAttachmentService) {
+				service.getAttachmentsByBeansID = func(context.Context, int) ([]attachment.Attachment, error) {
+					return []attachment.Attachment{}, nil
+				}
+			},
+		},
+		{
+			name: "get by id", method: http.MethodGet, target: "/rest/v1/attachments/1", id: "1",
+			status: http.StatusOK, expected: AttachmentResponse{*a}, handler: (*Handler).GetAttachmentById,
+			configure: func(_ *testing.T, service *fakeAttachmentService) {
+				service.getAttachmentByID = func(context.Context, int) (*attachment.Attachment, error) { return a, nil }
+			},
+		},
+		{
+			name: "delete", method: http.MethodDelete, target: "/rest/v1/attachments/1", id: "1",
+			status: http.StatusOK, expected: ItemDeletedResponse{Id: 1, Msg: "attachment 1 deleted successfully"}, handler: (*Handler).DeleteAttachmentById,
+			configure: func(_ *testing.T, service *fakeAttachmentService) {
+				service.deleteAttachmentByID = func(context.Context, int) error { return nil }
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newAttachmentTestHandler(t)
+			tt.configure(t, service)
+			req := newControllerRequest(t, tt.method, tt.target, tt.body, tt.contentType, tt.id)
+
+			recorder := executeControllerHandler(handler, tt.handler, req)
+
+			assertJSONResponse(t, recorder, tt.status, tt.expected)
+		})
+	}
+}
+
+func TestAttachmentHandlersErrorPaths(t *testing.T) {
+	body, contentType := multipartBody(t, "file", "notes.txt", []byte("not a picture"))
+	noFileBody, noFileContentType := multipartBody(t, "photo", "crema.png", []byte("picture"))
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		body        string
+		contentType string
+		id          string
+		status      int
+		message     string
+		configure   func(*fakeAttachmentService)
+		handler     controllerHandler
+	}{
+		{
+			name: "create with a json body", method: http.MethodPost, target: "/rest/v1/shots/3/attachments", id: "3",
+			body: `{"file":"crema.png"}`, contentType: ContentTypeApplicationJSON,
+			status: http.StatusUnsupportedMediaType, message: "Content-Type header is not multipart/form-data",
+			handler: (*Handler).CreateShotAttachment, configure: func(*fakeAttachmentService) {},
+		},
+		{
+			name: "create without file field", method: http.MethodPost, target: "/rest/v1/shots/3/attachments", id: "3",
+			body: noFileBody, contentType: noFileContentType,
+			status: http.StatusBadRequest, message: `request body must contain a file in the "file" field`,
+			handler: (*Handler).CreateShotAttachment, configure: func(*fakeAttachmentService) {},
+		},
+		{
+			name: "create with a truncated body", method: http.MethodPost, target: "/rest/v1/shots/3/attachments", id: "3",
+			body: body[:len(body)-20], contentType: contentType,
+			status: http.StatusBadRequest, message: "request body is not a valid multipart form",
+			handler: (*Handler).CreateShotAttachment, configure: func(*fakeAttachmentService) {},
+		},
+		{
+			name: "create with an unsupported file", method: http.MethodPost, target: "/rest/v1/shots/3/attachments", id: "3",
+			body: body, contentType: contentType,
+			status: http.StatusUnsupportedMediaType, message: "attachment type is unsupported. Must be a JPEG, PNG or GIF image",
+			handler: (*Handler).CreateShotAttachment,
+			configure: func(service *fakeAttachmentService) {
+				service.createShotAttachment = func(context.Context, int, string, []byte) (*attachment.Attachment, error) {
+					return nil, domainerrors.ErrAttachmentTypeIsUnsupported
+				}
+			},
+		},
+		{
+			name: "create for missing beans", method: http.MethodPost, target: "/rest/v1/beans/5/attachments", id: "5",
+			body: body, contentType: contentType,
+			status: http.StatusNotFound, message: "no beans found for given id",
+			handler: (*Handler).CreateBeansAttachment,
+			configure: func(service *fakeAttachmentService) {
+				service.createBeansAttachment = func(context.Context, int, string, []byte) (*attachment.Attachment, error) {
+					return nil, domainerrors.ErrBeansDoesNotExist
+				}
+			},
+		},
+		{
+			name: "get content of missing attachment", method: http.MethodGet, target: "/rest/v1/attachments/5/content", id: "5",
+			status: http.StatusNotFound, message: "no attachment found for given id",
+			handler: (*Handler).GetAttachmentContent,
+			configure: func(service *fakeAttachmentService) {
+				service.getAttachmentContent = func(context.Context, int, bool) (*attachment.Content, error) {
+					return nil, domainerrors.ErrAttachmentDoesNotExist
+				}
+			},
+		},
+		{
+			name: "delete missing attachment", method: http.MethodDelete, target: "/rest/v1/attachments/5", id: "5",
+			status: http.StatusNotFound, message: "no attachment found for given id",
+			handler: (*Handler).DeleteAttachmentById,
+			configure: func(service *fakeAttachmentService) {
+				service.deleteAttachmentByID = func(context.Context, int) error {
+					return domainerrors.ErrAttachmentDoesNotExist
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newAttachmentTestHandler(t)
+			tt.configure(service)
+			req := newControllerRequest(t, tt.method, tt.target, tt.body, tt.contentType, tt.id)
+
+			recorder := executeControllerHandler(handler, tt.handler, req)
+
+			assertJSONResponse(t, recorder, tt.status, ErrorResponse{Msg: tt.message})
+		})
+	}
+}
+
+func TestCreateShotAttachment_RequestTooLarge(t *testing.T) {
+	handler, _ := newAttachmentTestHandler(t)
+	body, contentType := multipartBody(t, "file", "crema.png", bytes.Repeat([]byte("x"), 128))
+	req := newControllerRequest(t, http.MethodPost, "/rest/v1/shots/3/attachments", body, contentType, "3")
+	recorder := httptest.NewRecorder()
+
+	handler.MaxReqSize()(http.HandlerFunc(handler.CreateShotAttachment)).ServeHTTP(recorder, req)
+
+	assertJSONResponse(t, recorder, http.StatusRequestEntityTooLarge, ErrorResponse{Msg: "request body must not be larger than 64 bytes"})
+}
+
+func TestGetAttachmentContent(t *testing.T) {
+	for _, thumbnail := range []bool{false, true} {
+		handler, service := newAttachmentTestHandler(t)
+		service.getAttachmentContent = func(_ context.Context, id int, gotThumbnail bool) (*attachment.Content, error) {
+			if id != 1 || gotThumbnail != thumbnail {
+				t.Errorf("GetAttachmentContent(%d, %t), want attachment 1 and thumbnail %t", id, gotThumbnail, thumbnail)
+			}
+			return &attachment.Content{
+				ReadCloser:  io.NopCloser(strings.NewReader("picture")),
+				ContentType: "image/jpeg",
+				Filename:    "crème.jpg",
+			}, nil
+		}
+		endpoint := (*Handler).GetAttachmentContent
+		if thumbnail {
+			endpoint = (*Handler).GetAttachmentThumbnail
+		}
+		req := newControllerRequest(t, http.MethodGet, "/rest/v1/attachments/1/content", "", "", "1")
+
+		recorder := executeControllerHandler(handler, endpoint, req)
+
+		if recorder.Code != http.StatusOK || recorder.Body.String() != "picture" {
+			t.Errorf("response = %d %q, want 200 with the file", recorder.Code, recorder.Body.String())
+		}
+		for header, want := range map[string]string{
+			"Content-Type":           "image/jpeg",
+			"Content-Disposition":    "inline; filename*=utf-8''cr%C3%A8me.jpg",
+			"X-Content-Type-Options": "nosniff",
+		} {
+			if got := recorder.Header().Get(header); got != want {
+				t.Errorf("%s = %q, want %q", header, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/apikey"
+	"github.com/lescactus/espressoapi-go/internal/services/sso"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// apiPathPrefix is the path prefix of the routes protected by api keys
+	apiPathPrefix = "/rest/v1/"
+
+	// HeaderAPIKey is the header carrying an api key, as an alternative
+	// to "Authorization: Bearer <key>"
+	HeaderAPIKey = "X-API-Key"
+)
+
+// APIKeyAuth is a HTTP middleware authenticating requests to /rest/v1/*
+// with an api key, given either as a bearer token or in the X-API-Key header.
+// Read-only keys are limited to safe methods.
+// When tokens is not nil, JWT bearer tokens are authenticated as access
+// tokens of the OpenID Connect provider instead, and allow writing.
+// On success, the owner of the key or token is stored in the request context.
+func (h *Handler) APIKeyAuth(keys apikey.Service, tokens sso.Service) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, apiPathPrefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			secret := apiKeyFromRequest(r)
+			if secret == "" {
+				h.setUnauthorizedResponse(w, ErrAPIKeyMissing)
+				return
+			}
+
+			if tokens != nil && isJWT(secret) {
+				user, err := tokens.AuthenticateToken(r.Context(), secret)
+				if err != nil {
+					h.setUnauthorizedResponse(w, err)
+					return
+				}
+
+				zerolog.Ctx(r.Context()).UpdateContext(func(c zerolog.Context) zerolog.Context {
+					return c.Str("user", user.Name).Str("auth", "oidc")
+				})
+
+				next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), user)))
+				return
+			}
+
+			key, err := keys.Authenticate(r.Context(), secret)
+			if err != nil {
+				h.setUnauthorizedResponse(w, err)
+				return
+			}
+
+			if !key.Scope.CanWrite() && !isSafeMethod(r.Method) {
+				h.SetErrorResponse(w, ErrAPIKeyReadOnly)
+				return
+			}
+
+			zerolog.Ctx(r.Context()).UpdateContext(func(c zerolog.Context) zerolog.Context {
+				return c.Str("user", key.UserName).Int("api_key_id", key.Id)
+			})
+
+			ctx := auth.NewContext(r.Context(), &auth.User{Id: key.UserId, Name: key.UserName, Role: key.UserRole})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Authorize is a HTTP middleware refusing the requests of users whose role is
+// not granted action on resource with a 403.
+func (h *Handler) Authorize(resource auth.Resource, action auth.Action) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !auth.Allowed(r.Context(), resource, action) {
+				zerolog.Ctx(r.Context()).Warn().Str("resource", string(resource)).Stringer("action", action).Msg("permission denied")
+				h.SetErrorResponse(w, domainerrors.ErrPermissionDenied)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// setUnauthorizedResponse sets the error response along with the
+// WWW-Authenticate header expected on 401 responses.
+func (h *Handler) setUnauthorizedResponse(w http.ResponseWriter, err error) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="espressoapi"`)
+	h.SetErrorResponse(w, err)
+}
+
+// apiKeyFromRequest returns the api key of the request, looking first
+// at the Authorization header and then at the X-API-Key header.
+func apiKeyFromRequest(r *http.Request) string {
+	if authz := r.Header.Get("Authorization"); authz != "" {
+		scheme, token, ok := strings.Cut(authz, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return strings.TrimSpace(r.Header.Get(HeaderAPIKey))
+}
+
+// isJWT reports whether a bearer token is a JWT, made of three dot separated
+// parts, which api keys never contain.
+func isJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// isSafeMethod reports whether the method does not modify resources.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
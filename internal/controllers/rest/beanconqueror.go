@@ -0,0 +1,107 @@
+package rest
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/lescactus/espressoapi-go/internal/services/beanconqueror"
+	"github.com/rs/zerolog/hlog"
+)
+
+// swagger:parameters importBeanconquerorBackup
+type BeanconquerorImportParams struct {
+	// The Beanconqueror.json backup of the app, or the zip archive holding
+	// it.
+	// in: formData
+	// required: true
+	// swagger:file
+	File []byte `json:"file"`
+}
+
+// BeanconquerorImportReportResponse represents the outcome of the import of a
+// Beanconqueror backup
+//
+// Every preparation, roaster, beans and brew of the backup is mapped onto the
+// id of the sheet, roaster, beans or shot it was imported as.
+//
+// swagger:response BeanconquerorImportReportResponse
+type BeanconquerorImportReportResponse struct {
+	// swagger:allOf
+	beanconqueror.Report
+}
+
+// swagger:route POST /rest/v1/import/beanconqueror imports importBeanconquerorBackup
+//
+// # Import a Beanconqueror backup
+//
+// This will import the preparations, beans and brews of the uploaded Beanconqueror backup as sheets, roasters, beans and shots. Records matching existing ones by name or imported before are reported as existing, so that a backup can be imported again. The request body is limited by the server maximum request size.
+//
+//	Consumes:
+//	- multipart/form-data
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: BeanconquerorImportReportResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  413: ErrorResponse
+//	  415: ErrorResponse
+func (h *Handler) ImportBeanconquerorBackup(w http.ResponseWriter, r *http.Request) {
+	_, data, err := readUploadedFile(r)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	report, err := h.BeanconquerorService.Import(r.Context(), data)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("created", report.Created).Int("existing", report.Existing).Int("failed", report.Failed).Msg("beanconqueror backup successfully imported")
+
+	h.writeJSONResponse(w, http.StatusOK, BeanconquerorImportReportResponse{*report})
+}
+
+// swagger:route GET /rest/v1/export/beanconqueror imports exportBeanconquerorBackup
+//
+// # Export a Beanconqueror backup
+//
+// This will download the sheets, beans and shots as a Beanconqueror.json backup, to be restored in the Beanconqueror app.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: description: The Beanconqueror.json backup
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+func (h *Handler) ExportBeanconquerorBackup(w http.ResponseWriter, r *http.Request) {
+	backup, err := h.BeanconquerorService.Export(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "Beanconqueror.json"}))
+	h.writeJSONResponse(w, http.StatusOK, backup)
+}
@@ -0,0 +1,116 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/beanconqueror"
+)
+
+func newBeanconquerorTestHandler(t *testing.T) (*Handler, *fakeBeanconquerorService) {
+	t.Helper()
+
+	handler, _, _, _, _ := newTestHandler(t)
+
+	return handler, handler.BeanconquerorService.(*fakeBeanconquerorService)
+}
+
+func TestImportBeanconquerorBackup(t *testing.T) {
+	handler, service := newBeanconquerorTestHandler(t)
+	sheetId, shotId := 3, 12
+	report := &beanconqueror.Report{
+		Created: 1, Existing: 1,
+		Mappings: []beanconqueror.Mapping{
+			{Kind: beanconqueror.KindPreparation, ExternalId: "p-1", Name: "Linea Mini", Id: &sheetId, Status: beanconqueror.StatusExisting},
+			{Kind: beanconqueror.KindBrew, ExternalId: "w-1", Name: "Red Brick", Id: &shotId, Status: beanconqueror.StatusCreated},
+		},
+	}
+	service.importBackup = func(_ context.Context, data []byte) (*beanconqueror.Report, error) {
+		if string(data) != `{"BEANS":[],"BREWS":[]}` {
+			t.Errorf("Import(%q), want the uploaded backup", data)
+		}
+		return report, nil
+	}
+	body, contentType := multipartBody(t, "file", "Beanconqueror.json", []byte(`{"BEANS":[],"BREWS":[]}`))
+	req := newControllerRequest(t, http.MethodPost, "/rest/v1/import/beanconqueror", body, contentType, "")
+
+	recorder := executeControllerHandler(handler, (*Handler).ImportBeanconquerorBackup, req)
+
+	assertJSONResponse(t, recorder, http.StatusOK, BeanconquerorImportReportResponse{*report})
+}
+
+func TestImportBeanconquerorBackupErrorPaths(t *testing.T) {
+	body, contentType := multipartBody(t, "file", "Beanconqueror.json", []byte("not a backup"))
+	noFileBody, noFileContentType := multipartBody(t, "backup", "Beanconqueror.json", []byte("{}"))
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		status      int
+		message     string
+		configure   func(*fakeBeanconquerorService)
+	}{
+		{
+			name: "json body", body: `{"BEANS":[],"BREWS":[]}`, contentType: ContentTypeApplicationJSON,
+			status: http.StatusUnsupportedMediaType, message: "Content-Type header is not multipart/form-data",
+			configure: func(*fakeBeanconquerorService) {},
+		},
+		{
+			name: "without file field", body: noFileBody, contentType: noFileContentType,
+			status: http.StatusBadRequest, message: `request body must contain a file in the "file" field`,
+			configure: func(*fakeBeanconquerorService) {},
+		},
+		{
+			name: "invalid backup", body: body, contentType: contentType,
+			status: http.StatusBadRequest, message: domainerrors.ErrImportBackupIsInvalid.Error(),
+			configure: func(service *fakeBeanconquerorService) {
+				service.importBackup = func(context.Context, []byte) (*beanconqueror.Report, error) {
+					return nil, domainerrors.ErrImportBackupIsInvalid
+				}
+			},
+		},
+		{
+			name: "service error", body: body, contentType: contentType,
+			status: http.StatusInternalServerError, message: "internal server error",
+			configure: func(service *fakeBeanconquerorService) {
+				service.importBackup = func(context.Context, []byte) (*beanconqueror.Report, error) {
+					return nil, errors.New("could not import beans: connection refused")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newBeanconquerorTestHandler(t)
+			tt.configure(service)
+			req := newControllerRequest(t, http.MethodPost, "/rest/v1/import/beanconqueror", tt.body, tt.contentType, "")
+
+			recorder := executeControllerHandler(handler, (*Handler).ImportBeanconquerorBackup, req)
+
+			assertJSONResponse(t, recorder, tt.status, ErrorResponse{Msg: tt.message})
+		})
+	}
+}
+
+func TestExportBeanconquerorBackup(t *testing.T) {
+	handler, service := newBeanconquerorTestHandler(t)
+	backup := &beanconqueror.Backup{
+		Beans:        []beanconqueror.Bean{{Config: beanconqueror.Config{UUID: "espressoapi-beans-2"}, Name: "Red Brick", Roaster: "Square Mile"}},
+		Brews:        []beanconqueror.Brew{},
+		Mills:        []beanconqueror.Mill{},
+		Preparations: []beanconqueror.Preparation{},
+	}
+	service.exportBackup = func(context.Context) (*beanconqueror.Backup, error) { return backup, nil }
+	req := newControllerRequest(t, http.MethodGet, "/rest/v1/export/beanconqueror", "", "", "")
+
+	recorder := executeControllerHandler(handler, (*Handler).ExportBeanconquerorBackup, req)
+
+	assertJSONResponse(t, recorder, http.StatusOK, backup)
+	if got, want := recorder.Header().Get("Content-Disposition"), `attachment; filename=Beanconqueror.json`; got != want {
+		t.Errorf("Content-Disposition = %q, want %q", got, want)
+	}
+}
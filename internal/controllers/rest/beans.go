@@ -23,10 +23,15 @@ type CreateBeansParams struct {
 // CreateBeansRequest represents the request body for creating beans
 // swagger:model
 type CreateBeansRequest struct {
-	Name       string         `json:"name"`
-	RoasterId  int            `json:"roaster_id"`
-	RoastDate  *RoastDate     `json:"roast_date"`
-	RoastLevel sql.RoastLevel `json:"roast_level"`
+	Name          string         `json:"name"`
+	RoasterId     int            `json:"roaster_id"`
+	RoastDate     *RoastDate     `json:"roast_date"`
+	RoastLevel    sql.RoastLevel `json:"roast_level"`
+	GreenCoffeeId *int           `json:"green_coffee_id"`
+	GreenWeight   float64        `json:"green_weight"`
+	Price         float64        `json:"price"`
+	Currency      string         `json:"currency"`
+	BagWeight     float64        `json:"bag_weight"`
 }
 
 // BeansResponse represents coffee beans for this application
@@ -84,6 +89,8 @@ func logBeansFromRequest(r *http.Request, beans *bean.Bean, msg string) {
 //	Responses:
 //	  201: BeansResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
 //	  409: ErrorResponse
 //	  413: ErrorResponse
 func (h *Handler) CreateBeans(w http.ResponseWriter, r *http.Request) {
@@ -104,8 +111,13 @@ func (h *Handler) CreateBeans(w http.ResponseWriter, r *http.Request) {
 		Roaster: &roaster.Roaster{
 			Id: beansReq.RoasterId,
 		},
-		RoastDate:  (*time.Time)(beansReq.RoastDate),
-		RoastLevel: beansReq.RoastLevel,
+		RoastDate:     (*time.Time)(beansReq.RoastDate),
+		RoastLevel:    beansReq.RoastLevel,
+		GreenCoffeeId: beansReq.GreenCoffeeId,
+		GreenWeight:   beansReq.GreenWeight,
+		Price:         beansReq.Price,
+		Currency:      beansReq.Currency,
+		BagWeight:     beansReq.BagWeight,
 	}
 
 	beans, err := h.BeanService.CreateBean(r.Context(), beans)
@@ -150,6 +162,7 @@ func (h *Handler) CreateBeans(w http.ResponseWriter, r *http.Request) {
 //	Responses:
 //	  200: BeansResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
 //	  404: ErrorResponse
 func (h *Handler) GetBeansById(w http.ResponseWriter, r *http.Request) {
 	id, err := h.getIdFromParams(r.Context())
@@ -192,6 +205,7 @@ func (h *Handler) GetBeansById(w http.ResponseWriter, r *http.Request) {
 //	Responses:
 //	  200: BeansResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
 //	  404: ErrorResponse
 func (h *Handler) GetAllBeans(w http.ResponseWriter, r *http.Request) {
 	beans, err := h.BeanService.GetAllBeans(r.Context())
@@ -220,10 +234,15 @@ type UpdateBeansByIdRequestParams struct {
 // with the given id
 // swagger:model
 type UpdateBeansByIdRequest struct {
-	Name       string         `json:"name"`
-	RoasterId  int            `json:"roaster_id"`
-	RoastDate  *RoastDate     `json:"roast_date"`
-	RoastLevel sql.RoastLevel `json:"roast_level"`
+	Name          string         `json:"name"`
+	RoasterId     int            `json:"roaster_id"`
+	RoastDate     *RoastDate     `json:"roast_date"`
+	RoastLevel    sql.RoastLevel `json:"roast_level"`
+	GreenCoffeeId *int           `json:"green_coffee_id"`
+	GreenWeight   float64        `json:"green_weight"`
+	Price         float64        `json:"price"`
+	Currency      string         `json:"currency"`
+	BagWeight     float64        `json:"bag_weight"`
 }
 
 // swagger:route PUT /rest/v1/beans/{id} beans updateBeansById
@@ -257,6 +276,8 @@ type UpdateBeansByIdRequest struct {
 //	Responses:
 //	  200: BeansResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
 //	  404: ErrorResponse
 //	  413: ErrorResponse
 func (h *Handler) UpdateBeanById(w http.ResponseWriter, r *http.Request) {
@@ -284,8 +305,13 @@ func (h *Handler) UpdateBeanById(w http.ResponseWriter, r *http.Request) {
 		Roaster: &roaster.Roaster{
 			Id: beansReq.RoasterId,
 		},
-		RoastDate:  (*time.Time)(beansReq.RoastDate),
-		RoastLevel: beansReq.RoastLevel,
+		RoastDate:     (*time.Time)(beansReq.RoastDate),
+		RoastLevel:    beansReq.RoastLevel,
+		GreenCoffeeId: beansReq.GreenCoffeeId,
+		GreenWeight:   beansReq.GreenWeight,
+		Price:         beansReq.Price,
+		Currency:      beansReq.Currency,
+		BagWeight:     beansReq.BagWeight,
 	}
 
 	beans, err = h.BeanService.UpdateBeanById(r.Context(), id, beans)
@@ -330,6 +356,8 @@ func (h *Handler) UpdateBeanById(w http.ResponseWriter, r *http.Request) {
 //	Responses:
 //	  200: ItemDeletedResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
 //	  404: ErrorResponse
 func (h *Handler) DeleteBeansById(w http.ResponseWriter, r *http.Request) {
 	id, err := h.getIdFromParams(r.Context())
@@ -64,6 +64,19 @@ func TestBeanHandlersHappyPaths(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "create from green coffee", method: http.MethodPost, target: "/rest/v1/beans", body: `{"name":"espresso blend","roaster_id":6,"roast_date":"2026-02-18","roast_level":2,"green_coffee_id":3,"green_weight":250}`,
+			status: http.StatusCreated, expected: BeansResponse{*created}, handler: (*Handler).CreateBeans,
+			configure: func(t *testing.T, service *fakeBeanService) {
+				service.createBean = func(_ context.Context, value *bean.Bean) (*bean.Bean, error) {
+					assertBeanRequest(t, value, 0, "espresso blend", 6, &expectedRoastDate, modelsql.RoastLevelMedium)
+					if value.GreenCoffeeId == nil || *value.GreenCoffeeId != 3 || value.GreenWeight != 250 {
+						t.Errorf("green coffee = %v, %v, want 3 and 250", value.GreenCoffeeId, value.GreenWeight)
+					}
+					return created, nil
+				}
+			},
+		},
 		{
 			name: "get by id", method: http.MethodGet, target: "/rest/v1/beans/7", id: "7",
 			status: http.StatusOK, expected: BeansResponse{*found}, handler: (*Handler).GetBeansById,
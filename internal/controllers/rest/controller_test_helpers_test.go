@@ -13,25 +13,46 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 	modelsql "github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/attachment"
 	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/beanconqueror"
+	"github.com/lescactus/espressoapi-go/internal/services/cupping"
+	"github.com/lescactus/espressoapi-go/internal/services/decent"
+	"github.com/lescactus/espressoapi-go/internal/services/greencoffee"
+	"github.com/lescactus/espressoapi-go/internal/services/maintenance"
+	"github.com/lescactus/espressoapi-go/internal/services/report"
+	"github.com/lescactus/espressoapi-go/internal/services/roastbatch"
 	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/share"
 	"github.com/lescactus/espressoapi-go/internal/services/sheet"
 	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/lescactus/espressoapi-go/internal/services/spreadsheet"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
 	"github.com/rs/zerolog"
 )
 
 type fakeSheetService struct {
 	t                 *testing.T
+	createSheet       func(context.Context, *sheet.Sheet) (*sheet.Sheet, error)
 	createSheetByName func(context.Context, string) (*sheet.Sheet, error)
 	getSheetByID      func(context.Context, int) (*sheet.Sheet, error)
 	getAllSheets      func(context.Context) ([]sheet.Sheet, error)
 	updateSheetByID   func(context.Context, int, *sheet.Sheet) (*sheet.Sheet, error)
 	deleteSheetByID   func(context.Context, int) error
+	cloneSheet        func(context.Context, int, string, bool) (*sheet.Sheet, error)
 	ping              func(context.Context) error
 }
 
 var _ sheet.Service = (*fakeSheetService)(nil)
 
+func (f *fakeSheetService) CreateSheet(ctx context.Context, value *sheet.Sheet) (*sheet.Sheet, error) {
+	if f.createSheet == nil {
+		f.t.Fatalf("unexpected CreateSheet call")
+		return nil, nil
+	}
+	return f.createSheet(ctx, value)
+}
+
 func (f *fakeSheetService) CreateSheetByName(ctx context.Context, name string) (*sheet.Sheet, error) {
 	if f.createSheetByName == nil {
 		f.t.Fatalf("unexpected CreateSheetByName call")
@@ -72,6 +93,14 @@ func (f *fakeSheetService) DeleteSheetById(ctx context.Context, id int) error {
 	return f.deleteSheetByID(ctx, id)
 }
 
+func (f *fakeSheetService) CloneSheet(ctx context.Context, id int, name string, copyBestShot bool) (*sheet.Sheet, error) {
+	if f.cloneSheet == nil {
+		f.t.Fatalf("unexpected CloneSheet call")
+		return nil, nil
+	}
+	return f.cloneSheet(ctx, id, name, copyBestShot)
+}
+
 func (f *fakeSheetService) Ping(ctx context.Context) error {
 	if f.ping == nil {
 		f.t.Fatalf("unexpected sheet Ping call")
@@ -208,6 +237,10 @@ type fakeShotService struct {
 	getShotsBySheetID func(context.Context, int) ([]shot.Shot, error)
 	updateShotByID    func(context.Context, int, *shot.Shot) (*shot.Shot, error)
 	deleteShotByID    func(context.Context, int) error
+	getShotProfile    func(context.Context, int) (*shot.Profile, error)
+	updateShotProfile func(context.Context, int, *shot.Profile) (*shot.Profile, error)
+	compareShots      func(context.Context, []int) (*shot.Comparison, error)
+	duplicateShot     func(context.Context, int, shot.Overrides) (*shot.Shot, error)
 	ping              func(context.Context) error
 }
 
@@ -261,6 +294,38 @@ func (f *fakeShotService) DeleteShotById(ctx context.Context, id int) error {
 	return f.deleteShotByID(ctx, id)
 }
 
+func (f *fakeShotService) GetShotProfileById(ctx context.Context, id int) (*shot.Profile, error) {
+	if f.getShotProfile == nil {
+		f.t.Fatalf("unexpected GetShotProfileById call")
+		return nil, nil
+	}
+	return f.getShotProfile(ctx, id)
+}
+
+func (f *fakeShotService) UpdateShotProfileById(ctx context.Context, id int, profile *shot.Profile) (*shot.Profile, error) {
+	if f.updateShotProfile == nil {
+		f.t.Fatalf("unexpected UpdateShotProfileById call")
+		return nil, nil
+	}
+	return f.updateShotProfile(ctx, id, profile)
+}
+
+func (f *fakeShotService) CompareShots(ctx context.Context, ids []int) (*shot.Comparison, error) {
+	if f.compareShots == nil {
+		f.t.Fatalf("unexpected CompareShots call")
+		return nil, nil
+	}
+	return f.compareShots(ctx, ids)
+}
+
+func (f *fakeShotService) DuplicateShot(ctx context.Context, id int, overrides shot.Overrides) (*shot.Shot, error) {
+	if f.duplicateShot == nil {
+		f.t.Fatalf("unexpected DuplicateShot call")
+		return nil, nil
+	}
+	return f.duplicateShot(ctx, id, overrides)
+}
+
 func (f *fakeShotService) Ping(ctx context.Context) error {
 	if f.ping == nil {
 		f.t.Fatalf("unexpected shot Ping call")
@@ -269,6 +334,567 @@ func (f *fakeShotService) Ping(ctx context.Context) error {
 	return f.ping(ctx)
 }
 
+type fakeCuppingService struct {
+	t                        *testing.T
+	createCuppingSession     func(context.Context, *cupping.CuppingSession) (*cupping.CuppingSession, error)
+	getCuppingSessionByID    func(context.Context, int) (*cupping.CuppingSession, error)
+	getAllCuppingSessions    func(context.Context) ([]cupping.CuppingSession, error)
+	updateCuppingSessionByID func(context.Context, int, *cupping.CuppingSession) (*cupping.CuppingSession, error)
+	deleteCuppingSessionByID func(context.Context, int) error
+	createCuppingScore       func(context.Context, *cupping.CuppingScore) (*cupping.CuppingScore, error)
+	getCuppingScoreByID      func(context.Context, int) (*cupping.CuppingScore, error)
+	getCuppingScoresByBeans  func(context.Context, int) ([]cupping.CuppingScore, error)
+	updateCuppingScoreByID   func(context.Context, int, *cupping.CuppingScore) (*cupping.CuppingScore, error)
+	deleteCuppingScoreByID   func(context.Context, int) error
+	ping                     func(context.Context) error
+}
+
+var _ cupping.Service = (*fakeCuppingService)(nil)
+
+func (f *fakeCuppingService) CreateCuppingSession(ctx context.Context, value *cupping.CuppingSession) (*cupping.CuppingSession, error) {
+	if f.createCuppingSession == nil {
+		f.t.Fatalf("unexpected CreateCuppingSession call")
+		return nil, nil
+	}
+	return f.createCuppingSession(ctx, value)
+}
+
+func (f *fakeCuppingService) GetCuppingSessionById(ctx context.Context, id int) (*cupping.CuppingSession, error) {
+	if f.getCuppingSessionByID == nil {
+		f.t.Fatalf("unexpected GetCuppingSessionById call")
+		return nil, nil
+	}
+	return f.getCuppingSessionByID(ctx, id)
+}
+
+func (f *fakeCuppingService) GetAllCuppingSessions(ctx context.Context) ([]cupping.CuppingSession, error) {
+	if f.getAllCuppingSessions == nil {
+		f.t.Fatalf("unexpected GetAllCuppingSessions call")
+		return nil, nil
+	}
+	return f.getAllCuppingSessions(ctx)
+}
+
+func (f *fakeCuppingService) UpdateCuppingSessionById(ctx context.Context, id int, value *cupping.CuppingSession) (*cupping.CuppingSession, error) {
+	if f.updateCuppingSessionByID == nil {
+		f.t.Fatalf("unexpected UpdateCuppingSessionById call")
+		return nil, nil
+	}
+	return f.updateCuppingSessionByID(ctx, id, value)
+}
+
+func (f *fakeCuppingService) DeleteCuppingSessionById(ctx context.Context, id int) error {
+	if f.deleteCuppingSessionByID == nil {
+		f.t.Fatalf("unexpected DeleteCuppingSessionById call")
+		return nil
+	}
+	return f.deleteCuppingSessionByID(ctx, id)
+}
+
+func (f *fakeCuppingService) CreateCuppingScore(ctx context.Context, value *cupping.CuppingScore) (*cupping.CuppingScore, error) {
+	if f.createCuppingScore == nil {
+		f.t.Fatalf("unexpected CreateCuppingScore call")
+		return nil, nil
+	}
+	return f.createCuppingScore(ctx, value)
+}
+
+func (f *fakeCuppingService) GetCuppingScoreById(ctx context.Context, id int) (*cupping.CuppingScore, error) {
+	if f.getCuppingScoreByID == nil {
+		f.t.Fatalf("unexpected GetCuppingScoreById call")
+		return nil, nil
+	}
+	return f.getCuppingScoreByID(ctx, id)
+}
+
+func (f *fakeCuppingService) GetCuppingScoresByBeansId(ctx context.Context, beansId int) ([]cupping.CuppingScore, error) {
+	if f.getCuppingScoresByBeans == nil {
+		f.t.Fatalf("unexpected GetCuppingScoresByBeansId call")
+		return nil, nil
+	}
+	return f.getCuppingScoresByBeans(ctx, beansId)
+}
+
+func (f *fakeCuppingService) UpdateCuppingScoreById(ctx context.Context, id int, value *cupping.CuppingScore) (*cupping.CuppingScore, error) {
+	if f.updateCuppingScoreByID == nil {
+		f.t.Fatalf("unexpected UpdateCuppingScoreById call")
+		return nil, nil
+	}
+	return f.updateCuppingScoreByID(ctx, id, value)
+}
+
+func (f *fakeCuppingService) DeleteCuppingScoreById(ctx context.Context, id int) error {
+	if f.deleteCuppingScoreByID == nil {
+		f.t.Fatalf("unexpected DeleteCuppingScoreById call")
+		return nil
+	}
+	return f.deleteCuppingScoreByID(ctx, id)
+}
+
+func (f *fakeCuppingService) Ping(ctx context.Context) error {
+	if f.ping == nil {
+		f.t.Fatalf("unexpected cupping Ping call")
+		return nil
+	}
+	return f.ping(ctx)
+}
+
+type fakeRoastBatchService struct {
+	t                         *testing.T
+	createRoastBatch          func(context.Context, *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error)
+	getRoastBatchByID         func(context.Context, int) (*roastbatch.RoastBatch, error)
+	getAllRoastBatches        func(context.Context) ([]roastbatch.RoastBatch, error)
+	updateRoastBatchByID      func(context.Context, int, *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error)
+	deleteRoastBatchByID      func(context.Context, int) error
+	createBeansFromRoastBatch func(context.Context, int) (*roastbatch.RoastBatch, error)
+	ping                      func(context.Context) error
+}
+
+var _ roastbatch.Service = (*fakeRoastBatchService)(nil)
+
+func (f *fakeRoastBatchService) CreateRoastBatch(ctx context.Context, value *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error) {
+	if f.createRoastBatch == nil {
+		f.t.Fatalf("unexpected CreateRoastBatch call")
+		return nil, nil
+	}
+	return f.createRoastBatch(ctx, value)
+}
+
+func (f *fakeRoastBatchService) GetRoastBatchById(ctx context.Context, id int) (*roastbatch.RoastBatch, error) {
+	if f.getRoastBatchByID == nil {
+		f.t.Fatalf("unexpected GetRoastBatchById call")
+		return nil, nil
+	}
+	return f.getRoastBatchByID(ctx, id)
+}
+
+func (f *fakeRoastBatchService) GetAllRoastBatches(ctx context.Context) ([]roastbatch.RoastBatch, error) {
+	if f.getAllRoastBatches == nil {
+		f.t.Fatalf("unexpected GetAllRoastBatches call")
+		return nil, nil
+	}
+	return f.getAllRoastBatches(ctx)
+}
+
+func (f *fakeRoastBatchService) UpdateRoastBatchById(ctx context.Context, id int, value *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error) {
+	if f.updateRoastBatchByID == nil {
+		f.t.Fatalf("unexpected UpdateRoastBatchById call")
+		return nil, nil
+	}
+	return f.updateRoastBatchByID(ctx, id, value)
+}
+
+func (f *fakeRoastBatchService) DeleteRoastBatchById(ctx context.Context, id int) error {
+	if f.deleteRoastBatchByID == nil {
+		f.t.Fatalf("unexpected DeleteRoastBatchById call")
+		return nil
+	}
+	return f.deleteRoastBatchByID(ctx, id)
+}
+
+func (f *fakeRoastBatchService) CreateBeansFromRoastBatch(ctx context.Context, id int) (*roastbatch.RoastBatch, error) {
+	if f.createBeansFromRoastBatch == nil {
+		f.t.Fatalf("unexpected CreateBeansFromRoastBatch call")
+		return nil, nil
+	}
+	return f.createBeansFromRoastBatch(ctx, id)
+}
+
+func (f *fakeRoastBatchService) Ping(ctx context.Context) error {
+	if f.ping == nil {
+		f.t.Fatalf("unexpected roast batch Ping call")
+		return nil
+	}
+	return f.ping(ctx)
+}
+
+type fakeGreenCoffeeService struct {
+	t                     *testing.T
+	createGreenCoffee     func(context.Context, *greencoffee.GreenCoffee) (*greencoffee.GreenCoffee, error)
+	getGreenCoffeeByID    func(context.Context, int) (*greencoffee.GreenCoffee, error)
+	getAllGreenCoffees    func(context.Context) ([]greencoffee.GreenCoffee, error)
+	updateGreenCoffeeByID func(context.Context, int, *greencoffee.GreenCoffee) (*greencoffee.GreenCoffee, error)
+	deleteGreenCoffeeByID func(context.Context, int) error
+	ping                  func(context.Context) error
+}
+
+var _ greencoffee.Service = (*fakeGreenCoffeeService)(nil)
+
+func (f *fakeGreenCoffeeService) CreateGreenCoffee(ctx context.Context, value *greencoffee.GreenCoffee) (*greencoffee.GreenCoffee, error) {
+	if f.createGreenCoffee == nil {
+		f.t.Fatalf("unexpected CreateGreenCoffee call")
+		return nil, nil
+	}
+	return f.createGreenCoffee(ctx, value)
+}
+
+func (f *fakeGreenCoffeeService) GetGreenCoffeeById(ctx context.Context, id int) (*greencoffee.GreenCoffee, error) {
+	if f.getGreenCoffeeByID == nil {
+		f.t.Fatalf("unexpected GetGreenCoffeeById call")
+		return nil, nil
+	}
+	return f.getGreenCoffeeByID(ctx, id)
+}
+
+func (f *fakeGreenCoffeeService) GetAllGreenCoffees(ctx context.Context) ([]greencoffee.GreenCoffee, error) {
+	if f.getAllGreenCoffees == nil {
+		f.t.Fatalf("unexpected GetAllGreenCoffees call")
+		return nil, nil
+	}
+	return f.getAllGreenCoffees(ctx)
+}
+
+func (f *fakeGreenCoffeeService) UpdateGreenCoffeeById(ctx context.Context, id int, value *greencoffee.GreenCoffee) (*greencoffee.GreenCoffee, error) {
+	if f.updateGreenCoffeeByID == nil {
+		f.t.Fatalf("unexpected UpdateGreenCoffeeById call")
+		return nil, nil
+	}
+	return f.updateGreenCoffeeByID(ctx, id, value)
+}
+
+func (f *fakeGreenCoffeeService) DeleteGreenCoffeeById(ctx context.Context, id int) error {
+	if f.deleteGreenCoffeeByID == nil {
+		f.t.Fatalf("unexpected DeleteGreenCoffeeById call")
+		return nil
+	}
+	return f.deleteGreenCoffeeByID(ctx, id)
+}
+
+func (f *fakeGreenCoffeeService) Ping(ctx context.Context) error {
+	if f.ping == nil {
+		f.t.Fatalf("unexpected green coffee Ping call")
+		return nil
+	}
+	return f.ping(ctx)
+}
+
+type fakeReportService struct {
+	t              *testing.T
+	getSpendReport func(context.Context, *time.Time, *time.Time, report.GroupBy) (*report.SpendReport, error)
+	ping           func(context.Context) error
+}
+
+var _ report.Service = (*fakeReportService)(nil)
+
+func (f *fakeReportService) GetSpendReport(ctx context.Context, from, to *time.Time, groupBy report.GroupBy) (*report.SpendReport, error) {
+	if f.getSpendReport == nil {
+		f.t.Fatalf("unexpected GetSpendReport call")
+		return nil, nil
+	}
+	return f.getSpendReport(ctx, from, to, groupBy)
+}
+
+func (f *fakeReportService) Ping(ctx context.Context) error {
+	if f.ping == nil {
+		f.t.Fatalf("unexpected report Ping call")
+		return nil
+	}
+	return f.ping(ctx)
+}
+
+type fakeStatsService struct {
+	t              *testing.T
+	getConsumption func(context.Context, *time.Time, *time.Time, string) (*stats.Consumption, error)
+	getShotStats   func(context.Context, modelsql.ShotStatsSubject, int) (*stats.ShotStats, error)
+	ping           func(context.Context) error
+}
+
+var _ stats.Service = (*fakeStatsService)(nil)
+
+func (f *fakeStatsService) GetConsumption(ctx context.Context, from, to *time.Time, timeZone string) (*stats.Consumption, error) {
+	if f.getConsumption == nil {
+		f.t.Fatalf("unexpected GetConsumption call")
+		return nil, nil
+	}
+	return f.getConsumption(ctx, from, to, timeZone)
+}
+
+func (f *fakeStatsService) GetShotStats(ctx context.Context, subject modelsql.ShotStatsSubject, id int) (*stats.ShotStats, error) {
+	if f.getShotStats == nil {
+		f.t.Fatalf("unexpected GetShotStats call")
+		return nil, nil
+	}
+	return f.getShotStats(ctx, subject, id)
+}
+
+func (f *fakeStatsService) Ping(ctx context.Context) error {
+	if f.ping == nil {
+		f.t.Fatalf("unexpected stats Ping call")
+		return nil
+	}
+	return f.ping(ctx)
+}
+
+type fakeMaintenanceService struct {
+	t                           *testing.T
+	createMaintenanceTask       func(context.Context, *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error)
+	getMaintenanceTaskByID      func(context.Context, int) (*maintenance.MaintenanceTask, error)
+	getAllMaintenanceTasks      func(context.Context) ([]maintenance.MaintenanceTask, error)
+	getDueMaintenanceTasks      func(context.Context) ([]maintenance.MaintenanceTask, error)
+	updateMaintenanceTaskByID   func(context.Context, int, *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error)
+	completeMaintenanceTaskByID func(context.Context, int) (*maintenance.MaintenanceTask, error)
+	deleteMaintenanceTaskByID   func(context.Context, int) error
+	ping                        func(context.Context) error
+}
+
+var _ maintenance.Service = (*fakeMaintenanceService)(nil)
+
+func (f *fakeMaintenanceService) CreateMaintenanceTask(ctx context.Context, value *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+	if f.createMaintenanceTask == nil {
+		f.t.Fatalf("unexpected CreateMaintenanceTask call")
+		return nil, nil
+	}
+	return f.createMaintenanceTask(ctx, value)
+}
+
+func (f *fakeMaintenanceService) GetMaintenanceTaskById(ctx context.Context, id int) (*maintenance.MaintenanceTask, error) {
+	if f.getMaintenanceTaskByID == nil {
+		f.t.Fatalf("unexpected GetMaintenanceTaskById call")
+		return nil, nil
+	}
+	return f.getMaintenanceTaskByID(ctx, id)
+}
+
+func (f *fakeMaintenanceService) GetAllMaintenanceTasks(ctx context.Context) ([]maintenance.MaintenanceTask, error) {
+	if f.getAllMaintenanceTasks == nil {
+		f.t.Fatalf("unexpected GetAllMaintenanceTasks call")
+		return nil, nil
+	}
+	return f.getAllMaintenanceTasks(ctx)
+}
+
+func (f *fakeMaintenanceService) GetDueMaintenanceTasks(ctx context.Context) ([]maintenance.MaintenanceTask, error) {
+	if f.getDueMaintenanceTasks == nil {
+		f.t.Fatalf("unexpected GetDueMaintenanceTasks call")
+		return nil, nil
+	}
+	return f.getDueMaintenanceTasks(ctx)
+}
+
+func (f *fakeMaintenanceService) UpdateMaintenanceTaskById(ctx context.Context, id int, value *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+	if f.updateMaintenanceTaskByID == nil {
+		f.t.Fatalf("unexpected UpdateMaintenanceTaskById call")
+		return nil, nil
+	}
+	return f.updateMaintenanceTaskByID(ctx, id, value)
+}
+
+func (f *fakeMaintenanceService) CompleteMaintenanceTaskById(ctx context.Context, id int) (*maintenance.MaintenanceTask, error) {
+	if f.completeMaintenanceTaskByID == nil {
+		f.t.Fatalf("unexpected CompleteMaintenanceTaskById call")
+		return nil, nil
+	}
+	return f.completeMaintenanceTaskByID(ctx, id)
+}
+
+func (f *fakeMaintenanceService) DeleteMaintenanceTaskById(ctx context.Context, id int) error {
+	if f.deleteMaintenanceTaskByID == nil {
+		f.t.Fatalf("unexpected DeleteMaintenanceTaskById call")
+		return nil
+	}
+	return f.deleteMaintenanceTaskByID(ctx, id)
+}
+
+func (f *fakeMaintenanceService) Ping(ctx context.Context) error {
+	if f.ping == nil {
+		f.t.Fatalf("unexpected maintenance Ping call")
+		return nil
+	}
+	return f.ping(ctx)
+}
+
+type fakeShareLinkService struct {
+	t                      *testing.T
+	createShareLink        func(context.Context, int, *time.Time) (*share.ShareLink, error)
+	getShareLinksBySheetID func(context.Context, int) ([]share.ShareLink, error)
+	revokeShareLinkByID    func(context.Context, int) error
+	resolveToken           func(context.Context, string) (*share.ShareLink, error)
+	ping                   func(context.Context) error
+}
+
+var _ share.Service = (*fakeShareLinkService)(nil)
+
+func (f *fakeShareLinkService) CreateShareLink(ctx context.Context, sheetId int, expiresAt *time.Time) (*share.ShareLink, error) {
+	if f.createShareLink == nil {
+		f.t.Fatalf("unexpected CreateShareLink call")
+		return nil, nil
+	}
+	return f.createShareLink(ctx, sheetId, expiresAt)
+}
+
+func (f *fakeShareLinkService) GetShareLinksBySheetId(ctx context.Context, sheetId int) ([]share.ShareLink, error) {
+	if f.getShareLinksBySheetID == nil {
+		f.t.Fatalf("unexpected GetShareLinksBySheetId call")
+		return nil, nil
+	}
+	return f.getShareLinksBySheetID(ctx, sheetId)
+}
+
+func (f *fakeShareLinkService) RevokeShareLinkById(ctx context.Context, id int) error {
+	if f.revokeShareLinkByID == nil {
+		f.t.Fatalf("unexpected RevokeShareLinkById call")
+		return nil
+	}
+	return f.revokeShareLinkByID(ctx, id)
+}
+
+func (f *fakeShareLinkService) ResolveToken(ctx context.Context, token string) (*share.ShareLink, error) {
+	if f.resolveToken == nil {
+		f.t.Fatalf("unexpected ResolveToken call")
+		return nil, nil
+	}
+	return f.resolveToken(ctx, token)
+}
+
+func (f *fakeShareLinkService) Ping(ctx context.Context) error {
+	if f.ping == nil {
+		f.t.Fatalf("unexpected share link Ping call")
+		return nil
+	}
+	return f.ping(ctx)
+}
+
+type fakeAttachmentService struct {
+	t                       *testing.T
+	createShotAttachment    func(context.Context, int, string, []byte) (*attachment.Attachment, error)
+	createBeansAttachment   func(context.Context, int, string, []byte) (*attachment.Attachment, error)
+	getAttachmentByID       func(context.Context, int) (*attachment.Attachment, error)
+	getAttachmentsByShotID  func(context.Context, int) ([]attachment.Attachment, error)
+	getAttachmentsByBeansID func(context.Context, int) ([]attachment.Attachment, error)
+	getAttachmentContent    func(context.Context, int, bool) (*attachment.Content, error)
+	deleteAttachmentByID    func(context.Context, int) error
+	ping                    func(context.Context) error
+}
+
+var _ attachment.Service = (*fakeAttachmentService)(nil)
+
+func (f *fakeAttachmentService) CreateShotAttachment(ctx context.Context, shotId int, filename string, data []byte) (*attachment.Attachment, error) {
+	if f.createShotAttachment == nil {
+		f.t.Fatalf("unexpected CreateShotAttachment call")
+		return nil, nil
+	}
+	return f.createShotAttachment(ctx, shotId, filename, data)
+}
+
+func (f *fakeAttachmentService) CreateBeansAttachment(ctx context.Context, beansId int, filename string, data []byte) (*attachment.Attachment, error) {
+	if f.createBeansAttachment == nil {
+		f.t.Fatalf("unexpected CreateBeansAttachment call")
+		return nil, nil
+	}
+	return f.createBeansAttachment(ctx, beansId, filename, data)
+}
+
+func (f *fakeAttachmentService) GetAttachmentById(ctx context.Context, id int) (*attachment.Attachment, error) {
+	if f.getAttachmentByID == nil {
+		f.t.Fatalf("unexpected GetAttachmentById call")
+		return nil, nil
+	}
+	return f.getAttachmentByID(ctx, id)
+}
+
+func (f *fakeAttachmentService) GetAttachmentsByShotId(ctx context.Context, shotId int) ([]attachment.Attachment, error) {
+	if f.getAttachmentsByShotID == nil {
+		f.t.Fatalf("unexpected GetAttachmentsByShotId call")
+		return nil, nil
+	}
+	return f.getAttachmentsByShotID(ctx, shotId)
+}
+
+func (f *fakeAttachmentService) GetAttachmentsByBeansId(ctx context.Context, beansId int) ([]attachment.Attachment, error) {
+	if f.getAttachmentsByBeansID == nil {
+		f.t.Fatalf("unexpected GetAttachmentsByBeansId call")
+		return nil, nil
+	}
+	return f.getAttachmentsByBeansID(ctx, beansId)
+}
+
+func (f *fakeAttachmentService) GetAttachmentContent(ctx context.Context, id int, thumbnail bool) (*attachment.Content, error) {
+	if f.getAttachmentContent == nil {
+		f.t.Fatalf("unexpected GetAttachmentContent call")
+		return nil, nil
+	}
+	return f.getAttachmentContent(ctx, id, thumbnail)
+}
+
+func (f *fakeAttachmentService) DeleteAttachmentById(ctx context.Context, id int) error {
+	if f.deleteAttachmentByID == nil {
+		f.t.Fatalf("unexpected DeleteAttachmentById call")
+		return nil
+	}
+	return f.deleteAttachmentByID(ctx, id)
+}
+
+func (f *fakeAttachmentService) Ping(ctx context.Context) error {
+	if f.ping == nil {
+		f.t.Fatalf("unexpected attachment Ping call")
+		return nil
+	}
+	return f.ping(ctx)
+}
+
+type fakeDecentService struct {
+	t           *testing.T
+	importShots func(context.Context, []decent.File, decent.Options) (*decent.Report, error)
+}
+
+var _ decent.Service = (*fakeDecentService)(nil)
+
+func (f *fakeDecentService) Import(ctx context.Context, files []decent.File, opts decent.Options) (*decent.Report, error) {
+	if f.importShots == nil {
+		f.t.Fatalf("unexpected Import call")
+		return nil, nil
+	}
+	return f.importShots(ctx, files, opts)
+}
+
+type fakeBeanconquerorService struct {
+	t            *testing.T
+	importBackup func(context.Context, []byte) (*beanconqueror.Report, error)
+	exportBackup func(context.Context) (*beanconqueror.Backup, error)
+}
+
+var _ beanconqueror.Service = (*fakeBeanconquerorService)(nil)
+
+func (f *fakeBeanconquerorService) Import(ctx context.Context, data []byte) (*beanconqueror.Report, error) {
+	if f.importBackup == nil {
+		f.t.Fatalf("unexpected Import call")
+		return nil, nil
+	}
+	return f.importBackup(ctx, data)
+}
+
+func (f *fakeBeanconquerorService) Export(ctx context.Context) (*beanconqueror.Backup, error) {
+	if f.exportBackup == nil {
+		f.t.Fatalf("unexpected Export call")
+		return nil, nil
+	}
+	return f.exportBackup(ctx)
+}
+
+type fakeSpreadsheetService struct {
+	t         *testing.T
+	exportCSV func(context.Context, spreadsheet.Resource, io.Writer) error
+	importCSV func(context.Context, spreadsheet.Resource, io.Reader) (*spreadsheet.Report, error)
+}
+
+var _ spreadsheet.Service = (*fakeSpreadsheetService)(nil)
+
+func (f *fakeSpreadsheetService) Export(ctx context.Context, resource spreadsheet.Resource, w io.Writer) error {
+	if f.exportCSV == nil {
+		f.t.Fatalf("unexpected Export call")
+		return nil
+	}
+	return f.exportCSV(ctx, resource, w)
+}
+
+func (f *fakeSpreadsheetService) Import(ctx context.Context, resource spreadsheet.Resource, r io.Reader) (*spreadsheet.Report, error) {
+	if f.importCSV == nil {
+		f.t.Fatalf("unexpected Import call")
+		return nil, nil
+	}
+	return f.importCSV(ctx, resource, r)
+}
+
 func newTestHandler(t *testing.T) (*Handler, *fakeSheetService, *fakeRoasterService, *fakeBeanService, *fakeShotService) {
 	t.Helper()
 
@@ -277,7 +903,7 @@ func newTestHandler(t *testing.T) (*Handler, *fakeSheetService, *fakeRoasterServ
 	beanService := &fakeBeanService{t: t}
 	shotService := &fakeShotService{t: t}
 
-	return NewHandler(sheetService, roasterService, beanService, shotService, 64), sheetService, roasterService, beanService, shotService
+	return NewHandler(sheetService, roasterService, beanService, shotService, &fakeCuppingService{t: t}, &fakeRoastBatchService{t: t}, &fakeGreenCoffeeService{t: t}, &fakeReportService{t: t}, &fakeStatsService{t: t}, &fakeMaintenanceService{t: t}, &fakeShareLinkService{t: t}, &fakeAttachmentService{t: t}, &fakeDecentService{t: t}, &fakeBeanconquerorService{t: t}, &fakeSpreadsheetService{t: t}, 64), sheetService, roasterService, beanService, shotService
 }
 
 func newControllerRequest(t *testing.T, method, target, body, contentType, id string) *http.Request {
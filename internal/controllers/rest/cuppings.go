@@ -0,0 +1,641 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/cupping"
+	"github.com/rs/zerolog/hlog"
+)
+
+// swagger:parameters createCuppingSession updateCuppingSessionById
+type CuppingSessionParams struct {
+	// The request body for creating or updating a cupping session
+	// in: body
+	// required: true
+	Body CuppingSessionRequest
+}
+
+// CuppingSessionRequest represents the request body for creating or
+// updating a cupping session
+// swagger:model
+type CuppingSessionRequest struct {
+	SessionDate  *RoastDate `json:"session_date"`
+	Participants []string   `json:"participants"`
+}
+
+// CuppingSessionResponse represents a cupping session for this application
+//
+// A cupping session has a date, participants and, when fetched by id,
+// the SCA score entries recorded during the session.
+//
+// swagger:response CuppingSessionResponse
+type CuppingSessionResponse struct {
+	// swagger:allOf
+	cupping.CuppingSession
+}
+
+// swagger:parameters createCuppingScore updateCuppingScoreById
+type CuppingScoreParams struct {
+	// The request body for creating or updating a cupping score
+	// in: body
+	// required: true
+	Body CuppingScoreRequest
+}
+
+// CuppingScoreRequest represents the request body for creating or updating
+// a cupping score. Every attribute must be between 0.0 and 10.0.
+// swagger:model
+type CuppingScoreRequest struct {
+	BeansId    int     `json:"beans_id"`
+	Fragrance  float64 `json:"fragrance"`
+	Flavor     float64 `json:"flavor"`
+	Aftertaste float64 `json:"aftertaste"`
+	Acidity    float64 `json:"acidity"`
+	Body       float64 `json:"body"`
+	Balance    float64 `json:"balance"`
+	Uniformity float64 `json:"uniformity"`
+	CleanCup   float64 `json:"clean_cup"`
+	Sweetness  float64 `json:"sweetness"`
+	Overall    float64 `json:"overall"`
+	Notes      string  `json:"notes"`
+}
+
+// CuppingScoreResponse represents a cupping score for this application
+//
+// A cupping score is the SCA form entry of some beans in a session,
+// with its computed total.
+//
+// swagger:response CuppingScoreResponse
+type CuppingScoreResponse struct {
+	// swagger:allOf
+	cupping.CuppingScore
+}
+
+func (req CuppingScoreRequest) toCuppingScore() *cupping.CuppingScore {
+	return &cupping.CuppingScore{
+		Beans:      &bean.Bean{Id: req.BeansId},
+		Fragrance:  req.Fragrance,
+		Flavor:     req.Flavor,
+		Aftertaste: req.Aftertaste,
+		Acidity:    req.Acidity,
+		Body:       req.Body,
+		Balance:    req.Balance,
+		Uniformity: req.Uniformity,
+		CleanCup:   req.CleanCup,
+		Sweetness:  req.Sweetness,
+		Overall:    req.Overall,
+		Notes:      req.Notes,
+	}
+}
+
+// swagger:route POST /rest/v1/cupping_sessions cupping_sessions createCuppingSession
+//
+// # Create a cupping session
+//
+// This will create a new cupping session.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  201: CuppingSessionResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  413: ErrorResponse
+func (h *Handler) CreateCuppingSession(w http.ResponseWriter, r *http.Request) {
+	var req CuppingSessionRequest
+
+	if err := h.parseContentType(r); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := jsonDecodeBody(r, &req); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	session, err := h.CuppingService.CreateCuppingSession(r.Context(), &cupping.CuppingSession{
+		SessionDate:  (*time.Time)(req.SessionDate),
+		Participants: req.Participants,
+	})
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("cupping_session_id", session.Id).Msg("cupping session successfully created")
+
+	h.writeJSONResponse(w, http.StatusCreated, CuppingSessionResponse{*session})
+}
+
+// swagger:route GET /rest/v1/cupping_sessions/{id} cupping_sessions getCuppingSession
+//
+// # Get a cupping session
+//
+// This will get the cupping session with the given id, including its score entries.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the cupping session to get
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: CuppingSessionResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetCuppingSessionById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	session, err := h.CuppingService.GetCuppingSessionById(r.Context(), id)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, CuppingSessionResponse{*session})
+}
+
+// swagger:route GET /rest/v1/cupping_sessions cupping_sessions getAllCuppingSessions
+//
+// # Get all cupping sessions
+//
+// This will show all cupping sessions, without their score entries.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: CuppingSessionResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+func (h *Handler) GetAllCuppingSessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := h.CuppingService.GetAllCuppingSessions(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	resp := make([]CuppingSessionResponse, len(sessions))
+	for k, v := range sessions {
+		resp[k] = CuppingSessionResponse{v}
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, &resp)
+}
+
+// swagger:route PUT /rest/v1/cupping_sessions/{id} cupping_sessions updateCuppingSessionById
+//
+// # Update a cupping session
+//
+// This will update a cupping session by its given id.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the cupping session to update
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: CuppingSessionResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+//	  413: ErrorResponse
+func (h *Handler) UpdateCuppingSessionById(w http.ResponseWriter, r *http.Request) {
+	var req CuppingSessionRequest
+
+	if err := h.parseContentType(r); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := jsonDecodeBody(r, &req); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	session, err := h.CuppingService.UpdateCuppingSessionById(r.Context(), id, &cupping.CuppingSession{
+		Id:           id,
+		SessionDate:  (*time.Time)(req.SessionDate),
+		Participants: req.Participants,
+	})
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("cupping_session_id", session.Id).Msg("cupping session successfully updated")
+
+	h.writeJSONResponse(w, http.StatusOK, CuppingSessionResponse{*session})
+}
+
+// swagger:route DELETE /rest/v1/cupping_sessions/{id} cupping_sessions deleteCuppingSession
+//
+// # Delete a cupping session
+//
+// This will delete a cupping session, and its score entries, by its given id.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the cupping session to delete
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: ItemDeletedResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) DeleteCuppingSessionById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := h.CuppingService.DeleteCuppingSessionById(r.Context(), id); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Msg("cupping session successfully deleted")
+
+	h.writeJSONResponse(w, http.StatusOK, ItemDeletedResponse{
+		Id:  id,
+		Msg: fmt.Sprintf("cupping session %d deleted successfully", id),
+	})
+}
+
+// swagger:route POST /rest/v1/cupping_sessions/{id}/scores cupping_scores createCuppingScore
+//
+// # Create a cupping score
+//
+// This will add the SCA score entry of some beans to the cupping session with the given id.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the cupping session to add the score to
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  201: CuppingScoreResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+//	  409: ErrorResponse
+//	  413: ErrorResponse
+func (h *Handler) CreateCuppingScore(w http.ResponseWriter, r *http.Request) {
+	var req CuppingScoreRequest
+
+	if err := h.parseContentType(r); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := jsonDecodeBody(r, &req); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	sessionId, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	score := req.toCuppingScore()
+	score.SessionId = sessionId
+
+	score, err = h.CuppingService.CreateCuppingScore(r.Context(), score)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("cupping_score_id", score.Id).Float64("total", score.Total).Msg("cupping score successfully created")
+
+	h.writeJSONResponse(w, http.StatusCreated, CuppingScoreResponse{*score})
+}
+
+// swagger:route GET /rest/v1/cupping_scores/{id} cupping_scores getCuppingScore
+//
+// # Get a cupping score
+//
+// This will get the cupping score with the given id.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the cupping score to get
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: CuppingScoreResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetCuppingScoreById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	score, err := h.CuppingService.GetCuppingScoreById(r.Context(), id)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, CuppingScoreResponse{*score})
+}
+
+// swagger:route GET /rest/v1/beans/{id}/cupping_scores cupping_scores getCuppingScoresByBeansId
+//
+// # Get cupping scores by beans
+//
+// This will show every cupping score recorded for the beans with the given id.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the beans
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: CuppingScoreResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+func (h *Handler) GetCuppingScoresByBeansId(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	scores, err := h.CuppingService.GetCuppingScoresByBeansId(r.Context(), id)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	resp := make([]CuppingScoreResponse, len(scores))
+	for k, v := range scores {
+		resp[k] = CuppingScoreResponse{v}
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, &resp)
+}
+
+// swagger:route PUT /rest/v1/cupping_scores/{id} cupping_scores updateCuppingScoreById
+//
+// # Update a cupping score
+//
+// This will update a cupping score by its given id.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the cupping score to update
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: CuppingScoreResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+//	  409: ErrorResponse
+//	  413: ErrorResponse
+func (h *Handler) UpdateCuppingScoreById(w http.ResponseWriter, r *http.Request) {
+	var req CuppingScoreRequest
+
+	if err := h.parseContentType(r); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := jsonDecodeBody(r, &req); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	score, err := h.CuppingService.UpdateCuppingScoreById(r.Context(), id, req.toCuppingScore())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("cupping_score_id", score.Id).Float64("total", score.Total).Msg("cupping score successfully updated")
+
+	h.writeJSONResponse(w, http.StatusOK, CuppingScoreResponse{*score})
+}
+
+// swagger:route DELETE /rest/v1/cupping_scores/{id} cupping_scores deleteCuppingScore
+//
+// # Delete a cupping score
+//
+// This will delete a cupping score by its given id.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the cupping score to delete
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: ItemDeletedResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) DeleteCuppingScoreById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := h.CuppingService.DeleteCuppingScoreById(r.Context(), id); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Msg("cupping score successfully deleted")
+
+	h.writeJSONResponse(w, http.StatusOK, ItemDeletedResponse{
+		Id:  id,
+		Msg: fmt.Sprintf("cupping score %d deleted successfully", id),
+	})
+}
@@ -0,0 +1,228 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/cupping"
+)
+
+func testCuppingSession(id int) *cupping.CuppingSession {
+	createdAt := time.Date(2026, time.January, 6, 3, 4, 5, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	sessionDate := time.Date(2026, time.January, 6, 0, 0, 0, 0, time.UTC)
+	return &cupping.CuppingSession{
+		Id:           id,
+		SessionDate:  &sessionDate,
+		Participants: []string{"alice", "bob"},
+		CreatedAt:    &createdAt,
+		UpdatedAt:    &updatedAt,
+	}
+}
+
+func testCuppingScore(id int) *cupping.CuppingScore {
+	createdAt := time.Date(2026, time.January, 6, 3, 4, 5, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	score := &cupping.CuppingScore{
+		Id: id, SessionId: 3, Beans: testBean(4, "test beans"),
+		Fragrance: 8, Flavor: 8.25, Aftertaste: 7.75, Acidity: 8, Body: 7.5,
+		Balance: 8, Uniformity: 10, CleanCup: 10, Sweetness: 10, Overall: 8,
+		Notes:     "stone fruit",
+		CreatedAt: &createdAt, UpdatedAt: &updatedAt,
+	}
+	score.Total = score.ComputeTotal()
+	return score
+}
+
+func newCuppingTestHandler(t *testing.T) (*Handler, *fakeCuppingService) {
+	t.Helper()
+
+	handler, _, _, _, _ := newTestHandler(t)
+
+	return handler, handler.CuppingService.(*fakeCuppingService)
+}
+
+func TestCuppingHandlersHappyPaths(t *testing.T) {
+	expectedSessionDate := time.Date(2026, time.January, 6, 0, 0, 0, 0, time.UTC)
+	session := testCuppingSession(1)
+	score := testCuppingScore(2)
+	scoreBody := `{"beans_id":4,"fragrance":8,"flavor":8.25,"aftertaste":7.75,"acidity":8,"body":7.5,"balance":8,"uniformity":10,"clean_cup":10,"sweetness":10,"overall":8,"notes":"stone fruit"}`
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		body      string
+		id        string
+		status    int
+		expected  any
+		configure func(*testing.T, *fakeCuppingService)
+		handler   controllerHandler
+	}{
+		{
+			name: "create session", method: http.MethodPost, target: "/rest/v1/cupping_sessions", body: `{"session_date":"2026-01-06","participants":["alice","bob"]}`,
+			status: http.StatusCreated, expected: CuppingSessionResponse{*session}, handler: (*Handler).CreateCuppingSession,
+			configure: func(t *testing.T, service *fakeCuppingService) {
+				service.createCuppingSession = func(_ context.Context, value *cupping.CuppingSession) (*cupping.CuppingSession, error) {
+					if value.SessionDate == nil || !value.SessionDate.Equal(expectedSessionDate) {
+						t.Errorf("session date = %v, want %v", value.SessionDate, expectedSessionDate)
+					}
+					if len(value.Participants) != 2 {
+						t.Errorf("participants = %v, want 2 entries", value.Participants)
+					}
+					return session, nil
+				}
+			},
+		},
+		{
+			name: "get all sessions", method: http.MethodGet, target: "/rest/v1/cupping_sessions",
+			status: http.StatusOK, expected: []CuppingSessionResponse{{*session}}, handler: (*Handler).GetAllCuppingSessions,
+			configure: func(_ *testing.T, service *fakeCuppingService) {
+				service.getAllCuppingSessions = func(context.Context) ([]cupping.CuppingSession, error) {
+					return []cupping.CuppingSession{*session}, nil
+				}
+			},
+		},
+		{
+			name: "delete session", method: http.MethodDelete, target: "/rest/v1/cupping_sessions/1", id: "1",
+			status: http.StatusOK, expected: ItemDeletedResponse{Id: 1, Msg: "cupping session 1 deleted successfully"}, handler: (*Handler).DeleteCuppingSessionById,
+			configure: func(_ *testing.T, service *fakeCuppingService) {
+				service.deleteCuppingSessionByID = func(context.Context, int) error { return nil }
+			},
+		},
+		{
+			name: "create score", method: http.MethodPost, target: "/rest/v1/cupping_sessions/3/scores", body: scoreBody, id: "3",
+			status: http.StatusCreated, expected: CuppingScoreResponse{*score}, handler: (*Handler).CreateCuppingScore,
+			configure: func(t *testing.T, service *fakeCuppingService) {
+				service.createCuppingScore = func(_ context.Context, value *cupping.CuppingScore) (*cupping.CuppingScore, error) {
+					if value.SessionId != 3 {
+						t.Errorf("session id = %d, want 3", value.SessionId)
+					}
+					if value.Beans == nil || value.Beans.Id != 4 {
+						t.Errorf("beans = %#v, want id 4", value.Beans)
+					}
+					if value.Flavor != 8.25 || value.CleanCup != 10 || value.Notes != "stone fruit" {
+						t.Errorf("score = %#v, want decoded attributes", value)
+					}
+					return score, nil
+				}
+			},
+		},
+		{
+			name: "get scores by beans", method: http.MethodGet, target: "/rest/v1/beans/4/cupping_scores", id: "4",
+			status: http.StatusOK, expected: []CuppingScoreResponse{{*score}}, handler: (*Handler).GetCuppingScoresByBeansId,
+			configure: func(t *testing.T, service *fakeCuppingService) {
+				service.getCuppingScoresByBeans = func(_ context.Context, id int) ([]cupping.CuppingScore, error) {
+					if id != 4 {
+						t.Errorf("beans id = %d, want 4", id)
+					}
+					return []cupping.CuppingScore{*score}, nil
+				}
+			},
+		},
+		{
+			name: "update score", method: http.MethodPut, target: "/rest/v1/cupping_scores/2", body: scoreBody, id: "2",
+			status: http.StatusOK, expected: CuppingScoreResponse{*score}, handler: (*Handler).UpdateCuppingScoreById,
+			configure: func(t *testing.T, service *fakeCuppingService) {
+				service.updateCuppingScoreByID = func(_ context.Context, id int, _ *cupping.CuppingScore) (*cupping.CuppingScore, error) {
+					if id != 2 {
+						t.Errorf("id = %d, want 2", id)
+					}
+					return score, nil
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newCuppingTestHandler(t)
+			tt.configure(t, service)
+			contentType := ""
+			if tt.body != "" {
+				contentType = ContentTypeApplicationJSON
+			}
+			req := newControllerRequest(t, tt.method, tt.target, tt.body, contentType, tt.id)
+
+			recorder := executeControllerHandler(handler, tt.handler, req)
+
+			assertJSONResponse(t, recorder, tt.status, tt.expected)
+		})
+	}
+}
+
+func TestCuppingHandlersErrorPaths(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		body      string
+		id        string
+		status    int
+		message   string
+		configure func(*fakeCuppingService)
+		handler   controllerHandler
+	}{
+		{
+			name: "create session without date", method: http.MethodPost, target: "/rest/v1/cupping_sessions", body: `{"participants":["alice"]}`,
+			status: http.StatusBadRequest, message: "cupping session date must not be empty", handler: (*Handler).CreateCuppingSession,
+			configure: func(service *fakeCuppingService) {
+				service.createCuppingSession = func(context.Context, *cupping.CuppingSession) (*cupping.CuppingSession, error) {
+					return nil, domainerrors.ErrCuppingSessionDateIsEmpty
+				}
+			},
+		},
+		{
+			name: "get missing session", method: http.MethodGet, target: "/rest/v1/cupping_sessions/5", id: "5",
+			status: http.StatusNotFound, message: "no cupping session found for given id", handler: (*Handler).GetCuppingSessionById,
+			configure: func(service *fakeCuppingService) {
+				service.getCuppingSessionByID = func(context.Context, int) (*cupping.CuppingSession, error) {
+					return nil, domainerrors.ErrCuppingSessionDoesNotExist
+				}
+			},
+		},
+		{
+			name: "create score out of range", method: http.MethodPost, target: "/rest/v1/cupping_sessions/3/scores", body: `{"beans_id":4,"fragrance":11}`, id: "3",
+			status: http.StatusBadRequest, message: "cupping score is out of range. Each attribute must be between 0.0 and 10.0", handler: (*Handler).CreateCuppingScore,
+			configure: func(service *fakeCuppingService) {
+				service.createCuppingScore = func(context.Context, *cupping.CuppingScore) (*cupping.CuppingScore, error) {
+					return nil, domainerrors.ErrCuppingScoreOutOfRange
+				}
+			},
+		},
+		{
+			name: "create duplicate score", method: http.MethodPost, target: "/rest/v1/cupping_sessions/3/scores", body: `{"beans_id":4}`, id: "3",
+			status: http.StatusConflict, message: "these beans already have a score in this cupping session", handler: (*Handler).CreateCuppingScore,
+			configure: func(service *fakeCuppingService) {
+				service.createCuppingScore = func(context.Context, *cupping.CuppingScore) (*cupping.CuppingScore, error) {
+					return nil, domainerrors.ErrCuppingScoreAlreadyExists
+				}
+			},
+		},
+		{
+			name: "delete missing score", method: http.MethodDelete, target: "/rest/v1/cupping_scores/5", id: "5",
+			status: http.StatusNotFound, message: "no cupping score found for given id", handler: (*Handler).DeleteCuppingScoreById,
+			configure: func(service *fakeCuppingService) {
+				service.deleteCuppingScoreByID = func(context.Context, int) error { return domainerrors.ErrCuppingScoreDoesNotExist }
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newCuppingTestHandler(t)
+			tt.configure(service)
+			contentType := ""
+			if tt.body != "" {
+				contentType = ContentTypeApplicationJSON
+			}
+			req := newControllerRequest(t, tt.method, tt.target, tt.body, contentType, tt.id)
+
+			recorder := executeControllerHandler(handler, tt.handler, req)
+
+			assertJSONResponse(t, recorder, tt.status, ErrorResponse{Msg: tt.message})
+		})
+	}
+}
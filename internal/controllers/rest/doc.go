@@ -0,0 +1,19 @@
+// Package rest implements the /rest/v1 JSON API.
+//
+// Routes under /rest/v1 require an api key, sent in the X-API-Key header or
+// as "Authorization: Bearer <key>".
+//
+//	SecurityDefinitions:
+//	  api_key:
+//	    type: apiKey
+//	    name: X-API-Key
+//	    in: header
+//	    description: API key created with `espressoapi-go apikeys create`. It can also be sent as `Authorization: Bearer <key>`.
+//	  oauth:
+//	    type: apiKey
+//	    name: Authorization
+//	    in: header
+//	    description: Access token (JWT) issued by the OpenID Connect provider configured with `OIDC_ISSUER_URL`, sent as `Authorization: Bearer <token>`.
+//
+// swagger:meta
+package rest
@@ -15,6 +15,9 @@ import (
 var (
 	ErrIDNotFound   = NewErrorResponse(http.StatusBadRequest, "id cannot be empty")
 	ErrIDNotInteger = NewErrorResponse(http.StatusBadRequest, "id must be an integer")
+
+	ErrAPIKeyMissing  = NewErrorResponse(http.StatusUnauthorized, "missing api key. Use the Authorization: Bearer or X-API-Key header")
+	ErrAPIKeyReadOnly = NewErrorResponse(http.StatusForbidden, "api key is read-only")
 )
 
 // ErrorResponse represents the json response
@@ -71,6 +74,12 @@ var domainErrorResponses = map[error]ErrorResponse{
 	domainerrors.ErrShotComparisonWithPreviousResultOutOfRange: {status: http.StatusBadRequest, Msg: "shot comparison with previous result is out of range. Must be between 0 and 3"},
 	// Catch if the shot time is out of range
 	domainerrors.ErrShotTimeOutOfRange: {status: http.StatusBadRequest, Msg: "shot time is out of range. Must be between 0 and 3600 seconds"},
+	// Catch if the shot profile samples are invalid
+	domainerrors.ErrShotProfileIsInvalid: {status: http.StatusBadRequest, Msg: "shot profile is invalid. Sample times must be distinct and between 0 and 3600 seconds, and values must not be negative"},
+	// Catch if the shot profile has too many samples
+	domainerrors.ErrShotProfileHasTooManySamples: {status: http.StatusBadRequest, Msg: "shot profile has too many samples. Must be at most 10000"},
+	// Catch if the shot ids to compare are invalid
+	domainerrors.ErrShotCompareIdsAreInvalid: {status: http.StatusBadRequest, Msg: "shot ids to compare are invalid. Must be between 2 and 6 distinct positive ids, separated by commas"},
 	// Catch if the beans roast level is out of range
 	domainerrors.ErrBeansRoastLevelOutOfRange: {status: http.StatusBadRequest, Msg: "beans roast level is out of range. Must be between 0 and 4"},
 	// Catch if the beans foreign key constraint failed
@@ -79,6 +88,108 @@ var domainErrorResponses = map[error]ErrorResponse{
 	domainerrors.ErrShotForeignKeyConstraint: {status: http.StatusBadRequest, Msg: "cannot delete due to existing references: shot foreign key constraint failed"},
 	// Catch if the beans name is empty
 	domainerrors.ErrBeansNameIsEmpty: {status: http.StatusBadRequest, Msg: "beans name must not be empty"},
+	// Catch if the beans green weight is out of range
+	domainerrors.ErrBeansGreenWeightOutOfRange: {status: http.StatusBadRequest, Msg: "beans green weight is out of range. Must not be negative"},
+	// Catch if the beans price is out of range
+	domainerrors.ErrBeansPriceOutOfRange: {status: http.StatusBadRequest, Msg: "beans price is out of range. Must not be negative"},
+	// Catch if the beans bag weight is out of range
+	domainerrors.ErrBeansBagWeightOutOfRange: {status: http.StatusBadRequest, Msg: "beans bag weight is out of range. Must not be negative"},
+	// Catch if the beans currency is invalid
+	domainerrors.ErrBeansCurrencyIsInvalid: {status: http.StatusBadRequest, Msg: "beans currency is invalid. Must be a three-letter ISO 4217 code"},
+	// Catch if the cupping session does not exist
+	domainerrors.ErrCuppingSessionDoesNotExist: {status: http.StatusNotFound, Msg: "no cupping session found for given id"},
+	// Catch if the cupping session date is empty
+	domainerrors.ErrCuppingSessionDateIsEmpty: {status: http.StatusBadRequest, Msg: "cupping session date must not be empty"},
+	// Catch if the cupping score does not exist
+	domainerrors.ErrCuppingScoreDoesNotExist: {status: http.StatusNotFound, Msg: "no cupping score found for given id"},
+	// Catch if the beans were already scored in the session
+	domainerrors.ErrCuppingScoreAlreadyExists: {status: http.StatusConflict, Msg: "these beans already have a score in this cupping session"},
+	// Catch if a cupping score attribute is out of range
+	domainerrors.ErrCuppingScoreOutOfRange: {status: http.StatusBadRequest, Msg: "cupping score is out of range. Each attribute must be between 0.0 and 10.0"},
+	// Catch if the cupping score foreign key constraint failed
+	domainerrors.ErrCuppingScoreForeignKeyConstraint: {status: http.StatusBadRequest, Msg: "cannot delete due to existing references: cupping score foreign key constraint failed"},
+	// Catch if the roast batch does not exist
+	domainerrors.ErrRoastBatchDoesNotExist: {status: http.StatusNotFound, Msg: "no roast batch found for given id"},
+	// Catch if the roast batch green coffee is empty
+	domainerrors.ErrRoastBatchGreenCoffeeIsEmpty: {status: http.StatusBadRequest, Msg: "roast batch green coffee must not be empty"},
+	// Catch if the roast batch roast date is empty
+	domainerrors.ErrRoastBatchRoastDateIsEmpty: {status: http.StatusBadRequest, Msg: "roast batch roast date must not be empty"},
+	// Catch if the roast batch roast level is out of range
+	domainerrors.ErrRoastBatchRoastLevelOutOfRange: {status: http.StatusBadRequest, Msg: "roast batch roast level is out of range. Must be between 0 and 4"},
+	// Catch if the roast batch weights are out of range
+	domainerrors.ErrRoastBatchWeightOutOfRange: {status: http.StatusBadRequest, Msg: "roast batch weight is out of range. Green weight must be positive and roasted weight between 0 and green weight"},
+	// Catch if the roast batch times are out of range
+	domainerrors.ErrRoastBatchTimeOutOfRange: {status: http.StatusBadRequest, Msg: "roast batch time is out of range. First crack and development times must not be negative"},
+	// Catch if the roast batch curve is invalid
+	domainerrors.ErrRoastBatchCurveIsInvalid: {status: http.StatusBadRequest, Msg: "roast batch curve is invalid. Point times must be distinct and not negative"},
+	// Catch if beans were already generated from the roast batch
+	domainerrors.ErrRoastBatchBeansAlreadyExist: {status: http.StatusConflict, Msg: "beans were already generated from this roast batch"},
+	// Catch if the roast batch foreign key constraint failed
+	domainerrors.ErrRoastBatchForeignKeyConstraint: {status: http.StatusBadRequest, Msg: "cannot delete due to existing references: roast batch foreign key constraint failed"},
+	// Catch if the green coffee does not exist
+	domainerrors.ErrGreenCoffeeDoesNotExist: {status: http.StatusNotFound, Msg: "no green coffee found for given id"},
+	// Catch if the green coffee origin is empty
+	domainerrors.ErrGreenCoffeeOriginIsEmpty: {status: http.StatusBadRequest, Msg: "green coffee origin must not be empty"},
+	// Catch if the green coffee purchase weight is out of range
+	domainerrors.ErrGreenCoffeePurchaseWeightOutOfRange: {status: http.StatusBadRequest, Msg: "green coffee purchase weight is out of range. Must be positive"},
+	// Catch if the green coffee price is out of range
+	domainerrors.ErrGreenCoffeePriceOutOfRange: {status: http.StatusBadRequest, Msg: "green coffee price is out of range. Must not be negative"},
+	// Catch if the green coffee moisture is out of range
+	domainerrors.ErrGreenCoffeeMoistureOutOfRange: {status: http.StatusBadRequest, Msg: "green coffee moisture is out of range. Must be between 0 and 100"},
+	// Catch if the spend report group by is invalid
+	domainerrors.ErrSpendReportGroupByIsInvalid: {status: http.StatusBadRequest, Msg: "spend report group by is invalid. Must be one of month, roaster or beans"},
+	// Catch if the spend report range is invalid
+	domainerrors.ErrSpendReportRangeIsInvalid: {status: http.StatusBadRequest, Msg: "spend report range is invalid. From must not be after to"},
+	// Catch if the stats time zone is invalid
+	domainerrors.ErrStatsTimeZoneIsInvalid: {status: http.StatusBadRequest, Msg: "stats time zone is invalid. Must be an IANA time zone name, such as Europe/Paris"},
+	// Catch if the stats range is invalid
+	domainerrors.ErrStatsRangeIsInvalid: {status: http.StatusBadRequest, Msg: "stats range is invalid. From must not be after to"},
+	// Catch if the stats range is too long
+	domainerrors.ErrStatsRangeIsTooLong: {status: http.StatusBadRequest, Msg: "stats range is too long. Must not exceed 366 days"},
+	// Catch if the database does not know the stats time zone
+	domainerrors.ErrStatsTimeZoneIsUnknown: {status: http.StatusInternalServerError, Msg: "stats time zone is unknown to the database. On MySQL, load the time zone tables"},
+	// Catch if the maintenance task does not exist
+	domainerrors.ErrMaintenanceTaskDoesNotExist: {status: http.StatusNotFound, Msg: "no maintenance task found for given id"},
+	// Catch if the maintenance task type is invalid
+	domainerrors.ErrMaintenanceTaskTypeIsInvalid: {status: http.StatusBadRequest, Msg: "maintenance task type is invalid. Must be one of backflush, descale, burr_change, gasket_change, water_filter, grinder_cleaning or other"},
+	// Catch if the maintenance task equipment is invalid
+	domainerrors.ErrMaintenanceTaskEquipmentIsInvalid: {status: http.StatusBadRequest, Msg: "maintenance task equipment is invalid. Must be machine, grinder or empty"},
+	// Catch if the maintenance task interval is out of range
+	domainerrors.ErrMaintenanceTaskIntervalOutOfRange: {status: http.StatusBadRequest, Msg: "maintenance task interval is out of range. A positive number of shots, days or both is required"},
+	// Catch if the maintenance task last done date is in the future
+	domainerrors.ErrMaintenanceTaskLastDoneIsInFuture: {status: http.StatusBadRequest, Msg: "maintenance task last done date is in the future"},
+	// Catch if the share link does not exist
+	domainerrors.ErrShareLinkDoesNotExist: {status: http.StatusNotFound, Msg: "no share link found for given id"},
+	// Catch if the share link expiry is invalid
+	domainerrors.ErrShareLinkExpiryIsInvalid: {status: http.StatusBadRequest, Msg: "share link expiry is invalid. Must be in the future and within 365 days"},
+	// Catch if the attachment does not exist
+	domainerrors.ErrAttachmentDoesNotExist: {status: http.StatusNotFound, Msg: "no attachment found for given id"},
+	// Catch if the uploaded file is empty
+	domainerrors.ErrAttachmentIsEmpty: {status: http.StatusBadRequest, Msg: "attachment must not be empty"},
+	// Catch if the uploaded file is not a supported image
+	domainerrors.ErrAttachmentTypeIsUnsupported: {status: http.StatusUnsupportedMediaType, Msg: "attachment type is unsupported. Must be a JPEG, PNG or GIF image"},
+	// Catch if the uploaded image cannot be decoded
+	domainerrors.ErrAttachmentImageIsInvalid: {status: http.StatusBadRequest, Msg: "attachment image is invalid or could not be decoded"},
+	// Catch if the uploaded image has too many pixels
+	domainerrors.ErrAttachmentImageIsTooLarge: {status: http.StatusBadRequest, Msg: "attachment image is too large. Must not exceed 40 megapixels"},
+	// Catch if an import has no files
+	domainerrors.ErrImportHasNoFiles: {status: http.StatusBadRequest, Msg: "import has no files"},
+	// Catch if an import backup is not a Beanconqueror backup
+	domainerrors.ErrImportBackupIsInvalid: {status: http.StatusBadRequest, Msg: "import backup is invalid. Must be a Beanconqueror JSON backup or its zip archive"},
+	// Catch if an imported csv file is malformed or misses a column
+	domainerrors.ErrCSVIsInvalid: {status: http.StatusBadRequest, Msg: "csv is invalid. Must be comma-separated values with a header row naming the columns"},
+	// Catch if the api key is unknown
+	domainerrors.ErrAPIKeyIsInvalid: {status: http.StatusUnauthorized, Msg: "api key is invalid"},
+	// Catch if the api key was revoked
+	domainerrors.ErrAPIKeyIsRevoked: {status: http.StatusUnauthorized, Msg: "api key is revoked"},
+	// Catch if the owner of the api key is disabled
+	domainerrors.ErrUserIsDisabled: {status: http.StatusUnauthorized, Msg: "user is disabled"},
+	// Catch if the oidc access token is invalid or expired
+	domainerrors.ErrOIDCTokenIsInvalid: {status: http.StatusUnauthorized, Msg: "oidc token is invalid"},
+	// Catch if no user matches the oidc access token
+	domainerrors.ErrOIDCIdentityIsUnknown: {status: http.StatusUnauthorized, Msg: "no user matches the oidc identity"},
+	// Catch if the role of the user does not allow the request
+	domainerrors.ErrPermissionDenied: {status: http.StatusForbidden, Msg: "permission denied. The role of the user does not allow this action"},
 }
 
 // SetErrorResponse will attempt to parse the given error
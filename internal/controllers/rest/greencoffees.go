@@ -0,0 +1,308 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/services/greencoffee"
+	"github.com/rs/zerolog/hlog"
+)
+
+// swagger:parameters createGreenCoffee updateGreenCoffeeById
+type GreenCoffeeParams struct {
+	// The request body for creating or updating a green coffee
+	// in: body
+	// required: true
+	Body GreenCoffeeRequest
+}
+
+// GreenCoffeeRequest represents the request body for creating or updating a
+// green coffee. The purchase weight is in kilograms and the price is the
+// total paid.
+// swagger:model
+type GreenCoffeeRequest struct {
+	Origin         string     `json:"origin"`
+	Supplier       string     `json:"supplier"`
+	PurchaseWeight float64    `json:"purchase_weight"`
+	Price          float64    `json:"price"`
+	ArrivalDate    *RoastDate `json:"arrival_date"`
+	Moisture       float64    `json:"moisture"`
+}
+
+// GreenCoffeeResponse represents a green coffee for this application
+//
+// A green coffee has its origin, supplier, purchase and, computed from the
+// roast batches and beans drawing on it, its remaining weight and cost per
+// kilogram.
+//
+// swagger:response GreenCoffeeResponse
+type GreenCoffeeResponse struct {
+	// swagger:allOf
+	greencoffee.GreenCoffee
+}
+
+func (req GreenCoffeeRequest) toGreenCoffee() *greencoffee.GreenCoffee {
+	return &greencoffee.GreenCoffee{
+		Origin:         req.Origin,
+		Supplier:       req.Supplier,
+		PurchaseWeight: req.PurchaseWeight,
+		Price:          req.Price,
+		ArrivalDate:    (*time.Time)(req.ArrivalDate),
+		Moisture:       req.Moisture,
+	}
+}
+
+// swagger:route POST /rest/v1/green_coffees green_coffees createGreenCoffee
+//
+// # Create a green coffee
+//
+// This will create a new green coffee.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  201: GreenCoffeeResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  413: ErrorResponse
+func (h *Handler) CreateGreenCoffee(w http.ResponseWriter, r *http.Request) {
+	var req GreenCoffeeRequest
+
+	if err := h.parseContentType(r); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := jsonDecodeBody(r, &req); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	greenCoffee, err := h.GreenCoffeeService.CreateGreenCoffee(r.Context(), req.toGreenCoffee())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("green_coffee_id", greenCoffee.Id).Msg("green coffee successfully created")
+
+	h.writeJSONResponse(w, http.StatusCreated, GreenCoffeeResponse{*greenCoffee})
+}
+
+// swagger:route GET /rest/v1/green_coffees/{id} green_coffees getGreenCoffee
+//
+// # Get a green coffee
+//
+// This will get the green coffee with the given id, with its remaining weight and cost per kilogram.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the green coffee to get
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: GreenCoffeeResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetGreenCoffeeById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	greenCoffee, err := h.GreenCoffeeService.GetGreenCoffeeById(r.Context(), id)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, GreenCoffeeResponse{*greenCoffee})
+}
+
+// swagger:route GET /rest/v1/green_coffees green_coffees getAllGreenCoffees
+//
+// # Get all green coffees
+//
+// This will show all green coffees, with their remaining weight and cost per kilogram.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: GreenCoffeeResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+func (h *Handler) GetAllGreenCoffees(w http.ResponseWriter, r *http.Request) {
+	greenCoffees, err := h.GreenCoffeeService.GetAllGreenCoffees(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	resp := make([]GreenCoffeeResponse, len(greenCoffees))
+	for k, v := range greenCoffees {
+		resp[k] = GreenCoffeeResponse{v}
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, &resp)
+}
+
+// swagger:route PUT /rest/v1/green_coffees/{id} green_coffees updateGreenCoffeeById
+//
+// # Update a green coffee
+//
+// This will update a green coffee by its given id.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the green coffee to update
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: GreenCoffeeResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+//	  413: ErrorResponse
+func (h *Handler) UpdateGreenCoffeeById(w http.ResponseWriter, r *http.Request) {
+	var req GreenCoffeeRequest
+
+	if err := h.parseContentType(r); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := jsonDecodeBody(r, &req); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	greenCoffee, err := h.GreenCoffeeService.UpdateGreenCoffeeById(r.Context(), id, req.toGreenCoffee())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("green_coffee_id", greenCoffee.Id).Msg("green coffee successfully updated")
+
+	h.writeJSONResponse(w, http.StatusOK, GreenCoffeeResponse{*greenCoffee})
+}
+
+// swagger:route DELETE /rest/v1/green_coffees/{id} green_coffees deleteGreenCoffee
+//
+// # Delete a green coffee
+//
+// This will delete a green coffee by its given id. It cannot be deleted while roast batches or beans reference it.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the green coffee to delete
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: ItemDeletedResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) DeleteGreenCoffeeById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := h.GreenCoffeeService.DeleteGreenCoffeeById(r.Context(), id); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Msg("green coffee successfully deleted")
+
+	h.writeJSONResponse(w, http.StatusOK, ItemDeletedResponse{
+		Id:  id,
+		Msg: fmt.Sprintf("green coffee %d deleted successfully", id),
+	})
+}
@@ -0,0 +1,170 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/greencoffee"
+)
+
+func testGreenCoffee(id int) *greencoffee.GreenCoffee {
+	arrivalDate := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+	return &greencoffee.GreenCoffee{
+		Id: id, Origin: "Ethiopia Guji", Supplier: "Green Traders", PurchaseWeight: 3, Price: 50,
+		ArrivalDate: &arrivalDate, Moisture: 10.5, RemainingWeight: 1.75, CostPerKilogram: 16.67,
+	}
+}
+
+func newGreenCoffeeTestHandler(t *testing.T) (*Handler, *fakeGreenCoffeeService) {
+	t.Helper()
+
+	handler, _, _, _, _ := newTestHandler(t)
+
+	return handler, handler.GreenCoffeeService.(*fakeGreenCoffeeService)
+}
+
+func TestGreenCoffeeHandlersHappyPaths(t *testing.T) {
+	greenCoffee := testGreenCoffee(1)
+	body := `{"origin":"Ethiopia Guji","supplier":"Green Traders","purchase_weight":3,"price":50,"arrival_date":"2026-09-01","moisture":10.5}`
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		body      string
+		id        string
+		status    int
+		expected  any
+		configure func(*testing.T, *fakeGreenCoffeeService)
+		handler   controllerHandler
+	}{
+		{
+			name: "create", method: http.MethodPost, target: "/rest/v1/green_coffees", body: body,
+			status: http.StatusCreated, expected: GreenCoffeeResponse{*greenCoffee}, handler: (*Handler).CreateGreenCoffee,
+			configure: func(t *testing.T, service *fakeGreenCoffeeService) {
+				service.createGreenCoffee = func(_ context.Context, value *greencoffee.GreenCoffee) (*greencoffee.GreenCoffee, error) {
+					if value.Origin != "Ethiopia Guji" || value.PurchaseWeight != 3 || value.Moisture != 10.5 || value.ArrivalDate == nil {
+						t.Errorf("green coffee = %#v, want decoded fields", value)
+					}
+					return greenCoffee, nil
+				}
+			},
+		},
+		{
+			name: "get", method: http.MethodGet, target: "/rest/v1/green_coffees/1", id: "1",
+			status: http.StatusOK, expected: GreenCoffeeResponse{*greenCoffee}, handler: (*Handler).GetGreenCoffeeById,
+			configure: func(_ *testing.T, service *fakeGreenCoffeeService) {
+				service.getGreenCoffeeByID = func(context.Context, int) (*greencoffee.GreenCoffee, error) {
+					return greenCoffee, nil
+				}
+			},
+		},
+		{
+			name: "get all", method: http.MethodGet, target: "/rest/v1/green_coffees",
+			status: http.StatusOK, expected: []GreenCoffeeResponse{{*greenCoffee}}, handler: (*Handler).GetAllGreenCoffees,
+			configure: func(_ *testing.T, service *fakeGreenCoffeeService) {
+				service.getAllGreenCoffees = func(context.Context) ([]greencoffee.GreenCoffee, error) {
+					return []greencoffee.GreenCoffee{*greenCoffee}, nil
+				}
+			},
+		},
+		{
+			name: "update", method: http.MethodPut, target: "/rest/v1/green_coffees/1", body: body, id: "1",
+			status: http.StatusOK, expected: GreenCoffeeResponse{*greenCoffee}, handler: (*Handler).UpdateGreenCoffeeById,
+			configure: func(t *testing.T, service *fakeGreenCoffeeService) {
+				service.updateGreenCoffeeByID = func(_ context.Context, id int, _ *greencoffee.GreenCoffee) (*greencoffee.GreenCoffee, error) {
+					if id != 1 {
+						t.Errorf("id = %d, want 1", id)
+					}
+					return greenCoffee, nil
+				}
+			},
+		},
+		{
+			name: "delete", method: http.MethodDelete, target: "/rest/v1/green_coffees/1", id: "1",
+			status: http.StatusOK, expected: ItemDeletedResponse{Id: 1, Msg: "green coffee 1 deleted successfully"}, handler: (*Handler).DeleteGreenCoffeeById,
+			configure: func(_ *testing.T, service *fakeGreenCoffeeService) {
+				service.deleteGreenCoffeeByID = func(context.Context, int) error { return nil }
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newGreenCoffeeTestHandler(t)
+			tt.configure(t, service)
+			contentType := ""
+			if tt.body != "" {
+				contentType = ContentTypeApplicationJSON
+			}
+			req := newControllerRequest(t, tt.method, tt.target, tt.body, contentType, tt.id)
+
+			recorder := executeControllerHandler(handler, tt.handler, req)
+
+			assertJSONResponse(t, recorder, tt.status, tt.expected)
+		})
+	}
+}
+
+func TestGreenCoffeeHandlersErrorPaths(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		body      string
+		id        string
+		status    int
+		message   string
+		configure func(*fakeGreenCoffeeService)
+		handler   controllerHandler
+	}{
+		{
+			name: "create without purchase weight", method: http.MethodPost, target: "/rest/v1/green_coffees",
+			body:   `{"origin":"Ethiopia Guji","purchase_weight":0}`,
+			status: http.StatusBadRequest, message: "green coffee purchase weight is out of range. Must be positive",
+			handler: (*Handler).CreateGreenCoffee,
+			configure: func(service *fakeGreenCoffeeService) {
+				service.createGreenCoffee = func(context.Context, *greencoffee.GreenCoffee) (*greencoffee.GreenCoffee, error) {
+					return nil, domainerrors.ErrGreenCoffeePurchaseWeightOutOfRange
+				}
+			},
+		},
+		{
+			name: "get missing green coffee", method: http.MethodGet, target: "/rest/v1/green_coffees/5", id: "5",
+			status: http.StatusNotFound, message: "no green coffee found for given id", handler: (*Handler).GetGreenCoffeeById,
+			configure: func(service *fakeGreenCoffeeService) {
+				service.getGreenCoffeeByID = func(context.Context, int) (*greencoffee.GreenCoffee, error) {
+					return nil, domainerrors.ErrGreenCoffeeDoesNotExist
+				}
+			},
+		},
+		{
+			name: "delete green coffee used by roast batches", method: http.MethodDelete, target: "/rest/v1/green_coffees/1", id: "1",
+			status: http.StatusBadRequest, message: "cannot delete due to existing references: roast batch foreign key constraint failed",
+			handler: (*Handler).DeleteGreenCoffeeById,
+			configure: func(service *fakeGreenCoffeeService) {
+				service.deleteGreenCoffeeByID = func(context.Context, int) error {
+					return domainerrors.ErrRoastBatchForeignKeyConstraint
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newGreenCoffeeTestHandler(t)
+			tt.configure(service)
+			contentType := ""
+			if tt.body != "" {
+				contentType = ContentTypeApplicationJSON
+			}
+			req := newControllerRequest(t, tt.method, tt.target, tt.body, contentType, tt.id)
+
+			recorder := executeControllerHandler(handler, tt.handler, req)
+
+			assertJSONResponse(t, recorder, tt.status, ErrorResponse{Msg: tt.message})
+		})
+	}
+}
@@ -8,24 +8,49 @@ import (
 	"strings"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/lescactus/espressoapi-go/internal/services/attachment"
 	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/beanconqueror"
+	"github.com/lescactus/espressoapi-go/internal/services/cupping"
+	"github.com/lescactus/espressoapi-go/internal/services/decent"
+	"github.com/lescactus/espressoapi-go/internal/services/greencoffee"
+	"github.com/lescactus/espressoapi-go/internal/services/maintenance"
+	"github.com/lescactus/espressoapi-go/internal/services/report"
+	"github.com/lescactus/espressoapi-go/internal/services/roastbatch"
 	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/share"
 	"github.com/lescactus/espressoapi-go/internal/services/sheet"
 	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/lescactus/espressoapi-go/internal/services/spreadsheet"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
 	"github.com/rs/zerolog"
 )
 
 const (
 	// ContentTypeApplicationJSON represent the applcation/json Content-Type value
 	ContentTypeApplicationJSON = "application/json"
+
+	// ContentTypeTextCSV represent the text/csv Content-Type value
+	ContentTypeTextCSV = "text/csv; charset=utf-8"
 )
 
 type Handler struct {
-	SheetService   sheet.Service
-	RoasterService roaster.Service
-	BeanService    bean.Service
-	ShotService    shot.Service
-	maxRequestSize int64
+	SheetService         sheet.Service
+	RoasterService       roaster.Service
+	BeanService          bean.Service
+	ShotService          shot.Service
+	CuppingService       cupping.Service
+	RoastBatchService    roastbatch.Service
+	GreenCoffeeService   greencoffee.Service
+	ReportService        report.Service
+	StatsService         stats.Service
+	MaintenanceService   maintenance.Service
+	ShareLinkService     share.Service
+	AttachmentService    attachment.Service
+	DecentService        decent.Service
+	BeanconquerorService beanconqueror.Service
+	SpreadsheetService   spreadsheet.Service
+	maxRequestSize       int64
 }
 
 func NewHandler(
@@ -33,13 +58,35 @@ func NewHandler(
 	roasterService roaster.Service,
 	beanService bean.Service,
 	ShotService shot.Service,
+	cuppingService cupping.Service,
+	roastBatchService roastbatch.Service,
+	greenCoffeeService greencoffee.Service,
+	reportService report.Service,
+	statsService stats.Service,
+	maintenanceService maintenance.Service,
+	shareLinkService share.Service,
+	attachmentService attachment.Service,
+	decentService decent.Service,
+	beanconquerorService beanconqueror.Service,
+	spreadsheetService spreadsheet.Service,
 	serverMaxRequestSize int64) *Handler {
 	return &Handler{
-		SheetService:   sheetService,
-		RoasterService: roasterService,
-		BeanService:    beanService,
-		ShotService:    ShotService,
-		maxRequestSize: serverMaxRequestSize,
+		SheetService:         sheetService,
+		RoasterService:       roasterService,
+		BeanService:          beanService,
+		ShotService:          ShotService,
+		CuppingService:       cuppingService,
+		RoastBatchService:    roastBatchService,
+		GreenCoffeeService:   greenCoffeeService,
+		ReportService:        reportService,
+		StatsService:         statsService,
+		MaintenanceService:   maintenanceService,
+		ShareLinkService:     shareLinkService,
+		AttachmentService:    attachmentService,
+		DecentService:        decentService,
+		BeanconquerorService: beanconquerorService,
+		SpreadsheetService:   spreadsheetService,
+		maxRequestSize:       serverMaxRequestSize,
 	}
 }
 
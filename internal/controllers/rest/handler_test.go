@@ -13,10 +13,21 @@ import (
 	"testing"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/lescactus/espressoapi-go/internal/services/attachment"
 	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/beanconqueror"
+	"github.com/lescactus/espressoapi-go/internal/services/cupping"
+	"github.com/lescactus/espressoapi-go/internal/services/decent"
+	"github.com/lescactus/espressoapi-go/internal/services/greencoffee"
+	"github.com/lescactus/espressoapi-go/internal/services/maintenance"
+	"github.com/lescactus/espressoapi-go/internal/services/report"
+	"github.com/lescactus/espressoapi-go/internal/services/roastbatch"
 	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/share"
 	"github.com/lescactus/espressoapi-go/internal/services/sheet"
 	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/lescactus/espressoapi-go/internal/services/spreadsheet"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/hlog"
 )
@@ -27,6 +38,17 @@ func TestNewHandler(t *testing.T) {
 		roasterService       roaster.Service
 		beanService          bean.Service
 		shotService          shot.Service
+		cuppingService       cupping.Service
+		roastBatchService    roastbatch.Service
+		greenCoffeeService   greencoffee.Service
+		reportService        report.Service
+		statsService         stats.Service
+		maintenanceService   maintenance.Service
+		shareLinkService     share.Service
+		attachmentService    attachment.Service
+		decentService        decent.Service
+		beanconquerorService beanconqueror.Service
+		spreadsheetService   spreadsheet.Service
 		serverMaxRequestSize int64
 	}
 	tests := []struct {
@@ -36,18 +58,18 @@ func TestNewHandler(t *testing.T) {
 	}{
 		{
 			name: "nil args",
-			args: args{nil, nil, nil, nil, 0},
-			want: &Handler{nil, nil, nil, nil, 0},
+			args: args{nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0},
+			want: &Handler{nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0},
 		},
 		{
 			name: "non nil args",
-			args: args{sheet.New(nil), roaster.New(nil), bean.New(nil), shot.New(nil), 10},
-			want: &Handler{sheet.New(nil), roaster.New(nil), bean.New(nil), shot.New(nil), 10},
+			args: args{sheet.New(nil), roaster.New(nil), bean.New(nil), shot.New(nil), cupping.New(nil), roastbatch.New(nil), greencoffee.New(nil), report.New(nil), stats.New(nil), maintenance.New(nil), share.New(nil, nil), attachment.New(nil, nil), decent.New(nil), beanconqueror.New(nil, nil, nil, nil), spreadsheet.New(nil, nil, nil, nil), 10},
+			want: &Handler{sheet.New(nil), roaster.New(nil), bean.New(nil), shot.New(nil), cupping.New(nil), roastbatch.New(nil), greencoffee.New(nil), report.New(nil), stats.New(nil), maintenance.New(nil), share.New(nil, nil), attachment.New(nil, nil), decent.New(nil), beanconqueror.New(nil, nil, nil, nil), spreadsheet.New(nil, nil, nil, nil), 10},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewHandler(tt.args.sheetService, tt.args.roasterService, tt.args.beanService, tt.args.shotService, tt.args.serverMaxRequestSize); !reflect.DeepEqual(got, tt.want) {
+			if got := NewHandler(tt.args.sheetService, tt.args.roasterService, tt.args.beanService, tt.args.shotService, tt.args.cuppingService, tt.args.roastBatchService, tt.args.greenCoffeeService, tt.args.reportService, tt.args.statsService, tt.args.maintenanceService, tt.args.shareLinkService, tt.args.attachmentService, tt.args.decentService, tt.args.beanconquerorService, tt.args.spreadsheetService, tt.args.serverMaxRequestSize); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewHandler() = %v, want %v", got, tt.want)
 			}
 		})
@@ -75,7 +97,7 @@ func TestMaxReqSizeMiddleware(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			handler := NewHandler(nil, nil, nil, nil, maxRequestSize)
+			handler := NewHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, maxRequestSize)
 			var body []byte
 			var readErr error
 			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -201,7 +223,7 @@ func TestHandlerIdParameterLoggerHandler(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			var logOutput bytes.Buffer
 			logger := zerolog.New(&logOutput)
-			handler := NewHandler(nil, nil, nil, nil, 1024)
+			handler := NewHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 1024)
 			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				hlog.FromRequest(r).Info().Msg("handled")
 				w.WriteHeader(http.StatusNoContent)
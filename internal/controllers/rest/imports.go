@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/lescactus/espressoapi-go/internal/services/decent"
+	"github.com/rs/zerolog/hlog"
+)
+
+// swagger:parameters importDecentShots
+type DecentImportParams struct {
+	// The .shot files of the DE1 app or the JSON files downloaded from
+	// visualizer.coffee, one per "file" field.
+	// in: formData
+	// required: true
+	// swagger:file
+	File []byte `json:"file"`
+
+	// The name of the sheet to import the shots in, created if missing.
+	// Defaults to Decent.
+	// in: query
+	Sheet string `json:"sheet"`
+
+	// Only report what would be imported, without importing anything.
+	// in: query
+	DryRun bool `json:"dry_run"`
+}
+
+// DecentImportReportResponse represents the outcome of an import of shots
+//
+// Every file is reported as imported, new (for a dry run), duplicate when
+// the shot was imported before, or failed.
+//
+// swagger:response DecentImportReportResponse
+type DecentImportReportResponse struct {
+	// swagger:allOf
+	decent.Report
+}
+
+// swagger:route POST /rest/v1/import/decent imports importDecentShots
+//
+// # Import shots from a Decent Espresso machine
+//
+// This will import the shots of the uploaded .shot files of the DE1 app or JSON files of visualizer.coffee, with their profile, creating their sheet, roaster and beans when missing. Shots imported before are reported as duplicates and skipped. The request body is limited by the server maximum request size.
+//
+//	Consumes:
+//	- multipart/form-data
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: DecentImportReportResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  413: ErrorResponse
+//	  415: ErrorResponse
+func (h *Handler) ImportDecentShots(w http.ResponseWriter, r *http.Request) {
+	opts := decent.Options{Sheet: r.URL.Query().Get("sheet")}
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+		if opts.DryRun, err = strconv.ParseBool(value); err != nil {
+			h.SetErrorResponse(w, &ErrorResponse{status: http.StatusBadRequest, Msg: "dry_run must be true or false"})
+			return
+		}
+	}
+
+	files, err := readUploadedFiles(r)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	report, err := h.DecentService.Import(r.Context(), files, opts)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Bool("dry_run", report.DryRun).Int("imported", report.Imported).Int("duplicates", report.Duplicates).Int("failed", report.Failed).Msg("decent shots successfully imported")
+
+	h.writeJSONResponse(w, http.StatusOK, DecentImportReportResponse{*report})
+}
+
+// readUploadedFiles returns every file of the multipart form of r uploaded in
+// the uploadFormField field.
+func readUploadedFiles(r *http.Request) ([]decent.File, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return nil, ErrUploadIsNotMultipart
+	}
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, ErrUploadIsMalformed
+	}
+	var files []decent.File
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, uploadError(err)
+		}
+		if part.FormName() != uploadFormField {
+			continue
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, uploadError(err)
+		}
+		files = append(files, decent.File{Name: part.FileName(), Data: data})
+	}
+	if len(files) == 0 {
+		return nil, ErrUploadFileIsMissing
+	}
+	return files, nil
+}
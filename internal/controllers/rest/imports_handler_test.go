@@ -0,0 +1,135 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/decent"
+)
+
+func newDecentTestHandler(t *testing.T) (*Handler, *fakeDecentService) {
+	t.Helper()
+
+	handler, _, _, _, _ := newTestHandler(t)
+
+	return handler, handler.DecentService.(*fakeDecentService)
+}
+
+// multipartFilesBody returns a multipart/form-data body with one "file" part
+// per entry of files, and its Content-Type.
+func multipartFilesBody(t *testing.T, files ...decent.File) (string, string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := mw.CreateFormFile("file", f.Name)
+		if err != nil {
+			t.Fatalf("create form file: %v", err)
+		}
+		fw.Write(f.Data)
+	}
+	mw.Close()
+
+	return buf.String(), mw.FormDataContentType()
+}
+
+func TestImportDecentShots(t *testing.T) {
+	handler, service := newDecentTestHandler(t)
+	shotId := 7
+	report := &decent.Report{
+		DryRun: true, Duplicates: 1,
+		Files: []decent.FileReport{
+			{Name: "0001.shot", Status: decent.StatusNew},
+			{Name: "visualizer.json", Status: decent.StatusDuplicate, ShotId: &shotId},
+		},
+	}
+	service.importShots = func(_ context.Context, files []decent.File, opts decent.Options) (*decent.Report, error) {
+		if len(files) != 2 || files[0].Name != "0001.shot" || string(files[0].Data) != "clock 1" || files[1].Name != "visualizer.json" {
+			t.Errorf("Import(%v), want both uploaded files in order", files)
+		}
+		if opts != (decent.Options{Sheet: "Morning", DryRun: true}) {
+			t.Errorf("Import options = %+v, want sheet Morning and a dry run", opts)
+		}
+		return report, nil
+	}
+	body, contentType := multipartFilesBody(t,
+		decent.File{Name: "0001.shot", Data: []byte("clock 1")},
+		decent.File{Name: "visualizer.json", Data: []byte("{}")},
+	)
+	req := newControllerRequest(t, http.MethodPost, "/rest/v1/import/decent?sheet=Morning&dry_run=true", body, contentType, "")
+
+	recorder := executeControllerHandler(handler, (*Handler).ImportDecentShots, req)
+
+	assertJSONResponse(t, recorder, http.StatusOK, DecentImportReportResponse{*report})
+}
+
+func TestImportDecentShotsErrorPaths(t *testing.T) {
+	body, contentType := multipartFilesBody(t, decent.File{Name: "0001.shot", Data: []byte("clock 1")})
+	noFileBody, noFileContentType := multipartBody(t, "photo", "0001.shot", []byte("clock 1"))
+	tests := []struct {
+		name        string
+		target      string
+		body        string
+		contentType string
+		status      int
+		message     string
+		configure   func(*fakeDecentService)
+	}{
+		{
+			name: "invalid dry_run", target: "/rest/v1/import/decent?dry_run=maybe",
+			body: body, contentType: contentType,
+			status: http.StatusBadRequest, message: "dry_run must be true or false",
+			configure: func(*fakeDecentService) {},
+		},
+		{
+			name: "json body", target: "/rest/v1/import/decent",
+			body: `{"file":"0001.shot"}`, contentType: ContentTypeApplicationJSON,
+			status: http.StatusUnsupportedMediaType, message: "Content-Type header is not multipart/form-data",
+			configure: func(*fakeDecentService) {},
+		},
+		{
+			name: "without file field", target: "/rest/v1/import/decent",
+			body: noFileBody, contentType: noFileContentType,
+			status: http.StatusBadRequest, message: `request body must contain a file in the "file" field`,
+			configure: func(*fakeDecentService) {},
+		},
+		{
+			name: "service error", target: "/rest/v1/import/decent",
+			body: body, contentType: contentType,
+			status: http.StatusInternalServerError, message: "internal server error",
+			configure: func(service *fakeDecentService) {
+				service.importShots = func(context.Context, []decent.File, decent.Options) (*decent.Report, error) {
+					return nil, errors.New("could not import decent shots: connection refused")
+				}
+			},
+		},
+		{
+			name: "no files", target: "/rest/v1/import/decent",
+			body: body, contentType: contentType,
+			status: http.StatusBadRequest, message: domainerrors.ErrImportHasNoFiles.Error(),
+			configure: func(service *fakeDecentService) {
+				service.importShots = func(context.Context, []decent.File, decent.Options) (*decent.Report, error) {
+					return nil, domainerrors.ErrImportHasNoFiles
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newDecentTestHandler(t)
+			tt.configure(service)
+			req := newControllerRequest(t, http.MethodPost, tt.target, tt.body, tt.contentType, "")
+
+			recorder := executeControllerHandler(handler, (*Handler).ImportDecentShots, req)
+
+			assertJSONResponse(t, recorder, tt.status, ErrorResponse{Msg: tt.message})
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/services/maintenance"
+	"github.com/rs/zerolog/hlog"
+)
+
+// swagger:parameters createMaintenanceTask updateMaintenanceTaskById
+type MaintenanceTaskParams struct {
+	// The request body for creating or updating a maintenance task
+	// in: body
+	// required: true
+	Body MaintenanceTaskRequest
+}
+
+// swagger:parameters getAllMaintenanceTasks
+type MaintenanceTasksParams struct {
+	// Only list the maintenance tasks that are due
+	// in: query
+	Due bool `json:"due"`
+}
+
+// MaintenanceTaskRequest represents the request body for creating or updating
+// a maintenance task. At least one of the intervals is required.
+// swagger:model
+type MaintenanceTaskRequest struct {
+	Type          string     `json:"type"`
+	Equipment     string     `json:"equipment"`
+	EquipmentName string     `json:"equipment_name"`
+	IntervalShots *int       `json:"interval_shots"`
+	IntervalDays  *int       `json:"interval_days"`
+	LastDoneAt    *time.Time `json:"last_done_at"`
+}
+
+// MaintenanceTaskResponse represents a maintenance task for this application
+//
+// A maintenance task has its type, the equipment it is for, its intervals and,
+// computed from the shots pulled since it was last done, how far it is from
+// being due.
+//
+// swagger:response MaintenanceTaskResponse
+type MaintenanceTaskResponse struct {
+	// swagger:allOf
+	maintenance.MaintenanceTask
+}
+
+func (req MaintenanceTaskRequest) toMaintenanceTask() *maintenance.MaintenanceTask {
+	return &maintenance.MaintenanceTask{
+		Type:          maintenance.TaskType(req.Type),
+		Equipment:     maintenance.Equipment(req.Equipment),
+		EquipmentName: req.EquipmentName,
+		IntervalShots: req.IntervalShots,
+		IntervalDays:  req.IntervalDays,
+		LastDoneAt:    req.LastDoneAt,
+	}
+}
+
+// swagger:route POST /rest/v1/maintenance_tasks maintenance_tasks createMaintenanceTask
+//
+// # Create a maintenance task
+//
+// This will create a new maintenance task.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  201: MaintenanceTaskResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  413: ErrorResponse
+func (h *Handler) CreateMaintenanceTask(w http.ResponseWriter, r *http.Request) {
+	var req MaintenanceTaskRequest
+
+	if err := h.parseContentType(r); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := jsonDecodeBody(r, &req); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	task, err := h.MaintenanceService.CreateMaintenanceTask(r.Context(), req.toMaintenanceTask())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("maintenance_task_id", task.Id).Msg("maintenance task successfully created")
+
+	h.writeJSONResponse(w, http.StatusCreated, MaintenanceTaskResponse{*task})
+}
+
+// swagger:route GET /rest/v1/maintenance_tasks/{id} maintenance_tasks getMaintenanceTask
+//
+// # Get a maintenance task
+//
+// This will get the maintenance task with the given id, with the shots and days left before it is due.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the maintenance task to get
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: MaintenanceTaskResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetMaintenanceTaskById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	task, err := h.MaintenanceService.GetMaintenanceTaskById(r.Context(), id)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, MaintenanceTaskResponse{*task})
+}
+
+// swagger:route GET /rest/v1/maintenance_tasks maintenance_tasks getAllMaintenanceTasks
+//
+// # Get all maintenance tasks
+//
+// This will show all maintenance tasks, or only the due ones with due=true.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: MaintenanceTaskResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+func (h *Handler) GetAllMaintenanceTasks(w http.ResponseWriter, r *http.Request) {
+	due := false
+	if value := r.URL.Query().Get("due"); value != "" {
+		var err error
+		if due, err = strconv.ParseBool(value); err != nil {
+			h.SetErrorResponse(w, &ErrorResponse{status: http.StatusBadRequest, Msg: "due must be true or false"})
+			return
+		}
+	}
+
+	var tasks []maintenance.MaintenanceTask
+	var err error
+	if due {
+		tasks, err = h.MaintenanceService.GetDueMaintenanceTasks(r.Context())
+	} else {
+		tasks, err = h.MaintenanceService.GetAllMaintenanceTasks(r.Context())
+	}
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	resp := make([]MaintenanceTaskResponse, len(tasks))
+	for k, v := range tasks {
+		resp[k] = MaintenanceTaskResponse{v}
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, &resp)
+}
+
+// swagger:route PUT /rest/v1/maintenance_tasks/{id} maintenance_tasks updateMaintenanceTaskById
+//
+// # Update a maintenance task
+//
+// This will update a maintenance task by its given id.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the maintenance task to update
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: MaintenanceTaskResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+//	  413: ErrorResponse
+func (h *Handler) UpdateMaintenanceTaskById(w http.ResponseWriter, r *http.Request) {
+	var req MaintenanceTaskRequest
+
+	if err := h.parseContentType(r); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := jsonDecodeBody(r, &req); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	task, err := h.MaintenanceService.UpdateMaintenanceTaskById(r.Context(), id, req.toMaintenanceTask())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("maintenance_task_id", task.Id).Msg("maintenance task successfully updated")
+
+	h.writeJSONResponse(w, http.StatusOK, MaintenanceTaskResponse{*task})
+}
+
+// swagger:route POST /rest/v1/maintenance_tasks/{id}/complete maintenance_tasks completeMaintenanceTask
+//
+// # Mark a maintenance task as done
+//
+// This will record the maintenance task with the given id as done now, which resets its shot and day counters.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the maintenance task to mark as done
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: MaintenanceTaskResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) CompleteMaintenanceTaskById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	task, err := h.MaintenanceService.CompleteMaintenanceTaskById(r.Context(), id)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("maintenance_task_id", task.Id).Msg("maintenance task successfully completed")
+
+	h.writeJSONResponse(w, http.StatusOK, MaintenanceTaskResponse{*task})
+}
+
+// swagger:route DELETE /rest/v1/maintenance_tasks/{id} maintenance_tasks deleteMaintenanceTask
+//
+// # Delete a maintenance task
+//
+// This will delete a maintenance task by its given id.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the maintenance task to delete
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: ItemDeletedResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) DeleteMaintenanceTaskById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := h.MaintenanceService.DeleteMaintenanceTaskById(r.Context(), id); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Msg("maintenance task successfully deleted")
+
+	h.writeJSONResponse(w, http.StatusOK, ItemDeletedResponse{
+		Id:  id,
+		Msg: fmt.Sprintf("maintenance task %d deleted successfully", id),
+	})
+}
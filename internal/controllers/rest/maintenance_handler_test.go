@@ -0,0 +1,200 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/maintenance"
+)
+
+func testMaintenanceTask(id int) *maintenance.MaintenanceTask {
+	lastDoneAt := time.Date(2026, time.October, 1, 8, 0, 0, 0, time.UTC)
+	intervalShots, shotsRemaining := 200, -14
+	return &maintenance.MaintenanceTask{
+		Id: id, Type: maintenance.TaskTypeBackflush, Equipment: maintenance.EquipmentMachine, EquipmentName: "Linea Mini",
+		IntervalShots: &intervalShots, LastDoneAt: &lastDoneAt, ShotsSinceDone: 214, DaysSinceDone: 17,
+		ShotsRemaining: &shotsRemaining, Due: true,
+	}
+}
+
+func newMaintenanceTestHandler(t *testing.T) (*Handler, *fakeMaintenanceService) {
+	t.Helper()
+
+	handler, _, _, _, _ := newTestHandler(t)
+
+	return handler, handler.MaintenanceService.(*fakeMaintenanceService)
+}
+
+func TestMaintenanceHandlersHappyPaths(t *testing.T) {
+	task := testMaintenanceTask(1)
+	body := `{"type":"backflush","equipment":"machine","equipment_name":"Linea Mini","interval_shots":200,"last_done_at":"2026-10-01T08:00:00Z"}`
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		body      string
+		id        string
+		status    int
+		expected  any
+		configure func(*testing.T, *fakeMaintenanceService)
+		handler   controllerHandler
+	}{
+		{
+			name: "create", method: http.MethodPost, target: "/rest/v1/maintenance_tasks", body: body,
+			status: http.StatusCreated, expected: MaintenanceTaskResponse{*task}, handler: (*Handler).CreateMaintenanceTask,
+			configure: func(t *testing.T, service *fakeMaintenanceService) {
+				service.createMaintenanceTask = func(_ context.Context, value *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+					if value.Type != maintenance.TaskTypeBackflush || value.Equipment != maintenance.EquipmentMachine ||
+						value.IntervalShots == nil || *value.IntervalShots != 200 || value.IntervalDays != nil || value.LastDoneAt == nil {
+						t.Errorf("maintenance task = %#v, want decoded fields", value)
+					}
+					return task, nil
+				}
+			},
+		},
+		{
+			name: "get", method: http.MethodGet, target: "/rest/v1/maintenance_tasks/1", id: "1",
+			status: http.StatusOK, expected: MaintenanceTaskResponse{*task}, handler: (*Handler).GetMaintenanceTaskById,
+			configure: func(_ *testing.T, service *fakeMaintenanceService) {
+				service.getMaintenanceTaskByID = func(context.Context, int) (*maintenance.MaintenanceTask, error) {
+					return task, nil
+				}
+			},
+		},
+		{
+			name: "get all", method: http.MethodGet, target: "/rest/v1/maintenance_tasks",
+			status: http.StatusOK, expected: []MaintenanceTaskResponse{{*task}}, handler: (*Handler).GetAllMaintenanceTasks,
+			configure: func(_ *testing.T, service *fakeMaintenanceService) {
+				service.getAllMaintenanceTasks = func(context.Context) ([]maintenance.MaintenanceTask, error) {
+					return []maintenance.MaintenanceTask{*task}, nil
+				}
+			},
+		},
+		{
+			name: "get due", method: http.MethodGet, target: "/rest/v1/maintenance_tasks?due=true",
+			status: http.StatusOK, expected: []MaintenanceTaskResponse{{*task}}, handler: (*Handler).GetAllMaintenanceTasks,
+			configure: func(_ *testing.T, service *fakeMaintenanceService) {
+				service.getDueMaintenanceTasks = func(context.Context) ([]maintenance.MaintenanceTask, error) {
+					return []maintenance.MaintenanceTask{*task}, nil
+				}
+			},
+		},
+		{
+			name: "update", method: http.MethodPut, target: "/rest/v1/maintenance_tasks/1", body: body, id: "1",
+			status: http.StatusOK, expected: MaintenanceTaskResponse{*task}, handler: (*Handler).UpdateMaintenanceTaskById,
+			configure: func(t *testing.T, service *fakeMaintenanceService) {
+				service.updateMaintenanceTaskByID = func(_ context.Context, id int, _ *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+					if id != 1 {
+						t.Errorf("id = %d, want 1", id)
+					}
+					return task, nil
+				}
+			},
+		},
+		{
+			name: "complete", method: http.MethodPost, target: "/rest/v1/maintenance_tasks/1/complete", id: "1",
+			status: http.StatusOK, expected: MaintenanceTaskResponse{*task}, handler: (*Handler).CompleteMaintenanceTaskById,
+			configure: func(t *testing.T, service *fakeMaintenanceService) {
+				service.completeMaintenanceTaskByID = func(_ context.Context, id int) (*maintenance.MaintenanceTask, error) {
+					if id != 1 {
+						t.Errorf("id = %d, want 1", id)
+					}
+					return task, nil
+				}
+			},
+		},
+		{
+			name: "delete", method: http.MethodDelete, target: "/rest/v1/maintenance_tasks/1", id: "1",
+			status: http.StatusOK, expected: ItemDeletedResponse{Id: 1, Msg: "maintenance task 1 deleted successfully"}, handler: (*Handler).DeleteMaintenanceTaskById,
+			configure: func(_ *testing.T, service *fakeMaintenanceService) {
+				service.deleteMaintenanceTaskByID = func(context.Context, int) error { return nil }
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newMaintenanceTestHandler(t)
+			tt.configure(t, service)
+			contentType := ""
+			if tt.body != "" {
+				contentType = ContentTypeApplicationJSON
+			}
+			req := newControllerRequest(t, tt.method, tt.target, tt.body, contentType, tt.id)
+
+			recorder := executeControllerHandler(handler, tt.handler, req)
+
+			assertJSONResponse(t, recorder, tt.status, tt.expected)
+		})
+	}
+}
+
+func TestMaintenanceHandlersErrorPaths(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		body      string
+		id        string
+		status    int
+		message   string
+		configure func(*fakeMaintenanceService)
+		handler   controllerHandler
+	}{
+		{
+			name: "create without interval", method: http.MethodPost, target: "/rest/v1/maintenance_tasks",
+			body:   `{"type":"descale"}`,
+			status: http.StatusBadRequest, message: "maintenance task interval is out of range. A positive number of shots, days or both is required",
+			handler: (*Handler).CreateMaintenanceTask,
+			configure: func(service *fakeMaintenanceService) {
+				service.createMaintenanceTask = func(context.Context, *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+					return nil, domainerrors.ErrMaintenanceTaskIntervalOutOfRange
+				}
+			},
+		},
+		{
+			name: "create with unknown type", method: http.MethodPost, target: "/rest/v1/maintenance_tasks",
+			body:   `{"type":"polish","interval_days":7}`,
+			status: http.StatusBadRequest, message: "maintenance task type is invalid. Must be one of backflush, descale, burr_change, gasket_change, water_filter, grinder_cleaning or other",
+			handler: (*Handler).CreateMaintenanceTask,
+			configure: func(service *fakeMaintenanceService) {
+				service.createMaintenanceTask = func(context.Context, *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+					return nil, domainerrors.ErrMaintenanceTaskTypeIsInvalid
+				}
+			},
+		},
+		{
+			name: "get all with invalid due", method: http.MethodGet, target: "/rest/v1/maintenance_tasks?due=soon",
+			status: http.StatusBadRequest, message: "due must be true or false", handler: (*Handler).GetAllMaintenanceTasks,
+			configure: func(*fakeMaintenanceService) {},
+		},
+		{
+			name: "complete missing maintenance task", method: http.MethodPost, target: "/rest/v1/maintenance_tasks/5/complete", id: "5",
+			status: http.StatusNotFound, message: "no maintenance task found for given id", handler: (*Handler).CompleteMaintenanceTaskById,
+			configure: func(service *fakeMaintenanceService) {
+				service.completeMaintenanceTaskByID = func(context.Context, int) (*maintenance.MaintenanceTask, error) {
+					return nil, domainerrors.ErrMaintenanceTaskDoesNotExist
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newMaintenanceTestHandler(t)
+			tt.configure(service)
+			contentType := ""
+			if tt.body != "" {
+				contentType = ContentTypeApplicationJSON
+			}
+			req := newControllerRequest(t, tt.method, tt.target, tt.body, contentType, tt.id)
+
+			recorder := executeControllerHandler(handler, tt.handler, req)
+
+			assertJSONResponse(t, recorder, tt.status, ErrorResponse{Msg: tt.message})
+		})
+	}
+}
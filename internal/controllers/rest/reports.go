@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/services/report"
+)
+
+// swagger:parameters getSpendReport
+type SpendReportParams struct {
+	// The first day of the report, as YYYY-MM-DD
+	// in: query
+	// format: date
+	From string `json:"from"`
+
+	// The last day of the report, included, as YYYY-MM-DD
+	// in: query
+	// format: date
+	To string `json:"to"`
+
+	// How to group the shots: month, roaster or beans. Defaults to month.
+	// in: query
+	// enum: month,roaster,beans
+	GroupBy string `json:"group_by"`
+}
+
+// SpendReportResponse represents the spend on coffee over a range of days
+//
+// The cost of each shot is derived from the price and bag weight of its
+// beans, then summed per month, roaster or beans, in each currency.
+//
+// swagger:response SpendReportResponse
+type SpendReportResponse struct {
+	// swagger:allOf
+	report.SpendReport
+	// The first day of the report, if bounded
+	From *RoastDate `json:"from"`
+	// The last day of the report, included, if bounded
+	To *RoastDate `json:"to"`
+}
+
+// parseQueryDate parses an optional YYYY-MM-DD query parameter.
+func parseQueryDate(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(roastDateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// swagger:route GET /rest/v1/reports/spend reports getSpendReport
+//
+// # Get the spend report
+//
+// This will sum the cost of the shots pulled between two days, grouped by month, roaster or beans.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: SpendReportResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+func (h *Handler) GetSpendReport(w http.ResponseWriter, r *http.Request) {
+	from, err := parseQueryDate(r, "from")
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	to, err := parseQueryDate(r, "to")
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	spend, err := h.ReportService.GetSpendReport(r.Context(), from, to, report.GroupBy(r.URL.Query().Get("group_by")))
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, SpendReportResponse{
+		SpendReport: *spend,
+		From:        (*RoastDate)(spend.From),
+		To:          (*RoastDate)(spend.To),
+	})
+}
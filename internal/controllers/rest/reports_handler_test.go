@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/report"
+)
+
+func newReportTestHandler(t *testing.T) (*Handler, *fakeReportService) {
+	t.Helper()
+
+	handler, _, _, _, _ := newTestHandler(t)
+
+	return handler, handler.ReportService.(*fakeReportService)
+}
+
+func TestGetSpendReport(t *testing.T) {
+	handler, service := newReportTestHandler(t)
+	from := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.September, 30, 0, 0, 0, 0, time.UTC)
+	spend := &report.SpendReport{
+		From: &from, To: &to, GroupBy: report.GroupByRoaster,
+		Groups: []report.SpendGroup{{Key: "1", Label: "Alpha", SpendTotal: report.SpendTotal{Currency: "EUR", Shots: 2, CoffeeWeight: 36, Spend: 2.16}}},
+		Totals: []report.SpendTotal{{Currency: "EUR", Shots: 2, CoffeeWeight: 36, Spend: 2.16}},
+	}
+	service.getSpendReport = func(_ context.Context, gotFrom, gotTo *time.Time, groupBy report.GroupBy) (*report.SpendReport, error) {
+		if !gotFrom.Equal(from) || !gotTo.Equal(to) || groupBy != report.GroupByRoaster {
+			t.Errorf("GetSpendReport(%v, %v, %q), want the parsed query", gotFrom, gotTo, groupBy)
+		}
+		return spend, nil
+	}
+
+	req := newControllerRequest(t, http.MethodGet, "/rest/v1/reports/spend?from=2026-09-01&to=2026-09-30&group_by=roaster", "", "", "")
+	recorder := executeHandler(handler.GetSpendReport, req)
+
+	assertJSONResponse(t, recorder, http.StatusOK, map[string]any{
+		"from": "2026-09-01", "to": "2026-09-30", "group_by": "roaster",
+		"groups": []any{map[string]any{"key": "1", "label": "Alpha", "currency": "EUR", "shots": 2.0, "coffee_weight": 36.0, "spend": 2.16}},
+		"totals": []any{map[string]any{"currency": "EUR", "shots": 2.0, "coffee_weight": 36.0, "spend": 2.16}},
+	})
+}
+
+func TestGetSpendReportErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    string
+		message   string
+		configure func(*fakeReportService)
+	}{
+		{
+			name:      "invalid from date",
+			target:    "/rest/v1/reports/spend?from=09/01/2026",
+			message:   `invalid time format: parsing time "09/01/2026" as "2006-01-02": cannot parse "09/01/2026" as "2006"`,
+			configure: func(*fakeReportService) {},
+		},
+		{
+			name:    "invalid group by",
+			target:  "/rest/v1/reports/spend?group_by=week",
+			message: "spend report group by is invalid. Must be one of month, roaster or beans",
+			configure: func(service *fakeReportService) {
+				service.getSpendReport = func(context.Context, *time.Time, *time.Time, report.GroupBy) (*report.SpendReport, error) {
+					return nil, domainerrors.ErrSpendReportGroupByIsInvalid
+				}
+			},
+		},
+		{
+			name:    "from after to",
+			target:  "/rest/v1/reports/spend?from=2026-10-02&to=2026-10-01",
+			message: "spend report range is invalid. From must not be after to",
+			configure: func(service *fakeReportService) {
+				service.getSpendReport = func(context.Context, *time.Time, *time.Time, report.GroupBy) (*report.SpendReport, error) {
+					return nil, domainerrors.ErrSpendReportRangeIsInvalid
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newReportTestHandler(t)
+			tt.configure(service)
+
+			req := newControllerRequest(t, http.MethodGet, tt.target, "", "", "")
+			recorder := executeHandler(handler.GetSpendReport, req)
+
+			assertJSONResponse(t, recorder, http.StatusBadRequest, ErrorResponse{Msg: tt.message})
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/roastbatch"
+	"github.com/rs/zerolog/hlog"
+)
+
+// swagger:parameters createRoastBatch updateRoastBatchById
+type RoastBatchParams struct {
+	// The request body for creating or updating a roast batch
+	// in: body
+	// required: true
+	Body RoastBatchRequest
+}
+
+// RoastBatchRequest represents the request body for creating or updating a
+// roast batch. Weights are in grams and times in seconds since charge.
+// swagger:model
+type RoastBatchRequest struct {
+	GreenCoffee       string                  `json:"green_coffee"`
+	RoastDate         *RoastDate              `json:"roast_date"`
+	RoastLevel        sql.RoastLevel          `json:"roast_level"`
+	GreenWeight       float64                 `json:"green_weight"`
+	RoastedWeight     float64                 `json:"roasted_weight"`
+	ChargeTemperature float64                 `json:"charge_temperature"`
+	FirstCrackTime    int                     `json:"first_crack_time"`
+	DevelopmentTime   int                     `json:"development_time"`
+	EndTemperature    float64                 `json:"end_temperature"`
+	CurvePoints       []roastbatch.CurvePoint `json:"curve_points"`
+	GreenCoffeeId     *int                    `json:"green_coffee_id"`
+}
+
+// RoastBatchResponse represents a home roast batch for this application
+//
+// A roast batch has its green coffee, weights, computed weight loss, key
+// temperatures and times, and, when fetched by id, its roast curve.
+//
+// swagger:response RoastBatchResponse
+type RoastBatchResponse struct {
+	// swagger:allOf
+	roastbatch.RoastBatch
+}
+
+func (req RoastBatchRequest) toRoastBatch() *roastbatch.RoastBatch {
+	return &roastbatch.RoastBatch{
+		GreenCoffee:       req.GreenCoffee,
+		RoastDate:         (*time.Time)(req.RoastDate),
+		RoastLevel:        req.RoastLevel,
+		GreenWeight:       req.GreenWeight,
+		RoastedWeight:     req.RoastedWeight,
+		ChargeTemperature: req.ChargeTemperature,
+		FirstCrackTime:    req.FirstCrackTime,
+		DevelopmentTime:   req.DevelopmentTime,
+		EndTemperature:    req.EndTemperature,
+		CurvePoints:       req.CurvePoints,
+		GreenCoffeeId:     req.GreenCoffeeId,
+	}
+}
+
+// swagger:route POST /rest/v1/roast_batches roast_batches createRoastBatch
+//
+// # Create a roast batch
+//
+// This will create a new roast batch, with its optional roast curve.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  201: RoastBatchResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  413: ErrorResponse
+func (h *Handler) CreateRoastBatch(w http.ResponseWriter, r *http.Request) {
+	var req RoastBatchRequest
+
+	if err := h.parseContentType(r); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := jsonDecodeBody(r, &req); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	batch, err := h.RoastBatchService.CreateRoastBatch(r.Context(), req.toRoastBatch())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("roast_batch_id", batch.Id).Msg("roast batch successfully created")
+
+	h.writeJSONResponse(w, http.StatusCreated, RoastBatchResponse{*batch})
+}
+
+// swagger:route GET /rest/v1/roast_batches/{id} roast_batches getRoastBatch
+//
+// # Get a roast batch
+//
+// This will get the roast batch with the given id, including its roast curve.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the roast batch to get
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: RoastBatchResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetRoastBatchById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	batch, err := h.RoastBatchService.GetRoastBatchById(r.Context(), id)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, RoastBatchResponse{*batch})
+}
+
+// swagger:route GET /rest/v1/roast_batches roast_batches getAllRoastBatches
+//
+// # Get all roast batches
+//
+// This will show all roast batches, without their roast curves.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: RoastBatchResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+func (h *Handler) GetAllRoastBatches(w http.ResponseWriter, r *http.Request) {
+	batches, err := h.RoastBatchService.GetAllRoastBatches(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	resp := make([]RoastBatchResponse, len(batches))
+	for k, v := range batches {
+		resp[k] = RoastBatchResponse{v}
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, &resp)
+}
+
+// swagger:route PUT /rest/v1/roast_batches/{id} roast_batches updateRoastBatchById
+//
+// # Update a roast batch
+//
+// This will update a roast batch by its given id. The roast curve is replaced by the given points.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the roast batch to update
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: RoastBatchResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+//	  413: ErrorResponse
+func (h *Handler) UpdateRoastBatchById(w http.ResponseWriter, r *http.Request) {
+	var req RoastBatchRequest
+
+	if err := h.parseContentType(r); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := jsonDecodeBody(r, &req); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	batch, err := h.RoastBatchService.UpdateRoastBatchById(r.Context(), id, req.toRoastBatch())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("roast_batch_id", batch.Id).Msg("roast batch successfully updated")
+
+	h.writeJSONResponse(w, http.StatusOK, RoastBatchResponse{*batch})
+}
+
+// swagger:route DELETE /rest/v1/roast_batches/{id} roast_batches deleteRoastBatch
+//
+// # Delete a roast batch
+//
+// This will delete a roast batch by its given id. Beans generated from it are kept.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the roast batch to delete
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: ItemDeletedResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) DeleteRoastBatchById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := h.RoastBatchService.DeleteRoastBatchById(r.Context(), id); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Msg("roast batch successfully deleted")
+
+	h.writeJSONResponse(w, http.StatusOK, ItemDeletedResponse{
+		Id:  id,
+		Msg: fmt.Sprintf("roast batch %d deleted successfully", id),
+	})
+}
+
+// swagger:route POST /rest/v1/roast_batches/{id}/beans roast_batches createBeansFromRoastBatch
+//
+// # Create beans from a roast batch
+//
+// This will create beans from the roast batch with the given id: named after its green coffee,
+// with its roast date and level, and roasted by the "self" roaster. A batch generates beans only once.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the roast batch to create beans from
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  201: RoastBatchResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+//	  409: ErrorResponse
+func (h *Handler) CreateBeansFromRoastBatch(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	batch, err := h.RoastBatchService.CreateBeansFromRoastBatch(r.Context(), id)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("roast_batch_id", batch.Id).Msg("beans successfully created from roast batch")
+
+	h.writeJSONResponse(w, http.StatusCreated, RoastBatchResponse{*batch})
+}
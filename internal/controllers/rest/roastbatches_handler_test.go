@@ -0,0 +1,182 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	modelsql "github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/roastbatch"
+)
+
+func testRoastBatch(id int) *roastbatch.RoastBatch {
+	createdAt := time.Date(2026, time.January, 7, 3, 4, 5, 0, time.UTC)
+	roastDate := time.Date(2026, time.January, 7, 0, 0, 0, 0, time.UTC)
+	return &roastbatch.RoastBatch{
+		Id: id, GreenCoffee: "Ethiopia Guji", RoastDate: &roastDate, RoastLevel: modelsql.RoastLevelLight,
+		GreenWeight: 250, RoastedWeight: 215, WeightLoss: 14,
+		ChargeTemperature: 200, FirstCrackTime: 480, DevelopmentTime: 75, EndTemperature: 205,
+		CurvePoints: []roastbatch.CurvePoint{{Time: 0, Temperature: 200}, {Time: 60, Temperature: 110}},
+		CreatedAt:   &createdAt,
+	}
+}
+
+func newRoastBatchTestHandler(t *testing.T) (*Handler, *fakeRoastBatchService) {
+	t.Helper()
+
+	handler, _, _, _, _ := newTestHandler(t)
+
+	return handler, handler.RoastBatchService.(*fakeRoastBatchService)
+}
+
+func TestRoastBatchHandlersHappyPaths(t *testing.T) {
+	batch := testRoastBatch(1)
+	withBeans := testRoastBatch(1)
+	beansId := 9
+	withBeans.BeansId = &beansId
+	body := `{"green_coffee":"Ethiopia Guji","roast_date":"2026-01-07","roast_level":0,"green_weight":250,"roasted_weight":215,` +
+		`"charge_temperature":200,"first_crack_time":480,"development_time":75,"end_temperature":205,` +
+		`"curve_points":[{"time":0,"temperature":200},{"time":60,"temperature":110}]}`
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		body      string
+		id        string
+		status    int
+		expected  any
+		configure func(*testing.T, *fakeRoastBatchService)
+		handler   controllerHandler
+	}{
+		{
+			name: "create", method: http.MethodPost, target: "/rest/v1/roast_batches", body: body,
+			status: http.StatusCreated, expected: RoastBatchResponse{*batch}, handler: (*Handler).CreateRoastBatch,
+			configure: func(t *testing.T, service *fakeRoastBatchService) {
+				service.createRoastBatch = func(_ context.Context, value *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error) {
+					if value.GreenCoffee != "Ethiopia Guji" || value.GreenWeight != 250 || value.FirstCrackTime != 480 {
+						t.Errorf("batch = %#v, want decoded fields", value)
+					}
+					if len(value.CurvePoints) != 2 || value.CurvePoints[1].Temperature != 110 {
+						t.Errorf("curve = %#v, want two decoded points", value.CurvePoints)
+					}
+					return batch, nil
+				}
+			},
+		},
+		{
+			name: "get all", method: http.MethodGet, target: "/rest/v1/roast_batches",
+			status: http.StatusOK, expected: []RoastBatchResponse{{*batch}}, handler: (*Handler).GetAllRoastBatches,
+			configure: func(_ *testing.T, service *fakeRoastBatchService) {
+				service.getAllRoastBatches = func(context.Context) ([]roastbatch.RoastBatch, error) {
+					return []roastbatch.RoastBatch{*batch}, nil
+				}
+			},
+		},
+		{
+			name: "update", method: http.MethodPut, target: "/rest/v1/roast_batches/1", body: body, id: "1",
+			status: http.StatusOK, expected: RoastBatchResponse{*batch}, handler: (*Handler).UpdateRoastBatchById,
+			configure: func(t *testing.T, service *fakeRoastBatchService) {
+				service.updateRoastBatchByID = func(_ context.Context, id int, _ *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error) {
+					if id != 1 {
+						t.Errorf("id = %d, want 1", id)
+					}
+					return batch, nil
+				}
+			},
+		},
+		{
+			name: "delete", method: http.MethodDelete, target: "/rest/v1/roast_batches/1", id: "1",
+			status: http.StatusOK, expected: ItemDeletedResponse{Id: 1, Msg: "roast batch 1 deleted successfully"}, handler: (*Handler).DeleteRoastBatchById,
+			configure: func(_ *testing.T, service *fakeRoastBatchService) {
+				service.deleteRoastBatchByID = func(context.Context, int) error { return nil }
+			},
+		},
+		{
+			name: "create beans", method: http.MethodPost, target: "/rest/v1/roast_batches/1/beans", id: "1",
+			status: http.StatusCreated, expected: RoastBatchResponse{*withBeans}, handler: (*Handler).CreateBeansFromRoastBatch,
+			configure: func(_ *testing.T, service *fakeRoastBatchService) {
+				service.createBeansFromRoastBatch = func(context.Context, int) (*roastbatch.RoastBatch, error) {
+					return withBeans, nil
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newRoastBatchTestHandler(t)
+			tt.configure(t, service)
+			contentType := ""
+			if tt.body != "" {
+				contentType = ContentTypeApplicationJSON
+			}
+			req := newControllerRequest(t, tt.method, tt.target, tt.body, contentType, tt.id)
+
+			recorder := executeControllerHandler(handler, tt.handler, req)
+
+			assertJSONResponse(t, recorder, tt.status, tt.expected)
+		})
+	}
+}
+
+func TestRoastBatchHandlersErrorPaths(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		body      string
+		id        string
+		status    int
+		message   string
+		configure func(*fakeRoastBatchService)
+		handler   controllerHandler
+	}{
+		{
+			name: "create with roasted weight above green weight", method: http.MethodPost, target: "/rest/v1/roast_batches",
+			body:   `{"green_coffee":"Ethiopia Guji","roast_date":"2026-01-07","green_weight":250,"roasted_weight":260}`,
+			status: http.StatusBadRequest, message: "roast batch weight is out of range. Green weight must be positive and roasted weight between 0 and green weight",
+			handler: (*Handler).CreateRoastBatch,
+			configure: func(service *fakeRoastBatchService) {
+				service.createRoastBatch = func(context.Context, *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error) {
+					return nil, domainerrors.ErrRoastBatchWeightOutOfRange
+				}
+			},
+		},
+		{
+			name: "get missing batch", method: http.MethodGet, target: "/rest/v1/roast_batches/5", id: "5",
+			status: http.StatusNotFound, message: "no roast batch found for given id", handler: (*Handler).GetRoastBatchById,
+			configure: func(service *fakeRoastBatchService) {
+				service.getRoastBatchByID = func(context.Context, int) (*roastbatch.RoastBatch, error) {
+					return nil, domainerrors.ErrRoastBatchDoesNotExist
+				}
+			},
+		},
+		{
+			name: "create beans twice", method: http.MethodPost, target: "/rest/v1/roast_batches/1/beans", id: "1",
+			status: http.StatusConflict, message: "beans were already generated from this roast batch", handler: (*Handler).CreateBeansFromRoastBatch,
+			configure: func(service *fakeRoastBatchService) {
+				service.createBeansFromRoastBatch = func(context.Context, int) (*roastbatch.RoastBatch, error) {
+					return nil, domainerrors.ErrRoastBatchBeansAlreadyExist
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newRoastBatchTestHandler(t)
+			tt.configure(service)
+			contentType := ""
+			if tt.body != "" {
+				contentType = ContentTypeApplicationJSON
+			}
+			req := newControllerRequest(t, tt.method, tt.target, tt.body, contentType, tt.id)
+
+			recorder := executeControllerHandler(handler, tt.handler, req)
+
+			assertJSONResponse(t, recorder, tt.status, ErrorResponse{Msg: tt.message})
+		})
+	}
+}
@@ -56,6 +56,8 @@ type RoasterResponse struct {
 //	Responses:
 //	  201: RoasterResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
 //	  409: ErrorResponse
 //	  413: ErrorResponse
 func (h *Handler) CreateRoaster(w http.ResponseWriter, r *http.Request) {
@@ -118,6 +120,7 @@ func (h *Handler) CreateRoaster(w http.ResponseWriter, r *http.Request) {
 //	Responses:
 //	  200: RoasterResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
 //	  404: ErrorResponse
 func (h *Handler) GetRoasterById(w http.ResponseWriter, r *http.Request) {
 	id, err := h.getIdFromParams(r.Context())
@@ -165,6 +168,7 @@ func (h *Handler) GetRoasterById(w http.ResponseWriter, r *http.Request) {
 //	Responses:
 //	  200: RoasterResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
 //	  404: ErrorResponse
 func (h *Handler) GetAllRoasters(w http.ResponseWriter, r *http.Request) {
 	roasters, err := h.RoasterService.GetAllRoasters(r.Context())
@@ -226,6 +230,8 @@ type UpdateRoasterByIdRequest struct {
 //	Responses:
 //	  200: RoasterResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
 //	  404: ErrorResponse
 //	  409: ErrorResponse
 //	  413: ErrorResponse
@@ -299,6 +305,8 @@ func (h *Handler) UpdateRoasterById(w http.ResponseWriter, r *http.Request) {
 //	Responses:
 //	  200: ItemDeletedResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
 //	  404: ErrorResponse
 func (h *Handler) DeleteRoasterById(w http.ResponseWriter, r *http.Request) {
 	id, err := h.getIdFromParams(r.Context())
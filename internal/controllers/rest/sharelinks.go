@@ -0,0 +1,210 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/services/share"
+	"github.com/rs/zerolog/hlog"
+)
+
+// swagger:parameters createShareLink
+type ShareLinkParams struct {
+	// The optional request body for creating a share link
+	// in: body
+	Body ShareLinkRequest
+}
+
+// ShareLinkRequest represents the request body for creating a share link.
+// The link expires after 7 days when no expiry is given.
+// swagger:model
+type ShareLinkRequest struct {
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// ShareLinkResponse represents a share link of a sheet
+//
+// A share link gives read-only access to a sheet and its shots, without
+// authentication, to anyone opening its path until it expires or is revoked.
+//
+// swagger:response ShareLinkResponse
+type ShareLinkResponse struct {
+	// swagger:allOf
+	share.ShareLink
+
+	// The path of the web page showing the shared sheet
+	Path string `json:"path"`
+}
+
+func newShareLinkResponse(link share.ShareLink) ShareLinkResponse {
+	return ShareLinkResponse{ShareLink: link, Path: "/share/" + link.Token}
+}
+
+// swagger:route POST /rest/v1/sheets/{id}/share_links share_links createShareLink
+//
+// # Create a share link
+//
+// This will create a read-only share link for the sheet with the given id.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the sheet to share
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  201: ShareLinkResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+//	  413: ErrorResponse
+func (h *Handler) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	// The body is optional.
+	var req ShareLinkRequest
+	if r.ContentLength != 0 {
+		if err := h.parseContentType(r); err != nil {
+			h.SetErrorResponse(w, err)
+			return
+		}
+
+		if err := jsonDecodeBody(r, &req); err != nil {
+			h.SetErrorResponse(w, err)
+			return
+		}
+	}
+
+	link, err := h.ShareLinkService.CreateShareLink(r.Context(), id, req.ExpiresAt)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("share_link_id", link.Id).Msg("share link successfully created")
+
+	h.writeJSONResponse(w, http.StatusCreated, newShareLinkResponse(*link))
+}
+
+// swagger:route GET /rest/v1/sheets/{id}/share_links share_links getShareLinksBySheetId
+//
+// # Get the share links of a sheet
+//
+// This will show all share links of the sheet with the given id, including the expired and revoked ones.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the sheet
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: ShareLinkResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetShareLinksBySheetId(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	links, err := h.ShareLinkService.GetShareLinksBySheetId(r.Context(), id)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	resp := make([]ShareLinkResponse, len(links))
+	for k, v := range links {
+		resp[k] = newShareLinkResponse(v)
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, &resp)
+}
+
+// swagger:route DELETE /rest/v1/share_links/{id} share_links revokeShareLink
+//
+// # Revoke a share link
+//
+// This will revoke a share link by its given id. The shared sheet cannot be seen through it anymore.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the share link to revoke
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: ItemDeletedResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) RevokeShareLinkById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := h.ShareLinkService.RevokeShareLinkById(r.Context(), id); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Msg("share link successfully revoked")
+
+	h.writeJSONResponse(w, http.StatusOK, ItemDeletedResponse{
+		Id:  id,
+		Msg: fmt.Sprintf("share link %d revoked successfully", id),
+	})
+}
@@ -0,0 +1,165 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/share"
+)
+
+func testShareLink(id int) *share.ShareLink {
+	createdAt := time.Date(2026, time.October, 18, 20, 0, 0, 0, time.UTC)
+	return &share.ShareLink{
+		Id: id, SheetId: 3, Token: "1.1761422400.c2lnbmF0dXJl",
+		ExpiresAt: createdAt.Add(share.DefaultLifetime), CreatedAt: &createdAt,
+	}
+}
+
+func newShareLinkTestHandler(t *testing.T) (*Handler, *fakeShareLinkService) {
+	t.Helper()
+
+	handler, _, _, _, _ := newTestHandler(t)
+
+	return handler, handler.ShareLinkService.(*fakeShareLinkService)
+}
+
+func TestShareLinkHandlersHappyPaths(t *testing.T) {
+	link := testShareLink(1)
+	expected := ShareLinkResponse{ShareLink: *link, Path: "/share/1.1761422400.c2lnbmF0dXJl"}
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		body      string
+		id        string
+		status    int
+		expected  any
+		configure func(*testing.T, *fakeShareLinkService)
+		handler   controllerHandler
+	}{
+		{
+			name: "create without body", method: http.MethodPost, target: "/rest/v1/sheets/3/share_links", id: "3",
+			status: http.StatusCreated, expected: expected, handler: (*Handler).CreateShareLink,
+			configure: func(t *testing.T, service *fakeShareLinkService) {
+				service.createShareLink = func(_ context.Context, sheetId int, expiresAt *time.Time) (*share.ShareLink, error) {
+					if sheetId != 3 || expiresAt != nil {
+						t.Errorf("CreateShareLink(%d, %v), want sheet 3 and the default expiry", sheetId, expiresAt)
+					}
+					return link, nil
+				}
+			},
+		},
+		{
+			name: "create with expiry", method: http.MethodPost, target: "/rest/v1/sheets/3/share_links", id: "3",
+			body:   `{"expires_at":"2026-10-25T20:00:00Z"}`,
+			status: http.StatusCreated, expected: expected, handler: (*Handler).CreateShareLink,
+			configure: func(t *testing.T, service *fakeShareLinkService) {
+				service.createShareLink = func(_ context.Context, _ int, expiresAt *time.Time) (*share.ShareLink, error) {
+					if expiresAt == nil || !expiresAt.Equal(link.ExpiresAt) {
+						t.Errorf("expires at = %v, want %v", expiresAt, link.ExpiresAt)
+					}
+					return link, nil
+				}
+			},
+		},
+		{
+			name: "get by sheet", method: http.MethodGet, target: "/rest/v1/sheets/3/share_links", id: "3",
+			status: http.StatusOK, expected: []ShareLinkResponse{expected}, handler: (*Handler).GetShareLinksBySheetId,
+			configure: func(_ *testing.T, service *fakeShareLinkService) {
+				service.getShareLinksBySheetID = func(context.Context, int) ([]share.ShareLink, error) {
+					return []share.ShareLink{*link}, nil
+				}
+			},
+		},
+		{
+			name: "revoke", method: http.MethodDelete, target: "/rest/v1/share_links/1", id: "1",
+			status: http.StatusOK, expected: ItemDeletedResponse{Id: 1, Msg: "share link 1 revoked successfully"}, handler: (*Handler).RevokeShareLinkById,
+			configure: func(_ *testing.T, service *fakeShareLinkService) {
+				service.revokeShareLinkByID = func(context.Context, int) error { return nil }
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newShareLinkTestHandler(t)
+			tt.configure(t, service)
+			contentType := ""
+			if tt.body != "" {
+				contentType = ContentTypeApplicationJSON
+			}
+			req := newControllerRequest(t, tt.method, tt.target, tt.body, contentType, tt.id)
+
+			recorder := executeControllerHandler(handler, tt.handler, req)
+
+			assertJSONResponse(t, recorder, tt.status, tt.expected)
+		})
+	}
+}
+
+func TestShareLinkHandlersErrorPaths(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		body        string
+		contentType string
+		id          string
+		status      int
+		message     string
+		configure   func(*fakeShareLinkService)
+		handler     controllerHandler
+	}{
+		{
+			name: "create with expiry in the past", method: http.MethodPost, target: "/rest/v1/sheets/3/share_links", id: "3",
+			body: `{"expires_at":"2020-01-01T00:00:00Z"}`, contentType: ContentTypeApplicationJSON,
+			status: http.StatusBadRequest, message: "share link expiry is invalid. Must be in the future and within 365 days",
+			handler: (*Handler).CreateShareLink,
+			configure: func(service *fakeShareLinkService) {
+				service.createShareLink = func(context.Context, int, *time.Time) (*share.ShareLink, error) {
+					return nil, domainerrors.ErrShareLinkExpiryIsInvalid
+				}
+			},
+		},
+		{
+			name: "create with a body which is not json", method: http.MethodPost, target: "/rest/v1/sheets/3/share_links", id: "3",
+			body: "expires_at=tomorrow", contentType: "application/x-www-form-urlencoded",
+			status: http.StatusUnsupportedMediaType, message: "Content-Type header is not application/json",
+			handler:   (*Handler).CreateShareLink,
+			configure: func(*fakeShareLinkService) {},
+		},
+		{
+			name: "create for missing sheet", method: http.MethodPost, target: "/rest/v1/sheets/5/share_links", id: "5",
+			status: http.StatusNotFound, message: "no sheet found for given id", handler: (*Handler).CreateShareLink,
+			configure: func(service *fakeShareLinkService) {
+				service.createShareLink = func(context.Context, int, *time.Time) (*share.ShareLink, error) {
+					return nil, domainerrors.ErrSheetDoesNotExist
+				}
+			},
+		},
+		{
+			name: "revoke missing share link", method: http.MethodDelete, target: "/rest/v1/share_links/5", id: "5",
+			status: http.StatusNotFound, message: "no share link found for given id", handler: (*Handler).RevokeShareLinkById,
+			configure: func(service *fakeShareLinkService) {
+				service.revokeShareLinkByID = func(context.Context, int) error {
+					return domainerrors.ErrShareLinkDoesNotExist
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newShareLinkTestHandler(t)
+			tt.configure(service)
+			req := newControllerRequest(t, tt.method, tt.target, tt.body, tt.contentType, tt.id)
+
+			recorder := executeControllerHandler(handler, tt.handler, req)
+
+			assertJSONResponse(t, recorder, tt.status, ErrorResponse{Msg: tt.message})
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/hlog"
+)
+
+// swagger:parameters cloneSheet
+type CloneSheetParams struct {
+	// The request body for cloning a sheet
+	// in: body
+	// required: true
+	Body CloneSheetRequest
+}
+
+// CloneSheetRequest represents the request body for cloning a sheet
+// swagger:model
+type CloneSheetRequest struct {
+	// Name of the new sheet
+	Name string `json:"name"`
+	// Whether the best rated shot of the cloned sheet is copied into the new
+	// sheet as its starting point
+	CopyBestShot bool `json:"copy_best_shot"`
+}
+
+// swagger:route POST /rest/v1/sheets/{id}/clone sheets cloneSheet
+//
+// # Clone sheets
+//
+// This will create a new sheet with the given name from the sheet with the given id, in a single transaction. The new sheet is not a template. With copy_best_shot, the best rated shot of the sheet (the latest one on a tie) is copied into the new sheet as its starting point; unrated shots, with a rating of 0, are never copied.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the sheet to clone
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  201: SheetResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+//	  409: ErrorResponse
+//	  413: ErrorResponse
+func (h *Handler) CloneSheet(w http.ResponseWriter, r *http.Request) {
+	var req CloneSheetRequest
+
+	if err := h.parseContentType(r); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := jsonDecodeBody(r, &req); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	sheet, err := h.SheetService.CloneSheet(r.Context(), id, req.Name, req.CopyBestShot)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	sheetResp := SheetResponse{*sheet}
+
+	hlog.FromRequest(r).Debug().Dict("sheet", zerolog.Dict().
+		Int("id", sheet.Id).
+		Int("cloned_from", id).
+		Str("name", sheet.Name)).
+		Msg("sheet successfully cloned")
+
+	h.writeJSONResponse(w, http.StatusCreated, &sheetResp)
+}
@@ -0,0 +1,96 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+)
+
+func TestCloneSheet(t *testing.T) {
+	cloned := testSheet(4, "new bag")
+	tests := []struct {
+		name      string
+		body      string
+		status    int
+		expected  any
+		configure func(*testing.T, *fakeSheetService)
+	}{
+		{
+			name:   "clone with the best shot",
+			body:   `{"name":"new bag","copy_best_shot":true}`,
+			status: http.StatusCreated, expected: SheetResponse{*cloned},
+			configure: func(t *testing.T, service *fakeSheetService) {
+				service.cloneSheet = func(_ context.Context, id int, name string, copyBestShot bool) (*sheet.Sheet, error) {
+					if id != 3 || name != "new bag" || !copyBestShot {
+						t.Errorf("CloneSheet(%d, %q, %t), want sheet 3 cloned to \"new bag\" with its best shot", id, name, copyBestShot)
+					}
+					return cloned, nil
+				}
+			},
+		},
+		{
+			name:   "clone without the best shot",
+			body:   `{"name":"new bag"}`,
+			status: http.StatusCreated, expected: SheetResponse{*cloned},
+			configure: func(t *testing.T, service *fakeSheetService) {
+				service.cloneSheet = func(_ context.Context, _ int, _ string, copyBestShot bool) (*sheet.Sheet, error) {
+					if copyBestShot {
+						t.Error("CloneSheet() copies the best shot, want the sheet only")
+					}
+					return cloned, nil
+				}
+			},
+		},
+		{
+			name:   "clone with an unknown field",
+			body:   `{"name":"new bag","best_shot":true}`,
+			status: http.StatusBadRequest, expected: ErrorResponse{Msg: `request body contains unknown field "best_shot"`},
+			configure: func(*testing.T, *fakeSheetService) {},
+		},
+		{
+			name:   "clone without a name",
+			body:   `{}`,
+			status: http.StatusBadRequest, expected: ErrorResponse{Msg: "sheet name must not be empty"},
+			configure: func(_ *testing.T, service *fakeSheetService) {
+				service.cloneSheet = func(context.Context, int, string, bool) (*sheet.Sheet, error) {
+					return nil, domainerrors.ErrSheetNameIsEmpty
+				}
+			},
+		},
+		{
+			name:   "clone to an existing name",
+			body:   `{"name":"dial in"}`,
+			status: http.StatusConflict, expected: ErrorResponse{Msg: "a sheet with the given name already exists"},
+			configure: func(_ *testing.T, service *fakeSheetService) {
+				service.cloneSheet = func(context.Context, int, string, bool) (*sheet.Sheet, error) {
+					return nil, domainerrors.ErrSheetAlreadyExists
+				}
+			},
+		},
+		{
+			name:   "clone a missing sheet",
+			body:   `{"name":"new bag"}`,
+			status: http.StatusNotFound, expected: ErrorResponse{Msg: "no sheet found for given id"},
+			configure: func(_ *testing.T, service *fakeSheetService) {
+				service.cloneSheet = func(context.Context, int, string, bool) (*sheet.Sheet, error) {
+					return nil, domainerrors.ErrSheetDoesNotExist
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service, _, _, _ := newTestHandler(t)
+			tt.configure(t, service)
+			req := newControllerRequest(t, http.MethodPost, "/rest/v1/sheets/3/clone", tt.body, ContentTypeApplicationJSON, "3")
+
+			recorder := executeControllerHandler(handler, (*Handler).CloneSheet, req)
+
+			assertJSONResponse(t, recorder, tt.status, tt.expected)
+		})
+	}
+}
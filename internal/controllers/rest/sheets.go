@@ -21,6 +21,9 @@ type CreateSheetParams struct {
 // swagger:model
 type CreateSheetRequest struct {
 	Name string `json:"name"`
+	// Whether the sheet is a template new sheets can start from. Defaults
+	// to false.
+	IsTemplate bool `json:"is_template"`
 }
 
 // SheetResponse represents a sheet for this application
@@ -57,6 +60,8 @@ type SheetResponse struct {
 //	Responses:
 //	  201: SheetResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
 //	  409: ErrorResponse
 //	  413: ErrorResponse
 func (h *Handler) CreateSheet(w http.ResponseWriter, r *http.Request) {
@@ -72,7 +77,10 @@ func (h *Handler) CreateSheet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sheet, err := h.SheetService.CreateSheetByName(r.Context(), sheetReq.Name)
+	sheet, err := h.SheetService.CreateSheet(r.Context(), &sheet.Sheet{
+		Name:       sheetReq.Name,
+		IsTemplate: sheetReq.IsTemplate,
+	})
 	if err != nil {
 		h.SetErrorResponse(w, err)
 		return
@@ -119,6 +127,7 @@ func (h *Handler) CreateSheet(w http.ResponseWriter, r *http.Request) {
 //	Responses:
 //	  200: SheetResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
 //	  404: ErrorResponse
 func (h *Handler) GetSheetById(w http.ResponseWriter, r *http.Request) {
 	id, err := h.getIdFromParams(r.Context())
@@ -166,6 +175,7 @@ func (h *Handler) GetSheetById(w http.ResponseWriter, r *http.Request) {
 //	Responses:
 //	  200: SheetResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
 //	  404: ErrorResponse
 func (h *Handler) GetAllSheets(w http.ResponseWriter, r *http.Request) {
 	sheets, err := h.SheetService.GetAllSheets(r.Context())
@@ -194,6 +204,9 @@ type UpdateSheetByIdRequestParams struct {
 // swagger:model
 type UpdateSheetByIdRequest struct {
 	Name string `json:"name"`
+	// Whether the sheet is a template new sheets can start from. The sheet
+	// keeps its current value when omitted.
+	IsTemplate *bool `json:"is_template"`
 }
 
 // swagger:route PUT /rest/v1/sheets/{id} sheets updateSheetById
@@ -227,6 +240,8 @@ type UpdateSheetByIdRequest struct {
 //	Responses:
 //	  200: SheetResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
 //	  404: ErrorResponse
 //	  409: ErrorResponse
 //	  413: ErrorResponse
@@ -253,6 +268,16 @@ func (h *Handler) UpdateSheetById(w http.ResponseWriter, r *http.Request) {
 		Id:   id,
 		Name: sheetReq.Name,
 	}
+	if sheetReq.IsTemplate != nil {
+		sheet.IsTemplate = *sheetReq.IsTemplate
+	} else {
+		current, err := h.SheetService.GetSheetById(r.Context(), id)
+		if err != nil {
+			h.SetErrorResponse(w, err)
+			return
+		}
+		sheet.IsTemplate = current.IsTemplate
+	}
 
 	sheet, err = h.SheetService.UpdateSheetById(r.Context(), id, sheet)
 	if err != nil {
@@ -300,6 +325,8 @@ func (h *Handler) UpdateSheetById(w http.ResponseWriter, r *http.Request) {
 //	Responses:
 //	  200: ItemDeletedResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
 //	  404: ErrorResponse
 func (h *Handler) DeleteSheetById(w http.ResponseWriter, r *http.Request) {
 	id, err := h.getIdFromParams(r.Context())
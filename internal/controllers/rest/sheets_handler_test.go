@@ -31,9 +31,21 @@ func TestSheetHandlersHappyPaths(t *testing.T) {
 			name: "create", method: http.MethodPost, target: "/rest/v1/sheets", body: `{"name":"morning shots"}`,
 			status: http.StatusCreated, expected: SheetResponse{*created}, handler: (*Handler).CreateSheet,
 			configure: func(t *testing.T, service *fakeSheetService) {
-				service.createSheetByName = func(_ context.Context, name string) (*sheet.Sheet, error) {
-					if name != created.Name {
-						t.Errorf("name = %q, want %q", name, created.Name)
+				service.createSheet = func(_ context.Context, value *sheet.Sheet) (*sheet.Sheet, error) {
+					if value.Name != created.Name || value.IsTemplate {
+						t.Errorf("sheet = %#v, want name %q and not a template", value, created.Name)
+					}
+					return created, nil
+				}
+			},
+		},
+		{
+			name: "create a template", method: http.MethodPost, target: "/rest/v1/sheets", body: `{"name":"morning shots","is_template":true}`,
+			status: http.StatusCreated, expected: SheetResponse{*created}, handler: (*Handler).CreateSheet,
+			configure: func(t *testing.T, service *fakeSheetService) {
+				service.createSheet = func(_ context.Context, value *sheet.Sheet) (*sheet.Sheet, error) {
+					if value.Name != created.Name || !value.IsTemplate {
+						t.Errorf("sheet = %#v, want name %q and a template", value, created.Name)
 					}
 					return created, nil
 				}
@@ -70,15 +82,30 @@ func TestSheetHandlersHappyPaths(t *testing.T) {
 			},
 		},
 		{
-			name: "update", method: http.MethodPut, target: "/rest/v1/sheets/9", body: `{"name":"updated"}`, id: "9",
+			name: "update", method: http.MethodPut, target: "/rest/v1/sheets/9", body: `{"name":"updated","is_template":true}`, id: "9",
 			status: http.StatusOK, expected: SheetResponse{*updated}, handler: (*Handler).UpdateSheetById,
 			configure: func(t *testing.T, service *fakeSheetService) {
 				service.updateSheetByID = func(_ context.Context, id int, value *sheet.Sheet) (*sheet.Sheet, error) {
 					if id != updated.Id {
 						t.Errorf("id = %d, want %d", id, updated.Id)
 					}
-					if value.Id != updated.Id || value.Name != updated.Name {
-						t.Errorf("sheet = %#v, want id %d and name %q", value, updated.Id, updated.Name)
+					if value.Id != updated.Id || value.Name != updated.Name || !value.IsTemplate {
+						t.Errorf("sheet = %#v, want id %d, name %q and a template", value, updated.Id, updated.Name)
+					}
+					return updated, nil
+				}
+			},
+		},
+		{
+			name: "update keeps the template flag when omitted", method: http.MethodPut, target: "/rest/v1/sheets/9", body: `{"name":"updated"}`, id: "9",
+			status: http.StatusOK, expected: SheetResponse{*updated}, handler: (*Handler).UpdateSheetById,
+			configure: func(t *testing.T, service *fakeSheetService) {
+				service.getSheetByID = func(context.Context, int) (*sheet.Sheet, error) {
+					return &sheet.Sheet{Id: 9, Name: "template", IsTemplate: true}, nil
+				}
+				service.updateSheetByID = func(_ context.Context, id int, value *sheet.Sheet) (*sheet.Sheet, error) {
+					if value.Name != updated.Name || !value.IsTemplate {
+						t.Errorf("sheet = %#v, want name %q and still a template", value, updated.Name)
 					}
 					return updated, nil
 				}
@@ -131,7 +158,9 @@ func TestSheetHandlersErrorPaths(t *testing.T) {
 			name: "create duplicate", method: http.MethodPost, target: "/rest/v1/sheets", body: `{"name":"duplicate"}`,
 			status: http.StatusConflict, message: "a sheet with the given name already exists", handler: (*Handler).CreateSheet,
 			configure: func(service *fakeSheetService) {
-				service.createSheetByName = func(context.Context, string) (*sheet.Sheet, error) { return nil, domainerrors.ErrSheetAlreadyExists }
+				service.createSheet = func(context.Context, *sheet.Sheet) (*sheet.Sheet, error) {
+					return nil, domainerrors.ErrSheetAlreadyExists
+				}
 			},
 		},
 		{
@@ -149,7 +178,7 @@ func TestSheetHandlersErrorPaths(t *testing.T) {
 			},
 		},
 		{
-			name: "update duplicate", method: http.MethodPut, target: "/rest/v1/sheets/5", body: `{"name":"duplicate"}`, id: "5",
+			name: "update duplicate", method: http.MethodPut, target: "/rest/v1/sheets/5", body: `{"name":"duplicate","is_template":false}`, id: "5",
 			status: http.StatusConflict, message: "a sheet with the given name already exists", handler: (*Handler).UpdateSheetById,
 			configure: func(service *fakeSheetService) {
 				service.updateSheetByID = func(context.Context, int, *sheet.Sheet) (*sheet.Sheet, error) {
@@ -157,6 +186,13 @@ func TestSheetHandlersErrorPaths(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "update missing sheet without the template flag", method: http.MethodPut, target: "/rest/v1/sheets/5", body: `{"name":"missing"}`, id: "5",
+			status: http.StatusNotFound, message: "no sheet found for given id", handler: (*Handler).UpdateSheetById,
+			configure: func(service *fakeSheetService) {
+				service.getSheetByID = func(context.Context, int) (*sheet.Sheet, error) { return nil, domainerrors.ErrSheetDoesNotExist }
+			},
+		},
 		{
 			name: "delete referenced sheet", method: http.MethodDelete, target: "/rest/v1/sheets/5", id: "5",
 			status: http.StatusBadRequest, message: "cannot delete due to existing references: shot foreign key constraint failed", handler: (*Handler).DeleteSheetById,
@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+)
+
+// swagger:parameters compareShots
+type CompareShotsParams struct {
+	// The ids of the shots to compare, separated by commas: between 2 and 6
+	// distinct ids, the first shot being the reference
+	// in: query
+	// required: true
+	// example: 12,15,18
+	Ids string `json:"ids"`
+}
+
+// ShotDeltaResponse is how much a shot differs from the reference shot
+type ShotDeltaResponse struct {
+	// swagger:allOf
+	shot.Delta
+	// The difference of shot time, in seconds
+	ShotTime DurationSeconds `json:"shot_time"`
+}
+
+// ShotComparisonResponse represents shots set side by side
+//
+// The deltas are the values of every shot minus the values of the first
+// one, and the differences list the parameters which are not the same for
+// every shot.
+//
+// swagger:response ShotComparisonResponse
+type ShotComparisonResponse struct {
+	// swagger:allOf
+	shot.Comparison
+	// The compared shots, in the requested order
+	Shots []ShotResponse `json:"shots"`
+	// The differences of every shot with the first one, in the same order
+	Deltas []ShotDeltaResponse `json:"deltas"`
+}
+
+func newShotComparisonResponse(c shot.Comparison) ShotComparisonResponse {
+	resp := ShotComparisonResponse{
+		Comparison: c,
+		Shots:      make([]ShotResponse, len(c.Shots)),
+		Deltas:     make([]ShotDeltaResponse, len(c.Deltas)),
+	}
+	for i, s := range c.Shots {
+		resp.Shots[i] = newShotResponse(s)
+	}
+	for i, d := range c.Deltas {
+		resp.Deltas[i] = ShotDeltaResponse{Delta: d, ShotTime: NewDurationSeconds(d.ShotTime)}
+	}
+	return resp
+}
+
+// swagger:route GET /rest/v1/shots/compare shots compareShots
+//
+// # Compare shots
+//
+// This will set the shots with the given ids side by side, with the differences of every shot with the first one for the grind setting, dose, yield, shot time, water temperature and rating.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: ShotComparisonResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) CompareShots(w http.ResponseWriter, r *http.Request) {
+	ids, err := shot.ParseShotIds(r.URL.Query().Get("ids"))
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	comparison, err := h.ShotService.CompareShots(r.Context(), ids)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, newShotComparisonResponse(*comparison))
+}
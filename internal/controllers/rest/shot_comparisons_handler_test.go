@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+)
+
+func TestCompareShots(t *testing.T) {
+	comparison := shot.Compare([]shot.Shot{
+		{Id: 4, GrindSetting: 12, QuantityIn: 18, QuantityOut: 36, ShotTime: 28 * time.Second, Rating: 7},
+		{Id: 9, GrindSetting: 10, QuantityIn: 18, QuantityOut: 40, ShotTime: 31500 * time.Millisecond, Rating: 8.5},
+	})
+	tests := []struct {
+		name      string
+		target    string
+		status    int
+		expected  any
+		configure func(*testing.T, *fakeShotService)
+	}{
+		{
+			name: "compare", target: "/rest/v1/shots/compare?ids=4,9",
+			status: http.StatusOK, expected: newShotComparisonResponse(*comparison),
+			configure: func(t *testing.T, service *fakeShotService) {
+				service.compareShots = func(_ context.Context, ids []int) (*shot.Comparison, error) {
+					if !slices.Equal(ids, []int{4, 9}) {
+						t.Errorf("CompareShots(%v), want shots 4 and 9", ids)
+					}
+					return comparison, nil
+				}
+			},
+		},
+		{
+			name: "compare a single shot", target: "/rest/v1/shots/compare?ids=4",
+			status: http.StatusBadRequest, expected: ErrorResponse{Msg: domainerrors.ErrShotCompareIdsAreInvalid.Error()},
+			configure: func(*testing.T, *fakeShotService) {},
+		},
+		{
+			name: "compare without ids", target: "/rest/v1/shots/compare",
+			status: http.StatusBadRequest, expected: ErrorResponse{Msg: domainerrors.ErrShotCompareIdsAreInvalid.Error()},
+			configure: func(*testing.T, *fakeShotService) {},
+		},
+		{
+			name: "compare a missing shot", target: "/rest/v1/shots/compare?ids=4,99",
+			status: http.StatusNotFound, expected: ErrorResponse{Msg: "no shot found for given id"},
+			configure: func(_ *testing.T, service *fakeShotService) {
+				service.compareShots = func(context.Context, []int) (*shot.Comparison, error) {
+					return nil, domainerrors.ErrShotDoesNotExist
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _, _, _, service := newTestHandler(t)
+			tt.configure(t, service)
+			req := newControllerRequest(t, http.MethodGet, tt.target, "", "", "")
+
+			recorder := executeControllerHandler(handler, (*Handler).CompareShots, req)
+
+			assertJSONResponse(t, recorder, tt.status, tt.expected)
+		})
+	}
+}
+
+func TestNewShotComparisonResponse_ShotTimeDeltaInSeconds(t *testing.T) {
+	comparison := shot.Compare([]shot.Shot{{Id: 1, ShotTime: 30 * time.Second}, {Id: 2, ShotTime: 27500 * time.Millisecond}})
+
+	resp := newShotComparisonResponse(*comparison)
+
+	if got := resp.Deltas[1].ShotTime; got != NewDurationSeconds(-2500*time.Millisecond) {
+		t.Errorf("shot_time delta = %v, want -2.5 seconds", got)
+	}
+	if len(resp.Shots) != 2 || resp.Shots[1].Id != 2 {
+		t.Errorf("shots = %+v, want shots 1 and 2", resp.Shots)
+	}
+}
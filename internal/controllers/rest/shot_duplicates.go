@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+)
+
+// swagger:parameters duplicateShot
+type DuplicateShotParams struct {
+	// The optional request body for overriding parameters of the duplicated
+	// shot
+	// in: body
+	Body DuplicateShotRequest
+}
+
+// DuplicateShotRequest represents the request body for duplicating a shot.
+// Every field is optional: an omitted field is copied from the duplicated
+// shot for its sheet, beans, grind setting, quantities, shot time and water
+// temperature, and is reset for its result.
+// swagger:model
+type DuplicateShotRequest struct {
+	SheetId      *int     `json:"sheet_id"`
+	BeansId      *int     `json:"beans_id"`
+	GrindSetting *int     `json:"grind_setting"`
+	QuantityIn   *float64 `json:"quantity_in"`
+	QuantityOut  *float64 `json:"quantity_out"`
+	// Shot duration in seconds (0 < value <= 3600), e.g. 28.5
+	ShotTime                     *DurationSeconds                  `json:"shot_time"`
+	WaterTemperature             *float64                          `json:"water_temperature"`
+	Rating                       *float64                          `json:"rating"`
+	IsTooBitter                  *bool                             `json:"is_too_bitter"`
+	IsTooSour                    *bool                             `json:"is_too_sour"`
+	ComparisonWithPreviousResult *sql.ComparisonWithPreviousResult `json:"comparison_with_previous_result"`
+	AdditionalNotes              *string                           `json:"additional_notes"`
+}
+
+func (req DuplicateShotRequest) overrides() shot.Overrides {
+	o := shot.Overrides{
+		SheetId:                      req.SheetId,
+		BeansId:                      req.BeansId,
+		GrindSetting:                 req.GrindSetting,
+		QuantityIn:                   req.QuantityIn,
+		QuantityOut:                  req.QuantityOut,
+		WaterTemperature:             req.WaterTemperature,
+		Rating:                       req.Rating,
+		IsTooBitter:                  req.IsTooBitter,
+		IsTooSour:                    req.IsTooSour,
+		ComparisonWithPreviousResult: req.ComparisonWithPreviousResult,
+		AdditionalNotes:              req.AdditionalNotes,
+	}
+	if req.ShotTime != nil {
+		d := req.ShotTime.Duration()
+		o.ShotTime = &d
+	}
+	return o
+}
+
+// swagger:route POST /rest/v1/shots/{id}/duplicate shots duplicateShot
+//
+// # Duplicate shots
+//
+// This will create a new shot pulled again from the shot with the given id: with the same sheet, beans, grind setting, quantities, shot time and water temperature, not rated yet and with an unknown comparison with the previous result. The fields of the optional body override the duplicated ones.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the shot to duplicate
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  201: ShotResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+//	  409: ErrorResponse
+//	  413: ErrorResponse
+func (h *Handler) DuplicateShot(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	// The body is optional. An empty one may also be sent chunked, without a
+	// length: the decoder then finds nothing to read, and there is no
+	// override either.
+	var req DuplicateShotRequest
+	if r.ContentLength != 0 {
+		err := jsonDecodeBody(r, &req)
+		if !errors.Is(err, io.EOF) {
+			// A body of the wrong type is reported as such rather than
+			// as invalid json.
+			if ctErr := h.parseContentType(r); ctErr != nil {
+				err = ctErr
+			}
+			if err != nil {
+				h.SetErrorResponse(w, err)
+				return
+			}
+		}
+	}
+
+	shot, err := h.ShotService.DuplicateShot(r.Context(), id, req.overrides())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	shotResp := newShotResponse(*shot)
+	logShotFromRequest(r, shot, "shot successfully duplicated")
+
+	h.writeJSONResponse(w, http.StatusCreated, shotResp)
+}
@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+)
+
+func TestDuplicateShot(t *testing.T) {
+	duplicated := testShot(4)
+	grind, shotTime := 10, 30500*time.Millisecond
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		chunked     bool
+		status      int
+		expected    any
+		configure   func(*testing.T, *fakeShotService)
+	}{
+		{
+			name:   "duplicate without a body",
+			status: http.StatusCreated, expected: newShotResponse(*duplicated),
+			configure: func(t *testing.T, service *fakeShotService) {
+				service.duplicateShot = func(_ context.Context, id int, o shot.Overrides) (*shot.Shot, error) {
+					if id != 3 || !reflect.DeepEqual(o, shot.Overrides{}) {
+						t.Errorf("DuplicateShot(%d, %+v), want shot 3 without overrides", id, o)
+					}
+					return duplicated, nil
+				}
+			},
+		},
+		{
+			name: "duplicate with an empty chunked body", chunked: true,
+			status: http.StatusCreated, expected: newShotResponse(*duplicated),
+			configure: func(t *testing.T, service *fakeShotService) {
+				service.duplicateShot = func(_ context.Context, id int, o shot.Overrides) (*shot.Shot, error) {
+					if id != 3 || !reflect.DeepEqual(o, shot.Overrides{}) {
+						t.Errorf("DuplicateShot(%d, %+v), want shot 3 without overrides", id, o)
+					}
+					return duplicated, nil
+				}
+			},
+		},
+		{
+			name: "duplicate with a chunked body of the wrong type", chunked: true, contentType: "text/plain",
+			body:   `{"grind_setting":10}`,
+			status: http.StatusUnsupportedMediaType, expected: ErrorResponse{Msg: "Content-Type header is not application/json"},
+			configure: func(*testing.T, *fakeShotService) {},
+		},
+		{
+			name: "duplicate with overrides", contentType: ContentTypeApplicationJSON,
+			body:   `{"grind_setting":10,"shot_time":30.5}`,
+			status: http.StatusCreated, expected: newShotResponse(*duplicated),
+			configure: func(t *testing.T, service *fakeShotService) {
+				service.duplicateShot = func(_ context.Context, id int, o shot.Overrides) (*shot.Shot, error) {
+					want := shot.Overrides{GrindSetting: &grind, ShotTime: &shotTime}
+					if id != 3 || !reflect.DeepEqual(o, want) {
+						t.Errorf("DuplicateShot(%d, %+v), want shot 3 with the grind setting and shot time overridden", id, o)
+					}
+					return duplicated, nil
+				}
+			},
+		},
+		{
+			name: "duplicate with an unknown field", contentType: ContentTypeApplicationJSON,
+			body:   `{"grind":10}`,
+			status: http.StatusBadRequest, expected: ErrorResponse{Msg: `request body contains unknown field "grind"`},
+			configure: func(*testing.T, *fakeShotService) {},
+		},
+		{
+			name: "duplicate with an out of range rating", contentType: ContentTypeApplicationJSON,
+			body:   `{"rating":11}`,
+			status: http.StatusBadRequest, expected: ErrorResponse{Msg: domainerrors.ErrShotRatingOutOfRange.Error()},
+			configure: func(_ *testing.T, service *fakeShotService) {
+				service.duplicateShot = func(context.Context, int, shot.Overrides) (*shot.Shot, error) {
+					return nil, domainerrors.ErrShotRatingOutOfRange
+				}
+			},
+		},
+		{
+			name:   "duplicate a missing shot",
+			status: http.StatusNotFound, expected: ErrorResponse{Msg: "no shot found for given id"},
+			configure: func(_ *testing.T, service *fakeShotService) {
+				service.duplicateShot = func(context.Context, int, shot.Overrides) (*shot.Shot, error) {
+					return nil, domainerrors.ErrShotDoesNotExist
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _, _, _, service := newTestHandler(t)
+			tt.configure(t, service)
+			req := newControllerRequest(t, http.MethodPost, "/rest/v1/shots/3/duplicate", tt.body, tt.contentType, "3")
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+
+			recorder := executeControllerHandler(handler, (*Handler).DuplicateShot, req)
+
+			assertJSONResponse(t, recorder, tt.status, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/rs/zerolog/hlog"
+)
+
+// swagger:parameters updateShotProfileById
+type ShotProfileParams struct {
+	// The request body for replacing the profile of a shot
+	// in: body
+	// required: true
+	Body ShotProfileRequest
+}
+
+// ShotProfileRequest represents the request body for replacing the profile of
+// a shot. The samples are sorted by time, and an empty list removes the
+// profile.
+// swagger:model
+type ShotProfileRequest struct {
+	Samples []shot.ProfileSample `json:"samples"`
+}
+
+// ShotProfileResponse represents the profile of a shot
+//
+// The profile is the telemetry recorded by the machine during the shot:
+// pressure, flow, weight and temperature samples ordered by time.
+//
+// swagger:response ShotProfileResponse
+type ShotProfileResponse struct {
+	// swagger:allOf
+	shot.Profile
+}
+
+// swagger:route GET /rest/v1/shots/{id}/profile shots getShotProfileById
+//
+// # Get the profile of a shot
+//
+// This will get the profile of the shot with the given id. A shot without a profile has no samples.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the shot
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: ShotProfileResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetShotProfileById(w http.ResponseWriter, r *http.Request) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	profile, err := h.ShotService.GetShotProfileById(r.Context(), id)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, ShotProfileResponse{*profile})
+}
+
+// swagger:route PUT /rest/v1/shots/{id}/profile shots updateShotProfileById
+//
+// # Replace the profile of a shot
+//
+// This will replace the profile of the shot with the given id by the given samples. An empty list of samples removes the profile.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the shot
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: ShotProfileResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  404: ErrorResponse
+//	  413: ErrorResponse
+func (h *Handler) UpdateShotProfileById(w http.ResponseWriter, r *http.Request) {
+	var req ShotProfileRequest
+
+	if err := h.parseContentType(r); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	if err := jsonDecodeBody(r, &req); err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	profile, err := h.ShotService.UpdateShotProfileById(r.Context(), id, &shot.Profile{Samples: req.Samples})
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Int("shot_id", id).Int("samples", len(profile.Samples)).Msg("shot profile successfully updated")
+
+	h.writeJSONResponse(w, http.StatusOK, ShotProfileResponse{*profile})
+}
@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+)
+
+func TestShotProfileHandlers(t *testing.T) {
+	pressure, weight := 9.0, 18.5
+	profile := &shot.Profile{ShotId: 3, Samples: []shot.ProfileSample{
+		{Time: 0, Pressure: &pressure},
+		{Time: 12.25, Pressure: &pressure, Weight: &weight},
+	}}
+	tests := []struct {
+		name        string
+		method      string
+		body        string
+		contentType string
+		status      int
+		expected    any
+		configure   func(*testing.T, *fakeShotService)
+		handler     controllerHandler
+	}{
+		{
+			name: "get", method: http.MethodGet,
+			status: http.StatusOK, expected: ShotProfileResponse{*profile}, handler: (*Handler).GetShotProfileById,
+			configure: func(t *testing.T, service *fakeShotService) {
+				service.getShotProfile = func(_ context.Context, id int) (*shot.Profile, error) {
+					if id != 3 {
+						t.Errorf("GetShotProfileById(%d), want shot 3", id)
+					}
+					return profile, nil
+				}
+			},
+		},
+		{
+			name: "get of a missing shot", method: http.MethodGet,
+			status: http.StatusNotFound, expected: ErrorResponse{Msg: "no shot found for given id"}, handler: (*Handler).GetShotProfileById,
+			configure: func(_ *testing.T, service *fakeShotService) {
+				service.getShotProfile = func(context.Context, int) (*shot.Profile, error) {
+					return nil, domainerrors.ErrShotDoesNotExist
+				}
+			},
+		},
+		{
+			name: "update", method: http.MethodPut, contentType: ContentTypeApplicationJSON,
+			body:   `{"samples":[{"t":12.25,"pressure":9,"weight":18.5},{"t":0,"pressure":9}]}`,
+			status: http.StatusOK, expected: ShotProfileResponse{*profile}, handler: (*Handler).UpdateShotProfileById,
+			configure: func(t *testing.T, service *fakeShotService) {
+				service.updateShotProfile = func(_ context.Context, id int, p *shot.Profile) (*shot.Profile, error) {
+					if id != 3 || len(p.Samples) != 2 || p.Samples[0].Time != 12.25 || *p.Samples[0].Weight != 18.5 || p.Samples[1].Flow != nil {
+						t.Errorf("UpdateShotProfileById(%d, %+v), want shot 3 and the decoded samples", id, p)
+					}
+					return profile, nil
+				}
+			},
+		},
+		{
+			name: "update with invalid samples", method: http.MethodPut, contentType: ContentTypeApplicationJSON,
+			body:   `{"samples":[{"t":1},{"t":1}]}`,
+			status: http.StatusBadRequest, expected: ErrorResponse{Msg: "shot profile is invalid. Sample times must be distinct and between 0 and 3600 seconds, and values must not be negative"},
+			handler: (*Handler).UpdateShotProfileById,
+			configure: func(_ *testing.T, service *fakeShotService) {
+				service.updateShotProfile = func(context.Context, int, *shot.Profile) (*shot.Profile, error) {
+					return nil, domainerrors.ErrShotProfileIsInvalid
+				}
+			},
+		},
+		{
+			name: "update with an unknown field", method: http.MethodPut, contentType: ContentTypeApplicationJSON,
+			body:   `{"points":[]}`,
+			status: http.StatusBadRequest, expected: ErrorResponse{Msg: `request body contains unknown field "points"`},
+			handler: (*Handler).UpdateShotProfileById, configure: func(*testing.T, *fakeShotService) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _, _, _, service := newTestHandler(t)
+			tt.configure(t, service)
+			req := newControllerRequest(t, tt.method, "/rest/v1/shots/3/profile", tt.body, tt.contentType, "3")
+
+			recorder := executeControllerHandler(handler, tt.handler, req)
+
+			assertJSONResponse(t, recorder, tt.status, tt.expected)
+		})
+	}
+}
@@ -115,6 +115,8 @@ func logShotFromRequest(r *http.Request, shot *shot.Shot, msg string) {
 //	Responses:
 //	  201: ShotResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
 //	  409: ErrorResponse
 //	  413: ErrorResponse
 func (h *Handler) CreateShot(w http.ResponseWriter, r *http.Request) {
@@ -187,6 +189,7 @@ func (h *Handler) CreateShot(w http.ResponseWriter, r *http.Request) {
 //	Responses:
 //	  200: ShotResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
 //	  404: ErrorResponse
 func (h *Handler) GetShotById(w http.ResponseWriter, r *http.Request) {
 	id, err := h.getIdFromParams(r.Context())
@@ -229,6 +232,7 @@ func (h *Handler) GetShotById(w http.ResponseWriter, r *http.Request) {
 //	Responses:
 //	  200: ShotResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
 //	  404: ErrorResponse
 func (h *Handler) GetAllShots(w http.ResponseWriter, r *http.Request) {
 	shots, err := h.ShotService.GetAllShots(r.Context())
@@ -302,6 +306,8 @@ type UpdateShotByIdRequest struct {
 //	Responses:
 //	  200: ShotResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
 //	  404: ErrorResponse
 //	  413: ErrorResponse
 func (h *Handler) UpdateShotById(w http.ResponseWriter, r *http.Request) {
@@ -381,6 +387,8 @@ func (h *Handler) UpdateShotById(w http.ResponseWriter, r *http.Request) {
 //	Responses:
 //	  200: ItemDeletedResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
 //	  404: ErrorResponse
 func (h *Handler) DeleteShotById(w http.ResponseWriter, r *http.Request) {
 	id, err := h.getIdFromParams(r.Context())
@@ -437,6 +445,7 @@ func (h *Handler) DeleteShotById(w http.ResponseWriter, r *http.Request) {
 //	Responses:
 //	  200: ShotResponse
 //	  400: ErrorResponse
+//	  401: ErrorResponse
 //	  404: ErrorResponse
 func (h *Handler) GetShotsBySheetId(w http.ResponseWriter, r *http.Request) {
 	id, err := h.getIdFromParams(r.Context())
@@ -0,0 +1,300 @@
+package rest
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+
+	"github.com/lescactus/espressoapi-go/internal/services/spreadsheet"
+	"github.com/rs/zerolog/hlog"
+)
+
+// swagger:parameters importSheetsCSV importRoastersCSV importBeansCSV importShotsCSV
+type CSVImportParams struct {
+	// The CSV file, with a header row naming its columns as in the export.
+	// in: formData
+	// required: true
+	// swagger:file
+	File []byte `json:"file"`
+}
+
+// CSVImportReportResponse represents the outcome of the import of a CSV file
+//
+// Every row is reported with the id of the record created from it, or with
+// why it could not be imported.
+//
+// swagger:response CSVImportReportResponse
+type CSVImportReportResponse struct {
+	// swagger:allOf
+	spreadsheet.Report
+}
+
+// swagger:route GET /rest/v1/sheets.csv sheets exportSheetsCSV
+//
+// # Export sheets as CSV
+//
+// This will download every sheet as a CSV file, one sheet per row.
+//
+//	Produces:
+//	- text/csv
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: description: The sheets.csv file
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+func (h *Handler) ExportSheetsCSV(w http.ResponseWriter, r *http.Request) {
+	h.exportCSV(w, r, spreadsheet.ResourceSheets)
+}
+
+// swagger:route GET /rest/v1/roasters.csv roasters exportRoastersCSV
+//
+// # Export roasters as CSV
+//
+// This will download every roaster as a CSV file, one roaster per row.
+//
+//	Produces:
+//	- text/csv
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: description: The roasters.csv file
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+func (h *Handler) ExportRoastersCSV(w http.ResponseWriter, r *http.Request) {
+	h.exportCSV(w, r, spreadsheet.ResourceRoasters)
+}
+
+// swagger:route GET /rest/v1/beans.csv beans exportBeansCSV
+//
+// # Export beans as CSV
+//
+// This will download all beans as a CSV file, one beans per row with the name of their roaster.
+//
+//	Produces:
+//	- text/csv
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: description: The beans.csv file
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+func (h *Handler) ExportBeansCSV(w http.ResponseWriter, r *http.Request) {
+	h.exportCSV(w, r, spreadsheet.ResourceBeans)
+}
+
+// swagger:route GET /rest/v1/shots.csv shots exportShotsCSV
+//
+// # Export shots as CSV
+//
+// This will download every shot as a CSV file, one shot per row with the name of its sheet, beans and roaster, and its shot time in seconds.
+//
+//	Produces:
+//	- text/csv
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: description: The shots.csv file
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+func (h *Handler) ExportShotsCSV(w http.ResponseWriter, r *http.Request) {
+	h.exportCSV(w, r, spreadsheet.ResourceShots)
+}
+
+// swagger:route POST /rest/v1/import/sheets.csv sheets importSheetsCSV
+//
+// # Import sheets from CSV
+//
+// This will create a sheet from every row of the uploaded CSV file, named by its name column. Rows which cannot be imported are reported with the reason why. The request body is limited by the server maximum request size.
+//
+//	Consumes:
+//	- multipart/form-data
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: CSVImportReportResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  413: ErrorResponse
+//	  415: ErrorResponse
+func (h *Handler) ImportSheetsCSV(w http.ResponseWriter, r *http.Request) {
+	h.importCSV(w, r, spreadsheet.ResourceSheets)
+}
+
+// swagger:route POST /rest/v1/import/roasters.csv roasters importRoastersCSV
+//
+// # Import roasters from CSV
+//
+// This will create a roaster from every row of the uploaded CSV file, named by its name column. Rows which cannot be imported are reported with the reason why. The request body is limited by the server maximum request size.
+//
+//	Consumes:
+//	- multipart/form-data
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: CSVImportReportResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  413: ErrorResponse
+//	  415: ErrorResponse
+func (h *Handler) ImportRoastersCSV(w http.ResponseWriter, r *http.Request) {
+	h.importCSV(w, r, spreadsheet.ResourceRoasters)
+}
+
+// swagger:route POST /rest/v1/import/beans.csv beans importBeansCSV
+//
+// # Import beans from CSV
+//
+// This will create beans from every row of the uploaded CSV file. Their roaster is given by the roaster_id column, or by the name of an existing roaster in the roaster column. Rows which cannot be imported are reported with the reason why. The request body is limited by the server maximum request size.
+//
+//	Consumes:
+//	- multipart/form-data
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: CSVImportReportResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  413: ErrorResponse
+//	  415: ErrorResponse
+func (h *Handler) ImportBeansCSV(w http.ResponseWriter, r *http.Request) {
+	h.importCSV(w, r, spreadsheet.ResourceBeans)
+}
+
+// swagger:route POST /rest/v1/import/shots.csv shots importShotsCSV
+//
+// # Import shots from CSV
+//
+// This will create a shot from every row of the uploaded CSV file. Its sheet and beans are given by the sheet_id and beans_id columns, or by the names of existing ones in the sheet, beans and roaster columns, and its shot time in seconds. Rows which cannot be imported are reported with the reason why. The request body is limited by the server maximum request size.
+//
+//	Consumes:
+//	- multipart/form-data
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: CSVImportReportResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  403: ErrorResponse
+//	  413: ErrorResponse
+//	  415: ErrorResponse
+func (h *Handler) ImportShotsCSV(w http.ResponseWriter, r *http.Request) {
+	h.importCSV(w, r, spreadsheet.ResourceShots)
+}
+
+func (h *Handler) exportCSV(w http.ResponseWriter, r *http.Request, resource spreadsheet.Resource) {
+	download := &csvDownload{ResponseWriter: w, filename: string(resource) + ".csv"}
+	if err := h.SpreadsheetService.Export(r.Context(), resource, download); err != nil {
+		if !download.started {
+			h.SetErrorResponse(w, err)
+			return
+		}
+		hlog.FromRequest(r).Error().Err(err).Str("resource", string(resource)).Msg("csv export interrupted")
+	}
+}
+
+func (h *Handler) importCSV(w http.ResponseWriter, r *http.Request, resource spreadsheet.Resource) {
+	_, data, err := readUploadedFile(r)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	report, err := h.SpreadsheetService.Import(r.Context(), resource, bytes.NewReader(data))
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	hlog.FromRequest(r).Debug().Str("resource", string(resource)).Int("imported", report.Imported).Int("failed", report.Failed).Msg("csv successfully imported")
+
+	h.writeJSONResponse(w, http.StatusOK, CSVImportReportResponse{*report})
+}
+
+// csvDownload streams a CSV file as an attachment, setting its headers on
+// the first write so that an error occurring before it can still be answered
+// as JSON.
+type csvDownload struct {
+	http.ResponseWriter
+	filename string
+	started  bool
+}
+
+func (d *csvDownload) Write(p []byte) (int, error) {
+	if !d.started {
+		d.started = true
+		d.Header().Set("Content-Type", ContentTypeTextCSV)
+		d.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": d.filename}))
+	}
+	return d.ResponseWriter.Write(p)
+}
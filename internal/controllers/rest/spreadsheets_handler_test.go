@@ -0,0 +1,150 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/spreadsheet"
+)
+
+func newSpreadsheetTestHandler(t *testing.T) (*Handler, *fakeSpreadsheetService) {
+	t.Helper()
+
+	handler, _, _, _, _ := newTestHandler(t)
+
+	return handler, handler.SpreadsheetService.(*fakeSpreadsheetService)
+}
+
+func TestExportCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		export   func(*Handler, http.ResponseWriter, *http.Request)
+		resource spreadsheet.Resource
+	}{
+		{name: "sheets", target: "/rest/v1/sheets.csv", export: (*Handler).ExportSheetsCSV, resource: spreadsheet.ResourceSheets},
+		{name: "roasters", target: "/rest/v1/roasters.csv", export: (*Handler).ExportRoastersCSV, resource: spreadsheet.ResourceRoasters},
+		{name: "beans", target: "/rest/v1/beans.csv", export: (*Handler).ExportBeansCSV, resource: spreadsheet.ResourceBeans},
+		{name: "shots", target: "/rest/v1/shots.csv", export: (*Handler).ExportShotsCSV, resource: spreadsheet.ResourceShots},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newSpreadsheetTestHandler(t)
+			service.exportCSV = func(_ context.Context, resource spreadsheet.Resource, w io.Writer) error {
+				if resource != tt.resource {
+					t.Errorf("Export(%q), want %q", resource, tt.resource)
+				}
+				_, err := io.WriteString(w, "id,name\n1,Espresso\n")
+				return err
+			}
+			req := newControllerRequest(t, http.MethodGet, tt.target, "", "", "")
+
+			recorder := executeControllerHandler(handler, tt.export, req)
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
+			}
+			if got, want := recorder.Header().Get("Content-Type"), ContentTypeTextCSV; got != want {
+				t.Errorf("Content-Type = %q, want %q", got, want)
+			}
+			if got, want := recorder.Header().Get("Content-Disposition"), "attachment; filename="+tt.name+".csv"; got != want {
+				t.Errorf("Content-Disposition = %q, want %q", got, want)
+			}
+			if got, want := recorder.Body.String(), "id,name\n1,Espresso\n"; got != want {
+				t.Errorf("body = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestExportCSVServiceError(t *testing.T) {
+	handler, service := newSpreadsheetTestHandler(t)
+	service.exportCSV = func(context.Context, spreadsheet.Resource, io.Writer) error {
+		return errors.New("could not export shots: connection refused")
+	}
+	req := newControllerRequest(t, http.MethodGet, "/rest/v1/shots.csv", "", "", "")
+
+	recorder := executeControllerHandler(handler, (*Handler).ExportShotsCSV, req)
+
+	assertJSONResponse(t, recorder, http.StatusInternalServerError, ErrorResponse{Msg: "internal server error"})
+	if got := recorder.Header().Get("Content-Disposition"); got != "" {
+		t.Errorf("Content-Disposition = %q, want none", got)
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	handler, service := newSpreadsheetTestHandler(t)
+	id := 12
+	report := &spreadsheet.Report{Imported: 1, Failed: 1, Rows: []spreadsheet.Row{
+		{Line: 2, Id: &id},
+		{Line: 3, Error: "could not create beans: beans name is empty"},
+	}}
+	service.importCSV = func(_ context.Context, resource spreadsheet.Resource, r io.Reader) (*spreadsheet.Report, error) {
+		if resource != spreadsheet.ResourceBeans {
+			t.Errorf("Import(%q), want beans", resource)
+		}
+		if data, _ := io.ReadAll(r); string(data) != "name,roaster\nRed Brick,Square Mile\n,Square Mile\n" {
+			t.Errorf("Import(%q), want the uploaded file", data)
+		}
+		return report, nil
+	}
+	body, contentType := multipartBody(t, "file", "beans.csv", []byte("name,roaster\nRed Brick,Square Mile\n,Square Mile\n"))
+	req := newControllerRequest(t, http.MethodPost, "/rest/v1/import/beans.csv", body, contentType, "")
+
+	recorder := executeControllerHandler(handler, (*Handler).ImportBeansCSV, req)
+
+	assertJSONResponse(t, recorder, http.StatusOK, CSVImportReportResponse{*report})
+}
+
+func TestImportCSVErrorPaths(t *testing.T) {
+	body, contentType := multipartBody(t, "file", "shots.csv", []byte("not,a\n\"csv"))
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		status      int
+		message     string
+		configure   func(*fakeSpreadsheetService)
+	}{
+		{
+			name: "csv body", body: "name\nEspresso\n", contentType: "text/csv",
+			status: http.StatusUnsupportedMediaType, message: "Content-Type header is not multipart/form-data",
+			configure: func(*fakeSpreadsheetService) {},
+		},
+		{
+			name: "invalid csv", body: body, contentType: contentType,
+			status: http.StatusBadRequest, message: domainerrors.ErrCSVIsInvalid.Error(),
+			configure: func(service *fakeSpreadsheetService) {
+				service.importCSV = func(context.Context, spreadsheet.Resource, io.Reader) (*spreadsheet.Report, error) {
+					return nil, domainerrors.ErrCSVIsInvalid
+				}
+			},
+		},
+		{
+			name: "service error", body: body, contentType: contentType,
+			status: http.StatusInternalServerError, message: "internal server error",
+			configure: func(service *fakeSpreadsheetService) {
+				service.importCSV = func(context.Context, spreadsheet.Resource, io.Reader) (*spreadsheet.Report, error) {
+					return nil, errors.New("could not import shots: connection refused")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newSpreadsheetTestHandler(t)
+			tt.configure(service)
+			req := newControllerRequest(t, http.MethodPost, "/rest/v1/import/shots.csv", tt.body, tt.contentType, "")
+
+			recorder := executeControllerHandler(handler, (*Handler).ImportShotsCSV, req)
+
+			assertJSONResponse(t, recorder, tt.status, ErrorResponse{Msg: tt.message})
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
+)
+
+// swagger:parameters getConsumption
+type ConsumptionParams struct {
+	// The first day, as YYYY-MM-DD. Defaults to a year before to.
+	// in: query
+	// format: date
+	From string `json:"from"`
+
+	// The last day, included, as YYYY-MM-DD. Defaults to today.
+	// in: query
+	// format: date
+	To string `json:"to"`
+
+	// The IANA time zone the days are in. Defaults to UTC.
+	// in: query
+	// example: Europe/Paris
+	TZ string `json:"tz"`
+}
+
+// ConsumptionDayResponse is what was pulled on one day
+type ConsumptionDayResponse struct {
+	// swagger:allOf
+	stats.ConsumptionDay
+	// The day
+	Date RoastDate `json:"date"`
+}
+
+// ConsumptionResponse represents the shots pulled each day over a range of days
+//
+// Only the days with shots are listed, in the requested time zone.
+//
+// swagger:response ConsumptionResponse
+type ConsumptionResponse struct {
+	// swagger:allOf
+	stats.Consumption
+	// The first day of the range
+	From RoastDate `json:"from"`
+	// The last day of the range, included
+	To RoastDate `json:"to"`
+	// The days with shots, oldest first
+	Days []ConsumptionDayResponse `json:"days"`
+}
+
+// swagger:route GET /rest/v1/stats/consumption stats getConsumption
+//
+// # Get the consumption statistics
+//
+// This will count the shots pulled, the coffee used and the average rating per day, over at most 366 days.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Responses:
+//	  200: ConsumptionResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+func (h *Handler) GetConsumption(w http.ResponseWriter, r *http.Request) {
+	from, err := parseQueryDate(r, "from")
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+	to, err := parseQueryDate(r, "to")
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	consumption, err := h.StatsService.GetConsumption(r.Context(), from, to, r.URL.Query().Get("tz"))
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	days := make([]ConsumptionDayResponse, len(consumption.Days))
+	for i, d := range consumption.Days {
+		days[i] = ConsumptionDayResponse{ConsumptionDay: d, Date: RoastDate(d.Date)}
+	}
+	h.writeJSONResponse(w, http.StatusOK, ConsumptionResponse{
+		Consumption: *consumption,
+		From:        RoastDate(consumption.From),
+		To:          RoastDate(consumption.To),
+		Days:        days,
+	})
+}
+
+// ShotStatsResponse represents the aggregates of the shots of beans, of a
+// roaster or of a sheet
+//
+// Every field but the number of shots is null without shots.
+//
+// swagger:response ShotStatsResponse
+type ShotStatsResponse struct {
+	// swagger:allOf
+	stats.ShotStats
+}
+
+// swagger:route GET /rest/v1/stats/beans/{id} stats getBeansStats
+//
+// # Get the statistics of beans
+//
+// This will aggregate the shots pulled with the beans with the given id.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the beans
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: ShotStatsResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetBeansStats(w http.ResponseWriter, r *http.Request) {
+	h.getShotStats(w, r, sql.ShotStatsOfBeans)
+}
+
+// swagger:route GET /rest/v1/stats/roasters/{id} stats getRoasterStats
+//
+// # Get the statistics of a roaster
+//
+// This will aggregate the shots pulled with the beans of the roaster with the given id.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the roaster
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: ShotStatsResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetRoasterStats(w http.ResponseWriter, r *http.Request) {
+	h.getShotStats(w, r, sql.ShotStatsOfRoaster)
+}
+
+// swagger:route GET /rest/v1/stats/sheets/{id} stats getSheetStats
+//
+// # Get the statistics of a sheet
+//
+// This will aggregate the shots of the sheet with the given id.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Deprecated: false
+//
+//	Security:
+//	  api_key:
+//	  oauth:
+//
+//	Parameters:
+//	  + name: id
+//	    in: path
+//	    description: id of the sheet
+//	    required: true
+//	    type: integer
+//	    format: int32
+//
+//	Responses:
+//	  200: ShotStatsResponse
+//	  400: ErrorResponse
+//	  401: ErrorResponse
+//	  404: ErrorResponse
+func (h *Handler) GetSheetStats(w http.ResponseWriter, r *http.Request) {
+	h.getShotStats(w, r, sql.ShotStatsOfSheet)
+}
+
+func (h *Handler) getShotStats(w http.ResponseWriter, r *http.Request, subject sql.ShotStatsSubject) {
+	id, err := h.getIdFromParams(r.Context())
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	shotStats, err := h.StatsService.GetShotStats(r.Context(), subject, id)
+	if err != nil {
+		h.SetErrorResponse(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, ShotStatsResponse{*shotStats})
+}
@@ -0,0 +1,160 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	modelsql "github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
+)
+
+func newStatsTestHandler(t *testing.T) (*Handler, *fakeStatsService) {
+	t.Helper()
+
+	handler, _, _, _, _ := newTestHandler(t)
+
+	return handler, handler.StatsService.(*fakeStatsService)
+}
+
+func TestGetConsumption(t *testing.T) {
+	handler, service := newStatsTestHandler(t)
+	from := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.September, 30, 0, 0, 0, 0, time.UTC)
+	rating := 7.5
+	consumption := &stats.Consumption{
+		From: from, To: to, TimeZone: "Europe/Paris",
+		Days:  []stats.ConsumptionDay{{Date: time.Date(2026, time.September, 2, 0, 0, 0, 0, time.UTC), Shots: 2, CoffeeWeight: 36, AverageRating: 7.5}},
+		Shots: 2, CoffeeWeight: 36, AverageRating: &rating,
+	}
+	service.getConsumption = func(_ context.Context, gotFrom, gotTo *time.Time, timeZone string) (*stats.Consumption, error) {
+		if !gotFrom.Equal(from) || !gotTo.Equal(to) || timeZone != "Europe/Paris" {
+			t.Errorf("GetConsumption(%v, %v, %q), want the parsed query", gotFrom, gotTo, timeZone)
+		}
+		return consumption, nil
+	}
+
+	req := newControllerRequest(t, http.MethodGet, "/rest/v1/stats/consumption?from=2026-09-01&to=2026-09-30&tz=Europe/Paris", "", "", "")
+	recorder := executeHandler(handler.GetConsumption, req)
+
+	assertJSONResponse(t, recorder, http.StatusOK, map[string]any{
+		"from": "2026-09-01", "to": "2026-09-30", "time_zone": "Europe/Paris",
+		"days":  []any{map[string]any{"date": "2026-09-02", "shots": 2.0, "coffee_weight": 36.0, "average_rating": 7.5}},
+		"shots": 2.0, "coffee_weight": 36.0, "average_rating": 7.5,
+	})
+}
+
+func TestGetConsumptionErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    string
+		message   string
+		configure func(*fakeStatsService)
+	}{
+		{
+			name:      "invalid to date",
+			target:    "/rest/v1/stats/consumption?to=tomorrow",
+			message:   `invalid time format: parsing time "tomorrow" as "2006-01-02": cannot parse "tomorrow" as "2006"`,
+			configure: func(*fakeStatsService) {},
+		},
+		{
+			name:    "invalid time zone",
+			target:  "/rest/v1/stats/consumption?tz=Mars/Olympus_Mons",
+			message: "stats time zone is invalid. Must be an IANA time zone name, such as Europe/Paris",
+			configure: func(service *fakeStatsService) {
+				service.getConsumption = func(context.Context, *time.Time, *time.Time, string) (*stats.Consumption, error) {
+					return nil, domainerrors.ErrStatsTimeZoneIsInvalid
+				}
+			},
+		},
+		{
+			name:    "range too long",
+			target:  "/rest/v1/stats/consumption?from=2024-01-01&to=2026-01-01",
+			message: "stats range is too long. Must not exceed 366 days",
+			configure: func(service *fakeStatsService) {
+				service.getConsumption = func(context.Context, *time.Time, *time.Time, string) (*stats.Consumption, error) {
+					return nil, domainerrors.ErrStatsRangeIsTooLong
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newStatsTestHandler(t)
+			tt.configure(service)
+
+			req := newControllerRequest(t, http.MethodGet, tt.target, "", "", "")
+			recorder := executeHandler(handler.GetConsumption, req)
+
+			assertJSONResponse(t, recorder, http.StatusBadRequest, ErrorResponse{Msg: tt.message})
+		})
+	}
+}
+
+func TestGetShotStats(t *testing.T) {
+	rating, ratio, shotTime, share := 8.5, 2.05, 28.4, 0.25
+	bestShotId, minGrind, maxGrind := 12, 10, 14
+
+	tests := []struct {
+		name    string
+		handler func(*Handler) http.HandlerFunc
+		subject modelsql.ShotStatsSubject
+	}{
+		{name: "beans", handler: func(h *Handler) http.HandlerFunc { return h.GetBeansStats }, subject: modelsql.ShotStatsOfBeans},
+		{name: "roaster", handler: func(h *Handler) http.HandlerFunc { return h.GetRoasterStats }, subject: modelsql.ShotStatsOfRoaster},
+		{name: "sheet", handler: func(h *Handler) http.HandlerFunc { return h.GetSheetStats }, subject: modelsql.ShotStatsOfSheet},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, service := newStatsTestHandler(t)
+			service.getShotStats = func(_ context.Context, subject modelsql.ShotStatsSubject, id int) (*stats.ShotStats, error) {
+				if subject != tt.subject || id != 4 {
+					t.Errorf("GetShotStats(%s, %d), want %s 4", subject, id, tt.subject)
+				}
+				return &stats.ShotStats{
+					Shots: 4, AverageRating: &rating, MedianRating: &rating, MaxRating: &rating, BestShotId: &bestShotId,
+					MinGrindSetting: &minGrind, MaxGrindSetting: &maxGrind, AverageRatio: &ratio, AverageShotTime: &shotTime,
+					BitterShare: &share, SourShare: &share,
+				}, nil
+			}
+
+			req := newControllerRequest(t, http.MethodGet, "/rest/v1/stats/"+string(tt.subject)+"/4", "", "", "4")
+			recorder := executeHandler(tt.handler(handler), req)
+
+			assertJSONResponse(t, recorder, http.StatusOK, map[string]any{
+				"shots": 4.0, "average_rating": 8.5, "median_rating": 8.5, "max_rating": 8.5, "best_shot_id": 12.0,
+				"min_grind_setting": 10.0, "max_grind_setting": 14.0, "average_ratio": 2.05, "average_shot_time": 28.4,
+				"bitter_share": 0.25, "sour_share": 0.25,
+			})
+		})
+	}
+}
+
+func TestGetShotStatsErrors(t *testing.T) {
+	t.Run("invalid id", func(t *testing.T) {
+		handler, _ := newStatsTestHandler(t)
+
+		req := newControllerRequest(t, http.MethodGet, "/rest/v1/stats/beans/abc", "", "", "abc")
+		recorder := executeHandler(handler.GetBeansStats, req)
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("GetBeansStats() status = %d, want %d", recorder.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("beans not found", func(t *testing.T) {
+		handler, service := newStatsTestHandler(t)
+		service.getShotStats = func(context.Context, modelsql.ShotStatsSubject, int) (*stats.ShotStats, error) {
+			return nil, domainerrors.ErrBeansDoesNotExist
+		}
+
+		req := newControllerRequest(t, http.MethodGet, "/rest/v1/stats/beans/4", "", "", "4")
+		recorder := executeHandler(handler.GetBeansStats, req)
+
+		assertJSONResponse(t, recorder, http.StatusNotFound, ErrorResponse{Msg: "no beans found for given id"})
+	})
+}
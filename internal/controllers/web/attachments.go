@@ -0,0 +1,144 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/services/attachment"
+	viewattachments "github.com/lescactus/espressoapi-go/views/templates/attachments"
+	"github.com/lescactus/espressoapi-go/views/templates/shared"
+)
+
+const (
+	errInvalidAttachmentID = "The photo id must be a positive number."
+	errMissingUpload       = "Choose a photo to upload."
+)
+
+// UploadShotAttachment handles POST /shots/attachments/:id: it attaches the
+// uploaded photo to the shot and re-renders its gallery.
+func (h *Handler) UploadShotAttachment(w http.ResponseWriter, r *http.Request) {
+	h.uploadAttachment(w, r, errInvalidShotID, viewattachments.ShotUploadPath,
+		h.AttachmentService.CreateShotAttachment, h.AttachmentService.GetAttachmentsByShotId)
+}
+
+// UploadBeansAttachment handles POST /beans/attachments/:id: it attaches the
+// uploaded photo to the beans and re-renders their gallery.
+func (h *Handler) UploadBeansAttachment(w http.ResponseWriter, r *http.Request) {
+	h.uploadAttachment(w, r, errInvalidBeanID, viewattachments.BeansUploadPath,
+		h.AttachmentService.CreateBeansAttachment, h.AttachmentService.GetAttachmentsByBeansId)
+}
+
+func (h *Handler) uploadAttachment(
+	w http.ResponseWriter,
+	r *http.Request,
+	errInvalidID string,
+	uploadPath func(int) string,
+	create func(ctx context.Context, id int, filename string, data []byte) (*attachment.Attachment, error),
+	list func(ctx context.Context, id int) ([]attachment.Attachment, error),
+) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidID})
+		return
+	}
+
+	// The body is bounded by the maximum request size, so parsing the form
+	// cannot buffer more than that.
+	file, header, err := r.FormFile("file")
+	if errors.Is(err, http.ErrMissingFile) {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errMissingUpload})
+		return
+	}
+	if err != nil {
+		status, msg := parseFormError(err)
+		h.writeGetError(w, r, webError{Status: status, Message: msg})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		status, msg := parseFormError(err)
+		h.writeGetError(w, r, webError{Status: status, Message: msg})
+		return
+	}
+
+	if _, err := create(r.Context(), id, header.Filename, data); err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+
+	photos, err := list(r.Context(), id)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewattachments.Gallery(uploadPath(id), photos).Render(r.Context(), w)
+	_ = shared.SuccessAlertOOB("Photo successfully uploaded.").Render(r.Context(), w)
+}
+
+// AttachmentContent handles GET /attachments/content/:id: the uploaded photo.
+func (h *Handler) AttachmentContent(w http.ResponseWriter, r *http.Request) {
+	h.attachmentContent(w, r, false)
+}
+
+// AttachmentThumbnail handles GET /attachments/thumbnail/:id: the JPEG
+// thumbnail of the photo shown in galleries.
+func (h *Handler) AttachmentThumbnail(w http.ResponseWriter, r *http.Request) {
+	h.attachmentContent(w, r, true)
+}
+
+func (h *Handler) attachmentContent(w http.ResponseWriter, r *http.Request, thumbnail bool) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidAttachmentID})
+		return
+	}
+
+	content, err := h.AttachmentService.GetAttachmentContent(r.Context(), id, thumbnail)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+	defer content.Close()
+
+	// Uploads are only served as the image type sniffed from their content,
+	// never rendered as HTML.
+	w.Header().Set("Content-Type", content.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": content.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=86400, immutable")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, content)
+}
+
+// DeleteAttachment handles DELETE /attachments/delete/:id.
+func (h *Handler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidAttachmentID})
+		return
+	}
+
+	if err := h.AttachmentService.DeleteAttachmentById(r.Context(), id); err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = shared.SuccessAlertOOB("Photo successfully deleted.").Render(r.Context(), w)
+}
+
+// attachmentsOf returns the photos listed by list for the record id, or none
+// for the roles which may not read attachments.
+func attachmentsOf(r *http.Request, id int, list func(ctx context.Context, id int) ([]attachment.Attachment, error)) ([]attachment.Attachment, error) {
+	if !auth.Allowed(r.Context(), auth.ResourceAttachments, auth.ActionRead) {
+		return nil, nil
+	}
+	return list(r.Context(), id)
+}
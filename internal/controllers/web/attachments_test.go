@@ -0,0 +1,172 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/attachment"
+)
+
+// fakeAttachmentService overrides the unusedAttachmentService methods
+// exercised by the photo routes.
+type fakeAttachmentService struct {
+	unusedAttachmentService
+	t                      *testing.T
+	createShotAttachment   func(context.Context, int, string, []byte) (*attachment.Attachment, error)
+	getAttachmentsByShotID func(context.Context, int) ([]attachment.Attachment, error)
+	getAttachmentContent   func(context.Context, int, bool) (*attachment.Content, error)
+	deleteAttachmentByID   func(context.Context, int) error
+}
+
+func (f *fakeAttachmentService) CreateShotAttachment(ctx context.Context, shotId int, filename string, data []byte) (*attachment.Attachment, error) {
+	if f.createShotAttachment == nil {
+		f.t.Fatalf("unexpected CreateShotAttachment call")
+	}
+	return f.createShotAttachment(ctx, shotId, filename, data)
+}
+
+func (f *fakeAttachmentService) GetAttachmentsByShotId(ctx context.Context, shotId int) ([]attachment.Attachment, error) {
+	if f.getAttachmentsByShotID == nil {
+		f.t.Fatalf("unexpected GetAttachmentsByShotId call")
+	}
+	return f.getAttachmentsByShotID(ctx, shotId)
+}
+
+func (f *fakeAttachmentService) GetAttachmentContent(ctx context.Context, id int, thumbnail bool) (*attachment.Content, error) {
+	if f.getAttachmentContent == nil {
+		f.t.Fatalf("unexpected GetAttachmentContent call")
+	}
+	return f.getAttachmentContent(ctx, id, thumbnail)
+}
+
+func (f *fakeAttachmentService) DeleteAttachmentById(ctx context.Context, id int) error {
+	if f.deleteAttachmentByID == nil {
+		f.t.Fatalf("unexpected DeleteAttachmentById call")
+	}
+	return f.deleteAttachmentByID(ctx, id)
+}
+
+func newTestAttachmentHandler(t *testing.T) (*Handler, *fakeAttachmentService) {
+	t.Helper()
+	svc := &fakeAttachmentService{t: t}
+	h := NewHandler(unusedSheetService{}, unusedRoasterService{}, unusedBeanService{}, unusedShotService{}, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, svc, unusedSpreadsheetService{}, unusedSessionService{}, nil)
+	return h, svc
+}
+
+// uploadForm returns a multipart form with data as the file of field, and
+// its Content-Type.
+func uploadForm(t *testing.T, field string, data []byte) (string, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	fw, err := mw.CreateFormFile(field, "crema.png")
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	fw.Write(data)
+	mw.Close()
+	return buf.String(), mw.FormDataContentType()
+}
+
+func TestUploadShotAttachment_RendersGallery(t *testing.T) {
+	h, svc := newTestAttachmentHandler(t)
+	svc.createShotAttachment = func(_ context.Context, shotId int, filename string, data []byte) (*attachment.Attachment, error) {
+		if shotId != 5 || filename != "crema.png" || string(data) != "picture" {
+			t.Errorf("CreateShotAttachment(%d, %q, %q), want shot 5 and the uploaded file", shotId, filename, data)
+		}
+		return &attachment.Attachment{Id: 4}, nil
+	}
+	svc.getAttachmentsByShotID = func(context.Context, int) ([]attachment.Attachment, error) {
+		return []attachment.Attachment{{Id: 4, Filename: "crema.png"}}, nil
+	}
+	body, contentType := uploadForm(t, "file", []byte("picture"))
+
+	rec := httptest.NewRecorder()
+	h.UploadShotAttachment(rec, newWebRequest(http.MethodPost, "/shots/attachments/5", body, contentType, "5", true))
+
+	got := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(got, `src="/attachments/thumbnail/4"`) {
+		t.Fatalf("expected the gallery with the new photo, got %d: %s", rec.Code, got)
+	}
+	if !strings.Contains(got, "Photo successfully uploaded.") {
+		t.Errorf("expected a success alert, got: %s", got)
+	}
+}
+
+func TestUploadShotAttachment_Errors(t *testing.T) {
+	withFile, withFileType := uploadForm(t, "file", []byte("not a picture"))
+	withoutFile, withoutFileType := uploadForm(t, "photo", []byte("picture"))
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		createErr   error
+		status      int
+		message     string
+	}{
+		{name: "missing file", body: withoutFile, contentType: withoutFileType, status: http.StatusBadRequest, message: "Choose a photo to upload."},
+		{name: "unsupported type", body: withFile, contentType: withFileType, createErr: errors.ErrAttachmentTypeIsUnsupported, status: http.StatusUnsupportedMediaType, message: "The photo must be a JPEG, PNG or GIF image."},
+		{name: "missing shot", body: withFile, contentType: withFileType, createErr: errors.ErrShotDoesNotExist, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, svc := newTestAttachmentHandler(t)
+			if tt.createErr != nil {
+				svc.createShotAttachment = func(context.Context, int, string, []byte) (*attachment.Attachment, error) {
+					return nil, tt.createErr
+				}
+			}
+
+			rec := httptest.NewRecorder()
+			h.UploadShotAttachment(rec, newWebRequest(http.MethodPost, "/shots/attachments/5", tt.body, tt.contentType, "5", true))
+
+			if rec.Code != tt.status || rec.Header().Get("HX-Reswap") != "none" || !strings.Contains(rec.Body.String(), tt.message) {
+				t.Errorf("expected a %d alert with %q, got %d: %s", tt.status, tt.message, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAttachmentThumbnail_ServesImage(t *testing.T) {
+	h, svc := newTestAttachmentHandler(t)
+	svc.getAttachmentContent = func(_ context.Context, id int, thumbnail bool) (*attachment.Content, error) {
+		if id != 4 || !thumbnail {
+			t.Errorf("GetAttachmentContent(%d, %t), want the thumbnail of attachment 4", id, thumbnail)
+		}
+		return &attachment.Content{ReadCloser: io.NopCloser(strings.NewReader("jpeg")), ContentType: "image/jpeg", Filename: "crema-thumbnail.jpg"}, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.AttachmentThumbnail(rec, newWebRequest(http.MethodGet, "/attachments/thumbnail/4", "", "", "4", false))
+
+	if rec.Code != http.StatusOK || rec.Body.String() != "jpeg" {
+		t.Fatalf("expected the thumbnail, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("Content-Type") != "image/jpeg" || rec.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Errorf("expected an image/jpeg nosniff response, got headers %v", rec.Header())
+	}
+}
+
+func TestDeleteAttachment_RemovesPhoto(t *testing.T) {
+	h, svc := newTestAttachmentHandler(t)
+	deleted := 0
+	svc.deleteAttachmentByID = func(_ context.Context, id int) error {
+		deleted = id
+		return nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.DeleteAttachment(rec, newWebRequest(http.MethodDelete, "/attachments/delete/4", "", "", "4", true))
+
+	if deleted != 4 || rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Photo successfully deleted.") {
+		t.Errorf("expected photo 4 to be deleted, got %d (deleted %d): %s", rec.Code, deleted, rec.Body.String())
+	}
+}
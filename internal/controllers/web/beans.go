@@ -1,6 +1,7 @@
 package web
 
 import (
+	"math"
 	"net/http"
 	"sort"
 	"strconv"
@@ -93,7 +94,12 @@ func (h *Handler) AddBeanForm(w http.ResponseWriter, r *http.Request) {
 		h.writeGetError(w, r, mapDomainError(err))
 		return
 	}
-	form := viewbeans.Form(viewbeans.FormState{}, roasters, true, "", "")
+	greenCoffees, err := h.greenCoffeeOptions(r)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+	form := viewbeans.Form(viewbeans.FormState{}, roasters, greenCoffees, true, "", "")
 
 	if !isHXRequest(r) {
 		beans, err := h.beansListForPage(r)
@@ -114,12 +120,17 @@ func (h *Handler) AddBeanForm(w http.ResponseWriter, r *http.Request) {
 // FormState (for redisplay) and, on success, the parsed service model.
 func parseBeanForm(r *http.Request, id int) (viewbeans.FormState, *bean.Bean, bool) {
 	state := viewbeans.FormState{
-		ID:         id,
-		Name:       strings.TrimSpace(r.PostFormValue("name")),
-		RoasterID:  strings.TrimSpace(r.PostFormValue("roaster_id")),
-		RoastDate:  strings.TrimSpace(r.PostFormValue("roast_date")),
-		RoastLevel: strings.TrimSpace(r.PostFormValue("roast_level")),
-		Errors:     map[string]string{},
+		ID:            id,
+		Name:          strings.TrimSpace(r.PostFormValue("name")),
+		RoasterID:     strings.TrimSpace(r.PostFormValue("roaster_id")),
+		RoastDate:     strings.TrimSpace(r.PostFormValue("roast_date")),
+		RoastLevel:    strings.TrimSpace(r.PostFormValue("roast_level")),
+		GreenCoffeeID: strings.TrimSpace(r.PostFormValue("green_coffee_id")),
+		GreenWeight:   strings.TrimSpace(r.PostFormValue("green_weight")),
+		Price:         strings.TrimSpace(r.PostFormValue("price")),
+		Currency:      strings.ToUpper(strings.TrimSpace(r.PostFormValue("currency"))),
+		BagWeight:     strings.TrimSpace(r.PostFormValue("bag_weight")),
+		Errors:        map[string]string{},
 	}
 
 	if state.Name == "" {
@@ -150,16 +161,56 @@ func parseBeanForm(r *http.Request, id int) (viewbeans.FormState, *bean.Bean, bo
 		roastLevel = n
 	}
 
+	greenCoffeeID, ok := parseOptionalGreenCoffeeID(state.GreenCoffeeID)
+	if !ok {
+		state.Errors["green_coffee_id"] = "Invalid green coffee."
+	}
+
+	var greenWeight float64
+	if state.GreenWeight != "" {
+		parsed, err := strconv.ParseFloat(state.GreenWeight, 64)
+		if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) || parsed < 0 {
+			state.Errors["green_weight"] = "Green weight must be a non-negative number."
+		} else {
+			greenWeight = parsed
+		}
+	}
+
+	var price float64
+	if state.Price != "" {
+		parsed, err := strconv.ParseFloat(state.Price, 64)
+		if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) || parsed < 0 {
+			state.Errors["price"] = "Price must be a non-negative number."
+		} else {
+			price = parsed
+		}
+	}
+
+	var bagWeight float64
+	if state.BagWeight != "" {
+		parsed, err := strconv.ParseFloat(state.BagWeight, 64)
+		if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) || parsed < 0 {
+			state.Errors["bag_weight"] = "Bag weight must be a non-negative number."
+		} else {
+			bagWeight = parsed
+		}
+	}
+
 	if len(state.Errors) > 0 {
 		return state, nil, false
 	}
 
 	return state, &bean.Bean{
-		Id:         id,
-		Name:       state.Name,
-		Roaster:    &roaster.Roaster{Id: roasterID},
-		RoastDate:  roastDate,
-		RoastLevel: sql.RoastLevel(roastLevel),
+		Id:            id,
+		Name:          state.Name,
+		Roaster:       &roaster.Roaster{Id: roasterID},
+		RoastDate:     roastDate,
+		RoastLevel:    sql.RoastLevel(roastLevel),
+		GreenCoffeeId: greenCoffeeID,
+		GreenWeight:   greenWeight,
+		Price:         price,
+		Currency:      state.Currency,
+		BagWeight:     bagWeight,
 	}, true
 }
 
@@ -215,11 +266,22 @@ func (h *Handler) GetBean(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeHTMLStatus(w, http.StatusOK)
 	if !isHXRequest(r) {
-		_ = viewbeans.RowPage(*b).Render(r.Context(), w)
+		photos, err := attachmentsOf(r, id, h.AttachmentService.GetAttachmentsByBeansId)
+		if err != nil {
+			h.writeGetError(w, r, mapDomainError(err))
+			return
+		}
+		shotStats, err := h.StatsService.GetShotStats(r.Context(), sql.ShotStatsOfBeans, id)
+		if err != nil {
+			h.writeGetError(w, r, mapDomainError(err))
+			return
+		}
+		writeHTMLStatus(w, http.StatusOK)
+		_ = viewbeans.RowPage(*b, photos, *shotStats).Render(r.Context(), w)
 		return
 	}
+	writeHTMLStatus(w, http.StatusOK)
 	_ = viewbeans.Row(*b, "").Render(r.Context(), w)
 }
 
@@ -241,15 +303,33 @@ func (h *Handler) EditBeanForm(w http.ResponseWriter, r *http.Request) {
 		h.writeGetError(w, r, mapDomainError(err))
 		return
 	}
+	greenCoffees, err := h.greenCoffeeOptions(r)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
 
 	state := viewbeans.FormState{ID: b.Id, Name: b.Name, RoastLevel: strconv.Itoa(int(b.RoastLevel))}
 	if b.Roaster != nil {
 		state.RoasterID = strconv.Itoa(b.Roaster.Id)
 	}
+	if b.GreenCoffeeId != nil {
+		state.GreenCoffeeID = strconv.Itoa(*b.GreenCoffeeId)
+	}
+	if b.GreenWeight != 0 {
+		state.GreenWeight = strconv.FormatFloat(b.GreenWeight, 'f', -1, 64)
+	}
+	if b.Price != 0 {
+		state.Price = strconv.FormatFloat(b.Price, 'f', -1, 64)
+	}
+	state.Currency = b.Currency
+	if b.BagWeight != 0 {
+		state.BagWeight = strconv.FormatFloat(b.BagWeight, 'f', -1, 64)
+	}
 	if b.RoastDate != nil {
 		state.RoastDate = b.RoastDate.UTC().Format("2006-01-02")
 	}
-	form := viewbeans.Form(state, roasters, false, shared.FormatTimestamp(b.CreatedAt), shared.FormatTimestamp(b.UpdatedAt))
+	form := viewbeans.Form(state, roasters, greenCoffees, false, shared.FormatTimestamp(b.CreatedAt), shared.FormatTimestamp(b.UpdatedAt))
 
 	if !isHXRequest(r) {
 		beans, err := h.beansListForPage(r)
@@ -317,8 +397,12 @@ func (h *Handler) renderBeanFormError(w http.ResponseWriter, r *http.Request, st
 	if err != nil {
 		roasters = nil
 	}
+	greenCoffees, err := h.greenCoffeeOptions(r)
+	if err != nil {
+		greenCoffees = nil
+	}
 	writeHTMLStatus(w, status)
-	_ = viewbeans.Form(state, roasters, isAdd, "", "").Render(r.Context(), w)
+	_ = viewbeans.Form(state, roasters, greenCoffees, isAdd, "", "").Render(r.Context(), w)
 }
 
 // DeleteBean handles DELETE /beans/delete/:id.
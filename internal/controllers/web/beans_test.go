@@ -10,8 +10,10 @@ import (
 	"time"
 
 	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
 	"github.com/lescactus/espressoapi-go/internal/services/bean"
 	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
 )
 
 // fakeBeanService is a hand-rolled fake with func fields, matching the
@@ -78,7 +80,7 @@ func (f fakeRoasterServiceForBeans) GetAllRoasters(context.Context) ([]roaster.R
 func newTestBeanHandler(t *testing.T, roasters []roaster.Roaster) (*Handler, *fakeBeanService) {
 	t.Helper()
 	svc := &fakeBeanService{t: t}
-	h := NewHandler(unusedSheetService{}, fakeRoasterServiceForBeans{roasters: roasters}, svc, unusedShotService{})
+	h := NewHandler(unusedSheetService{}, fakeRoasterServiceForBeans{roasters: roasters}, svc, unusedShotService{}, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil)
 	return h, svc
 }
 
@@ -263,6 +265,42 @@ func TestCreateBean_RoastLevelOutOfRangeDomainErrorMapsToRoastLevelField(t *test
 	}
 }
 
+func TestCreateBean_ParsesCostFields(t *testing.T) {
+	h, svc := newTestBeanHandler(t, []roaster.Roaster{{Id: 1, Name: "Roaster"}})
+	svc.createBean = func(_ context.Context, b *bean.Bean) (*bean.Bean, error) {
+		if b.Price != 15.5 || b.Currency != "EUR" || b.BagWeight != 250 {
+			t.Errorf("expected price 15.5 EUR for 250 g, got %v %q for %v g", b.Price, b.Currency, b.BagWeight)
+		}
+		return testBean(5, b.Name), nil
+	}
+
+	req := newWebRequest(http.MethodPost, "/beans/add", "name=Ethiopia&roaster_id=1&roast_level=2&price=15.5&currency=eur&bag_weight=250", formURLEncoded, "", true)
+	rec := httptest.NewRecorder()
+	h.CreateBean(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCreateBean_CurrencyDomainErrorMapsToCurrencyField(t *testing.T) {
+	h, svc := newTestBeanHandler(t, []roaster.Roaster{{Id: 1, Name: "Roaster"}})
+	svc.createBean = func(context.Context, *bean.Bean) (*bean.Bean, error) {
+		return nil, errors.ErrBeansCurrencyIsInvalid
+	}
+
+	req := newWebRequest(http.MethodPost, "/beans/add", "name=Ethiopia&roaster_id=1&roast_level=2&price=15&currency=EURO", formURLEncoded, "", true)
+	rec := httptest.NewRecorder()
+	h.CreateBean(rec, req)
+
+	body := rec.Body.String()
+	currencyIdx := strings.Index(body, `name="currency"`)
+	msgIdx := strings.Index(body, "Currency must be a three-letter code, such as EUR.")
+	if rec.Code != http.StatusBadRequest || currencyIdx < 0 || msgIdx < 0 || !(currencyIdx < msgIdx) {
+		t.Errorf("expected a 400 with the currency error under the currency field, got %d: %s", rec.Code, body)
+	}
+}
+
 func TestCreateBean_DuplicateReturns409(t *testing.T) {
 	h, svc := newTestBeanHandler(t, []roaster.Roaster{{Id: 1, Name: "Roaster"}})
 	svc.createBean = func(context.Context, *bean.Bean) (*bean.Bean, error) { return nil, errors.ErrBeansAlreadyExists }
@@ -391,6 +429,26 @@ func TestGetBean_FullPageFallbackForDirectNavigation(t *testing.T) {
 	}
 }
 
+func TestGetBean_FullPageShowsShotStats(t *testing.T) {
+	h, svc := newTestBeanHandler(t, nil)
+	svc.getBeanByID = func(context.Context, int) (*bean.Bean, error) { return testBean(9, "Ethiopia"), nil }
+	rating := 8.5
+	h.StatsService = &fakeStatsService{t: t, getShotStats: func(_ context.Context, subject sql.ShotStatsSubject, id int) (*stats.ShotStats, error) {
+		if subject != sql.ShotStatsOfBeans || id != 9 {
+			t.Errorf("GetShotStats(%q, %d), want the stats of beans 9", subject, id)
+		}
+		return &stats.ShotStats{Shots: 3, AverageRating: &rating}, nil
+	}}
+
+	rec := httptest.NewRecorder()
+	h.GetBean(rec, newWebRequest(http.MethodGet, "/beans/get/9", "", "", "9", false))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, `id="shot-stats"`) || !strings.Contains(body, "8.5 / ") {
+		t.Fatalf("expected the shot stats on the page, got %d: %s", rec.Code, body)
+	}
+}
+
 func TestEditBeanForm_PrefillsExistingValues(t *testing.T) {
 	h, svc := newTestBeanHandler(t, []roaster.Roaster{{Id: 1, Name: "Roaster"}})
 	svc.getBeanByID = func(context.Context, int) (*bean.Bean, error) { return testBean(9, "Ethiopia"), nil }
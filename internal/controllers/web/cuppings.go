@@ -0,0 +1,560 @@
+package web
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/cupping"
+	viewcuppings "github.com/lescactus/espressoapi-go/views/templates/cuppings"
+	"github.com/lescactus/espressoapi-go/views/templates/shared"
+)
+
+const (
+	errInvalidCuppingID      = "The cupping id must be a positive number."
+	errInvalidCuppingScoreID = "The cupping score id must be a positive number."
+)
+
+// sortCuppingSessions sorts sessions most recent first, the natural reading
+// order of a tasting log.
+func sortCuppingSessions(sessions []cupping.CuppingSession) {
+	sort.SliceStable(sessions, func(i, j int) bool {
+		a, b := sessions[i], sessions[j]
+		if timeLess(b.SessionDate, a.SessionDate) {
+			return true
+		}
+		if timeLess(a.SessionDate, b.SessionDate) {
+			return false
+		}
+		return a.Id > b.Id
+	})
+}
+
+// ListCuppings handles GET /cuppings.
+func (h *Handler) ListCuppings(w http.ResponseWriter, r *http.Request) {
+	sessions, err := h.CuppingService.GetAllCuppingSessions(r.Context())
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+	sortCuppingSessions(sessions)
+
+	writeHTMLStatus(w, http.StatusOK)
+	if isHXRequest(r) {
+		_ = viewcuppings.Table(sessions).Render(r.Context(), w)
+		return
+	}
+	_ = viewcuppings.Page(sessions, nil).Render(r.Context(), w)
+}
+
+// renderCuppingsPage renders the full sessions list page with form
+// pre-opened in the dialog, for the full-page fallback of a direct GET to
+// an add/edit dialog route.
+func (h *Handler) renderCuppingsPage(w http.ResponseWriter, r *http.Request, form templ.Component) {
+	sessions, err := h.CuppingService.GetAllCuppingSessions(r.Context())
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+	sortCuppingSessions(sessions)
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewcuppings.Page(sessions, form).Render(r.Context(), w)
+}
+
+// AddCuppingForm handles GET /cuppings/add.
+func (h *Handler) AddCuppingForm(w http.ResponseWriter, r *http.Request) {
+	form := viewcuppings.Form(viewcuppings.SessionFormState{}, true, "", "")
+	if !isHXRequest(r) {
+		h.renderCuppingsPage(w, r, form)
+		return
+	}
+	writeHTMLStatus(w, http.StatusOK)
+	_ = form.Render(r.Context(), w)
+}
+
+// parseCuppingSessionForm extracts and validates cupping session form
+// fields, returning the raw SessionFormState (for redisplay) and, on
+// success, the parsed service model.
+func parseCuppingSessionForm(r *http.Request, id int) (viewcuppings.SessionFormState, *cupping.CuppingSession, bool) {
+	state := viewcuppings.SessionFormState{
+		ID:           id,
+		SessionDate:  strings.TrimSpace(r.PostFormValue("session_date")),
+		Participants: strings.TrimSpace(r.PostFormValue("participants")),
+		Errors:       map[string]string{},
+	}
+
+	var sessionDate time.Time
+	if state.SessionDate == "" {
+		state.Errors["session_date"] = "Cupping date must not be empty."
+	} else if parsed, err := time.Parse("2006-01-02", state.SessionDate); err != nil {
+		state.Errors["session_date"] = "Cupping date must be a valid date."
+	} else {
+		sessionDate = parsed
+	}
+
+	if len(state.Participants) > 511 {
+		state.Errors["participants"] = "Participants must be 511 characters or fewer."
+	}
+
+	if len(state.Errors) > 0 {
+		return state, nil, false
+	}
+
+	return state, &cupping.CuppingSession{
+		Id:           id,
+		SessionDate:  &sessionDate,
+		Participants: cupping.NormalizeParticipants([]string{state.Participants}),
+	}, true
+}
+
+// CreateCupping handles POST /cuppings/add.
+func (h *Handler) CreateCupping(w http.ResponseWriter, r *http.Request) {
+	if !isFormURLEncoded(r) {
+		h.renderCuppingFormError(w, r, viewcuppings.SessionFormState{}, true, http.StatusUnsupportedMediaType)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		status, message := parseFormError(err)
+		h.renderCuppingFormError(w, r, viewcuppings.SessionFormState{FormError: message}, true, status)
+		return
+	}
+
+	state, model, ok := parseCuppingSessionForm(r, 0)
+	if !ok {
+		h.renderCuppingFormError(w, r, state, true, http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.CuppingService.CreateCuppingSession(r.Context(), model)
+	if err != nil {
+		we := mapDomainError(err)
+		state.FormError = we.Message
+		h.renderCuppingFormError(w, r, state, true, we.Status)
+		return
+	}
+
+	w.Header().Set("HX-Reswap", "none")
+	w.Header().Set("HX-Trigger", "dialog-close")
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewcuppings.Row(*created, "insert").Render(r.Context(), w)
+	_ = shared.SuccessAlertOOB("Cupping successfully created.").Render(r.Context(), w)
+}
+
+// GetCupping handles GET /cuppings/get/:id: the session detail page with its
+// score sheet for direct navigation, or the list row for htmx.
+func (h *Handler) GetCupping(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidCuppingID})
+		return
+	}
+	s, err := h.CuppingService.GetCuppingSessionById(r.Context(), id)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	if !isHXRequest(r) {
+		_ = viewcuppings.Detail(*s, nil).Render(r.Context(), w)
+		return
+	}
+	_ = viewcuppings.Row(*s, "").Render(r.Context(), w)
+}
+
+// EditCuppingForm handles GET /cuppings/update/:id.
+func (h *Handler) EditCuppingForm(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidCuppingID})
+		return
+	}
+	s, err := h.CuppingService.GetCuppingSessionById(r.Context(), id)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+
+	state := viewcuppings.SessionFormState{ID: s.Id, Participants: strings.Join(s.Participants, ", ")}
+	if s.SessionDate != nil {
+		state.SessionDate = s.SessionDate.UTC().Format("2006-01-02")
+	}
+	form := viewcuppings.Form(state, false, shared.FormatTimestamp(s.CreatedAt), shared.FormatTimestamp(s.UpdatedAt))
+
+	if !isHXRequest(r) {
+		h.renderCuppingsPage(w, r, form)
+		return
+	}
+	writeHTMLStatus(w, http.StatusOK)
+	_ = form.Render(r.Context(), w)
+}
+
+// UpdateCupping handles PUT /cuppings/update/:id.
+func (h *Handler) UpdateCupping(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		writeHTMLStatus(w, http.StatusBadRequest)
+		w.Header().Set("HX-Reswap", "none")
+		_ = shared.ErrorAlertOOB(errInvalidCuppingID).Render(r.Context(), w)
+		return
+	}
+
+	if !isFormURLEncoded(r) {
+		h.renderCuppingFormError(w, r, viewcuppings.SessionFormState{ID: id}, false, http.StatusUnsupportedMediaType)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		status, message := parseFormError(err)
+		h.renderCuppingFormError(w, r, viewcuppings.SessionFormState{ID: id, FormError: message}, false, status)
+		return
+	}
+
+	state, model, ok := parseCuppingSessionForm(r, id)
+	if !ok {
+		h.renderCuppingFormError(w, r, state, false, http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.CuppingService.UpdateCuppingSessionById(r.Context(), id, model)
+	if err != nil {
+		we := mapDomainError(err)
+		state.FormError = we.Message
+		h.renderCuppingFormError(w, r, state, false, we.Status)
+		return
+	}
+
+	w.Header().Set("HX-Reswap", "none")
+	w.Header().Set("HX-Trigger", "dialog-close")
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewcuppings.Row(*updated, "replace").Render(r.Context(), w)
+	_ = shared.SuccessAlertOOB("Cupping successfully updated.").Render(r.Context(), w)
+}
+
+func (h *Handler) renderCuppingFormError(w http.ResponseWriter, r *http.Request, state viewcuppings.SessionFormState, isAdd bool, status int) {
+	writeHTMLStatus(w, status)
+	_ = viewcuppings.Form(state, isAdd, "", "").Render(r.Context(), w)
+}
+
+// DeleteCupping handles DELETE /cuppings/delete/:id. The session's scores
+// are deleted with it.
+func (h *Handler) DeleteCupping(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		w.Header().Set("HX-Reswap", "none")
+		writeHTMLStatus(w, http.StatusBadRequest)
+		_ = shared.ErrorAlertOOB(errInvalidCuppingID).Render(r.Context(), w)
+		return
+	}
+
+	if err := h.CuppingService.DeleteCuppingSessionById(r.Context(), id); err != nil {
+		we := mapDomainError(err)
+		w.Header().Set("HX-Reswap", "none")
+		writeHTMLStatus(w, we.Status)
+		_ = shared.ErrorAlertOOB(we.Message).Render(r.Context(), w)
+		return
+	}
+
+	if viewContext(r) == viewContextCuppingDetail {
+		w.Header().Set("HX-Redirect", "/cuppings")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = shared.SuccessAlertOOB("Cupping successfully deleted.").Render(r.Context(), w)
+}
+
+// cuppingBeansOptions returns the beans selectable on a score form.
+func (h *Handler) cuppingBeansOptions(r *http.Request) ([]bean.Bean, error) {
+	beans, err := h.BeanService.GetAllBeans(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	sortBeans(beans, "id", "asc")
+	return beans, nil
+}
+
+// renderCuppingDetailPage renders the session detail page with form
+// pre-opened in the score dialog, for the full-page fallback of a direct
+// GET to a score add/edit route.
+func (h *Handler) renderCuppingDetailPage(w http.ResponseWriter, r *http.Request, sessionID int, form templ.Component) {
+	s, err := h.CuppingService.GetCuppingSessionById(r.Context(), sessionID)
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewcuppings.Detail(*s, form).Render(r.Context(), w)
+}
+
+// AddCuppingScoreForm handles GET /cuppings/scores/add?session_id=: the
+// score dialog form for that session.
+func (h *Handler) AddCuppingScoreForm(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.Atoi(r.URL.Query().Get("session_id"))
+	if err != nil || sessionID <= 0 {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidCuppingID})
+		return
+	}
+	beans, err := h.cuppingBeansOptions(r)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+	form := viewcuppings.ScoreForm(viewcuppings.ScoreFormState{SessionID: sessionID}, beans, true, "", "")
+
+	if !isHXRequest(r) {
+		h.renderCuppingDetailPage(w, r, sessionID, form)
+		return
+	}
+	writeHTMLStatus(w, http.StatusOK)
+	_ = form.Render(r.Context(), w)
+}
+
+// parseCuppingScoreForm extracts and validates cupping score form fields,
+// returning the raw ScoreFormState (for redisplay) and, on success, the
+// parsed service model.
+func parseCuppingScoreForm(r *http.Request, id int) (viewcuppings.ScoreFormState, *cupping.CuppingScore, bool) {
+	state := viewcuppings.ScoreFormState{
+		ID:         id,
+		BeansID:    strings.TrimSpace(r.PostFormValue("beans_id")),
+		Attributes: map[string]string{},
+		Notes:      r.PostFormValue("notes"),
+		Errors:     map[string]string{},
+	}
+	state.SessionID, _ = strconv.Atoi(strings.TrimSpace(r.PostFormValue("session_id")))
+
+	if id == 0 && state.SessionID <= 0 {
+		state.FormError = errInvalidCuppingID
+	}
+
+	beansID, err := strconv.Atoi(state.BeansID)
+	if err != nil || beansID <= 0 {
+		state.Errors["beans_id"] = "Select beans."
+	}
+
+	values := make(map[string]float64, len(viewcuppings.ScoreAttributes))
+	for _, attr := range viewcuppings.ScoreAttributes {
+		raw := strings.TrimSpace(r.PostFormValue(attr.Field))
+		state.Attributes[attr.Field] = raw
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(v) || v < cupping.MinAttributeScore || v > cupping.MaxAttributeScore {
+			state.Errors[attr.Field] = attr.Label + " must be between 0 and 10."
+			continue
+		}
+		values[attr.Field] = v
+	}
+
+	if len(state.Notes) > 511 {
+		state.Errors["notes"] = "Notes must be 511 characters or fewer."
+	}
+
+	if len(state.Errors) > 0 || state.FormError != "" {
+		return state, nil, false
+	}
+
+	return state, &cupping.CuppingScore{
+		Id:         id,
+		SessionId:  state.SessionID,
+		Beans:      &bean.Bean{Id: beansID},
+		Fragrance:  values["fragrance"],
+		Flavor:     values["flavor"],
+		Aftertaste: values["aftertaste"],
+		Acidity:    values["acidity"],
+		Body:       values["body"],
+		Balance:    values["balance"],
+		Uniformity: values["uniformity"],
+		CleanCup:   values["clean_cup"],
+		Sweetness:  values["sweetness"],
+		Overall:    values["overall"],
+		Notes:      state.Notes,
+	}, true
+}
+
+// CreateCuppingScore handles POST /cuppings/scores/add.
+func (h *Handler) CreateCuppingScore(w http.ResponseWriter, r *http.Request) {
+	if !isFormURLEncoded(r) {
+		h.renderCuppingScoreFormError(w, r, viewcuppings.ScoreFormState{}, true, http.StatusUnsupportedMediaType)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		status, message := parseFormError(err)
+		h.renderCuppingScoreFormError(w, r, viewcuppings.ScoreFormState{FormError: message}, true, status)
+		return
+	}
+
+	state, model, ok := parseCuppingScoreForm(r, 0)
+	if !ok {
+		h.renderCuppingScoreFormError(w, r, state, true, http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.CuppingService.CreateCuppingScore(r.Context(), model)
+	if err != nil {
+		we := mapDomainError(err)
+		if field := cuppingScoreErrorField(err); field != "" {
+			state.Errors[field] = we.Message
+		} else {
+			state.FormError = we.Message
+		}
+		h.renderCuppingScoreFormError(w, r, state, true, we.Status)
+		return
+	}
+
+	w.Header().Set("HX-Reswap", "none")
+	w.Header().Set("HX-Trigger", "dialog-close")
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewcuppings.ScoreRow(*created, "insert").Render(r.Context(), w)
+	_ = shared.SuccessAlertOOB("Score successfully created.").Render(r.Context(), w)
+}
+
+// EditCuppingScoreForm handles GET /cuppings/scores/update/:id.
+func (h *Handler) EditCuppingScoreForm(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidCuppingScoreID})
+		return
+	}
+	s, err := h.CuppingService.GetCuppingScoreById(r.Context(), id)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+	beans, err := h.cuppingBeansOptions(r)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+
+	state := viewcuppings.ScoreFormState{
+		ID:        s.Id,
+		SessionID: s.SessionId,
+		Notes:     s.Notes,
+		Attributes: map[string]string{
+			"fragrance":  strconv.FormatFloat(s.Fragrance, 'f', 2, 64),
+			"flavor":     strconv.FormatFloat(s.Flavor, 'f', 2, 64),
+			"aftertaste": strconv.FormatFloat(s.Aftertaste, 'f', 2, 64),
+			"acidity":    strconv.FormatFloat(s.Acidity, 'f', 2, 64),
+			"body":       strconv.FormatFloat(s.Body, 'f', 2, 64),
+			"balance":    strconv.FormatFloat(s.Balance, 'f', 2, 64),
+			"uniformity": strconv.FormatFloat(s.Uniformity, 'f', 2, 64),
+			"clean_cup":  strconv.FormatFloat(s.CleanCup, 'f', 2, 64),
+			"sweetness":  strconv.FormatFloat(s.Sweetness, 'f', 2, 64),
+			"overall":    strconv.FormatFloat(s.Overall, 'f', 2, 64),
+		},
+	}
+	if s.Beans != nil {
+		state.BeansID = strconv.Itoa(s.Beans.Id)
+	}
+	form := viewcuppings.ScoreForm(state, beans, false, shared.FormatTimestamp(s.CreatedAt), shared.FormatTimestamp(s.UpdatedAt))
+
+	if !isHXRequest(r) {
+		h.renderCuppingDetailPage(w, r, s.SessionId, form)
+		return
+	}
+	writeHTMLStatus(w, http.StatusOK)
+	_ = form.Render(r.Context(), w)
+}
+
+// UpdateCuppingScore handles PUT /cuppings/scores/update/:id.
+func (h *Handler) UpdateCuppingScore(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		writeHTMLStatus(w, http.StatusBadRequest)
+		w.Header().Set("HX-Reswap", "none")
+		_ = shared.ErrorAlertOOB(errInvalidCuppingScoreID).Render(r.Context(), w)
+		return
+	}
+
+	if !isFormURLEncoded(r) {
+		h.renderCuppingScoreFormError(w, r, viewcuppings.ScoreFormState{ID: id}, false, http.StatusUnsupportedMediaType)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		status, message := parseFormError(err)
+		h.renderCuppingScoreFormError(w, r, viewcuppings.ScoreFormState{ID: id, FormError: message}, false, status)
+		return
+	}
+
+	state, model, ok := parseCuppingScoreForm(r, id)
+	if !ok {
+		h.renderCuppingScoreFormError(w, r, state, false, http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.CuppingService.UpdateCuppingScoreById(r.Context(), id, model)
+	if err != nil {
+		we := mapDomainError(err)
+		if field := cuppingScoreErrorField(err); field != "" {
+			state.Errors[field] = we.Message
+		} else {
+			state.FormError = we.Message
+		}
+		h.renderCuppingScoreFormError(w, r, state, false, we.Status)
+		return
+	}
+
+	w.Header().Set("HX-Reswap", "none")
+	w.Header().Set("HX-Trigger", "dialog-close")
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewcuppings.ScoreRow(*updated, "replace").Render(r.Context(), w)
+	_ = shared.SuccessAlertOOB("Score successfully updated.").Render(r.Context(), w)
+}
+
+func (h *Handler) renderCuppingScoreFormError(w http.ResponseWriter, r *http.Request, state viewcuppings.ScoreFormState, isAdd bool, status int) {
+	beans, err := h.cuppingBeansOptions(r)
+	if err != nil {
+		beans = nil
+	}
+	writeHTMLStatus(w, status)
+	_ = viewcuppings.ScoreForm(state, beans, isAdd, "", "").Render(r.Context(), w)
+}
+
+// DeleteCuppingScore handles DELETE /cuppings/scores/delete/:id.
+func (h *Handler) DeleteCuppingScore(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		w.Header().Set("HX-Reswap", "none")
+		writeHTMLStatus(w, http.StatusBadRequest)
+		_ = shared.ErrorAlertOOB(errInvalidCuppingScoreID).Render(r.Context(), w)
+		return
+	}
+
+	if err := h.CuppingService.DeleteCuppingScoreById(r.Context(), id); err != nil {
+		we := mapDomainError(err)
+		w.Header().Set("HX-Reswap", "none")
+		writeHTMLStatus(w, we.Status)
+		_ = shared.ErrorAlertOOB(we.Message).Render(r.Context(), w)
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = shared.SuccessAlertOOB("Score successfully deleted.").Render(r.Context(), w)
+}
+
+// BeanCuppings handles GET /beans/cuppings/:id: every cupping score
+// recorded for the beans, across sessions.
+func (h *Handler) BeanCuppings(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		h.writeFullPageError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidBeanID})
+		return
+	}
+	b, err := h.BeanService.GetBeanById(r.Context(), id)
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+	scores, err := h.CuppingService.GetCuppingScoresByBeansId(r.Context(), id)
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewcuppings.BeansScores(*b, scores).Render(r.Context(), w)
+}
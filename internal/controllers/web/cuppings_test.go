@@ -0,0 +1,237 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/cupping"
+	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+)
+
+// fakeCuppingService overrides the unusedCuppingService methods exercised
+// by the cupping routes.
+type fakeCuppingService struct {
+	unusedCuppingService
+	t                     *testing.T
+	createCuppingSession  func(context.Context, *cupping.CuppingSession) (*cupping.CuppingSession, error)
+	getCuppingSessionByID func(context.Context, int) (*cupping.CuppingSession, error)
+	getAllSessions        func(context.Context) ([]cupping.CuppingSession, error)
+	createCuppingScore    func(context.Context, *cupping.CuppingScore) (*cupping.CuppingScore, error)
+	getScoresByBeansID    func(context.Context, int) ([]cupping.CuppingScore, error)
+	deleteSessionByID     func(context.Context, int) error
+}
+
+func (f *fakeCuppingService) CreateCuppingSession(ctx context.Context, value *cupping.CuppingSession) (*cupping.CuppingSession, error) {
+	if f.createCuppingSession == nil {
+		f.t.Fatalf("unexpected CreateCuppingSession call")
+	}
+	return f.createCuppingSession(ctx, value)
+}
+
+func (f *fakeCuppingService) GetCuppingSessionById(ctx context.Context, id int) (*cupping.CuppingSession, error) {
+	if f.getCuppingSessionByID == nil {
+		f.t.Fatalf("unexpected GetCuppingSessionById call")
+	}
+	return f.getCuppingSessionByID(ctx, id)
+}
+
+func (f *fakeCuppingService) GetAllCuppingSessions(ctx context.Context) ([]cupping.CuppingSession, error) {
+	if f.getAllSessions == nil {
+		f.t.Fatalf("unexpected GetAllCuppingSessions call")
+	}
+	return f.getAllSessions(ctx)
+}
+
+func (f *fakeCuppingService) CreateCuppingScore(ctx context.Context, value *cupping.CuppingScore) (*cupping.CuppingScore, error) {
+	if f.createCuppingScore == nil {
+		f.t.Fatalf("unexpected CreateCuppingScore call")
+	}
+	return f.createCuppingScore(ctx, value)
+}
+
+func (f *fakeCuppingService) GetCuppingScoresByBeansId(ctx context.Context, id int) ([]cupping.CuppingScore, error) {
+	if f.getScoresByBeansID == nil {
+		f.t.Fatalf("unexpected GetCuppingScoresByBeansId call")
+	}
+	return f.getScoresByBeansID(ctx, id)
+}
+
+func (f *fakeCuppingService) DeleteCuppingSessionById(ctx context.Context, id int) error {
+	if f.deleteSessionByID == nil {
+		f.t.Fatalf("unexpected DeleteCuppingSessionById call")
+	}
+	return f.deleteSessionByID(ctx, id)
+}
+
+// fakeBeanServiceForCuppings returns a fixed beans list so score forms can
+// populate the beans <select>.
+type fakeBeanServiceForCuppings struct {
+	unusedBeanService
+	beans []bean.Bean
+}
+
+func (f fakeBeanServiceForCuppings) GetAllBeans(context.Context) ([]bean.Bean, error) {
+	return f.beans, nil
+}
+
+func (f fakeBeanServiceForCuppings) GetBeanById(_ context.Context, id int) (*bean.Bean, error) {
+	for _, b := range f.beans {
+		if b.Id == id {
+			return &b, nil
+		}
+	}
+	return nil, errors.ErrBeansDoesNotExist
+}
+
+func newTestCuppingHandler(t *testing.T, beans []bean.Bean) (*Handler, *fakeCuppingService) {
+	t.Helper()
+	svc := &fakeCuppingService{t: t}
+	h := NewHandler(unusedSheetService{}, unusedRoasterService{}, fakeBeanServiceForCuppings{beans: beans}, unusedShotService{}, svc, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil)
+	return h, svc
+}
+
+func testCuppingSession(id int) *cupping.CuppingSession {
+	sessionDate := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	return &cupping.CuppingSession{Id: id, SessionDate: &sessionDate, Participants: []string{"Alice", "Bob"}}
+}
+
+const validCuppingScoreForm = "session_id=4&beans_id=9&fragrance=8&flavor=8.25&aftertaste=7.75&acidity=8&body=7.5" +
+	"&balance=8&uniformity=10&clean_cup=10&sweetness=10&overall=8&notes=stone+fruit"
+
+func TestListCuppings_MostRecentFirst(t *testing.T) {
+	h, svc := newTestCuppingHandler(t, nil)
+	older := testCuppingSession(1)
+	olderDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	older.SessionDate = &olderDate
+	svc.getAllSessions = func(context.Context) ([]cupping.CuppingSession, error) {
+		return []cupping.CuppingSession{*older, *testCuppingSession(2)}, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.ListCuppings(rec, newWebRequest(http.MethodGet, "/cuppings", "", "", "", false))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, `id="cupping-dialog"`) {
+		t.Fatalf("expected the full page with the dialog target, got %d: %s", rec.Code, body)
+	}
+	if strings.Index(body, "2026-03-04") > strings.Index(body, "2026-01-01") {
+		t.Errorf("expected the most recent session first, got: %s", body)
+	}
+}
+
+func TestCreateCupping_ParsesParticipants(t *testing.T) {
+	h, svc := newTestCuppingHandler(t, nil)
+	svc.createCuppingSession = func(_ context.Context, s *cupping.CuppingSession) (*cupping.CuppingSession, error) {
+		if len(s.Participants) != 2 || s.Participants[0] != "Alice" || s.Participants[1] != "Bob" {
+			t.Errorf("participants = %#v, want [Alice Bob]", s.Participants)
+		}
+		created := *testCuppingSession(5)
+		return &created, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.CreateCupping(rec, newWebRequest(http.MethodPost, "/cuppings/add", "session_date=2026-03-04&participants=Alice%2C+%2CBob", "application/x-www-form-urlencoded", "", true))
+
+	if rec.Code != http.StatusOK || rec.Header().Get("HX-Trigger") != "dialog-close" {
+		t.Fatalf("expected a successful dialog close, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `hx-swap-oob="beforeend:#cuppings-tbody"`) {
+		t.Errorf("expected the new row to be inserted out-of-band, got: %s", rec.Body.String())
+	}
+}
+
+func TestCreateCupping_MissingDateIsAFieldError(t *testing.T) {
+	h, _ := newTestCuppingHandler(t, nil)
+
+	rec := httptest.NewRecorder()
+	h.CreateCupping(rec, newWebRequest(http.MethodPost, "/cuppings/add", "participants=Alice", "application/x-www-form-urlencoded", "", true))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "Cupping date must not be empty.") {
+		t.Errorf("expected a 400 with an inline date error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCreateCuppingScore_HappyPath(t *testing.T) {
+	h, svc := newTestCuppingHandler(t, []bean.Bean{{Id: 9, Name: "Ethiopia"}})
+	svc.createCuppingScore = func(_ context.Context, s *cupping.CuppingScore) (*cupping.CuppingScore, error) {
+		if s.SessionId != 4 || s.Beans == nil || s.Beans.Id != 9 || s.Flavor != 8.25 || s.CleanCup != 10 {
+			t.Errorf("score = %#v, want the parsed form values", s)
+		}
+		created := *s
+		created.Id = 7
+		created.Beans = &bean.Bean{Id: 9, Name: "Ethiopia"}
+		created.Total = created.ComputeTotal()
+		return &created, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.CreateCuppingScore(rec, newWebRequest(http.MethodPost, "/cuppings/scores/add", validCuppingScoreForm, "application/x-www-form-urlencoded", "", true))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, `hx-swap-oob="beforeend:#cupping-scores-tbody"`) {
+		t.Fatalf("expected the new score row to be inserted out-of-band, got %d: %s", rec.Code, body)
+	}
+	if !strings.Contains(body, "85.50") {
+		t.Errorf("expected the computed total to be rendered, got: %s", body)
+	}
+}
+
+func TestCreateCuppingScore_OutOfRangeIsAFieldError(t *testing.T) {
+	h, _ := newTestCuppingHandler(t, []bean.Bean{{Id: 9, Name: "Ethiopia"}})
+	form := strings.Replace(validCuppingScoreForm, "flavor=8.25", "flavor=10.5", 1)
+
+	rec := httptest.NewRecorder()
+	h.CreateCuppingScore(rec, newWebRequest(http.MethodPost, "/cuppings/scores/add", form, "application/x-www-form-urlencoded", "", true))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "Flavor must be between 0 and 10.") {
+		t.Errorf("expected a 400 with an inline range error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCreateCuppingScore_DuplicateBeansIsAFieldError(t *testing.T) {
+	h, svc := newTestCuppingHandler(t, []bean.Bean{{Id: 9, Name: "Ethiopia"}})
+	svc.createCuppingScore = func(context.Context, *cupping.CuppingScore) (*cupping.CuppingScore, error) {
+		return nil, errors.ErrCuppingScoreAlreadyExists
+	}
+
+	rec := httptest.NewRecorder()
+	h.CreateCuppingScore(rec, newWebRequest(http.MethodPost, "/cuppings/scores/add", validCuppingScoreForm, "application/x-www-form-urlencoded", "", true))
+
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "These beans already have a score in this cupping.") {
+		t.Errorf("expected a 409 with an inline beans error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestDeleteCupping_FromDetailRedirects(t *testing.T) {
+	h, svc := newTestCuppingHandler(t, nil)
+	svc.deleteSessionByID = func(context.Context, int) error { return nil }
+
+	rec := httptest.NewRecorder()
+	h.DeleteCupping(rec, newWebRequest(http.MethodDelete, "/cuppings/delete/4?view_context=cupping-detail", "", "", "4", true))
+
+	if rec.Code != http.StatusOK || rec.Header().Get("HX-Redirect") != "/cuppings" {
+		t.Errorf("expected an HX-Redirect to the list, got %d %v", rec.Code, rec.Header())
+	}
+}
+
+func TestBeanCuppings_ListsScoresAcrossSessions(t *testing.T) {
+	h, svc := newTestCuppingHandler(t, []bean.Bean{{Id: 9, Name: "Ethiopia", Roaster: &roaster.Roaster{Id: 1, Name: "Roaster"}}})
+	svc.getScoresByBeansID = func(_ context.Context, id int) ([]cupping.CuppingScore, error) {
+		sessionDate := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+		return []cupping.CuppingScore{{Id: 7, SessionId: 4, SessionDate: &sessionDate, Total: 85.5}}, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.BeanCuppings(rec, newWebRequest(http.MethodGet, "/beans/cuppings/9", "", "", "9", false))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, "Cuppings of Ethiopia") || !strings.Contains(body, `href="/cuppings/get/4"`) {
+		t.Errorf("expected the beans cupping page linking to the session, got %d: %s", rec.Code, body)
+	}
+}
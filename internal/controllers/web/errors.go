@@ -24,18 +24,78 @@ var domainErrorMessages = map[error]webError{
 	domainerrors.ErrRoasterAlreadyExists: {http.StatusConflict, "A roaster with this name already exists."},
 	domainerrors.ErrRoasterNameIsEmpty:   {http.StatusBadRequest, "Roaster name must not be empty."},
 
-	domainerrors.ErrBeansDoesNotExist:         {http.StatusNotFound, "No beans found for the given id."},
-	domainerrors.ErrBeansAlreadyExists:        {http.StatusConflict, "Beans with this name already exist."},
-	domainerrors.ErrBeansNameIsEmpty:          {http.StatusBadRequest, "Beans name must not be empty."},
-	domainerrors.ErrBeansRoastLevelOutOfRange: {http.StatusBadRequest, "Roast level must be between light and dark."},
-	domainerrors.ErrBeansForeignKeyConstraint: {http.StatusConflict, "This roaster is still used by beans. Delete or reassign those beans first."},
+	domainerrors.ErrBeansDoesNotExist:          {http.StatusNotFound, "No beans found for the given id."},
+	domainerrors.ErrBeansAlreadyExists:         {http.StatusConflict, "Beans with this name already exist."},
+	domainerrors.ErrBeansNameIsEmpty:           {http.StatusBadRequest, "Beans name must not be empty."},
+	domainerrors.ErrBeansRoastLevelOutOfRange:  {http.StatusBadRequest, "Roast level must be between light and dark."},
+	domainerrors.ErrBeansGreenWeightOutOfRange: {http.StatusBadRequest, "Green weight must not be negative."},
+	domainerrors.ErrBeansPriceOutOfRange:       {http.StatusBadRequest, "Price must not be negative."},
+	domainerrors.ErrBeansBagWeightOutOfRange:   {http.StatusBadRequest, "Bag weight must not be negative."},
+	domainerrors.ErrBeansCurrencyIsInvalid:     {http.StatusBadRequest, "Currency must be a three-letter code, such as EUR."},
+	domainerrors.ErrBeansForeignKeyConstraint:  {http.StatusConflict, "This roaster is still used by beans. Delete or reassign those beans first."},
 
 	domainerrors.ErrShotDoesNotExist:                           {http.StatusNotFound, "No shot found for the given id."},
 	domainerrors.ErrShotAlreadyExists:                          {http.StatusConflict, "Shot already exists."},
 	domainerrors.ErrShotRatingOutOfRange:                       {http.StatusBadRequest, "Rating must be between 0 and 10."},
 	domainerrors.ErrShotComparisonWithPreviousResultOutOfRange: {http.StatusBadRequest, "Invalid comparison value."},
 	domainerrors.ErrShotTimeOutOfRange:                         {http.StatusBadRequest, "Shot time must be between 0 and 3600 seconds."},
+	domainerrors.ErrShotCompareIdsAreInvalid:                   {http.StatusBadRequest, "Select between 2 and 6 shots to compare."},
 	domainerrors.ErrShotForeignKeyConstraint:                   {http.StatusConflict, "This sheet or beans selection is still referenced by shots. Delete those shots first."},
+
+	domainerrors.ErrCuppingSessionDoesNotExist:       {http.StatusNotFound, "No cupping found for the given id."},
+	domainerrors.ErrCuppingSessionDateIsEmpty:        {http.StatusBadRequest, "Cupping date must not be empty."},
+	domainerrors.ErrCuppingScoreDoesNotExist:         {http.StatusNotFound, "No cupping score found for the given id."},
+	domainerrors.ErrCuppingScoreAlreadyExists:        {http.StatusConflict, "These beans already have a score in this cupping."},
+	domainerrors.ErrCuppingScoreOutOfRange:           {http.StatusBadRequest, "Each score must be between 0 and 10."},
+	domainerrors.ErrCuppingScoreForeignKeyConstraint: {http.StatusConflict, "These beans are still used by cupping scores. Delete those scores first."},
+
+	domainerrors.ErrRoastBatchDoesNotExist:         {http.StatusNotFound, "No roast found for the given id."},
+	domainerrors.ErrRoastBatchGreenCoffeeIsEmpty:   {http.StatusBadRequest, "Green coffee must not be empty."},
+	domainerrors.ErrRoastBatchRoastDateIsEmpty:     {http.StatusBadRequest, "Roast date must not be empty."},
+	domainerrors.ErrRoastBatchRoastLevelOutOfRange: {http.StatusBadRequest, "Roast level must be between light and dark."},
+	domainerrors.ErrRoastBatchWeightOutOfRange:     {http.StatusBadRequest, "Green weight must be positive and roasted weight at most the green weight."},
+	domainerrors.ErrRoastBatchTimeOutOfRange:       {http.StatusBadRequest, "Times must not be negative."},
+	domainerrors.ErrRoastBatchCurveIsInvalid:       {http.StatusBadRequest, "Each curve reading needs a distinct, non-negative time."},
+	domainerrors.ErrRoastBatchBeansAlreadyExist:    {http.StatusConflict, "Beans were already created from this roast."},
+	domainerrors.ErrRoastBatchForeignKeyConstraint: {http.StatusConflict, "This green coffee is still used by roasts. Delete or unlink those roasts first."},
+
+	domainerrors.ErrGreenCoffeeDoesNotExist:             {http.StatusNotFound, "No green coffee found for the given id."},
+	domainerrors.ErrGreenCoffeeOriginIsEmpty:            {http.StatusBadRequest, "Origin must not be empty."},
+	domainerrors.ErrGreenCoffeePurchaseWeightOutOfRange: {http.StatusBadRequest, "Purchase weight must be positive."},
+	domainerrors.ErrGreenCoffeePriceOutOfRange:          {http.StatusBadRequest, "Price must not be negative."},
+	domainerrors.ErrGreenCoffeeMoistureOutOfRange:       {http.StatusBadRequest, "Moisture must be between 0 and 100 %."},
+
+	domainerrors.ErrSpendReportGroupByIsInvalid: {http.StatusBadRequest, "Group by must be month, roaster or beans."},
+	domainerrors.ErrSpendReportRangeIsInvalid:   {http.StatusBadRequest, "The from date must not be after the to date."},
+
+	domainerrors.ErrStatsTimeZoneIsInvalid: {http.StatusBadRequest, "Time zone must be an IANA name, such as Europe/Paris."},
+	domainerrors.ErrStatsRangeIsInvalid:    {http.StatusBadRequest, "The from date must not be after the to date."},
+	domainerrors.ErrStatsRangeIsTooLong:    {http.StatusBadRequest, "The range must not exceed 366 days."},
+	domainerrors.ErrStatsTimeZoneIsUnknown: {http.StatusInternalServerError, "The database does not know this time zone. On MySQL, load its time zone tables."},
+
+	domainerrors.ErrMaintenanceTaskDoesNotExist:       {http.StatusNotFound, "No maintenance task found for the given id."},
+	domainerrors.ErrMaintenanceTaskTypeIsInvalid:      {http.StatusBadRequest, "Pick a task from the list."},
+	domainerrors.ErrMaintenanceTaskEquipmentIsInvalid: {http.StatusBadRequest, "Equipment must be a machine, a grinder or none."},
+	domainerrors.ErrMaintenanceTaskIntervalOutOfRange: {http.StatusBadRequest, "Give a positive number of shots, of days or both."},
+	domainerrors.ErrMaintenanceTaskLastDoneIsInFuture: {http.StatusBadRequest, "Last done date must not be in the future."},
+
+	domainerrors.ErrShareLinkDoesNotExist:    {http.StatusNotFound, "No share link found for the given id."},
+	domainerrors.ErrShareLinkIsInvalid:       {http.StatusNotFound, "This share link is invalid, has expired or was revoked."},
+	domainerrors.ErrShareLinkExpiryIsInvalid: {http.StatusBadRequest, "The share link must expire in the future and within a year."},
+
+	domainerrors.ErrAttachmentDoesNotExist:      {http.StatusNotFound, "No photo found for the given id."},
+	domainerrors.ErrAttachmentIsEmpty:           {http.StatusBadRequest, "The photo must not be empty."},
+	domainerrors.ErrAttachmentTypeIsUnsupported: {http.StatusUnsupportedMediaType, "The photo must be a JPEG, PNG or GIF image."},
+	domainerrors.ErrAttachmentImageIsInvalid:    {http.StatusBadRequest, "The photo could not be read. It may be corrupted."},
+	domainerrors.ErrAttachmentImageIsTooLarge:   {http.StatusBadRequest, "The photo must not exceed 40 megapixels."},
+
+	domainerrors.ErrInvalidCredentials: {http.StatusUnauthorized, "Invalid user name or password."},
+	domainerrors.ErrUserIsDisabled:     {http.StatusForbidden, "This user is disabled."},
+	domainerrors.ErrPermissionDenied:   {http.StatusForbidden, "Your role does not allow this action."},
+
+	domainerrors.ErrOIDCLoginFailed:       {http.StatusUnauthorized, "Single sign-on failed. Please try again."},
+	domainerrors.ErrOIDCTokenIsInvalid:    {http.StatusUnauthorized, "The identity provider returned an invalid token. Please try again."},
+	domainerrors.ErrOIDCIdentityIsUnknown: {http.StatusForbidden, "No user matches your identity. Ask an administrator to create or link your user."},
 }
 
 // mapDomainError resolves a service error to a UI status/message pair,
@@ -59,6 +119,16 @@ func beanErrorField(err error) string {
 		return "roaster_id"
 	case errors.Is(err, domainerrors.ErrBeansRoastLevelOutOfRange):
 		return "roast_level"
+	case errors.Is(err, domainerrors.ErrGreenCoffeeDoesNotExist):
+		return "green_coffee_id"
+	case errors.Is(err, domainerrors.ErrBeansGreenWeightOutOfRange):
+		return "green_weight"
+	case errors.Is(err, domainerrors.ErrBeansPriceOutOfRange):
+		return "price"
+	case errors.Is(err, domainerrors.ErrBeansCurrencyIsInvalid):
+		return "currency"
+	case errors.Is(err, domainerrors.ErrBeansBagWeightOutOfRange):
+		return "bag_weight"
 	case errors.Is(err, domainerrors.ErrBeansAlreadyExists), errors.Is(err, domainerrors.ErrBeansNameIsEmpty):
 		return "name"
 	default:
@@ -87,13 +157,83 @@ func shotErrorField(err error) string {
 	}
 }
 
+// cuppingScoreErrorField resolves a cupping score domain error to the form
+// field it should be displayed under, or "" for the form's general error
+// slot.
+func cuppingScoreErrorField(err error) string {
+	switch {
+	case errors.Is(err, domainerrors.ErrBeansDoesNotExist), errors.Is(err, domainerrors.ErrCuppingScoreAlreadyExists):
+		return "beans_id"
+	default:
+		return ""
+	}
+}
+
+// roastBatchErrorField resolves a roast batch domain error to the form field
+// it should be displayed under, or "" for the form's general error slot.
+func roastBatchErrorField(err error) string {
+	switch {
+	case errors.Is(err, domainerrors.ErrRoastBatchGreenCoffeeIsEmpty):
+		return "green_coffee"
+	case errors.Is(err, domainerrors.ErrRoastBatchRoastDateIsEmpty):
+		return "roast_date"
+	case errors.Is(err, domainerrors.ErrRoastBatchRoastLevelOutOfRange):
+		return "roast_level"
+	case errors.Is(err, domainerrors.ErrRoastBatchWeightOutOfRange):
+		return "roasted_weight"
+	case errors.Is(err, domainerrors.ErrRoastBatchCurveIsInvalid):
+		return "curve"
+	case errors.Is(err, domainerrors.ErrGreenCoffeeDoesNotExist):
+		return "green_coffee_id"
+	default:
+		return ""
+	}
+}
+
+// greenCoffeeErrorField resolves a green coffee domain error to the form
+// field it should be displayed under, or "" for the form's general error
+// slot.
+func greenCoffeeErrorField(err error) string {
+	switch {
+	case errors.Is(err, domainerrors.ErrGreenCoffeeOriginIsEmpty):
+		return "origin"
+	case errors.Is(err, domainerrors.ErrGreenCoffeePurchaseWeightOutOfRange):
+		return "purchase_weight"
+	case errors.Is(err, domainerrors.ErrGreenCoffeePriceOutOfRange):
+		return "price"
+	case errors.Is(err, domainerrors.ErrGreenCoffeeMoistureOutOfRange):
+		return "moisture"
+	default:
+		return ""
+	}
+}
+
+func maintenanceTaskErrorField(err error) string {
+	switch {
+	case errors.Is(err, domainerrors.ErrMaintenanceTaskTypeIsInvalid):
+		return "type"
+	case errors.Is(err, domainerrors.ErrMaintenanceTaskEquipmentIsInvalid):
+		return "equipment"
+	case errors.Is(err, domainerrors.ErrMaintenanceTaskIntervalOutOfRange):
+		return "interval_shots"
+	case errors.Is(err, domainerrors.ErrMaintenanceTaskLastDoneIsInFuture):
+		return "last_done_at"
+	default:
+		return ""
+	}
+}
+
 // mapDeleteError resolves a delete-time domain error to a UI status/message
 // pair. domainErrorMessages' entry for ErrShotForeignKeyConstraint hedges
 // between "sheet or beans" since sheets and beans share that same sentinel
 // error when referenced by shots; fkMessage substitutes the resource-
-// specific wording for the caller (DeleteSheet/DeleteBean) instead.
+// specific wording for the caller (DeleteSheet/DeleteBean) instead. A
+// green coffee is referenced by both roasts and beans, so DeleteGreenCoffee
+// gets the same substitution for their foreign key errors.
 func mapDeleteError(err error, fkMessage string) webError {
-	if errors.Is(err, domainerrors.ErrShotForeignKeyConstraint) {
+	if errors.Is(err, domainerrors.ErrShotForeignKeyConstraint) ||
+		errors.Is(err, domainerrors.ErrBeansForeignKeyConstraint) ||
+		errors.Is(err, domainerrors.ErrRoastBatchForeignKeyConstraint) {
 		return webError{Status: http.StatusConflict, Message: fkMessage}
 	}
 	return mapDomainError(err)
@@ -0,0 +1,313 @@
+package web
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/lescactus/espressoapi-go/internal/services/greencoffee"
+	viewgreencoffees "github.com/lescactus/espressoapi-go/views/templates/greencoffees"
+	"github.com/lescactus/espressoapi-go/views/templates/shared"
+)
+
+const errInvalidGreenCoffeeID = "The green coffee id must be a positive number."
+
+// sortGreenCoffees sorts green coffees most recent arrival first, so the
+// purchase being roasted is near the top.
+func sortGreenCoffees(greenCoffees []greencoffee.GreenCoffee) {
+	sort.SliceStable(greenCoffees, func(i, j int) bool {
+		a, b := greenCoffees[i], greenCoffees[j]
+		if timeLess(b.ArrivalDate, a.ArrivalDate) {
+			return true
+		}
+		if timeLess(a.ArrivalDate, b.ArrivalDate) {
+			return false
+		}
+		return a.Id > b.Id
+	})
+}
+
+// greenCoffeeOptions fetches the green coffees offered by the beans and
+// roast forms' green coffee select, ordered by id.
+func (h *Handler) greenCoffeeOptions(r *http.Request) ([]greencoffee.GreenCoffee, error) {
+	greenCoffees, err := h.GreenCoffeeService.GetAllGreenCoffees(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(greenCoffees, func(i, j int) bool { return greenCoffees[i].Id < greenCoffees[j].Id })
+	return greenCoffees, nil
+}
+
+// parseOptionalGreenCoffeeID parses the beans and roast forms' optional
+// green coffee select, where "" means none.
+func parseOptionalGreenCoffeeID(raw string) (*int, bool) {
+	if raw == "" {
+		return nil, true
+	}
+	id, err := strconv.Atoi(raw)
+	if err != nil || id <= 0 {
+		return nil, false
+	}
+	return &id, true
+}
+
+// ListGreenCoffees handles GET /green_coffees.
+func (h *Handler) ListGreenCoffees(w http.ResponseWriter, r *http.Request) {
+	greenCoffees, err := h.GreenCoffeeService.GetAllGreenCoffees(r.Context())
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+	sortGreenCoffees(greenCoffees)
+
+	writeHTMLStatus(w, http.StatusOK)
+	if isHXRequest(r) {
+		_ = viewgreencoffees.Table(greenCoffees).Render(r.Context(), w)
+		return
+	}
+	_ = viewgreencoffees.Page(greenCoffees, nil).Render(r.Context(), w)
+}
+
+// renderGreenCoffeesPage renders the full green coffees list page with form
+// pre-opened in the dialog, for the full-page fallback of a direct GET to an
+// add/edit dialog route.
+func (h *Handler) renderGreenCoffeesPage(w http.ResponseWriter, r *http.Request, form templ.Component) {
+	greenCoffees, err := h.GreenCoffeeService.GetAllGreenCoffees(r.Context())
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+	sortGreenCoffees(greenCoffees)
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewgreencoffees.Page(greenCoffees, form).Render(r.Context(), w)
+}
+
+// AddGreenCoffeeForm handles GET /green_coffees/add.
+func (h *Handler) AddGreenCoffeeForm(w http.ResponseWriter, r *http.Request) {
+	form := viewgreencoffees.Form(viewgreencoffees.FormState{}, true, "", "")
+	if !isHXRequest(r) {
+		h.renderGreenCoffeesPage(w, r, form)
+		return
+	}
+	writeHTMLStatus(w, http.StatusOK)
+	_ = form.Render(r.Context(), w)
+}
+
+// parseGreenCoffeeForm extracts and validates green coffee form fields,
+// returning the raw FormState (for redisplay) and, on success, the parsed
+// service model. Price and moisture default to 0 when left empty.
+func parseGreenCoffeeForm(r *http.Request, id int) (viewgreencoffees.FormState, *greencoffee.GreenCoffee, bool) {
+	state := viewgreencoffees.FormState{
+		ID:             id,
+		Origin:         strings.TrimSpace(r.PostFormValue("origin")),
+		Supplier:       strings.TrimSpace(r.PostFormValue("supplier")),
+		PurchaseWeight: strings.TrimSpace(r.PostFormValue("purchase_weight")),
+		Price:          strings.TrimSpace(r.PostFormValue("price")),
+		ArrivalDate:    strings.TrimSpace(r.PostFormValue("arrival_date")),
+		Moisture:       strings.TrimSpace(r.PostFormValue("moisture")),
+		Errors:         map[string]string{},
+	}
+
+	if state.Origin == "" {
+		state.Errors["origin"] = "Origin must not be empty."
+	} else if len(state.Origin) > 255 {
+		state.Errors["origin"] = "Origin must be 255 characters or fewer."
+	}
+
+	if len(state.Supplier) > 255 {
+		state.Errors["supplier"] = "Supplier must be 255 characters or fewer."
+	}
+
+	purchaseWeight, err := strconv.ParseFloat(state.PurchaseWeight, 64)
+	if err != nil || math.IsNaN(purchaseWeight) || math.IsInf(purchaseWeight, 0) || purchaseWeight <= 0 {
+		state.Errors["purchase_weight"] = "Purchase weight must be a positive number."
+	}
+
+	var price float64
+	if state.Price != "" {
+		price, err = strconv.ParseFloat(state.Price, 64)
+		if err != nil || math.IsNaN(price) || math.IsInf(price, 0) || price < 0 {
+			state.Errors["price"] = "Price must be a non-negative number."
+		}
+	}
+
+	var arrivalDate *time.Time
+	if state.ArrivalDate != "" {
+		parsed, err := time.Parse("2006-01-02", state.ArrivalDate)
+		if err != nil {
+			state.Errors["arrival_date"] = "Arrival date must be a valid date."
+		} else {
+			arrivalDate = &parsed
+		}
+	}
+
+	var moisture float64
+	if state.Moisture != "" {
+		moisture, err = strconv.ParseFloat(state.Moisture, 64)
+		if err != nil || math.IsNaN(moisture) || moisture < 0 || moisture > 100 {
+			state.Errors["moisture"] = "Moisture must be between 0 and 100 %."
+		}
+	}
+
+	if len(state.Errors) > 0 {
+		return state, nil, false
+	}
+
+	return state, &greencoffee.GreenCoffee{
+		Id:             id,
+		Origin:         state.Origin,
+		Supplier:       state.Supplier,
+		PurchaseWeight: purchaseWeight,
+		Price:          price,
+		ArrivalDate:    arrivalDate,
+		Moisture:       moisture,
+	}, true
+}
+
+// CreateGreenCoffee handles POST /green_coffees/add.
+func (h *Handler) CreateGreenCoffee(w http.ResponseWriter, r *http.Request) {
+	if !isFormURLEncoded(r) {
+		h.renderGreenCoffeeFormError(w, r, viewgreencoffees.FormState{}, true, http.StatusUnsupportedMediaType)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		status, message := parseFormError(err)
+		h.renderGreenCoffeeFormError(w, r, viewgreencoffees.FormState{FormError: message}, true, status)
+		return
+	}
+
+	state, model, ok := parseGreenCoffeeForm(r, 0)
+	if !ok {
+		h.renderGreenCoffeeFormError(w, r, state, true, http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.GreenCoffeeService.CreateGreenCoffee(r.Context(), model)
+	if err != nil {
+		we := mapDomainError(err)
+		if field := greenCoffeeErrorField(err); field != "" {
+			state.Errors[field] = we.Message
+		} else {
+			state.FormError = we.Message
+		}
+		h.renderGreenCoffeeFormError(w, r, state, true, we.Status)
+		return
+	}
+
+	w.Header().Set("HX-Reswap", "none")
+	w.Header().Set("HX-Trigger", "dialog-close")
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewgreencoffees.Row(*created, "insert").Render(r.Context(), w)
+	_ = shared.SuccessAlertOOB("Green coffee successfully created.").Render(r.Context(), w)
+}
+
+// EditGreenCoffeeForm handles GET /green_coffees/update/:id.
+func (h *Handler) EditGreenCoffeeForm(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidGreenCoffeeID})
+		return
+	}
+	g, err := h.GreenCoffeeService.GetGreenCoffeeById(r.Context(), id)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+
+	state := viewgreencoffees.FormState{
+		ID:             g.Id,
+		Origin:         g.Origin,
+		Supplier:       g.Supplier,
+		PurchaseWeight: strconv.FormatFloat(g.PurchaseWeight, 'f', -1, 64),
+		Price:          strconv.FormatFloat(g.Price, 'f', -1, 64),
+		Moisture:       strconv.FormatFloat(g.Moisture, 'f', -1, 64),
+	}
+	if g.ArrivalDate != nil {
+		state.ArrivalDate = g.ArrivalDate.UTC().Format("2006-01-02")
+	}
+	form := viewgreencoffees.Form(state, false, shared.FormatTimestamp(g.CreatedAt), shared.FormatTimestamp(g.UpdatedAt))
+
+	if !isHXRequest(r) {
+		h.renderGreenCoffeesPage(w, r, form)
+		return
+	}
+	writeHTMLStatus(w, http.StatusOK)
+	_ = form.Render(r.Context(), w)
+}
+
+// UpdateGreenCoffee handles PUT /green_coffees/update/:id.
+func (h *Handler) UpdateGreenCoffee(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		writeHTMLStatus(w, http.StatusBadRequest)
+		w.Header().Set("HX-Reswap", "none")
+		_ = shared.ErrorAlertOOB(errInvalidGreenCoffeeID).Render(r.Context(), w)
+		return
+	}
+
+	if !isFormURLEncoded(r) {
+		h.renderGreenCoffeeFormError(w, r, viewgreencoffees.FormState{ID: id}, false, http.StatusUnsupportedMediaType)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		status, message := parseFormError(err)
+		h.renderGreenCoffeeFormError(w, r, viewgreencoffees.FormState{ID: id, FormError: message}, false, status)
+		return
+	}
+
+	state, model, ok := parseGreenCoffeeForm(r, id)
+	if !ok {
+		h.renderGreenCoffeeFormError(w, r, state, false, http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.GreenCoffeeService.UpdateGreenCoffeeById(r.Context(), id, model)
+	if err != nil {
+		we := mapDomainError(err)
+		if field := greenCoffeeErrorField(err); field != "" {
+			state.Errors[field] = we.Message
+		} else {
+			state.FormError = we.Message
+		}
+		h.renderGreenCoffeeFormError(w, r, state, false, we.Status)
+		return
+	}
+
+	w.Header().Set("HX-Reswap", "none")
+	w.Header().Set("HX-Trigger", "dialog-close")
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewgreencoffees.Row(*updated, "replace").Render(r.Context(), w)
+	_ = shared.SuccessAlertOOB("Green coffee successfully updated.").Render(r.Context(), w)
+}
+
+func (h *Handler) renderGreenCoffeeFormError(w http.ResponseWriter, r *http.Request, state viewgreencoffees.FormState, isAdd bool, status int) {
+	writeHTMLStatus(w, status)
+	_ = viewgreencoffees.Form(state, isAdd, "", "").Render(r.Context(), w)
+}
+
+// DeleteGreenCoffee handles DELETE /green_coffees/delete/:id. A green
+// coffee still drawn on by roasts or beans cannot be deleted.
+func (h *Handler) DeleteGreenCoffee(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		w.Header().Set("HX-Reswap", "none")
+		writeHTMLStatus(w, http.StatusBadRequest)
+		_ = shared.ErrorAlertOOB(errInvalidGreenCoffeeID).Render(r.Context(), w)
+		return
+	}
+
+	if err := h.GreenCoffeeService.DeleteGreenCoffeeById(r.Context(), id); err != nil {
+		we := mapDeleteError(err, "This green coffee is still used by roasts or beans. Delete or unlink those first.")
+		w.Header().Set("HX-Reswap", "none")
+		writeHTMLStatus(w, we.Status)
+		_ = shared.ErrorAlertOOB(we.Message).Render(r.Context(), w)
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = shared.SuccessAlertOOB("Green coffee successfully deleted.").Render(r.Context(), w)
+}
@@ -0,0 +1,137 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/greencoffee"
+	"github.com/lescactus/espressoapi-go/internal/services/roastbatch"
+)
+
+// fakeGreenCoffeeService overrides the unusedGreenCoffeeService methods
+// exercised by the green coffee routes.
+type fakeGreenCoffeeService struct {
+	unusedGreenCoffeeService
+	t                     *testing.T
+	createGreenCoffee     func(context.Context, *greencoffee.GreenCoffee) (*greencoffee.GreenCoffee, error)
+	getAllGreenCoffees    func(context.Context) ([]greencoffee.GreenCoffee, error)
+	deleteGreenCoffeeByID func(context.Context, int) error
+}
+
+func (f *fakeGreenCoffeeService) CreateGreenCoffee(ctx context.Context, value *greencoffee.GreenCoffee) (*greencoffee.GreenCoffee, error) {
+	if f.createGreenCoffee == nil {
+		f.t.Fatalf("unexpected CreateGreenCoffee call")
+	}
+	return f.createGreenCoffee(ctx, value)
+}
+
+func (f *fakeGreenCoffeeService) GetAllGreenCoffees(ctx context.Context) ([]greencoffee.GreenCoffee, error) {
+	if f.getAllGreenCoffees == nil {
+		f.t.Fatalf("unexpected GetAllGreenCoffees call")
+	}
+	return f.getAllGreenCoffees(ctx)
+}
+
+func (f *fakeGreenCoffeeService) DeleteGreenCoffeeById(ctx context.Context, id int) error {
+	if f.deleteGreenCoffeeByID == nil {
+		f.t.Fatalf("unexpected DeleteGreenCoffeeById call")
+	}
+	return f.deleteGreenCoffeeByID(ctx, id)
+}
+
+func newTestGreenCoffeeHandler(t *testing.T) (*Handler, *fakeGreenCoffeeService) {
+	t.Helper()
+	svc := &fakeGreenCoffeeService{t: t}
+	h := NewHandler(unusedSheetService{}, unusedRoasterService{}, unusedBeanService{}, unusedShotService{}, unusedCuppingService{}, unusedRoastBatchService{}, svc, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil)
+	return h, svc
+}
+
+func testGreenCoffee(id int) *greencoffee.GreenCoffee {
+	arrivalDate := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	return &greencoffee.GreenCoffee{
+		Id: id, Origin: "Ethiopia Guji", Supplier: "Green Traders", PurchaseWeight: 3, Price: 50,
+		ArrivalDate: &arrivalDate, Moisture: 10.5, RemainingWeight: 1.75, CostPerKilogram: 16.67,
+	}
+}
+
+const validGreenCoffeeForm = "origin=Ethiopia+Guji&supplier=Green+Traders&purchase_weight=3&price=50&arrival_date=2026-09-01&moisture=10.5"
+
+func TestCreateGreenCoffee_InsertsRowWithStockAndCost(t *testing.T) {
+	h, svc := newTestGreenCoffeeHandler(t)
+	svc.createGreenCoffee = func(_ context.Context, g *greencoffee.GreenCoffee) (*greencoffee.GreenCoffee, error) {
+		if g.Origin != "Ethiopia Guji" || g.PurchaseWeight != 3 || g.Price != 50 || g.Moisture != 10.5 || g.ArrivalDate == nil {
+			t.Errorf("green coffee = %#v, want the parsed form values", g)
+		}
+		return testGreenCoffee(2), nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.CreateGreenCoffee(rec, newWebRequest(http.MethodPost, "/green_coffees/add", validGreenCoffeeForm, "application/x-www-form-urlencoded", "", true))
+
+	if rec.Code != http.StatusOK || rec.Header().Get("HX-Trigger") != "dialog-close" {
+		t.Fatalf("expected a successful dialog close, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, `hx-swap-oob="beforeend:#green-coffees-tbody"`) || !strings.Contains(body, "<td>16.67</td>") {
+		t.Errorf("expected the new row with its cost per kilogram to be inserted out-of-band, got: %s", body)
+	}
+}
+
+func TestCreateGreenCoffee_InvalidPurchaseWeightIsAFieldError(t *testing.T) {
+	h, _ := newTestGreenCoffeeHandler(t)
+	form := strings.Replace(validGreenCoffeeForm, "purchase_weight=3", "purchase_weight=0", 1)
+
+	rec := httptest.NewRecorder()
+	h.CreateGreenCoffee(rec, newWebRequest(http.MethodPost, "/green_coffees/add", form, "application/x-www-form-urlencoded", "", true))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "Purchase weight must be a positive number.") {
+		t.Errorf("expected a 400 with an inline purchase weight error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestDeleteGreenCoffee_StillReferencedIsAConflict(t *testing.T) {
+	for name, err := range map[string]error{
+		"roasts": errors.ErrRoastBatchForeignKeyConstraint,
+		"beans":  errors.ErrBeansForeignKeyConstraint,
+	} {
+		t.Run(name, func(t *testing.T) {
+			h, svc := newTestGreenCoffeeHandler(t)
+			svc.deleteGreenCoffeeByID = func(context.Context, int) error { return err }
+
+			rec := httptest.NewRecorder()
+			h.DeleteGreenCoffee(rec, newWebRequest(http.MethodDelete, "/green_coffees/delete/2", "", "", "2", true))
+
+			if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "This green coffee is still used by roasts or beans.") {
+				t.Errorf("expected a 409 with the green coffee wording, got %d: %s", rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCreateRoastBatch_LinksGreenCoffeeFromStock(t *testing.T) {
+	svc := &fakeRoastBatchService{t: t}
+	greenCoffees := &fakeGreenCoffeeService{t: t}
+	h := NewHandler(unusedSheetService{}, unusedRoasterService{}, unusedBeanService{}, unusedShotService{}, unusedCuppingService{}, svc, greenCoffees, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil)
+	svc.createRoastBatch = func(context.Context, *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error) {
+		return nil, errors.ErrGreenCoffeeDoesNotExist
+	}
+	greenCoffees.getAllGreenCoffees = func(context.Context) ([]greencoffee.GreenCoffee, error) {
+		return []greencoffee.GreenCoffee{*testGreenCoffee(2)}, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.CreateRoastBatch(rec, newWebRequest(http.MethodPost, "/roasts/add", validRoastBatchForm+"&green_coffee_id=9", "application/x-www-form-urlencoded", "", true))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusNotFound || !strings.Contains(body, "No green coffee found for the given id.") {
+		t.Fatalf("expected a 404 with an inline green coffee error, got %d: %s", rec.Code, body)
+	}
+	if !strings.Contains(body, "Ethiopia Guji (1.75 kg left)") {
+		t.Errorf("expected the form to be redisplayed with the green coffee options, got: %s", body)
+	}
+}
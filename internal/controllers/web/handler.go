@@ -3,24 +3,59 @@
 package web
 
 import (
+	"github.com/lescactus/espressoapi-go/internal/services/attachment"
 	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/cupping"
+	"github.com/lescactus/espressoapi-go/internal/services/greencoffee"
+	"github.com/lescactus/espressoapi-go/internal/services/maintenance"
+	"github.com/lescactus/espressoapi-go/internal/services/report"
+	"github.com/lescactus/espressoapi-go/internal/services/roastbatch"
 	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/session"
+	"github.com/lescactus/espressoapi-go/internal/services/share"
 	"github.com/lescactus/espressoapi-go/internal/services/sheet"
 	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/lescactus/espressoapi-go/internal/services/spreadsheet"
+	"github.com/lescactus/espressoapi-go/internal/services/sso"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
 )
 
 type Handler struct {
-	SheetService   sheet.Service
-	RoasterService roaster.Service
-	BeanService    bean.Service
-	ShotService    shot.Service
+	SheetService       sheet.Service
+	RoasterService     roaster.Service
+	BeanService        bean.Service
+	ShotService        shot.Service
+	CuppingService     cupping.Service
+	RoastBatchService  roastbatch.Service
+	GreenCoffeeService greencoffee.Service
+	ReportService      report.Service
+	StatsService       stats.Service
+	MaintenanceService maintenance.Service
+	ShareLinkService   share.Service
+	AttachmentService  attachment.Service
+	SpreadsheetService spreadsheet.Service
+	SessionService     session.Service
+
+	// SSOService is nil when single sign-on is not configured.
+	SSOService sso.Service
 }
 
-func NewHandler(sheetService sheet.Service, roasterService roaster.Service, beanService bean.Service, shotService shot.Service) *Handler {
+func NewHandler(sheetService sheet.Service, roasterService roaster.Service, beanService bean.Service, shotService shot.Service, cuppingService cupping.Service, roastBatchService roastbatch.Service, greenCoffeeService greencoffee.Service, reportService report.Service, statsService stats.Service, maintenanceService maintenance.Service, shareLinkService share.Service, attachmentService attachment.Service, spreadsheetService spreadsheet.Service, sessionService session.Service, ssoService sso.Service) *Handler {
 	return &Handler{
-		SheetService:   sheetService,
-		RoasterService: roasterService,
-		BeanService:    beanService,
-		ShotService:    shotService,
+		SheetService:       sheetService,
+		RoasterService:     roasterService,
+		BeanService:        beanService,
+		ShotService:        shotService,
+		CuppingService:     cuppingService,
+		RoastBatchService:  roastBatchService,
+		GreenCoffeeService: greenCoffeeService,
+		ReportService:      reportService,
+		StatsService:       statsService,
+		MaintenanceService: maintenanceService,
+		ShareLinkService:   shareLinkService,
+		AttachmentService:  attachmentService,
+		SpreadsheetService: spreadsheetService,
+		SessionService:     sessionService,
+		SSOService:         ssoService,
 	}
 }
@@ -34,16 +34,19 @@ func isFormURLEncoded(r *http.Request) bool {
 // default context; the value is never used to build a template name or
 // selector directly.
 const (
-	viewContextList   = "sheet-list"
-	viewContextDetail = "sheet-detail"
+	viewContextList          = "sheet-list"
+	viewContextDetail        = "sheet-detail"
+	viewContextCuppingDetail = "cupping-detail"
+	viewContextRoastDetail   = "roast-detail"
 )
 
 // viewContext resolves the request's view_context query parameter to one of
 // the closed values above, defaulting to viewContextList (the common case:
 // most resources only ever have a list row, not a detail page).
 func viewContext(r *http.Request) string {
-	if r.URL.Query().Get("view_context") == viewContextDetail {
-		return viewContextDetail
+	switch vc := r.URL.Query().Get("view_context"); vc {
+	case viewContextDetail, viewContextCuppingDetail, viewContextRoastDetail:
+		return vc
 	}
 	return viewContextList
 }
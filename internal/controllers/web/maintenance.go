@@ -0,0 +1,324 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/lescactus/espressoapi-go/internal/services/maintenance"
+	viewmaintenance "github.com/lescactus/espressoapi-go/views/templates/maintenance"
+	"github.com/lescactus/espressoapi-go/views/templates/shared"
+)
+
+const errInvalidMaintenanceTaskID = "The maintenance task id must be a positive number."
+
+// maintenanceAlerts words the home page banner's line for each due task,
+// such as "Backflush (Linea Mini): overdue by 14 shots.".
+func maintenanceAlerts(tasks []maintenance.MaintenanceTask) []string {
+	alerts := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		alerts = append(alerts, viewmaintenance.Title(task)+": "+strings.ToLower(viewmaintenance.Status(task))+".")
+	}
+	return alerts
+}
+
+// ListMaintenanceTasks handles GET /maintenance.
+func (h *Handler) ListMaintenanceTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := h.MaintenanceService.GetAllMaintenanceTasks(r.Context())
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	if isHXRequest(r) {
+		_ = viewmaintenance.Table(tasks).Render(r.Context(), w)
+		return
+	}
+	_ = viewmaintenance.Page(tasks, nil).Render(r.Context(), w)
+}
+
+// renderMaintenancePage renders the full maintenance tasks list page with
+// form pre-opened in the dialog, for the full-page fallback of a direct GET
+// to an add/edit dialog route.
+func (h *Handler) renderMaintenancePage(w http.ResponseWriter, r *http.Request, form templ.Component) {
+	tasks, err := h.MaintenanceService.GetAllMaintenanceTasks(r.Context())
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewmaintenance.Page(tasks, form).Render(r.Context(), w)
+}
+
+// AddMaintenanceTaskForm handles GET /maintenance/add.
+func (h *Handler) AddMaintenanceTaskForm(w http.ResponseWriter, r *http.Request) {
+	form := viewmaintenance.Form(viewmaintenance.FormState{}, true, "", "")
+	if !isHXRequest(r) {
+		h.renderMaintenancePage(w, r, form)
+		return
+	}
+	writeHTMLStatus(w, http.StatusOK)
+	_ = form.Render(r.Context(), w)
+}
+
+// parseOptionalInterval parses one of the maintenance form's optional
+// intervals, where "" means none.
+func parseOptionalInterval(raw string) (*int, bool) {
+	if raw == "" {
+		return nil, true
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v <= 0 {
+		return nil, false
+	}
+	return &v, true
+}
+
+// parseMaintenanceTaskForm extracts and validates maintenance task form
+// fields, returning the raw FormState (for redisplay) and, on success, the
+// parsed service model. The last done date is a day, read as midnight UTC.
+func parseMaintenanceTaskForm(r *http.Request, id int) (viewmaintenance.FormState, *maintenance.MaintenanceTask, bool) {
+	state := viewmaintenance.FormState{
+		ID:            id,
+		Type:          strings.TrimSpace(r.PostFormValue("type")),
+		Equipment:     strings.TrimSpace(r.PostFormValue("equipment")),
+		EquipmentName: strings.TrimSpace(r.PostFormValue("equipment_name")),
+		IntervalShots: strings.TrimSpace(r.PostFormValue("interval_shots")),
+		IntervalDays:  strings.TrimSpace(r.PostFormValue("interval_days")),
+		LastDoneAt:    strings.TrimSpace(r.PostFormValue("last_done_at")),
+		Errors:        map[string]string{},
+	}
+
+	if !maintenance.TaskType(state.Type).IsValid() {
+		state.Errors["type"] = "Pick a task from the list."
+	}
+
+	if !maintenance.Equipment(state.Equipment).IsValid() {
+		state.Errors["equipment"] = "Equipment must be a machine, a grinder or none."
+	}
+
+	if len(state.EquipmentName) > 255 {
+		state.Errors["equipment_name"] = "Equipment name must be 255 characters or fewer."
+	}
+
+	intervalShots, ok := parseOptionalInterval(state.IntervalShots)
+	if !ok {
+		state.Errors["interval_shots"] = "Shots must be a positive whole number."
+	}
+	intervalDays, ok := parseOptionalInterval(state.IntervalDays)
+	if !ok {
+		state.Errors["interval_days"] = "Days must be a positive whole number."
+	}
+	if state.IntervalShots == "" && state.IntervalDays == "" {
+		state.Errors["interval_shots"] = "Give a positive number of shots, of days or both."
+	}
+
+	var lastDoneAt *time.Time
+	if state.LastDoneAt != "" {
+		parsed, err := time.Parse("2006-01-02", state.LastDoneAt)
+		if err != nil {
+			state.Errors["last_done_at"] = "Last done date must be a valid date."
+		} else {
+			lastDoneAt = &parsed
+		}
+	}
+
+	if len(state.Errors) > 0 {
+		return state, nil, false
+	}
+
+	return state, &maintenance.MaintenanceTask{
+		Id:            id,
+		Type:          maintenance.TaskType(state.Type),
+		Equipment:     maintenance.Equipment(state.Equipment),
+		EquipmentName: state.EquipmentName,
+		IntervalShots: intervalShots,
+		IntervalDays:  intervalDays,
+		LastDoneAt:    lastDoneAt,
+	}, true
+}
+
+// CreateMaintenanceTask handles POST /maintenance/add.
+func (h *Handler) CreateMaintenanceTask(w http.ResponseWriter, r *http.Request) {
+	if !isFormURLEncoded(r) {
+		h.renderMaintenanceTaskFormError(w, r, viewmaintenance.FormState{}, true, http.StatusUnsupportedMediaType)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		status, message := parseFormError(err)
+		h.renderMaintenanceTaskFormError(w, r, viewmaintenance.FormState{FormError: message}, true, status)
+		return
+	}
+
+	state, model, ok := parseMaintenanceTaskForm(r, 0)
+	if !ok {
+		h.renderMaintenanceTaskFormError(w, r, state, true, http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.MaintenanceService.CreateMaintenanceTask(r.Context(), model)
+	if err != nil {
+		we := mapDomainError(err)
+		if field := maintenanceTaskErrorField(err); field != "" {
+			state.Errors[field] = we.Message
+		} else {
+			state.FormError = we.Message
+		}
+		h.renderMaintenanceTaskFormError(w, r, state, true, we.Status)
+		return
+	}
+
+	w.Header().Set("HX-Reswap", "none")
+	w.Header().Set("HX-Trigger", "dialog-close")
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewmaintenance.Row(*created, "insert").Render(r.Context(), w)
+	_ = shared.SuccessAlertOOB("Maintenance task successfully created.").Render(r.Context(), w)
+}
+
+// EditMaintenanceTaskForm handles GET /maintenance/update/:id.
+func (h *Handler) EditMaintenanceTaskForm(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidMaintenanceTaskID})
+		return
+	}
+	task, err := h.MaintenanceService.GetMaintenanceTaskById(r.Context(), id)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+
+	state := viewmaintenance.FormState{
+		ID:            task.Id,
+		Type:          string(task.Type),
+		Equipment:     string(task.Equipment),
+		EquipmentName: task.EquipmentName,
+	}
+	if task.IntervalShots != nil {
+		state.IntervalShots = strconv.Itoa(*task.IntervalShots)
+	}
+	if task.IntervalDays != nil {
+		state.IntervalDays = strconv.Itoa(*task.IntervalDays)
+	}
+	if task.LastDoneAt != nil {
+		state.LastDoneAt = task.LastDoneAt.UTC().Format("2006-01-02")
+	}
+	form := viewmaintenance.Form(state, false, shared.FormatTimestamp(task.CreatedAt), shared.FormatTimestamp(task.UpdatedAt))
+
+	if !isHXRequest(r) {
+		h.renderMaintenancePage(w, r, form)
+		return
+	}
+	writeHTMLStatus(w, http.StatusOK)
+	_ = form.Render(r.Context(), w)
+}
+
+// UpdateMaintenanceTask handles PUT /maintenance/update/:id. The form only
+// holds the day a task was last done, so when that day is unchanged the
+// recorded time is kept and shots pulled earlier that day are not counted
+// again.
+func (h *Handler) UpdateMaintenanceTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		writeHTMLStatus(w, http.StatusBadRequest)
+		w.Header().Set("HX-Reswap", "none")
+		_ = shared.ErrorAlertOOB(errInvalidMaintenanceTaskID).Render(r.Context(), w)
+		return
+	}
+
+	if !isFormURLEncoded(r) {
+		h.renderMaintenanceTaskFormError(w, r, viewmaintenance.FormState{ID: id}, false, http.StatusUnsupportedMediaType)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		status, message := parseFormError(err)
+		h.renderMaintenanceTaskFormError(w, r, viewmaintenance.FormState{ID: id, FormError: message}, false, status)
+		return
+	}
+
+	state, model, ok := parseMaintenanceTaskForm(r, id)
+	if !ok {
+		h.renderMaintenanceTaskFormError(w, r, state, false, http.StatusBadRequest)
+		return
+	}
+
+	if model.LastDoneAt != nil {
+		if current, err := h.MaintenanceService.GetMaintenanceTaskById(r.Context(), id); err == nil &&
+			current.LastDoneAt != nil && current.LastDoneAt.UTC().Format("2006-01-02") == state.LastDoneAt {
+			model.LastDoneAt = current.LastDoneAt
+		}
+	}
+
+	updated, err := h.MaintenanceService.UpdateMaintenanceTaskById(r.Context(), id, model)
+	if err != nil {
+		we := mapDomainError(err)
+		if field := maintenanceTaskErrorField(err); field != "" {
+			state.Errors[field] = we.Message
+		} else {
+			state.FormError = we.Message
+		}
+		h.renderMaintenanceTaskFormError(w, r, state, false, we.Status)
+		return
+	}
+
+	w.Header().Set("HX-Reswap", "none")
+	w.Header().Set("HX-Trigger", "dialog-close")
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewmaintenance.Row(*updated, "replace").Render(r.Context(), w)
+	_ = shared.SuccessAlertOOB("Maintenance task successfully updated.").Render(r.Context(), w)
+}
+
+func (h *Handler) renderMaintenanceTaskFormError(w http.ResponseWriter, r *http.Request, state viewmaintenance.FormState, isAdd bool, status int) {
+	writeHTMLStatus(w, status)
+	_ = viewmaintenance.Form(state, isAdd, "", "").Render(r.Context(), w)
+}
+
+// CompleteMaintenanceTask handles POST /maintenance/done/:id, recording the
+// task as done now and swapping in its reset row.
+func (h *Handler) CompleteMaintenanceTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		w.Header().Set("HX-Reswap", "none")
+		writeHTMLStatus(w, http.StatusBadRequest)
+		_ = shared.ErrorAlertOOB(errInvalidMaintenanceTaskID).Render(r.Context(), w)
+		return
+	}
+
+	task, err := h.MaintenanceService.CompleteMaintenanceTaskById(r.Context(), id)
+	if err != nil {
+		we := mapDomainError(err)
+		w.Header().Set("HX-Reswap", "none")
+		writeHTMLStatus(w, we.Status)
+		_ = shared.ErrorAlertOOB(we.Message).Render(r.Context(), w)
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewmaintenance.Row(*task, "").Render(r.Context(), w)
+	_ = shared.SuccessAlertOOB(viewmaintenance.Title(*task)+" marked as done.").Render(r.Context(), w)
+}
+
+// DeleteMaintenanceTask handles DELETE /maintenance/delete/:id.
+func (h *Handler) DeleteMaintenanceTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		w.Header().Set("HX-Reswap", "none")
+		writeHTMLStatus(w, http.StatusBadRequest)
+		_ = shared.ErrorAlertOOB(errInvalidMaintenanceTaskID).Render(r.Context(), w)
+		return
+	}
+
+	if err := h.MaintenanceService.DeleteMaintenanceTaskById(r.Context(), id); err != nil {
+		we := mapDomainError(err)
+		w.Header().Set("HX-Reswap", "none")
+		writeHTMLStatus(w, we.Status)
+		_ = shared.ErrorAlertOOB(we.Message).Render(r.Context(), w)
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = shared.SuccessAlertOOB("Maintenance task successfully deleted.").Render(r.Context(), w)
+}
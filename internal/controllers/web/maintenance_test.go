@@ -0,0 +1,216 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/maintenance"
+	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+)
+
+// fakeMaintenanceService overrides the unusedMaintenanceService methods
+// exercised by the maintenance routes and the home page banner.
+type fakeMaintenanceService struct {
+	unusedMaintenanceService
+	t                           *testing.T
+	createMaintenanceTask       func(context.Context, *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error)
+	getMaintenanceTaskByID      func(context.Context, int) (*maintenance.MaintenanceTask, error)
+	getDueMaintenanceTasks      func(context.Context) ([]maintenance.MaintenanceTask, error)
+	updateMaintenanceTaskByID   func(context.Context, int, *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error)
+	completeMaintenanceTaskByID func(context.Context, int) (*maintenance.MaintenanceTask, error)
+}
+
+func (f *fakeMaintenanceService) CreateMaintenanceTask(ctx context.Context, value *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+	if f.createMaintenanceTask == nil {
+		f.t.Fatalf("unexpected CreateMaintenanceTask call")
+	}
+	return f.createMaintenanceTask(ctx, value)
+}
+
+func (f *fakeMaintenanceService) GetMaintenanceTaskById(ctx context.Context, id int) (*maintenance.MaintenanceTask, error) {
+	if f.getMaintenanceTaskByID == nil {
+		f.t.Fatalf("unexpected GetMaintenanceTaskById call")
+	}
+	return f.getMaintenanceTaskByID(ctx, id)
+}
+
+func (f *fakeMaintenanceService) GetDueMaintenanceTasks(ctx context.Context) ([]maintenance.MaintenanceTask, error) {
+	if f.getDueMaintenanceTasks == nil {
+		f.t.Fatalf("unexpected GetDueMaintenanceTasks call")
+	}
+	return f.getDueMaintenanceTasks(ctx)
+}
+
+func (f *fakeMaintenanceService) UpdateMaintenanceTaskById(ctx context.Context, id int, value *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+	if f.updateMaintenanceTaskByID == nil {
+		f.t.Fatalf("unexpected UpdateMaintenanceTaskById call")
+	}
+	return f.updateMaintenanceTaskByID(ctx, id, value)
+}
+
+func (f *fakeMaintenanceService) CompleteMaintenanceTaskById(ctx context.Context, id int) (*maintenance.MaintenanceTask, error) {
+	if f.completeMaintenanceTaskByID == nil {
+		f.t.Fatalf("unexpected CompleteMaintenanceTaskById call")
+	}
+	return f.completeMaintenanceTaskByID(ctx, id)
+}
+
+func newTestMaintenanceHandler(t *testing.T, sheets *fakeSheetService) (*Handler, *fakeMaintenanceService) {
+	t.Helper()
+	svc := &fakeMaintenanceService{t: t}
+	h := NewHandler(sheets, unusedRoasterService{}, unusedBeanService{}, unusedShotService{}, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, svc, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil)
+	return h, svc
+}
+
+func testMaintenanceTask(id int) *maintenance.MaintenanceTask {
+	lastDoneAt := time.Date(2026, 10, 1, 8, 30, 0, 0, time.UTC)
+	intervalShots, shotsRemaining := 200, -14
+	return &maintenance.MaintenanceTask{
+		Id: id, Type: maintenance.TaskTypeBackflush, Equipment: maintenance.EquipmentMachine, EquipmentName: "Linea Mini",
+		IntervalShots: &intervalShots, LastDoneAt: &lastDoneAt, ShotsSinceDone: 214, ShotsRemaining: &shotsRemaining, Due: true,
+	}
+}
+
+const validMaintenanceTaskForm = "type=backflush&equipment=machine&equipment_name=Linea+Mini&interval_shots=200&interval_days=&last_done_at=2026-10-01"
+
+func TestHome_ShowsOverdueMaintenanceBanner(t *testing.T) {
+	sheets := &fakeSheetService{t: t}
+	sheets.getAllSheets = func(context.Context) ([]sheet.Sheet, error) {
+		return []sheet.Sheet{*testSheet(1, "Double shot")}, nil
+	}
+	h, svc := newTestMaintenanceHandler(t, sheets)
+	svc.getDueMaintenanceTasks = func(context.Context) ([]maintenance.MaintenanceTask, error) {
+		return []maintenance.MaintenanceTask{*testMaintenanceTask(1)}, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.Home(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, "Backflush (Linea Mini): overdue by 14 shots.") || !strings.Contains(body, "Double shot") {
+		t.Errorf("expected the sheets below an overdue maintenance banner, got %d: %s", rec.Code, body)
+	}
+}
+
+func TestHome_MaintenanceErrorOnlyHidesBanner(t *testing.T) {
+	sheets := &fakeSheetService{t: t}
+	sheets.getAllSheets = func(context.Context) ([]sheet.Sheet, error) {
+		return []sheet.Sheet{*testSheet(1, "Double shot")}, nil
+	}
+	h, svc := newTestMaintenanceHandler(t, sheets)
+	svc.getDueMaintenanceTasks = func(context.Context) ([]maintenance.MaintenanceTask, error) {
+		return nil, fmt.Errorf("connection refused")
+	}
+
+	rec := httptest.NewRecorder()
+	h.Home(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || strings.Contains(body, `class="alert-warning"`) || !strings.Contains(body, "Double shot") {
+		t.Errorf("expected the sheets without a banner, got %d: %s", rec.Code, body)
+	}
+}
+
+func TestCreateMaintenanceTask_InsertsRow(t *testing.T) {
+	h, svc := newTestMaintenanceHandler(t, &fakeSheetService{t: t})
+	svc.createMaintenanceTask = func(_ context.Context, task *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+		if task.Type != maintenance.TaskTypeBackflush || task.Equipment != maintenance.EquipmentMachine ||
+			task.IntervalShots == nil || *task.IntervalShots != 200 || task.IntervalDays != nil || task.LastDoneAt == nil {
+			t.Errorf("maintenance task = %#v, want the parsed form values", task)
+		}
+		return testMaintenanceTask(2), nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.CreateMaintenanceTask(rec, newWebRequest(http.MethodPost, "/maintenance/add", validMaintenanceTaskForm, formURLEncoded, "", true))
+
+	if rec.Code != http.StatusOK || rec.Header().Get("HX-Trigger") != "dialog-close" {
+		t.Fatalf("expected a successful dialog close, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if body := rec.Body.String(); !strings.Contains(body, `hx-swap-oob="beforeend:#maintenance-tasks-tbody"`) || !strings.Contains(body, "Overdue by 14 shots") {
+		t.Errorf("expected the new row with its status to be inserted out-of-band, got: %s", body)
+	}
+}
+
+func TestCreateMaintenanceTask_WithoutIntervalIsAFieldError(t *testing.T) {
+	h, _ := newTestMaintenanceHandler(t, &fakeSheetService{t: t})
+	form := strings.Replace(validMaintenanceTaskForm, "interval_shots=200", "interval_shots=", 1)
+
+	rec := httptest.NewRecorder()
+	h.CreateMaintenanceTask(rec, newWebRequest(http.MethodPost, "/maintenance/add", form, formURLEncoded, "", true))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "Give a positive number of shots, of days or both.") {
+		t.Errorf("expected a 400 with an inline interval error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCreateMaintenanceTask_FutureLastDoneIsAFieldError(t *testing.T) {
+	h, svc := newTestMaintenanceHandler(t, &fakeSheetService{t: t})
+	svc.createMaintenanceTask = func(context.Context, *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+		return nil, errors.ErrMaintenanceTaskLastDoneIsInFuture
+	}
+
+	rec := httptest.NewRecorder()
+	h.CreateMaintenanceTask(rec, newWebRequest(http.MethodPost, "/maintenance/add", validMaintenanceTaskForm, formURLEncoded, "", true))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "Last done date must not be in the future.") {
+		t.Errorf("expected a 400 with an inline last done error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestUpdateMaintenanceTask_KeepsLastDoneTimeOnSameDay(t *testing.T) {
+	h, svc := newTestMaintenanceHandler(t, &fakeSheetService{t: t})
+	current := testMaintenanceTask(2)
+	svc.getMaintenanceTaskByID = func(context.Context, int) (*maintenance.MaintenanceTask, error) { return current, nil }
+	svc.updateMaintenanceTaskByID = func(_ context.Context, _ int, task *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+		if task.LastDoneAt == nil || !task.LastDoneAt.Equal(*current.LastDoneAt) {
+			t.Errorf("last done at = %v, want the recorded %v", task.LastDoneAt, current.LastDoneAt)
+		}
+		return current, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.UpdateMaintenanceTask(rec, newWebRequest(http.MethodPut, "/maintenance/update/2", validMaintenanceTaskForm, formURLEncoded, "2", true))
+
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `hx-swap-oob="true"`) {
+		t.Errorf("expected the row to be replaced out-of-band, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCompleteMaintenanceTask_SwapsResetRow(t *testing.T) {
+	h, svc := newTestMaintenanceHandler(t, &fakeSheetService{t: t})
+	svc.completeMaintenanceTaskByID = func(_ context.Context, id int) (*maintenance.MaintenanceTask, error) {
+		task := testMaintenanceTask(id)
+		remaining := 200
+		task.ShotsSinceDone, task.ShotsRemaining, task.Due = 0, &remaining, false
+		return task, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.CompleteMaintenanceTask(rec, newWebRequest(http.MethodPost, "/maintenance/done/2", "", "", "2", true))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, "Due in 200 shots") || !strings.Contains(body, "Backflush (Linea Mini) marked as done.") {
+		t.Errorf("expected the reset row and a success alert, got %d: %s", rec.Code, body)
+	}
+}
+
+func TestCompleteMaintenanceTask_MissingIsNotFound(t *testing.T) {
+	h, svc := newTestMaintenanceHandler(t, &fakeSheetService{t: t})
+	svc.completeMaintenanceTaskByID = func(context.Context, int) (*maintenance.MaintenanceTask, error) {
+		return nil, errors.ErrMaintenanceTaskDoesNotExist
+	}
+
+	rec := httptest.NewRecorder()
+	h.CompleteMaintenanceTask(rec, newWebRequest(http.MethodPost, "/maintenance/done/9", "", "", "9", true))
+
+	if rec.Code != http.StatusNotFound || rec.Header().Get("HX-Reswap") != "none" || !strings.Contains(rec.Body.String(), "No maintenance task found for the given id.") {
+		t.Errorf("expected a 404 error alert, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
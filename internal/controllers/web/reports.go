@@ -0,0 +1,61 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/services/report"
+	viewreports "github.com/lescactus/espressoapi-go/views/templates/reports"
+)
+
+// parseReportDate parses a report's optional from/to date filter.
+func parseReportDate(raw string) (*time.Time, bool) {
+	if raw == "" {
+		return nil, true
+	}
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return nil, false
+	}
+	return &t, true
+}
+
+// SpendReport handles GET /reports. An invalid filter redisplays the page
+// with the filter as typed and a 400 status.
+func (h *Handler) SpendReport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := viewreports.Filter{
+		From:    strings.TrimSpace(query.Get("from")),
+		To:      strings.TrimSpace(query.Get("to")),
+		GroupBy: strings.TrimSpace(query.Get("group_by")),
+	}
+	if filter.GroupBy == "" {
+		filter.GroupBy = string(report.GroupByMonth)
+	}
+
+	from, fromOk := parseReportDate(filter.From)
+	to, toOk := parseReportDate(filter.To)
+	if !fromOk || !toOk {
+		filter.Error = "Dates must be valid, as YYYY-MM-DD."
+		writeHTMLStatus(w, http.StatusBadRequest)
+		_ = viewreports.Page(filter, nil).Render(r.Context(), w)
+		return
+	}
+
+	spend, err := h.ReportService.GetSpendReport(r.Context(), from, to, report.GroupBy(filter.GroupBy))
+	if err != nil {
+		we := mapDomainError(err)
+		if we.Status != http.StatusBadRequest {
+			h.writeFullPageError(w, r, we)
+			return
+		}
+		filter.Error = we.Message
+		writeHTMLStatus(w, we.Status)
+		_ = viewreports.Page(filter, nil).Render(r.Context(), w)
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewreports.Page(filter, spend).Render(r.Context(), w)
+}
@@ -0,0 +1,87 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/report"
+)
+
+// fakeReportService overrides the unusedReportService method exercised by
+// the reports page.
+type fakeReportService struct {
+	unusedReportService
+	t              *testing.T
+	getSpendReport func(context.Context, *time.Time, *time.Time, report.GroupBy) (*report.SpendReport, error)
+}
+
+func (f *fakeReportService) GetSpendReport(ctx context.Context, from, to *time.Time, groupBy report.GroupBy) (*report.SpendReport, error) {
+	if f.getSpendReport == nil {
+		f.t.Fatalf("unexpected GetSpendReport call")
+	}
+	return f.getSpendReport(ctx, from, to, groupBy)
+}
+
+func newTestReportHandler(t *testing.T) (*Handler, *fakeReportService) {
+	t.Helper()
+	svc := &fakeReportService{t: t}
+	h := NewHandler(unusedSheetService{}, unusedRoasterService{}, unusedBeanService{}, unusedShotService{}, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, svc, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil)
+	return h, svc
+}
+
+func TestSpendReport_RendersChartAndTable(t *testing.T) {
+	h, svc := newTestReportHandler(t)
+	svc.getSpendReport = func(_ context.Context, from, to *time.Time, groupBy report.GroupBy) (*report.SpendReport, error) {
+		if from == nil || from.Format("2006-01-02") != "2026-09-01" || to != nil || groupBy != report.GroupByBeans {
+			t.Errorf("GetSpendReport(%v, %v, %q), want the parsed filter", from, to, groupBy)
+		}
+		return &report.SpendReport{
+			GroupBy: report.GroupByBeans,
+			Groups:  []report.SpendGroup{{Key: "1", Label: "Guji (Alpha)", SpendTotal: report.SpendTotal{Currency: "EUR", Shots: 2, CoffeeWeight: 36, Spend: 2.16}}},
+			Totals:  []report.SpendTotal{{Currency: "EUR", Shots: 2, CoffeeWeight: 36, Spend: 2.16}},
+		}, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.SpendReport(rec, httptest.NewRequest(http.MethodGet, "/reports?from=2026-09-01&group_by=beans", nil))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, body)
+	}
+	for _, want := range []string{`id="spend-chart"`, "<td>Guji (Alpha)</td>", "<th>2.16 EUR</th>", `<option value="beans" selected>Beans</option>`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected the page to contain %q, got: %s", want, body)
+		}
+	}
+}
+
+func TestSpendReport_InvalidDateIsABadRequest(t *testing.T) {
+	h, _ := newTestReportHandler(t)
+
+	rec := httptest.NewRecorder()
+	h.SpendReport(rec, httptest.NewRequest(http.MethodGet, "/reports?from=09/01/2026", nil))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "Dates must be valid, as YYYY-MM-DD.") || !strings.Contains(rec.Body.String(), `value="09/01/2026"`) {
+		t.Errorf("expected a 400 redisplaying the filter with its error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestSpendReport_InvalidRangeIsABadRequest(t *testing.T) {
+	h, svc := newTestReportHandler(t)
+	svc.getSpendReport = func(context.Context, *time.Time, *time.Time, report.GroupBy) (*report.SpendReport, error) {
+		return nil, errors.ErrSpendReportRangeIsInvalid
+	}
+
+	rec := httptest.NewRecorder()
+	h.SpendReport(rec, httptest.NewRequest(http.MethodGet, "/reports?from=2026-10-02&to=2026-10-01", nil))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "The from date must not be after the to date.") {
+		t.Errorf("expected a 400 with the range error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
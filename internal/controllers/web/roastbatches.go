@@ -0,0 +1,435 @@
+package web
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/roastbatch"
+	viewroasts "github.com/lescactus/espressoapi-go/views/templates/roasts"
+	"github.com/lescactus/espressoapi-go/views/templates/shared"
+)
+
+const errInvalidRoastBatchID = "The roast id must be a positive number."
+
+// sortRoastBatches sorts batches most recent roast first, the natural
+// reading order of a roasting log.
+func sortRoastBatches(batches []roastbatch.RoastBatch) {
+	sort.SliceStable(batches, func(i, j int) bool {
+		a, b := batches[i], batches[j]
+		if timeLess(b.RoastDate, a.RoastDate) {
+			return true
+		}
+		if timeLess(a.RoastDate, b.RoastDate) {
+			return false
+		}
+		return a.Id > b.Id
+	})
+}
+
+// ListRoastBatches handles GET /roasts.
+func (h *Handler) ListRoastBatches(w http.ResponseWriter, r *http.Request) {
+	batches, err := h.RoastBatchService.GetAllRoastBatches(r.Context())
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+	sortRoastBatches(batches)
+
+	writeHTMLStatus(w, http.StatusOK)
+	if isHXRequest(r) {
+		_ = viewroasts.Table(batches).Render(r.Context(), w)
+		return
+	}
+	_ = viewroasts.Page(batches, nil).Render(r.Context(), w)
+}
+
+// renderRoastBatchesPage renders the full batches list page with form
+// pre-opened in the dialog, for the full-page fallback of a direct GET to
+// an add/edit dialog route.
+func (h *Handler) renderRoastBatchesPage(w http.ResponseWriter, r *http.Request, form templ.Component) {
+	batches, err := h.RoastBatchService.GetAllRoastBatches(r.Context())
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+	sortRoastBatches(batches)
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewroasts.Page(batches, form).Render(r.Context(), w)
+}
+
+// AddRoastBatchForm handles GET /roasts/add.
+func (h *Handler) AddRoastBatchForm(w http.ResponseWriter, r *http.Request) {
+	greenCoffees, err := h.greenCoffeeOptions(r)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+	form := viewroasts.Form(viewroasts.FormState{}, greenCoffees, true, "", "")
+	if !isHXRequest(r) {
+		h.renderRoastBatchesPage(w, r, form)
+		return
+	}
+	writeHTMLStatus(w, http.StatusOK)
+	_ = form.Render(r.Context(), w)
+}
+
+// parseRoastCurve parses the curve textarea, one "time,temperature" reading
+// per line. Blank lines are ignored.
+func parseRoastCurve(raw string) ([]roastbatch.CurvePoint, bool) {
+	var points []roastbatch.CurvePoint
+	for line := range strings.Lines(raw) {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		rawTime, rawTemp, found := strings.Cut(line, ",")
+		if !found {
+			return nil, false
+		}
+		elapsed, err := strconv.Atoi(strings.TrimSpace(rawTime))
+		if err != nil || elapsed < 0 {
+			return nil, false
+		}
+		temp, err := strconv.ParseFloat(strings.TrimSpace(rawTemp), 64)
+		if err != nil || math.IsNaN(temp) || math.IsInf(temp, 0) {
+			return nil, false
+		}
+		points = append(points, roastbatch.CurvePoint{Time: elapsed, Temperature: temp})
+	}
+	return points, true
+}
+
+// parseRoastBatchForm extracts and validates roast batch form fields,
+// returning the raw FormState (for redisplay) and, on success, the parsed
+// service model.
+func parseRoastBatchForm(r *http.Request, id int) (viewroasts.FormState, *roastbatch.RoastBatch, bool) {
+	state := viewroasts.FormState{
+		ID:                id,
+		GreenCoffee:       strings.TrimSpace(r.PostFormValue("green_coffee")),
+		GreenCoffeeID:     strings.TrimSpace(r.PostFormValue("green_coffee_id")),
+		RoastDate:         strings.TrimSpace(r.PostFormValue("roast_date")),
+		RoastLevel:        strings.TrimSpace(r.PostFormValue("roast_level")),
+		GreenWeight:       strings.TrimSpace(r.PostFormValue("green_weight")),
+		RoastedWeight:     strings.TrimSpace(r.PostFormValue("roasted_weight")),
+		ChargeTemperature: strings.TrimSpace(r.PostFormValue("charge_temperature")),
+		FirstCrackTime:    strings.TrimSpace(r.PostFormValue("first_crack_time")),
+		DevelopmentTime:   strings.TrimSpace(r.PostFormValue("development_time")),
+		EndTemperature:    strings.TrimSpace(r.PostFormValue("end_temperature")),
+		Curve:             r.PostFormValue("curve"),
+		Errors:            map[string]string{},
+	}
+
+	if state.GreenCoffee == "" {
+		state.Errors["green_coffee"] = "Green coffee must not be empty."
+	} else if len(state.GreenCoffee) > 255 {
+		state.Errors["green_coffee"] = "Green coffee must be 255 characters or fewer."
+	}
+
+	var roastDate time.Time
+	if state.RoastDate == "" {
+		state.Errors["roast_date"] = "Roast date must not be empty."
+	} else if parsed, err := time.Parse("2006-01-02", state.RoastDate); err != nil {
+		state.Errors["roast_date"] = "Roast date must be a valid date."
+	} else {
+		roastDate = parsed
+	}
+
+	roastLevel := 0
+	if state.RoastLevel == "" {
+		state.Errors["roast_level"] = "Select a roast level."
+	} else if n, err := strconv.Atoi(state.RoastLevel); err != nil || n < int(sql.RoastLevelLight) || n > int(sql.RoastLevelDark) {
+		state.Errors["roast_level"] = "Invalid roast level."
+	} else {
+		roastLevel = n
+	}
+
+	greenWeight, err := strconv.ParseFloat(state.GreenWeight, 64)
+	if err != nil || math.IsNaN(greenWeight) || math.IsInf(greenWeight, 0) || greenWeight <= 0 {
+		state.Errors["green_weight"] = "Green weight must be a positive number."
+	}
+
+	roastedWeight, err := strconv.ParseFloat(state.RoastedWeight, 64)
+	if err != nil || math.IsNaN(roastedWeight) || math.IsInf(roastedWeight, 0) || roastedWeight < 0 {
+		state.Errors["roasted_weight"] = "Roasted weight must be a non-negative number."
+	}
+
+	var chargeTemperature float64
+	if state.ChargeTemperature != "" {
+		chargeTemperature, err = strconv.ParseFloat(state.ChargeTemperature, 64)
+		if err != nil || math.IsNaN(chargeTemperature) || math.IsInf(chargeTemperature, 0) {
+			state.Errors["charge_temperature"] = "Charge temperature must be a number."
+		}
+	}
+
+	var endTemperature float64
+	if state.EndTemperature != "" {
+		endTemperature, err = strconv.ParseFloat(state.EndTemperature, 64)
+		if err != nil || math.IsNaN(endTemperature) || math.IsInf(endTemperature, 0) {
+			state.Errors["end_temperature"] = "End temperature must be a number."
+		}
+	}
+
+	var firstCrackTime int
+	if state.FirstCrackTime != "" {
+		firstCrackTime, err = strconv.Atoi(state.FirstCrackTime)
+		if err != nil || firstCrackTime < 0 {
+			state.Errors["first_crack_time"] = "First crack time must be a non-negative whole number of seconds."
+		}
+	}
+
+	var developmentTime int
+	if state.DevelopmentTime != "" {
+		developmentTime, err = strconv.Atoi(state.DevelopmentTime)
+		if err != nil || developmentTime < 0 {
+			state.Errors["development_time"] = "Development time must be a non-negative whole number of seconds."
+		}
+	}
+
+	greenCoffeeID, ok := parseOptionalGreenCoffeeID(state.GreenCoffeeID)
+	if !ok {
+		state.Errors["green_coffee_id"] = "Invalid green coffee."
+	}
+
+	curve, ok := parseRoastCurve(state.Curve)
+	if !ok {
+		state.Errors["curve"] = `Each curve line must be "seconds,temperature", e.g. 60,110.`
+	}
+
+	if len(state.Errors) > 0 {
+		return state, nil, false
+	}
+
+	return state, &roastbatch.RoastBatch{
+		Id:                id,
+		GreenCoffee:       state.GreenCoffee,
+		RoastDate:         &roastDate,
+		RoastLevel:        sql.RoastLevel(roastLevel),
+		GreenWeight:       greenWeight,
+		RoastedWeight:     roastedWeight,
+		ChargeTemperature: chargeTemperature,
+		FirstCrackTime:    firstCrackTime,
+		DevelopmentTime:   developmentTime,
+		EndTemperature:    endTemperature,
+		CurvePoints:       curve,
+		GreenCoffeeId:     greenCoffeeID,
+	}, true
+}
+
+// CreateRoastBatch handles POST /roasts/add.
+func (h *Handler) CreateRoastBatch(w http.ResponseWriter, r *http.Request) {
+	if !isFormURLEncoded(r) {
+		h.renderRoastBatchFormError(w, r, viewroasts.FormState{}, true, http.StatusUnsupportedMediaType)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		status, message := parseFormError(err)
+		h.renderRoastBatchFormError(w, r, viewroasts.FormState{FormError: message}, true, status)
+		return
+	}
+
+	state, model, ok := parseRoastBatchForm(r, 0)
+	if !ok {
+		h.renderRoastBatchFormError(w, r, state, true, http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.RoastBatchService.CreateRoastBatch(r.Context(), model)
+	if err != nil {
+		we := mapDomainError(err)
+		if field := roastBatchErrorField(err); field != "" {
+			state.Errors[field] = we.Message
+		} else {
+			state.FormError = we.Message
+		}
+		h.renderRoastBatchFormError(w, r, state, true, we.Status)
+		return
+	}
+
+	w.Header().Set("HX-Reswap", "none")
+	w.Header().Set("HX-Trigger", "dialog-close")
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewroasts.Row(*created, "insert").Render(r.Context(), w)
+	_ = shared.SuccessAlertOOB("Roast successfully created.").Render(r.Context(), w)
+}
+
+// GetRoastBatch handles GET /roasts/get/:id: the batch detail page with its
+// roast curve for direct navigation, or the list row for htmx.
+func (h *Handler) GetRoastBatch(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidRoastBatchID})
+		return
+	}
+	b, err := h.RoastBatchService.GetRoastBatchById(r.Context(), id)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	if !isHXRequest(r) {
+		_ = viewroasts.Detail(*b).Render(r.Context(), w)
+		return
+	}
+	_ = viewroasts.Row(*b, "").Render(r.Context(), w)
+}
+
+// EditRoastBatchForm handles GET /roasts/update/:id.
+func (h *Handler) EditRoastBatchForm(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidRoastBatchID})
+		return
+	}
+	b, err := h.RoastBatchService.GetRoastBatchById(r.Context(), id)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+	greenCoffees, err := h.greenCoffeeOptions(r)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+
+	state := viewroasts.FormState{
+		ID:                b.Id,
+		GreenCoffee:       b.GreenCoffee,
+		RoastLevel:        strconv.Itoa(int(b.RoastLevel)),
+		GreenWeight:       strconv.FormatFloat(b.GreenWeight, 'f', -1, 64),
+		RoastedWeight:     strconv.FormatFloat(b.RoastedWeight, 'f', -1, 64),
+		ChargeTemperature: strconv.FormatFloat(b.ChargeTemperature, 'f', -1, 64),
+		FirstCrackTime:    strconv.Itoa(b.FirstCrackTime),
+		DevelopmentTime:   strconv.Itoa(b.DevelopmentTime),
+		EndTemperature:    strconv.FormatFloat(b.EndTemperature, 'f', -1, 64),
+		Curve:             viewroasts.FormatCurve(b.CurvePoints),
+	}
+	if b.GreenCoffeeId != nil {
+		state.GreenCoffeeID = strconv.Itoa(*b.GreenCoffeeId)
+	}
+	if b.RoastDate != nil {
+		state.RoastDate = b.RoastDate.UTC().Format("2006-01-02")
+	}
+	form := viewroasts.Form(state, greenCoffees, false, shared.FormatTimestamp(b.CreatedAt), shared.FormatTimestamp(b.UpdatedAt))
+
+	if !isHXRequest(r) {
+		h.renderRoastBatchesPage(w, r, form)
+		return
+	}
+	writeHTMLStatus(w, http.StatusOK)
+	_ = form.Render(r.Context(), w)
+}
+
+// UpdateRoastBatch handles PUT /roasts/update/:id.
+func (h *Handler) UpdateRoastBatch(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		writeHTMLStatus(w, http.StatusBadRequest)
+		w.Header().Set("HX-Reswap", "none")
+		_ = shared.ErrorAlertOOB(errInvalidRoastBatchID).Render(r.Context(), w)
+		return
+	}
+
+	if !isFormURLEncoded(r) {
+		h.renderRoastBatchFormError(w, r, viewroasts.FormState{ID: id}, false, http.StatusUnsupportedMediaType)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		status, message := parseFormError(err)
+		h.renderRoastBatchFormError(w, r, viewroasts.FormState{ID: id, FormError: message}, false, status)
+		return
+	}
+
+	state, model, ok := parseRoastBatchForm(r, id)
+	if !ok {
+		h.renderRoastBatchFormError(w, r, state, false, http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.RoastBatchService.UpdateRoastBatchById(r.Context(), id, model)
+	if err != nil {
+		we := mapDomainError(err)
+		if field := roastBatchErrorField(err); field != "" {
+			state.Errors[field] = we.Message
+		} else {
+			state.FormError = we.Message
+		}
+		h.renderRoastBatchFormError(w, r, state, false, we.Status)
+		return
+	}
+
+	w.Header().Set("HX-Reswap", "none")
+	w.Header().Set("HX-Trigger", "dialog-close")
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewroasts.Row(*updated, "replace").Render(r.Context(), w)
+	_ = shared.SuccessAlertOOB("Roast successfully updated.").Render(r.Context(), w)
+}
+
+func (h *Handler) renderRoastBatchFormError(w http.ResponseWriter, r *http.Request, state viewroasts.FormState, isAdd bool, status int) {
+	greenCoffees, err := h.greenCoffeeOptions(r)
+	if err != nil {
+		greenCoffees = nil
+	}
+	writeHTMLStatus(w, status)
+	_ = viewroasts.Form(state, greenCoffees, isAdd, "", "").Render(r.Context(), w)
+}
+
+// DeleteRoastBatch handles DELETE /roasts/delete/:id. Beans created from
+// the batch are kept.
+func (h *Handler) DeleteRoastBatch(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		w.Header().Set("HX-Reswap", "none")
+		writeHTMLStatus(w, http.StatusBadRequest)
+		_ = shared.ErrorAlertOOB(errInvalidRoastBatchID).Render(r.Context(), w)
+		return
+	}
+
+	if err := h.RoastBatchService.DeleteRoastBatchById(r.Context(), id); err != nil {
+		we := mapDomainError(err)
+		w.Header().Set("HX-Reswap", "none")
+		writeHTMLStatus(w, we.Status)
+		_ = shared.ErrorAlertOOB(we.Message).Render(r.Context(), w)
+		return
+	}
+
+	if viewContext(r) == viewContextRoastDetail {
+		w.Header().Set("HX-Redirect", "/roasts")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = shared.SuccessAlertOOB("Roast successfully deleted.").Render(r.Context(), w)
+}
+
+// CreateBeansFromRoastBatch handles POST /roasts/beans/:id: it creates the
+// batch's beans and swaps the detail page's beans section in place.
+func (h *Handler) CreateBeansFromRoastBatch(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		w.Header().Set("HX-Reswap", "none")
+		writeHTMLStatus(w, http.StatusBadRequest)
+		_ = shared.ErrorAlertOOB(errInvalidRoastBatchID).Render(r.Context(), w)
+		return
+	}
+
+	b, err := h.RoastBatchService.CreateBeansFromRoastBatch(r.Context(), id)
+	if err != nil {
+		we := mapDomainError(err)
+		w.Header().Set("HX-Reswap", "none")
+		writeHTMLStatus(w, we.Status)
+		_ = shared.ErrorAlertOOB(we.Message).Render(r.Context(), w)
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewroasts.BeansSection(*b).Render(r.Context(), w)
+	_ = shared.SuccessAlertOOB("Beans successfully created.").Render(r.Context(), w)
+}
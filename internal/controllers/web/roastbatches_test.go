@@ -0,0 +1,176 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/roastbatch"
+)
+
+// fakeRoastBatchService overrides the unusedRoastBatchService methods
+// exercised by the roast routes.
+type fakeRoastBatchService struct {
+	unusedRoastBatchService
+	t                         *testing.T
+	createRoastBatch          func(context.Context, *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error)
+	getRoastBatchByID         func(context.Context, int) (*roastbatch.RoastBatch, error)
+	getAllRoastBatches        func(context.Context) ([]roastbatch.RoastBatch, error)
+	createBeansFromRoastBatch func(context.Context, int) (*roastbatch.RoastBatch, error)
+}
+
+func (f *fakeRoastBatchService) CreateRoastBatch(ctx context.Context, value *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error) {
+	if f.createRoastBatch == nil {
+		f.t.Fatalf("unexpected CreateRoastBatch call")
+	}
+	return f.createRoastBatch(ctx, value)
+}
+
+func (f *fakeRoastBatchService) GetRoastBatchById(ctx context.Context, id int) (*roastbatch.RoastBatch, error) {
+	if f.getRoastBatchByID == nil {
+		f.t.Fatalf("unexpected GetRoastBatchById call")
+	}
+	return f.getRoastBatchByID(ctx, id)
+}
+
+func (f *fakeRoastBatchService) GetAllRoastBatches(ctx context.Context) ([]roastbatch.RoastBatch, error) {
+	if f.getAllRoastBatches == nil {
+		f.t.Fatalf("unexpected GetAllRoastBatches call")
+	}
+	return f.getAllRoastBatches(ctx)
+}
+
+func (f *fakeRoastBatchService) CreateBeansFromRoastBatch(ctx context.Context, id int) (*roastbatch.RoastBatch, error) {
+	if f.createBeansFromRoastBatch == nil {
+		f.t.Fatalf("unexpected CreateBeansFromRoastBatch call")
+	}
+	return f.createBeansFromRoastBatch(ctx, id)
+}
+
+func newTestRoastBatchHandler(t *testing.T) (*Handler, *fakeRoastBatchService) {
+	t.Helper()
+	svc := &fakeRoastBatchService{t: t}
+	h := NewHandler(unusedSheetService{}, unusedRoasterService{}, unusedBeanService{}, unusedShotService{}, unusedCuppingService{}, svc, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil)
+	return h, svc
+}
+
+func testRoastBatch(id int) *roastbatch.RoastBatch {
+	roastDate := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	return &roastbatch.RoastBatch{
+		Id: id, GreenCoffee: "Ethiopia Guji", RoastDate: &roastDate,
+		GreenWeight: 250, RoastedWeight: 215, WeightLoss: 14, FirstCrackTime: 480,
+		CurvePoints: []roastbatch.CurvePoint{{Time: 0, Temperature: 200}, {Time: 60, Temperature: 110}, {Time: 600, Temperature: 205}},
+	}
+}
+
+const validRoastBatchForm = "green_coffee=Ethiopia+Guji&roast_date=2026-03-04&roast_level=0&green_weight=250&roasted_weight=215" +
+	"&charge_temperature=200&first_crack_time=480&development_time=75&end_temperature=205&curve=0%2C200%0D%0A%0D%0A60%2C+110%0A600%2C205"
+
+func TestCreateRoastBatch_ParsesCurve(t *testing.T) {
+	h, svc := newTestRoastBatchHandler(t)
+	svc.createRoastBatch = func(_ context.Context, b *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error) {
+		want := []roastbatch.CurvePoint{{Time: 0, Temperature: 200}, {Time: 60, Temperature: 110}, {Time: 600, Temperature: 205}}
+		if len(b.CurvePoints) != len(want) {
+			t.Fatalf("curve = %#v, want %#v", b.CurvePoints, want)
+		}
+		for i := range want {
+			if b.CurvePoints[i] != want[i] {
+				t.Errorf("curve[%d] = %#v, want %#v", i, b.CurvePoints[i], want[i])
+			}
+		}
+		if b.GreenWeight != 250 || b.DevelopmentTime != 75 {
+			t.Errorf("batch = %#v, want the parsed form values", b)
+		}
+		return testRoastBatch(3), nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.CreateRoastBatch(rec, newWebRequest(http.MethodPost, "/roasts/add", validRoastBatchForm, "application/x-www-form-urlencoded", "", true))
+
+	if rec.Code != http.StatusOK || rec.Header().Get("HX-Trigger") != "dialog-close" {
+		t.Fatalf("expected a successful dialog close, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `hx-swap-oob="beforeend:#roasts-tbody"`) || !strings.Contains(rec.Body.String(), "14.0 %") {
+		t.Errorf("expected the new row with its weight loss to be inserted out-of-band, got: %s", rec.Body.String())
+	}
+}
+
+func TestCreateRoastBatch_MalformedCurveIsAFieldError(t *testing.T) {
+	h, _ := newTestRoastBatchHandler(t)
+	form := strings.Replace(validRoastBatchForm, "curve=0%2C200", "curve=0+200", 1)
+
+	rec := httptest.NewRecorder()
+	h.CreateRoastBatch(rec, newWebRequest(http.MethodPost, "/roasts/add", form, "application/x-www-form-urlencoded", "", true))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "Each curve line must be") {
+		t.Errorf("expected a 400 with an inline curve error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCreateRoastBatch_WeightErrorIsAFieldError(t *testing.T) {
+	h, svc := newTestRoastBatchHandler(t)
+	svc.createRoastBatch = func(context.Context, *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error) {
+		return nil, errors.ErrRoastBatchWeightOutOfRange
+	}
+
+	rec := httptest.NewRecorder()
+	h.CreateRoastBatch(rec, newWebRequest(http.MethodPost, "/roasts/add", validRoastBatchForm, "application/x-www-form-urlencoded", "", true))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "roasted weight at most the green weight") {
+		t.Errorf("expected a 400 with an inline weight error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGetRoastBatch_DetailShowsCurve(t *testing.T) {
+	h, svc := newTestRoastBatchHandler(t)
+	svc.getRoastBatchByID = func(context.Context, int) (*roastbatch.RoastBatch, error) {
+		return testRoastBatch(3), nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.GetRoastBatch(rec, newWebRequest(http.MethodGet, "/roasts/get/3", "", "", "3", false))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, `<svg id="roast-curve"`) || !strings.Contains(body, "<polyline") {
+		t.Fatalf("expected the detail page with its roast curve, got %d: %s", rec.Code, body)
+	}
+	if !strings.Contains(body, `hx-post="/roasts/beans/3"`) {
+		t.Errorf("expected a create beans button, got: %s", body)
+	}
+}
+
+func TestCreateBeansFromRoastBatch_SwapsBeansSection(t *testing.T) {
+	h, svc := newTestRoastBatchHandler(t)
+	svc.createBeansFromRoastBatch = func(context.Context, int) (*roastbatch.RoastBatch, error) {
+		b := testRoastBatch(3)
+		beansID := 9
+		b.BeansId = &beansID
+		return b, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.CreateBeansFromRoastBatch(rec, newWebRequest(http.MethodPost, "/roasts/beans/3", "", "", "3", true))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, `id="roast-beans"`) || !strings.Contains(body, `href="/beans/get/9"`) {
+		t.Errorf("expected the beans section linking to the new beans, got %d: %s", rec.Code, body)
+	}
+}
+
+func TestCreateBeansFromRoastBatch_AlreadyCreated(t *testing.T) {
+	h, svc := newTestRoastBatchHandler(t)
+	svc.createBeansFromRoastBatch = func(context.Context, int) (*roastbatch.RoastBatch, error) {
+		return nil, errors.ErrRoastBatchBeansAlreadyExist
+	}
+
+	rec := httptest.NewRecorder()
+	h.CreateBeansFromRoastBatch(rec, newWebRequest(http.MethodPost, "/roasts/beans/3", "", "", "3", true))
+
+	if rec.Code != http.StatusConflict || rec.Header().Get("HX-Reswap") != "none" {
+		t.Errorf("expected a 409 error alert, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
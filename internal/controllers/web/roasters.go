@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
 	"github.com/lescactus/espressoapi-go/internal/services/roaster"
 	viewroasters "github.com/lescactus/espressoapi-go/views/templates/roasters"
 	"github.com/lescactus/espressoapi-go/views/templates/shared"
@@ -122,11 +123,17 @@ func (h *Handler) GetRoaster(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeHTMLStatus(w, http.StatusOK)
 	if !isHXRequest(r) {
-		_ = viewroasters.RowPage(*roasterVal).Render(r.Context(), w)
+		shotStats, err := h.StatsService.GetShotStats(r.Context(), sql.ShotStatsOfRoaster, id)
+		if err != nil {
+			h.writeGetError(w, r, mapDomainError(err))
+			return
+		}
+		writeHTMLStatus(w, http.StatusOK)
+		_ = viewroasters.RowPage(*roasterVal, *shotStats).Render(r.Context(), w)
 		return
 	}
+	writeHTMLStatus(w, http.StatusOK)
 	_ = viewroasters.Row(*roasterVal).Render(r.Context(), w)
 }
 
@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
 	"github.com/lescactus/espressoapi-go/internal/services/roaster"
 	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
 )
 
 // fakeRoasterService is a hand-rolled fake with func fields, matching the
@@ -67,6 +69,9 @@ func (f *fakeRoasterService) Ping(context.Context) error { return nil }
 // that only exercise roaster routes.
 type unusedSheetService struct{}
 
+func (unusedSheetService) CreateSheet(context.Context, *sheet.Sheet) (*sheet.Sheet, error) {
+	return nil, nil
+}
 func (unusedSheetService) CreateSheetByName(context.Context, string) (*sheet.Sheet, error) {
 	return nil, nil
 }
@@ -76,12 +81,15 @@ func (unusedSheetService) UpdateSheetById(context.Context, int, *sheet.Sheet) (*
 	return nil, nil
 }
 func (unusedSheetService) DeleteSheetById(context.Context, int) error { return nil }
-func (unusedSheetService) Ping(context.Context) error                 { return nil }
+func (unusedSheetService) CloneSheet(context.Context, int, string, bool) (*sheet.Sheet, error) {
+	return nil, nil
+}
+func (unusedSheetService) Ping(context.Context) error { return nil }
 
 func newTestRoasterHandler(t *testing.T) (*Handler, *fakeRoasterService) {
 	t.Helper()
 	svc := &fakeRoasterService{t: t}
-	return NewHandler(unusedSheetService{}, svc, unusedBeanService{}, unusedShotService{}), svc
+	return NewHandler(unusedSheetService{}, svc, unusedBeanService{}, unusedShotService{}, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil), svc
 }
 
 func testRoaster(id int, name string) *roaster.Roaster {
@@ -217,6 +225,24 @@ func TestGetRoaster_FragmentVsOneRowTablePage(t *testing.T) {
 	}
 }
 
+func TestGetRoaster_FullPageShotStatsError(t *testing.T) {
+	h, svc := newTestRoasterHandler(t)
+	svc.getRoasterByID = func(context.Context, int) (*roaster.Roaster, error) { return testRoaster(1, "Blue Bottle"), nil }
+	h.StatsService = &fakeStatsService{t: t, getShotStats: func(_ context.Context, subject sql.ShotStatsSubject, id int) (*stats.ShotStats, error) {
+		if subject != sql.ShotStatsOfRoaster || id != 1 {
+			t.Errorf("GetShotStats(%q, %d), want the stats of roaster 1", subject, id)
+		}
+		return nil, errors.ErrRoasterDoesNotExist
+	}}
+
+	rec := httptest.NewRecorder()
+	h.GetRoaster(rec, newWebRequest(http.MethodGet, "/roasters/get/1", "", "", "1", false))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
 func TestUpdateRoaster_HappyPath(t *testing.T) {
 	h, svc := newTestRoasterHandler(t)
 	svc.updateRoasterByID = func(_ context.Context, id int, r *roaster.Roaster) (*roaster.Roaster, error) {
@@ -0,0 +1,255 @@
+package web
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/session"
+	viewlogin "github.com/lescactus/espressoapi-go/views/templates/login"
+	"github.com/lescactus/espressoapi-go/views/templates/shared"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// sessionCookieName is the cookie holding the session token.
+	sessionCookieName = "espresso_session"
+
+	// csrfFormField is the form field a CSRF token can be posted in, for
+	// the requests not sent by htmx with the shared.CSRFHeader header.
+	csrfFormField = "csrf_token"
+
+	loginPath = "/login"
+
+	// sharePathPrefix starts the paths of the shared sheets, which anyone
+	// with their share link may see without a session.
+	sharePathPrefix = "/share/"
+)
+
+var (
+	errCSRFTokenInvalid = webError{http.StatusForbidden, "Your session token is missing or invalid. Reload the page and try again."}
+	errCrossOrigin      = webError{http.StatusForbidden, "Cross-origin requests are not allowed."}
+)
+
+// SameOrigin is a HTTP middleware rejecting the cross-origin requests which
+// are not safe (POST, PUT, DELETE...), based on the Sec-Fetch-Site header or,
+// for older browsers, on the Origin header.
+func (h *Handler) SameOrigin() func(next http.Handler) http.Handler {
+	protection := http.NewCrossOriginProtection()
+	protection.SetDenyHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.writeGetError(w, r, errCrossOrigin)
+	}))
+	return protection.Handler
+}
+
+// RequireSession is a HTTP middleware requiring a session for every route but
+// the login pages and the shared sheets. Requests without a valid session are
+// sent to the login page; requests which are not safe must also carry the CSRF
+// token of the session. On success, the user and the CSRF token are stored in
+// the request context.
+func (h *Handler) RequireSession() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == loginPath || strings.HasPrefix(r.URL.Path, loginPath+"/") || strings.HasPrefix(r.URL.Path, sharePathPrefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			s, err := h.sessionFromRequest(r)
+			if err != nil {
+				if errors.Is(err, domainerrors.ErrSessionIsInvalid) || errors.Is(err, domainerrors.ErrUserIsDisabled) {
+					clearSessionCookie(w, r)
+					redirectToLogin(w, r)
+					return
+				}
+				h.writeGetError(w, r, mapDomainError(err))
+				return
+			}
+
+			if !isSafeMethod(r.Method) && !validCSRFToken(r, s.CSRFToken) {
+				h.writeGetError(w, r, errCSRFTokenInvalid)
+				return
+			}
+
+			zerolog.Ctx(r.Context()).UpdateContext(func(c zerolog.Context) zerolog.Context {
+				return c.Str("user", s.UserName)
+			})
+
+			ctx := auth.NewContext(r.Context(), &auth.User{Id: s.UserId, Name: s.UserName, Role: s.UserRole})
+			ctx = auth.NewCSRFContext(ctx, s.CSRFToken)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Authorize is a HTTP middleware refusing the requests of users whose role is
+// not granted action on resource with a 403, as an error alert for htmx
+// requests or an error page otherwise.
+func (h *Handler) Authorize(resource auth.Resource, action auth.Action) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !auth.Allowed(r.Context(), resource, action) {
+				zerolog.Ctx(r.Context()).Warn().Str("resource", string(resource)).Stringer("action", action).Msg("permission denied")
+				h.writeGetError(w, r, mapDomainError(domainerrors.ErrPermissionDenied))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// LoginForm handles GET /login.
+func (h *Handler) LoginForm(w http.ResponseWriter, r *http.Request) {
+	state := h.loginFormState(localPath(r.URL.Query().Get("next")))
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewlogin.Page(state).Render(r.Context(), w)
+}
+
+// Login handles POST /login. On success, it sets the session cookie and
+// redirects to the page the user was sent to the login page from.
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		status, message := parseFormError(err)
+		h.writeFullPageError(w, r, webError{status, message})
+		return
+	}
+	state := h.loginFormState(localPath(r.PostFormValue("next")))
+	state.Name = strings.TrimSpace(r.PostFormValue("name"))
+
+	s, token, err := h.SessionService.Login(r.Context(), state.Name, r.PostFormValue("password"))
+	if err != nil {
+		we := mapDomainError(err)
+		if we.Status == http.StatusInternalServerError {
+			h.writeFullPageError(w, r, we)
+			return
+		}
+		state.Error = we.Message
+		writeHTMLStatus(w, we.Status)
+		_ = viewlogin.Page(state).Render(r.Context(), w)
+		return
+	}
+
+	setSessionCookie(w, r, s, token)
+	http.Redirect(w, r, state.Next, http.StatusSeeOther)
+}
+
+// Logout handles POST /logout, which RequireSession guards like any other
+// route.
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		if err := h.SessionService.Logout(r.Context(), cookie.Value); err != nil {
+			h.writeGetError(w, r, mapDomainError(err))
+			return
+		}
+	}
+	clearSessionCookie(w, r)
+
+	if isHXRequest(r) {
+		w.Header().Set("HX-Redirect", loginPath)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, loginPath, http.StatusSeeOther)
+}
+
+// loginFormState returns the state of an empty login form, going back to
+// next once logged in.
+func (h *Handler) loginFormState(next string) viewlogin.FormState {
+	state := viewlogin.FormState{Next: next}
+	if h.SSOService != nil {
+		state.SSOURL = oidcLoginPath + "?next=" + url.QueryEscape(next)
+	}
+	return state
+}
+
+// sessionFromRequest authenticates the session cookie of the request.
+func (h *Handler) sessionFromRequest(r *http.Request) (*session.Session, error) {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil {
+		return nil, domainerrors.ErrSessionIsInvalid
+	}
+	return h.SessionService.Authenticate(r.Context(), cookie.Value)
+}
+
+// redirectToLogin sends the browser to the login page, to come back to the
+// current page once logged in. htmx requests get a HX-Redirect to follow, as
+// a redirect would only swap the login page into the target element.
+func redirectToLogin(w http.ResponseWriter, r *http.Request) {
+	if isHXRequest(r) {
+		w.Header().Set("HX-Redirect", loginPath)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	target := loginPath
+	if r.Method == http.MethodGet && r.URL.Path != "/" {
+		target += "?next=" + url.QueryEscape(r.URL.RequestURI())
+	}
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
+
+func setSessionCookie(w http.ResponseWriter, r *http.Request, s *session.Session, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    token,
+		Path:     "/",
+		Expires:  s.ExpiresAt,
+		Secure:   isHTTPS(r),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func clearSessionCookie(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   isHTTPS(r),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// validCSRFToken reports whether the request carries the CSRF token of its
+// session, in the shared.CSRFHeader header or in the csrf_token form field.
+func validCSRFToken(r *http.Request, want string) bool {
+	got := r.Header.Get(shared.CSRFHeader)
+	if got == "" && isFormURLEncoded(r) {
+		got = r.PostFormValue(csrfFormField)
+	}
+	return got != "" && subtle.ConstantTimeCompare([]byte(got), []byte(want)) == 1
+}
+
+// localPath returns next if it is a path on this site, and "/" otherwise, so
+// the login page cannot be used to redirect to another site.
+func localPath(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/"
+	}
+	u, err := url.Parse(next)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == loginPath {
+		return "/"
+	}
+	return next
+}
+
+// isHTTPS reports whether the request reached the server, or the proxy in
+// front of it, over https, in which case cookies are only sent over https.
+func isHTTPS(r *http.Request) bool {
+	return r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+}
+
+// isSafeMethod reports whether the method does not modify resources.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
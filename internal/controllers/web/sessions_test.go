@@ -0,0 +1,475 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/session"
+	"github.com/lescactus/espressoapi-go/views/templates/shared"
+)
+
+// fakeSessionService overrides the unusedSessionService methods exercised by
+// the login routes and the session middleware.
+type fakeSessionService struct {
+	unusedSessionService
+	t            *testing.T
+	login        func(context.Context, string, string) (*session.Session, string, error)
+	startSession func(context.Context, *auth.User) (*session.Session, string, error)
+	authenticate func(context.Context, string) (*session.Session, error)
+	logout       func(context.Context, string) error
+}
+
+func (f *fakeSessionService) Login(ctx context.Context, name, password string) (*session.Session, string, error) {
+	if f.login == nil {
+		f.t.Fatalf("unexpected Login call")
+	}
+	return f.login(ctx, name, password)
+}
+
+func (f *fakeSessionService) StartSession(ctx context.Context, user *auth.User) (*session.Session, string, error) {
+	if f.startSession == nil {
+		f.t.Fatalf("unexpected StartSession call")
+	}
+	return f.startSession(ctx, user)
+}
+
+func (f *fakeSessionService) Authenticate(ctx context.Context, token string) (*session.Session, error) {
+	if f.authenticate == nil {
+		f.t.Fatalf("unexpected Authenticate call")
+	}
+	return f.authenticate(ctx, token)
+}
+
+func (f *fakeSessionService) Logout(ctx context.Context, token string) error {
+	if f.logout == nil {
+		f.t.Fatalf("unexpected Logout call")
+	}
+	return f.logout(ctx, token)
+}
+
+func newTestSessionHandler(t *testing.T) (*Handler, *fakeSessionService) {
+	t.Helper()
+	svc := &fakeSessionService{t: t}
+	h := NewHandler(unusedSheetService{}, unusedRoasterService{}, unusedBeanService{}, unusedShotService{}, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, svc, nil)
+	return h, svc
+}
+
+func testSession() *session.Session {
+	return &session.Session{
+		Id: 3, UserId: 7, UserName: "alice", UserRole: auth.RoleBarista, CSRFToken: "csrf-token",
+		ExpiresAt: time.Date(2026, 10, 25, 17, 0, 0, 0, time.UTC),
+	}
+}
+
+// authenticateToken accepts the "session-token" token only.
+func authenticateToken(_ context.Context, token string) (*session.Session, error) {
+	if token != "session-token" {
+		return nil, errors.ErrSessionIsInvalid
+	}
+	return testSession(), nil
+}
+
+// protectedHandler records the user and CSRF token RequireSession stored in
+// the request context.
+type protectedHandler struct {
+	called bool
+	user   *auth.User
+	csrf   string
+}
+
+func (p *protectedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.called = true
+	p.user, _ = auth.FromContext(r.Context())
+	p.csrf, _ = auth.CSRFTokenFromContext(r.Context())
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestRequireSession(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		body         string
+		cookie       string
+		headers      map[string]string
+		wantStatus   int
+		wantLocation string
+		wantCalled   bool
+	}{
+		{
+			name: "login page does not require a session", method: http.MethodGet, target: "/login",
+			wantStatus: http.StatusNoContent, wantCalled: true,
+		},
+		{
+			name: "single sign-on callback does not require a session", method: http.MethodGet, target: "/login/oidc/callback?code=c&state=s",
+			wantStatus: http.StatusNoContent, wantCalled: true,
+		},
+		{
+			name: "shared sheet does not require a session", method: http.MethodGet, target: "/share/1.1792281600.sig",
+			wantStatus: http.StatusNoContent, wantCalled: true,
+		},
+		{
+			name: "no cookie redirects to the login page", method: http.MethodGet, target: "/sheets/get/1?tab=shots",
+			wantStatus: http.StatusSeeOther, wantLocation: "/login?next=" + url.QueryEscape("/sheets/get/1?tab=shots"),
+		},
+		{
+			name: "invalid session redirects to the login page", method: http.MethodGet, target: "/", cookie: "expired",
+			wantStatus: http.StatusSeeOther, wantLocation: "/login",
+		},
+		{
+			name: "htmx request without session gets HX-Redirect", method: http.MethodDelete, target: "/sheets/delete/1",
+			headers:    map[string]string{"HX-Request": "true"},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "valid session on a safe method", method: http.MethodGet, target: "/sheets", cookie: "session-token",
+			wantStatus: http.StatusNoContent, wantCalled: true,
+		},
+		{
+			name: "unsafe method without CSRF token is refused", method: http.MethodDelete, target: "/sheets/delete/1", cookie: "session-token",
+			headers:    map[string]string{"HX-Request": "true"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "unsafe method with a wrong CSRF token is refused", method: http.MethodPut, target: "/sheets/update/1", cookie: "session-token",
+			headers:    map[string]string{"HX-Request": "true", shared.CSRFHeader: "forged"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "unsafe method with the CSRF header", method: http.MethodPut, target: "/sheets/update/1", cookie: "session-token",
+			headers:    map[string]string{"HX-Request": "true", shared.CSRFHeader: "csrf-token"},
+			wantStatus: http.StatusNoContent, wantCalled: true,
+		},
+		{
+			name: "unsafe method with the CSRF form field", method: http.MethodPost, target: "/sheets/add", cookie: "session-token",
+			body:       "name=Morning&csrf_token=csrf-token",
+			headers:    map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+			wantStatus: http.StatusNoContent, wantCalled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, svc := newTestSessionHandler(t)
+			svc.authenticate = authenticateToken
+			next := &protectedHandler{}
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+
+			h.RequireSession()(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d; body = %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if got := rec.Header().Get("Location"); got != tt.wantLocation {
+				t.Errorf("Location = %q, want %q", got, tt.wantLocation)
+			}
+			if rec.Code == http.StatusUnauthorized {
+				if got := rec.Header().Get("HX-Redirect"); got != "/login" {
+					t.Errorf("HX-Redirect = %q, want /login", got)
+				}
+			}
+			if next.called != tt.wantCalled {
+				t.Fatalf("next called = %v, want %v", next.called, tt.wantCalled)
+			}
+			if tt.wantCalled && tt.cookie != "" {
+				if next.user == nil || next.user.Id != 7 || next.user.Name != "alice" || next.user.Role != auth.RoleBarista {
+					t.Errorf("context user = %+v, want barista alice (7)", next.user)
+				}
+				if next.csrf != "csrf-token" {
+					t.Errorf("context CSRF token = %q, want csrf-token", next.csrf)
+				}
+			}
+		})
+	}
+}
+
+func TestRequireSession_ServiceErrorIsNotARedirect(t *testing.T) {
+	h, svc := newTestSessionHandler(t)
+	svc.authenticate = func(context.Context, string) (*session.Session, error) {
+		return nil, context.DeadlineExceeded
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/sheets", nil)
+	req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: "session-token"})
+	rec := httptest.NewRecorder()
+
+	h.RequireSession()(&protectedHandler{}).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name       string
+		user       *auth.User
+		method     string
+		htmx       bool
+		action     auth.Action
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "unauthenticated request", method: http.MethodDelete, action: auth.ActionDelete, wantStatus: http.StatusNoContent},
+		{
+			name: "barista edits a roaster", user: &auth.User{Id: 7, Name: "alice", Role: auth.RoleBarista},
+			method: http.MethodPut, action: auth.ActionUpdate, wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "barista cannot delete a roaster", user: &auth.User{Id: 7, Name: "alice", Role: auth.RoleBarista},
+			method: http.MethodDelete, htmx: true, action: auth.ActionDelete,
+			wantStatus: http.StatusForbidden, wantBody: "Your role does not allow this action.",
+		},
+		{
+			name: "viewer cannot open the add form", user: &auth.User{Id: 8, Name: "guest", Role: auth.RoleViewer},
+			method: http.MethodGet, action: auth.ActionCreate,
+			wantStatus: http.StatusForbidden, wantBody: "Your role does not allow this action.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newTestSessionHandler(t)
+
+			req := httptest.NewRequest(tt.method, "/roasters", nil)
+			if tt.user != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), tt.user))
+			}
+			if tt.htmx {
+				req.Header.Set("HX-Request", "true")
+			}
+			rec := httptest.NewRecorder()
+			next := &protectedHandler{}
+
+			h.Authorize(auth.ResourceRoasters, tt.action)(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if next.called != (tt.wantStatus == http.StatusNoContent) {
+				t.Errorf("next called = %v", next.called)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body does not contain %q: %s", tt.wantBody, rec.Body.String())
+			}
+			if tt.htmx && rec.Code == http.StatusForbidden && rec.Header().Get("HX-Reswap") != "none" {
+				t.Errorf("HX-Reswap = %q, want none", rec.Header().Get("HX-Reswap"))
+			}
+		})
+	}
+}
+
+func TestSameOrigin(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		headers    map[string]string
+		wantStatus int
+	}{
+		{name: "same origin post", method: http.MethodPost, headers: map[string]string{"Sec-Fetch-Site": "same-origin"}, wantStatus: http.StatusNoContent},
+		{name: "cross site post", method: http.MethodPost, headers: map[string]string{"Sec-Fetch-Site": "cross-site"}, wantStatus: http.StatusForbidden},
+		{name: "foreign origin delete", method: http.MethodDelete, headers: map[string]string{"Origin": "https://evil.example"}, wantStatus: http.StatusForbidden},
+		{name: "matching origin put", method: http.MethodPut, headers: map[string]string{"Origin": "http://example.com"}, wantStatus: http.StatusNoContent},
+		{name: "cross site get is allowed", method: http.MethodGet, headers: map[string]string{"Sec-Fetch-Site": "cross-site"}, wantStatus: http.StatusNoContent},
+		{name: "non-browser client without headers", method: http.MethodPost, wantStatus: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newTestSessionHandler(t)
+
+			req := httptest.NewRequest(tt.method, "http://example.com/sheets/add", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+
+			h.SameOrigin()(&protectedHandler{}).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestLoginForm(t *testing.T) {
+	h, _ := newTestSessionHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/login?next=%2Fshots", nil)
+	rec := httptest.NewRecorder()
+
+	h.LoginForm(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, `action="/login"`) || !strings.Contains(body, `value="/shots"`) {
+		t.Errorf("login form missing action or next field: %s", body)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	tests := []struct {
+		name         string
+		form         string
+		loginErr     error
+		wantStatus   int
+		wantLocation string
+		wantCookie   bool
+		wantBody     string
+	}{
+		{
+			name: "success redirects to next", form: "name=alice&password=s3cret+pass&next=%2Fshots%3Fsort%3Ddate",
+			wantStatus: http.StatusSeeOther, wantLocation: "/shots?sort=date", wantCookie: true,
+		},
+		{
+			name: "external next redirects home", form: "name=alice&password=s3cret+pass&next=%2F%2Fevil.example",
+			wantStatus: http.StatusSeeOther, wantLocation: "/", wantCookie: true,
+		},
+		{
+			name: "invalid credentials", form: "name=alice&password=wrong", loginErr: errors.ErrInvalidCredentials,
+			wantStatus: http.StatusUnauthorized, wantBody: "Invalid user name or password.",
+		},
+		{
+			name: "disabled user", form: "name=alice&password=s3cret+pass", loginErr: errors.ErrUserIsDisabled,
+			wantStatus: http.StatusForbidden, wantBody: "This user is disabled.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, svc := newTestSessionHandler(t)
+			svc.login = func(_ context.Context, name, password string) (*session.Session, string, error) {
+				if name != "alice" {
+					t.Errorf("Login name = %q, want alice", name)
+				}
+				if tt.loginErr != nil {
+					return nil, "", tt.loginErr
+				}
+				return testSession(), "session-token", nil
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(tt.form))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+
+			h.Login(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d; body = %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if got := rec.Header().Get("Location"); got != tt.wantLocation {
+				t.Errorf("Location = %q, want %q", got, tt.wantLocation)
+			}
+			if tt.wantBody != "" && !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body does not contain %q: %s", tt.wantBody, rec.Body.String())
+			}
+
+			cookies := rec.Result().Cookies()
+			if !tt.wantCookie {
+				if len(cookies) != 0 {
+					t.Errorf("cookies = %v, want none", cookies)
+				}
+				return
+			}
+			if len(cookies) != 1 {
+				t.Fatalf("cookies = %v, want one session cookie", cookies)
+			}
+			c := cookies[0]
+			if c.Name != sessionCookieName || c.Value != "session-token" || !c.HttpOnly || c.SameSite != http.SameSiteLaxMode || c.Path != "/" {
+				t.Errorf("session cookie = %+v", c)
+			}
+			if !c.Expires.Equal(testSession().ExpiresAt) {
+				t.Errorf("cookie expires = %v, want %v", c.Expires, testSession().ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	tests := []struct {
+		name         string
+		htmx         bool
+		wantStatus   int
+		wantLocation string
+		wantRedirect string
+	}{
+		{name: "form post", wantStatus: http.StatusSeeOther, wantLocation: "/login"},
+		{name: "htmx request", htmx: true, wantStatus: http.StatusOK, wantRedirect: "/login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, svc := newTestSessionHandler(t)
+			var loggedOut string
+			svc.logout = func(_ context.Context, token string) error {
+				loggedOut = token
+				return nil
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/logout", nil)
+			req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: "session-token"})
+			if tt.htmx {
+				req.Header.Set("HX-Request", "true")
+			}
+			rec := httptest.NewRecorder()
+
+			h.Logout(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if loggedOut != "session-token" {
+				t.Errorf("Logout token = %q, want session-token", loggedOut)
+			}
+			if got := rec.Header().Get("Location"); got != tt.wantLocation {
+				t.Errorf("Location = %q, want %q", got, tt.wantLocation)
+			}
+			if got := rec.Header().Get("HX-Redirect"); got != tt.wantRedirect {
+				t.Errorf("HX-Redirect = %q, want %q", got, tt.wantRedirect)
+			}
+			cookies := rec.Result().Cookies()
+			if len(cookies) != 1 || cookies[0].Name != sessionCookieName || cookies[0].MaxAge >= 0 {
+				t.Errorf("cookies = %v, want the session cookie cleared", cookies)
+			}
+		})
+	}
+}
+
+func TestLocalPath(t *testing.T) {
+	tests := []struct {
+		next string
+		want string
+	}{
+		{"", "/"},
+		{"/shots?sort=date", "/shots?sort=date"},
+		{"//evil.example", "/"},
+		{"/\\evil.example", "/"},
+		{"https://evil.example/", "/"},
+		{"shots", "/"},
+		{"/login", "/"},
+	}
+
+	for _, tt := range tests {
+		if got := localPath(tt.next); got != tt.want {
+			t.Errorf("localPath(%q) = %q, want %q", tt.next, got, tt.want)
+		}
+	}
+}
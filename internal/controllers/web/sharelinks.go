@@ -0,0 +1,116 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/services/share"
+	"github.com/lescactus/espressoapi-go/views/templates/shared"
+	viewsheets "github.com/lescactus/espressoapi-go/views/templates/sheets"
+)
+
+const errInvalidShareLinkID = "The share link id must be a positive number."
+
+// ShareSheet handles POST /sheets/share/:id: it creates a share link for the
+// sheet with the default lifetime and re-renders the share links section.
+func (h *Handler) ShareSheet(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidSheetID})
+		return
+	}
+
+	if _, err := h.ShareLinkService.CreateShareLink(r.Context(), id, nil); err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+
+	links, err := h.activeShareLinks(r, id)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewsheets.ShareSection(id, links, baseURL(r)).Render(r.Context(), w)
+	_ = shared.SuccessAlertOOB("Share link successfully created.").Render(r.Context(), w)
+}
+
+// RevokeShareLink handles DELETE /share_links/delete/:id.
+func (h *Handler) RevokeShareLink(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidShareLinkID})
+		return
+	}
+
+	if err := h.ShareLinkService.RevokeShareLinkById(r.Context(), id); err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = shared.SuccessAlertOOB("Share link successfully revoked.").Render(r.Context(), w)
+}
+
+// SharedSheet handles GET /share/:token: the read-only page of the sheet the
+// share link token was created for, with its shots. It is served without a
+// session, so the sheet and its shots are read on behalf of nobody, and an
+// invalid, expired or revoked token is a 404 page.
+func (h *Handler) SharedSheet(w http.ResponseWriter, r *http.Request) {
+	link, err := h.ShareLinkService.ResolveToken(r.Context(), httprouter.ParamsFromContext(r.Context()).ByName("token"))
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+
+	s, err := h.SheetService.GetSheetById(r.Context(), link.SheetId)
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+	shots, err := h.ShotService.GetShotsBySheetId(r.Context(), link.SheetId)
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+	sortShots(shots, "id", "asc")
+
+	// Shared pages are not to be indexed, and their token must not leak
+	// through the Referer header of the links they contain.
+	w.Header().Set("X-Robots-Tag", "noindex")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewsheets.Shared(*s, shots).Render(r.Context(), w)
+}
+
+// activeShareLinks returns the share links of the sheet id which are neither
+// expired nor revoked, or none for the roles which may not read them.
+func (h *Handler) activeShareLinks(r *http.Request, id int) ([]share.ShareLink, error) {
+	if !auth.Allowed(r.Context(), auth.ResourceShareLinks, auth.ActionRead) {
+		return nil, nil
+	}
+	links, err := h.ShareLinkService.GetShareLinksBySheetId(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	active := make([]share.ShareLink, 0, len(links))
+	now := time.Now()
+	for _, l := range links {
+		if l.IsActive(now) {
+			active = append(active, l)
+		}
+	}
+	return active, nil
+}
+
+// baseURL returns the scheme and host the request was sent to, which share
+// link URLs are built on.
+func baseURL(r *http.Request) string {
+	if isHTTPS(r) {
+		return "https://" + r.Host
+	}
+	return "http://" + r.Host
+}
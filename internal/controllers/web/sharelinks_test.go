@@ -0,0 +1,205 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/share"
+	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+)
+
+// fakeShareLinkService overrides the unusedShareLinkService methods
+// exercised by the share link routes and the sheet detail page.
+type fakeShareLinkService struct {
+	unusedShareLinkService
+	t                      *testing.T
+	createShareLink        func(context.Context, int, *time.Time) (*share.ShareLink, error)
+	getShareLinksBySheetID func(context.Context, int) ([]share.ShareLink, error)
+	revokeShareLinkByID    func(context.Context, int) error
+	resolveToken           func(context.Context, string) (*share.ShareLink, error)
+}
+
+func (f *fakeShareLinkService) CreateShareLink(ctx context.Context, sheetId int, expiresAt *time.Time) (*share.ShareLink, error) {
+	if f.createShareLink == nil {
+		f.t.Fatalf("unexpected CreateShareLink call")
+	}
+	return f.createShareLink(ctx, sheetId, expiresAt)
+}
+
+func (f *fakeShareLinkService) GetShareLinksBySheetId(ctx context.Context, sheetId int) ([]share.ShareLink, error) {
+	if f.getShareLinksBySheetID == nil {
+		f.t.Fatalf("unexpected GetShareLinksBySheetId call")
+	}
+	return f.getShareLinksBySheetID(ctx, sheetId)
+}
+
+func (f *fakeShareLinkService) RevokeShareLinkById(ctx context.Context, id int) error {
+	if f.revokeShareLinkByID == nil {
+		f.t.Fatalf("unexpected RevokeShareLinkById call")
+	}
+	return f.revokeShareLinkByID(ctx, id)
+}
+
+func (f *fakeShareLinkService) ResolveToken(ctx context.Context, token string) (*share.ShareLink, error) {
+	if f.resolveToken == nil {
+		f.t.Fatalf("unexpected ResolveToken call")
+	}
+	return f.resolveToken(ctx, token)
+}
+
+func newTestShareLinkHandler(t *testing.T, sheets *fakeSheetService, shots shotsBySheetIDStub) (*Handler, *fakeShareLinkService) {
+	t.Helper()
+	svc := &fakeShareLinkService{t: t}
+	h := NewHandler(sheets, unusedRoasterService{}, unusedBeanService{}, shots, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, svc, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil)
+	return h, svc
+}
+
+func testShareLink(id int, expiresAt time.Time) share.ShareLink {
+	return share.ShareLink{Id: id, SheetId: 1, Token: "1.1792281600.sig", ExpiresAt: expiresAt}
+}
+
+func newSharedSheetRequest(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/share/"+token, nil)
+	params := httprouter.Params{{Key: "token", Value: token}}
+	return req.WithContext(context.WithValue(req.Context(), httprouter.ParamsKey, params))
+}
+
+func TestSharedSheet_RendersReadOnlySheet(t *testing.T) {
+	sheets := &fakeSheetService{t: t}
+	sheets.getSheetByID = func(_ context.Context, id int) (*sheet.Sheet, error) {
+		if id != 1 {
+			t.Errorf("id = %d, want 1", id)
+		}
+		return testSheet(1, "Double shot"), nil
+	}
+	shots := shotsBySheetIDStub{getShotsBySheetID: func(context.Context, int) ([]shot.Shot, error) {
+		return []shot.Shot{{Id: 9, Beans: &bean.Bean{Name: "Ethiopia"}}}, nil
+	}}
+	h, svc := newTestShareLinkHandler(t, sheets, shots)
+	svc.resolveToken = func(_ context.Context, token string) (*share.ShareLink, error) {
+		if token != "1.1792281600.sig" {
+			t.Errorf("token = %q, want 1.1792281600.sig", token)
+		}
+		link := testShareLink(1, time.Now().Add(time.Hour))
+		return &link, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.SharedSheet(rec, newSharedSheetRequest("1.1792281600.sig"))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, "Double shot") || !strings.Contains(body, "Ethiopia") {
+		t.Fatalf("expected the sheet and its shots, got %d: %s", rec.Code, body)
+	}
+	for _, unwanted := range []string{"<nav", "hx-delete", "hx-put", "hx-post", "Edit", "sheet-share-links"} {
+		if strings.Contains(body, unwanted) {
+			t.Errorf("expected a read-only page without %q, got: %s", unwanted, body)
+		}
+	}
+	if got := rec.Header().Get("X-Robots-Tag"); got != "noindex" {
+		t.Errorf("X-Robots-Tag = %q, want noindex", got)
+	}
+}
+
+func TestSharedSheet_InvalidTokenIsNotFound(t *testing.T) {
+	h, svc := newTestShareLinkHandler(t, &fakeSheetService{t: t}, shotsBySheetIDStub{})
+	svc.resolveToken = func(context.Context, string) (*share.ShareLink, error) {
+		return nil, errors.ErrShareLinkIsInvalid
+	}
+
+	rec := httptest.NewRecorder()
+	h.SharedSheet(rec, newSharedSheetRequest("1.1792281600.forged"))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected a 404 page, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestShareSheet_CreatesLinkAndRendersActiveLinks(t *testing.T) {
+	h, svc := newTestShareLinkHandler(t, &fakeSheetService{t: t}, shotsBySheetIDStub{})
+	created := false
+	svc.createShareLink = func(_ context.Context, sheetId int, expiresAt *time.Time) (*share.ShareLink, error) {
+		if sheetId != 1 || expiresAt != nil {
+			t.Errorf("CreateShareLink(%d, %v), want (1, nil)", sheetId, expiresAt)
+		}
+		created = true
+		link := testShareLink(2, time.Now().Add(time.Hour))
+		return &link, nil
+	}
+	svc.getShareLinksBySheetID = func(context.Context, int) ([]share.ShareLink, error) {
+		expired := testShareLink(1, time.Now().Add(-time.Hour))
+		expired.Token = "1.1.expired"
+		return []share.ShareLink{expired, testShareLink(2, time.Now().Add(time.Hour))}, nil
+	}
+
+	req := newWebRequest(http.MethodPost, "/sheets/share/1", "", "", "1", true)
+	req.Host = "espresso.example.com"
+	rec := httptest.NewRecorder()
+	h.ShareSheet(rec, req)
+
+	body := rec.Body.String()
+	if !created || rec.Code != http.StatusOK {
+		t.Fatalf("expected the share link to be created, got %d: %s", rec.Code, body)
+	}
+	if !strings.Contains(body, "http://espresso.example.com/share/1.1792281600.sig") || strings.Contains(body, "1.1.expired") {
+		t.Errorf("expected only the active share link with its absolute URL, got: %s", body)
+	}
+	if !strings.Contains(body, "Share link successfully created.") {
+		t.Errorf("expected a success alert, got: %s", body)
+	}
+}
+
+func TestShareSheet_MissingSheetIsAnAlert(t *testing.T) {
+	h, svc := newTestShareLinkHandler(t, &fakeSheetService{t: t}, shotsBySheetIDStub{})
+	svc.createShareLink = func(context.Context, int, *time.Time) (*share.ShareLink, error) {
+		return nil, errors.ErrSheetDoesNotExist
+	}
+
+	rec := httptest.NewRecorder()
+	h.ShareSheet(rec, newWebRequest(http.MethodPost, "/sheets/share/9", "", "", "9", true))
+
+	if rec.Header().Get("HX-Reswap") != "none" || !strings.Contains(rec.Body.String(), "alert") {
+		t.Errorf("expected an out-of-band alert, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRevokeShareLink_RemovesLink(t *testing.T) {
+	h, svc := newTestShareLinkHandler(t, &fakeSheetService{t: t}, shotsBySheetIDStub{})
+	revoked := 0
+	svc.revokeShareLinkByID = func(_ context.Context, id int) error {
+		revoked = id
+		return nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.RevokeShareLink(rec, newWebRequest(http.MethodDelete, "/share_links/delete/3", "", "", "3", true))
+
+	if revoked != 3 || rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Share link successfully revoked.") {
+		t.Errorf("expected share link 3 to be revoked, got %d (revoked %d): %s", rec.Code, revoked, rec.Body.String())
+	}
+}
+
+func TestGetSheet_ViewerDoesNotSeeShareLinks(t *testing.T) {
+	sheets := &fakeSheetService{t: t}
+	sheets.getSheetByID = func(context.Context, int) (*sheet.Sheet, error) { return testSheet(1, "Double shot"), nil }
+	shots := shotsBySheetIDStub{getShotsBySheetID: func(context.Context, int) ([]shot.Shot, error) { return nil, nil }}
+	h, _ := newTestShareLinkHandler(t, sheets, shots)
+
+	req := newWebRequest(http.MethodGet, "/sheets/get/1", "", "", "1", false)
+	req = req.WithContext(auth.NewContext(req.Context(), &auth.User{Id: 8, Name: "guest", Role: auth.RoleViewer}))
+	rec := httptest.NewRecorder()
+	h.GetSheet(rec, req)
+
+	if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), "sheet-share-links") {
+		t.Errorf("expected the detail page without share links, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"errors"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
 	"github.com/lescactus/espressoapi-go/internal/services/sheet"
 	"github.com/lescactus/espressoapi-go/views/templates/shared"
 	viewsheets "github.com/lescactus/espressoapi-go/views/templates/sheets"
@@ -79,6 +81,8 @@ func (h *Handler) writeFullPageError(w http.ResponseWriter, r *http.Request, we
 		_ = shared.NotFoundPage().Render(r.Context(), w)
 	case http.StatusBadRequest:
 		_ = shared.BadRequestPage(we.Message).Render(r.Context(), w)
+	case http.StatusForbidden:
+		_ = shared.ForbiddenPage(we.Message).Render(r.Context(), w)
 	default:
 		_ = shared.InternalErrorPage().Render(r.Context(), w)
 	}
@@ -86,7 +90,9 @@ func (h *Handler) writeFullPageError(w http.ResponseWriter, r *http.Request, we
 
 const errInvalidSheetID = "The sheet id must be a positive number."
 
-// Home renders the "/" landing page.
+// Home renders the "/" landing page, with a banner when maintenance tasks are
+// due. The banner is a reminder only: failing to load the due tasks hides it
+// rather than the sheets.
 func (h *Handler) Home(w http.ResponseWriter, r *http.Request) {
 	sheets, err := h.SheetService.GetAllSheets(r.Context())
 	if err != nil {
@@ -94,8 +100,14 @@ func (h *Handler) Home(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	sortSheets(sheets, "id", "asc")
+
+	var alerts []string
+	if due, err := h.MaintenanceService.GetDueMaintenanceTasks(r.Context()); err == nil {
+		alerts = maintenanceAlerts(due)
+	}
+
 	writeHTMLStatus(w, http.StatusOK)
-	_ = viewsheets.Home(sheets).Render(r.Context(), w)
+	_ = viewsheets.Home(sheets, alerts).Render(r.Context(), w)
 }
 
 // ListSheets renders GET /sheets: the full page, or just the sortable table
@@ -121,47 +133,61 @@ func (h *Handler) ListSheets(w http.ResponseWriter, r *http.Request) {
 // AddSheetForm renders GET /sheets/add: a blank inline row fragment, or the
 // full list page with that row already open for direct navigation.
 func (h *Handler) AddSheetForm(w http.ResponseWriter, r *http.Request) {
-	if isHXRequest(r) {
-		writeHTMLStatus(w, http.StatusOK)
-		_ = viewsheets.AddRow(viewsheets.FormState{}).Render(r.Context(), w)
-		return
-	}
 	sheets, err := h.SheetService.GetAllSheets(r.Context())
 	if err != nil {
-		h.writeFullPageError(w, r, mapDomainError(err))
+		h.writeGetError(w, r, mapDomainError(err))
 		return
 	}
 	sortSheets(sheets, "id", "asc")
 	writeHTMLStatus(w, http.StatusOK)
+	if isHXRequest(r) {
+		_ = viewsheets.AddRow(viewsheets.FormState{}, viewsheets.Templates(sheets)).Render(r.Context(), w)
+		return
+	}
 	_ = viewsheets.Page(sheets, "id", "asc", true).Render(r.Context(), w)
 }
 
-// CreateSheet handles POST /sheets/add.
+// CreateSheet handles POST /sheets/add. With a template_id, the new sheet is
+// cloned from that template (and its best shot with copy_best_shot).
 func (h *Handler) CreateSheet(w http.ResponseWriter, r *http.Request) {
 	if !isFormURLEncoded(r) {
-		writeHTMLStatus(w, http.StatusUnsupportedMediaType)
-		_ = viewsheets.AddRow(viewsheets.FormState{Error: "Invalid form. Please try again."}).Render(r.Context(), w)
+		h.renderSheetAddRow(w, r, viewsheets.FormState{Error: "Invalid form. Please try again."}, http.StatusUnsupportedMediaType)
 		return
 	}
 	if err := r.ParseForm(); err != nil {
 		status, message := parseFormError(err)
-		writeHTMLStatus(w, status)
-		_ = viewsheets.AddRow(viewsheets.FormState{Error: message}).Render(r.Context(), w)
+		h.renderSheetAddRow(w, r, viewsheets.FormState{Error: message}, status)
 		return
 	}
 
-	name := strings.TrimSpace(r.PostFormValue("name"))
-	if name == "" {
-		writeHTMLStatus(w, http.StatusBadRequest)
-		_ = viewsheets.AddRow(viewsheets.FormState{Name: name, Error: "Sheet name must not be empty."}).Render(r.Context(), w)
+	state := viewsheets.FormState{
+		Name:         strings.TrimSpace(r.PostFormValue("name")),
+		TemplateID:   r.PostFormValue("template_id"),
+		CopyBestShot: r.PostFormValue("copy_best_shot") == "true",
+	}
+	if state.Name == "" {
+		state.Error = "Sheet name must not be empty."
+		h.renderSheetAddRow(w, r, state, http.StatusBadRequest)
 		return
 	}
 
-	created, err := h.SheetService.CreateSheetByName(r.Context(), name)
+	var created *sheet.Sheet
+	var err error
+	if state.TemplateID == "" {
+		created, err = h.SheetService.CreateSheetByName(r.Context(), state.Name)
+	} else {
+		templateID, convErr := strconv.Atoi(state.TemplateID)
+		if convErr != nil || templateID <= 0 {
+			state.Error = "The template must be one of the listed sheets."
+			h.renderSheetAddRow(w, r, state, http.StatusBadRequest)
+			return
+		}
+		created, err = h.SheetService.CloneSheet(r.Context(), templateID, state.Name, state.CopyBestShot)
+	}
 	if err != nil {
 		we := mapDomainError(err)
-		writeHTMLStatus(w, we.Status)
-		_ = viewsheets.AddRow(viewsheets.FormState{Name: name, Error: we.Message}).Render(r.Context(), w)
+		state.Error = we.Message
+		h.renderSheetAddRow(w, r, state, we.Status)
 		return
 	}
 
@@ -170,6 +196,19 @@ func (h *Handler) CreateSheet(w http.ResponseWriter, r *http.Request) {
 	_ = shared.SuccessAlertOOB("Sheet successfully created.").Render(r.Context(), w)
 }
 
+// renderSheetAddRow re-renders the add row with the submitted (possibly
+// invalid) state and an inline error message. The templates are best effort:
+// without them the row still creates sheets from scratch.
+func (h *Handler) renderSheetAddRow(w http.ResponseWriter, r *http.Request, state viewsheets.FormState, status int) {
+	sheets, err := h.SheetService.GetAllSheets(r.Context())
+	if err != nil {
+		sheets = nil
+	}
+	sortSheets(sheets, "id", "asc")
+	writeHTMLStatus(w, status)
+	_ = viewsheets.AddRow(state, viewsheets.Templates(sheets)).Render(r.Context(), w)
+}
+
 // GetSheet handles GET /sheets/get/:id: the full detail page for direct
 // navigation, or the view_context-selected fragment for an htmx cancel.
 func (h *Handler) GetSheet(w http.ResponseWriter, r *http.Request) {
@@ -191,8 +230,18 @@ func (h *Handler) GetSheet(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		sortShots(shots, "id", "asc")
+		links, err := h.activeShareLinks(r, id)
+		if err != nil {
+			h.writeFullPageError(w, r, mapDomainError(err))
+			return
+		}
+		shotStats, err := h.StatsService.GetShotStats(r.Context(), sql.ShotStatsOfSheet, id)
+		if err != nil {
+			h.writeFullPageError(w, r, mapDomainError(err))
+			return
+		}
 		writeHTMLStatus(w, http.StatusOK)
-		_ = viewsheets.Detail(*s, shots).Render(r.Context(), w)
+		_ = viewsheets.Detail(*s, shots, *shotStats, links, baseURL(r)).Render(r.Context(), w)
 		return
 	}
 
@@ -217,7 +266,7 @@ func (h *Handler) EditSheetForm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	state := viewsheets.FormState{ID: s.Id, Name: s.Name}
+	state := viewsheets.FormState{ID: s.Id, Name: s.Name, IsTemplate: s.IsTemplate}
 	createdAt := shared.FormatTimestamp(s.CreatedAt)
 	updatedAt := shared.FormatTimestamp(s.UpdatedAt)
 	vc := viewContext(r)
@@ -230,8 +279,18 @@ func (h *Handler) EditSheetForm(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			sortShots(shots, "id", "asc")
+			links, err := h.activeShareLinks(r, id)
+			if err != nil {
+				h.writeFullPageError(w, r, mapDomainError(err))
+				return
+			}
+			shotStats, err := h.StatsService.GetShotStats(r.Context(), sql.ShotStatsOfSheet, id)
+			if err != nil {
+				h.writeFullPageError(w, r, mapDomainError(err))
+				return
+			}
 			writeHTMLStatus(w, http.StatusOK)
-			_ = viewsheets.DetailEditing(state, createdAt, updatedAt, shots, s.Id).Render(r.Context(), w)
+			_ = viewsheets.DetailEditing(state, createdAt, updatedAt, shots, *shotStats, s.Id, links, baseURL(r)).Render(r.Context(), w)
 			return
 		}
 		sheets, err := h.SheetService.GetAllSheets(r.Context())
@@ -274,16 +333,22 @@ func (h *Handler) UpdateSheet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	name := strings.TrimSpace(r.PostFormValue("name"))
-	if name == "" {
-		h.renderSheetFormError(w, r, viewsheets.FormState{ID: id, Name: name, Error: "Sheet name must not be empty."}, vc, http.StatusBadRequest)
+	state := viewsheets.FormState{
+		ID:         id,
+		Name:       strings.TrimSpace(r.PostFormValue("name")),
+		IsTemplate: r.PostFormValue("is_template") == "true",
+	}
+	if state.Name == "" {
+		state.Error = "Sheet name must not be empty."
+		h.renderSheetFormError(w, r, state, vc, http.StatusBadRequest)
 		return
 	}
 
-	updated, err := h.SheetService.UpdateSheetById(r.Context(), id, &sheet.Sheet{Id: id, Name: name})
+	updated, err := h.SheetService.UpdateSheetById(r.Context(), id, &sheet.Sheet{Id: id, Name: state.Name, IsTemplate: state.IsTemplate})
 	if err != nil {
 		we := mapDomainError(err)
-		h.renderSheetFormError(w, r, viewsheets.FormState{ID: id, Name: name, Error: we.Message}, vc, we.Status)
+		state.Error = we.Message
+		h.renderSheetFormError(w, r, state, vc, we.Status)
 		return
 	}
 
@@ -3,6 +3,7 @@ package web
 import (
 	"context"
 	stderrors "errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,26 +11,47 @@ import (
 	"time"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/lescactus/espressoapi-go/internal/auth"
 	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/attachment"
 	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/cupping"
+	"github.com/lescactus/espressoapi-go/internal/services/greencoffee"
+	"github.com/lescactus/espressoapi-go/internal/services/maintenance"
+	"github.com/lescactus/espressoapi-go/internal/services/report"
+	"github.com/lescactus/espressoapi-go/internal/services/roastbatch"
 	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/session"
+	"github.com/lescactus/espressoapi-go/internal/services/share"
 	"github.com/lescactus/espressoapi-go/internal/services/sheet"
 	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/lescactus/espressoapi-go/internal/services/spreadsheet"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
 )
 
 // fakeSheetService is a hand-rolled fake with func fields, matching the
 // pattern used by internal/controllers/rest.
 type fakeSheetService struct {
 	t                 *testing.T
+	createSheet       func(context.Context, *sheet.Sheet) (*sheet.Sheet, error)
 	createSheetByName func(context.Context, string) (*sheet.Sheet, error)
 	getSheetByID      func(context.Context, int) (*sheet.Sheet, error)
 	getAllSheets      func(context.Context) ([]sheet.Sheet, error)
 	updateSheetByID   func(context.Context, int, *sheet.Sheet) (*sheet.Sheet, error)
 	deleteSheetByID   func(context.Context, int) error
+	cloneSheet        func(context.Context, int, string, bool) (*sheet.Sheet, error)
 }
 
 var _ sheet.Service = (*fakeSheetService)(nil)
 
+func (f *fakeSheetService) CreateSheet(ctx context.Context, value *sheet.Sheet) (*sheet.Sheet, error) {
+	if f.createSheet == nil {
+		f.t.Fatalf("unexpected CreateSheet call")
+	}
+	return f.createSheet(ctx, value)
+}
+
 func (f *fakeSheetService) CreateSheetByName(ctx context.Context, name string) (*sheet.Sheet, error) {
 	if f.createSheetByName == nil {
 		f.t.Fatalf("unexpected CreateSheetByName call")
@@ -65,10 +87,21 @@ func (f *fakeSheetService) DeleteSheetById(ctx context.Context, id int) error {
 	return f.deleteSheetByID(ctx, id)
 }
 
+func (f *fakeSheetService) CloneSheet(ctx context.Context, id int, name string, copyBestShot bool) (*sheet.Sheet, error) {
+	if f.cloneSheet == nil {
+		f.t.Fatalf("unexpected CloneSheet call")
+	}
+	return f.cloneSheet(ctx, id, name, copyBestShot)
+}
+
 func (f *fakeSheetService) Ping(context.Context) error { return nil }
 
-// unusedRoasterService/unusedBeanService/unusedShotService satisfy the
-// remaining Handler dependencies for tests that only exercise sheet routes.
+// unusedRoasterService/unusedBeanService/unusedShotService/
+// unusedCuppingService/unusedRoastBatchService/unusedGreenCoffeeService/
+// unusedReportService/unusedStatsService/unusedMaintenanceService/
+// unusedShareLinkService/unusedAttachmentService satisfy
+// the remaining Handler dependencies for tests that only exercise sheet
+// routes.
 type unusedRoasterService struct{}
 
 func (unusedRoasterService) CreateRoasterByName(context.Context, string) (*roaster.Roaster, error) {
@@ -109,12 +142,191 @@ func (unusedShotService) UpdateShotById(context.Context, int, *shot.Shot) (*shot
 	return nil, nil
 }
 func (unusedShotService) DeleteShotById(context.Context, int) error { return nil }
-func (unusedShotService) Ping(context.Context) error                { return nil }
+func (unusedShotService) GetShotProfileById(context.Context, int) (*shot.Profile, error) {
+	return nil, nil
+}
+func (unusedShotService) UpdateShotProfileById(context.Context, int, *shot.Profile) (*shot.Profile, error) {
+	return nil, nil
+}
+func (unusedShotService) CompareShots(context.Context, []int) (*shot.Comparison, error) {
+	return nil, nil
+}
+func (unusedShotService) DuplicateShot(context.Context, int, shot.Overrides) (*shot.Shot, error) {
+	return nil, nil
+}
+func (unusedShotService) Ping(context.Context) error { return nil }
+
+type unusedCuppingService struct{}
+
+func (unusedCuppingService) CreateCuppingSession(context.Context, *cupping.CuppingSession) (*cupping.CuppingSession, error) {
+	return nil, nil
+}
+func (unusedCuppingService) GetCuppingSessionById(context.Context, int) (*cupping.CuppingSession, error) {
+	return nil, nil
+}
+func (unusedCuppingService) GetAllCuppingSessions(context.Context) ([]cupping.CuppingSession, error) {
+	return nil, nil
+}
+func (unusedCuppingService) UpdateCuppingSessionById(context.Context, int, *cupping.CuppingSession) (*cupping.CuppingSession, error) {
+	return nil, nil
+}
+func (unusedCuppingService) DeleteCuppingSessionById(context.Context, int) error { return nil }
+func (unusedCuppingService) CreateCuppingScore(context.Context, *cupping.CuppingScore) (*cupping.CuppingScore, error) {
+	return nil, nil
+}
+func (unusedCuppingService) GetCuppingScoreById(context.Context, int) (*cupping.CuppingScore, error) {
+	return nil, nil
+}
+func (unusedCuppingService) GetCuppingScoresByBeansId(context.Context, int) ([]cupping.CuppingScore, error) {
+	return nil, nil
+}
+func (unusedCuppingService) UpdateCuppingScoreById(context.Context, int, *cupping.CuppingScore) (*cupping.CuppingScore, error) {
+	return nil, nil
+}
+func (unusedCuppingService) DeleteCuppingScoreById(context.Context, int) error { return nil }
+func (unusedCuppingService) Ping(context.Context) error                        { return nil }
+
+type unusedRoastBatchService struct{}
+
+func (unusedRoastBatchService) CreateRoastBatch(context.Context, *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error) {
+	return nil, nil
+}
+func (unusedRoastBatchService) GetRoastBatchById(context.Context, int) (*roastbatch.RoastBatch, error) {
+	return nil, nil
+}
+func (unusedRoastBatchService) GetAllRoastBatches(context.Context) ([]roastbatch.RoastBatch, error) {
+	return nil, nil
+}
+func (unusedRoastBatchService) UpdateRoastBatchById(context.Context, int, *roastbatch.RoastBatch) (*roastbatch.RoastBatch, error) {
+	return nil, nil
+}
+func (unusedRoastBatchService) DeleteRoastBatchById(context.Context, int) error { return nil }
+func (unusedRoastBatchService) CreateBeansFromRoastBatch(context.Context, int) (*roastbatch.RoastBatch, error) {
+	return nil, nil
+}
+func (unusedRoastBatchService) Ping(context.Context) error { return nil }
+
+type unusedGreenCoffeeService struct{}
+
+func (unusedGreenCoffeeService) CreateGreenCoffee(context.Context, *greencoffee.GreenCoffee) (*greencoffee.GreenCoffee, error) {
+	return nil, nil
+}
+func (unusedGreenCoffeeService) GetGreenCoffeeById(context.Context, int) (*greencoffee.GreenCoffee, error) {
+	return nil, nil
+}
+func (unusedGreenCoffeeService) GetAllGreenCoffees(context.Context) ([]greencoffee.GreenCoffee, error) {
+	return nil, nil
+}
+func (unusedGreenCoffeeService) UpdateGreenCoffeeById(context.Context, int, *greencoffee.GreenCoffee) (*greencoffee.GreenCoffee, error) {
+	return nil, nil
+}
+func (unusedGreenCoffeeService) DeleteGreenCoffeeById(context.Context, int) error { return nil }
+func (unusedGreenCoffeeService) Ping(context.Context) error                       { return nil }
+
+type unusedReportService struct{}
+
+func (unusedReportService) GetSpendReport(context.Context, *time.Time, *time.Time, report.GroupBy) (*report.SpendReport, error) {
+	return nil, nil
+}
+func (unusedReportService) Ping(context.Context) error { return nil }
+
+type unusedStatsService struct{}
+
+func (unusedStatsService) GetConsumption(context.Context, *time.Time, *time.Time, string) (*stats.Consumption, error) {
+	return nil, nil
+}
+func (unusedStatsService) GetShotStats(context.Context, sql.ShotStatsSubject, int) (*stats.ShotStats, error) {
+	return &stats.ShotStats{}, nil
+}
+func (unusedStatsService) Ping(context.Context) error { return nil }
+
+type unusedSessionService struct{}
+
+func (unusedSessionService) Login(context.Context, string, string) (*session.Session, string, error) {
+	return nil, "", nil
+}
+func (unusedSessionService) StartSession(context.Context, *auth.User) (*session.Session, string, error) {
+	return nil, "", nil
+}
+func (unusedSessionService) Authenticate(context.Context, string) (*session.Session, error) {
+	return nil, nil
+}
+func (unusedSessionService) Logout(context.Context, string) error { return nil }
+func (unusedSessionService) Ping(context.Context) error           { return nil }
+
+type unusedMaintenanceService struct{}
+
+func (unusedMaintenanceService) CreateMaintenanceTask(context.Context, *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+	return nil, nil
+}
+func (unusedMaintenanceService) GetMaintenanceTaskById(context.Context, int) (*maintenance.MaintenanceTask, error) {
+	return nil, nil
+}
+func (unusedMaintenanceService) GetAllMaintenanceTasks(context.Context) ([]maintenance.MaintenanceTask, error) {
+	return nil, nil
+}
+func (unusedMaintenanceService) GetDueMaintenanceTasks(context.Context) ([]maintenance.MaintenanceTask, error) {
+	return nil, nil
+}
+func (unusedMaintenanceService) UpdateMaintenanceTaskById(context.Context, int, *maintenance.MaintenanceTask) (*maintenance.MaintenanceTask, error) {
+	return nil, nil
+}
+func (unusedMaintenanceService) CompleteMaintenanceTaskById(context.Context, int) (*maintenance.MaintenanceTask, error) {
+	return nil, nil
+}
+func (unusedMaintenanceService) DeleteMaintenanceTaskById(context.Context, int) error { return nil }
+func (unusedMaintenanceService) Ping(context.Context) error                           { return nil }
+
+type unusedShareLinkService struct{}
+
+func (unusedShareLinkService) CreateShareLink(context.Context, int, *time.Time) (*share.ShareLink, error) {
+	return nil, nil
+}
+func (unusedShareLinkService) GetShareLinksBySheetId(context.Context, int) ([]share.ShareLink, error) {
+	return nil, nil
+}
+func (unusedShareLinkService) RevokeShareLinkById(context.Context, int) error { return nil }
+func (unusedShareLinkService) ResolveToken(context.Context, string) (*share.ShareLink, error) {
+	return nil, nil
+}
+func (unusedShareLinkService) Ping(context.Context) error { return nil }
+
+type unusedAttachmentService struct{}
+
+func (unusedAttachmentService) CreateShotAttachment(context.Context, int, string, []byte) (*attachment.Attachment, error) {
+	return nil, nil
+}
+func (unusedAttachmentService) CreateBeansAttachment(context.Context, int, string, []byte) (*attachment.Attachment, error) {
+	return nil, nil
+}
+func (unusedAttachmentService) GetAttachmentById(context.Context, int) (*attachment.Attachment, error) {
+	return nil, nil
+}
+func (unusedAttachmentService) GetAttachmentsByShotId(context.Context, int) ([]attachment.Attachment, error) {
+	return nil, nil
+}
+func (unusedAttachmentService) GetAttachmentsByBeansId(context.Context, int) ([]attachment.Attachment, error) {
+	return nil, nil
+}
+func (unusedAttachmentService) GetAttachmentContent(context.Context, int, bool) (*attachment.Content, error) {
+	return nil, nil
+}
+func (unusedAttachmentService) DeleteAttachmentById(context.Context, int) error { return nil }
+func (unusedAttachmentService) Ping(context.Context) error                      { return nil }
+
+type unusedSpreadsheetService struct{}
+
+func (unusedSpreadsheetService) Export(context.Context, spreadsheet.Resource, io.Writer) error {
+	return nil
+}
+func (unusedSpreadsheetService) Import(context.Context, spreadsheet.Resource, io.Reader) (*spreadsheet.Report, error) {
+	return nil, nil
+}
 
 func newTestSheetHandler(t *testing.T) (*Handler, *fakeSheetService) {
 	t.Helper()
 	svc := &fakeSheetService{t: t}
-	return NewHandler(svc, unusedRoasterService{}, unusedBeanService{}, unusedShotService{}), svc
+	return NewHandler(svc, unusedRoasterService{}, unusedBeanService{}, unusedShotService{}, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil), svc
 }
 
 // shotsBySheetIDStub is a minimal shot.Service exposing only a configurable
@@ -274,7 +486,8 @@ func TestCreateSheet_HappyPath(t *testing.T) {
 }
 
 func TestCreateSheet_EmptyNameReturns400WithInlineError(t *testing.T) {
-	h, _ := newTestSheetHandler(t)
+	h, svc := newTestSheetHandler(t)
+	svc.getAllSheets = func(context.Context) ([]sheet.Sheet, error) { return nil, nil }
 
 	req := newWebRequest(http.MethodPost, "/sheets/add", "name=", formURLEncoded, "", true)
 	rec := httptest.NewRecorder()
@@ -290,6 +503,7 @@ func TestCreateSheet_EmptyNameReturns400WithInlineError(t *testing.T) {
 
 func TestCreateSheet_DuplicateNameReturns409(t *testing.T) {
 	h, svc := newTestSheetHandler(t)
+	svc.getAllSheets = func(context.Context) ([]sheet.Sheet, error) { return nil, nil }
 	svc.createSheetByName = func(context.Context, string) (*sheet.Sheet, error) {
 		return nil, errors.ErrSheetAlreadyExists
 	}
@@ -307,7 +521,8 @@ func TestCreateSheet_DuplicateNameReturns409(t *testing.T) {
 }
 
 func TestCreateSheet_WrongContentTypeReturns415(t *testing.T) {
-	h, _ := newTestSheetHandler(t)
+	h, svc := newTestSheetHandler(t)
+	svc.getAllSheets = func(context.Context) ([]sheet.Sheet, error) { return nil, nil }
 
 	req := newWebRequest(http.MethodPost, "/sheets/add", `{"name":"Cortado"}`, "application/json", "", true)
 	rec := httptest.NewRecorder()
@@ -319,7 +534,8 @@ func TestCreateSheet_WrongContentTypeReturns415(t *testing.T) {
 }
 
 func TestCreateSheet_MalformedFormBodyReturns400(t *testing.T) {
-	h, _ := newTestSheetHandler(t)
+	h, svc := newTestSheetHandler(t)
+	svc.getAllSheets = func(context.Context) ([]sheet.Sheet, error) { return nil, nil }
 
 	req := newWebRequest(http.MethodPost, "/sheets/add", "name=%zz", formURLEncoded, "", true)
 	rec := httptest.NewRecorder()
@@ -331,7 +547,8 @@ func TestCreateSheet_MalformedFormBodyReturns400(t *testing.T) {
 }
 
 func TestCreateSheet_OversizedBodyReturns413(t *testing.T) {
-	h, _ := newTestSheetHandler(t)
+	h, svc := newTestSheetHandler(t)
+	svc.getAllSheets = func(context.Context) ([]sheet.Sheet, error) { return nil, nil }
 
 	req := newOversizedWebRequest(http.MethodPost, "/sheets/add", formURLEncoded, "")
 	rec := httptest.NewRecorder()
@@ -342,6 +559,81 @@ func TestCreateSheet_OversizedBodyReturns413(t *testing.T) {
 	}
 }
 
+func TestAddSheetForm_ListsOnlyTemplates(t *testing.T) {
+	h, svc := newTestSheetHandler(t)
+	template := testSheet(2, "Espresso template")
+	template.IsTemplate = true
+	svc.getAllSheets = func(context.Context) ([]sheet.Sheet, error) {
+		return []sheet.Sheet{*testSheet(1, "Cortado"), *template}, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.AddSheetForm(rec, newWebRequest(http.MethodGet, "/sheets/add", "", "", "", true))
+
+	body := rec.Body.String()
+	if !strings.Contains(body, `<option value="2">Start from Espresso template</option>`) {
+		t.Errorf("expected the template as a starting point, got: %s", body)
+	}
+	if strings.Contains(body, "Start from Cortado") {
+		t.Errorf("expected only templates as starting points, got: %s", body)
+	}
+	if !strings.Contains(body, `name="copy_best_shot"`) {
+		t.Errorf("expected the copy best shot option, got: %s", body)
+	}
+}
+
+func TestCreateSheet_FromTemplateClonesIt(t *testing.T) {
+	h, svc := newTestSheetHandler(t)
+	svc.cloneSheet = func(_ context.Context, id int, name string, copyBestShot bool) (*sheet.Sheet, error) {
+		if id != 2 || name != "New bag" || !copyBestShot {
+			t.Errorf("CloneSheet(%d, %q, %t), want template 2 cloned to \"New bag\" with its best shot", id, name, copyBestShot)
+		}
+		return testSheet(5, name), nil
+	}
+
+	req := newWebRequest(http.MethodPost, "/sheets/add", "name=New+bag&template_id=2&copy_best_shot=true", formURLEncoded, "", true)
+	rec := httptest.NewRecorder()
+	h.CreateSheet(rec, req)
+
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `id="sheet-row-5"`) {
+		t.Fatalf("expected the new row, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCreateSheet_InvalidTemplateKeepsSubmittedValues(t *testing.T) {
+	h, svc := newTestSheetHandler(t)
+	template := testSheet(2, "Espresso template")
+	template.IsTemplate = true
+	svc.getAllSheets = func(context.Context) ([]sheet.Sheet, error) { return []sheet.Sheet{*template}, nil }
+
+	for _, tt := range []struct {
+		name, templateID string
+		status           int
+		configure        func()
+	}{
+		{name: "malformed id", templateID: "abc", status: http.StatusBadRequest, configure: func() {}},
+		{name: "missing template", templateID: "2", status: http.StatusNotFound, configure: func() {
+			svc.cloneSheet = func(context.Context, int, string, bool) (*sheet.Sheet, error) {
+				return nil, errors.ErrSheetDoesNotExist
+			}
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.configure()
+			req := newWebRequest(http.MethodPost, "/sheets/add", "name=New+bag&template_id="+tt.templateID, formURLEncoded, "", true)
+			rec := httptest.NewRecorder()
+			h.CreateSheet(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), `value="New bag"`) || !strings.Contains(rec.Body.String(), "Start from Espresso template") {
+				t.Errorf("expected the add row with the submitted name and the templates, got: %s", rec.Body.String())
+			}
+		})
+	}
+}
+
 func TestGetSheet_InvalidIDReturns400(t *testing.T) {
 	for _, id := range []string{"", "abc", "0", "-1"} {
 		t.Run(id, func(t *testing.T) {
@@ -372,6 +664,24 @@ func TestGetSheet_UnknownIDReturns404(t *testing.T) {
 	}
 }
 
+func TestGetSheet_FullDetailPageShowsShotStats(t *testing.T) {
+	h, svc := newTestSheetHandler(t)
+	svc.getSheetByID = func(context.Context, int) (*sheet.Sheet, error) { return testSheet(1, "Double shot"), nil }
+	h.StatsService = &fakeStatsService{t: t, getShotStats: func(_ context.Context, subject sql.ShotStatsSubject, id int) (*stats.ShotStats, error) {
+		if subject != sql.ShotStatsOfSheet || id != 1 {
+			t.Errorf("GetShotStats(%q, %d), want the stats of sheet 1", subject, id)
+		}
+		return &stats.ShotStats{}, nil
+	}}
+
+	rec := httptest.NewRecorder()
+	h.GetSheet(rec, newWebRequest(http.MethodGet, "/sheets/get/1", "", "", "1", false))
+
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "No shots yet.") {
+		t.Fatalf("expected the empty shot stats on the detail page, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
 func TestGetSheet_FullDetailPageVsViewContextFragments(t *testing.T) {
 	h, svc := newTestSheetHandler(t)
 	svc.getSheetByID = func(context.Context, int) (*sheet.Sheet, error) { return testSheet(1, "Double shot"), nil }
@@ -404,7 +714,7 @@ func TestGetSheet_DetailPageIncludesShots(t *testing.T) {
 		}
 		return []shot.Shot{{Id: 9, Beans: &bean.Bean{Name: "Ethiopia"}}}, nil
 	}}
-	h := NewHandler(sheetSvc, unusedRoasterService{}, unusedBeanService{}, shotSvc)
+	h := NewHandler(sheetSvc, unusedRoasterService{}, unusedBeanService{}, shotSvc, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil)
 
 	rec := httptest.NewRecorder()
 	h.GetSheet(rec, newWebRequest(http.MethodGet, "/sheets/get/1", "", "", "1", false))
@@ -420,7 +730,7 @@ func TestGetSheet_ShotsFetchErrorReturnsErrorStatusNotOK(t *testing.T) {
 	shotSvc := shotsBySheetIDStub{getShotsBySheetID: func(context.Context, int) ([]shot.Shot, error) {
 		return nil, stderrors.New("boom")
 	}}
-	h := NewHandler(sheetSvc, unusedRoasterService{}, unusedBeanService{}, shotSvc)
+	h := NewHandler(sheetSvc, unusedRoasterService{}, unusedBeanService{}, shotSvc, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil)
 
 	rec := httptest.NewRecorder()
 	h.GetSheet(rec, newWebRequest(http.MethodGet, "/sheets/get/1", "", "", "1", false))
@@ -436,7 +746,7 @@ func TestGetSheet_DetailPageSortsShotsByIDAscending(t *testing.T) {
 	shotSvc := shotsBySheetIDStub{getShotsBySheetID: func(context.Context, int) ([]shot.Shot, error) {
 		return []shot.Shot{{Id: 9}, {Id: 3}, {Id: 5}}, nil
 	}}
-	h := NewHandler(sheetSvc, unusedRoasterService{}, unusedBeanService{}, shotSvc)
+	h := NewHandler(sheetSvc, unusedRoasterService{}, unusedBeanService{}, shotSvc, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil)
 
 	rec := httptest.NewRecorder()
 	h.GetSheet(rec, newWebRequest(http.MethodGet, "/sheets/get/1", "", "", "1", false))
@@ -457,7 +767,7 @@ func TestEditSheetForm_DetailContextSortsShotsByIDAscending(t *testing.T) {
 	shotSvc := shotsBySheetIDStub{getShotsBySheetID: func(context.Context, int) ([]shot.Shot, error) {
 		return []shot.Shot{{Id: 9}, {Id: 3}, {Id: 5}}, nil
 	}}
-	h := NewHandler(sheetSvc, unusedRoasterService{}, unusedBeanService{}, shotSvc)
+	h := NewHandler(sheetSvc, unusedRoasterService{}, unusedBeanService{}, shotSvc, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil)
 
 	rec := httptest.NewRecorder()
 	h.EditSheetForm(rec, newWebRequest(http.MethodGet, "/sheets/update/1?view_context=sheet-detail", "", "", "1", false))
@@ -496,6 +806,26 @@ func TestUpdateSheet_HappyPathBothViewContexts(t *testing.T) {
 	}
 }
 
+func TestUpdateSheet_FlagsTemplate(t *testing.T) {
+	h, svc := newTestSheetHandler(t)
+	svc.updateSheetByID = func(_ context.Context, id int, s *sheet.Sheet) (*sheet.Sheet, error) {
+		if !s.IsTemplate {
+			t.Errorf("UpdateSheetById() sheet = %+v, want a template", s)
+		}
+		updated := testSheet(id, s.Name)
+		updated.IsTemplate = s.IsTemplate
+		return updated, nil
+	}
+
+	req := newWebRequest(http.MethodPut, "/sheets/update/1", "name=Espresso&is_template=true", formURLEncoded, "1", true)
+	rec := httptest.NewRecorder()
+	h.UpdateSheet(rec, req)
+
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "<small>Template</small>") {
+		t.Errorf("expected the updated row flagged as a template, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
 func TestUpdateSheet_EmptyNamePreservesSubmittedValueAndError(t *testing.T) {
 	h, _ := newTestSheetHandler(t)
 
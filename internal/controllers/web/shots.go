@@ -116,6 +116,16 @@ func (h *Handler) AddShotForm(w http.ResponseWriter, r *http.Request) {
 	if lockedID := r.URL.Query().Get("sheet_id"); lockedID != "" {
 		if id, err := strconv.Atoi(lockedID); err == nil && id > 0 {
 			if s, err := h.SheetService.GetSheetById(r.Context(), id); err == nil {
+				// Prefill the form with the latest shot of the sheet, most
+				// new shots being that one with one parameter changed.
+				shots, err := h.ShotService.GetShotsBySheetId(r.Context(), s.Id)
+				if err != nil {
+					h.writeGetError(w, r, mapDomainError(err))
+					return
+				}
+				if latest := shot.Latest(shots); latest != nil {
+					state = againFormState(*latest)
+				}
 				state.SheetLocked = true
 				state.SheetID = strconv.Itoa(s.Id)
 				state.SheetName = s.Name
@@ -148,6 +158,74 @@ func (h *Handler) AddShotForm(w http.ResponseWriter, r *http.Request) {
 	_ = form.Render(r.Context(), w)
 }
 
+// AgainShotForm handles GET /shots/again/:id: the add form prefilled with
+// the shot pulled again from the shot id. Opened from the sheet detail page,
+// the sheet is locked like for its "Add shot" button.
+func (h *Handler) AgainShotForm(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePositiveID(r)
+	if !ok {
+		h.writeGetError(w, r, webError{Status: http.StatusBadRequest, Message: errInvalidShotID})
+		return
+	}
+	s, err := h.ShotService.GetShotById(r.Context(), id)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+	sheets, beans, err := h.shotFormOptions(r)
+	if err != nil {
+		h.writeGetError(w, r, mapDomainError(err))
+		return
+	}
+
+	state := againFormState(*s)
+	if r.URL.Query().Get("view_context") == viewshots.ViewContextSheetShots && s.Sheet != nil {
+		state.SheetLocked = true
+		state.SheetName = s.Sheet.Name
+		state.ViewContext = viewshots.ViewContextSheetShots
+	}
+	form := viewshots.Form(state, sheets, beans, true, "", "")
+
+	if !isHXRequest(r) {
+		allShots, err := h.shotsListForPage(r)
+		if err != nil {
+			h.writeFullPageError(w, r, mapDomainError(err))
+			return
+		}
+		// See AddShotForm: the full-page fallback always renders the
+		// standalone shots page, with the sheet to pick.
+		fallbackState := againFormState(*s)
+		fallbackForm := viewshots.Form(fallbackState, sheets, beans, true, "", "")
+		writeHTMLStatus(w, http.StatusOK)
+		_ = viewshots.Page(allShots, "id", "asc", fallbackForm).Render(r.Context(), w)
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = form.Render(r.Context(), w)
+}
+
+// againFormState prefills an add form with the shot pulled again from s (see
+// shot.Again). Its rating is left blank, to be filled once tasted.
+func againFormState(s shot.Shot) viewshots.FormState {
+	again := shot.Again(s)
+	state := viewshots.FormState{
+		GrindSetting:                 strconv.Itoa(again.GrindSetting),
+		QuantityIn:                   strconv.FormatFloat(again.QuantityIn, 'f', 1, 64),
+		QuantityOut:                  strconv.FormatFloat(again.QuantityOut, 'f', 1, 64),
+		ShotTimeSeconds:              strconv.FormatFloat(again.ShotTime.Seconds(), 'f', 1, 64),
+		WaterTemperature:             strconv.FormatFloat(again.WaterTemperature, 'f', 1, 64),
+		ComparisonWithPreviousResult: strconv.Itoa(int(again.ComparisonWithPreviousResult)),
+	}
+	if again.Sheet != nil {
+		state.SheetID = strconv.Itoa(again.Sheet.Id)
+	}
+	if again.Beans != nil {
+		state.BeansID = strconv.Itoa(again.Beans.Id)
+	}
+	return state
+}
+
 // parseShotForm extracts and validates shot form fields, returning the raw
 // FormState (for redisplay) and, on success, the parsed service model.
 // Rating, comparison, and shot_time range checks are intentionally left to
@@ -306,14 +384,44 @@ func (h *Handler) GetShot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeHTMLStatus(w, http.StatusOK)
 	if !isHXRequest(r) {
-		_ = viewshots.RowPage(*s).Render(r.Context(), w)
+		profile, err := h.ShotService.GetShotProfileById(r.Context(), id)
+		if err != nil {
+			h.writeGetError(w, r, mapDomainError(err))
+			return
+		}
+		photos, err := attachmentsOf(r, id, h.AttachmentService.GetAttachmentsByShotId)
+		if err != nil {
+			h.writeGetError(w, r, mapDomainError(err))
+			return
+		}
+		writeHTMLStatus(w, http.StatusOK)
+		_ = viewshots.RowPage(*s, *profile, photos).Render(r.Context(), w)
 		return
 	}
+	writeHTMLStatus(w, http.StatusOK)
 	_ = viewshots.Row(*s, true, "").Render(r.Context(), w)
 }
 
+// CompareShots handles GET /shots/compare?ids=1,2,3. The ids are either
+// comma separated or repeated, as submitted by the selection checkboxes of
+// the shots tables.
+func (h *Handler) CompareShots(w http.ResponseWriter, r *http.Request) {
+	ids, err := shot.ParseShotIds(strings.Join(r.URL.Query()["ids"], ","))
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+	c, err := h.ShotService.CompareShots(r.Context(), ids)
+	if err != nil {
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewshots.ComparePage(*c).Render(r.Context(), w)
+}
+
 // EditShotForm handles GET /shots/update/:id.
 func (h *Handler) EditShotForm(w http.ResponseWriter, r *http.Request) {
 	id, ok := parsePositiveID(r)
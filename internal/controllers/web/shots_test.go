@@ -5,6 +5,7 @@ import (
 	stderrors "errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -25,6 +26,9 @@ type fakeShotServiceForWeb struct {
 	getShotsBySheetID func(context.Context, int) ([]shot.Shot, error)
 	updateShotByID    func(context.Context, int, *shot.Shot) (*shot.Shot, error)
 	deleteShotByID    func(context.Context, int) error
+	getShotProfile    func(context.Context, int) (*shot.Profile, error)
+	compareShots      func(context.Context, []int) (*shot.Comparison, error)
+	duplicateShot     func(context.Context, int, shot.Overrides) (*shot.Shot, error)
 }
 
 var _ shot.Service = (*fakeShotServiceForWeb)(nil)
@@ -71,6 +75,32 @@ func (f *fakeShotServiceForWeb) DeleteShotById(ctx context.Context, id int) erro
 	return f.deleteShotByID(ctx, id)
 }
 
+func (f *fakeShotServiceForWeb) GetShotProfileById(ctx context.Context, id int) (*shot.Profile, error) {
+	if f.getShotProfile == nil {
+		return &shot.Profile{ShotId: id}, nil
+	}
+	return f.getShotProfile(ctx, id)
+}
+
+func (f *fakeShotServiceForWeb) UpdateShotProfileById(context.Context, int, *shot.Profile) (*shot.Profile, error) {
+	f.t.Fatalf("unexpected UpdateShotProfileById call")
+	return nil, nil
+}
+
+func (f *fakeShotServiceForWeb) CompareShots(ctx context.Context, ids []int) (*shot.Comparison, error) {
+	if f.compareShots == nil {
+		f.t.Fatalf("unexpected CompareShots call")
+	}
+	return f.compareShots(ctx, ids)
+}
+
+func (f *fakeShotServiceForWeb) DuplicateShot(ctx context.Context, id int, overrides shot.Overrides) (*shot.Shot, error) {
+	if f.duplicateShot == nil {
+		f.t.Fatalf("unexpected DuplicateShot call")
+	}
+	return f.duplicateShot(ctx, id, overrides)
+}
+
 func (f *fakeShotServiceForWeb) Ping(context.Context) error { return nil }
 
 // fakeSheetServiceForShots and fakeBeanServiceForShots return fixed,
@@ -105,7 +135,7 @@ func (f fakeBeanServiceForShots) GetAllBeans(context.Context) ([]bean.Bean, erro
 func newTestShotHandler(t *testing.T, sheets []sheet.Sheet, beans []bean.Bean) (*Handler, *fakeShotServiceForWeb) {
 	t.Helper()
 	svc := &fakeShotServiceForWeb{t: t}
-	h := NewHandler(fakeSheetServiceForShots{sheets: sheets}, unusedRoasterService{}, fakeBeanServiceForShots{beans: beans}, svc)
+	h := NewHandler(fakeSheetServiceForShots{sheets: sheets}, unusedRoasterService{}, fakeBeanServiceForShots{beans: beans}, svc, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil)
 	return h, svc
 }
 
@@ -145,7 +175,8 @@ func TestListShots_FullPageVsFragment(t *testing.T) {
 }
 
 func TestAddShotForm_LocksSheetWhenQueryParamGiven(t *testing.T) {
-	h, _ := newTestShotHandler(t, []sheet.Sheet{{Id: 1, Name: "Morning"}}, []bean.Bean{{Id: 2, Name: "Ethiopia"}})
+	h, svc := newTestShotHandler(t, []sheet.Sheet{{Id: 1, Name: "Morning"}}, []bean.Bean{{Id: 2, Name: "Ethiopia"}})
+	svc.getShotsBySheetID = func(context.Context, int) ([]shot.Shot, error) { return nil, nil }
 
 	rec := httptest.NewRecorder()
 	h.AddShotForm(rec, newWebRequest(http.MethodGet, "/shots/add?sheet_id=1", "", "", "", true))
@@ -191,6 +222,7 @@ func TestAddShotForm_FullPageFallbackForDirectNavigation(t *testing.T) {
 func TestAddShotForm_SheetLockedFullPageFallbackOmitsSheetShotsViewContext(t *testing.T) {
 	h, svc := newTestShotHandler(t, []sheet.Sheet{{Id: 1, Name: "Morning"}}, []bean.Bean{{Id: 2, Name: "Ethiopia"}})
 	svc.getAllShots = func(context.Context) ([]shot.Shot, error) { return []shot.Shot{*testShot(1)}, nil }
+	svc.getShotsBySheetID = func(context.Context, int) ([]shot.Shot, error) { return nil, nil }
 
 	rec := httptest.NewRecorder()
 	h.AddShotForm(rec, newWebRequest(http.MethodGet, "/shots/add?sheet_id=1", "", "", "", false))
@@ -207,6 +239,71 @@ func TestAddShotForm_SheetLockedFullPageFallbackOmitsSheetShotsViewContext(t *te
 	}
 }
 
+func TestAddShotForm_SheetLockedPrefillsFromLatestShot(t *testing.T) {
+	h, svc := newTestShotHandler(t, []sheet.Sheet{{Id: 1, Name: "Morning"}}, []bean.Bean{{Id: 2, Name: "Ethiopia"}})
+	svc.getShotsBySheetID = func(_ context.Context, id int) ([]shot.Shot, error) {
+		latest := testShot(8)
+		latest.GrindSetting = 11
+		return []shot.Shot{*testShot(3), *latest}, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.AddShotForm(rec, newWebRequest(http.MethodGet, "/shots/add?sheet_id=1", "", "", "", true))
+
+	body := rec.Body.String()
+	for _, want := range []string{`name="grind_setting" required value="11"`, `name="shot_time" value="28.0"`, `<option value="2" selected>Ethiopia</option>`, `name="rating" required value=""`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected the form prefilled from the latest shot with %q, got: %s", want, body)
+		}
+	}
+	if !strings.Contains(body, `type="hidden" name="sheet_id" value="1"`) {
+		t.Errorf("expected the sheet to stay locked, got: %s", body)
+	}
+}
+
+func TestAgainShotForm_PrefillsAddForm(t *testing.T) {
+	h, svc := newTestShotHandler(t, []sheet.Sheet{{Id: 1, Name: "Morning"}}, []bean.Bean{{Id: 2, Name: "Ethiopia"}})
+	svc.getShotByID = func(context.Context, int) (*shot.Shot, error) { return testShot(5), nil }
+
+	rec := httptest.NewRecorder()
+	h.AgainShotForm(rec, newWebRequest(http.MethodGet, "/shots/again/5", "", "", "5", true))
+
+	body := rec.Body.String()
+	for _, want := range []string{"Add shot", `hx-post="/shots/add"`, `<option value="1" selected>Morning</option>`, `name="quantity_out" required value="36.0"`, `<option value="3" selected>Unknown</option>`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected the add form prefilled with %q, got: %s", want, body)
+		}
+	}
+	if strings.Contains(body, `name="view_context"`) {
+		t.Errorf("expected no view_context outside the sheet detail page, got: %s", body)
+	}
+}
+
+func TestAgainShotForm_SheetShotsContextLocksSheet(t *testing.T) {
+	h, svc := newTestShotHandler(t, []sheet.Sheet{{Id: 1, Name: "Morning"}}, []bean.Bean{{Id: 2, Name: "Ethiopia"}})
+	svc.getShotByID = func(context.Context, int) (*shot.Shot, error) { return testShot(5), nil }
+
+	rec := httptest.NewRecorder()
+	h.AgainShotForm(rec, newWebRequest(http.MethodGet, "/shots/again/5?view_context=sheet-shots", "", "", "5", true))
+
+	body := rec.Body.String()
+	if !strings.Contains(body, `type="hidden" name="sheet_id" value="1"`) || !strings.Contains(body, `name="view_context" value="sheet-shots"`) {
+		t.Errorf("expected the sheet locked with the sheet-shots view context, got: %s", body)
+	}
+}
+
+func TestAgainShotForm_UnknownIDReturns404(t *testing.T) {
+	h, svc := newTestShotHandler(t, nil, nil)
+	svc.getShotByID = func(context.Context, int) (*shot.Shot, error) { return nil, errors.ErrShotDoesNotExist }
+
+	rec := httptest.NewRecorder()
+	h.AgainShotForm(rec, newWebRequest(http.MethodGet, "/shots/again/9", "", "", "9", true))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
 func TestCreateShot_HappyPath(t *testing.T) {
 	h, svc := newTestShotHandler(t, []sheet.Sheet{{Id: 1, Name: "Morning"}}, []bean.Bean{{Id: 2, Name: "Ethiopia"}})
 	svc.createShot = func(_ context.Context, s *shot.Shot) (*shot.Shot, error) {
@@ -538,6 +635,64 @@ func TestGetShot_FullPageFallbackForDirectNavigation(t *testing.T) {
 	}
 }
 
+func TestGetShot_FullPageRendersProfileChart(t *testing.T) {
+	h, svc := newTestShotHandler(t, nil, nil)
+	svc.getShotByID = func(context.Context, int) (*shot.Shot, error) { return testShot(5), nil }
+	pressure := 9.0
+	svc.getShotProfile = func(_ context.Context, id int) (*shot.Profile, error) {
+		return &shot.Profile{ShotId: id, Samples: []shot.ProfileSample{{Time: 0, Pressure: &pressure}, {Time: 25, Pressure: &pressure}}}, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.GetShot(rec, newWebRequest(http.MethodGet, "/shots/get/5", "", "", "5", false))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, `id="shot-profile"`) || !strings.Contains(body, "Pressure (bar)") {
+		t.Fatalf("expected the profile chart on the full page, got %d: %s", rec.Code, body)
+	}
+}
+
+func TestCompareShots_AcceptsRepeatedIDsFromCheckboxes(t *testing.T) {
+	h, svc := newTestShotHandler(t, nil, nil)
+	svc.compareShots = func(_ context.Context, ids []int) (*shot.Comparison, error) {
+		if !slices.Equal(ids, []int{5, 8}) {
+			t.Errorf("CompareShots(%v), want shots 5 and 8", ids)
+		}
+		return shot.Compare([]shot.Shot{*testShot(5), *testShot(8)}), nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.CompareShots(rec, newWebRequest(http.MethodGet, "/shots/compare?ids=5&ids=8", "", "", "", false))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, `id="shots-comparison"`) || !strings.Contains(body, "Shot #8") {
+		t.Fatalf("expected the comparison page, got %d: %s", rec.Code, body)
+	}
+}
+
+func TestCompareShots_SingleShotReturns400(t *testing.T) {
+	h, _ := newTestShotHandler(t, nil, nil)
+
+	rec := httptest.NewRecorder()
+	h.CompareShots(rec, newWebRequest(http.MethodGet, "/shots/compare?ids=5", "", "", "", false))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "Select between 2 and 6 shots to compare.") {
+		t.Fatalf("expected 400 asking for more shots, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCompareShots_UnknownShotReturns404(t *testing.T) {
+	h, svc := newTestShotHandler(t, nil, nil)
+	svc.compareShots = func(context.Context, []int) (*shot.Comparison, error) { return nil, errors.ErrShotDoesNotExist }
+
+	rec := httptest.NewRecorder()
+	h.CompareShots(rec, newWebRequest(http.MethodGet, "/shots/compare?ids=5,99", "", "", "", false))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
 func TestEditShotForm_PrefillsSecondsFromDuration(t *testing.T) {
 	h, svc := newTestShotHandler(t, []sheet.Sheet{{Id: 1, Name: "Morning"}}, []bean.Bean{{Id: 2, Name: "Ethiopia"}})
 	svc.getShotByID = func(context.Context, int) (*shot.Shot, error) { return testShot(5), nil }
@@ -0,0 +1,59 @@
+package web
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/lescactus/espressoapi-go/internal/services/spreadsheet"
+	"github.com/rs/zerolog/hlog"
+)
+
+// ExportSheetsCSV handles GET /sheets/export: the sheets.csv download behind
+// the "Export CSV" button of the sheets list.
+func (h *Handler) ExportSheetsCSV(w http.ResponseWriter, r *http.Request) {
+	h.exportCSV(w, r, spreadsheet.ResourceSheets)
+}
+
+// ExportRoastersCSV handles GET /roasters/export.
+func (h *Handler) ExportRoastersCSV(w http.ResponseWriter, r *http.Request) {
+	h.exportCSV(w, r, spreadsheet.ResourceRoasters)
+}
+
+// ExportBeansCSV handles GET /beans/export.
+func (h *Handler) ExportBeansCSV(w http.ResponseWriter, r *http.Request) {
+	h.exportCSV(w, r, spreadsheet.ResourceBeans)
+}
+
+// ExportShotsCSV handles GET /shots/export.
+func (h *Handler) ExportShotsCSV(w http.ResponseWriter, r *http.Request) {
+	h.exportCSV(w, r, spreadsheet.ResourceShots)
+}
+
+func (h *Handler) exportCSV(w http.ResponseWriter, r *http.Request, resource spreadsheet.Resource) {
+	download := &csvDownload{ResponseWriter: w, filename: string(resource) + ".csv"}
+	if err := h.SpreadsheetService.Export(r.Context(), resource, download); err != nil {
+		if !download.started {
+			h.writeGetError(w, r, mapDomainError(err))
+			return
+		}
+		hlog.FromRequest(r).Error().Err(err).Str("resource", string(resource)).Msg("csv export interrupted")
+	}
+}
+
+// csvDownload streams a CSV file as an attachment, setting its headers on
+// the first write so that an error occurring before it still renders the
+// error page.
+type csvDownload struct {
+	http.ResponseWriter
+	filename string
+	started  bool
+}
+
+func (d *csvDownload) Write(p []byte) (int, error) {
+	if !d.started {
+		d.started = true
+		d.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		d.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": d.filename}))
+	}
+	return d.ResponseWriter.Write(p)
+}
@@ -0,0 +1,90 @@
+package web
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lescactus/espressoapi-go/internal/services/spreadsheet"
+)
+
+// fakeSpreadsheetService overrides the unusedSpreadsheetService export
+// exercised by the "Export CSV" buttons.
+type fakeSpreadsheetService struct {
+	unusedSpreadsheetService
+	t         *testing.T
+	exportCSV func(context.Context, spreadsheet.Resource, io.Writer) error
+}
+
+func (f *fakeSpreadsheetService) Export(ctx context.Context, resource spreadsheet.Resource, w io.Writer) error {
+	if f.exportCSV == nil {
+		f.t.Fatalf("unexpected Export call")
+	}
+	return f.exportCSV(ctx, resource, w)
+}
+
+func newTestSpreadsheetHandler(t *testing.T) (*Handler, *fakeSpreadsheetService) {
+	t.Helper()
+	svc := &fakeSpreadsheetService{t: t}
+	h := NewHandler(unusedSheetService{}, unusedRoasterService{}, unusedBeanService{}, unusedShotService{}, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, svc, unusedSessionService{}, nil)
+	return h, svc
+}
+
+func TestExportCSV_DownloadsFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		export   func(*Handler, http.ResponseWriter, *http.Request)
+		resource spreadsheet.Resource
+	}{
+		{name: "sheets", export: (*Handler).ExportSheetsCSV, resource: spreadsheet.ResourceSheets},
+		{name: "roasters", export: (*Handler).ExportRoastersCSV, resource: spreadsheet.ResourceRoasters},
+		{name: "beans", export: (*Handler).ExportBeansCSV, resource: spreadsheet.ResourceBeans},
+		{name: "shots", export: (*Handler).ExportShotsCSV, resource: spreadsheet.ResourceShots},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, svc := newTestSpreadsheetHandler(t)
+			svc.exportCSV = func(_ context.Context, resource spreadsheet.Resource, w io.Writer) error {
+				if resource != tt.resource {
+					t.Errorf("Export(%q), want %q", resource, tt.resource)
+				}
+				_, err := io.WriteString(w, "id,name\n1,Espresso\n")
+				return err
+			}
+
+			rec := httptest.NewRecorder()
+			tt.export(h, rec, newWebRequest(http.MethodGet, "/"+tt.name+"/export", "", "", "", false))
+
+			if rec.Code != http.StatusOK || rec.Body.String() != "id,name\n1,Espresso\n" {
+				t.Fatalf("expected the csv file, got %d: %s", rec.Code, rec.Body.String())
+			}
+			if got, want := rec.Header().Get("Content-Disposition"), "attachment; filename="+tt.name+".csv"; got != want {
+				t.Errorf("Content-Disposition = %q, want %q", got, want)
+			}
+			if got, want := rec.Header().Get("Content-Type"), "text/csv; charset=utf-8"; got != want {
+				t.Errorf("Content-Type = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestExportCSV_ErrorRendersErrorPage(t *testing.T) {
+	h, svc := newTestSpreadsheetHandler(t)
+	svc.exportCSV = func(context.Context, spreadsheet.Resource, io.Writer) error {
+		return stderrors.New("could not export shots: connection refused")
+	}
+
+	rec := httptest.NewRecorder()
+	h.ExportShotsCSV(rec, newWebRequest(http.MethodGet, "/shots/export", "", "", "", false))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if got := rec.Header().Get("Content-Disposition"); got != "" {
+		t.Errorf("Content-Disposition = %q, want none", got)
+	}
+}
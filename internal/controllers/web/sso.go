@@ -0,0 +1,149 @@
+package web
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/auth/oidc"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/session"
+	viewlogin "github.com/lescactus/espressoapi-go/views/templates/login"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// oidcCookieName is the cookie keeping the state of a login with the
+	// OpenID Connect provider, while the user is away on its login page.
+	oidcCookieName = "espresso_oidc"
+
+	oidcLoginPath = loginPath + "/oidc"
+
+	// oidcFlowLifetime is how long the user has to log in with the provider.
+	oidcFlowLifetime = 10 * time.Minute
+)
+
+var errSSONotConfigured = webError{http.StatusNotFound, "Single sign-on is not configured."}
+
+// oidcFlow is a login with the OpenID Connect provider in progress: the state
+// and nonce it is identified by, the PKCE code verifier, and the local path to
+// go back to once logged in.
+type oidcFlow struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	Next     string `json:"next"`
+}
+
+// OIDCLogin handles GET /login/oidc. It sends the browser to the login page of
+// the OpenID Connect provider.
+func (h *Handler) OIDCLogin(w http.ResponseWriter, r *http.Request) {
+	if h.SSOService == nil {
+		h.writeGetError(w, r, errSSONotConfigured)
+		return
+	}
+
+	flow := oidcFlow{Next: localPath(r.URL.Query().Get("next"))}
+	for _, v := range []*string{&flow.State, &flow.Nonce, &flow.Verifier} {
+		var err error
+		if *v, err = oidc.RandomString(); err != nil {
+			zerolog.Ctx(r.Context()).Err(err).Msg("could not start oidc login")
+			h.writeFullPageError(w, r, mapDomainError(err))
+			return
+		}
+	}
+
+	value, err := json.Marshal(flow)
+	if err != nil {
+		zerolog.Ctx(r.Context()).Err(err).Msg("could not start oidc login")
+		h.writeFullPageError(w, r, mapDomainError(err))
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(value),
+		Path:     oidcLoginPath,
+		MaxAge:   int(oidcFlowLifetime.Seconds()),
+		Secure:   isHTTPS(r),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, h.SSOService.AuthCodeURL(flow.State, flow.Nonce, oidc.CodeChallenge(flow.Verifier)), http.StatusFound)
+}
+
+// OIDCCallback handles GET /login/oidc/callback, where the OpenID Connect
+// provider sends the browser back to. On success, it opens a session like
+// Login does.
+func (h *Handler) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	if h.SSOService == nil {
+		h.writeGetError(w, r, errSSONotConfigured)
+		return
+	}
+
+	flow, ok := oidcFlowFromRequest(r)
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookieName,
+		Value:    "",
+		Path:     oidcLoginPath,
+		MaxAge:   -1,
+		Secure:   isHTTPS(r),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	q := r.URL.Query()
+	var err error
+	switch {
+	case !ok || subtle.ConstantTimeCompare([]byte(q.Get("state")), []byte(flow.State)) != 1:
+		err = fmt.Errorf("%w: state does not match", domainerrors.ErrOIDCLoginFailed)
+	case q.Get("error") != "":
+		err = fmt.Errorf("%w: provider returned %s: %s", domainerrors.ErrOIDCLoginFailed, q.Get("error"), q.Get("error_description"))
+	}
+	if err != nil {
+		zerolog.Ctx(r.Context()).Err(err).Msg("could not log in with oidc")
+	}
+
+	var user *auth.User
+	if err == nil {
+		user, err = h.SSOService.Login(r.Context(), q.Get("code"), flow.Verifier, flow.Nonce)
+	}
+	var s *session.Session
+	var token string
+	if err == nil {
+		s, token, err = h.SessionService.StartSession(r.Context(), user)
+	}
+	if err != nil {
+		we := mapDomainError(err)
+		if we.Status == http.StatusInternalServerError {
+			h.writeFullPageError(w, r, we)
+			return
+		}
+		state := h.loginFormState(flow.Next)
+		state.SSOError = we.Message
+		writeHTMLStatus(w, we.Status)
+		_ = viewlogin.Page(state).Render(r.Context(), w)
+		return
+	}
+
+	setSessionCookie(w, r, s, token)
+	http.Redirect(w, r, flow.Next, http.StatusSeeOther)
+}
+
+// oidcFlowFromRequest returns the login in progress kept in the cookie.
+func oidcFlowFromRequest(r *http.Request) (oidcFlow, bool) {
+	flow := oidcFlow{Next: "/"}
+	cookie, err := r.Cookie(oidcCookieName)
+	if err != nil {
+		return flow, false
+	}
+	value, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil || json.Unmarshal(value, &flow) != nil || flow.State == "" {
+		return oidcFlow{Next: "/"}, false
+	}
+	flow.Next = localPath(flow.Next)
+	return flow, true
+}
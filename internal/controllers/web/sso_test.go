@@ -0,0 +1,194 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/auth/oidc"
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/session"
+	"github.com/lescactus/espressoapi-go/internal/services/sso"
+)
+
+// fakeSSOService stands for an OpenID Connect provider whose login page
+// redirects straight back with the code "the-code".
+type fakeSSOService struct {
+	t        *testing.T
+	loginErr error
+
+	challenge string
+	nonce     string
+}
+
+var _ sso.Service = (*fakeSSOService)(nil)
+
+func (f *fakeSSOService) AuthCodeURL(state, nonce, codeChallenge string) string {
+	f.challenge, f.nonce = codeChallenge, nonce
+	return "https://idp.example.com/authorize?" + url.Values{"state": {state}, "nonce": {nonce}, "code_challenge": {codeChallenge}}.Encode()
+}
+
+func (f *fakeSSOService) Login(ctx context.Context, code, codeVerifier, nonce string) (*auth.User, error) {
+	if code != "the-code" || oidc.CodeChallenge(codeVerifier) != f.challenge || nonce != f.nonce {
+		f.t.Errorf("Login(%q, %q, %q) does not match the login started", code, codeVerifier, nonce)
+	}
+	if f.loginErr != nil {
+		return nil, f.loginErr
+	}
+	return &auth.User{Id: 7, Name: "alice"}, nil
+}
+
+func (f *fakeSSOService) AuthenticateToken(ctx context.Context, token string) (*auth.User, error) {
+	f.t.Fatalf("unexpected AuthenticateToken call")
+	return nil, nil
+}
+
+func newTestSSOHandler(t *testing.T, ssoService sso.Service) (*Handler, *fakeSessionService) {
+	t.Helper()
+	svc := &fakeSessionService{t: t}
+	h := NewHandler(unusedSheetService{}, unusedRoasterService{}, unusedBeanService{}, unusedShotService{}, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, unusedStatsService{}, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, svc, ssoService)
+	return h, svc
+}
+
+// startOIDCLogin runs GET /login/oidc and returns the flow cookie and the
+// state sent to the provider.
+func startOIDCLogin(t *testing.T, h *Handler, next string) (*http.Cookie, string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/login/oidc?next="+url.QueryEscape(next), nil)
+	rec := httptest.NewRecorder()
+
+	h.OIDCLogin(rec, req)
+
+	if rec.Code != http.StatusFound {
+		t.Fatalf("OIDCLogin status = %d, want %d", rec.Code, http.StatusFound)
+	}
+	location, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil || location.Host != "idp.example.com" {
+		t.Fatalf("OIDCLogin Location = %q, want the provider login page", rec.Header().Get("Location"))
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != oidcCookieName || !cookies[0].HttpOnly || cookies[0].Path != "/login/oidc" {
+		t.Fatalf("OIDCLogin cookies = %v, want the oidc flow cookie", cookies)
+	}
+	return cookies[0], location.Query().Get("state")
+}
+
+func TestOIDCLogin_NotConfigured(t *testing.T) {
+	h, _ := newTestSSOHandler(t, nil)
+
+	for _, path := range []string{"/login/oidc", "/login/oidc/callback?code=c&state=s"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		if strings.HasSuffix(req.URL.Path, "/callback") {
+			h.OIDCCallback(rec, req)
+		} else {
+			h.OIDCLogin(rec, req)
+		}
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s status = %d, want %d", path, rec.Code, http.StatusNotFound)
+		}
+	}
+}
+
+func TestLoginForm_SingleSignOnLink(t *testing.T) {
+	h, _ := newTestSSOHandler(t, &fakeSSOService{t: t})
+
+	req := httptest.NewRequest(http.MethodGet, "/login?next=%2Fshots", nil)
+	rec := httptest.NewRecorder()
+
+	h.LoginForm(rec, req)
+
+	if !strings.Contains(rec.Body.String(), `href="/login/oidc?next=%2Fshots"`) {
+		t.Errorf("login form has no single sign-on link: %s", rec.Body.String())
+	}
+}
+
+func TestOIDCCallback(t *testing.T) {
+	tests := []struct {
+		name         string
+		loginErr     error
+		state        func(string) string
+		query        string
+		noCookie     bool
+		wantStatus   int
+		wantLocation string
+		wantBody     string
+	}{
+		{name: "success", wantStatus: http.StatusSeeOther, wantLocation: "/shots"},
+		{
+			name: "state mismatch", state: func(string) string { return "forged" },
+			wantStatus: http.StatusUnauthorized, wantBody: "Single sign-on failed.",
+		},
+		{
+			name: "no login in progress", noCookie: true,
+			wantStatus: http.StatusUnauthorized, wantBody: "Single sign-on failed.",
+		},
+		{
+			name: "provider error", query: "&error=access_denied",
+			wantStatus: http.StatusUnauthorized, wantBody: "Single sign-on failed.",
+		},
+		{
+			name: "unknown identity", loginErr: errors.ErrOIDCIdentityIsUnknown,
+			wantStatus: http.StatusForbidden, wantBody: "No user matches your identity.",
+		},
+		{
+			name: "disabled user", loginErr: errors.ErrUserIsDisabled,
+			wantStatus: http.StatusForbidden, wantBody: "This user is disabled.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeSSOService{t: t, loginErr: tt.loginErr}
+			h, svc := newTestSSOHandler(t, provider)
+			svc.startSession = func(_ context.Context, user *auth.User) (*session.Session, string, error) {
+				if user.Id != 7 || user.Name != "alice" {
+					t.Errorf("StartSession user = %+v, want alice (7)", user)
+				}
+				return testSession(), "session-token", nil
+			}
+
+			cookie, state := startOIDCLogin(t, h, "/shots")
+			if tt.state != nil {
+				state = tt.state(state)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/login/oidc/callback?code=the-code&state="+url.QueryEscape(state)+tt.query, nil)
+			if !tt.noCookie {
+				req.AddCookie(cookie)
+			}
+			rec := httptest.NewRecorder()
+
+			h.OIDCCallback(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d; body = %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if got := rec.Header().Get("Location"); got != tt.wantLocation {
+				t.Errorf("Location = %q, want %q", got, tt.wantLocation)
+			}
+			if tt.wantBody != "" && !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body does not contain %q: %s", tt.wantBody, rec.Body.String())
+			}
+
+			var gotSession bool
+			for _, c := range rec.Result().Cookies() {
+				switch c.Name {
+				case oidcCookieName:
+					if c.MaxAge >= 0 {
+						t.Errorf("oidc flow cookie = %+v, want it cleared", c)
+					}
+				case sessionCookieName:
+					gotSession = c.Value == "session-token"
+				}
+			}
+			if wantSession := tt.wantLocation != ""; gotSession != wantSession {
+				t.Errorf("session cookie set = %v, want %v", gotSession, wantSession)
+			}
+		})
+	}
+}
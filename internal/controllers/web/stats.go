@@ -0,0 +1,44 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+
+	viewstats "github.com/lescactus/espressoapi-go/views/templates/stats"
+)
+
+// Consumption handles GET /stats. An invalid filter redisplays the page with
+// the filter as typed and a 400 status.
+func (h *Handler) Consumption(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := viewstats.Filter{
+		From: strings.TrimSpace(query.Get("from")),
+		To:   strings.TrimSpace(query.Get("to")),
+		TZ:   strings.TrimSpace(query.Get("tz")),
+	}
+
+	from, fromOk := parseReportDate(filter.From)
+	to, toOk := parseReportDate(filter.To)
+	if !fromOk || !toOk {
+		filter.Error = "Dates must be valid, as YYYY-MM-DD."
+		writeHTMLStatus(w, http.StatusBadRequest)
+		_ = viewstats.Page(filter, nil).Render(r.Context(), w)
+		return
+	}
+
+	consumption, err := h.StatsService.GetConsumption(r.Context(), from, to, filter.TZ)
+	if err != nil {
+		we := mapDomainError(err)
+		if we.Status != http.StatusBadRequest {
+			h.writeFullPageError(w, r, we)
+			return
+		}
+		filter.Error = we.Message
+		writeHTMLStatus(w, we.Status)
+		_ = viewstats.Page(filter, nil).Render(r.Context(), w)
+		return
+	}
+
+	writeHTMLStatus(w, http.StatusOK)
+	_ = viewstats.Page(filter, consumption).Render(r.Context(), w)
+}
@@ -0,0 +1,101 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
+)
+
+// fakeStatsService overrides the unusedStatsService methods exercised by the
+// stats page and the beans, roaster and sheet pages.
+type fakeStatsService struct {
+	unusedStatsService
+	t              *testing.T
+	getConsumption func(context.Context, *time.Time, *time.Time, string) (*stats.Consumption, error)
+	getShotStats   func(context.Context, sql.ShotStatsSubject, int) (*stats.ShotStats, error)
+}
+
+func (f *fakeStatsService) GetConsumption(ctx context.Context, from, to *time.Time, timeZone string) (*stats.Consumption, error) {
+	if f.getConsumption == nil {
+		f.t.Fatalf("unexpected GetConsumption call")
+	}
+	return f.getConsumption(ctx, from, to, timeZone)
+}
+
+func (f *fakeStatsService) GetShotStats(ctx context.Context, subject sql.ShotStatsSubject, id int) (*stats.ShotStats, error) {
+	if f.getShotStats == nil {
+		f.t.Fatalf("unexpected GetShotStats call")
+	}
+	return f.getShotStats(ctx, subject, id)
+}
+
+func newTestStatsHandler(t *testing.T) (*Handler, *fakeStatsService) {
+	t.Helper()
+	svc := &fakeStatsService{t: t}
+	h := NewHandler(unusedSheetService{}, unusedRoasterService{}, unusedBeanService{}, unusedShotService{}, unusedCuppingService{}, unusedRoastBatchService{}, unusedGreenCoffeeService{}, unusedReportService{}, svc, unusedMaintenanceService{}, unusedShareLinkService{}, unusedAttachmentService{}, unusedSpreadsheetService{}, unusedSessionService{}, nil)
+	return h, svc
+}
+
+func TestConsumption_RendersHeatmap(t *testing.T) {
+	h, svc := newTestStatsHandler(t)
+	svc.getConsumption = func(_ context.Context, from, to *time.Time, timeZone string) (*stats.Consumption, error) {
+		if from != nil || to == nil || to.Format("2006-01-02") != "2026-10-17" || timeZone != "Europe/Paris" {
+			t.Errorf("GetConsumption(%v, %v, %q), want the parsed filter", from, to, timeZone)
+		}
+		rating := 8.0
+		return &stats.Consumption{
+			From:          time.Date(2026, time.October, 11, 0, 0, 0, 0, time.UTC),
+			To:            time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC),
+			TimeZone:      timeZone,
+			Days:          []stats.ConsumptionDay{{Date: time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC), Shots: 2, CoffeeWeight: 36, AverageRating: 8}},
+			Shots:         2,
+			CoffeeWeight:  36,
+			AverageRating: &rating,
+		}, nil
+	}
+
+	rec := httptest.NewRecorder()
+	h.Consumption(rec, httptest.NewRequest(http.MethodGet, "/stats?to=2026-10-17&tz=Europe/Paris", nil))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, body)
+	}
+	for _, want := range []string{`id="consumption-heatmap"`, `data-date="2026-10-12" data-level="4"`, `id="consumption-summary"`, `value="Europe/Paris"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected the page to contain %q, got: %s", want, body)
+		}
+	}
+}
+
+func TestConsumption_InvalidDateIsABadRequest(t *testing.T) {
+	h, _ := newTestStatsHandler(t)
+
+	rec := httptest.NewRecorder()
+	h.Consumption(rec, httptest.NewRequest(http.MethodGet, "/stats?from=yesterday", nil))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "Dates must be valid, as YYYY-MM-DD.") {
+		t.Errorf("expected a 400 with the date error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestConsumption_InvalidTimeZoneIsABadRequest(t *testing.T) {
+	h, svc := newTestStatsHandler(t)
+	svc.getConsumption = func(context.Context, *time.Time, *time.Time, string) (*stats.Consumption, error) {
+		return nil, errors.ErrStatsTimeZoneIsInvalid
+	}
+
+	rec := httptest.NewRecorder()
+	h.Consumption(rec, httptest.NewRequest(http.MethodGet, "/stats?tz=Mars/Olympus_Mons", nil))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "Time zone must be an IANA name, such as Europe/Paris.") || !strings.Contains(rec.Body.String(), `value="Mars/Olympus_Mons"`) {
+		t.Errorf("expected a 400 redisplaying the filter with its error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
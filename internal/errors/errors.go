@@ -11,12 +11,16 @@ var (
 	ErrRoasterDoesNotExist  = errors.New("roaster does not exists")
 	ErrRoasterNameIsEmpty   = errors.New("roaster name is empty")
 
-	ErrBeansAlreadyExists        = errors.New("beans already exist")
-	ErrBeansDoesNotExist         = errors.New("beans does not exists")
-	ErrBeansForeignKeyConstraint = errors.New("beans foreign key constraint failed")
-	ErrBeansIsNil                = errors.New("beans is nil")
-	ErrBeansNameIsEmpty          = errors.New("beans name is empty")
-	ErrBeansRoastLevelOutOfRange = errors.New("beans roast level is out of range. Must be between 0 and 4")
+	ErrBeansAlreadyExists         = errors.New("beans already exist")
+	ErrBeansDoesNotExist          = errors.New("beans does not exists")
+	ErrBeansForeignKeyConstraint  = errors.New("beans foreign key constraint failed")
+	ErrBeansIsNil                 = errors.New("beans is nil")
+	ErrBeansNameIsEmpty           = errors.New("beans name is empty")
+	ErrBeansRoastLevelOutOfRange  = errors.New("beans roast level is out of range. Must be between 0 and 4")
+	ErrBeansGreenWeightOutOfRange = errors.New("beans green weight is out of range. Must not be negative")
+	ErrBeansPriceOutOfRange       = errors.New("beans price is out of range. Must not be negative")
+	ErrBeansBagWeightOutOfRange   = errors.New("beans bag weight is out of range. Must not be negative")
+	ErrBeansCurrencyIsInvalid     = errors.New("beans currency is invalid. Must be a three-letter ISO 4217 code")
 
 	ErrShotAlreadyExists                          = errors.New("shot already exists")
 	ErrShotDoesNotExist                           = errors.New("shot does not exists")
@@ -24,4 +28,105 @@ var (
 	ErrShotComparisonWithPreviousResultOutOfRange = errors.New("shot comparison with previous result is out of range. Must be between 0 and 3")
 	ErrShotTimeOutOfRange                         = errors.New("shot time is out of range. Must be between 0 and 3600 seconds")
 	ErrShotForeignKeyConstraint                   = errors.New("shot foreign key constraint failed")
+	ErrShotProfileIsInvalid                       = errors.New("shot profile is invalid. Sample times must be distinct and between 0 and 3600 seconds, and values must not be negative")
+	ErrShotProfileHasTooManySamples               = errors.New("shot profile has too many samples. Must be at most 10000")
+	ErrShotCompareIdsAreInvalid                   = errors.New("shot ids to compare are invalid. Must be between 2 and 6 distinct positive ids, separated by commas")
+
+	ErrCuppingSessionDoesNotExist       = errors.New("cupping session does not exists")
+	ErrCuppingSessionDateIsEmpty        = errors.New("cupping session date is empty")
+	ErrCuppingScoreAlreadyExists        = errors.New("cupping score already exists for these beans in this session")
+	ErrCuppingScoreDoesNotExist         = errors.New("cupping score does not exists")
+	ErrCuppingScoreIsNil                = errors.New("cupping score is nil")
+	ErrCuppingScoreOutOfRange           = errors.New("cupping score is out of range. Each attribute must be between 0.0 and 10.0")
+	ErrCuppingScoreForeignKeyConstraint = errors.New("cupping score foreign key constraint failed")
+
+	ErrRoastBatchDoesNotExist         = errors.New("roast batch does not exists")
+	ErrRoastBatchIsNil                = errors.New("roast batch is nil")
+	ErrRoastBatchGreenCoffeeIsEmpty   = errors.New("roast batch green coffee is empty")
+	ErrRoastBatchRoastDateIsEmpty     = errors.New("roast batch roast date is empty")
+	ErrRoastBatchRoastLevelOutOfRange = errors.New("roast batch roast level is out of range. Must be between 0 and 4")
+	ErrRoastBatchWeightOutOfRange     = errors.New("roast batch weight is out of range. Green weight must be positive and roasted weight between 0 and green weight")
+	ErrRoastBatchTimeOutOfRange       = errors.New("roast batch time is out of range. First crack and development times must not be negative")
+	ErrRoastBatchCurveIsInvalid       = errors.New("roast batch curve is invalid. Point times must be distinct and not negative")
+	ErrRoastBatchBeansAlreadyExist    = errors.New("beans were already generated from this roast batch")
+	ErrRoastBatchForeignKeyConstraint = errors.New("roast batch foreign key constraint failed")
+
+	ErrGreenCoffeeDoesNotExist             = errors.New("green coffee does not exists")
+	ErrGreenCoffeeIsNil                    = errors.New("green coffee is nil")
+	ErrGreenCoffeeOriginIsEmpty            = errors.New("green coffee origin is empty")
+	ErrGreenCoffeePurchaseWeightOutOfRange = errors.New("green coffee purchase weight is out of range. Must be positive")
+	ErrGreenCoffeePriceOutOfRange          = errors.New("green coffee price is out of range. Must not be negative")
+	ErrGreenCoffeeMoistureOutOfRange       = errors.New("green coffee moisture is out of range. Must be between 0 and 100")
+
+	ErrSpendReportGroupByIsInvalid = errors.New("spend report group by is invalid. Must be one of month, roaster or beans")
+	ErrSpendReportRangeIsInvalid   = errors.New("spend report range is invalid. From must not be after to")
+
+	ErrMaintenanceTaskDoesNotExist       = errors.New("maintenance task does not exists")
+	ErrMaintenanceTaskIsNil              = errors.New("maintenance task is nil")
+	ErrMaintenanceTaskTypeIsInvalid      = errors.New("maintenance task type is invalid. Must be one of backflush, descale, burr_change, gasket_change, water_filter, grinder_cleaning or other")
+	ErrMaintenanceTaskEquipmentIsInvalid = errors.New("maintenance task equipment is invalid. Must be machine, grinder or empty")
+	ErrMaintenanceTaskIntervalOutOfRange = errors.New("maintenance task interval is out of range. A positive number of shots, days or both is required")
+	ErrMaintenanceTaskLastDoneIsInFuture = errors.New("maintenance task last done date is in the future")
+
+	ErrUserDoesNotExist  = errors.New("user does not exists")
+	ErrUserAlreadyExists = errors.New("user already exists")
+	ErrUserNameIsEmpty   = errors.New("user name is empty")
+	ErrUserIsDisabled    = errors.New("user is disabled")
+	ErrUserRoleIsInvalid = errors.New("user role is invalid. Must be viewer, barista or admin")
+
+	ErrPermissionDenied = errors.New("permission denied. The role of the user does not allow this action")
+
+	ErrUserPasswordIsTooShort = errors.New("user password is too short. Must be at least 8 characters")
+	ErrInvalidCredentials     = errors.New("invalid user name or password")
+
+	ErrSessionDoesNotExist = errors.New("session does not exists")
+	ErrSessionIsInvalid    = errors.New("session is invalid or expired")
+
+	ErrOIDCSubjectIsEmpty    = errors.New("oidc subject is empty")
+	ErrOIDCLoginFailed       = errors.New("oidc login failed")
+	ErrOIDCTokenIsInvalid    = errors.New("oidc token is invalid")
+	ErrOIDCIdentityIsUnknown = errors.New("no user matches the oidc identity")
+
+	ErrAPIKeyDoesNotExist   = errors.New("api key does not exists")
+	ErrAPIKeyNameIsEmpty    = errors.New("api key name is empty")
+	ErrAPIKeyScopeIsInvalid = errors.New("api key scope is invalid. Must be read or write")
+	ErrAPIKeyIsInvalid      = errors.New("api key is invalid")
+	ErrAPIKeyIsRevoked      = errors.New("api key is revoked")
+
+	ErrShareLinkDoesNotExist    = errors.New("share link does not exists")
+	ErrShareLinkIsInvalid       = errors.New("share link is invalid or expired")
+	ErrShareLinkExpiryIsInvalid = errors.New("share link expiry is invalid. Must be in the future and within 365 days")
+
+	ErrAttachmentDoesNotExist      = errors.New("attachment does not exists")
+	ErrAttachmentIsEmpty           = errors.New("attachment is empty")
+	ErrAttachmentTypeIsUnsupported = errors.New("attachment type is unsupported. Must be a JPEG, PNG or GIF image")
+	ErrAttachmentImageIsInvalid    = errors.New("attachment image is invalid or could not be decoded")
+	ErrAttachmentImageIsTooLarge   = errors.New("attachment image is too large. Must not exceed 40 megapixels")
+
+	ErrImportHasNoFiles        = errors.New("import has no files")
+	ErrImportFileIsInvalid     = errors.New("import file is invalid. Must be a Decent .shot file or a visualizer.coffee JSON file")
+	ErrImportShotAlreadyExists = errors.New("shot has already been imported")
+	ErrImportBackupIsInvalid   = errors.New("import backup is invalid. Must be a Beanconqueror JSON backup or its zip archive")
+	ErrImportBrewIsInvalid     = errors.New("brew is invalid. Must have a uuid and beans from the backup")
+
+	ErrCSVIsInvalid    = errors.New("csv is invalid. Must be comma-separated values with a header row naming the columns")
+	ErrCSVRowIsInvalid = errors.New("csv row is invalid")
+
+	ErrBackupIsInvalid          = errors.New("backup is invalid. Must be a JSON backup of espressoapi-go, optionally gzipped")
+	ErrBackupVersionUnsupported = errors.New("backup version is unsupported")
+	ErrBackupRestoreNotEmpty    = errors.New("database is not empty. Restore into an empty database, or merge the backup into the existing records")
+	ErrBackupRestoreConflict    = errors.New("backup record conflicts with another record with the same id. Restore into an empty database instead of merging")
+
+	ErrSeedRecordExists = errors.New("demo record already exists. Reset the database before seeding, or use another seed")
+
+	ErrTransferTargetNotEmpty = errors.New("target database is not empty. Transfer into a database without records")
+	ErrTransferSchemaMismatch = errors.New("source and target databases have different columns. Migrate the source database to the latest version")
+
+	ErrStatsTimeZoneIsInvalid = errors.New("stats time zone is invalid. Must be an IANA time zone name, such as Europe/Paris")
+	ErrStatsRangeIsInvalid    = errors.New("stats range is invalid. From must not be after to")
+	ErrStatsRangeIsTooLong    = errors.New("stats range is too long. Must not exceed 366 days")
+	// ErrStatsTimeZoneIsUnknown is a configuration error of the database:
+	// MySQL does not know IANA time zone names until its time zone tables
+	// are loaded.
+	ErrStatsTimeZoneIsUnknown = errors.New("stats time zone is unknown to the database. On MySQL, load the time zone tables")
 )
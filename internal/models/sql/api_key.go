@@ -0,0 +1,22 @@
+package sql
+
+import "time"
+
+type APIKey struct {
+	Id         int        `db:"id"`
+	UserId     int        `db:"user_id"`
+	Name       string     `db:"name"`
+	Prefix     string     `db:"prefix"`
+	Hash       string     `db:"hash"`
+	Scope      string     `db:"scope"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+	CreatedAt  *time.Time `db:"created_at"`
+	UpdatedAt  *time.Time `db:"updated_at"`
+
+	// UserName, UserRole and UserDisabled are read from the user owning the
+	// key.
+	UserName     string `db:"user_name"`
+	UserRole     string `db:"user_role"`
+	UserDisabled bool   `db:"user_disabled"`
+}
@@ -0,0 +1,20 @@
+package sql
+
+import "time"
+
+// Attachment is a photo of either a shot or beans. Its content and thumbnail
+// are kept in the blob store under BlobKey and ThumbnailKey.
+type Attachment struct {
+	Id           int        `db:"id"`
+	ShotId       *int       `db:"shot_id"`
+	BeansId      *int       `db:"beans_id"`
+	OwnerId      *int       `db:"owner_id"`
+	Filename     string     `db:"filename"`
+	ContentType  string     `db:"content_type"`
+	Size         int64      `db:"size"`
+	Width        int        `db:"width"`
+	Height       int        `db:"height"`
+	BlobKey      string     `db:"blob_key"`
+	ThumbnailKey string     `db:"thumbnail_key"`
+	CreatedAt    *time.Time `db:"created_at"`
+}
@@ -0,0 +1,56 @@
+package sql
+
+// Backup holds the sheets, roasters, beans and shots to restore with their
+// ids and timestamps. Beans reference their roaster and shots their sheet and
+// beans by id only.
+type Backup struct {
+	Sheets   []Sheet
+	Roasters []Roaster
+	Beans    []Beans
+	Shots    []Shot
+	Owners   BackupOwners
+}
+
+// BackupOwners holds the users owning the records of a backup, and the id of
+// the owner of each sheet, roaster, beans and shot by id of the record. The
+// records without an owner are left out.
+type BackupOwners struct {
+	Users    []User
+	Sheets   map[int]int
+	Roasters map[int]int
+	Beans    map[int]int
+	Shots    map[int]int
+}
+
+// Table returns the owners of the records of table by id of the record, or
+// nil for a table which is not part of a backup.
+func (o *BackupOwners) Table(table string) map[int]int {
+	switch table {
+	case "sheets":
+		return o.Sheets
+	case "roasters":
+		return o.Roasters
+	case "beans":
+		return o.Beans
+	case "shots":
+		return o.Shots
+	}
+	return nil
+}
+
+// RestoreCount is the number of records of a table restored from a backup,
+// and of those skipped because a record with the same id already existed.
+type RestoreCount struct {
+	Restored int
+	Skipped  int
+}
+
+// RestoreResult is the outcome of the restore of a backup, per table. Users
+// are restored when missing, and skipped when one with the same name exists.
+type RestoreResult struct {
+	Users    RestoreCount
+	Sheets   RestoreCount
+	Roasters RestoreCount
+	Beans    RestoreCount
+	Shots    RestoreCount
+}
@@ -47,6 +47,14 @@ type Beans struct {
 	Name       string     `db:"name"`
 	RoastDate  *time.Time `db:"roast_date"`
 	RoastLevel RoastLevel `db:"roast_level"`
-	CreatedAt  *time.Time `db:"created_at"`
-	UpdatedAt  *time.Time `db:"updated_at"`
+	// GreenCoffeeId is the green coffee stock the beans were roasted from,
+	// and GreenWeight the grams drawn from it.
+	GreenCoffeeId *int    `db:"green_coffee_id"`
+	GreenWeight   float64 `db:"green_weight"`
+	// Price is what a bag of BagWeight grams cost, in Currency.
+	Price     float64    `db:"price"`
+	Currency  string     `db:"currency"`
+	BagWeight float64    `db:"bag_weight"`
+	CreatedAt *time.Time `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
 }
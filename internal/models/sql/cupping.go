@@ -0,0 +1,31 @@
+package sql
+
+import "time"
+
+type CuppingSession struct {
+	Id           int        `db:"id"`
+	SessionDate  *time.Time `db:"session_date"`
+	Participants string     `db:"participants"`
+	CreatedAt    *time.Time `db:"created_at"`
+	UpdatedAt    *time.Time `db:"updated_at"`
+}
+
+type CuppingScore struct {
+	Id          int        `db:"id"`
+	SessionId   int        `db:"session_id"`
+	SessionDate *time.Time `db:"session_date"`
+	Beans       *Beans     `db:"beans"`
+	Fragrance   float64    `db:"fragrance"`
+	Flavor      float64    `db:"flavor"`
+	Aftertaste  float64    `db:"aftertaste"`
+	Acidity     float64    `db:"acidity"`
+	Body        float64    `db:"body"`
+	Balance     float64    `db:"balance"`
+	Uniformity  float64    `db:"uniformity"`
+	CleanCup    float64    `db:"clean_cup"`
+	Sweetness   float64    `db:"sweetness"`
+	Overall     float64    `db:"overall"`
+	Notes       string     `db:"notes"`
+	CreatedAt   *time.Time `db:"created_at"`
+	UpdatedAt   *time.Time `db:"updated_at"`
+}
@@ -0,0 +1,19 @@
+package sql
+
+import "time"
+
+type GreenCoffee struct {
+	Id             int        `db:"id"`
+	Origin         string     `db:"origin"`
+	Supplier       string     `db:"supplier"`
+	PurchaseWeight float64    `db:"purchase_weight"`
+	Price          float64    `db:"price"`
+	ArrivalDate    *time.Time `db:"arrival_date"`
+	Moisture       float64    `db:"moisture"`
+	CreatedAt      *time.Time `db:"created_at"`
+	UpdatedAt      *time.Time `db:"updated_at"`
+
+	// ConsumedWeight is the green weight, in grams, of the roast batches and
+	// beans drawing on this stock. It is computed when reading.
+	ConsumedWeight float64 `db:"consumed_weight"`
+}
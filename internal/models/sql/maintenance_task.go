@@ -0,0 +1,20 @@
+package sql
+
+import "time"
+
+type MaintenanceTask struct {
+	Id            int        `db:"id"`
+	Type          string     `db:"type"`
+	Equipment     string     `db:"equipment"`
+	EquipmentName string     `db:"equipment_name"`
+	IntervalShots *int       `db:"interval_shots"`
+	IntervalDays  *int       `db:"interval_days"`
+	LastDoneAt    *time.Time `db:"last_done_at"`
+	CreatedAt     *time.Time `db:"created_at"`
+	UpdatedAt     *time.Time `db:"updated_at"`
+
+	// ShotsSinceDone is the number of shots pulled since the task was last
+	// done, or since it was created when it never was. It is computed when
+	// reading.
+	ShotsSinceDone int `db:"shots_since_done"`
+}
@@ -0,0 +1,18 @@
+package sql
+
+import "time"
+
+// ShotCost is a shot pulled from beans with a known price, along with what is
+// needed to cost it and to group it by month, roaster or beans.
+type ShotCost struct {
+	ShotId      int        `db:"shot_id"`
+	QuantityIn  float64    `db:"quantity_in"`
+	CreatedAt   *time.Time `db:"created_at"`
+	BeansId     int        `db:"beans_id"`
+	BeansName   string     `db:"beans_name"`
+	RoasterId   int        `db:"roaster_id"`
+	RoasterName string     `db:"roaster_name"`
+	Price       float64    `db:"price"`
+	Currency    string     `db:"currency"`
+	BagWeight   float64    `db:"bag_weight"`
+}
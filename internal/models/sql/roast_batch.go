@@ -0,0 +1,29 @@
+package sql
+
+import "time"
+
+type RoastBatch struct {
+	Id                int        `db:"id"`
+	GreenCoffee       string     `db:"green_coffee"`
+	RoastDate         *time.Time `db:"roast_date"`
+	RoastLevel        RoastLevel `db:"roast_level"`
+	GreenWeight       float64    `db:"green_weight"`
+	RoastedWeight     float64    `db:"roasted_weight"`
+	ChargeTemperature float64    `db:"charge_temperature"`
+	FirstCrackTime    int        `db:"first_crack_time"`
+	DevelopmentTime   int        `db:"development_time"`
+	EndTemperature    float64    `db:"end_temperature"`
+	GreenCoffeeId     *int       `db:"green_coffee_id"`
+	BeansId           *int       `db:"beans_id"`
+	CreatedAt         *time.Time `db:"created_at"`
+	UpdatedAt         *time.Time `db:"updated_at"`
+
+	// CurvePoints are stored in the roast_curve_points table.
+	CurvePoints []RoastCurvePoint `db:"-"`
+}
+
+type RoastCurvePoint struct {
+	RoastBatchId int     `db:"roast_batch_id"`
+	ElapsedTime  int     `db:"elapsed_time"`
+	Temperature  float64 `db:"temperature"`
+}
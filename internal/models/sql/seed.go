@@ -0,0 +1,12 @@
+package sql
+
+// SeedReset is the number of records of each table deleted by the reset of
+// the database before it is seeded.
+type SeedReset struct {
+	CuppingSessions int
+	RoastBatches    int
+	Shots           int
+	Beans           int
+	Sheets          int
+	Roasters        int
+}
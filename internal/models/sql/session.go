@@ -0,0 +1,18 @@
+package sql
+
+import "time"
+
+type Session struct {
+	Id        int        `db:"id"`
+	UserId    int        `db:"user_id"`
+	TokenHash string     `db:"token_hash"`
+	CSRFToken string     `db:"csrf_token"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	CreatedAt *time.Time `db:"created_at"`
+
+	// UserName, UserRole and UserDisabled are read from the user owning the
+	// session.
+	UserName     string `db:"user_name"`
+	UserRole     string `db:"user_role"`
+	UserDisabled bool   `db:"user_disabled"`
+}
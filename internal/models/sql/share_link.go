@@ -0,0 +1,12 @@
+package sql
+
+import "time"
+
+type ShareLink struct {
+	Id        int        `db:"id"`
+	SheetId   int        `db:"sheet_id"`
+	OwnerId   *int       `db:"owner_id"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+	CreatedAt *time.Time `db:"created_at"`
+}
@@ -3,8 +3,9 @@ package sql
 import "time"
 
 type Sheet struct {
-	Id        int        `db:"id"`
-	Name      string     `db:"name"`
-	CreatedAt *time.Time `db:"created_at"`
-	UpdatedAt *time.Time `db:"updated_at"`
+	Id         int        `db:"id"`
+	Name       string     `db:"name"`
+	IsTemplate bool       `db:"is_template"`
+	CreatedAt  *time.Time `db:"created_at"`
+	UpdatedAt  *time.Time `db:"updated_at"`
 }
@@ -56,3 +56,15 @@ type Shot struct {
 	CreatedAt                    *time.Time                   `db:"created_at"`
 	UpdatedAt                    *time.Time                   `db:"updated_at"`
 }
+
+// ShotProfileSample is one reading of the telemetry recorded by the machine
+// during a shot. Readings the machine does not measure are nil.
+type ShotProfileSample struct {
+	ShotId int `db:"shot_id"`
+	// ElapsedTime is in milliseconds since the start of the shot.
+	ElapsedTime int      `db:"elapsed_time"`
+	Pressure    *float64 `db:"pressure"`
+	Flow        *float64 `db:"flow"`
+	Weight      *float64 `db:"weight"`
+	Temperature *float64 `db:"temperature"`
+}
@@ -0,0 +1,22 @@
+package sql
+
+import "time"
+
+// ImportedShot is a shot read from the file of another application, along
+// with the names of its sheet, roaster and beans, which are created if
+// missing, and its profile.
+type ImportedShot struct {
+	// Source is the application the shot comes from, and ExternalId the id
+	// of the shot in that application, to detect repeated imports.
+	Source     string
+	ExternalId string
+
+	SheetName   string
+	RoasterName string
+	BeansName   string
+	RoastDate   *time.Time
+	RoastLevel  RoastLevel
+
+	Shot    *Shot
+	Profile []ShotProfileSample
+}
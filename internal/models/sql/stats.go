@@ -0,0 +1,42 @@
+package sql
+
+import "time"
+
+// DailyConsumption is what was pulled on one calendar day, in the time zone
+// the day was computed in. AverageRating is the average of the RatedShots,
+// the shots with a rating above 0.
+type DailyConsumption struct {
+	Day           time.Time `db:"shot_date"`
+	Shots         int       `db:"shots"`
+	CoffeeWeight  float64   `db:"coffee_weight"`
+	RatedShots    int       `db:"rated_shots"`
+	AverageRating float64   `db:"average_rating"`
+}
+
+// ShotStatsSubject is what the shots aggregated by ShotStats were pulled
+// with: beans, the beans of a roaster, or a sheet.
+type ShotStatsSubject string
+
+const (
+	ShotStatsOfBeans   ShotStatsSubject = "beans"
+	ShotStatsOfRoaster ShotStatsSubject = "roasters"
+	ShotStatsOfSheet   ShotStatsSubject = "sheets"
+)
+
+// ShotStats aggregates the shots of a subject. Every field but the counts is
+// nil without shots, and the ratings are nil without RatedShots, the shots
+// with a rating above 0.
+type ShotStats struct {
+	Shots             int      `db:"shots"`
+	RatedShots        int      `db:"rated_shots"`
+	AverageRating     *float64 `db:"average_rating"`
+	MedianRating      *float64 `db:"-"`
+	MaxRating         *float64 `db:"max_rating"`
+	BestShotId        *int     `db:"-"`
+	MinGrindSetting   *int     `db:"min_grind_setting"`
+	MaxGrindSetting   *int     `db:"max_grind_setting"`
+	AverageRatio      *float64 `db:"average_ratio"`
+	AverageShotTimeMs *float64 `db:"average_shot_time_ms"`
+	BitterShare       *float64 `db:"bitter_share"`
+	SourShare         *float64 `db:"sour_share"`
+}
@@ -0,0 +1,12 @@
+package sql
+
+import "time"
+
+type User struct {
+	Id        int        `db:"id"`
+	Name      string     `db:"name"`
+	Disabled  bool       `db:"disabled"`
+	Role      string     `db:"role"`
+	CreatedAt *time.Time `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
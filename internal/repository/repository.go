@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/lescactus/espressoapi-go/internal/models/sql"
 )
@@ -13,6 +14,7 @@ type SheetRepository interface {
 	GetAllSheets(ctx context.Context) ([]sql.Sheet, error)
 	UpdateSheetById(ctx context.Context, id int, sheet *sql.Sheet) (*sql.Sheet, error)
 	DeleteSheetById(ctx context.Context, id int) error
+	CloneSheet(ctx context.Context, id int, name string, copyBestShot bool) (int, error)
 	Ping(ctx context.Context) error
 }
 
@@ -42,5 +44,134 @@ type ShotRepository interface {
 	GetShotsBySheetId(ctx context.Context, sheetId int) ([]sql.Shot, error)
 	UpdateShotById(ctx context.Context, id int, shot *sql.Shot) (*sql.Shot, error)
 	DeleteShotById(ctx context.Context, id int) error
+	GetShotProfileById(ctx context.Context, id int) ([]sql.ShotProfileSample, error)
+	UpdateShotProfileById(ctx context.Context, id int, samples []sql.ShotProfileSample) error
+	Ping(ctx context.Context) error
+}
+
+type ShotImportRepository interface {
+	GetImportedShotIds(ctx context.Context, source string, externalIds []string) (map[string]int, error)
+	ImportShot(ctx context.Context, shot *sql.ImportedShot) (int, error)
+	GetShotExternalIds(ctx context.Context, source string) (map[int]string, error)
+	GetOrCreateSheet(ctx context.Context, name string) (int, bool, error)
+	GetOrCreateRoaster(ctx context.Context, name string) (int, bool, error)
+	GetOrCreateBeans(ctx context.Context, beans *sql.Beans) (int, bool, error)
+	Ping(ctx context.Context) error
+}
+
+type BackupRepository interface {
+	GetBackupOwners(ctx context.Context) (*sql.BackupOwners, error)
+	RestoreBackup(ctx context.Context, backup *sql.Backup, merge bool) (*sql.RestoreResult, error)
+	Ping(ctx context.Context) error
+}
+
+type SeedRepository interface {
+	ResetSeed(ctx context.Context) (*sql.SeedReset, error)
+	Ping(ctx context.Context) error
+}
+
+type CuppingRepository interface {
+	CreateCuppingSession(ctx context.Context, session *sql.CuppingSession) (int, error)
+	GetCuppingSessionById(ctx context.Context, id int) (*sql.CuppingSession, error)
+	GetAllCuppingSessions(ctx context.Context) ([]sql.CuppingSession, error)
+	UpdateCuppingSessionById(ctx context.Context, id int, session *sql.CuppingSession) (*sql.CuppingSession, error)
+	DeleteCuppingSessionById(ctx context.Context, id int) error
+	CreateCuppingScore(ctx context.Context, score *sql.CuppingScore) (int, error)
+	GetCuppingScoreById(ctx context.Context, id int) (*sql.CuppingScore, error)
+	GetCuppingScoresBySessionId(ctx context.Context, sessionId int) ([]sql.CuppingScore, error)
+	GetCuppingScoresByBeansId(ctx context.Context, beansId int) ([]sql.CuppingScore, error)
+	UpdateCuppingScoreById(ctx context.Context, id int, score *sql.CuppingScore) (*sql.CuppingScore, error)
+	DeleteCuppingScoreById(ctx context.Context, id int) error
+	Ping(ctx context.Context) error
+}
+
+type RoastBatchRepository interface {
+	CreateRoastBatch(ctx context.Context, batch *sql.RoastBatch) (int, error)
+	GetRoastBatchById(ctx context.Context, id int) (*sql.RoastBatch, error)
+	GetAllRoastBatches(ctx context.Context) ([]sql.RoastBatch, error)
+	UpdateRoastBatchById(ctx context.Context, id int, batch *sql.RoastBatch) (*sql.RoastBatch, error)
+	DeleteRoastBatchById(ctx context.Context, id int) error
+	CreateBeansFromRoastBatch(ctx context.Context, id int, roasterName string) (int, error)
+	Ping(ctx context.Context) error
+}
+
+type GreenCoffeeRepository interface {
+	CreateGreenCoffee(ctx context.Context, greenCoffee *sql.GreenCoffee) (int, error)
+	GetGreenCoffeeById(ctx context.Context, id int) (*sql.GreenCoffee, error)
+	GetAllGreenCoffees(ctx context.Context) ([]sql.GreenCoffee, error)
+	UpdateGreenCoffeeById(ctx context.Context, id int, greenCoffee *sql.GreenCoffee) (*sql.GreenCoffee, error)
+	DeleteGreenCoffeeById(ctx context.Context, id int) error
+	Ping(ctx context.Context) error
+}
+
+type MaintenanceTaskRepository interface {
+	CreateMaintenanceTask(ctx context.Context, task *sql.MaintenanceTask) (int, error)
+	GetMaintenanceTaskById(ctx context.Context, id int) (*sql.MaintenanceTask, error)
+	GetAllMaintenanceTasks(ctx context.Context) ([]sql.MaintenanceTask, error)
+	UpdateMaintenanceTaskById(ctx context.Context, id int, task *sql.MaintenanceTask) (*sql.MaintenanceTask, error)
+	CompleteMaintenanceTaskById(ctx context.Context, id int, doneAt time.Time) (*sql.MaintenanceTask, error)
+	DeleteMaintenanceTaskById(ctx context.Context, id int) error
+	Ping(ctx context.Context) error
+}
+
+type ReportRepository interface {
+	GetShotCosts(ctx context.Context, from, to *time.Time) ([]sql.ShotCost, error)
+	Ping(ctx context.Context) error
+}
+
+type StatsRepository interface {
+	GetDailyConsumption(ctx context.Context, from, to time.Time, timeZone string) ([]sql.DailyConsumption, error)
+	GetShotStats(ctx context.Context, subject sql.ShotStatsSubject, id int) (*sql.ShotStats, error)
+	Ping(ctx context.Context) error
+}
+
+type UserRepository interface {
+	CreateUser(ctx context.Context, user *sql.User) (int, error)
+	GetUserById(ctx context.Context, id int) (*sql.User, error)
+	GetUserByName(ctx context.Context, name string) (*sql.User, error)
+	GetAllUsers(ctx context.Context) ([]sql.User, error)
+	DisableUserByName(ctx context.Context, name string) error
+	SetUserRoleByName(ctx context.Context, name, role string) error
+	SetUserPasswordHashByName(ctx context.Context, name, hash string) error
+	GetUserPasswordHashByName(ctx context.Context, name string) (string, error)
+	GetUserByOIDCSubject(ctx context.Context, subject string) (*sql.User, error)
+	SetUserOIDCSubjectByName(ctx context.Context, name, subject string) error
+	GetUserOIDCSubjectByName(ctx context.Context, name string) (string, error)
+	Ping(ctx context.Context) error
+}
+
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key *sql.APIKey) (int, error)
+	GetAPIKeyById(ctx context.Context, id int) (*sql.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, hash string) (*sql.APIKey, error)
+	GetAllAPIKeys(ctx context.Context) ([]sql.APIKey, error)
+	RevokeAPIKeyById(ctx context.Context, id int, revokedAt time.Time) error
+	UpdateAPIKeyLastUsedAt(ctx context.Context, id int, usedAt time.Time) error
+	Ping(ctx context.Context) error
+}
+
+type ShareLinkRepository interface {
+	CreateShareLink(ctx context.Context, link *sql.ShareLink) (int, error)
+	GetShareLinkById(ctx context.Context, id int) (*sql.ShareLink, error)
+	GetShareLinksBySheetId(ctx context.Context, sheetId int) ([]sql.ShareLink, error)
+	RevokeShareLinkById(ctx context.Context, id int, revokedAt time.Time) error
+	Ping(ctx context.Context) error
+}
+
+type AttachmentRepository interface {
+	CreateAttachment(ctx context.Context, attachment *sql.Attachment) (int, error)
+	GetAttachmentById(ctx context.Context, id int) (*sql.Attachment, error)
+	GetAttachmentsByShotId(ctx context.Context, shotId int) ([]sql.Attachment, error)
+	GetAttachmentsByBeansId(ctx context.Context, beansId int) ([]sql.Attachment, error)
+	DeleteAttachmentById(ctx context.Context, id int) error
+	GetAllBlobKeys(ctx context.Context) ([]string, error)
+	Ping(ctx context.Context) error
+}
+
+type SessionRepository interface {
+	CreateSession(ctx context.Context, session *sql.Session) (int, error)
+	GetSessionByTokenHash(ctx context.Context, tokenHash string) (*sql.Session, error)
+	DeleteSessionByTokenHash(ctx context.Context, tokenHash string) error
+	DeleteExpiredSessions(ctx context.Context, before time.Time) error
 	Ping(ctx context.Context) error
 }
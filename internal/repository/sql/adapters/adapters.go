@@ -15,7 +15,7 @@ func MySQL() shared.Dialect {
 	return shared.Dialect{
 		Rebind:     func(query string) string { return query },
 		ParseError: mysqlerrors.ParseMySQLError,
-		InsertID: func(ctx context.Context, db *sqlx.DB, query string, entity *sqlerrors.Entity, args ...any) (int, error) {
+		InsertID: func(ctx context.Context, db sqlx.ExtContext, query string, entity *sqlerrors.Entity, args ...any) (int, error) {
 			result, err := db.ExecContext(ctx, query, args...)
 			if err != nil {
 				return 0, mysqlerrors.ParseMySQLError(err, entity, fmt.Errorf("failed to insert record to the database: %w", err))
@@ -26,6 +26,17 @@ func MySQL() shared.Dialect {
 			}
 			return int(id), nil
 		},
+		// CONVERT_TZ needs the server's time zone tables to know zone names:
+		// without them it returns NULL, which the stats repository reports
+		// as ErrStatsTimeZoneIsUnknown.
+		LocalDate: func(column string) string {
+			return "DATE(CONVERT_TZ(" + column + ", @@session.time_zone, ?))"
+		},
+		// InnoDB moves the AUTO_INCREMENT counter past the ids inserted
+		// explicitly, so there is nothing to reset.
+		ResetSequence: func(context.Context, sqlx.ExecerContext, string) error {
+			return nil
+		},
 	}
 }
 
@@ -33,12 +44,22 @@ func PostgreSQL() shared.Dialect {
 	return shared.Dialect{
 		Rebind:     func(query string) string { return sqlx.Rebind(sqlx.DOLLAR, query) },
 		ParseError: postgreserrors.ParsePostgresError,
-		InsertID: func(ctx context.Context, db *sqlx.DB, query string, entity *sqlerrors.Entity, args ...any) (int, error) {
+		InsertID: func(ctx context.Context, db sqlx.ExtContext, query string, entity *sqlerrors.Entity, args ...any) (int, error) {
 			var id int
 			if err := db.QueryRowxContext(ctx, query+" RETURNING id", args...).Scan(&id); err != nil {
 				return 0, postgreserrors.ParsePostgresError(err, entity, fmt.Errorf("failed to insert record to the database: %w", err))
 			}
 			return id, nil
 		},
+		LocalDate: func(column string) string {
+			return "CAST(" + column + " AT TIME ZONE ? AS DATE)"
+		},
+		ResetSequence: func(ctx context.Context, db sqlx.ExecerContext, table string) error {
+			query := "SELECT setval(pg_get_serial_sequence('" + table + "', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM " + table
+			if _, err := db.ExecContext(ctx, query); err != nil {
+				return fmt.Errorf("failed to reset the id sequence of %s: %w", table, err)
+			}
+			return nil
+		},
 	}
 }
@@ -11,6 +11,17 @@ const (
 	EntityRoaster Entity = "roasters"
 	EntityBeans   Entity = "beans"
 	EntityShot    Entity = "shots"
+
+	EntityCuppingSession  Entity = "cupping_sessions"
+	EntityCuppingScore    Entity = "cupping_scores"
+	EntityRoastBatch      Entity = "roast_batches"
+	EntityGreenCoffee     Entity = "green_coffees"
+	EntityMaintenanceTask Entity = "maintenance_tasks"
+	EntityUser            Entity = "users"
+	EntityAPIKey          Entity = "api_keys"
+	EntitySession         Entity = "sessions"
+	EntityShareLink       Entity = "share_links"
+	EntityAttachment      Entity = "attachments"
 )
 
 // EntityToErrAlreadyExists maps entities to duplicate-entry domain errors.
@@ -19,12 +30,18 @@ var EntityToErrAlreadyExists = map[Entity]error{
 	EntityRoaster: domainerrors.ErrRoasterAlreadyExists,
 	EntityBeans:   domainerrors.ErrBeansAlreadyExists,
 	EntityShot:    domainerrors.ErrShotAlreadyExists,
+
+	EntityCuppingScore: domainerrors.ErrCuppingScoreAlreadyExists,
+	EntityUser:         domainerrors.ErrUserAlreadyExists,
 }
 
 // EntityToErrForeignKeyConstraint maps entities to delete constraint errors.
 var EntityToErrForeignKeyConstraint = map[Entity]error{
 	EntityBeans: domainerrors.ErrBeansForeignKeyConstraint,
 	EntityShot:  domainerrors.ErrShotForeignKeyConstraint,
+
+	EntityCuppingScore: domainerrors.ErrCuppingScoreForeignKeyConstraint,
+	EntityRoastBatch:   domainerrors.ErrRoastBatchForeignKeyConstraint,
 }
 
 // EntityToErrDoesNotExist maps entities to missing-record domain errors.
@@ -33,6 +50,17 @@ var EntityToErrDoesNotExist = map[Entity]error{
 	EntityRoaster: domainerrors.ErrRoasterDoesNotExist,
 	EntityBeans:   domainerrors.ErrBeansDoesNotExist,
 	EntityShot:    domainerrors.ErrShotDoesNotExist,
+
+	EntityCuppingSession:  domainerrors.ErrCuppingSessionDoesNotExist,
+	EntityCuppingScore:    domainerrors.ErrCuppingScoreDoesNotExist,
+	EntityRoastBatch:      domainerrors.ErrRoastBatchDoesNotExist,
+	EntityGreenCoffee:     domainerrors.ErrGreenCoffeeDoesNotExist,
+	EntityMaintenanceTask: domainerrors.ErrMaintenanceTaskDoesNotExist,
+	EntityUser:            domainerrors.ErrUserDoesNotExist,
+	EntityAPIKey:          domainerrors.ErrAPIKeyDoesNotExist,
+	EntitySession:         domainerrors.ErrSessionDoesNotExist,
+	EntityShareLink:       domainerrors.ErrShareLinkDoesNotExist,
+	EntityAttachment:      domainerrors.ErrAttachmentDoesNotExist,
 }
 
 // MappedEntityError returns the mapped error for an entity or the fallback.
@@ -0,0 +1,18 @@
+package apikey
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+var _ repository.APIKeyRepository = (*APIKey)(nil)
+
+type APIKey struct {
+	*shared.APIKey
+}
+
+func New(db *sqlx.DB) *APIKey {
+	return &APIKey{shared.NewAPIKey(db, adapters.MySQL())}
+}
@@ -0,0 +1,146 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const selectAPIKeyQuery = `
+SELECT
+	api_keys.id,
+	api_keys.user_id,
+	api_keys.name,
+	api_keys.prefix,
+	api_keys.hash,
+	api_keys.scope,
+	api_keys.last_used_at,
+	api_keys.revoked_at,
+	api_keys.created_at,
+	api_keys.updated_at,
+	users.name AS user_name,
+	users.role AS user_role,
+	users.disabled AS user_disabled
+FROM api_keys
+	INNER JOIN users ON api_keys.user_id = users.id`
+
+var apiKeyColumns = []string{
+	"id", "user_id", "name", "prefix", "hash", "scope", "last_used_at", "revoked_at", "created_at", "updated_at", "user_name", "user_role", "user_disabled",
+}
+
+func TestAPIKeyRepositoryMySQLBehavior(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 16, 0, 0, 0, time.UTC)
+	hash := "4f2c1d0e9b8a7f6e5d4c3b2a1f0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e"
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *APIKey, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create returns the inserted id",
+			run: func(t *testing.T, repository *APIKey, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO api_keys (user_id, name, prefix, hash, scope) VALUES (?, ?, ?, ?, ?)").
+					WithArgs(4, "grafana", "esp_abcdefgh", hash, "read").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+
+				id, err := repository.CreateAPIKey(context.Background(), &sql.APIKey{UserId: 4, Name: "grafana", Prefix: "esp_abcdefgh", Hash: hash, Scope: "read"})
+				if err != nil {
+					t.Fatalf("CreateAPIKey() error = %v", err)
+				}
+				if id != 2 {
+					t.Errorf("CreateAPIKey() id = %d, want 2", id)
+				}
+			},
+		},
+		{
+			name: "create for missing user returns user does not exist",
+			run: func(t *testing.T, repository *APIKey, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO api_keys (user_id, name, prefix, hash, scope) VALUES (?, ?, ?, ?, ?)").
+					WithArgs(9, "grafana", "esp_abcdefgh", hash, "read").
+					WillReturnError(&mysql.MySQLError{
+						Number:  1452,
+						Message: "Cannot add or update a child row: a foreign key constraint fails (`espresso-api`.`api_keys`, CONSTRAINT `fk_api_keys_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`))",
+					})
+
+				_, err := repository.CreateAPIKey(context.Background(), &sql.APIKey{UserId: 9, Name: "grafana", Prefix: "esp_abcdefgh", Hash: hash, Scope: "read"})
+				if !errors.Is(err, domainerrors.ErrUserDoesNotExist) {
+					t.Fatalf("CreateAPIKey() error = %v, want %v", err, domainerrors.ErrUserDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "get by hash reads the owning user",
+			run: func(t *testing.T, repository *APIKey, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectAPIKeyQuery + "\nWHERE api_keys.hash = ?").WithArgs(hash).
+					WillReturnRows(sqlmock.NewRows(apiKeyColumns).
+						AddRow(2, 4, "grafana", "esp_abcdefgh", hash, "read", nil, nil, now, nil, "alice", "barista", false))
+
+				got, err := repository.GetAPIKeyByHash(context.Background(), hash)
+				if err != nil {
+					t.Fatalf("GetAPIKeyByHash() error = %v", err)
+				}
+				if got.Id != 2 || got.UserName != "alice" || got.UserRole != "barista" || got.UserDisabled || got.RevokedAt != nil {
+					t.Errorf("GetAPIKeyByHash() = %+v, want key 2 of enabled user alice", got)
+				}
+			},
+		},
+		{
+			name: "get by unknown hash returns does not exist",
+			run: func(t *testing.T, repository *APIKey, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectAPIKeyQuery + "\nWHERE api_keys.hash = ?").WithArgs(hash).
+					WillReturnRows(sqlmock.NewRows(apiKeyColumns))
+
+				_, err := repository.GetAPIKeyByHash(context.Background(), hash)
+				if !errors.Is(err, domainerrors.ErrAPIKeyDoesNotExist) {
+					t.Fatalf("GetAPIKeyByHash() error = %v, want %v", err, domainerrors.ErrAPIKeyDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "revoke missing key returns does not exist",
+			run: func(t *testing.T, repository *APIKey, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE api_keys SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?").WithArgs(now, 9).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(selectAPIKeyQuery + "\nWHERE api_keys.id = ?").WithArgs(9).
+					WillReturnRows(sqlmock.NewRows(apiKeyColumns))
+
+				err := repository.RevokeAPIKeyById(context.Background(), 9, now)
+				if !errors.Is(err, domainerrors.ErrAPIKeyDoesNotExist) {
+					t.Fatalf("RevokeAPIKeyById() error = %v, want %v", err, domainerrors.ErrAPIKeyDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "update last use",
+			run: func(t *testing.T, repository *APIKey, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE api_keys SET last_used_at = ? WHERE id = ?").WithArgs(now, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				if err := repository.UpdateAPIKeyLastUsedAt(context.Background(), 2, now); err != nil {
+					t.Fatalf("UpdateAPIKeyLastUsedAt() error = %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
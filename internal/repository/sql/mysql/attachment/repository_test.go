@@ -0,0 +1,183 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const selectAttachmentQuery = `
+SELECT
+	id,
+	shot_id,
+	beans_id,
+	owner_id,
+	filename,
+	content_type,
+	size,
+	width,
+	height,
+	blob_key,
+	thumbnail_key,
+	created_at
+FROM attachments`
+
+const insertAttachmentQuery = "INSERT INTO attachments (shot_id, beans_id, owner_id, filename, content_type, size, width, height, blob_key, thumbnail_key) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+
+var attachmentColumns = []string{"id", "shot_id", "beans_id", "owner_id", "filename", "content_type", "size", "width", "height", "blob_key", "thumbnail_key", "created_at"}
+
+func TestAttachmentRepositoryMySQLBehavior(t *testing.T) {
+	aliceCtx := auth.NewContext(context.Background(), &auth.User{Id: 7, Name: "alice"})
+	now := time.Date(2026, time.October, 18, 21, 0, 0, 0, time.UTC)
+	shotId, beansId := 3, 4
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *Attachment, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create for a shot records the owner of the attachment",
+			run: func(t *testing.T, repository *Attachment, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(*) FROM shots WHERE id = ?\n\tAND owner_id = ?").WithArgs(3, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec(insertAttachmentQuery).
+					WithArgs(&shotId, nil, 7, "puck.jpg", "image/jpeg", int64(2048), 640, 480, "attachments/a", "attachments/a-thumbnail").
+					WillReturnResult(sqlmock.NewResult(5, 1))
+
+				id, err := repository.CreateAttachment(aliceCtx, &sql.Attachment{
+					ShotId: &shotId, Filename: "puck.jpg", ContentType: "image/jpeg", Size: 2048, Width: 640, Height: 480,
+					BlobKey: "attachments/a", ThumbnailKey: "attachments/a-thumbnail",
+				})
+				if err != nil {
+					t.Fatalf("CreateAttachment() error = %v", err)
+				}
+				if id != 5 {
+					t.Errorf("CreateAttachment() id = %d, want 5", id)
+				}
+			},
+		},
+		{
+			name: "create for beans of another user returns beans does not exist",
+			run: func(t *testing.T, repository *Attachment, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(*) FROM beans WHERE id = ?\n\tAND owner_id = ?").WithArgs(4, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				_, err := repository.CreateAttachment(aliceCtx, &sql.Attachment{BeansId: &beansId})
+				if !errors.Is(err, domainerrors.ErrBeansDoesNotExist) {
+					t.Fatalf("CreateAttachment() error = %v, want %v", err, domainerrors.ErrBeansDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "create for a missing shot returns shot does not exist",
+			run: func(t *testing.T, repository *Attachment, mock sqlmock.Sqlmock) {
+				mock.ExpectExec(insertAttachmentQuery).
+					WithArgs(&shotId, nil, nil, "puck.jpg", "image/jpeg", int64(2048), 640, 480, "attachments/a", "attachments/a-thumbnail").
+					WillReturnError(&mysql.MySQLError{
+						Number:  1452,
+						Message: "Cannot add or update a child row: a foreign key constraint fails (`espresso-api`.`attachments`, CONSTRAINT `fk_attachments_shot` FOREIGN KEY (`shot_id`) REFERENCES `shots` (`id`) ON DELETE CASCADE)",
+					})
+
+				_, err := repository.CreateAttachment(context.Background(), &sql.Attachment{
+					ShotId: &shotId, Filename: "puck.jpg", ContentType: "image/jpeg", Size: 2048, Width: 640, Height: 480,
+					BlobKey: "attachments/a", ThumbnailKey: "attachments/a-thumbnail",
+				})
+				if !errors.Is(err, domainerrors.ErrShotDoesNotExist) {
+					t.Fatalf("CreateAttachment() error = %v, want %v", err, domainerrors.ErrShotDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "get an attachment of another user returns does not exist",
+			run: func(t *testing.T, repository *Attachment, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectAttachmentQuery+"\nWHERE id = ?\n\tAND owner_id = ?").WithArgs(5, 7).
+					WillReturnRows(sqlmock.NewRows(attachmentColumns))
+
+				_, err := repository.GetAttachmentById(aliceCtx, 5)
+				if !errors.Is(err, domainerrors.ErrAttachmentDoesNotExist) {
+					t.Fatalf("GetAttachmentById() error = %v, want %v", err, domainerrors.ErrAttachmentDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "get by shot checks the owner of the shot",
+			run: func(t *testing.T, repository *Attachment, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(*) FROM shots WHERE id = ?\n\tAND owner_id = ?").WithArgs(3, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(selectAttachmentQuery + "\nWHERE shot_id = ?\nORDER BY id").WithArgs(3).
+					WillReturnRows(sqlmock.NewRows(attachmentColumns).
+						AddRow(5, 3, nil, 7, "puck.jpg", "image/jpeg", 2048, 640, 480, "attachments/a", "attachments/a-thumbnail", now).
+						AddRow(6, 3, nil, 7, "cup.png", "image/png", 4096, 800, 600, "attachments/b", "attachments/b-thumbnail", now))
+
+				got, err := repository.GetAttachmentsByShotId(aliceCtx, 3)
+				if err != nil {
+					t.Fatalf("GetAttachmentsByShotId() error = %v", err)
+				}
+				if len(got) != 2 || *got[0].ShotId != 3 || got[0].BeansId != nil || got[1].ContentType != "image/png" {
+					t.Errorf("GetAttachmentsByShotId() = %+v, want attachments 5 and 6 of shot 3", got)
+				}
+			},
+		},
+		{
+			name: "get by beans of another user returns beans does not exist",
+			run: func(t *testing.T, repository *Attachment, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(*) FROM beans WHERE id = ?\n\tAND owner_id = ?").WithArgs(4, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				_, err := repository.GetAttachmentsByBeansId(aliceCtx, 4)
+				if !errors.Is(err, domainerrors.ErrBeansDoesNotExist) {
+					t.Fatalf("GetAttachmentsByBeansId() error = %v, want %v", err, domainerrors.ErrBeansDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "delete is scoped to the owner",
+			run: func(t *testing.T, repository *Attachment, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM attachments WHERE id = ?\n\tAND owner_id = ?").WithArgs(5, 7).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				err := repository.DeleteAttachmentById(aliceCtx, 5)
+				if !errors.Is(err, domainerrors.ErrAttachmentDoesNotExist) {
+					t.Fatalf("DeleteAttachmentById() error = %v, want %v", err, domainerrors.ErrAttachmentDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "get all blob keys is not scoped to the owner",
+			run: func(t *testing.T, repository *Attachment, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT blob_key FROM attachments UNION ALL SELECT thumbnail_key FROM attachments").
+					WillReturnRows(sqlmock.NewRows([]string{"blob_key"}).AddRow("attachments/a").AddRow("attachments/a-thumbnail"))
+
+				keys, err := repository.GetAllBlobKeys(aliceCtx)
+				if err != nil {
+					t.Fatalf("GetAllBlobKeys() error = %v", err)
+				}
+				if len(keys) != 2 {
+					t.Errorf("GetAllBlobKeys() = %v, want the file and thumbnail keys", keys)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
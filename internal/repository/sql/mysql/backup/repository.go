@@ -0,0 +1,18 @@
+package backup
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+var _ repository.BackupRepository = (*Backup)(nil)
+
+type Backup struct {
+	*shared.Backup
+}
+
+func New(db *sqlx.DB) *Backup {
+	return &Backup{shared.NewBackup(db, adapters.MySQL())}
+}
@@ -0,0 +1,257 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const (
+	insertRoasterQuery = "INSERT INTO roasters (id, name, created_at, updated_at, owner_id) VALUES (?, ?, COALESCE(?, CURRENT_TIMESTAMP), ?, ?)"
+	insertSheetQuery   = "INSERT INTO sheets (id, name, is_template, created_at, updated_at, owner_id) VALUES (?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP), ?, ?)"
+	insertBeansQuery   = "INSERT INTO beans (id, name, roaster_id, roast_date, roast_level, green_coffee_id, green_weight, price, currency, bag_weight, created_at, updated_at, owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP), ?, ?)"
+	insertShotQuery    = "INSERT INTO shots (id, sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, created_at, updated_at, owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP), ?, ?)"
+)
+
+func TestBackupRepositoryMySQLRestoreBackup(t *testing.T) {
+	aliceCtx := auth.NewContext(context.Background(), &auth.User{Id: 7, Name: "alice"})
+	created := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	greenCoffeeId := 2
+	backup := func() *sql.Backup {
+		return &sql.Backup{
+			Roasters: []sql.Roaster{{Id: 4, Name: "Square Mile", CreatedAt: &created}},
+			Sheets:   []sql.Sheet{{Id: 3, Name: "Linea Mini", IsTemplate: true, CreatedAt: &created}},
+			Beans: []sql.Beans{{Id: 5, Name: "Red Brick", Roaster: &sql.Roaster{Id: 4}, RoastLevel: sql.RoastLevelMedium,
+				GreenCoffeeId: &greenCoffeeId, Price: 14.5, Currency: "GBP", BagWeight: 350, CreatedAt: &created}},
+			Shots: []sql.Shot{{Id: 9, Sheet: &sql.Sheet{Id: 3}, Beans: &sql.Beans{Id: 5}, GrindSetting: 12, QuantityIn: 18, QuantityOut: 36,
+				ShotTime: 28500 * time.Millisecond, WaterTemperature: 93, Rating: 8, ComparisonWithPreviousResult: sql.Unknown, AdditionalNotes: "sweet", CreatedAt: &created, UpdatedAt: &created}},
+		}
+	}
+	expectEmpty := func(mock sqlmock.Sqlmock, counts ...int) {
+		for i, table := range []string{"roasters", "sheets", "beans", "shots"} {
+			if i == len(counts) {
+				return
+			}
+			mock.ExpectQuery("SELECT COUNT(*) FROM " + table).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(counts[i]))
+		}
+	}
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *Backup, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "restore keeps the ids and timestamps in a single transaction",
+			run: func(t *testing.T, repository *Backup, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectEmpty(mock, 0, 0, 0, 0)
+				mock.ExpectExec(insertRoasterQuery).WithArgs(4, "Square Mile", &created, nil, nil).WillReturnResult(sqlmock.NewResult(4, 1))
+				mock.ExpectExec(insertSheetQuery).WithArgs(3, "Linea Mini", true, &created, nil, nil).WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectQuery("SELECT COUNT(*) FROM green_coffees WHERE id = ?").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec(insertBeansQuery).
+					WithArgs(5, "Red Brick", 4, nil, sql.RoastLevelMedium, &greenCoffeeId, 0.0, 14.5, "GBP", 350.0, &created, nil, nil).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectExec(insertShotQuery).
+					WithArgs(9, 3, 5, 12, 18.0, 36.0, int64(28500), 93.0, 8.0, false, false, sql.Unknown, "sweet", &created, &created, nil).
+					WillReturnResult(sqlmock.NewResult(9, 1))
+				mock.ExpectCommit()
+
+				result, err := repository.RestoreBackup(context.Background(), backup(), false)
+				if err != nil {
+					t.Fatalf("RestoreBackup() error = %v", err)
+				}
+				want := sql.RestoreResult{
+					Sheets: sql.RestoreCount{Restored: 1}, Roasters: sql.RestoreCount{Restored: 1},
+					Beans: sql.RestoreCount{Restored: 1}, Shots: sql.RestoreCount{Restored: 1},
+				}
+				if *result != want {
+					t.Errorf("RestoreBackup() = %+v, want %+v", *result, want)
+				}
+			},
+		},
+		{
+			name: "restore into a database with records rolls back",
+			run: func(t *testing.T, repository *Backup, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectEmpty(mock, 0, 2)
+				mock.ExpectRollback()
+
+				_, err := repository.RestoreBackup(context.Background(), backup(), false)
+				if !errors.Is(err, domainerrors.ErrBackupRestoreNotEmpty) {
+					t.Fatalf("RestoreBackup() error = %v, want %v", err, domainerrors.ErrBackupRestoreNotEmpty)
+				}
+			},
+		},
+		{
+			name: "merge skips existing ids and drops missing green coffees",
+			run: func(t *testing.T, repository *Backup, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(*) FROM roasters WHERE id = ?").WithArgs(4).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("SELECT COUNT(*) FROM roasters WHERE id = ? AND COALESCE(owner_id, 0) = ? AND name = ?").WithArgs(4, 7, "Square Mile").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("SELECT COUNT(*) FROM sheets WHERE id = ?").WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("SELECT COUNT(*) FROM sheets WHERE id = ? AND COALESCE(owner_id, 0) = ? AND name = ?").WithArgs(3, 7, "Linea Mini").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("SELECT COUNT(*) FROM green_coffees WHERE id = ?").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery("SELECT COUNT(*) FROM beans WHERE id = ?").WithArgs(5).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec(insertBeansQuery).
+					WithArgs(5, "Red Brick", 4, nil, sql.RoastLevelMedium, nil, 0.0, 14.5, "GBP", 350.0, &created, nil, 7).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectQuery("SELECT COUNT(*) FROM shots WHERE id = ?").WithArgs(9).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec(insertShotQuery).
+					WithArgs(9, 3, 5, 12, 18.0, 36.0, int64(28500), 93.0, 8.0, false, false, sql.Unknown, "sweet", &created, &created, 7).
+					WillReturnResult(sqlmock.NewResult(9, 1))
+				mock.ExpectCommit()
+
+				result, err := repository.RestoreBackup(aliceCtx, backup(), true)
+				if err != nil {
+					t.Fatalf("RestoreBackup() error = %v", err)
+				}
+				want := sql.RestoreResult{
+					Sheets: sql.RestoreCount{Skipped: 1}, Roasters: sql.RestoreCount{Skipped: 1},
+					Beans: sql.RestoreCount{Restored: 1}, Shots: sql.RestoreCount{Restored: 1},
+				}
+				if *result != want {
+					t.Errorf("RestoreBackup() = %+v, want %+v", *result, want)
+				}
+			},
+		},
+		{
+			name: "merge of an id held by another record rolls back",
+			run: func(t *testing.T, repository *Backup, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(*) FROM roasters WHERE id = ?").WithArgs(4).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("SELECT COUNT(*) FROM roasters WHERE id = ? AND COALESCE(owner_id, 0) = ? AND name = ?").WithArgs(4, 7, "Square Mile").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectRollback()
+
+				_, err := repository.RestoreBackup(aliceCtx, backup(), true)
+				if !errors.Is(err, domainerrors.ErrBackupRestoreConflict) {
+					t.Fatalf("RestoreBackup() error = %v, want %v", err, domainerrors.ErrBackupRestoreConflict)
+				}
+			},
+		},
+		{
+			name: "restore keeps the owners, matched by name or created",
+			run: func(t *testing.T, repository *Backup, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectEmpty(mock, 0, 0, 0, 0)
+				mock.ExpectQuery("SELECT id FROM users WHERE name = ?").WithArgs("alice").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectQuery("SELECT id FROM users WHERE name = ?").WithArgs("bob").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectExec("INSERT INTO users (name, role, disabled, created_at) VALUES (?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP))").
+					WithArgs("bob", "user", false, &created).WillReturnResult(sqlmock.NewResult(8, 1))
+				mock.ExpectExec(insertRoasterQuery).WithArgs(4, "Square Mile", &created, nil, 7).WillReturnResult(sqlmock.NewResult(4, 1))
+				mock.ExpectExec(insertSheetQuery).WithArgs(3, "Linea Mini", true, &created, nil, 8).WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectCommit()
+
+				records := &sql.Backup{
+					Roasters: backup().Roasters,
+					Sheets:   backup().Sheets,
+					Owners: sql.BackupOwners{
+						Users:    []sql.User{{Id: 1, Name: "alice", Role: "admin"}, {Id: 2, Name: "bob", Role: "user", CreatedAt: &created}},
+						Roasters: map[int]int{4: 1},
+						Sheets:   map[int]int{3: 2},
+					},
+				}
+				result, err := repository.RestoreBackup(context.Background(), records, false)
+				if err != nil {
+					t.Fatalf("RestoreBackup() error = %v", err)
+				}
+				want := sql.RestoreResult{
+					Users:  sql.RestoreCount{Restored: 1, Skipped: 1},
+					Sheets: sql.RestoreCount{Restored: 1}, Roasters: sql.RestoreCount{Restored: 1},
+				}
+				if *result != want {
+					t.Errorf("RestoreBackup() = %+v, want %+v", *result, want)
+				}
+			},
+		},
+		{
+			name: "restore of a duplicate name rolls back",
+			run: func(t *testing.T, repository *Backup, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(*) FROM roasters WHERE id = ?").WithArgs(4).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec(insertRoasterQuery).WithArgs(4, "Square Mile", &created, nil, nil).
+					WillReturnError(&mysql.MySQLError{Number: 1062})
+				mock.ExpectRollback()
+
+				_, err := repository.RestoreBackup(context.Background(), &sql.Backup{Roasters: backup().Roasters}, true)
+				if !errors.Is(err, domainerrors.ErrRoasterAlreadyExists) {
+					t.Fatalf("RestoreBackup() error = %v, want %v", err, domainerrors.ErrRoasterAlreadyExists)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestBackupRepositoryMySQLGetBackupOwners(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	aliceCtx := auth.NewContext(context.Background(), &auth.User{Id: 7, Name: "alice"})
+	owned := func(rows ...[2]int) *sqlmock.Rows {
+		r := sqlmock.NewRows([]string{"id", "owner_id"})
+		for _, row := range rows {
+			r.AddRow(row[0], row[1])
+		}
+		return r
+	}
+	mock.ExpectQuery("SELECT id, owner_id FROM roasters WHERE owner_id IS NOT NULL\n\tAND owner_id = ?").WithArgs(7).WillReturnRows(owned([2]int{4, 7}))
+	mock.ExpectQuery("SELECT id, owner_id FROM sheets WHERE owner_id IS NOT NULL\n\tAND owner_id = ?").WithArgs(7).WillReturnRows(owned())
+	mock.ExpectQuery("SELECT id, owner_id FROM beans WHERE owner_id IS NOT NULL\n\tAND owner_id = ?").WithArgs(7).WillReturnRows(owned([2]int{5, 7}))
+	mock.ExpectQuery("SELECT id, owner_id FROM shots WHERE owner_id IS NOT NULL\n\tAND owner_id = ?").WithArgs(7).WillReturnRows(owned())
+	mock.ExpectQuery(`SELECT id, name, disabled, role, created_at, updated_at FROM users
+WHERE id IN (SELECT owner_id FROM roasters UNION SELECT owner_id FROM sheets UNION SELECT owner_id FROM beans UNION SELECT owner_id FROM shots)
+	AND id = ?
+ORDER BY id`).WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "disabled", "role", "created_at", "updated_at"}).AddRow(7, "alice", false, "admin", nil, nil))
+
+	owners, err := New(sqlx.NewDb(db, "sqlmock")).GetBackupOwners(aliceCtx)
+	if err != nil {
+		t.Fatalf("GetBackupOwners() error = %v", err)
+	}
+	if len(owners.Users) != 1 || owners.Users[0].Name != "alice" {
+		t.Errorf("GetBackupOwners() users = %+v, want alice", owners.Users)
+	}
+	if owners.Roasters[4] != 7 || owners.Beans[5] != 7 || len(owners.Sheets) != 0 || len(owners.Shots) != 0 {
+		t.Errorf("GetBackupOwners() = %+v, want roaster 4 and beans 5 owned by 7", owners)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
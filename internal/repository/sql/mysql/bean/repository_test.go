@@ -49,8 +49,8 @@ func TestDBCreateBeans(t *testing.T) {
 			name: "Beans - no error",
 			args: args{ctx: context.TODO(), beans: &sql.Beans{Roaster: &sql.Roaster{Id: 1}, Name: "beans01", RoastDate: &now, RoastLevel: sql.RoastLevelMediumToDark}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO beans (name, roaster_id, roast_date, roast_level) VALUES (?, ?, ?, ?)").
-					WithArgs("beans01", 1, now, sql.RoastLevelMediumToDark).
+				mock.ExpectExec("INSERT INTO beans (name, roaster_id, roast_date, roast_level, green_coffee_id, green_weight, price, currency, bag_weight, owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+					WithArgs("beans01", 1, now, sql.RoastLevelMediumToDark, nil, 0.0, 0.0, "", 0.0, nil).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			want:    1,
@@ -60,8 +60,8 @@ func TestDBCreateBeans(t *testing.T) {
 			name: "Beans - LastInsertId error",
 			args: args{ctx: context.TODO(), beans: &sql.Beans{Roaster: &sql.Roaster{Id: 1}, Name: "beans01", RoastDate: &now, RoastLevel: sql.RoastLevelMediumToDark}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO beans (name, roaster_id, roast_date, roast_level) VALUES (?, ?, ?, ?)").
-					WithArgs("beans01", 1, now, sql.RoastLevelMediumToDark).
+				mock.ExpectExec("INSERT INTO beans (name, roaster_id, roast_date, roast_level, green_coffee_id, green_weight, price, currency, bag_weight, owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+					WithArgs("beans01", 1, now, sql.RoastLevelMediumToDark, nil, 0.0, 0.0, "", 0.0, nil).
 					WillReturnResult(sqlmock.NewErrorResult(fmt.Errorf("mock error")))
 			},
 			want:       0,
@@ -72,8 +72,8 @@ func TestDBCreateBeans(t *testing.T) {
 			name: "Beans - foreign key constraint error - roaster does not exist",
 			args: args{ctx: context.TODO(), beans: &sql.Beans{Roaster: &sql.Roaster{Id: 1}, Name: "beans01", RoastDate: &now, RoastLevel: sql.RoastLevelMediumToDark}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO beans (name, roaster_id, roast_date, roast_level) VALUES (?, ?, ?, ?)").
-					WithArgs("beans01", 1, now, sql.RoastLevelMediumToDark).
+				mock.ExpectExec("INSERT INTO beans (name, roaster_id, roast_date, roast_level, green_coffee_id, green_weight, price, currency, bag_weight, owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+					WithArgs("beans01", 1, now, sql.RoastLevelMediumToDark, nil, 0.0, 0.0, "", 0.0, nil).
 					WillReturnError(&mysql.MySQLError{
 						Number:  1452, // Error 1452 is "Cannot add or update a child row: a foreign key constraint fails"
 						Message: missingRoasterForeignKeyError,
@@ -87,8 +87,8 @@ func TestDBCreateBeans(t *testing.T) {
 			name: "Beans - duplicate error",
 			args: args{ctx: context.TODO(), beans: &sql.Beans{Roaster: &sql.Roaster{Id: 1}, Name: "beans01", RoastDate: &now, RoastLevel: sql.RoastLevelMediumToDark}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO beans (name, roaster_id, roast_date, roast_level) VALUES (?, ?, ?, ?)").
-					WithArgs("beans01", 1, now, sql.RoastLevelMediumToDark).
+				mock.ExpectExec("INSERT INTO beans (name, roaster_id, roast_date, roast_level, green_coffee_id, green_weight, price, currency, bag_weight, owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+					WithArgs("beans01", 1, now, sql.RoastLevelMediumToDark, nil, 0.0, 0.0, "", 0.0, nil).
 					WillReturnError(&mysql.MySQLError{Number: 1062})
 			},
 			want:        0,
@@ -99,8 +99,8 @@ func TestDBCreateBeans(t *testing.T) {
 			name: "Beans - error",
 			args: args{ctx: context.TODO(), beans: &sql.Beans{Roaster: &sql.Roaster{Id: 1}, Name: "beans01", RoastDate: &now, RoastLevel: sql.RoastLevelMediumToDark}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO beans (name, roaster_id, roast_date, roast_level) VALUES (?, ?, ?, ?)").
-					WithArgs("beans01", 1, now, sql.RoastLevelMediumToDark).
+				mock.ExpectExec("INSERT INTO beans (name, roaster_id, roast_date, roast_level, green_coffee_id, green_weight, price, currency, bag_weight, owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+					WithArgs("beans01", 1, now, sql.RoastLevelMediumToDark, nil, 0.0, 0.0, "", 0.0, nil).
 					WillReturnError(fmt.Errorf("mock error"))
 			},
 			want:    0,
@@ -157,6 +157,11 @@ func TestBeanGetBeansById(t *testing.T) {
 		beans.name,
 		beans.roast_date,
 		beans.roast_level,
+		beans.green_coffee_id,
+		beans.green_weight,
+		beans.price,
+		beans.currency,
+		beans.bag_weight,
 		beans.created_at,
 		beans.updated_at,
 		roaster.id AS "roaster.id",
@@ -252,6 +257,11 @@ func TestBeanGetAllBeans(t *testing.T) {
 		beans.name,
 		beans.roast_date,
 		beans.roast_level,
+		beans.green_coffee_id,
+		beans.green_weight,
+		beans.price,
+		beans.currency,
+		beans.bag_weight,
 		beans.created_at,
 		beans.updated_at,
 		roaster.id AS "roaster.id",
@@ -362,8 +372,8 @@ func TestBeanUpdateBeansById(t *testing.T) {
 			name: "Beans.Id matching id - No error",
 			args: args{ctx: context.TODO(), id: 1, beans: &sql.Beans{Id: 1, Roaster: &sql.Roaster{Id: 1}, Name: "beans01", RoastDate: &now, RoastLevel: sql.RoastLevelMediumToDark}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("UPDATE beans SET name = ?, roaster_id = ?, roast_date = ?, roast_level = ? WHERE id = ?").
-					WithArgs("beans01", 1, AnyTime{}, sql.RoastLevelMediumToDark, 1).
+				mock.ExpectExec("UPDATE beans SET name = ?, roaster_id = ?, roast_date = ?, roast_level = ?, green_coffee_id = ?, green_weight = ?, price = ?, currency = ?, bag_weight = ? WHERE id = ?").
+					WithArgs("beans01", 1, AnyTime{}, sql.RoastLevelMediumToDark, nil, 0.0, 0.0, "", 0.0, 1).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			want:    &sql.Beans{Id: 1, Roaster: &sql.Roaster{Id: 1}, Name: "beans01", RoastDate: &now, RoastLevel: sql.RoastLevelMediumToDark},
@@ -373,8 +383,8 @@ func TestBeanUpdateBeansById(t *testing.T) {
 			name: "Beans.Id matching id - Error",
 			args: args{ctx: context.TODO(), id: 1, beans: &sql.Beans{Id: 1, Roaster: &sql.Roaster{Id: 1}, Name: "beans01", RoastDate: &now, RoastLevel: sql.RoastLevelMediumToDark}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("UPDATE beans SET name = ?, roaster_id = ?, roast_date = ?, roast_level = ? WHERE id = ?").
-					WithArgs("beans01", 1, AnyTime{}, sql.RoastLevelMediumToDark, 1).
+				mock.ExpectExec("UPDATE beans SET name = ?, roaster_id = ?, roast_date = ?, roast_level = ?, green_coffee_id = ?, green_weight = ?, price = ?, currency = ?, bag_weight = ? WHERE id = ?").
+					WithArgs("beans01", 1, AnyTime{}, sql.RoastLevelMediumToDark, nil, 0.0, 0.0, "", 0.0, 1).
 					WillReturnError(fmt.Errorf("mock error"))
 			},
 			want:    nil,
@@ -384,8 +394,8 @@ func TestBeanUpdateBeansById(t *testing.T) {
 			name: "Beans.Id not matching id - Error",
 			args: args{ctx: context.TODO(), id: 1, beans: &sql.Beans{Id: 2, Roaster: &sql.Roaster{Id: 1}, Name: "beans01", RoastDate: &now, RoastLevel: sql.RoastLevelMediumToDark}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("UPDATE beans SET name = ?, roaster_id = ?, roast_date = ?, roast_level = ? WHERE id = ?").
-					WithArgs("beans01", 1, AnyTime{}, sql.RoastLevelMediumToDark, 1).
+				mock.ExpectExec("UPDATE beans SET name = ?, roaster_id = ?, roast_date = ?, roast_level = ?, green_coffee_id = ?, green_weight = ?, price = ?, currency = ?, bag_weight = ? WHERE id = ?").
+					WithArgs("beans01", 1, AnyTime{}, sql.RoastLevelMediumToDark, nil, 0.0, 0.0, "", 0.0, 1).
 					WillReturnError(fmt.Errorf("mock error"))
 			},
 			want:    nil,
@@ -395,8 +405,8 @@ func TestBeanUpdateBeansById(t *testing.T) {
 			name: "Missing roaster",
 			args: args{ctx: context.TODO(), id: 1, beans: &sql.Beans{Id: 1, Roaster: &sql.Roaster{Id: 2}, Name: "beans01", RoastDate: &now, RoastLevel: sql.RoastLevelMediumToDark}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("UPDATE beans SET name = ?, roaster_id = ?, roast_date = ?, roast_level = ? WHERE id = ?").
-					WithArgs("beans01", 2, AnyTime{}, sql.RoastLevelMediumToDark, 1).
+				mock.ExpectExec("UPDATE beans SET name = ?, roaster_id = ?, roast_date = ?, roast_level = ?, green_coffee_id = ?, green_weight = ?, price = ?, currency = ?, bag_weight = ? WHERE id = ?").
+					WithArgs("beans01", 2, AnyTime{}, sql.RoastLevelMediumToDark, nil, 0.0, 0.0, "", 0.0, 1).
 					WillReturnError(&mysql.MySQLError{Number: 1452, Message: missingRoasterForeignKeyError})
 			},
 			want:        nil,
@@ -407,8 +417,8 @@ func TestBeanUpdateBeansById(t *testing.T) {
 			name: "Duplicate beans",
 			args: args{ctx: context.TODO(), id: 1, beans: &sql.Beans{Id: 1, Roaster: &sql.Roaster{Id: 1}, Name: "beans01", RoastDate: &now, RoastLevel: sql.RoastLevelMediumToDark}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("UPDATE beans SET name = ?, roaster_id = ?, roast_date = ?, roast_level = ? WHERE id = ?").
-					WithArgs("beans01", 1, AnyTime{}, sql.RoastLevelMediumToDark, 1).
+				mock.ExpectExec("UPDATE beans SET name = ?, roaster_id = ?, roast_date = ?, roast_level = ?, green_coffee_id = ?, green_weight = ?, price = ?, currency = ?, bag_weight = ? WHERE id = ?").
+					WithArgs("beans01", 1, AnyTime{}, sql.RoastLevelMediumToDark, nil, 0.0, 0.0, "", 0.0, 1).
 					WillReturnError(&mysql.MySQLError{Number: 1062})
 			},
 			want:        nil,
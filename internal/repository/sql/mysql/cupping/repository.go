@@ -0,0 +1,18 @@
+package cupping
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+var _ repository.CuppingRepository = (*Cupping)(nil)
+
+type Cupping struct {
+	*shared.Cupping
+}
+
+func New(db *sqlx.DB) *Cupping {
+	return &Cupping{shared.NewCupping(db, adapters.MySQL())}
+}
@@ -0,0 +1,185 @@
+package cupping
+
+import (
+	"context"
+	dbsql "database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const insertScoreQuery = `INSERT INTO
+	cupping_scores (session_id, beans_id, fragrance, flavor, aftertaste, acidity, body, balance, uniformity, clean_cup, sweetness, overall, notes, owner_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+func testScore() *sql.CuppingScore {
+	return &sql.CuppingScore{
+		SessionId: 1, Beans: &sql.Beans{Id: 2},
+		Fragrance: 8, Flavor: 8, Aftertaste: 7.5, Acidity: 7.75, Body: 7.5,
+		Balance: 7.75, Uniformity: 10, CleanCup: 10, Sweetness: 10, Overall: 8,
+		Notes: "stone fruit",
+	}
+}
+
+func scoreArgs(s *sql.CuppingScore) []driver.Value {
+	return []driver.Value{s.SessionId, s.Beans.Id, s.Fragrance, s.Flavor, s.Aftertaste, s.Acidity, s.Body, s.Balance, s.Uniformity, s.CleanCup, s.Sweetness, s.Overall, s.Notes, nil}
+}
+
+func TestCuppingRepositoryMySQLBehavior(t *testing.T) {
+	sessionDate := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create session returns last insert id",
+			run: func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO cupping_sessions (session_date, participants, owner_id) VALUES (?, ?, ?)").
+					WithArgs(sessionDate, "alice, bob", nil).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+
+				id, err := repository.CreateCuppingSession(context.Background(), &sql.CuppingSession{SessionDate: &sessionDate, Participants: "alice, bob"})
+				if err != nil {
+					t.Fatalf("CreateCuppingSession() error = %v", err)
+				}
+				if id != 3 {
+					t.Errorf("CreateCuppingSession() id = %d, want 3", id)
+				}
+			},
+		},
+		{
+			name: "get missing session returns domain error",
+			run: func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, session_date, participants, created_at, updated_at FROM cupping_sessions WHERE id = ?").
+					WithArgs(42).
+					WillReturnError(dbsql.ErrNoRows)
+
+				_, err := repository.GetCuppingSessionById(context.Background(), 42)
+				if !errors.Is(err, domainerrors.ErrCuppingSessionDoesNotExist) {
+					t.Fatalf("GetCuppingSessionById() error = %v, want %v", err, domainerrors.ErrCuppingSessionDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "delete missing session returns domain error",
+			run: func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM cupping_sessions WHERE id = ?").
+					WithArgs(42).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				err := repository.DeleteCuppingSessionById(context.Background(), 42)
+				if !errors.Is(err, domainerrors.ErrCuppingSessionDoesNotExist) {
+					t.Fatalf("DeleteCuppingSessionById() error = %v, want %v", err, domainerrors.ErrCuppingSessionDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "create score returns last insert id",
+			run: func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock) {
+				score := testScore()
+				mock.ExpectExec(insertScoreQuery).WithArgs(scoreArgs(score)...).WillReturnResult(sqlmock.NewResult(5, 1))
+
+				id, err := repository.CreateCuppingScore(context.Background(), score)
+				if err != nil {
+					t.Fatalf("CreateCuppingScore() error = %v", err)
+				}
+				if id != 5 {
+					t.Errorf("CreateCuppingScore() id = %d, want 5", id)
+				}
+			},
+		},
+		{
+			name: "create duplicate score returns already exists",
+			run: func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock) {
+				score := testScore()
+				mock.ExpectExec(insertScoreQuery).WithArgs(scoreArgs(score)...).WillReturnError(&mysql.MySQLError{Number: 1062})
+
+				_, err := repository.CreateCuppingScore(context.Background(), score)
+				if !errors.Is(err, domainerrors.ErrCuppingScoreAlreadyExists) {
+					t.Fatalf("CreateCuppingScore() error = %v, want %v", err, domainerrors.ErrCuppingScoreAlreadyExists)
+				}
+			},
+		},
+		{
+			name: "create score for missing session returns session does not exist",
+			run: func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock) {
+				score := testScore()
+				mock.ExpectExec(insertScoreQuery).WithArgs(scoreArgs(score)...).WillReturnError(&mysql.MySQLError{
+					Number:  1452,
+					Message: "Cannot add or update a child row: a foreign key constraint fails (`espresso-api`.`cupping_scores`, CONSTRAINT `cupping_scores_ibfk_1` FOREIGN KEY (`session_id`) REFERENCES `cupping_sessions` (`id`) ON DELETE CASCADE)",
+				})
+
+				_, err := repository.CreateCuppingScore(context.Background(), score)
+				if !errors.Is(err, domainerrors.ErrCuppingSessionDoesNotExist) {
+					t.Fatalf("CreateCuppingScore() error = %v, want %v", err, domainerrors.ErrCuppingSessionDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "create out of range score returns range error",
+			run: func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock) {
+				score := testScore()
+				mock.ExpectExec(insertScoreQuery).WithArgs(scoreArgs(score)...).WillReturnError(&mysql.MySQLError{
+					Number:  3819,
+					Message: "Check constraint 'chk_cupping_scores_range' is violated.",
+				})
+
+				_, err := repository.CreateCuppingScore(context.Background(), score)
+				if !errors.Is(err, domainerrors.ErrCuppingScoreOutOfRange) {
+					t.Fatalf("CreateCuppingScore() error = %v, want %v", err, domainerrors.ErrCuppingScoreOutOfRange)
+				}
+			},
+		},
+		{
+			name: "delete missing score returns domain error",
+			run: func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM cupping_scores WHERE id = ?").
+					WithArgs(42).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				err := repository.DeleteCuppingScoreById(context.Background(), 42)
+				if !errors.Is(err, domainerrors.ErrCuppingScoreDoesNotExist) {
+					t.Fatalf("DeleteCuppingScoreById() error = %v, want %v", err, domainerrors.ErrCuppingScoreDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "create score with beans of another user returns beans does not exist",
+			run: func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock) {
+				aliceCtx := auth.NewContext(context.Background(), &auth.User{Id: 7, Name: "alice"})
+				mock.ExpectQuery("SELECT COUNT(*) FROM cupping_sessions WHERE id = ?\n\tAND owner_id = ?").WithArgs(1, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("SELECT COUNT(*) FROM beans WHERE id = ?\n\tAND owner_id = ?").WithArgs(2, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				_, err := repository.CreateCuppingScore(aliceCtx, testScore())
+				if !errors.Is(err, domainerrors.ErrBeansDoesNotExist) {
+					t.Fatalf("CreateCuppingScore() error = %v, want %v", err, domainerrors.ErrBeansDoesNotExist)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package greencoffee
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+var _ repository.GreenCoffeeRepository = (*GreenCoffee)(nil)
+
+type GreenCoffee struct {
+	*shared.GreenCoffee
+}
+
+func New(db *sqlx.DB) *GreenCoffee {
+	return &GreenCoffee{shared.NewGreenCoffee(db, adapters.MySQL())}
+}
@@ -0,0 +1,184 @@
+package greencoffee
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const (
+	insertGreenCoffeeQuery = `INSERT INTO
+	green_coffees (origin, supplier, purchase_weight, price, arrival_date, moisture, owner_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?)`
+	updateGreenCoffeeQuery = `UPDATE green_coffees SET
+	origin = ?, supplier = ?, purchase_weight = ?, price = ?, arrival_date = ?, moisture = ?
+	WHERE id = ?`
+	selectGreenCoffeeQuery = `
+SELECT
+	green_coffees.id,
+	green_coffees.origin,
+	green_coffees.supplier,
+	green_coffees.purchase_weight,
+	green_coffees.price,
+	green_coffees.arrival_date,
+	green_coffees.moisture,
+	green_coffees.created_at,
+	green_coffees.updated_at,
+	(SELECT COALESCE(SUM(roast_batches.green_weight), 0) FROM roast_batches WHERE roast_batches.green_coffee_id = green_coffees.id)
+		+ (SELECT COALESCE(SUM(beans.green_weight), 0) FROM beans WHERE beans.green_coffee_id = green_coffees.id) AS consumed_weight
+FROM green_coffees`
+)
+
+var greenCoffeeColumns = []string{
+	"id", "origin", "supplier", "purchase_weight", "price", "arrival_date", "moisture", "created_at", "updated_at", "consumed_weight",
+}
+
+func TestGreenCoffeeRepositoryMySQLBehavior(t *testing.T) {
+	arrivalDate := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+	greenCoffee := &sql.GreenCoffee{
+		Origin: "Ethiopia Guji", Supplier: "Green Traders", PurchaseWeight: 5, Price: 60, ArrivalDate: &arrivalDate, Moisture: 10.5,
+	}
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *GreenCoffee, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create returns the inserted id",
+			run: func(t *testing.T, repository *GreenCoffee, mock sqlmock.Sqlmock) {
+				mock.ExpectExec(insertGreenCoffeeQuery).
+					WithArgs("Ethiopia Guji", "Green Traders", 5.0, 60.0, arrivalDate, 10.5, nil).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+
+				id, err := repository.CreateGreenCoffee(context.Background(), greenCoffee)
+				if err != nil {
+					t.Fatalf("CreateGreenCoffee() error = %v", err)
+				}
+				if id != 2 {
+					t.Errorf("CreateGreenCoffee() id = %d, want 2", id)
+				}
+			},
+		},
+		{
+			name: "create with invalid moisture returns range error",
+			run: func(t *testing.T, repository *GreenCoffee, mock sqlmock.Sqlmock) {
+				mock.ExpectExec(insertGreenCoffeeQuery).
+					WithArgs("Ethiopia Guji", "Green Traders", 5.0, 60.0, arrivalDate, 10.5, nil).
+					WillReturnError(&mysql.MySQLError{Number: 3819, Message: "Check constraint 'chk_green_coffees_moisture' is violated."})
+
+				_, err := repository.CreateGreenCoffee(context.Background(), greenCoffee)
+				if !errors.Is(err, domainerrors.ErrGreenCoffeeMoistureOutOfRange) {
+					t.Fatalf("CreateGreenCoffee() error = %v, want %v", err, domainerrors.ErrGreenCoffeeMoistureOutOfRange)
+				}
+			},
+		},
+		{
+			name: "get reads the consumed weight",
+			run: func(t *testing.T, repository *GreenCoffee, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectGreenCoffeeQuery + "\nWHERE green_coffees.id = ?").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows(greenCoffeeColumns).
+						AddRow(2, "Ethiopia Guji", "Green Traders", 5.0, 60.0, arrivalDate, 10.5, arrivalDate, nil, 1250.0))
+
+				got, err := repository.GetGreenCoffeeById(context.Background(), 2)
+				if err != nil {
+					t.Fatalf("GetGreenCoffeeById() error = %v", err)
+				}
+				if got.Origin != "Ethiopia Guji" || got.ConsumedWeight != 1250 {
+					t.Errorf("GetGreenCoffeeById() = %#v, want the consumed weight", got)
+				}
+			},
+		},
+		{
+			name: "get missing green coffee returns domain error",
+			run: func(t *testing.T, repository *GreenCoffee, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectGreenCoffeeQuery + "\nWHERE green_coffees.id = ?").WithArgs(42).WillReturnError(dbsql.ErrNoRows)
+
+				_, err := repository.GetGreenCoffeeById(context.Background(), 42)
+				if !errors.Is(err, domainerrors.ErrGreenCoffeeDoesNotExist) {
+					t.Fatalf("GetGreenCoffeeById() error = %v, want %v", err, domainerrors.ErrGreenCoffeeDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "update reads the green coffee back",
+			run: func(t *testing.T, repository *GreenCoffee, mock sqlmock.Sqlmock) {
+				mock.ExpectExec(updateGreenCoffeeQuery).
+					WithArgs("Ethiopia Guji", "Green Traders", 5.0, 60.0, arrivalDate, 10.5, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(selectGreenCoffeeQuery + "\nWHERE green_coffees.id = ?").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows(greenCoffeeColumns).
+						AddRow(2, "Ethiopia Guji", "Green Traders", 5.0, 60.0, arrivalDate, 10.5, arrivalDate, arrivalDate, 250.0))
+
+				got, err := repository.UpdateGreenCoffeeById(context.Background(), 2, greenCoffee)
+				if err != nil {
+					t.Fatalf("UpdateGreenCoffeeById() error = %v", err)
+				}
+				if got.Id != 2 || got.ConsumedWeight != 250 {
+					t.Errorf("UpdateGreenCoffeeById() = %#v, want the stored green coffee", got)
+				}
+			},
+		},
+		{
+			name: "delete referenced by roast batches returns foreign key error",
+			run: func(t *testing.T, repository *GreenCoffee, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM green_coffees WHERE id = ?").WithArgs(2).
+					WillReturnError(&mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row: a foreign key constraint fails (`espresso-api`.`roast_batches`, CONSTRAINT `fk_roast_batches_green_coffee` FOREIGN KEY (`green_coffee_id`) REFERENCES `green_coffees` (`id`))"})
+
+				err := repository.DeleteGreenCoffeeById(context.Background(), 2)
+				if !errors.Is(err, domainerrors.ErrRoastBatchForeignKeyConstraint) {
+					t.Fatalf("DeleteGreenCoffeeById() error = %v, want %v", err, domainerrors.ErrRoastBatchForeignKeyConstraint)
+				}
+			},
+		},
+		{
+			name: "delete missing green coffee returns domain error",
+			run: func(t *testing.T, repository *GreenCoffee, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM green_coffees WHERE id = ?").WithArgs(42).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				err := repository.DeleteGreenCoffeeById(context.Background(), 42)
+				if !errors.Is(err, domainerrors.ErrGreenCoffeeDoesNotExist) {
+					t.Fatalf("DeleteGreenCoffeeById() error = %v, want %v", err, domainerrors.ErrGreenCoffeeDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "get all is scoped to the authenticated user",
+			run: func(t *testing.T, repository *GreenCoffee, mock sqlmock.Sqlmock) {
+				aliceCtx := auth.NewContext(context.Background(), &auth.User{Id: 7, Name: "alice"})
+				mock.ExpectQuery(selectGreenCoffeeQuery + "\nWHERE green_coffees.owner_id = ?").WithArgs(7).
+					WillReturnRows(sqlmock.NewRows(greenCoffeeColumns))
+
+				got, err := repository.GetAllGreenCoffees(aliceCtx)
+				if err != nil {
+					t.Fatalf("GetAllGreenCoffees() error = %v", err)
+				}
+				if len(got) != 0 {
+					t.Errorf("GetAllGreenCoffees() = %+v, want none", got)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
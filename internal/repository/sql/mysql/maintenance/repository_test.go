@@ -0,0 +1,181 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const (
+	insertMaintenanceTaskQuery = `INSERT INTO
+	maintenance_tasks (type, equipment, equipment_name, interval_shots, interval_days, last_done_at, owner_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?)`
+	completeMaintenanceTaskQuery = `UPDATE maintenance_tasks SET last_done_at = ? WHERE id = ?`
+	selectMaintenanceTaskQuery   = `
+SELECT
+	maintenance_tasks.id,
+	maintenance_tasks.type,
+	maintenance_tasks.equipment,
+	maintenance_tasks.equipment_name,
+	maintenance_tasks.interval_shots,
+	maintenance_tasks.interval_days,
+	maintenance_tasks.last_done_at,
+	maintenance_tasks.created_at,
+	maintenance_tasks.updated_at,
+	(SELECT COUNT(*) FROM shots WHERE shots.created_at > COALESCE(maintenance_tasks.last_done_at, maintenance_tasks.created_at)
+		AND COALESCE(shots.owner_id, 0) = COALESCE(maintenance_tasks.owner_id, 0)) AS shots_since_done
+FROM maintenance_tasks`
+)
+
+var maintenanceTaskColumns = []string{
+	"id", "type", "equipment", "equipment_name", "interval_shots", "interval_days", "last_done_at", "created_at", "updated_at", "shots_since_done",
+}
+
+func TestMaintenanceTaskRepositoryMySQLBehavior(t *testing.T) {
+	intervalShots := 200
+	doneAt := time.Date(2026, time.October, 1, 8, 0, 0, 0, time.UTC)
+	task := &sql.MaintenanceTask{Type: "backflush", Equipment: "machine", EquipmentName: "Linea Mini", IntervalShots: &intervalShots}
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *MaintenanceTask, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create returns the inserted id",
+			run: func(t *testing.T, repository *MaintenanceTask, mock sqlmock.Sqlmock) {
+				mock.ExpectExec(insertMaintenanceTaskQuery).
+					WithArgs("backflush", "machine", "Linea Mini", 200, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+
+				id, err := repository.CreateMaintenanceTask(context.Background(), task)
+				if err != nil {
+					t.Fatalf("CreateMaintenanceTask() error = %v", err)
+				}
+				if id != 3 {
+					t.Errorf("CreateMaintenanceTask() id = %d, want 3", id)
+				}
+			},
+		},
+		{
+			name: "create without interval returns range error",
+			run: func(t *testing.T, repository *MaintenanceTask, mock sqlmock.Sqlmock) {
+				mock.ExpectExec(insertMaintenanceTaskQuery).
+					WithArgs("descale", "", "", nil, nil, nil, nil).
+					WillReturnError(&mysql.MySQLError{Number: 3819, Message: "Check constraint 'chk_maintenance_tasks_interval' is violated."})
+
+				_, err := repository.CreateMaintenanceTask(context.Background(), &sql.MaintenanceTask{Type: "descale"})
+				if !errors.Is(err, domainerrors.ErrMaintenanceTaskIntervalOutOfRange) {
+					t.Fatalf("CreateMaintenanceTask() error = %v, want %v", err, domainerrors.ErrMaintenanceTaskIntervalOutOfRange)
+				}
+			},
+		},
+		{
+			name: "get all counts shots since done",
+			run: func(t *testing.T, repository *MaintenanceTask, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectMaintenanceTaskQuery + "\nORDER BY maintenance_tasks.id").
+					WillReturnRows(sqlmock.NewRows(maintenanceTaskColumns).
+						AddRow(3, "backflush", "machine", "Linea Mini", 200, nil, doneAt, doneAt, nil, 214))
+
+				got, err := repository.GetAllMaintenanceTasks(context.Background())
+				if err != nil {
+					t.Fatalf("GetAllMaintenanceTasks() error = %v", err)
+				}
+				if len(got) != 1 || got[0].ShotsSinceDone != 214 || *got[0].IntervalShots != 200 || got[0].IntervalDays != nil || !got[0].LastDoneAt.Equal(doneAt) {
+					t.Errorf("GetAllMaintenanceTasks() = %+v, want the backflush with 214 shots since done", got)
+				}
+			},
+		},
+		{
+			name: "complete records the date and reads the task back",
+			run: func(t *testing.T, repository *MaintenanceTask, mock sqlmock.Sqlmock) {
+				mock.ExpectExec(completeMaintenanceTaskQuery).WithArgs(doneAt, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(selectMaintenanceTaskQuery + "\nWHERE maintenance_tasks.id = ?").WithArgs(3).
+					WillReturnRows(sqlmock.NewRows(maintenanceTaskColumns).
+						AddRow(3, "backflush", "machine", "Linea Mini", 200, nil, doneAt, doneAt, doneAt, 0))
+
+				got, err := repository.CompleteMaintenanceTaskById(context.Background(), 3, doneAt)
+				if err != nil {
+					t.Fatalf("CompleteMaintenanceTaskById() error = %v", err)
+				}
+				if got.ShotsSinceDone != 0 || !got.LastDoneAt.Equal(doneAt) {
+					t.Errorf("CompleteMaintenanceTaskById() = %+v, want no shots since %v", got, doneAt)
+				}
+			},
+		},
+		{
+			name: "complete missing task returns does not exist",
+			run: func(t *testing.T, repository *MaintenanceTask, mock sqlmock.Sqlmock) {
+				mock.ExpectExec(completeMaintenanceTaskQuery).WithArgs(doneAt, 9).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				_, err := repository.CompleteMaintenanceTaskById(context.Background(), 9, doneAt)
+				if !errors.Is(err, domainerrors.ErrMaintenanceTaskDoesNotExist) {
+					t.Fatalf("CompleteMaintenanceTaskById() error = %v, want %v", err, domainerrors.ErrMaintenanceTaskDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "get missing task returns does not exist",
+			run: func(t *testing.T, repository *MaintenanceTask, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectMaintenanceTaskQuery + "\nWHERE maintenance_tasks.id = ?").WithArgs(9).
+					WillReturnRows(sqlmock.NewRows(maintenanceTaskColumns))
+
+				_, err := repository.GetMaintenanceTaskById(context.Background(), 9)
+				if !errors.Is(err, domainerrors.ErrMaintenanceTaskDoesNotExist) {
+					t.Fatalf("GetMaintenanceTaskById() error = %v, want %v", err, domainerrors.ErrMaintenanceTaskDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "delete missing task returns does not exist",
+			run: func(t *testing.T, repository *MaintenanceTask, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM maintenance_tasks WHERE id = ?").WithArgs(9).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				err := repository.DeleteMaintenanceTaskById(context.Background(), 9)
+				if !errors.Is(err, domainerrors.ErrMaintenanceTaskDoesNotExist) {
+					t.Fatalf("DeleteMaintenanceTaskById() error = %v, want %v", err, domainerrors.ErrMaintenanceTaskDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "get all is scoped to the authenticated user",
+			run: func(t *testing.T, repository *MaintenanceTask, mock sqlmock.Sqlmock) {
+				aliceCtx := auth.NewContext(context.Background(), &auth.User{Id: 7, Name: "alice"})
+				mock.ExpectQuery(selectMaintenanceTaskQuery + "\nWHERE maintenance_tasks.owner_id = ?\nORDER BY maintenance_tasks.id").WithArgs(7).
+					WillReturnRows(sqlmock.NewRows(maintenanceTaskColumns))
+
+				got, err := repository.GetAllMaintenanceTasks(aliceCtx)
+				if err != nil {
+					t.Fatalf("GetAllMaintenanceTasks() error = %v", err)
+				}
+				if len(got) != 0 {
+					t.Errorf("GetAllMaintenanceTasks() = %+v, want none", got)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
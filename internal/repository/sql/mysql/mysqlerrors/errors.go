@@ -19,6 +19,21 @@ var (
 	checkConstraintErrors = map[string]error{
 		"chk_beans_roast_level":                     domainerrors.ErrBeansRoastLevelOutOfRange,
 		"chk_shots_comparison_with_previous_result": domainerrors.ErrShotComparisonWithPreviousResultOutOfRange,
+		"chk_cupping_scores_range":                  domainerrors.ErrCuppingScoreOutOfRange,
+		"chk_roast_batches_roast_level":             domainerrors.ErrRoastBatchRoastLevelOutOfRange,
+		"chk_roast_batches_weights":                 domainerrors.ErrRoastBatchWeightOutOfRange,
+		"chk_roast_batches_times":                   domainerrors.ErrRoastBatchTimeOutOfRange,
+		"chk_roast_curve_points_elapsed_time":       domainerrors.ErrRoastBatchCurveIsInvalid,
+		"chk_beans_green_weight":                    domainerrors.ErrBeansGreenWeightOutOfRange,
+		"chk_beans_price":                           domainerrors.ErrBeansPriceOutOfRange,
+		"chk_beans_bag_weight":                      domainerrors.ErrBeansBagWeightOutOfRange,
+		"chk_green_coffees_purchase_weight":         domainerrors.ErrGreenCoffeePurchaseWeightOutOfRange,
+		"chk_green_coffees_price":                   domainerrors.ErrGreenCoffeePriceOutOfRange,
+		"chk_green_coffees_moisture":                domainerrors.ErrGreenCoffeeMoistureOutOfRange,
+		"chk_maintenance_tasks_type":                domainerrors.ErrMaintenanceTaskTypeIsInvalid,
+		"chk_maintenance_tasks_equipment":           domainerrors.ErrMaintenanceTaskEquipmentIsInvalid,
+		"chk_maintenance_tasks_interval":            domainerrors.ErrMaintenanceTaskIntervalOutOfRange,
+		"chk_api_keys_scope":                        domainerrors.ErrAPIKeyScopeIsInvalid,
 	}
 )
 
@@ -27,6 +42,17 @@ var (
 	EntityRoaster = sqlerrors.EntityRoaster
 	EntityBeans   = sqlerrors.EntityBeans
 	EntityShot    = sqlerrors.EntityShot
+
+	EntityCuppingSession  = sqlerrors.EntityCuppingSession
+	EntityCuppingScore    = sqlerrors.EntityCuppingScore
+	EntityRoastBatch      = sqlerrors.EntityRoastBatch
+	EntityGreenCoffee     = sqlerrors.EntityGreenCoffee
+	EntityMaintenanceTask = sqlerrors.EntityMaintenanceTask
+	EntityUser            = sqlerrors.EntityUser
+	EntityAPIKey          = sqlerrors.EntityAPIKey
+	EntitySession         = sqlerrors.EntitySession
+	EntityShareLink       = sqlerrors.EntityShareLink
+	EntityAttachment      = sqlerrors.EntityAttachment
 )
 
 var (
@@ -0,0 +1,18 @@
+package report
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+var _ repository.ReportRepository = (*Report)(nil)
+
+type Report struct {
+	*shared.Report
+}
+
+func New(db *sqlx.DB) *Report {
+	return &Report{shared.NewReport(db, adapters.MySQL())}
+}
@@ -0,0 +1,109 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const selectShotCostsQuery = `
+SELECT
+	shots.id AS shot_id,
+	shots.quantity_in,
+	shots.created_at,
+	beans.id AS beans_id,
+	beans.name AS beans_name,
+	roasters.id AS roaster_id,
+	roasters.name AS roaster_name,
+	beans.price,
+	beans.currency,
+	beans.bag_weight
+FROM shots
+	INNER JOIN beans ON shots.beans_id = beans.id
+	INNER JOIN roasters ON beans.roaster_id = roasters.id
+WHERE beans.price > 0
+	AND beans.bag_weight > 0`
+
+var shotCostColumns = []string{
+	"shot_id", "quantity_in", "created_at", "beans_id", "beans_name", "roaster_id", "roaster_name", "price", "currency", "bag_weight",
+}
+
+func TestReportRepositoryMySQLBehavior(t *testing.T) {
+	from := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	pulledAt := time.Date(2026, time.September, 12, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *Report, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "get shot costs without bounds",
+			run: func(t *testing.T, repository *Report, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectShotCostsQuery + "\nORDER BY shots.created_at, shots.id").
+					WillReturnRows(sqlmock.NewRows(shotCostColumns).
+						AddRow(3, 18.0, pulledAt, 1, "Guji", 2, "Roaster", 15.0, "EUR", 250.0))
+
+				got, err := repository.GetShotCosts(context.Background(), nil, nil)
+				if err != nil {
+					t.Fatalf("GetShotCosts() error = %v", err)
+				}
+				want := []sql.ShotCost{{
+					ShotId: 3, QuantityIn: 18, CreatedAt: &pulledAt, BeansId: 1, BeansName: "Guji",
+					RoasterId: 2, RoasterName: "Roaster", Price: 15, Currency: "EUR", BagWeight: 250,
+				}}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("GetShotCosts() = %+v, want %+v", got, want)
+				}
+			},
+		},
+		{
+			name: "get shot costs within a range",
+			run: func(t *testing.T, repository *Report, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectShotCostsQuery+"\n\tAND shots.created_at >= ?\n\tAND shots.created_at < ?\nORDER BY shots.created_at, shots.id").
+					WithArgs(from, to).
+					WillReturnRows(sqlmock.NewRows(shotCostColumns))
+
+				got, err := repository.GetShotCosts(context.Background(), &from, &to)
+				if err != nil {
+					t.Fatalf("GetShotCosts() error = %v", err)
+				}
+				if len(got) != 0 {
+					t.Errorf("GetShotCosts() = %+v, want no shot costs", got)
+				}
+			},
+		},
+		{
+			name: "get shot costs error",
+			run: func(t *testing.T, repository *Report, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectShotCostsQuery + "\n\tAND shots.created_at >= ?\nORDER BY shots.created_at, shots.id").
+					WithArgs(from).
+					WillReturnError(errors.New("connection refused"))
+
+				if _, err := repository.GetShotCosts(context.Background(), &from, nil); err == nil {
+					t.Fatal("GetShotCosts() error = nil, want an error")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package roastbatch
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const (
+	insertBatchQuery = `INSERT INTO
+	roast_batches (green_coffee, roast_date, roast_level, green_weight, roasted_weight, charge_temperature, first_crack_time, development_time, end_temperature, green_coffee_id, owner_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	insertPointQuery = `INSERT INTO roast_curve_points (roast_batch_id, elapsed_time, temperature) VALUES (?, ?, ?)`
+	selectBatchQuery = `
+SELECT
+	id,
+	green_coffee,
+	roast_date,
+	roast_level,
+	green_weight,
+	roasted_weight,
+	charge_temperature,
+	first_crack_time,
+	development_time,
+	end_temperature,
+	green_coffee_id,
+	beans_id,
+	created_at,
+	updated_at
+FROM roast_batches
+WHERE id = ?`
+)
+
+var batchColumns = []string{
+	"id", "green_coffee", "roast_date", "roast_level", "green_weight", "roasted_weight",
+	"charge_temperature", "first_crack_time", "development_time", "end_temperature", "green_coffee_id", "beans_id", "created_at", "updated_at",
+}
+
+func TestRoastBatchRepositoryMySQLBehavior(t *testing.T) {
+	roastDate := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	batch := &sql.RoastBatch{
+		GreenCoffee: "Ethiopia Guji", RoastDate: &roastDate, RoastLevel: sql.RoastLevelLight,
+		GreenWeight: 250, RoastedWeight: 215, ChargeTemperature: 200, FirstCrackTime: 480, DevelopmentTime: 75, EndTemperature: 205,
+		CurvePoints: []sql.RoastCurvePoint{{ElapsedTime: 0, Temperature: 200}, {ElapsedTime: 60, Temperature: 110}},
+	}
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *RoastBatch, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create inserts the batch and its curve points in a transaction",
+			run: func(t *testing.T, repository *RoastBatch, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(insertBatchQuery).
+					WithArgs("Ethiopia Guji", roastDate, sql.RoastLevelLight, 250.0, 215.0, 200.0, 480, 75, 205.0, nil, nil).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectExec(insertPointQuery).WithArgs(3, 0, 200.0).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(insertPointQuery).WithArgs(3, 60, 110.0).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+
+				id, err := repository.CreateRoastBatch(context.Background(), batch)
+				if err != nil {
+					t.Fatalf("CreateRoastBatch() error = %v", err)
+				}
+				if id != 3 {
+					t.Errorf("CreateRoastBatch() id = %d, want 3", id)
+				}
+			},
+		},
+		{
+			name: "create with invalid weights rolls back and returns range error",
+			run: func(t *testing.T, repository *RoastBatch, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(insertBatchQuery).
+					WithArgs("Ethiopia Guji", roastDate, sql.RoastLevelLight, 250.0, 215.0, 200.0, 480, 75, 205.0, nil, nil).
+					WillReturnError(&mysql.MySQLError{Number: 3819, Message: "Check constraint 'chk_roast_batches_weights' is violated."})
+				mock.ExpectRollback()
+
+				_, err := repository.CreateRoastBatch(context.Background(), batch)
+				if !errors.Is(err, domainerrors.ErrRoastBatchWeightOutOfRange) {
+					t.Fatalf("CreateRoastBatch() error = %v, want %v", err, domainerrors.ErrRoastBatchWeightOutOfRange)
+				}
+			},
+		},
+		{
+			name: "get missing batch returns domain error",
+			run: func(t *testing.T, repository *RoastBatch, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectBatchQuery).WithArgs(42).WillReturnError(dbsql.ErrNoRows)
+
+				_, err := repository.GetRoastBatchById(context.Background(), 42)
+				if !errors.Is(err, domainerrors.ErrRoastBatchDoesNotExist) {
+					t.Fatalf("GetRoastBatchById() error = %v, want %v", err, domainerrors.ErrRoastBatchDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "update missing batch returns domain error",
+			run: func(t *testing.T, repository *RoastBatch, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM roast_batches WHERE id = ?").WithArgs(42).WillReturnError(dbsql.ErrNoRows)
+				mock.ExpectRollback()
+
+				_, err := repository.UpdateRoastBatchById(context.Background(), 42, batch)
+				if !errors.Is(err, domainerrors.ErrRoastBatchDoesNotExist) {
+					t.Fatalf("UpdateRoastBatchById() error = %v, want %v", err, domainerrors.ErrRoastBatchDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "create beans reuses the existing roaster and links the beans",
+			run: func(t *testing.T, repository *RoastBatch, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectBatchQuery).WithArgs(3).
+					WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(3, "Ethiopia Guji", roastDate, 0, 250.0, 215.0, 200.0, 480, 75, 205.0, nil, nil, roastDate, nil))
+				mock.ExpectQuery("SELECT id FROM roasters WHERE name = ?").WithArgs("self").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectExec("INSERT INTO beans (name, roaster_id, roast_date, roast_level, owner_id) VALUES (?, ?, ?, ?, ?)").
+					WithArgs("Ethiopia Guji", 7, roastDate, sql.RoastLevelLight, nil).
+					WillReturnResult(sqlmock.NewResult(11, 1))
+				mock.ExpectExec("UPDATE roast_batches SET beans_id = ? WHERE id = ? AND beans_id IS NULL").WithArgs(11, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+
+				id, err := repository.CreateBeansFromRoastBatch(context.Background(), 3, "self")
+				if err != nil {
+					t.Fatalf("CreateBeansFromRoastBatch() error = %v", err)
+				}
+				if id != 11 {
+					t.Errorf("CreateBeansFromRoastBatch() id = %d, want 11", id)
+				}
+			},
+		},
+		{
+			name: "create beans creates the roaster when missing",
+			run: func(t *testing.T, repository *RoastBatch, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectBatchQuery).WithArgs(3).
+					WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(3, "Ethiopia Guji", roastDate, 0, 250.0, 215.0, 200.0, 480, 75, 205.0, nil, nil, roastDate, nil))
+				mock.ExpectQuery("SELECT id FROM roasters WHERE name = ?").WithArgs("self").WillReturnError(dbsql.ErrNoRows)
+				mock.ExpectExec("INSERT INTO roasters (name, owner_id) VALUES (?, ?)").WithArgs("self", nil).WillReturnResult(sqlmock.NewResult(8, 1))
+				mock.ExpectExec("INSERT INTO beans (name, roaster_id, roast_date, roast_level, owner_id) VALUES (?, ?, ?, ?, ?)").
+					WithArgs("Ethiopia Guji", 8, roastDate, sql.RoastLevelLight, nil).
+					WillReturnResult(sqlmock.NewResult(11, 1))
+				mock.ExpectExec("UPDATE roast_batches SET beans_id = ? WHERE id = ? AND beans_id IS NULL").WithArgs(11, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+
+				if _, err := repository.CreateBeansFromRoastBatch(context.Background(), 3, "self"); err != nil {
+					t.Fatalf("CreateBeansFromRoastBatch() error = %v", err)
+				}
+			},
+		},
+		{
+			name: "create beans twice returns already exist",
+			run: func(t *testing.T, repository *RoastBatch, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectBatchQuery).WithArgs(3).
+					WillReturnRows(sqlmock.NewRows(batchColumns).AddRow(3, "Ethiopia Guji", roastDate, 0, 250.0, 215.0, 200.0, 480, 75, 205.0, nil, 11, roastDate, nil))
+				mock.ExpectRollback()
+
+				_, err := repository.CreateBeansFromRoastBatch(context.Background(), 3, "self")
+				if !errors.Is(err, domainerrors.ErrRoastBatchBeansAlreadyExist) {
+					t.Fatalf("CreateBeansFromRoastBatch() error = %v, want %v", err, domainerrors.ErrRoastBatchBeansAlreadyExist)
+				}
+			},
+		},
+		{
+			name: "delete missing batch returns domain error",
+			run: func(t *testing.T, repository *RoastBatch, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM roast_batches WHERE id = ?").WithArgs(42).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				err := repository.DeleteRoastBatchById(context.Background(), 42)
+				if !errors.Is(err, domainerrors.ErrRoastBatchDoesNotExist) {
+					t.Fatalf("DeleteRoastBatchById() error = %v, want %v", err, domainerrors.ErrRoastBatchDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "create with green coffee of another user returns green coffee does not exist",
+			run: func(t *testing.T, repository *RoastBatch, mock sqlmock.Sqlmock) {
+				aliceCtx := auth.NewContext(context.Background(), &auth.User{Id: 7, Name: "alice"})
+				greenCoffeeId := 4
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(*) FROM green_coffees WHERE id = ?\n\tAND owner_id = ?").WithArgs(4, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectRollback()
+
+				_, err := repository.CreateRoastBatch(aliceCtx, &sql.RoastBatch{GreenCoffee: "Ethiopia Guji", GreenWeight: 250, RoastedWeight: 215, GreenCoffeeId: &greenCoffeeId})
+				if !errors.Is(err, domainerrors.ErrGreenCoffeeDoesNotExist) {
+					t.Fatalf("CreateRoastBatch() error = %v, want %v", err, domainerrors.ErrGreenCoffeeDoesNotExist)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -31,7 +31,7 @@ func TestDBCreateRoaster(t *testing.T) {
 			name: "Unique roaster - no error",
 			args: args{ctx: context.TODO(), roaster: &sql.Roaster{Name: "roaster01"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO roasters (name) VALUES (?)").WithArgs("roaster01").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO roasters (name, owner_id) VALUES (?, ?)").WithArgs("roaster01", nil).WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			wantErr: false,
 		},
@@ -39,7 +39,7 @@ func TestDBCreateRoaster(t *testing.T) {
 			name: "Duplicate roaster - no error",
 			args: args{ctx: context.TODO(), roaster: &sql.Roaster{Name: "roasteralreadyexists"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO roasters (name) VALUES (?)").WithArgs("roasteralreadyexists").WillReturnError(&mysql.MySQLError{
+				mock.ExpectExec("INSERT INTO roasters (name, owner_id) VALUES (?, ?)").WithArgs("roasteralreadyexists", nil).WillReturnError(&mysql.MySQLError{
 					Number: 1062, // Error 1062 is "Duplicate entry"
 				})
 			},
@@ -49,7 +49,7 @@ func TestDBCreateRoaster(t *testing.T) {
 			name: "Unique roaster - error",
 			args: args{ctx: context.TODO(), roaster: &sql.Roaster{Name: "roaster02"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO roasters (name) VALUES (?)").WithArgs("roaster02").WillReturnError(fmt.Errorf("mock error"))
+				mock.ExpectExec("INSERT INTO roasters (name, owner_id) VALUES (?, ?)").WithArgs("roaster02", nil).WillReturnError(fmt.Errorf("mock error"))
 			},
 			wantErr: true,
 		},
@@ -0,0 +1,18 @@
+package seed
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+var _ repository.SeedRepository = (*Seed)(nil)
+
+type Seed struct {
+	*shared.Seed
+}
+
+func New(db *sqlx.DB) *Seed {
+	return &Seed{shared.NewSeed(db, adapters.MySQL())}
+}
@@ -0,0 +1,84 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+func TestSeedRepositoryMySQLResetSeed(t *testing.T) {
+	tables := []string{"cupping_scores", "cupping_sessions", "roast_batches", "shots", "beans", "sheets", "roasters"}
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *Seed, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "reset deletes each table before those it references in a single transaction",
+			run: func(t *testing.T, repository *Seed, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				for i, table := range tables {
+					mock.ExpectExec("DELETE FROM " + table).WillReturnResult(sqlmock.NewResult(0, int64(i+1)))
+				}
+				mock.ExpectCommit()
+
+				reset, err := repository.ResetSeed(context.Background())
+				if err != nil {
+					t.Fatalf("ResetSeed() error = %v", err)
+				}
+				want := sql.SeedReset{CuppingSessions: 2, RoastBatches: 3, Shots: 4, Beans: 5, Sheets: 6, Roasters: 7}
+				if *reset != want {
+					t.Errorf("ResetSeed() = %+v, want %+v", *reset, want)
+				}
+			},
+		},
+		{
+			name: "reset of a user only deletes theirs",
+			run: func(t *testing.T, repository *Seed, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				for _, table := range tables {
+					mock.ExpectExec("DELETE FROM " + table + "\nWHERE owner_id = ?").WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 0))
+				}
+				mock.ExpectCommit()
+
+				ctx := auth.NewContext(context.Background(), &auth.User{Id: 7, Name: "alice"})
+				if _, err := repository.ResetSeed(ctx); err != nil {
+					t.Fatalf("ResetSeed() error = %v", err)
+				}
+			},
+		},
+		{
+			name: "failed reset rolls back",
+			run: func(t *testing.T, repository *Seed, mock sqlmock.Sqlmock) {
+				errDatabase := errors.New("foreign key constraint fails")
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM cupping_scores").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM cupping_sessions").WillReturnError(errDatabase)
+				mock.ExpectRollback()
+
+				if _, err := repository.ResetSeed(context.Background()); !errors.Is(err, errDatabase) {
+					t.Fatalf("ResetSeed() error = %v, want %v", err, errDatabase)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
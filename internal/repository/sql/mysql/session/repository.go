@@ -0,0 +1,18 @@
+package session
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+var _ repository.SessionRepository = (*Session)(nil)
+
+type Session struct {
+	*shared.Session
+}
+
+func New(db *sqlx.DB) *Session {
+	return &Session{shared.NewSession(db, adapters.MySQL())}
+}
@@ -0,0 +1,120 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const selectSessionQuery = `
+SELECT
+	sessions.id,
+	sessions.user_id,
+	sessions.token_hash,
+	sessions.csrf_token,
+	sessions.expires_at,
+	sessions.created_at,
+	users.name AS user_name,
+	users.role AS user_role,
+	users.disabled AS user_disabled
+FROM sessions
+	INNER JOIN users ON sessions.user_id = users.id`
+
+var sessionColumns = []string{
+	"id", "user_id", "token_hash", "csrf_token", "expires_at", "created_at", "user_name", "user_role", "user_disabled",
+}
+
+func TestSessionRepositoryMySQLBehavior(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 17, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *Session, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create returns last insert id",
+			run: func(t *testing.T, repository *Session, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO sessions (user_id, token_hash, csrf_token, expires_at) VALUES (?, ?, ?, ?)").
+					WithArgs(4, "token-hash", "csrf", now).
+					WillReturnResult(sqlmock.NewResult(9, 1))
+
+				id, err := repository.CreateSession(context.Background(), &sql.Session{UserId: 4, TokenHash: "token-hash", CSRFToken: "csrf", ExpiresAt: now})
+				if err != nil {
+					t.Fatalf("CreateSession() error = %v", err)
+				}
+				if id != 9 {
+					t.Errorf("CreateSession() id = %d, want 9", id)
+				}
+			},
+		},
+		{
+			name: "get by token hash joins the user",
+			run: func(t *testing.T, repository *Session, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectSessionQuery + "\nWHERE sessions.token_hash = ?").WithArgs("token-hash").
+					WillReturnRows(sqlmock.NewRows(sessionColumns).AddRow(9, 4, "token-hash", "csrf", now, now, "alice", "barista", false))
+
+				session, err := repository.GetSessionByTokenHash(context.Background(), "token-hash")
+				if err != nil {
+					t.Fatalf("GetSessionByTokenHash() error = %v", err)
+				}
+				if session.Id != 9 || session.UserName != "alice" || session.UserRole != "barista" || session.CSRFToken != "csrf" || !session.ExpiresAt.Equal(now) {
+					t.Errorf("GetSessionByTokenHash() = %+v, want session 9 of alice", session)
+				}
+			},
+		},
+		{
+			name: "get unknown token hash returns does not exist",
+			run: func(t *testing.T, repository *Session, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectSessionQuery + "\nWHERE sessions.token_hash = ?").WithArgs("unknown").
+					WillReturnRows(sqlmock.NewRows(sessionColumns))
+
+				_, err := repository.GetSessionByTokenHash(context.Background(), "unknown")
+				if !errors.Is(err, domainerrors.ErrSessionDoesNotExist) {
+					t.Fatalf("GetSessionByTokenHash() error = %v, want %v", err, domainerrors.ErrSessionDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "delete by token hash",
+			run: func(t *testing.T, repository *Session, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM sessions WHERE token_hash = ?").WithArgs("token-hash").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				if err := repository.DeleteSessionByTokenHash(context.Background(), "token-hash"); err != nil {
+					t.Fatalf("DeleteSessionByTokenHash() error = %v", err)
+				}
+			},
+		},
+		{
+			name: "delete expired sessions",
+			run: func(t *testing.T, repository *Session, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM sessions WHERE expires_at < ?").WithArgs(now).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+
+				if err := repository.DeleteExpiredSessions(context.Background(), now); err != nil {
+					t.Fatalf("DeleteExpiredSessions() error = %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
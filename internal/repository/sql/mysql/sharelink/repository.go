@@ -0,0 +1,18 @@
+package sharelink
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+var _ repository.ShareLinkRepository = (*ShareLink)(nil)
+
+type ShareLink struct {
+	*shared.ShareLink
+}
+
+func New(db *sqlx.DB) *ShareLink {
+	return &ShareLink{shared.NewShareLink(db, adapters.MySQL())}
+}
@@ -0,0 +1,170 @@
+package sharelink
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const selectShareLinkQuery = `
+SELECT
+	id,
+	sheet_id,
+	owner_id,
+	expires_at,
+	revoked_at,
+	created_at
+FROM share_links`
+
+var shareLinkColumns = []string{"id", "sheet_id", "owner_id", "expires_at", "revoked_at", "created_at"}
+
+func TestShareLinkRepositoryMySQLBehavior(t *testing.T) {
+	aliceCtx := auth.NewContext(context.Background(), &auth.User{Id: 7, Name: "alice"})
+	now := time.Date(2026, time.October, 18, 20, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(7 * 24 * time.Hour)
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *ShareLink, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create records the owner of the link",
+			run: func(t *testing.T, repository *ShareLink, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(*) FROM sheets WHERE id = ?\n\tAND owner_id = ?").WithArgs(3, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO share_links (sheet_id, owner_id, expires_at) VALUES (?, ?, ?)").
+					WithArgs(3, 7, expiresAt).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+
+				id, err := repository.CreateShareLink(aliceCtx, &sql.ShareLink{SheetId: 3, ExpiresAt: expiresAt})
+				if err != nil {
+					t.Fatalf("CreateShareLink() error = %v", err)
+				}
+				if id != 5 {
+					t.Errorf("CreateShareLink() id = %d, want 5", id)
+				}
+			},
+		},
+		{
+			name: "create for a sheet of another user returns sheet does not exist",
+			run: func(t *testing.T, repository *ShareLink, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(*) FROM sheets WHERE id = ?\n\tAND owner_id = ?").WithArgs(3, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				_, err := repository.CreateShareLink(aliceCtx, &sql.ShareLink{SheetId: 3, ExpiresAt: expiresAt})
+				if !errors.Is(err, domainerrors.ErrSheetDoesNotExist) {
+					t.Fatalf("CreateShareLink() error = %v, want %v", err, domainerrors.ErrSheetDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "create for a missing sheet returns sheet does not exist",
+			run: func(t *testing.T, repository *ShareLink, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO share_links (sheet_id, owner_id, expires_at) VALUES (?, ?, ?)").
+					WithArgs(9, nil, expiresAt).
+					WillReturnError(&mysql.MySQLError{
+						Number:  1452,
+						Message: "Cannot add or update a child row: a foreign key constraint fails (`espresso-api`.`share_links`, CONSTRAINT `fk_share_links_sheet` FOREIGN KEY (`sheet_id`) REFERENCES `sheets` (`id`) ON DELETE CASCADE)",
+					})
+
+				_, err := repository.CreateShareLink(context.Background(), &sql.ShareLink{SheetId: 9, ExpiresAt: expiresAt})
+				if !errors.Is(err, domainerrors.ErrSheetDoesNotExist) {
+					t.Fatalf("CreateShareLink() error = %v, want %v", err, domainerrors.ErrSheetDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "get without a user is not scoped",
+			run: func(t *testing.T, repository *ShareLink, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectShareLinkQuery + "\nWHERE id = ?").WithArgs(5).
+					WillReturnRows(sqlmock.NewRows(shareLinkColumns).AddRow(5, 3, 7, expiresAt, nil, now))
+
+				got, err := repository.GetShareLinkById(context.Background(), 5)
+				if err != nil {
+					t.Fatalf("GetShareLinkById() error = %v", err)
+				}
+				if got.Id != 5 || got.SheetId != 3 || !got.ExpiresAt.Equal(expiresAt) || got.RevokedAt != nil {
+					t.Errorf("GetShareLinkById() = %+v, want active link 5 of sheet 3", got)
+				}
+			},
+		},
+		{
+			name: "get a link of another user returns does not exist",
+			run: func(t *testing.T, repository *ShareLink, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectShareLinkQuery+"\nWHERE id = ?\n\tAND owner_id = ?").WithArgs(5, 7).
+					WillReturnRows(sqlmock.NewRows(shareLinkColumns))
+
+				_, err := repository.GetShareLinkById(aliceCtx, 5)
+				if !errors.Is(err, domainerrors.ErrShareLinkDoesNotExist) {
+					t.Fatalf("GetShareLinkById() error = %v, want %v", err, domainerrors.ErrShareLinkDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "get by sheet checks the owner of the sheet",
+			run: func(t *testing.T, repository *ShareLink, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(*) FROM sheets WHERE id = ?\n\tAND owner_id = ?").WithArgs(3, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(selectShareLinkQuery + "\nWHERE sheet_id = ?\nORDER BY id").WithArgs(3).
+					WillReturnRows(sqlmock.NewRows(shareLinkColumns).
+						AddRow(4, 3, 7, expiresAt, now, now).
+						AddRow(5, 3, 7, expiresAt, nil, now))
+
+				got, err := repository.GetShareLinksBySheetId(aliceCtx, 3)
+				if err != nil {
+					t.Fatalf("GetShareLinksBySheetId() error = %v", err)
+				}
+				if len(got) != 2 || got[0].RevokedAt == nil || got[1].RevokedAt != nil {
+					t.Errorf("GetShareLinksBySheetId() = %+v, want revoked link 4 and active link 5", got)
+				}
+			},
+		},
+		{
+			name: "revoke is scoped to the owner",
+			run: func(t *testing.T, repository *ShareLink, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE share_links SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?\n\tAND owner_id = ?").WithArgs(now, 5, 7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				if err := repository.RevokeShareLinkById(aliceCtx, 5, now); err != nil {
+					t.Fatalf("RevokeShareLinkById() error = %v", err)
+				}
+			},
+		},
+		{
+			name: "revoke missing link returns does not exist",
+			run: func(t *testing.T, repository *ShareLink, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE share_links SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?").WithArgs(now, 9).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(selectShareLinkQuery + "\nWHERE id = ?").WithArgs(9).
+					WillReturnRows(sqlmock.NewRows(shareLinkColumns))
+
+				err := repository.RevokeShareLinkById(context.Background(), 9, now)
+				if !errors.Is(err, domainerrors.ErrShareLinkDoesNotExist) {
+					t.Fatalf("RevokeShareLinkById() error = %v, want %v", err, domainerrors.ErrShareLinkDoesNotExist)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -31,7 +31,15 @@ func TestDBCreateSheet(t *testing.T) {
 			name: "Unique sheet - no error",
 			args: args{ctx: context.TODO(), sheet: &sql.Sheet{Name: "sheet01"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO sheets (name) VALUES (?)").WithArgs("sheet01").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO sheets (name, is_template, owner_id) VALUES (?, ?, ?)").WithArgs("sheet01", false, nil).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "Template sheet - no error",
+			args: args{ctx: context.TODO(), sheet: &sql.Sheet{Name: "template", IsTemplate: true}},
+			mockClosure: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO sheets (name, is_template, owner_id) VALUES (?, ?, ?)").WithArgs("template", true, nil).WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			wantErr: false,
 		},
@@ -39,7 +47,7 @@ func TestDBCreateSheet(t *testing.T) {
 			name: "Duplicate sheet - no error",
 			args: args{ctx: context.TODO(), sheet: &sql.Sheet{Name: "sheetalreadyexists"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO sheets (name) VALUES (?)").WithArgs("sheetalreadyexists").WillReturnError(&mysql.MySQLError{
+				mock.ExpectExec("INSERT INTO sheets (name, is_template, owner_id) VALUES (?, ?, ?)").WithArgs("sheetalreadyexists", false, nil).WillReturnError(&mysql.MySQLError{
 					Number: 1062, // Error 1062 is "Duplicate entry"
 				})
 			},
@@ -49,7 +57,7 @@ func TestDBCreateSheet(t *testing.T) {
 			name: "Unique sheet - error",
 			args: args{ctx: context.TODO(), sheet: &sql.Sheet{Name: "sheet02"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO sheets (name) VALUES (?)").WithArgs("sheet02").WillReturnError(fmt.Errorf("mock error"))
+				mock.ExpectExec("INSERT INTO sheets (name, is_template, owner_id) VALUES (?, ?, ?)").WithArgs("sheet02", false, nil).WillReturnError(fmt.Errorf("mock error"))
 			},
 			wantErr: true,
 		},
@@ -98,7 +106,7 @@ func TestSheetGetSheetById(t *testing.T) {
 			name: "Sheet exists",
 			args: args{ctx: context.TODO(), id: 1},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("^SELECT id, name, created_at, updated_at FROM sheets WHERE id = \\?$").WithArgs(1).WillReturnRows(
+				mock.ExpectQuery("^SELECT id, name, is_template, created_at, updated_at FROM sheets WHERE id = \\?$").WithArgs(1).WillReturnRows(
 					sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "sheet01"),
 				)
 			},
@@ -109,7 +117,7 @@ func TestSheetGetSheetById(t *testing.T) {
 			name: "Sheet does not exists",
 			args: args{ctx: context.TODO(), id: 2},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("^SELECT id, name, created_at, updated_at FROM sheets WHERE id = \\?$").WithArgs(2).WillReturnError(dbsql.ErrNoRows)
+				mock.ExpectQuery("^SELECT id, name, is_template, created_at, updated_at FROM sheets WHERE id = \\?$").WithArgs(2).WillReturnError(dbsql.ErrNoRows)
 			},
 			want:    nil,
 			wantErr: true,
@@ -118,7 +126,7 @@ func TestSheetGetSheetById(t *testing.T) {
 			name: "Error",
 			args: args{ctx: context.TODO(), id: 3},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("^SELECT id, name, created_at, updated_at FROM sheets WHERE id = \\?$").WithArgs(3).WillReturnError(fmt.Errorf("mock error"))
+				mock.ExpectQuery("^SELECT id, name, is_template, created_at, updated_at FROM sheets WHERE id = \\?$").WithArgs(3).WillReturnError(fmt.Errorf("mock error"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -171,7 +179,7 @@ func TestSheetGetSheetByName(t *testing.T) {
 			name: "Sheet exists",
 			args: args{ctx: context.TODO(), name: "sheet01"},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("^SELECT id, name, created_at, updated_at FROM sheets WHERE name = \\?$").WithArgs("sheet01").WillReturnRows(
+				mock.ExpectQuery("^SELECT id, name, is_template, created_at, updated_at FROM sheets WHERE name = \\?$").WithArgs("sheet01").WillReturnRows(
 					sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "sheet01"),
 				)
 			},
@@ -182,7 +190,7 @@ func TestSheetGetSheetByName(t *testing.T) {
 			name: "Sheet does not exists",
 			args: args{ctx: context.TODO(), name: "sheet02"},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("^SELECT id, name, created_at, updated_at FROM sheets WHERE name = \\?$").WithArgs("sheet02").WillReturnError(dbsql.ErrNoRows)
+				mock.ExpectQuery("^SELECT id, name, is_template, created_at, updated_at FROM sheets WHERE name = \\?$").WithArgs("sheet02").WillReturnError(dbsql.ErrNoRows)
 			},
 			want:    nil,
 			wantErr: true,
@@ -191,7 +199,7 @@ func TestSheetGetSheetByName(t *testing.T) {
 			name: "Error",
 			args: args{ctx: context.TODO(), name: "sheet03"},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("^SELECT id, name, created_at, updated_at FROM sheets WHERE name = \\?$").WithArgs("sheet03").WillReturnError(fmt.Errorf("mock error"))
+				mock.ExpectQuery("^SELECT id, name, is_template, created_at, updated_at FROM sheets WHERE name = \\?$").WithArgs("sheet03").WillReturnError(fmt.Errorf("mock error"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -245,7 +253,7 @@ func TestSheetGetAllSheets(t *testing.T) {
 			name: "Empty result",
 			args: args{context.TODO()},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("SELECT id, name, created_at, updated_at FROM sheets").WillReturnRows(
+				mock.ExpectQuery("SELECT id, name, is_template, created_at, updated_at FROM sheets").WillReturnRows(
 					sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}),
 				)
 			},
@@ -256,7 +264,7 @@ func TestSheetGetAllSheets(t *testing.T) {
 			name: "Non empty result",
 			args: args{context.TODO()},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("SELECT id, name, created_at, updated_at FROM sheets").WillReturnRows(
+				mock.ExpectQuery("SELECT id, name, is_template, created_at, updated_at FROM sheets").WillReturnRows(
 					sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
 						AddRow(1, "sheet01", now, nil).
 						AddRow(2, "sheet02", now, now).
@@ -274,7 +282,7 @@ func TestSheetGetAllSheets(t *testing.T) {
 			name: "Error",
 			args: args{context.TODO()},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("SELECT id, name, created_at, updated_at FROM sheets").WillReturnError(fmt.Errorf("mock error"))
+				mock.ExpectQuery("SELECT id, name, is_template, created_at, updated_at FROM sheets").WillReturnError(fmt.Errorf("mock error"))
 			},
 			want:    []sql.Sheet{},
 			wantErr: true,
@@ -329,7 +337,7 @@ func TestSheetUpdateSheetById(t *testing.T) {
 			name: "Sheet.Id matching id - No error",
 			args: args{ctx: context.TODO(), id: 1, sheet: &sql.Sheet{Id: 1, Name: "sheetnewname"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("UPDATE sheets SET name = ? WHERE id = ?").WithArgs("sheetnewname", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE sheets SET name = ?, is_template = ? WHERE id = ?").WithArgs("sheetnewname", false, 1).WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			want:    &sql.Sheet{Id: 1, Name: "sheetnewname"},
 			wantErr: false,
@@ -338,7 +346,7 @@ func TestSheetUpdateSheetById(t *testing.T) {
 			name: "Duplicate sheet name",
 			args: args{ctx: context.TODO(), id: 1, sheet: &sql.Sheet{Id: 1, Name: "sheetalreadyexists"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("UPDATE sheets SET name = ? WHERE id = ?").WithArgs("sheetalreadyexists", 1).WillReturnError(&mysql.MySQLError{Number: 1062})
+				mock.ExpectExec("UPDATE sheets SET name = ?, is_template = ? WHERE id = ?").WithArgs("sheetalreadyexists", false, 1).WillReturnError(&mysql.MySQLError{Number: 1062})
 			},
 			want:        nil,
 			wantErr:     true,
@@ -348,7 +356,7 @@ func TestSheetUpdateSheetById(t *testing.T) {
 			name: "Sheet.Id matching id - Error",
 			args: args{ctx: context.TODO(), id: 1, sheet: &sql.Sheet{Id: 1, Name: "sheetnewname"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("UPDATE sheets SET name = ? WHERE id = ?").WithArgs("sheetnewname", 1).WillReturnError(fmt.Errorf("mock error"))
+				mock.ExpectExec("UPDATE sheets SET name = ?, is_template = ? WHERE id = ?").WithArgs("sheetnewname", false, 1).WillReturnError(fmt.Errorf("mock error"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -357,7 +365,7 @@ func TestSheetUpdateSheetById(t *testing.T) {
 			name: "Sheet.Id not matching id - No error",
 			args: args{ctx: context.TODO(), id: 1, sheet: &sql.Sheet{Id: 2, Name: "sheetnewname"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("UPDATE sheets SET name = ? WHERE id = ?").WithArgs("sheetnewname", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE sheets SET name = ?, is_template = ? WHERE id = ?").WithArgs("sheetnewname", false, 1).WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			want:    &sql.Sheet{Id: 1, Name: "sheetnewname"},
 			wantErr: false,
@@ -366,7 +374,7 @@ func TestSheetUpdateSheetById(t *testing.T) {
 			name: "Sheet.Id not matching id - Error",
 			args: args{ctx: context.TODO(), id: 1, sheet: &sql.Sheet{Id: 2, Name: "sheetnewname"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("UPDATE sheets SET name = ? WHERE id = ?").WithArgs("sheetnewname", 1).WillReturnError(fmt.Errorf("mock error"))
+				mock.ExpectExec("UPDATE sheets SET name = ?, is_template = ? WHERE id = ?").WithArgs("sheetnewname", false, 1).WillReturnError(fmt.Errorf("mock error"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -375,8 +383,8 @@ func TestSheetUpdateSheetById(t *testing.T) {
 			name: "Unchanged sheet exists",
 			args: args{ctx: context.TODO(), id: 1, sheet: &sql.Sheet{Id: 1, Name: "sheetnewname"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("UPDATE sheets SET name = ? WHERE id = ?").WithArgs("sheetnewname", 1).WillReturnResult(sqlmock.NewResult(0, 0))
-				mock.ExpectQuery("SELECT id, name, created_at, updated_at FROM sheets WHERE id = ?").WithArgs(1).WillReturnRows(
+				mock.ExpectExec("UPDATE sheets SET name = ?, is_template = ? WHERE id = ?").WithArgs("sheetnewname", false, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT id, name, is_template, created_at, updated_at FROM sheets WHERE id = ?").WithArgs(1).WillReturnRows(
 					sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "sheetnewname"),
 				)
 			},
@@ -387,8 +395,8 @@ func TestSheetUpdateSheetById(t *testing.T) {
 			name: "Sheet does not exist",
 			args: args{ctx: context.TODO(), id: 2, sheet: &sql.Sheet{Id: 2, Name: "sheetnewname"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("UPDATE sheets SET name = ? WHERE id = ?").WithArgs("sheetnewname", 2).WillReturnResult(sqlmock.NewResult(0, 0))
-				mock.ExpectQuery("SELECT id, name, created_at, updated_at FROM sheets WHERE id = ?").WithArgs(2).WillReturnError(dbsql.ErrNoRows)
+				mock.ExpectExec("UPDATE sheets SET name = ?, is_template = ? WHERE id = ?").WithArgs("sheetnewname", false, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT id, name, is_template, created_at, updated_at FROM sheets WHERE id = ?").WithArgs(2).WillReturnError(dbsql.ErrNoRows)
 			},
 			want:    nil,
 			wantErr: true,
@@ -540,3 +548,123 @@ func TestSheetPing(t *testing.T) {
 		})
 	}
 }
+
+func TestSheetCloneSheet(t *testing.T) {
+	copyQuery := `INSERT INTO
+	shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id)
+	SELECT ?, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, ?
+	FROM shots WHERE sheet_id = ? AND rating > 0
+ORDER BY rating DESC, id DESC
+LIMIT 1`
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *Sheet, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "clone copies the best shot in a single transaction",
+			run: func(t *testing.T, repository *Sheet, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(*) FROM sheets WHERE id = ?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO sheets (name, owner_id) VALUES (?, ?)").WithArgs("new bag", nil).WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec(copyQuery).WithArgs(2, nil, 1).WillReturnResult(sqlmock.NewResult(10, 1))
+				mock.ExpectCommit()
+
+				id, err := repository.CloneSheet(context.Background(), 1, "new bag", true)
+				if err != nil {
+					t.Fatalf("CloneSheet() error = %v", err)
+				}
+				if id != 2 {
+					t.Errorf("CloneSheet() = %d, want 2", id)
+				}
+			},
+		},
+		{
+			name: "clone of a sheet without rated shots copies no shot",
+			run: func(t *testing.T, repository *Sheet, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(*) FROM sheets WHERE id = ?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO sheets (name, owner_id) VALUES (?, ?)").WithArgs("new bag", nil).WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec(copyQuery).WithArgs(2, nil, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+
+				id, err := repository.CloneSheet(context.Background(), 1, "new bag", true)
+				if err != nil {
+					t.Fatalf("CloneSheet() error = %v", err)
+				}
+				if id != 2 {
+					t.Errorf("CloneSheet() = %d, want 2", id)
+				}
+			},
+		},
+		{
+			name: "clone without the best shot only creates the sheet",
+			run: func(t *testing.T, repository *Sheet, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(*) FROM sheets WHERE id = ?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO sheets (name, owner_id) VALUES (?, ?)").WithArgs("new bag", nil).WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+
+				if _, err := repository.CloneSheet(context.Background(), 1, "new bag", false); err != nil {
+					t.Fatalf("CloneSheet() error = %v", err)
+				}
+			},
+		},
+		{
+			name: "clone of a missing sheet rolls back",
+			run: func(t *testing.T, repository *Sheet, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(*) FROM sheets WHERE id = ?").WithArgs(42).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectRollback()
+
+				_, err := repository.CloneSheet(context.Background(), 42, "new bag", true)
+				if !errors.Is(err, domainerrors.ErrSheetDoesNotExist) {
+					t.Fatalf("CloneSheet() error = %v, want %v", err, domainerrors.ErrSheetDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "clone to an existing name rolls back",
+			run: func(t *testing.T, repository *Sheet, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(*) FROM sheets WHERE id = ?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO sheets (name, owner_id) VALUES (?, ?)").WithArgs("sheet01", nil).WillReturnError(&mysql.MySQLError{Number: 1062})
+				mock.ExpectRollback()
+
+				_, err := repository.CloneSheet(context.Background(), 1, "sheet01", true)
+				if !errors.Is(err, domainerrors.ErrSheetAlreadyExists) {
+					t.Fatalf("CloneSheet() error = %v, want %v", err, domainerrors.ErrSheetAlreadyExists)
+				}
+			},
+		},
+		{
+			name: "failed shot copy rolls back the new sheet",
+			run: func(t *testing.T, repository *Sheet, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(*) FROM sheets WHERE id = ?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO sheets (name, owner_id) VALUES (?, ?)").WithArgs("new bag", nil).WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec(copyQuery).WithArgs(2, nil, 1).WillReturnError(fmt.Errorf("mock error"))
+				mock.ExpectRollback()
+
+				if _, err := repository.CloneSheet(context.Background(), 1, "new bag", true); err == nil {
+					t.Fatal("CloneSheet() error = nil, want an error")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -46,9 +46,9 @@ func TestShotCreateShot(t *testing.T) {
 				ComparisonWithPreviousResult: sql.Unknown, AdditionalNotes: "This is a test"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(`INSERT INTO 
-				shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
-					WithArgs(1, 1, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Unknown, "This is a test").
+				shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+					WithArgs(1, 1, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Unknown, "This is a test", nil).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			want:    1,
@@ -60,9 +60,9 @@ func TestShotCreateShot(t *testing.T) {
 				ComparisonWithPreviousResult: sql.Unknown, AdditionalNotes: "This is a test"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(`INSERT INTO 
-				shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
-					WithArgs(1, 1, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Unknown, "This is a test").
+				shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+					WithArgs(1, 1, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Unknown, "This is a test", nil).
 					WillReturnResult(sqlmock.NewErrorResult(fmt.Errorf("mock error")))
 			},
 			want:       0,
@@ -75,9 +75,9 @@ func TestShotCreateShot(t *testing.T) {
 				ComparisonWithPreviousResult: sql.Unknown, AdditionalNotes: "This is a test"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(`INSERT INTO
-				shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
-					WithArgs(1, 1, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Unknown, "This is a test").
+				shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+					WithArgs(1, 1, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Unknown, "This is a test", nil).
 					WillReturnError(&mysql.MySQLError{Number: 1062})
 			},
 			want:        0,
@@ -90,9 +90,9 @@ func TestShotCreateShot(t *testing.T) {
 				ComparisonWithPreviousResult: sql.Unknown, AdditionalNotes: "This is a test"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(`INSERT INTO 
-				shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
-					WithArgs(1, 1, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Unknown, "This is a test").
+				shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+					WithArgs(1, 1, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Unknown, "This is a test", nil).
 					WillReturnError(&mysql.MySQLError{
 						Message: "Cannot add or update a child row: a foreign key constraint fails (`espresso-api`.`shots`, CONSTRAINT `shots_ibfk_1` FOREIGN KEY (`sheet_id`) REFERENCES `sheets` (`id`))",
 						Number:  1452, // Error 1452 is "Cannot add or update a child row: a foreign key constraint fails"
@@ -107,9 +107,9 @@ func TestShotCreateShot(t *testing.T) {
 				ComparisonWithPreviousResult: sql.Unknown, AdditionalNotes: "This is a test"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(`INSERT INTO 
-				shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
-					WithArgs(1, 1, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Unknown, "This is a test").
+				shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+					WithArgs(1, 1, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Unknown, "This is a test", nil).
 					WillReturnError(&mysql.MySQLError{
 						Message: "Cannot add or update a child row: a foreign key constraint fails (`espresso-api`.`shots`, CONSTRAINT `shots_ibfk_1` FOREIGN KEY (`sheet_id`) REFERENCES `beans` (`id`))",
 						Number:  1452, // Error 1452 is "Cannot add or update a child row: a foreign key constraint fails"
@@ -124,9 +124,9 @@ func TestShotCreateShot(t *testing.T) {
 				ComparisonWithPreviousResult: sql.Unknown, AdditionalNotes: "This is a test"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(`INSERT INTO 
-				shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
-					WithArgs(1, 1, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Unknown, "This is a test").
+				shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+					WithArgs(1, 1, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Unknown, "This is a test", nil).
 					WillReturnError(fmt.Errorf("mock error"))
 			},
 			want:    0,
@@ -138,9 +138,9 @@ func TestShotCreateShot(t *testing.T) {
 				ComparisonWithPreviousResult: sql.Unknown, AdditionalNotes: "This is a test"}},
 			mockClosure: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(`INSERT INTO 
-				shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
-					WithArgs(1, 1, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Unknown, "This is a test").
+				shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+					WithArgs(1, 1, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Unknown, "This is a test", nil).
 					WillReturnError(&mysql.MySQLError{
 						Message: "unparsable error message",
 						Number:  1452, // Error 1452 is "Cannot add or update a child row: a foreign key constraint fails"
@@ -212,6 +212,9 @@ SELECT
 	beans.name as "beans.name",
 	beans.roast_date as "beans.roast_date",
 	beans.roast_level as "beans.roast_level",
+	beans.price as "beans.price",
+	beans.currency as "beans.currency",
+	beans.bag_weight as "beans.bag_weight",
 	roaster.id AS "beans.roaster.id",
 	roaster.name AS "beans.roaster.name",
 	roaster.created_at AS "beans.roaster.created_at",
@@ -344,6 +347,9 @@ SELECT
 	beans.name as "beans.name",
 	beans.roast_date as "beans.roast_date",
 	beans.roast_level as "beans.roast_level",
+	beans.price as "beans.price",
+	beans.currency as "beans.currency",
+	beans.bag_weight as "beans.bag_weight",
 	roaster.id AS "beans.roaster.id",
 	roaster.name AS "beans.roaster.name",
 	roaster.created_at AS "beans.roaster.created_at",
@@ -478,6 +484,9 @@ SELECT
 	beans.name as "beans.name",
 	beans.roast_date as "beans.roast_date",
 	beans.roast_level as "beans.roast_level",
+	beans.price as "beans.price",
+	beans.currency as "beans.currency",
+	beans.bag_weight as "beans.bag_weight",
 	roaster.id AS "beans.roaster.id",
 	roaster.name AS "beans.roaster.name",
 	roaster.created_at AS "beans.roaster.created_at",
@@ -877,3 +886,105 @@ func TestShotPing(t *testing.T) {
 		})
 	}
 }
+
+func TestShotProfile(t *testing.T) {
+	pressure, weight := 9.0, 36.2
+	samples := []sql.ShotProfileSample{
+		{ElapsedTime: 0, Pressure: &pressure},
+		{ElapsedTime: 250, Pressure: &pressure, Weight: &weight},
+	}
+	profileColumns := []string{"shot_id", "elapsed_time", "pressure", "flow", "weight", "temperature"}
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *Shot, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "get returns the samples ordered by time",
+			run: func(t *testing.T, repository *Shot, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id FROM shots WHERE id = ?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery("SELECT shot_id, elapsed_time, pressure, flow, weight, temperature FROM shot_profile_samples WHERE shot_id = ? ORDER BY elapsed_time").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(profileColumns).AddRow(1, 0, 9.0, nil, nil, nil).AddRow(1, 250, 9.0, nil, 36.2, nil))
+
+				got, err := repository.GetShotProfileById(context.Background(), 1)
+				if err != nil {
+					t.Fatalf("GetShotProfileById() error = %v", err)
+				}
+				want := []sql.ShotProfileSample{
+					{ShotId: 1, ElapsedTime: 0, Pressure: &pressure},
+					{ShotId: 1, ElapsedTime: 250, Pressure: &pressure, Weight: &weight},
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("GetShotProfileById() = %+v, want %+v", got, want)
+				}
+			},
+		},
+		{
+			name: "get of a missing shot returns domain error",
+			run: func(t *testing.T, repository *Shot, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id FROM shots WHERE id = ?").WithArgs(42).WillReturnError(dbsql.ErrNoRows)
+
+				_, err := repository.GetShotProfileById(context.Background(), 42)
+				if !errors.Is(err, domainerrors.ErrShotDoesNotExist) {
+					t.Fatalf("GetShotProfileById() error = %v, want %v", err, domainerrors.ErrShotDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "update replaces the samples in a single insert",
+			run: func(t *testing.T, repository *Shot, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM shots WHERE id = ?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec("DELETE FROM shot_profile_samples WHERE shot_id = ?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec("INSERT INTO shot_profile_samples (shot_id, elapsed_time, pressure, flow, weight, temperature) VALUES (?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?)").
+					WithArgs(1, 0, 9.0, nil, nil, nil, 1, 250, 9.0, nil, 36.2, nil).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+
+				if err := repository.UpdateShotProfileById(context.Background(), 1, samples); err != nil {
+					t.Fatalf("UpdateShotProfileById() error = %v", err)
+				}
+			},
+		},
+		{
+			name: "update without samples only clears the profile",
+			run: func(t *testing.T, repository *Shot, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM shots WHERE id = ?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec("DELETE FROM shot_profile_samples WHERE shot_id = ?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+
+				if err := repository.UpdateShotProfileById(context.Background(), 1, nil); err != nil {
+					t.Fatalf("UpdateShotProfileById() error = %v", err)
+				}
+			},
+		},
+		{
+			name: "update of a missing shot rolls back",
+			run: func(t *testing.T, repository *Shot, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM shots WHERE id = ?").WithArgs(42).WillReturnError(dbsql.ErrNoRows)
+				mock.ExpectRollback()
+
+				err := repository.UpdateShotProfileById(context.Background(), 42, samples)
+				if !errors.Is(err, domainerrors.ErrShotDoesNotExist) {
+					t.Fatalf("UpdateShotProfileById() error = %v, want %v", err, domainerrors.ErrShotDoesNotExist)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package shotimport
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const selectImportedShotsQuery = `SELECT shot_imports.external_id, shot_imports.shot_id FROM shot_imports
+	JOIN shots ON shots.id = shot_imports.shot_id
+	WHERE shot_imports.source = ? AND shot_imports.external_id IN (?, ?)`
+
+const insertShotQuery = `INSERT INTO
+	shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+func TestShotImportRepositoryMySQLBehavior(t *testing.T) {
+	aliceCtx := auth.NewContext(context.Background(), &auth.User{Id: 7, Name: "alice"})
+	pressure := 9.0
+	imported := func() *sql.ImportedShot {
+		return &sql.ImportedShot{
+			Source: "decent", ExternalId: "1700000000",
+			SheetName: "Decent", RoasterName: "Square Mile", BeansName: "Red Brick", RoastLevel: sql.RoastLevelMedium,
+			Shot: &sql.Shot{GrindSetting: 12, QuantityIn: 18, QuantityOut: 36, ShotTime: 28500 * time.Millisecond, WaterTemperature: 93, Rating: 8,
+				ComparisonWithPreviousResult: sql.Unknown, AdditionalNotes: "sweet"},
+			Profile: []sql.ShotProfileSample{{ElapsedTime: 0, Pressure: &pressure}, {ElapsedTime: 250, Pressure: &pressure}},
+		}
+	}
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *ShotImport, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "get imported shot ids is scoped to the owner",
+			run: func(t *testing.T, repository *ShotImport, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectImportedShotsQuery+"\n\tAND shots.owner_id = ?").WithArgs("decent", "1", "2", 7).
+					WillReturnRows(sqlmock.NewRows([]string{"external_id", "shot_id"}).AddRow("2", 12))
+
+				ids, err := repository.GetImportedShotIds(aliceCtx, "decent", []string{"1", "2"})
+				if err != nil {
+					t.Fatalf("GetImportedShotIds() error = %v", err)
+				}
+				if len(ids) != 1 || ids["2"] != 12 {
+					t.Errorf("GetImportedShotIds() = %v, want map[2:12]", ids)
+				}
+			},
+		},
+		{
+			name: "get imported shot ids without external ids runs no query",
+			run: func(t *testing.T, repository *ShotImport, mock sqlmock.Sqlmock) {
+				ids, err := repository.GetImportedShotIds(context.Background(), "decent", nil)
+				if err != nil || len(ids) != 0 {
+					t.Fatalf("GetImportedShotIds() = %v, %v, want an empty map", ids, err)
+				}
+			},
+		},
+		{
+			name: "import creates the missing sheet and beans in a single transaction",
+			run: func(t *testing.T, repository *ShotImport, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT shot_imports.external_id, shot_imports.shot_id FROM shot_imports
+	JOIN shots ON shots.id = shot_imports.shot_id
+	WHERE shot_imports.source = ? AND shot_imports.external_id IN (?)
+	AND shots.owner_id = ?`).WithArgs("decent", "1700000000", 7).
+					WillReturnRows(sqlmock.NewRows([]string{"external_id", "shot_id"}))
+				mock.ExpectQuery("SELECT id FROM sheets WHERE name = ?\n\tAND owner_id = ?").WithArgs("Decent", 7).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectExec("INSERT INTO sheets (name, owner_id) VALUES (?, ?)").WithArgs("Decent", 7).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectQuery("SELECT id FROM roasters WHERE name = ?\n\tAND owner_id = ?").WithArgs("Square Mile", 7).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				mock.ExpectQuery("SELECT id FROM beans WHERE name = ? AND roaster_id = ?\n\tAND owner_id = ?").WithArgs("Red Brick", 4, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectExec("INSERT INTO beans (name, roaster_id, roast_date, roast_level, price, currency, bag_weight, owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?)").
+					WithArgs("Red Brick", 4, nil, sql.RoastLevelMedium, 0.0, "", 0.0, 7).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectExec(insertShotQuery).
+					WithArgs(3, 5, 12, 18.0, 36.0, int64(28500), 93.0, 8.0, false, false, sql.Unknown, "sweet", 7).
+					WillReturnResult(sqlmock.NewResult(9, 1))
+				mock.ExpectExec("INSERT INTO shot_profile_samples (shot_id, elapsed_time, pressure, flow, weight, temperature) VALUES (?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?)").
+					WithArgs(9, 0, &pressure, nil, nil, nil, 9, 250, &pressure, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("INSERT INTO shot_imports (shot_id, source, external_id) VALUES (?, ?, ?)").WithArgs(9, "decent", "1700000000").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+
+				id, err := repository.ImportShot(aliceCtx, imported())
+				if err != nil {
+					t.Fatalf("ImportShot() error = %v", err)
+				}
+				if id != 9 {
+					t.Errorf("ImportShot() id = %d, want 9", id)
+				}
+			},
+		},
+		{
+			name: "import of an already imported shot rolls back",
+			run: func(t *testing.T, repository *ShotImport, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT shot_imports.external_id, shot_imports.shot_id FROM shot_imports
+	JOIN shots ON shots.id = shot_imports.shot_id
+	WHERE shot_imports.source = ? AND shot_imports.external_id IN (?)`).WithArgs("decent", "1700000000").
+					WillReturnRows(sqlmock.NewRows([]string{"external_id", "shot_id"}).AddRow("1700000000", 9))
+				mock.ExpectRollback()
+
+				_, err := repository.ImportShot(context.Background(), imported())
+				if !errors.Is(err, domainerrors.ErrImportShotAlreadyExists) {
+					t.Fatalf("ImportShot() error = %v, want %v", err, domainerrors.ErrImportShotAlreadyExists)
+				}
+			},
+		},
+		{
+			name: "get shot external ids is scoped to the owner",
+			run: func(t *testing.T, repository *ShotImport, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT shot_imports.shot_id, shot_imports.external_id FROM shot_imports
+	JOIN shots ON shots.id = shot_imports.shot_id
+	WHERE shot_imports.source = ?
+	AND shots.owner_id = ?`).WithArgs("beanconqueror", 7).
+					WillReturnRows(sqlmock.NewRows([]string{"shot_id", "external_id"}).AddRow(12, "5f1c").AddRow(13, "8a2d"))
+
+				ids, err := repository.GetShotExternalIds(aliceCtx, "beanconqueror")
+				if err != nil {
+					t.Fatalf("GetShotExternalIds() error = %v", err)
+				}
+				if len(ids) != 2 || ids[12] != "5f1c" || ids[13] != "8a2d" {
+					t.Errorf("GetShotExternalIds() = %v, want map[12:5f1c 13:8a2d]", ids)
+				}
+			},
+		},
+		{
+			name: "get or create beans creates missing beans with their cost",
+			run: func(t *testing.T, repository *ShotImport, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM beans WHERE name = ? AND roaster_id = ?\n\tAND owner_id = ?").WithArgs("Red Brick", 4, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectExec("INSERT INTO beans (name, roaster_id, roast_date, roast_level, price, currency, bag_weight, owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?)").
+					WithArgs("Red Brick", 4, nil, sql.RoastLevelDark, 14.5, "", 250.0, 7).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectCommit()
+
+				id, created, err := repository.GetOrCreateBeans(aliceCtx, &sql.Beans{
+					Name: "Red Brick", Roaster: &sql.Roaster{Id: 4}, RoastLevel: sql.RoastLevelDark, Price: 14.5, BagWeight: 250,
+				})
+				if err != nil || id != 5 || !created {
+					t.Fatalf("GetOrCreateBeans() = %d, %t, %v, want 5, true, nil", id, created, err)
+				}
+			},
+		},
+		{
+			name: "get or create sheet returns the existing sheet",
+			run: func(t *testing.T, repository *ShotImport, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM sheets WHERE name = ?").WithArgs("Portafilter").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectCommit()
+
+				id, created, err := repository.GetOrCreateSheet(context.Background(), "Portafilter")
+				if err != nil || id != 3 || created {
+					t.Fatalf("GetOrCreateSheet() = %d, %t, %v, want 3, false, nil", id, created, err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package stats
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+var _ repository.StatsRepository = (*Stats)(nil)
+
+type Stats struct {
+	*shared.Stats
+}
+
+func New(db *sqlx.DB) *Stats {
+	return &Stats{shared.NewStats(db, adapters.MySQL())}
+}
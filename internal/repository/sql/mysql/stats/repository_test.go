@@ -0,0 +1,194 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const selectDailyConsumptionQuery = `
+SELECT
+	DATE(CONVERT_TZ(shots.created_at, @@session.time_zone, ?)) AS shot_date,
+	COUNT(*) AS shots,
+	SUM(shots.quantity_in) AS coffee_weight,
+	COUNT(NULLIF(shots.rating, 0)) AS rated_shots,
+	COALESCE(AVG(NULLIF(shots.rating, 0)), 0) AS average_rating
+FROM shots
+WHERE shots.created_at >= ?
+	AND shots.created_at < ?
+GROUP BY shot_date
+ORDER BY shot_date`
+
+const selectBeansShotStatsQuery = `
+SELECT
+	COUNT(*) AS shots,
+	COUNT(NULLIF(shots.rating, 0)) AS rated_shots,
+	AVG(NULLIF(shots.rating, 0)) AS average_rating,
+	MAX(NULLIF(shots.rating, 0)) AS max_rating,
+	MIN(shots.grind_setting) AS min_grind_setting,
+	MAX(shots.grind_setting) AS max_grind_setting,
+	AVG(shots.quantity_out / NULLIF(shots.quantity_in, 0)) AS average_ratio,
+	AVG(shots.shot_time_ms) AS average_shot_time_ms,
+	AVG(CASE WHEN shots.is_too_bitter THEN 1.0 ELSE 0.0 END) AS bitter_share,
+	AVG(CASE WHEN shots.is_too_sour THEN 1.0 ELSE 0.0 END) AS sour_share
+FROM shots
+WHERE shots.beans_id = ?`
+
+func TestStatsRepositoryMySQLBehavior(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	from := time.Date(2026, time.September, 1, 0, 0, 0, 0, paris)
+	to := time.Date(2026, time.October, 1, 0, 0, 0, 0, paris)
+	day := time.Date(2026, time.September, 12, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *Stats, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "get daily consumption",
+			run: func(t *testing.T, repository *Stats, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectDailyConsumptionQuery).
+					WithArgs("Europe/Paris", from, to).
+					WillReturnRows(sqlmock.NewRows([]string{"shot_date", "shots", "coffee_weight", "rated_shots", "average_rating"}).
+						AddRow(day, 2, 36.5, 2, 7.25))
+
+				got, err := repository.GetDailyConsumption(context.Background(), from, to, "Europe/Paris")
+				if err != nil {
+					t.Fatalf("GetDailyConsumption() error = %v", err)
+				}
+				want := []sql.DailyConsumption{{Day: day, Shots: 2, CoffeeWeight: 36.5, RatedShots: 2, AverageRating: 7.25}}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("GetDailyConsumption() = %+v, want %+v", got, want)
+				}
+			},
+		},
+		{
+			name: "get daily consumption error",
+			run: func(t *testing.T, repository *Stats, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectDailyConsumptionQuery).
+					WithArgs("UTC", from, to).
+					WillReturnError(errors.New("connection refused"))
+
+				if _, err := repository.GetDailyConsumption(context.Background(), from, to, "UTC"); err == nil {
+					t.Fatal("GetDailyConsumption() error = nil, want an error")
+				}
+			},
+		},
+		{
+			name: "get daily consumption without the time zone tables",
+			run: func(t *testing.T, repository *Stats, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectDailyConsumptionQuery).
+					WithArgs("Europe/Paris", from, to).
+					WillReturnRows(sqlmock.NewRows([]string{"shot_date", "shots", "coffee_weight", "rated_shots", "average_rating"}).
+						AddRow(nil, 2, 36.5, 2, 7.25))
+
+				_, err := repository.GetDailyConsumption(context.Background(), from, to, "Europe/Paris")
+				if !errors.Is(err, domainerrors.ErrStatsTimeZoneIsUnknown) {
+					t.Fatalf("GetDailyConsumption() error = %v, want %v", err, domainerrors.ErrStatsTimeZoneIsUnknown)
+				}
+			},
+		},
+		{
+			name: "get shot stats of beans",
+			run: func(t *testing.T, repository *Stats, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(*) FROM beans WHERE id = ?").
+					WithArgs(4).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(selectBeansShotStatsQuery).
+					WithArgs(4).
+					WillReturnRows(sqlmock.NewRows([]string{"shots", "rated_shots", "average_rating", "max_rating", "min_grind_setting", "max_grind_setting", "average_ratio", "average_shot_time_ms", "bitter_share", "sour_share"}).
+						AddRow(5, 4, "7.5000", 9, 12, 16, "2.0500", "27500.0000", "0.25000", "0.50000"))
+				mock.ExpectQuery("SELECT shots.rating FROM shots WHERE shots.beans_id = ? AND shots.rating > 0\nORDER BY shots.rating\nLIMIT ? OFFSET ?").
+					WithArgs(4, 2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"rating"}).AddRow(7).AddRow(8))
+				mock.ExpectQuery("SELECT shots.id FROM shots WHERE shots.beans_id = ? AND shots.rating > 0\nORDER BY shots.rating DESC, shots.id DESC\nLIMIT 1").
+					WithArgs(4).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(31))
+
+				got, err := repository.GetShotStats(context.Background(), sql.ShotStatsOfBeans, 4)
+				if err != nil {
+					t.Fatalf("GetShotStats() error = %v", err)
+				}
+				if got.Shots != 5 || got.RatedShots != 4 || *got.AverageRating != 7.5 || *got.MedianRating != 7.5 || *got.MaxRating != 9 ||
+					*got.BestShotId != 31 || *got.MinGrindSetting != 12 || *got.MaxGrindSetting != 16 ||
+					*got.AverageRatio != 2.05 || *got.AverageShotTimeMs != 27500 || *got.BitterShare != 0.25 || *got.SourShare != 0.5 {
+					t.Errorf("GetShotStats() = %+v, want the stats of 5 shots, 4 of them rated", got)
+				}
+			},
+		},
+		{
+			name: "get shot stats of beans without shots",
+			run: func(t *testing.T, repository *Stats, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(*) FROM beans WHERE id = ?").
+					WithArgs(4).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(selectBeansShotStatsQuery).
+					WithArgs(4).
+					WillReturnRows(sqlmock.NewRows([]string{"shots", "rated_shots", "average_rating", "max_rating", "min_grind_setting", "max_grind_setting", "average_ratio", "average_shot_time_ms", "bitter_share", "sour_share"}).
+						AddRow(0, 0, nil, nil, nil, nil, nil, nil, nil, nil))
+
+				got, err := repository.GetShotStats(context.Background(), sql.ShotStatsOfBeans, 4)
+				if err != nil {
+					t.Fatalf("GetShotStats() error = %v", err)
+				}
+				if got.Shots != 0 || got.AverageRating != nil || got.MedianRating != nil || got.BestShotId != nil {
+					t.Errorf("GetShotStats() = %+v, want empty stats", got)
+				}
+			},
+		},
+		{
+			name: "get shot stats of beans without rated shots",
+			run: func(t *testing.T, repository *Stats, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(*) FROM beans WHERE id = ?").
+					WithArgs(4).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(selectBeansShotStatsQuery).
+					WithArgs(4).
+					WillReturnRows(sqlmock.NewRows([]string{"shots", "rated_shots", "average_rating", "max_rating", "min_grind_setting", "max_grind_setting", "average_ratio", "average_shot_time_ms", "bitter_share", "sour_share"}).
+						AddRow(1, 0, nil, nil, 14, 14, "2.0000", "28000.0000", "0.00000", "0.00000"))
+
+				got, err := repository.GetShotStats(context.Background(), sql.ShotStatsOfBeans, 4)
+				if err != nil {
+					t.Fatalf("GetShotStats() error = %v", err)
+				}
+				if got.Shots != 1 || got.AverageRating != nil || got.MedianRating != nil || got.MaxRating != nil || got.BestShotId != nil || *got.MinGrindSetting != 14 {
+					t.Errorf("GetShotStats() = %+v, want the stats of 1 shot without ratings", got)
+				}
+			},
+		},
+		{
+			name: "get shot stats of unknown roaster",
+			run: func(t *testing.T, repository *Stats, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(*) FROM roasters WHERE id = ?").
+					WithArgs(9).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				_, err := repository.GetShotStats(context.Background(), sql.ShotStatsOfRoaster, 9)
+				if !errors.Is(err, domainerrors.ErrRoasterDoesNotExist) {
+					t.Fatalf("GetShotStats() error = %v, want %v", err, domainerrors.ErrRoasterDoesNotExist)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package user
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+var _ repository.UserRepository = (*User)(nil)
+
+type User struct {
+	*shared.User
+}
+
+func New(db *sqlx.DB) *User {
+	return &User{shared.NewUser(db, adapters.MySQL())}
+}
@@ -0,0 +1,125 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const selectUserByNameQuery = "SELECT id, name, disabled, role, created_at, updated_at FROM users WHERE name = ?"
+
+var userColumns = []string{"id", "name", "disabled", "role", "created_at", "updated_at"}
+
+func TestUserRepositoryMySQLBehavior(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *User, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create returns the inserted id",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO users (name, role) VALUES (?, ?)").WithArgs("alice", "barista").
+					WillReturnResult(sqlmock.NewResult(4, 1))
+
+				id, err := repository.CreateUser(context.Background(), &sql.User{Name: "alice", Role: "barista"})
+				if err != nil {
+					t.Fatalf("CreateUser() error = %v", err)
+				}
+				if id != 4 {
+					t.Errorf("CreateUser() id = %d, want 4", id)
+				}
+			},
+		},
+		{
+			name: "create duplicate returns already exists",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO users (name, role) VALUES (?, ?)").WithArgs("alice", "barista").
+					WillReturnError(&mysql.MySQLError{Number: 1062})
+
+				_, err := repository.CreateUser(context.Background(), &sql.User{Name: "alice", Role: "barista"})
+				if !errors.Is(err, domainerrors.ErrUserAlreadyExists) {
+					t.Fatalf("CreateUser() error = %v, want %v", err, domainerrors.ErrUserAlreadyExists)
+				}
+			},
+		},
+		{
+			name: "get all orders users by name",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, name, disabled, role, created_at, updated_at FROM users ORDER BY name").
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(4, "alice", false, "admin", now, nil).
+						AddRow(5, "bob", true, "viewer", now, now))
+
+				got, err := repository.GetAllUsers(context.Background())
+				if err != nil {
+					t.Fatalf("GetAllUsers() error = %v", err)
+				}
+				if len(got) != 2 || got[0].Name != "alice" || got[0].Disabled || got[0].Role != "admin" || !got[1].Disabled {
+					t.Errorf("GetAllUsers() = %+v, want alice enabled admin and bob disabled", got)
+				}
+			},
+		},
+		{
+			name: "get missing user returns does not exist",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, name, disabled, role, created_at, updated_at FROM users WHERE id = ?").WithArgs(9).
+					WillReturnRows(sqlmock.NewRows(userColumns))
+
+				_, err := repository.GetUserById(context.Background(), 9)
+				if !errors.Is(err, domainerrors.ErrUserDoesNotExist) {
+					t.Fatalf("GetUserById() error = %v, want %v", err, domainerrors.ErrUserDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "disable already disabled user succeeds",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE users SET disabled = ? WHERE name = ?").WithArgs(true, "bob").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(selectUserByNameQuery).WithArgs("bob").
+					WillReturnRows(sqlmock.NewRows(userColumns).AddRow(5, "bob", true, "viewer", now, now))
+
+				if err := repository.DisableUserByName(context.Background(), "bob"); err != nil {
+					t.Fatalf("DisableUserByName() error = %v", err)
+				}
+			},
+		},
+		{
+			name: "disable missing user returns does not exist",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE users SET disabled = ? WHERE name = ?").WithArgs(true, "carol").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(selectUserByNameQuery).WithArgs("carol").
+					WillReturnRows(sqlmock.NewRows(userColumns))
+
+				err := repository.DisableUserByName(context.Background(), "carol")
+				if !errors.Is(err, domainerrors.ErrUserDoesNotExist) {
+					t.Fatalf("DisableUserByName() error = %v, want %v", err, domainerrors.ErrUserDoesNotExist)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
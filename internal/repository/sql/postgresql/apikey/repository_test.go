@@ -0,0 +1,65 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+func TestAPIKeyRepositoryPostgresBehavior(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *APIKey, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create returns postgres generated id",
+			run: func(t *testing.T, repository *APIKey, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO api_keys (user_id, name, prefix, hash, scope) VALUES ($1, $2, $3, $4, $5) RETURNING id").
+					WithArgs(4, "backup", "esp_abcdefgh", "hash", "write").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+				id, err := repository.CreateAPIKey(context.Background(), &sql.APIKey{UserId: 4, Name: "backup", Prefix: "esp_abcdefgh", Hash: "hash", Scope: "write"})
+				if err != nil {
+					t.Fatalf("CreateAPIKey() error = %v", err)
+				}
+				if id != 3 {
+					t.Errorf("CreateAPIKey() id = %d, want 3", id)
+				}
+			},
+		},
+		{
+			name: "create with invalid scope returns scope error",
+			run: func(t *testing.T, repository *APIKey, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO api_keys (user_id, name, prefix, hash, scope) VALUES ($1, $2, $3, $4, $5) RETURNING id").
+					WithArgs(4, "backup", "esp_abcdefgh", "hash", "admin").
+					WillReturnError(&pgconn.PgError{Code: "23514", ConstraintName: "chk_api_keys_scope"})
+
+				_, err := repository.CreateAPIKey(context.Background(), &sql.APIKey{UserId: 4, Name: "backup", Prefix: "esp_abcdefgh", Hash: "hash", Scope: "admin"})
+				if !errors.Is(err, domainerrors.ErrAPIKeyScopeIsInvalid) {
+					t.Fatalf("CreateAPIKey() error = %v, want %v", err, domainerrors.ErrAPIKeyScopeIsInvalid)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
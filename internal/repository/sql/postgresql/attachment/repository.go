@@ -0,0 +1,18 @@
+package attachment
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+var _ repository.AttachmentRepository = (*Attachment)(nil)
+
+type Attachment struct {
+	*shared.Attachment
+}
+
+func New(db *sqlx.DB) *Attachment {
+	return &Attachment{shared.NewAttachment(db, adapters.PostgreSQL())}
+}
@@ -0,0 +1,72 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const insertAttachmentQuery = "INSERT INTO attachments (shot_id, beans_id, owner_id, filename, content_type, size, width, height, blob_key, thumbnail_key) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id"
+
+func TestAttachmentRepositoryPostgresBehavior(t *testing.T) {
+	beansId := 4
+	attachment := &sql.Attachment{
+		BeansId: &beansId, Filename: "bag.png", ContentType: "image/png", Size: 4096, Width: 800, Height: 600,
+		BlobKey: "attachments/b", ThumbnailKey: "attachments/b-thumbnail",
+	}
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *Attachment, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create returns postgres generated id",
+			run: func(t *testing.T, repository *Attachment, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(insertAttachmentQuery).
+					WithArgs(nil, &beansId, nil, "bag.png", "image/png", int64(4096), 800, 600, "attachments/b", "attachments/b-thumbnail").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+				id, err := repository.CreateAttachment(context.Background(), attachment)
+				if err != nil {
+					t.Fatalf("CreateAttachment() error = %v", err)
+				}
+				if id != 5 {
+					t.Errorf("CreateAttachment() id = %d, want 5", id)
+				}
+			},
+		},
+		{
+			name: "create for missing beans returns beans does not exist",
+			run: func(t *testing.T, repository *Attachment, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(insertAttachmentQuery).
+					WithArgs(nil, &beansId, nil, "bag.png", "image/png", int64(4096), 800, 600, "attachments/b", "attachments/b-thumbnail").
+					WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: "fk_attachments_beans"})
+
+				_, err := repository.CreateAttachment(context.Background(), attachment)
+				if !errors.Is(err, domainerrors.ErrBeansDoesNotExist) {
+					t.Fatalf("CreateAttachment() error = %v, want %v", err, domainerrors.ErrBeansDoesNotExist)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
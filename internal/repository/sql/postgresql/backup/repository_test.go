@@ -0,0 +1,46 @@
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+func TestBackupRepositoryPostgresRestoreBackupResetsSequences(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	created := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	for _, table := range []string{"roasters", "sheets", "beans", "shots"} {
+		mock.ExpectQuery("SELECT COUNT(*) FROM " + table).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	}
+	mock.ExpectExec("INSERT INTO sheets (id, name, is_template, created_at, updated_at, owner_id) VALUES ($1, $2, $3, COALESCE($4, CURRENT_TIMESTAMP), $5, $6)").
+		WithArgs(42, "Linea Mini", false, &created, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	for _, table := range []string{"roasters", "sheets", "beans", "shots"} {
+		mock.ExpectExec("SELECT setval(pg_get_serial_sequence('" + table + "', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM " + table).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	result, err := New(sqlx.NewDb(db, "sqlmock")).RestoreBackup(context.Background(), &sql.Backup{
+		Sheets: []sql.Sheet{{Id: 42, Name: "Linea Mini", CreatedAt: &created}},
+	}, false)
+	if err != nil {
+		t.Fatalf("RestoreBackup() error = %v", err)
+	}
+	if result.Sheets.Restored != 1 {
+		t.Errorf("RestoreBackup() restored %d sheets, want 1", result.Sheets.Restored)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
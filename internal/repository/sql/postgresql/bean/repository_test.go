@@ -23,8 +23,8 @@ func TestBeanRepositoryPostgresBehavior(t *testing.T) {
 		{
 			name: "create returns postgres generated id",
 			run: func(t *testing.T, repository *Bean, mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("INSERT INTO beans (name, roaster_id, roast_date, roast_level) VALUES ($1, $2, $3, $4) RETURNING id").
-					WithArgs("beans", 1, roastDate, sql.RoastLevelMedium).
+				mock.ExpectQuery("INSERT INTO beans (name, roaster_id, roast_date, roast_level, green_coffee_id, green_weight, price, currency, bag_weight, owner_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id").
+					WithArgs("beans", 1, roastDate, sql.RoastLevelMedium, nil, 0.0, 0.0, "", 0.0, nil).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
 
 				id, err := repository.CreateBeans(context.Background(), &sql.Beans{
@@ -44,8 +44,8 @@ func TestBeanRepositoryPostgresBehavior(t *testing.T) {
 		{
 			name: "create with missing roaster returns domain error",
 			run: func(t *testing.T, repository *Bean, mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("INSERT INTO beans (name, roaster_id, roast_date, roast_level) VALUES ($1, $2, $3, $4) RETURNING id").
-					WithArgs("beans", 2, roastDate, sql.RoastLevelMedium).
+				mock.ExpectQuery("INSERT INTO beans (name, roaster_id, roast_date, roast_level, green_coffee_id, green_weight, price, currency, bag_weight, owner_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id").
+					WithArgs("beans", 2, roastDate, sql.RoastLevelMedium, nil, 0.0, 0.0, "", 0.0, nil).
 					WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: "beans_roaster_id_fkey"})
 
 				_, err := repository.CreateBeans(context.Background(), &sql.Beans{
@@ -62,7 +62,7 @@ func TestBeanRepositoryPostgresBehavior(t *testing.T) {
 		{
 			name: "get missing beans returns domain error",
 			run: func(t *testing.T, repository *Bean, mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("\nSELECT\n\tbeans.id,\n\tbeans.name,\n\tbeans.roast_date,\n\tbeans.roast_level,\n\tbeans.created_at,\n\tbeans.updated_at,\n\troaster.id AS \"roaster.id\",\n\troaster.name AS \"roaster.name\",\n\troaster.created_at AS \"roaster.created_at\",\n\troaster.updated_at AS \"roaster.updated_at\"\nFROM beans\n\tINNER JOIN roasters roaster\n\t\tON beans.roaster_id = roaster.id\nWHERE\n\tbeans.id = $1").
+				mock.ExpectQuery("\nSELECT\n\tbeans.id,\n\tbeans.name,\n\tbeans.roast_date,\n\tbeans.roast_level,\n\tbeans.green_coffee_id,\n\tbeans.green_weight,\n\tbeans.price,\n\tbeans.currency,\n\tbeans.bag_weight,\n\tbeans.created_at,\n\tbeans.updated_at,\n\troaster.id AS \"roaster.id\",\n\troaster.name AS \"roaster.name\",\n\troaster.created_at AS \"roaster.created_at\",\n\troaster.updated_at AS \"roaster.updated_at\"\nFROM beans\n\tINNER JOIN roasters roaster\n\t\tON beans.roaster_id = roaster.id\nWHERE\n\tbeans.id = $1").
 					WithArgs(42).
 					WillReturnError(dbsql.ErrNoRows)
 
@@ -0,0 +1,101 @@
+package cupping
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const insertScoreQuery = `INSERT INTO
+	cupping_scores (session_id, beans_id, fragrance, flavor, aftertaste, acidity, body, balance, uniformity, clean_cup, sweetness, overall, notes, owner_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id`
+
+func TestCuppingRepositoryPostgresBehavior(t *testing.T) {
+	sessionDate := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	score := &sql.CuppingScore{
+		SessionId: 1, Beans: &sql.Beans{Id: 2},
+		Fragrance: 8, Flavor: 8, Aftertaste: 7.5, Acidity: 7.75, Body: 7.5,
+		Balance: 7.75, Uniformity: 10, CleanCup: 10, Sweetness: 10, Overall: 8,
+	}
+	args := []driver.Value{1, 2, 8.0, 8.0, 7.5, 7.75, 7.5, 7.75, 10.0, 10.0, 10.0, 8.0, "", nil}
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create session returns postgres generated id",
+			run: func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO cupping_sessions (session_date, participants, owner_id) VALUES ($1, $2, $3) RETURNING id").
+					WithArgs(sessionDate, "alice", nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+
+				id, err := repository.CreateCuppingSession(context.Background(), &sql.CuppingSession{SessionDate: &sessionDate, Participants: "alice"})
+				if err != nil {
+					t.Fatalf("CreateCuppingSession() error = %v", err)
+				}
+				if id != 4 {
+					t.Errorf("CreateCuppingSession() id = %d, want 4", id)
+				}
+			},
+		},
+		{
+			name: "create score with missing beans returns domain error",
+			run: func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(insertScoreQuery).WithArgs(args...).
+					WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: "cupping_scores_beans_id_fkey"})
+
+				_, err := repository.CreateCuppingScore(context.Background(), score)
+				if !errors.Is(err, domainerrors.ErrBeansDoesNotExist) {
+					t.Fatalf("CreateCuppingScore() error = %v, want %v", err, domainerrors.ErrBeansDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "create duplicate score returns already exists",
+			run: func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(insertScoreQuery).WithArgs(args...).
+					WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "uq_cupping_scores_session_beans"})
+
+				_, err := repository.CreateCuppingScore(context.Background(), score)
+				if !errors.Is(err, domainerrors.ErrCuppingScoreAlreadyExists) {
+					t.Fatalf("CreateCuppingScore() error = %v, want %v", err, domainerrors.ErrCuppingScoreAlreadyExists)
+				}
+			},
+		},
+		{
+			name: "create out of range score returns range error",
+			run: func(t *testing.T, repository *Cupping, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(insertScoreQuery).WithArgs(args...).
+					WillReturnError(&pgconn.PgError{Code: "23514", ConstraintName: "chk_cupping_scores_range"})
+
+				_, err := repository.CreateCuppingScore(context.Background(), score)
+				if !errors.Is(err, domainerrors.ErrCuppingScoreOutOfRange) {
+					t.Fatalf("CreateCuppingScore() error = %v, want %v", err, domainerrors.ErrCuppingScoreOutOfRange)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package greencoffee
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const insertGreenCoffeeQuery = `INSERT INTO
+	green_coffees (origin, supplier, purchase_weight, price, arrival_date, moisture, owner_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+func TestGreenCoffeeRepositoryPostgresBehavior(t *testing.T) {
+	greenCoffee := &sql.GreenCoffee{Origin: "Ethiopia Guji", PurchaseWeight: 5, Price: 60}
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *GreenCoffee, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create returns postgres generated id",
+			run: func(t *testing.T, repository *GreenCoffee, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(insertGreenCoffeeQuery).
+					WithArgs("Ethiopia Guji", "", 5.0, 60.0, nil, 0.0, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+
+				id, err := repository.CreateGreenCoffee(context.Background(), greenCoffee)
+				if err != nil {
+					t.Fatalf("CreateGreenCoffee() error = %v", err)
+				}
+				if id != 4 {
+					t.Errorf("CreateGreenCoffee() id = %d, want 4", id)
+				}
+			},
+		},
+		{
+			name: "create with invalid purchase weight returns range error",
+			run: func(t *testing.T, repository *GreenCoffee, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(insertGreenCoffeeQuery).
+					WithArgs("Ethiopia Guji", "", 5.0, 60.0, nil, 0.0, nil).
+					WillReturnError(&pgconn.PgError{Code: "23514", ConstraintName: "chk_green_coffees_purchase_weight"})
+
+				_, err := repository.CreateGreenCoffee(context.Background(), greenCoffee)
+				if !errors.Is(err, domainerrors.ErrGreenCoffeePurchaseWeightOutOfRange) {
+					t.Fatalf("CreateGreenCoffee() error = %v, want %v", err, domainerrors.ErrGreenCoffeePurchaseWeightOutOfRange)
+				}
+			},
+		},
+		{
+			name: "delete referenced by beans returns foreign key error",
+			run: func(t *testing.T, repository *GreenCoffee, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM green_coffees WHERE id = $1").WithArgs(4).
+					WillReturnError(&pgconn.PgError{Code: "23503", TableName: "beans", ConstraintName: "fk_beans_green_coffee"})
+
+				err := repository.DeleteGreenCoffeeById(context.Background(), 4)
+				if !errors.Is(err, domainerrors.ErrBeansForeignKeyConstraint) {
+					t.Fatalf("DeleteGreenCoffeeById() error = %v, want %v", err, domainerrors.ErrBeansForeignKeyConstraint)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
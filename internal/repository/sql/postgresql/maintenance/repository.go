@@ -0,0 +1,18 @@
+package maintenance
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+var _ repository.MaintenanceTaskRepository = (*MaintenanceTask)(nil)
+
+type MaintenanceTask struct {
+	*shared.MaintenanceTask
+}
+
+func New(db *sqlx.DB) *MaintenanceTask {
+	return &MaintenanceTask{shared.NewMaintenanceTask(db, adapters.PostgreSQL())}
+}
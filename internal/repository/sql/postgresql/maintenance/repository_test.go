@@ -0,0 +1,84 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const insertMaintenanceTaskQuery = `INSERT INTO
+	maintenance_tasks (type, equipment, equipment_name, interval_shots, interval_days, last_done_at, owner_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+func TestMaintenanceTaskRepositoryPostgresBehavior(t *testing.T) {
+	intervalDays := 90
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *MaintenanceTask, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create returns postgres generated id",
+			run: func(t *testing.T, repository *MaintenanceTask, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(insertMaintenanceTaskQuery).
+					WithArgs("descale", "machine", "", nil, 90, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+				id, err := repository.CreateMaintenanceTask(context.Background(), &sql.MaintenanceTask{Type: "descale", Equipment: "machine", IntervalDays: &intervalDays})
+				if err != nil {
+					t.Fatalf("CreateMaintenanceTask() error = %v", err)
+				}
+				if id != 5 {
+					t.Errorf("CreateMaintenanceTask() id = %d, want 5", id)
+				}
+			},
+		},
+		{
+			name: "create with invalid type returns type error",
+			run: func(t *testing.T, repository *MaintenanceTask, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(insertMaintenanceTaskQuery).
+					WithArgs("polish", "", "", nil, 90, nil, nil).
+					WillReturnError(&pgconn.PgError{Code: "23514", ConstraintName: "chk_maintenance_tasks_type"})
+
+				_, err := repository.CreateMaintenanceTask(context.Background(), &sql.MaintenanceTask{Type: "polish", IntervalDays: &intervalDays})
+				if !errors.Is(err, domainerrors.ErrMaintenanceTaskTypeIsInvalid) {
+					t.Fatalf("CreateMaintenanceTask() error = %v, want %v", err, domainerrors.ErrMaintenanceTaskTypeIsInvalid)
+				}
+			},
+		},
+		{
+			name: "complete binds postgres placeholders",
+			run: func(t *testing.T, repository *MaintenanceTask, mock sqlmock.Sqlmock) {
+				doneAt := time.Date(2026, time.October, 1, 8, 0, 0, 0, time.UTC)
+				mock.ExpectExec("UPDATE maintenance_tasks SET last_done_at = $1 WHERE id = $2").WithArgs(doneAt, 5).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				_, err := repository.CompleteMaintenanceTaskById(context.Background(), 5, doneAt)
+				if !errors.Is(err, domainerrors.ErrMaintenanceTaskDoesNotExist) {
+					t.Fatalf("CompleteMaintenanceTaskById() error = %v, want %v", err, domainerrors.ErrMaintenanceTaskDoesNotExist)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
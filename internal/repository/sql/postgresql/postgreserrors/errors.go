@@ -14,11 +14,46 @@ var (
 	checkConstraintErrors = map[string]error{
 		"chk_beans_roast_level":                     domainerrors.ErrBeansRoastLevelOutOfRange,
 		"chk_shots_comparison_with_previous_result": domainerrors.ErrShotComparisonWithPreviousResultOutOfRange,
+		"chk_cupping_scores_range":                  domainerrors.ErrCuppingScoreOutOfRange,
+		"chk_roast_batches_roast_level":             domainerrors.ErrRoastBatchRoastLevelOutOfRange,
+		"chk_roast_batches_weights":                 domainerrors.ErrRoastBatchWeightOutOfRange,
+		"chk_roast_batches_times":                   domainerrors.ErrRoastBatchTimeOutOfRange,
+		"chk_roast_curve_points_elapsed_time":       domainerrors.ErrRoastBatchCurveIsInvalid,
+		"chk_beans_green_weight":                    domainerrors.ErrBeansGreenWeightOutOfRange,
+		"chk_beans_price":                           domainerrors.ErrBeansPriceOutOfRange,
+		"chk_beans_bag_weight":                      domainerrors.ErrBeansBagWeightOutOfRange,
+		"chk_green_coffees_purchase_weight":         domainerrors.ErrGreenCoffeePurchaseWeightOutOfRange,
+		"chk_green_coffees_price":                   domainerrors.ErrGreenCoffeePriceOutOfRange,
+		"chk_green_coffees_moisture":                domainerrors.ErrGreenCoffeeMoistureOutOfRange,
+		"chk_maintenance_tasks_type":                domainerrors.ErrMaintenanceTaskTypeIsInvalid,
+		"chk_maintenance_tasks_equipment":           domainerrors.ErrMaintenanceTaskEquipmentIsInvalid,
+		"chk_maintenance_tasks_interval":            domainerrors.ErrMaintenanceTaskIntervalOutOfRange,
+		"chk_api_keys_scope":                        domainerrors.ErrAPIKeyScopeIsInvalid,
 	}
 	foreignKeyReferenceErrors = map[string]error{
-		"beans_roaster_id_fkey": domainerrors.ErrRoasterDoesNotExist,
-		"shots_sheet_id_fkey":   domainerrors.ErrSheetDoesNotExist,
-		"shots_beans_id_fkey":   domainerrors.ErrBeansDoesNotExist,
+		"beans_roaster_id_fkey":          domainerrors.ErrRoasterDoesNotExist,
+		"shots_sheet_id_fkey":            domainerrors.ErrSheetDoesNotExist,
+		"shots_beans_id_fkey":            domainerrors.ErrBeansDoesNotExist,
+		"cupping_scores_session_id_fkey": domainerrors.ErrCuppingSessionDoesNotExist,
+		"cupping_scores_beans_id_fkey":   domainerrors.ErrBeansDoesNotExist,
+		"fk_roast_batches_green_coffee":  domainerrors.ErrGreenCoffeeDoesNotExist,
+		"fk_beans_green_coffee":          domainerrors.ErrGreenCoffeeDoesNotExist,
+		"fk_sheets_owner":                domainerrors.ErrUserDoesNotExist,
+		"fk_roasters_owner":              domainerrors.ErrUserDoesNotExist,
+		"fk_beans_owner":                 domainerrors.ErrUserDoesNotExist,
+		"fk_shots_owner":                 domainerrors.ErrUserDoesNotExist,
+		"fk_api_keys_user":               domainerrors.ErrUserDoesNotExist,
+		"fk_sessions_user":               domainerrors.ErrUserDoesNotExist,
+		"fk_share_links_sheet":           domainerrors.ErrSheetDoesNotExist,
+		"fk_share_links_owner":           domainerrors.ErrUserDoesNotExist,
+		"fk_attachments_shot":            domainerrors.ErrShotDoesNotExist,
+		"fk_attachments_beans":           domainerrors.ErrBeansDoesNotExist,
+		"fk_attachments_owner":           domainerrors.ErrUserDoesNotExist,
+		"fk_cupping_sessions_owner":      domainerrors.ErrUserDoesNotExist,
+		"fk_cupping_scores_owner":        domainerrors.ErrUserDoesNotExist,
+		"fk_green_coffees_owner":         domainerrors.ErrUserDoesNotExist,
+		"fk_roast_batches_owner":         domainerrors.ErrUserDoesNotExist,
+		"fk_maintenance_tasks_owner":     domainerrors.ErrUserDoesNotExist,
 	}
 )
 
@@ -27,6 +62,17 @@ var (
 	EntityRoaster = sqlerrors.EntityRoaster
 	EntityBeans   = sqlerrors.EntityBeans
 	EntityShot    = sqlerrors.EntityShot
+
+	EntityCuppingSession  = sqlerrors.EntityCuppingSession
+	EntityCuppingScore    = sqlerrors.EntityCuppingScore
+	EntityRoastBatch      = sqlerrors.EntityRoastBatch
+	EntityGreenCoffee     = sqlerrors.EntityGreenCoffee
+	EntityMaintenanceTask = sqlerrors.EntityMaintenanceTask
+	EntityUser            = sqlerrors.EntityUser
+	EntityAPIKey          = sqlerrors.EntityAPIKey
+	EntitySession         = sqlerrors.EntitySession
+	EntityShareLink       = sqlerrors.EntityShareLink
+	EntityAttachment      = sqlerrors.EntityAttachment
 )
 
 var (
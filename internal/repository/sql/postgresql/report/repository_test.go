@@ -0,0 +1,37 @@
+package report
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestReportRepositoryPostgresBehavior(t *testing.T) {
+	from := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`AND shots\.created_at >= \$1\s+AND shots\.created_at < \$2\s+ORDER BY shots\.created_at, shots\.id$`).
+		WithArgs(from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"shot_id", "quantity_in", "created_at", "beans_id", "beans_name", "roaster_id", "roaster_name", "price", "currency", "bag_weight"}).
+			AddRow(3, 18.0, from, 1, "Guji", 2, "Roaster", 15.0, "EUR", 250.0))
+
+	got, err := New(sqlx.NewDb(db, "sqlmock")).GetShotCosts(context.Background(), &from, &to)
+	if err != nil {
+		t.Fatalf("GetShotCosts() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Currency != "EUR" {
+		t.Errorf("GetShotCosts() = %+v, want one EUR shot cost", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,18 @@
+package roastbatch
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+var _ repository.RoastBatchRepository = (*RoastBatch)(nil)
+
+type RoastBatch struct {
+	*shared.RoastBatch
+}
+
+func New(db *sqlx.DB) *RoastBatch {
+	return &RoastBatch{shared.NewRoastBatch(db, adapters.PostgreSQL())}
+}
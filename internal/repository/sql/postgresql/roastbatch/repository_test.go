@@ -0,0 +1,83 @@
+package roastbatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+const insertBatchQuery = `INSERT INTO
+	roast_batches (green_coffee, roast_date, roast_level, green_weight, roasted_weight, charge_temperature, first_crack_time, development_time, end_temperature, green_coffee_id, owner_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id`
+
+func TestRoastBatchRepositoryPostgresBehavior(t *testing.T) {
+	roastDate := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	batch := &sql.RoastBatch{
+		GreenCoffee: "Ethiopia Guji", RoastDate: &roastDate, RoastLevel: sql.RoastLevelLight,
+		GreenWeight: 250, RoastedWeight: 215,
+		CurvePoints: []sql.RoastCurvePoint{{ElapsedTime: 0, Temperature: 200}},
+	}
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *RoastBatch, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create returns postgres generated id",
+			run: func(t *testing.T, repository *RoastBatch, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertBatchQuery).
+					WithArgs("Ethiopia Guji", roastDate, sql.RoastLevelLight, 250.0, 215.0, 0.0, 0, 0, 0.0, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				mock.ExpectExec("INSERT INTO roast_curve_points (roast_batch_id, elapsed_time, temperature) VALUES ($1, $2, $3)").
+					WithArgs(4, 0, 200.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+
+				id, err := repository.CreateRoastBatch(context.Background(), batch)
+				if err != nil {
+					t.Fatalf("CreateRoastBatch() error = %v", err)
+				}
+				if id != 4 {
+					t.Errorf("CreateRoastBatch() id = %d, want 4", id)
+				}
+			},
+		},
+		{
+			name: "create with invalid roast level returns range error",
+			run: func(t *testing.T, repository *RoastBatch, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertBatchQuery).
+					WithArgs("Ethiopia Guji", roastDate, sql.RoastLevelLight, 250.0, 215.0, 0.0, 0, 0, 0.0, nil, nil).
+					WillReturnError(&pgconn.PgError{Code: "23514", ConstraintName: "chk_roast_batches_roast_level"})
+				mock.ExpectRollback()
+
+				_, err := repository.CreateRoastBatch(context.Background(), batch)
+				if !errors.Is(err, domainerrors.ErrRoastBatchRoastLevelOutOfRange) {
+					t.Fatalf("CreateRoastBatch() error = %v, want %v", err, domainerrors.ErrRoastBatchRoastLevelOutOfRange)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -20,8 +20,8 @@ func TestRoasterRepositoryPostgresBehavior(t *testing.T) {
 		{
 			name: "create uses postgres placeholder",
 			run: func(t *testing.T, repository *Roaster, mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO roasters (name) VALUES ($1)").
-					WithArgs("roaster").
+				mock.ExpectExec("INSERT INTO roasters (name, owner_id) VALUES ($1, $2)").
+					WithArgs("roaster", nil).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
 				if err := repository.CreateRoaster(context.Background(), &sql.Roaster{Name: "roaster"}); err != nil {
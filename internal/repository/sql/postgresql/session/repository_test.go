@@ -0,0 +1,78 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+func TestSessionRepositoryPostgresBehavior(t *testing.T) {
+	expiresAt := time.Date(2026, time.October, 25, 17, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *Session, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create returns postgres generated id",
+			run: func(t *testing.T, repository *Session, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO sessions (user_id, token_hash, csrf_token, expires_at) VALUES ($1, $2, $3, $4) RETURNING id").
+					WithArgs(4, "token-hash", "csrf", expiresAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+
+				id, err := repository.CreateSession(context.Background(), &sql.Session{UserId: 4, TokenHash: "token-hash", CSRFToken: "csrf", ExpiresAt: expiresAt})
+				if err != nil {
+					t.Fatalf("CreateSession() error = %v", err)
+				}
+				if id != 9 {
+					t.Errorf("CreateSession() id = %d, want 9", id)
+				}
+			},
+		},
+		{
+			name: "create for unknown user returns user does not exist",
+			run: func(t *testing.T, repository *Session, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO sessions (user_id, token_hash, csrf_token, expires_at) VALUES ($1, $2, $3, $4) RETURNING id").
+					WithArgs(99, "token-hash", "csrf", expiresAt).
+					WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: "fk_sessions_user"})
+
+				_, err := repository.CreateSession(context.Background(), &sql.Session{UserId: 99, TokenHash: "token-hash", CSRFToken: "csrf", ExpiresAt: expiresAt})
+				if !errors.Is(err, domainerrors.ErrUserDoesNotExist) {
+					t.Fatalf("CreateSession() error = %v, want %v", err, domainerrors.ErrUserDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "delete expired sessions binds postgres placeholders",
+			run: func(t *testing.T, repository *Session, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM sessions WHERE expires_at < $1").WithArgs(expiresAt).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+
+				if err := repository.DeleteExpiredSessions(context.Background(), expiresAt); err != nil {
+					t.Fatalf("DeleteExpiredSessions() error = %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package sharelink
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+func TestShareLinkRepositoryPostgresBehavior(t *testing.T) {
+	expiresAt := time.Date(2026, time.October, 25, 20, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *ShareLink, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create returns postgres generated id",
+			run: func(t *testing.T, repository *ShareLink, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO share_links (sheet_id, owner_id, expires_at) VALUES ($1, $2, $3) RETURNING id").
+					WithArgs(3, nil, expiresAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+				id, err := repository.CreateShareLink(context.Background(), &sql.ShareLink{SheetId: 3, ExpiresAt: expiresAt})
+				if err != nil {
+					t.Fatalf("CreateShareLink() error = %v", err)
+				}
+				if id != 5 {
+					t.Errorf("CreateShareLink() id = %d, want 5", id)
+				}
+			},
+		},
+		{
+			name: "create for a missing sheet returns sheet does not exist",
+			run: func(t *testing.T, repository *ShareLink, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO share_links (sheet_id, owner_id, expires_at) VALUES ($1, $2, $3) RETURNING id").
+					WithArgs(9, nil, expiresAt).
+					WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: "fk_share_links_sheet"})
+
+				_, err := repository.CreateShareLink(context.Background(), &sql.ShareLink{SheetId: 9, ExpiresAt: expiresAt})
+				if !errors.Is(err, domainerrors.ErrSheetDoesNotExist) {
+					t.Fatalf("CreateShareLink() error = %v, want %v", err, domainerrors.ErrSheetDoesNotExist)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -9,11 +9,13 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/auth"
 	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
 	"github.com/lescactus/espressoapi-go/internal/models/sql"
 )
 
 func TestSheetRepositoryPostgresBehavior(t *testing.T) {
+	aliceCtx := auth.NewContext(context.Background(), &auth.User{Id: 7, Name: "alice"})
 	tests := []struct {
 		name string
 		run  func(t *testing.T, repository *Sheet, mock sqlmock.Sqlmock)
@@ -21,8 +23,8 @@ func TestSheetRepositoryPostgresBehavior(t *testing.T) {
 		{
 			name: "create uses postgres placeholder",
 			run: func(t *testing.T, repository *Sheet, mock sqlmock.Sqlmock) {
-				mock.ExpectExec("INSERT INTO sheets (name) VALUES ($1)").
-					WithArgs("sheet").
+				mock.ExpectExec("INSERT INTO sheets (name, is_template, owner_id) VALUES ($1, $2, $3)").
+					WithArgs("sheet", false, nil).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
 				if err := repository.CreateSheet(context.Background(), &sql.Sheet{Name: "sheet"}); err != nil {
@@ -33,7 +35,7 @@ func TestSheetRepositoryPostgresBehavior(t *testing.T) {
 		{
 			name: "get missing sheet returns domain error",
 			run: func(t *testing.T, repository *Sheet, mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("SELECT id, name, created_at, updated_at FROM sheets WHERE id = $1").
+				mock.ExpectQuery("SELECT id, name, is_template, created_at, updated_at FROM sheets WHERE id = $1").
 					WithArgs(42).
 					WillReturnError(dbsql.ErrNoRows)
 
@@ -56,6 +58,80 @@ func TestSheetRepositoryPostgresBehavior(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "create records the authenticated user as owner",
+			run: func(t *testing.T, repository *Sheet, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO sheets (name, is_template, owner_id) VALUES ($1, $2, $3)").
+					WithArgs("sheet", false, 7).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				if err := repository.CreateSheet(aliceCtx, &sql.Sheet{Name: "sheet"}); err != nil {
+					t.Fatalf("CreateSheet() error = %v", err)
+				}
+			},
+		},
+		{
+			name: "get all is scoped to the authenticated user",
+			run: func(t *testing.T, repository *Sheet, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, name, is_template, created_at, updated_at FROM sheets\nWHERE owner_id = $1").
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).AddRow(1, "sheet", nil, nil))
+
+				got, err := repository.GetAllSheets(aliceCtx)
+				if err != nil {
+					t.Fatalf("GetAllSheets() error = %v", err)
+				}
+				if len(got) != 1 {
+					t.Errorf("GetAllSheets() = %+v, want 1 sheet", got)
+				}
+			},
+		},
+		{
+			name: "update of another user's sheet returns domain error",
+			run: func(t *testing.T, repository *Sheet, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE sheets SET name = $1, is_template = $2 WHERE id = $3\n\tAND owner_id = $4").
+					WithArgs("renamed", false, 2, 7).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT id, name, is_template, created_at, updated_at FROM sheets WHERE id = $1\n\tAND owner_id = $2").
+					WithArgs(2, 7).
+					WillReturnError(dbsql.ErrNoRows)
+
+				_, err := repository.UpdateSheetById(aliceCtx, 2, &sql.Sheet{Name: "renamed"})
+				if !errors.Is(err, domainerrors.ErrSheetDoesNotExist) {
+					t.Fatalf("UpdateSheetById() error = %v, want %v", err, domainerrors.ErrSheetDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "clone is scoped to the authenticated user and returns the new id",
+			run: func(t *testing.T, repository *Sheet, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(*) FROM sheets WHERE id = $1\n\tAND owner_id = $2").
+					WithArgs(1, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("INSERT INTO sheets (name, owner_id) VALUES ($1, $2) RETURNING id").
+					WithArgs("new bag", 7).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectExec(`INSERT INTO
+	shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id)
+	SELECT $1, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, $2
+	FROM shots WHERE sheet_id = $3 AND rating > 0
+	AND owner_id = $4
+ORDER BY rating DESC, id DESC
+LIMIT 1`).
+					WithArgs(3, 7, 1, 7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+
+				id, err := repository.CloneSheet(aliceCtx, 1, "new bag", true)
+				if err != nil {
+					t.Fatalf("CloneSheet() error = %v", err)
+				}
+				if id != 3 {
+					t.Errorf("CloneSheet() = %d, want 3", id)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -10,6 +10,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/auth"
 	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
 	"github.com/lescactus/espressoapi-go/internal/models/sql"
 )
@@ -36,6 +37,9 @@ SELECT
 	beans.name as "beans.name",
 	beans.roast_date as "beans.roast_date",
 	beans.roast_level as "beans.roast_level",
+	beans.price as "beans.price",
+	beans.currency as "beans.currency",
+	beans.bag_weight as "beans.bag_weight",
 	roaster.id AS "beans.roaster.id",
 	roaster.name AS "beans.roaster.name",
 	roaster.created_at AS "beans.roaster.created_at",
@@ -142,6 +146,7 @@ WHERE shots.sheet_id = $1`
 }
 
 func TestShotRepositoryPostgresCreate(t *testing.T) {
+	aliceCtx := auth.NewContext(context.Background(), &auth.User{Id: 7, Name: "alice"})
 	tests := []struct {
 		name string
 		run  func(t *testing.T, repository *Shot, mock sqlmock.Sqlmock)
@@ -149,8 +154,8 @@ func TestShotRepositoryPostgresCreate(t *testing.T) {
 		{
 			name: "create returns postgres generated id",
 			run: func(t *testing.T, repository *Shot, mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("INSERT INTO shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id").
-					WithArgs(1, 2, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Worst, "notes").
+				mock.ExpectQuery("INSERT INTO shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id").
+					WithArgs(1, 2, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Worst, "notes", nil).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
 
 				id, err := repository.CreateShot(context.Background(), &sql.Shot{
@@ -169,8 +174,8 @@ func TestShotRepositoryPostgresCreate(t *testing.T) {
 		{
 			name: "create with missing sheet returns domain error",
 			run: func(t *testing.T, repository *Shot, mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("INSERT INTO shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id").
-					WithArgs(1, 2, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Worst, "notes").
+				mock.ExpectQuery("INSERT INTO shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id").
+					WithArgs(1, 2, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Worst, "notes", nil).
 					WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: "shots_sheet_id_fkey"})
 
 				_, err := repository.CreateShot(context.Background(), &sql.Shot{
@@ -183,6 +188,44 @@ func TestShotRepositoryPostgresCreate(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "create records the authenticated user as owner",
+			run: func(t *testing.T, repository *Shot, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(*) FROM sheets WHERE id = $1\n\tAND owner_id = $2").WithArgs(1, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("SELECT COUNT(*) FROM beans WHERE id = $1\n\tAND owner_id = $2").WithArgs(2, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("INSERT INTO shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id").
+					WithArgs(1, 2, 0, 0.0, 0.0, 0, 0.0, 0.0, false, false, sql.Worst, "notes", 7).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+
+				if _, err := repository.CreateShot(aliceCtx, &sql.Shot{
+					Sheet:           &sql.Sheet{Id: 1},
+					Beans:           &sql.Beans{Id: 2},
+					AdditionalNotes: "notes",
+				}); err != nil {
+					t.Fatalf("CreateShot() error = %v", err)
+				}
+			},
+		},
+		{
+			name: "create with another user's beans returns domain error",
+			run: func(t *testing.T, repository *Shot, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT COUNT(*) FROM sheets WHERE id = $1\n\tAND owner_id = $2").WithArgs(1, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery("SELECT COUNT(*) FROM beans WHERE id = $1\n\tAND owner_id = $2").WithArgs(2, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				_, err := repository.CreateShot(aliceCtx, &sql.Shot{
+					Sheet:           &sql.Sheet{Id: 1},
+					Beans:           &sql.Beans{Id: 2},
+					AdditionalNotes: "notes",
+				})
+				if !errors.Is(err, domainerrors.ErrBeansDoesNotExist) {
+					t.Fatalf("CreateShot() error = %v, want %v", err, domainerrors.ErrBeansDoesNotExist)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -200,3 +243,33 @@ func TestShotRepositoryPostgresCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestShotRepositoryPostgresUpdateShotProfileById(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+	aliceCtx := auth.NewContext(context.Background(), &auth.User{Id: 7, Name: "alice"})
+	flow := 2.4
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM shots WHERE id = $1\n\tAND owner_id = $2").WithArgs(3, 7).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectExec("DELETE FROM shot_profile_samples WHERE shot_id = $1").WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO shot_profile_samples (shot_id, elapsed_time, pressure, flow, weight, temperature) VALUES ($1, $2, $3, $4, $5, $6), ($7, $8, $9, $10, $11, $12)").
+		WithArgs(3, 0, nil, 2.4, nil, nil, 3, 100, nil, 2.4, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err = New(sqlx.NewDb(db, "sqlmock")).UpdateShotProfileById(aliceCtx, 3, []sql.ShotProfileSample{
+		{ElapsedTime: 0, Flow: &flow},
+		{ElapsedTime: 100, Flow: &flow},
+	})
+	if err != nil {
+		t.Fatalf("UpdateShotProfileById() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
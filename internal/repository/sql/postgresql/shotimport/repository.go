@@ -0,0 +1,18 @@
+package shotimport
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+var _ repository.ShotImportRepository = (*ShotImport)(nil)
+
+type ShotImport struct {
+	*shared.ShotImport
+}
+
+func New(db *sqlx.DB) *ShotImport {
+	return &ShotImport{shared.NewShotImport(db, adapters.PostgreSQL())}
+}
@@ -0,0 +1,54 @@
+package shotimport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+func TestShotImportRepositoryPostgresImportShot(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT shot_imports.external_id, shot_imports.shot_id FROM shot_imports
+	JOIN shots ON shots.id = shot_imports.shot_id
+	WHERE shot_imports.source = $1 AND shot_imports.external_id IN ($2)`).WithArgs("visualizer", "0b1c").
+		WillReturnRows(sqlmock.NewRows([]string{"external_id", "shot_id"}))
+	mock.ExpectQuery("SELECT id FROM sheets WHERE name = $1").WithArgs("Decent").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectQuery("SELECT id FROM roasters WHERE name = $1").WithArgs("Square Mile").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	mock.ExpectQuery("SELECT id FROM beans WHERE name = $1 AND roaster_id = $2").WithArgs("Red Brick", 4).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectQuery(`INSERT INTO
+	shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`).
+		WithArgs(3, 5, 0, 18.0, 40.0, int64(31000), 92.0, 0.0, false, false, sql.Unknown, "", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	mock.ExpectExec("INSERT INTO shot_imports (shot_id, source, external_id) VALUES ($1, $2, $3)").WithArgs(9, "visualizer", "0b1c").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	id, err := New(sqlx.NewDb(db, "sqlmock")).ImportShot(context.Background(), &sql.ImportedShot{
+		Source: "visualizer", ExternalId: "0b1c",
+		SheetName: "Decent", RoasterName: "Square Mile", BeansName: "Red Brick",
+		Shot: &sql.Shot{QuantityIn: 18, QuantityOut: 40, ShotTime: 31 * time.Second, WaterTemperature: 92, ComparisonWithPreviousResult: sql.Unknown},
+	})
+	if err != nil {
+		t.Fatalf("ImportShot() error = %v", err)
+	}
+	if id != 9 {
+		t.Errorf("ImportShot() id = %d, want 9", id)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
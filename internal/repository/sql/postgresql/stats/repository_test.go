@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+func TestStatsRepositoryPostgresBehavior(t *testing.T) {
+	from := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`CAST\(shots\.created_at AT TIME ZONE \$1 AS DATE\) AS shot_date,[\s\S]+WHERE shots\.created_at >= \$2\s+AND shots\.created_at < \$3\s+GROUP BY shot_date\s+ORDER BY shot_date$`).
+		WithArgs("America/New_York", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"shot_date", "shots", "coffee_weight", "rated_shots", "average_rating"}).
+			AddRow(from, 3, "54.0", 3, "8.0000000000000000"))
+
+	got, err := New(sqlx.NewDb(db, "sqlmock")).GetDailyConsumption(context.Background(), from, to, "America/New_York")
+	if err != nil {
+		t.Fatalf("GetDailyConsumption() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Shots != 3 || got[0].CoffeeWeight != 54 || got[0].AverageRating != 8 {
+		t.Errorf("GetDailyConsumption() = %+v, want one day of 3 shots, 54 g and a rating of 8", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStatsRepositoryPostgresShotStats(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`^SELECT COUNT\(\*\) FROM sheets WHERE id = \$1$`).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`AVG\(CASE WHEN shots\.is_too_sour THEN 1\.0 ELSE 0\.0 END\) AS sour_share\s+FROM shots\s+WHERE shots\.sheet_id = \$1$`).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"shots", "rated_shots", "average_rating", "max_rating", "min_grind_setting", "max_grind_setting", "average_ratio", "average_shot_time_ms", "bitter_share", "sour_share"}).
+			AddRow(3, 3, "8.0000000000000000", "9.5", 14, 15, "1.9500000000000000", "29000.000000000000", "0.33333333333333333333", "0.00000000000000000000"))
+	mock.ExpectQuery(`^SELECT shots\.rating FROM shots WHERE shots\.sheet_id = \$1 AND shots\.rating > 0\s+ORDER BY shots\.rating\s+LIMIT \$2 OFFSET \$3$`).
+		WithArgs(2, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"rating"}).AddRow("8"))
+	mock.ExpectQuery(`^SELECT shots\.id FROM shots WHERE shots\.sheet_id = \$1 AND shots\.rating > 0\s+ORDER BY shots\.rating DESC, shots\.id DESC\s+LIMIT 1$`).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+
+	got, err := New(sqlx.NewDb(db, "sqlmock")).GetShotStats(context.Background(), sql.ShotStatsOfSheet, 2)
+	if err != nil {
+		t.Fatalf("GetShotStats() error = %v", err)
+	}
+	if got.Shots != 3 || *got.AverageRating != 8 || *got.MedianRating != 8 || *got.MaxRating != 9.5 || *got.BestShotId != 12 || *got.AverageShotTimeMs != 29000 {
+		t.Errorf("GetShotStats() = %+v, want the stats of 3 shots", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
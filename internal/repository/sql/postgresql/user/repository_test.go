@@ -0,0 +1,168 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+func TestUserRepositoryPostgresBehavior(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repository *User, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "create returns postgres generated id",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO users (name, role) VALUES ($1, $2) RETURNING id").WithArgs("alice", "barista").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+
+				id, err := repository.CreateUser(context.Background(), &sql.User{Name: "alice", Role: "barista"})
+				if err != nil {
+					t.Fatalf("CreateUser() error = %v", err)
+				}
+				if id != 4 {
+					t.Errorf("CreateUser() id = %d, want 4", id)
+				}
+			},
+		},
+		{
+			name: "create duplicate returns already exists",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO users (name, role) VALUES ($1, $2) RETURNING id").WithArgs("alice", "barista").
+					WillReturnError(&pgconn.PgError{Code: "23505"})
+
+				_, err := repository.CreateUser(context.Background(), &sql.User{Name: "alice", Role: "barista"})
+				if !errors.Is(err, domainerrors.ErrUserAlreadyExists) {
+					t.Fatalf("CreateUser() error = %v, want %v", err, domainerrors.ErrUserAlreadyExists)
+				}
+			},
+		},
+		{
+			name: "disable binds postgres placeholders",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE users SET disabled = $1 WHERE name = $2").WithArgs(true, "bob").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				if err := repository.DisableUserByName(context.Background(), "bob"); err != nil {
+					t.Fatalf("DisableUserByName() error = %v", err)
+				}
+			},
+		},
+		{
+			name: "set role binds postgres placeholders",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE users SET role = $1 WHERE name = $2").WithArgs("viewer", "alice").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				if err := repository.SetUserRoleByName(context.Background(), "alice", "viewer"); err != nil {
+					t.Fatalf("SetUserRoleByName() error = %v", err)
+				}
+			},
+		},
+		{
+			name: "set password hash of unknown user returns does not exist",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE users SET password_hash = $1 WHERE name = $2").WithArgs("hash", "carol").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT id, name, disabled, role, created_at, updated_at FROM users WHERE name = $1").WithArgs("carol").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "disabled", "role", "created_at", "updated_at"}))
+
+				err := repository.SetUserPasswordHashByName(context.Background(), "carol", "hash")
+				if !errors.Is(err, domainerrors.ErrUserDoesNotExist) {
+					t.Fatalf("SetUserPasswordHashByName() error = %v, want %v", err, domainerrors.ErrUserDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "get password hash of user without password returns empty hash",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT password_hash FROM users WHERE name = $1").WithArgs("alice").
+					WillReturnRows(sqlmock.NewRows([]string{"password_hash"}).AddRow(nil))
+
+				hash, err := repository.GetUserPasswordHashByName(context.Background(), "alice")
+				if err != nil {
+					t.Fatalf("GetUserPasswordHashByName() error = %v", err)
+				}
+				if hash != "" {
+					t.Errorf("GetUserPasswordHashByName() = %q, want empty", hash)
+				}
+			},
+		},
+		{
+			name: "get by oidc subject binds postgres placeholders",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, name, disabled, role, created_at, updated_at FROM users WHERE oidc_subject = $1").WithArgs("sub-123").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "disabled", "role", "created_at", "updated_at"}).AddRow(4, "alice", false, "barista", nil, nil))
+
+				user, err := repository.GetUserByOIDCSubject(context.Background(), "sub-123")
+				if err != nil {
+					t.Fatalf("GetUserByOIDCSubject() error = %v", err)
+				}
+				if user.Id != 4 || user.Name != "alice" {
+					t.Errorf("GetUserByOIDCSubject() = %+v, want alice (4)", user)
+				}
+			},
+		},
+		{
+			name: "get by unknown oidc subject returns does not exist",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, name, disabled, role, created_at, updated_at FROM users WHERE oidc_subject = $1").WithArgs("unknown").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "disabled", "role", "created_at", "updated_at"}))
+
+				_, err := repository.GetUserByOIDCSubject(context.Background(), "unknown")
+				if !errors.Is(err, domainerrors.ErrUserDoesNotExist) {
+					t.Fatalf("GetUserByOIDCSubject() error = %v, want %v", err, domainerrors.ErrUserDoesNotExist)
+				}
+			},
+		},
+		{
+			name: "set oidc subject already linked to another user returns already exists",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE users SET oidc_subject = $1 WHERE name = $2").WithArgs("sub-123", "bob").
+					WillReturnError(&pgconn.PgError{Code: "23505"})
+
+				err := repository.SetUserOIDCSubjectByName(context.Background(), "bob", "sub-123")
+				if !errors.Is(err, domainerrors.ErrUserAlreadyExists) {
+					t.Fatalf("SetUserOIDCSubjectByName() error = %v, want %v", err, domainerrors.ErrUserAlreadyExists)
+				}
+			},
+		},
+		{
+			name: "get oidc subject of unlinked user returns empty subject",
+			run: func(t *testing.T, repository *User, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT oidc_subject FROM users WHERE name = $1").WithArgs("alice").
+					WillReturnRows(sqlmock.NewRows([]string{"oidc_subject"}).AddRow(nil))
+
+				subject, err := repository.GetUserOIDCSubjectByName(context.Background(), "alice")
+				if err != nil {
+					t.Fatalf("GetUserOIDCSubjectByName() error = %v", err)
+				}
+				if subject != "" {
+					t.Errorf("GetUserOIDCSubjectByName() = %q, want empty", subject)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			tt.run(t, New(sqlx.NewDb(db, "sqlmock")), mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
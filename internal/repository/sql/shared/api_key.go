@@ -0,0 +1,101 @@
+package shared
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type APIKey struct {
+	db      *sqlx.DB
+	dialect Dialect
+}
+
+func NewAPIKey(db *sqlx.DB, dialect Dialect) *APIKey { return &APIKey{db: db, dialect: dialect} }
+
+func (db *APIKey) CreateAPIKey(ctx context.Context, key *sql.APIKey) (int, error) {
+	query := db.dialect.Rebind(`INSERT INTO api_keys (user_id, name, prefix, hash, scope) VALUES (?, ?, ?, ?, ?)`)
+	return db.dialect.InsertID(ctx, db.db, query, &entityAPIKey, key.UserId, key.Name, key.Prefix, key.Hash, key.Scope)
+}
+
+func (db *APIKey) GetAPIKeyById(ctx context.Context, id int) (*sql.APIKey, error) {
+	var key sql.APIKey
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(apiKeyQuery+"\nWHERE api_keys.id = ?"), id).StructScan(&key); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return nil, domainerrors.ErrAPIKeyDoesNotExist
+		}
+		return nil, fmt.Errorf("failed to read record for api key id=%d from the database: %w", id, err)
+	}
+	return &key, nil
+}
+
+// GetAPIKeyByHash returns the api key whose secret hashes to hash, revoked or
+// not.
+func (db *APIKey) GetAPIKeyByHash(ctx context.Context, hash string) (*sql.APIKey, error) {
+	var key sql.APIKey
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(apiKeyQuery+"\nWHERE api_keys.hash = ?"), hash).StructScan(&key); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return nil, domainerrors.ErrAPIKeyDoesNotExist
+		}
+		return nil, fmt.Errorf("failed to read record for api key from the database: %w", err)
+	}
+	return &key, nil
+}
+
+func (db *APIKey) GetAllAPIKeys(ctx context.Context) ([]sql.APIKey, error) {
+	keys := make([]sql.APIKey, 0)
+	if err := db.db.SelectContext(ctx, &keys, db.dialect.Rebind(apiKeyQuery+"\nORDER BY api_keys.id")); err != nil {
+		return keys, fmt.Errorf("failed to read records for api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKeyById revokes an api key at revokedAt. Revoking a key which
+// already is revoked keeps its first revocation date.
+func (db *APIKey) RevokeAPIKeyById(ctx context.Context, id int, revokedAt time.Time) error {
+	query := db.dialect.Rebind(`UPDATE api_keys SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?`)
+	res, err := db.db.ExecContext(ctx, query, revokedAt, id)
+	if err != nil {
+		return db.dialect.ParseError(err, &entityAPIKey, fmt.Errorf("failed to update record for api key id=%d: %w", id, err))
+	}
+	// MySQL does not count the rows an UPDATE leaves unchanged.
+	if row, _ := res.RowsAffected(); row == 0 {
+		if _, err := db.GetAPIKeyById(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *APIKey) UpdateAPIKeyLastUsedAt(ctx context.Context, id int, usedAt time.Time) error {
+	if _, err := db.db.ExecContext(ctx, db.dialect.Rebind(`UPDATE api_keys SET last_used_at = ? WHERE id = ?`), usedAt, id); err != nil {
+		return fmt.Errorf("failed to update last use of api key id=%d: %w", id, err)
+	}
+	return nil
+}
+
+func (db *APIKey) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
+
+const apiKeyQuery = `
+SELECT
+	api_keys.id,
+	api_keys.user_id,
+	api_keys.name,
+	api_keys.prefix,
+	api_keys.hash,
+	api_keys.scope,
+	api_keys.last_used_at,
+	api_keys.revoked_at,
+	api_keys.created_at,
+	api_keys.updated_at,
+	users.name AS user_name,
+	users.role AS user_role,
+	users.disabled AS user_disabled
+FROM api_keys
+	INNER JOIN users ON api_keys.user_id = users.id`
@@ -0,0 +1,117 @@
+package shared
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type Attachment struct {
+	db      *sqlx.DB
+	dialect Dialect
+}
+
+func NewAttachment(db *sqlx.DB, dialect Dialect) *Attachment {
+	return &Attachment{db: db, dialect: dialect}
+}
+
+// CreateAttachment records an attachment of either the shot or the beans it
+// references, which must belong to the same user.
+func (db *Attachment) CreateAttachment(ctx context.Context, attachment *sql.Attachment) (int, error) {
+	switch {
+	case attachment.ShotId != nil:
+		if err := checkOwner(ctx, db.db, db.dialect, "shots", *attachment.ShotId, domainerrors.ErrShotDoesNotExist); err != nil {
+			return 0, err
+		}
+	case attachment.BeansId != nil:
+		if err := checkOwner(ctx, db.db, db.dialect, "beans", *attachment.BeansId, domainerrors.ErrBeansDoesNotExist); err != nil {
+			return 0, err
+		}
+	}
+	query := db.dialect.Rebind(`INSERT INTO attachments (shot_id, beans_id, owner_id, filename, content_type, size, width, height, blob_key, thumbnail_key) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	return db.dialect.InsertID(ctx, db.db, query, &entityAttachment,
+		attachment.ShotId, attachment.BeansId, ownerId(ctx), attachment.Filename, attachment.ContentType,
+		attachment.Size, attachment.Width, attachment.Height, attachment.BlobKey, attachment.ThumbnailKey)
+}
+
+func (db *Attachment) GetAttachmentById(ctx context.Context, id int) (*sql.Attachment, error) {
+	var attachment sql.Attachment
+	query, args := scopeToOwner(ctx, attachmentQuery+"\nWHERE id = ?", "owner_id", id)
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).StructScan(&attachment); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return nil, domainerrors.ErrAttachmentDoesNotExist
+		}
+		return nil, fmt.Errorf("failed to read record for attachment id=%d from the database: %w", id, err)
+	}
+	return &attachment, nil
+}
+
+func (db *Attachment) GetAttachmentsByShotId(ctx context.Context, shotId int) ([]sql.Attachment, error) {
+	attachments := make([]sql.Attachment, 0)
+	if err := checkOwner(ctx, db.db, db.dialect, "shots", shotId, domainerrors.ErrShotDoesNotExist); err != nil {
+		return attachments, err
+	}
+	query := db.dialect.Rebind(attachmentQuery + "\nWHERE shot_id = ?\nORDER BY id")
+	if err := db.db.SelectContext(ctx, &attachments, query, shotId); err != nil {
+		return attachments, fmt.Errorf("failed to read records for attachments of shot id=%d: %w", shotId, err)
+	}
+	return attachments, nil
+}
+
+func (db *Attachment) GetAttachmentsByBeansId(ctx context.Context, beansId int) ([]sql.Attachment, error) {
+	attachments := make([]sql.Attachment, 0)
+	if err := checkOwner(ctx, db.db, db.dialect, "beans", beansId, domainerrors.ErrBeansDoesNotExist); err != nil {
+		return attachments, err
+	}
+	query := db.dialect.Rebind(attachmentQuery + "\nWHERE beans_id = ?\nORDER BY id")
+	if err := db.db.SelectContext(ctx, &attachments, query, beansId); err != nil {
+		return attachments, fmt.Errorf("failed to read records for attachments of beans id=%d: %w", beansId, err)
+	}
+	return attachments, nil
+}
+
+func (db *Attachment) DeleteAttachmentById(ctx context.Context, id int) error {
+	query, args := scopeToOwner(ctx, `DELETE FROM attachments WHERE id = ?`, "owner_id", id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
+	if err != nil {
+		return db.dialect.ParseError(err, nil, fmt.Errorf("failed to delete record for attachment id=%d: %w", id, err))
+	}
+	if row, _ := res.RowsAffected(); row != 1 {
+		return domainerrors.ErrAttachmentDoesNotExist
+	}
+	return nil
+}
+
+// GetAllBlobKeys returns the keys of the files and thumbnails of the
+// attachments of every user, to tell the blobs still in use.
+func (db *Attachment) GetAllBlobKeys(ctx context.Context) ([]string, error) {
+	keys := make([]string, 0)
+	query := `SELECT blob_key FROM attachments UNION ALL SELECT thumbnail_key FROM attachments`
+	if err := db.db.SelectContext(ctx, &keys, query); err != nil {
+		return keys, fmt.Errorf("failed to read blob keys of attachments: %w", err)
+	}
+	return keys, nil
+}
+
+func (db *Attachment) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
+
+const attachmentQuery = `
+SELECT
+	id,
+	shot_id,
+	beans_id,
+	owner_id,
+	filename,
+	content_type,
+	size,
+	width,
+	height,
+	blob_key,
+	thumbnail_key,
+	created_at
+FROM attachments`
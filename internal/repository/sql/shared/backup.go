@@ -0,0 +1,288 @@
+package shared
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	sqlerrors "github.com/lescactus/espressoapi-go/internal/repository/sql/errors"
+)
+
+// backupTables are the tables restored from a backup, each after the tables
+// it references.
+var backupTables = []string{"roasters", "sheets", "beans", "shots"}
+
+type Backup struct {
+	db      *sqlx.DB
+	dialect Dialect
+}
+
+func NewBackup(db *sqlx.DB, dialect Dialect) *Backup {
+	return &Backup{db: db, dialect: dialect}
+}
+
+// GetBackupOwners returns the owner of every sheet, roaster, beans and shot
+// with one, and the users owning them, of the authenticated user only if any.
+func (db *Backup) GetBackupOwners(ctx context.Context) (*sql.BackupOwners, error) {
+	owners := &sql.BackupOwners{
+		Users:    make([]sql.User, 0),
+		Sheets:   make(map[int]int),
+		Roasters: make(map[int]int),
+		Beans:    make(map[int]int),
+		Shots:    make(map[int]int),
+	}
+	for _, table := range backupTables {
+		var rows []struct {
+			Id      int `db:"id"`
+			OwnerId int `db:"owner_id"`
+		}
+		query, args := scopeToOwner(ctx, "SELECT id, owner_id FROM "+table+" WHERE owner_id IS NOT NULL", "owner_id")
+		if err := db.db.SelectContext(ctx, &rows, db.dialect.Rebind(query), args...); err != nil {
+			return nil, fmt.Errorf("failed to read owners of %s: %w", table, err)
+		}
+		byId := owners.Table(table)
+		for _, row := range rows {
+			byId[row.Id] = row.OwnerId
+		}
+	}
+
+	query, args := scopeToOwner(ctx, `SELECT id, name, disabled, role, created_at, updated_at FROM users
+WHERE id IN (SELECT owner_id FROM roasters UNION SELECT owner_id FROM sheets UNION SELECT owner_id FROM beans UNION SELECT owner_id FROM shots)`, "id")
+	if err := db.db.SelectContext(ctx, &owners.Users, db.dialect.Rebind(query+"\nORDER BY id"), args...); err != nil {
+		return nil, fmt.Errorf("failed to read users owning records: %w", err)
+	}
+	return owners, nil
+}
+
+// RestoreBackup inserts the records of backup with their ids and timestamps
+// in a single transaction, then moves the id sequences past the restored
+// ids. Unless merge is set, the tables must be empty and
+// ErrBackupRestoreNotEmpty is returned otherwise. With merge, a record whose
+// id already exists is skipped when the existing row is the same record, with
+// the same owner and name or parents, and ErrBackupRestoreConflict is
+// returned otherwise, so no restored record ever references an unrelated one.
+//
+// The records are owned by the user ctx is authenticated as, if any, and by
+// their owner in the backup otherwise. The owners are matched to the users by
+// name, and created when missing.
+func (db *Backup) RestoreBackup(ctx context.Context, backup *sql.Backup, merge bool) (*sql.RestoreResult, error) {
+	tx, err := db.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if !merge {
+		for _, table := range backupTables {
+			var count int
+			if err := tx.GetContext(ctx, &count, "SELECT COUNT(*) FROM "+table); err != nil {
+				return nil, fmt.Errorf("failed to count records for %s: %w", table, err)
+			}
+			if count > 0 {
+				return nil, domainerrors.ErrBackupRestoreNotEmpty
+			}
+		}
+	}
+
+	var result sql.RestoreResult
+	owner, err := db.restoreOwners(ctx, tx, &backup.Owners, &result.Users)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, roaster := range backup.Roasters {
+		err := db.restore(ctx, tx, &result.Roasters, merge, backupRow{
+			table: "roasters", entity: &entityRoaster, id: roaster.Id, ownerId: owner("roasters", roaster.Id), identity: 1,
+			columns: "name, created_at, updated_at",
+			values:  []any{roaster.Name, roaster.CreatedAt, roaster.UpdatedAt},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, sheet := range backup.Sheets {
+		err := db.restore(ctx, tx, &result.Sheets, merge, backupRow{
+			table: "sheets", entity: &entitySheet, id: sheet.Id, ownerId: owner("sheets", sheet.Id), identity: 1,
+			columns: "name, is_template, created_at, updated_at",
+			values:  []any{sheet.Name, sheet.IsTemplate, sheet.CreatedAt, sheet.UpdatedAt},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, beans := range backup.Beans {
+		// Green coffees are not part of a backup: the link to one missing
+		// from this database is dropped.
+		greenCoffeeId := beans.GreenCoffeeId
+		if greenCoffeeId != nil {
+			exists, err := db.exists(ctx, tx, "green_coffees", *greenCoffeeId)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				greenCoffeeId = nil
+			}
+		}
+		err := db.restore(ctx, tx, &result.Beans, merge, backupRow{
+			table: "beans", entity: &entityBeans, id: beans.Id, ownerId: owner("beans", beans.Id), identity: 2,
+			columns: "name, roaster_id, roast_date, roast_level, green_coffee_id, green_weight, price, currency, bag_weight, created_at, updated_at",
+			values:  []any{beans.Name, beans.Roaster.Id, beans.RoastDate, beans.RoastLevel, greenCoffeeId, beans.GreenWeight, beans.Price, beans.Currency, beans.BagWeight, beans.CreatedAt, beans.UpdatedAt},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, shot := range backup.Shots {
+		err := db.restore(ctx, tx, &result.Shots, merge, backupRow{
+			table: "shots", entity: &entityShot, id: shot.Id, ownerId: owner("shots", shot.Id), identity: 2,
+			columns: "sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, created_at, updated_at",
+			values:  []any{shot.Sheet.Id, shot.Beans.Id, shot.GrindSetting, shot.QuantityIn, shot.QuantityOut, shot.ShotTime.Milliseconds(), shot.WaterTemperature, shot.Rating, shot.IsTooBitter, shot.IsTooSour, shot.ComparisonWithPreviousResult, shot.AdditionalNotes, shot.CreatedAt, shot.UpdatedAt},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, table := range backupTables {
+		if err := db.dialect.ResetSequence(ctx, tx, table); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit restored backup: %w", err)
+	}
+	return &result, nil
+}
+
+func (db *Backup) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
+
+// restoreOwners returns the owner of the restored record id of a table: the
+// user ctx is authenticated as, if any, or the user of this database with the
+// name of its owner in the backup. The users missing from this database are
+// created, without credentials, and counted in count.
+func (db *Backup) restoreOwners(ctx context.Context, tx *sqlx.Tx, owners *sql.BackupOwners, count *sql.RestoreCount) (func(table string, id int) *int, error) {
+	if user := ownerId(ctx); user != nil {
+		return func(string, int) *int { return user }, nil
+	}
+
+	userIds := make(map[int]int, len(owners.Users))
+	for _, user := range owners.Users {
+		var id int
+		err := tx.GetContext(ctx, &id, db.dialect.Rebind("SELECT id FROM users WHERE name = ?"), user.Name)
+		switch {
+		case err == nil:
+			count.Skipped++
+		case errors.Is(err, dbsql.ErrNoRows):
+			query := db.dialect.Rebind("INSERT INTO users (name, role, disabled, created_at) VALUES (?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP))")
+			if id, err = db.dialect.InsertID(ctx, tx, query, &entityUser, user.Name, user.Role, user.Disabled, user.CreatedAt); err != nil {
+				return nil, err
+			}
+			count.Restored++
+		default:
+			return nil, fmt.Errorf("failed to read record for user name=%q from the database: %w", user.Name, err)
+		}
+		userIds[user.Id] = id
+	}
+
+	return func(table string, id int) *int {
+		owner, ok := owners.Table(table)[id]
+		if !ok {
+			return nil
+		}
+		if userId, ok := userIds[owner]; ok {
+			return &userId
+		}
+		// The owner is not part of the backup: the record is restored
+		// without an owner rather than as a stranger's.
+		return nil
+	}, nil
+}
+
+// backupRow is a record of a backup to restore into table. Its columns end
+// with created_at and updated_at, and the first identity of them tell the
+// record apart from another one with the same id and owner.
+type backupRow struct {
+	table    string
+	entity   *sqlerrors.Entity
+	id       int
+	ownerId  *int
+	columns  string
+	values   []any
+	identity int
+}
+
+// restore inserts row and counts it in count. With merge, a row whose id
+// already exists is counted as skipped instead when it is the same record,
+// and ErrBackupRestoreConflict is returned otherwise.
+func (db *Backup) restore(ctx context.Context, tx *sqlx.Tx, count *sql.RestoreCount, merge bool, row backupRow) error {
+	if merge {
+		exists, err := db.exists(ctx, tx, row.table, row.id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			same, err := db.same(ctx, tx, row)
+			if err != nil {
+				return err
+			}
+			if !same {
+				return fmt.Errorf("%w: %s id=%d", domainerrors.ErrBackupRestoreConflict, row.table, row.id)
+			}
+			count.Skipped++
+			return nil
+		}
+	}
+
+	// created_at is not nullable: a record without one is stamped now, as
+	// when it is created.
+	placeholders := "?"
+	for range len(row.values) - 2 {
+		placeholders += ", ?"
+	}
+	placeholders += ", COALESCE(?, CURRENT_TIMESTAMP), ?"
+
+	query := db.dialect.Rebind("INSERT INTO " + row.table + " (id, " + row.columns + ", owner_id) VALUES (" + placeholders + ", ?)")
+	args := append(append([]any{row.id}, row.values...), row.ownerId)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return db.dialect.ParseError(err, row.entity, fmt.Errorf("failed to restore record for %s id=%d: %w", row.table, row.id, err))
+	}
+	count.Restored++
+	return nil
+}
+
+// exists reports whether the row id of table exists, whoever owns it.
+func (db *Backup) exists(ctx context.Context, tx *sqlx.Tx, table string, id int) (bool, error) {
+	var count int
+	if err := tx.GetContext(ctx, &count, db.dialect.Rebind("SELECT COUNT(*) FROM "+table+" WHERE id = ?"), id); err != nil {
+		return false, fmt.Errorf("failed to read record for %s id=%d from the database: %w", table, id, err)
+	}
+	return count > 0, nil
+}
+
+// same reports whether the existing row with the id of row has its owner and
+// identity columns. Records without an owner compare as owned by user 0,
+// which never exists.
+func (db *Backup) same(ctx context.Context, tx *sqlx.Tx, row backupRow) (bool, error) {
+	ownerId := 0
+	if row.ownerId != nil {
+		ownerId = *row.ownerId
+	}
+	query := "SELECT COUNT(*) FROM " + row.table + " WHERE id = ? AND COALESCE(owner_id, 0) = ?"
+	args := []any{row.id, ownerId}
+	for i, column := range strings.Split(row.columns, ", ")[:row.identity] {
+		query += " AND " + column + " = ?"
+		args = append(args, row.values[i])
+	}
+
+	var count int
+	if err := tx.GetContext(ctx, &count, db.dialect.Rebind(query), args...); err != nil {
+		return false, fmt.Errorf("failed to read record for %s id=%d from the database: %w", row.table, row.id, err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,184 @@
+package shared
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type Cupping struct {
+	db      *sqlx.DB
+	dialect Dialect
+}
+
+func NewCupping(db *sqlx.DB, dialect Dialect) *Cupping { return &Cupping{db: db, dialect: dialect} }
+
+func (db *Cupping) CreateCuppingSession(ctx context.Context, session *sql.CuppingSession) (int, error) {
+	query := db.dialect.Rebind(`INSERT INTO cupping_sessions (session_date, participants, owner_id) VALUES (?, ?, ?)`)
+	return db.dialect.InsertID(ctx, db.db, query, &entityCuppingSession, session.SessionDate, session.Participants, ownerId(ctx))
+}
+
+func (db *Cupping) GetCuppingSessionById(ctx context.Context, id int) (*sql.CuppingSession, error) {
+	var session sql.CuppingSession
+	query, args := scopeToOwner(ctx, "SELECT id, session_date, participants, created_at, updated_at FROM cupping_sessions WHERE id = ?", "owner_id", id)
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).StructScan(&session); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return nil, domainerrors.ErrCuppingSessionDoesNotExist
+		}
+		return nil, fmt.Errorf("failed to read record for cupping session id=%d from the database: %w", id, err)
+	}
+	return &session, nil
+}
+
+func (db *Cupping) GetAllCuppingSessions(ctx context.Context) ([]sql.CuppingSession, error) {
+	sessions := make([]sql.CuppingSession, 0)
+	query, args := scopeToOwner(ctx, "SELECT id, session_date, participants, created_at, updated_at FROM cupping_sessions", "owner_id")
+	if err := db.db.SelectContext(ctx, &sessions, db.dialect.Rebind(query), args...); err != nil {
+		return sessions, fmt.Errorf("failed to read records for cupping sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+func (db *Cupping) UpdateCuppingSessionById(ctx context.Context, id int, session *sql.CuppingSession) (*sql.CuppingSession, error) {
+	session.Id = id
+	query, args := scopeToOwner(ctx, `UPDATE cupping_sessions SET session_date = ?, participants = ? WHERE id = ?`, "owner_id", session.SessionDate, session.Participants, session.Id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, db.dialect.ParseError(err, &entityCuppingSession, fmt.Errorf("failed to update record for cupping session id=%d: %w", id, err))
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		if _, err := db.GetCuppingSessionById(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return session, nil
+}
+
+// DeleteCuppingSessionById deletes a session. Its score entries are removed
+// with it by the ON DELETE CASCADE foreign key.
+func (db *Cupping) DeleteCuppingSessionById(ctx context.Context, id int) error {
+	query, args := scopeToOwner(ctx, `DELETE FROM cupping_sessions WHERE id = ?`, "owner_id", id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
+	if err != nil {
+		return db.dialect.ParseError(err, nil, fmt.Errorf("failed to delete record for cupping session id=%d: %w", id, err))
+	}
+	if row, _ := res.RowsAffected(); row != 1 {
+		return domainerrors.ErrCuppingSessionDoesNotExist
+	}
+	return nil
+}
+
+func (db *Cupping) CreateCuppingScore(ctx context.Context, score *sql.CuppingScore) (int, error) {
+	if err := checkOwner(ctx, db.db, db.dialect, "cupping_sessions", score.SessionId, domainerrors.ErrCuppingSessionDoesNotExist); err != nil {
+		return 0, err
+	}
+	if err := checkOwner(ctx, db.db, db.dialect, "beans", score.Beans.Id, domainerrors.ErrBeansDoesNotExist); err != nil {
+		return 0, err
+	}
+	query := db.dialect.Rebind(`INSERT INTO
+	cupping_scores (session_id, beans_id, fragrance, flavor, aftertaste, acidity, body, balance, uniformity, clean_cup, sweetness, overall, notes, owner_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	return db.dialect.InsertID(ctx, db.db, query, &entityCuppingScore, score.SessionId, score.Beans.Id, score.Fragrance, score.Flavor, score.Aftertaste, score.Acidity, score.Body, score.Balance, score.Uniformity, score.CleanCup, score.Sweetness, score.Overall, score.Notes, ownerId(ctx))
+}
+
+func (db *Cupping) GetCuppingScoreById(ctx context.Context, id int) (*sql.CuppingScore, error) {
+	var score sql.CuppingScore
+	query, args := scopeToOwner(ctx, cuppingScoreQuery+"\nWHERE cupping_scores.id = ?", "cupping_scores.owner_id", id)
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).StructScan(&score); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return nil, domainerrors.ErrCuppingScoreDoesNotExist
+		}
+		return nil, fmt.Errorf("failed to read record for cupping score id=%d from the database: %w", id, err)
+	}
+	return &score, nil
+}
+
+func (db *Cupping) GetCuppingScoresBySessionId(ctx context.Context, sessionId int) ([]sql.CuppingScore, error) {
+	scores := make([]sql.CuppingScore, 0)
+	query, args := scopeToOwner(ctx, cuppingScoreQuery+"\nWHERE cupping_scores.session_id = ?", "cupping_scores.owner_id", sessionId)
+	if err := db.db.SelectContext(ctx, &scores, db.dialect.Rebind(query), args...); err != nil {
+		return scores, fmt.Errorf("failed to read records for cupping scores with session_id=%d: %w", sessionId, err)
+	}
+	return scores, nil
+}
+
+func (db *Cupping) GetCuppingScoresByBeansId(ctx context.Context, beansId int) ([]sql.CuppingScore, error) {
+	scores := make([]sql.CuppingScore, 0)
+	query, args := scopeToOwner(ctx, cuppingScoreQuery+"\nWHERE cupping_scores.beans_id = ?", "cupping_scores.owner_id", beansId)
+	if err := db.db.SelectContext(ctx, &scores, db.dialect.Rebind(query), args...); err != nil {
+		return scores, fmt.Errorf("failed to read records for cupping scores with beans_id=%d: %w", beansId, err)
+	}
+	return scores, nil
+}
+
+func (db *Cupping) UpdateCuppingScoreById(ctx context.Context, id int, score *sql.CuppingScore) (*sql.CuppingScore, error) {
+	if err := checkOwner(ctx, db.db, db.dialect, "beans", score.Beans.Id, domainerrors.ErrBeansDoesNotExist); err != nil {
+		return nil, err
+	}
+	query, args := scopeToOwner(ctx, `UPDATE cupping_scores SET
+	beans_id = ?, fragrance = ?, flavor = ?, aftertaste = ?, acidity = ?, body = ?, balance = ?, uniformity = ?, clean_cup = ?, sweetness = ?, overall = ?, notes = ?
+	WHERE id = ?`, "owner_id", score.Beans.Id, score.Fragrance, score.Flavor, score.Aftertaste, score.Acidity, score.Body, score.Balance, score.Uniformity, score.CleanCup, score.Sweetness, score.Overall, score.Notes, id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, db.dialect.ParseError(err, &entityCuppingScore, fmt.Errorf("failed to update record for cupping score id=%d: %w", id, err))
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		if _, err := db.GetCuppingScoreById(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return score, nil
+}
+
+func (db *Cupping) DeleteCuppingScoreById(ctx context.Context, id int) error {
+	query, args := scopeToOwner(ctx, `DELETE FROM cupping_scores WHERE id = ?`, "owner_id", id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
+	if err != nil {
+		return db.dialect.ParseError(err, nil, fmt.Errorf("failed to delete record for cupping score id=%d: %w", id, err))
+	}
+	if row, _ := res.RowsAffected(); row != 1 {
+		return domainerrors.ErrCuppingScoreDoesNotExist
+	}
+	return nil
+}
+
+func (db *Cupping) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
+
+const cuppingScoreQuery = `
+SELECT
+	cupping_scores.id,
+	cupping_scores.session_id,
+	cupping_sessions.session_date,
+	cupping_scores.fragrance,
+	cupping_scores.flavor,
+	cupping_scores.aftertaste,
+	cupping_scores.acidity,
+	cupping_scores.body,
+	cupping_scores.balance,
+	cupping_scores.uniformity,
+	cupping_scores.clean_cup,
+	cupping_scores.sweetness,
+	cupping_scores.overall,
+	cupping_scores.notes,
+	cupping_scores.created_at,
+	cupping_scores.updated_at,
+	beans.id as "beans.id",
+	beans.name as "beans.name",
+	beans.roast_date as "beans.roast_date",
+	beans.roast_level as "beans.roast_level",
+	roaster.id AS "beans.roaster.id",
+	roaster.name AS "beans.roaster.name",
+	roaster.created_at AS "beans.roaster.created_at",
+	roaster.updated_at AS "beans.roaster.updated_at"
+FROM cupping_scores
+INNER JOIN
+	cupping_sessions ON cupping_scores.session_id = cupping_sessions.id
+INNER JOIN
+	beans beans ON cupping_scores.beans_id = beans.id
+INNER JOIN
+	roasters roaster ON beans.roaster_id = roaster.id`
@@ -0,0 +1,106 @@
+package shared
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type GreenCoffee struct {
+	db      *sqlx.DB
+	dialect Dialect
+}
+
+func NewGreenCoffee(db *sqlx.DB, dialect Dialect) *GreenCoffee {
+	return &GreenCoffee{db: db, dialect: dialect}
+}
+
+func (db *GreenCoffee) CreateGreenCoffee(ctx context.Context, greenCoffee *sql.GreenCoffee) (int, error) {
+	query := db.dialect.Rebind(`INSERT INTO
+	green_coffees (origin, supplier, purchase_weight, price, arrival_date, moisture, owner_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	return db.dialect.InsertID(ctx, db.db, query, &entityGreenCoffee, greenCoffee.Origin, greenCoffee.Supplier, greenCoffee.PurchaseWeight, greenCoffee.Price, greenCoffee.ArrivalDate, greenCoffee.Moisture, ownerId(ctx))
+}
+
+// GetGreenCoffeeById returns the green coffee with the weight consumed by
+// the roast batches and beans referencing it, if it belongs to the
+// authenticated user.
+func (db *GreenCoffee) GetGreenCoffeeById(ctx context.Context, id int) (*sql.GreenCoffee, error) {
+	var greenCoffee sql.GreenCoffee
+	query, args := scopeToOwner(ctx, greenCoffeeQuery+"\nWHERE green_coffees.id = ?", "green_coffees.owner_id", id)
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).StructScan(&greenCoffee); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return nil, domainerrors.ErrGreenCoffeeDoesNotExist
+		}
+		return nil, fmt.Errorf("failed to read record for green coffee id=%d from the database: %w", id, err)
+	}
+	return &greenCoffee, nil
+}
+
+func (db *GreenCoffee) GetAllGreenCoffees(ctx context.Context) ([]sql.GreenCoffee, error) {
+	greenCoffees := make([]sql.GreenCoffee, 0)
+	query, args := scopeToOwner(ctx, greenCoffeeQuery, "green_coffees.owner_id")
+	if err := db.db.SelectContext(ctx, &greenCoffees, db.dialect.Rebind(query), args...); err != nil {
+		return greenCoffees, fmt.Errorf("failed to read records for green coffees: %w", err)
+	}
+	return greenCoffees, nil
+}
+
+// UpdateGreenCoffeeById updates a green coffee and reads it back, so the
+// returned value carries its consumed weight.
+func (db *GreenCoffee) UpdateGreenCoffeeById(ctx context.Context, id int, greenCoffee *sql.GreenCoffee) (*sql.GreenCoffee, error) {
+	query, args := scopeToOwner(ctx, `UPDATE green_coffees SET
+	origin = ?, supplier = ?, purchase_weight = ?, price = ?, arrival_date = ?, moisture = ?
+	WHERE id = ?`, "owner_id", greenCoffee.Origin, greenCoffee.Supplier, greenCoffee.PurchaseWeight, greenCoffee.Price, greenCoffee.ArrivalDate, greenCoffee.Moisture, id)
+	if _, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...); err != nil {
+		return nil, db.dialect.ParseError(err, &entityGreenCoffee, fmt.Errorf("failed to update record for green coffee id=%d: %w", id, err))
+	}
+	return db.GetGreenCoffeeById(ctx, id)
+}
+
+// DeleteGreenCoffeeById deletes a green coffee. It fails while roast batches
+// or beans still reference it.
+func (db *GreenCoffee) DeleteGreenCoffeeById(ctx context.Context, id int) error {
+	query, args := scopeToOwner(ctx, `DELETE FROM green_coffees WHERE id = ?`, "owner_id", id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
+	if err != nil {
+		return db.dialect.ParseError(err, nil, fmt.Errorf("failed to delete record for green coffee id=%d: %w", id, err))
+	}
+	if row, _ := res.RowsAffected(); row != 1 {
+		return domainerrors.ErrGreenCoffeeDoesNotExist
+	}
+	return nil
+}
+
+func (db *GreenCoffee) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
+
+// checkGreenCoffeeOwner returns ErrGreenCoffeeDoesNotExist unless the green
+// coffee id, when set, is owned by the user ctx is authenticated as.
+func checkGreenCoffeeOwner(ctx context.Context, db sqlx.QueryerContext, dialect Dialect, id *int) error {
+	if id == nil {
+		return nil
+	}
+	return checkOwner(ctx, db, dialect, "green_coffees", *id, domainerrors.ErrGreenCoffeeDoesNotExist)
+}
+
+// greenCoffeeQuery computes the consumed weight from the roast batches and
+// beans drawing on each green coffee, rather than storing a running stock.
+const greenCoffeeQuery = `
+SELECT
+	green_coffees.id,
+	green_coffees.origin,
+	green_coffees.supplier,
+	green_coffees.purchase_weight,
+	green_coffees.price,
+	green_coffees.arrival_date,
+	green_coffees.moisture,
+	green_coffees.created_at,
+	green_coffees.updated_at,
+	(SELECT COALESCE(SUM(roast_batches.green_weight), 0) FROM roast_batches WHERE roast_batches.green_coffee_id = green_coffees.id)
+		+ (SELECT COALESCE(SUM(beans.green_weight), 0) FROM beans WHERE beans.green_coffee_id = green_coffees.id) AS consumed_weight
+FROM green_coffees`
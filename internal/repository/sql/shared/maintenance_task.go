@@ -0,0 +1,112 @@
+package shared
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type MaintenanceTask struct {
+	db      *sqlx.DB
+	dialect Dialect
+}
+
+func NewMaintenanceTask(db *sqlx.DB, dialect Dialect) *MaintenanceTask {
+	return &MaintenanceTask{db: db, dialect: dialect}
+}
+
+func (db *MaintenanceTask) CreateMaintenanceTask(ctx context.Context, task *sql.MaintenanceTask) (int, error) {
+	query := db.dialect.Rebind(`INSERT INTO
+	maintenance_tasks (type, equipment, equipment_name, interval_shots, interval_days, last_done_at, owner_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	return db.dialect.InsertID(ctx, db.db, query, &entityMaintenanceTask, task.Type, task.Equipment, task.EquipmentName, task.IntervalShots, task.IntervalDays, task.LastDoneAt, ownerId(ctx))
+}
+
+// GetMaintenanceTaskById returns the maintenance task with the number of
+// shots pulled since it was last done.
+func (db *MaintenanceTask) GetMaintenanceTaskById(ctx context.Context, id int) (*sql.MaintenanceTask, error) {
+	var task sql.MaintenanceTask
+	query, args := scopeToOwner(ctx, maintenanceTaskQuery+"\nWHERE maintenance_tasks.id = ?", "maintenance_tasks.owner_id", id)
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).StructScan(&task); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return nil, domainerrors.ErrMaintenanceTaskDoesNotExist
+		}
+		return nil, fmt.Errorf("failed to read record for maintenance task id=%d from the database: %w", id, err)
+	}
+	return &task, nil
+}
+
+func (db *MaintenanceTask) GetAllMaintenanceTasks(ctx context.Context) ([]sql.MaintenanceTask, error) {
+	tasks := make([]sql.MaintenanceTask, 0)
+	query, args := scopeToOwner(ctx, maintenanceTaskQuery, "maintenance_tasks.owner_id")
+	query += "\nORDER BY maintenance_tasks.id"
+	if err := db.db.SelectContext(ctx, &tasks, db.dialect.Rebind(query), args...); err != nil {
+		return tasks, fmt.Errorf("failed to read records for maintenance tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+// UpdateMaintenanceTaskById updates a maintenance task and reads it back, so
+// the returned value carries its shot count.
+func (db *MaintenanceTask) UpdateMaintenanceTaskById(ctx context.Context, id int, task *sql.MaintenanceTask) (*sql.MaintenanceTask, error) {
+	query, args := scopeToOwner(ctx, `UPDATE maintenance_tasks SET
+	type = ?, equipment = ?, equipment_name = ?, interval_shots = ?, interval_days = ?, last_done_at = ?
+	WHERE id = ?`, "owner_id", task.Type, task.Equipment, task.EquipmentName, task.IntervalShots, task.IntervalDays, task.LastDoneAt, id)
+	if _, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...); err != nil {
+		return nil, db.dialect.ParseError(err, &entityMaintenanceTask, fmt.Errorf("failed to update record for maintenance task id=%d: %w", id, err))
+	}
+	return db.GetMaintenanceTaskById(ctx, id)
+}
+
+// CompleteMaintenanceTaskById records the maintenance task as done at doneAt,
+// which restarts its shot count, and reads it back.
+func (db *MaintenanceTask) CompleteMaintenanceTaskById(ctx context.Context, id int, doneAt time.Time) (*sql.MaintenanceTask, error) {
+	query, args := scopeToOwner(ctx, `UPDATE maintenance_tasks SET last_done_at = ? WHERE id = ?`, "owner_id", doneAt, id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, db.dialect.ParseError(err, &entityMaintenanceTask, fmt.Errorf("failed to update record for maintenance task id=%d: %w", id, err))
+	}
+	if row, _ := res.RowsAffected(); row != 1 {
+		return nil, domainerrors.ErrMaintenanceTaskDoesNotExist
+	}
+	return db.GetMaintenanceTaskById(ctx, id)
+}
+
+func (db *MaintenanceTask) DeleteMaintenanceTaskById(ctx context.Context, id int) error {
+	query, args := scopeToOwner(ctx, `DELETE FROM maintenance_tasks WHERE id = ?`, "owner_id", id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
+	if err != nil {
+		return db.dialect.ParseError(err, nil, fmt.Errorf("failed to delete record for maintenance task id=%d: %w", id, err))
+	}
+	if row, _ := res.RowsAffected(); row != 1 {
+		return domainerrors.ErrMaintenanceTaskDoesNotExist
+	}
+	return nil
+}
+
+func (db *MaintenanceTask) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
+
+// maintenanceTaskQuery counts the shots pulled since each task was last done,
+// or since it was created when it never was, rather than storing a counter
+// every shot would have to update. Only the shots of the task's owner count,
+// and tasks without an owner count the shots without one.
+const maintenanceTaskQuery = `
+SELECT
+	maintenance_tasks.id,
+	maintenance_tasks.type,
+	maintenance_tasks.equipment,
+	maintenance_tasks.equipment_name,
+	maintenance_tasks.interval_shots,
+	maintenance_tasks.interval_days,
+	maintenance_tasks.last_done_at,
+	maintenance_tasks.created_at,
+	maintenance_tasks.updated_at,
+	(SELECT COUNT(*) FROM shots WHERE shots.created_at > COALESCE(maintenance_tasks.last_done_at, maintenance_tasks.created_at)
+		AND COALESCE(shots.owner_id, 0) = COALESCE(maintenance_tasks.owner_id, 0)) AS shots_since_done
+FROM maintenance_tasks`
@@ -0,0 +1,74 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+)
+
+// ownerId returns the id of the user ctx is authenticated as, to record as the
+// owner of the rows it creates. It returns nil outside of an authenticated
+// request, such as from the CLI, and those rows have no owner.
+func ownerId(ctx context.Context) *int {
+	if user, ok := auth.FromContext(ctx); ok {
+		return &user.Id
+	}
+	return nil
+}
+
+// scopeToOwner restricts query to the rows whose owner column is the user ctx
+// is authenticated as. The condition is appended to the query, so it must end
+// with its FROM or WHERE clause. A WHERE inside a subquery does not count as
+// the query's own. Without an authenticated user, the query and its arguments
+// are returned unchanged.
+func scopeToOwner(ctx context.Context, query, column string, args ...any) (string, []any) {
+	user, ok := auth.FromContext(ctx)
+	if !ok {
+		return query, args
+	}
+	if hasWhere(query) {
+		query += "\n\tAND " + column + " = ?"
+	} else {
+		query += "\nWHERE " + column + " = ?"
+	}
+	return query, append(args, user.Id)
+}
+
+// checkOwner returns notFound unless the row id of table is owned by the user
+// ctx is authenticated as, so rows can only reference rows of the same user.
+// Without an authenticated user, nothing is checked.
+func checkOwner(ctx context.Context, db sqlx.QueryerContext, dialect Dialect, table string, id int, notFound error) error {
+	if _, ok := auth.FromContext(ctx); !ok {
+		return nil
+	}
+	query, args := scopeToOwner(ctx, "SELECT COUNT(*) FROM "+table+" WHERE id = ?", "owner_id", id)
+	var count int
+	if err := sqlx.GetContext(ctx, db, &count, dialect.Rebind(query), args...); err != nil {
+		return fmt.Errorf("failed to check the owner of %s id=%d: %w", table, id, err)
+	}
+	if count == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// hasWhere reports whether query has a WHERE clause outside of parentheses.
+func hasWhere(query string) bool {
+	depth := 0
+	for i := 0; i < len(query); i++ {
+		switch query[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		default:
+			if depth == 0 && strings.HasPrefix(query[i:], "WHERE") {
+				return true
+			}
+		}
+	}
+	return false
+}
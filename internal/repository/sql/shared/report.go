@@ -0,0 +1,65 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type Report struct {
+	db      *sqlx.DB
+	dialect Dialect
+}
+
+func NewReport(db *sqlx.DB, dialect Dialect) *Report {
+	return &Report{db: db, dialect: dialect}
+}
+
+// GetShotCosts returns the shots pulled in [from, to) from beans with a price
+// and a bag weight, oldest first. A nil bound leaves that side of the range
+// open. Only the shots of the authenticated user are returned, if any.
+func (db *Report) GetShotCosts(ctx context.Context, from, to *time.Time) ([]sql.ShotCost, error) {
+	query := shotCostQuery
+	args := make([]any, 0, 2)
+	if from != nil {
+		query += "\n\tAND shots.created_at >= ?"
+		args = append(args, *from)
+	}
+	if to != nil {
+		query += "\n\tAND shots.created_at < ?"
+		args = append(args, *to)
+	}
+	query, args = scopeToOwner(ctx, query, "shots.owner_id", args...)
+	query += "\nORDER BY shots.created_at, shots.id"
+
+	costs := make([]sql.ShotCost, 0)
+	if err := db.db.SelectContext(ctx, &costs, db.dialect.Rebind(query), args...); err != nil {
+		return costs, fmt.Errorf("failed to read shot costs: %w", err)
+	}
+	return costs, nil
+}
+
+func (db *Report) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
+
+// shotCostQuery leaves out the shots whose beans have no price, as their cost
+// is unknown rather than zero.
+const shotCostQuery = `
+SELECT
+	shots.id AS shot_id,
+	shots.quantity_in,
+	shots.created_at,
+	beans.id AS beans_id,
+	beans.name AS beans_name,
+	roasters.id AS roaster_id,
+	roasters.name AS roaster_name,
+	beans.price,
+	beans.currency,
+	beans.bag_weight
+FROM shots
+	INNER JOIN beans ON shots.beans_id = beans.id
+	INNER JOIN roasters ON beans.roaster_id = roasters.id
+WHERE beans.price > 0
+	AND beans.bag_weight > 0`
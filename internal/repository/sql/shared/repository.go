@@ -16,7 +16,15 @@ import (
 type Dialect struct {
 	Rebind     func(string) string
 	ParseError func(error, *sqlerrors.Entity, error) error
-	InsertID   func(context.Context, *sqlx.DB, string, *sqlerrors.Entity, ...any) (int, error)
+	// InsertID runs an INSERT, on the database or inside a transaction,
+	// and returns the id of the new row.
+	InsertID func(context.Context, sqlx.ExtContext, string, *sqlerrors.Entity, ...any) (int, error)
+	// LocalDate returns the expression of the calendar date of a timestamp
+	// column in the time zone bound to its single placeholder.
+	LocalDate func(column string) string
+	// ResetSequence moves the sequence generating the ids of table past its
+	// largest id, once rows were inserted with their own ids.
+	ResetSequence func(ctx context.Context, db sqlx.ExecerContext, table string) error
 }
 
 var (
@@ -24,6 +32,17 @@ var (
 	entityRoaster = sqlerrors.EntityRoaster
 	entitySheet   = sqlerrors.EntitySheet
 	entityShot    = sqlerrors.EntityShot
+
+	entityCuppingSession  = sqlerrors.EntityCuppingSession
+	entityCuppingScore    = sqlerrors.EntityCuppingScore
+	entityRoastBatch      = sqlerrors.EntityRoastBatch
+	entityGreenCoffee     = sqlerrors.EntityGreenCoffee
+	entityMaintenanceTask = sqlerrors.EntityMaintenanceTask
+	entityUser            = sqlerrors.EntityUser
+	entityAPIKey          = sqlerrors.EntityAPIKey
+	entitySession         = sqlerrors.EntitySession
+	entityShareLink       = sqlerrors.EntityShareLink
+	entityAttachment      = sqlerrors.EntityAttachment
 )
 
 type Bean struct {
@@ -34,18 +53,29 @@ type Bean struct {
 func NewBean(db *sqlx.DB, dialect Dialect) *Bean { return &Bean{db: db, dialect: dialect} }
 
 func (db *Bean) CreateBeans(ctx context.Context, beans *sql.Beans) (int, error) {
-	query := db.dialect.Rebind("INSERT INTO beans (name, roaster_id, roast_date, roast_level) VALUES (?, ?, ?, ?)")
-	return db.dialect.InsertID(ctx, db.db, query, &entityBeans, beans.Name, beans.Roaster.Id, beans.RoastDate, beans.RoastLevel)
+	if err := checkOwner(ctx, db.db, db.dialect, "roasters", beans.Roaster.Id, domainerrors.ErrRoasterDoesNotExist); err != nil {
+		return 0, err
+	}
+	if err := checkGreenCoffeeOwner(ctx, db.db, db.dialect, beans.GreenCoffeeId); err != nil {
+		return 0, err
+	}
+	query := db.dialect.Rebind("INSERT INTO beans (name, roaster_id, roast_date, roast_level, green_coffee_id, green_weight, price, currency, bag_weight, owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	return db.dialect.InsertID(ctx, db.db, query, &entityBeans, beans.Name, beans.Roaster.Id, beans.RoastDate, beans.RoastLevel, beans.GreenCoffeeId, beans.GreenWeight, beans.Price, beans.Currency, beans.BagWeight, ownerId(ctx))
 }
 
 func (db *Bean) GetBeansById(ctx context.Context, id int) (*sql.Beans, error) {
 	var beans sql.Beans
-	query := db.dialect.Rebind(`
+	query, args := scopeToOwner(ctx, `
 SELECT
 	beans.id,
 	beans.name,
 	beans.roast_date,
 	beans.roast_level,
+	beans.green_coffee_id,
+	beans.green_weight,
+	beans.price,
+	beans.currency,
+	beans.bag_weight,
 	beans.created_at,
 	beans.updated_at,
 	roaster.id AS "roaster.id",
@@ -56,8 +86,8 @@ FROM beans
 	INNER JOIN roasters roaster
 		ON beans.roaster_id = roaster.id
 WHERE
-	beans.id = ?`)
-	if err := db.db.QueryRowxContext(ctx, query, id).StructScan(&beans); err != nil {
+	beans.id = ?`, "beans.owner_id", id)
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).StructScan(&beans); err != nil {
 		if errors.Is(err, dbsql.ErrNoRows) {
 			return nil, domainerrors.ErrBeansDoesNotExist
 		}
@@ -68,12 +98,17 @@ WHERE
 
 func (db *Bean) GetAllBeans(ctx context.Context) ([]sql.Beans, error) {
 	beans := make([]sql.Beans, 0)
-	query := db.dialect.Rebind(`
+	query, args := scopeToOwner(ctx, `
 	SELECT
 		beans.id,
 		beans.name,
 		beans.roast_date,
 		beans.roast_level,
+		beans.green_coffee_id,
+		beans.green_weight,
+		beans.price,
+		beans.currency,
+		beans.bag_weight,
 		beans.created_at,
 		beans.updated_at,
 		roaster.id AS "roaster.id",
@@ -82,24 +117,31 @@ func (db *Bean) GetAllBeans(ctx context.Context) ([]sql.Beans, error) {
 		roaster.updated_at AS "roaster.updated_at"
 	FROM beans
 		INNER JOIN roasters roaster
-			ON beans.roaster_id = roaster.id`)
-	if err := db.db.SelectContext(ctx, &beans, query); err != nil {
+			ON beans.roaster_id = roaster.id`, "beans.owner_id")
+	if err := db.db.SelectContext(ctx, &beans, db.dialect.Rebind(query), args...); err != nil {
 		return beans, fmt.Errorf("failed to read records for beans: %w", err)
 	}
 	return beans, nil
 }
 
 func (db *Bean) UpdateBeansById(ctx context.Context, id int, beans *sql.Beans) (*sql.Beans, error) {
-	query := db.dialect.Rebind(`UPDATE beans SET name = ?, roaster_id = ?, roast_date = ?, roast_level = ? WHERE id = ?`)
-	if _, err := db.db.ExecContext(ctx, query, beans.Name, beans.Roaster.Id, beans.RoastDate, beans.RoastLevel, id); err != nil {
+	if err := checkOwner(ctx, db.db, db.dialect, "roasters", beans.Roaster.Id, domainerrors.ErrRoasterDoesNotExist); err != nil {
+		return nil, err
+	}
+	if err := checkGreenCoffeeOwner(ctx, db.db, db.dialect, beans.GreenCoffeeId); err != nil {
+		return nil, err
+	}
+	query, args := scopeToOwner(ctx, `UPDATE beans SET name = ?, roaster_id = ?, roast_date = ?, roast_level = ?, green_coffee_id = ?, green_weight = ?, price = ?, currency = ?, bag_weight = ? WHERE id = ?`, "owner_id",
+		beans.Name, beans.Roaster.Id, beans.RoastDate, beans.RoastLevel, beans.GreenCoffeeId, beans.GreenWeight, beans.Price, beans.Currency, beans.BagWeight, id)
+	if _, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...); err != nil {
 		return nil, db.dialect.ParseError(err, &entityBeans, fmt.Errorf("failed to update record for beans id=%d: %w", id, err))
 	}
 	return beans, nil
 }
 
 func (db *Bean) DeleteBeansById(ctx context.Context, id int) error {
-	query := db.dialect.Rebind(`DELETE FROM beans WHERE id = ?`)
-	res, err := db.db.ExecContext(ctx, query, id)
+	query, args := scopeToOwner(ctx, `DELETE FROM beans WHERE id = ?`, "owner_id", id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
 	if err != nil {
 		return db.dialect.ParseError(err, nil, fmt.Errorf("failed to delete record for beans id=%d: %w", id, err))
 	}
@@ -119,8 +161,8 @@ type Roaster struct {
 func NewRoaster(db *sqlx.DB, dialect Dialect) *Roaster { return &Roaster{db: db, dialect: dialect} }
 
 func (db *Roaster) CreateRoaster(ctx context.Context, roaster *sql.Roaster) error {
-	query := db.dialect.Rebind(`INSERT INTO roasters (name) VALUES (?)`)
-	_, err := db.db.ExecContext(ctx, query, roaster.Name)
+	query := db.dialect.Rebind(`INSERT INTO roasters (name, owner_id) VALUES (?, ?)`)
+	_, err := db.db.ExecContext(ctx, query, roaster.Name, ownerId(ctx))
 	if err != nil {
 		return db.dialect.ParseError(err, &entityRoaster, fmt.Errorf("failed to insert record to the database: %w", err))
 	}
@@ -129,8 +171,8 @@ func (db *Roaster) CreateRoaster(ctx context.Context, roaster *sql.Roaster) erro
 
 func (db *Roaster) GetRoasterById(ctx context.Context, id int) (*sql.Roaster, error) {
 	var roaster sql.Roaster
-	query := db.dialect.Rebind("SELECT id, name, created_at, updated_at FROM roasters WHERE id = ?")
-	if err := db.db.QueryRowxContext(ctx, query, id).StructScan(&roaster); err != nil {
+	query, args := scopeToOwner(ctx, "SELECT id, name, created_at, updated_at FROM roasters WHERE id = ?", "owner_id", id)
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).StructScan(&roaster); err != nil {
 		if errors.Is(err, dbsql.ErrNoRows) {
 			return nil, domainerrors.ErrRoasterDoesNotExist
 		}
@@ -141,8 +183,8 @@ func (db *Roaster) GetRoasterById(ctx context.Context, id int) (*sql.Roaster, er
 
 func (db *Roaster) GetRoasterByName(ctx context.Context, name string) (*sql.Roaster, error) {
 	var roaster sql.Roaster
-	query := db.dialect.Rebind("SELECT id, name, created_at, updated_at FROM roasters WHERE name = ?")
-	if err := db.db.QueryRowxContext(ctx, query, name).StructScan(&roaster); err != nil {
+	query, args := scopeToOwner(ctx, "SELECT id, name, created_at, updated_at FROM roasters WHERE name = ?", "owner_id", name)
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).StructScan(&roaster); err != nil {
 		if errors.Is(err, dbsql.ErrNoRows) {
 			return nil, domainerrors.ErrRoasterDoesNotExist
 		}
@@ -153,8 +195,8 @@ func (db *Roaster) GetRoasterByName(ctx context.Context, name string) (*sql.Roas
 
 func (db *Roaster) GetAllRoasters(ctx context.Context) ([]sql.Roaster, error) {
 	roasters := make([]sql.Roaster, 0)
-	query := db.dialect.Rebind("SELECT id, name, created_at, updated_at FROM roasters")
-	if err := db.db.SelectContext(ctx, &roasters, query); err != nil {
+	query, args := scopeToOwner(ctx, "SELECT id, name, created_at, updated_at FROM roasters", "owner_id")
+	if err := db.db.SelectContext(ctx, &roasters, db.dialect.Rebind(query), args...); err != nil {
 		return roasters, fmt.Errorf("failed to read records for roasters: %w", err)
 	}
 	return roasters, nil
@@ -162,8 +204,8 @@ func (db *Roaster) GetAllRoasters(ctx context.Context) ([]sql.Roaster, error) {
 
 func (db *Roaster) UpdateRoasterById(ctx context.Context, id int, roaster *sql.Roaster) (*sql.Roaster, error) {
 	roaster.Id = id
-	query := db.dialect.Rebind(`UPDATE roasters SET name = ? WHERE id = ?`)
-	res, err := db.db.ExecContext(ctx, query, roaster.Name, roaster.Id)
+	query, args := scopeToOwner(ctx, `UPDATE roasters SET name = ? WHERE id = ?`, "owner_id", roaster.Name, roaster.Id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
 	if err != nil {
 		return nil, db.dialect.ParseError(err, &entityRoaster, fmt.Errorf("failed to update record for roaster id=%d: %w", id, err))
 	}
@@ -176,8 +218,8 @@ func (db *Roaster) UpdateRoasterById(ctx context.Context, id int, roaster *sql.R
 }
 
 func (db *Roaster) DeleteRoasterById(ctx context.Context, id int) error {
-	query := db.dialect.Rebind(`DELETE FROM roasters WHERE id = ?`)
-	res, err := db.db.ExecContext(ctx, query, id)
+	query, args := scopeToOwner(ctx, `DELETE FROM roasters WHERE id = ?`, "owner_id", id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
 	if err != nil {
 		return db.dialect.ParseError(err, nil, fmt.Errorf("failed to delete record for roaster id=%d: %w", id, err))
 	}
@@ -197,8 +239,8 @@ type Sheet struct {
 func NewSheet(db *sqlx.DB, dialect Dialect) *Sheet { return &Sheet{db: db, dialect: dialect} }
 
 func (db *Sheet) CreateSheet(ctx context.Context, sheet *sql.Sheet) error {
-	query := db.dialect.Rebind(`INSERT INTO sheets (name) VALUES (?)`)
-	_, err := db.db.ExecContext(ctx, query, sheet.Name)
+	query := db.dialect.Rebind(`INSERT INTO sheets (name, is_template, owner_id) VALUES (?, ?, ?)`)
+	_, err := db.db.ExecContext(ctx, query, sheet.Name, sheet.IsTemplate, ownerId(ctx))
 	if err != nil {
 		return db.dialect.ParseError(err, &entitySheet, fmt.Errorf("failed to insert record to the database: %w", err))
 	}
@@ -207,8 +249,8 @@ func (db *Sheet) CreateSheet(ctx context.Context, sheet *sql.Sheet) error {
 
 func (db *Sheet) GetSheetById(ctx context.Context, id int) (*sql.Sheet, error) {
 	var sheet sql.Sheet
-	query := db.dialect.Rebind("SELECT id, name, created_at, updated_at FROM sheets WHERE id = ?")
-	if err := db.db.QueryRowxContext(ctx, query, id).StructScan(&sheet); err != nil {
+	query, args := scopeToOwner(ctx, "SELECT id, name, is_template, created_at, updated_at FROM sheets WHERE id = ?", "owner_id", id)
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).StructScan(&sheet); err != nil {
 		if errors.Is(err, dbsql.ErrNoRows) {
 			return nil, domainerrors.ErrSheetDoesNotExist
 		}
@@ -219,8 +261,8 @@ func (db *Sheet) GetSheetById(ctx context.Context, id int) (*sql.Sheet, error) {
 
 func (db *Sheet) GetSheetByName(ctx context.Context, name string) (*sql.Sheet, error) {
 	var sheet sql.Sheet
-	query := db.dialect.Rebind("SELECT id, name, created_at, updated_at FROM sheets WHERE name = ?")
-	if err := db.db.QueryRowxContext(ctx, query, name).StructScan(&sheet); err != nil {
+	query, args := scopeToOwner(ctx, "SELECT id, name, is_template, created_at, updated_at FROM sheets WHERE name = ?", "owner_id", name)
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).StructScan(&sheet); err != nil {
 		if errors.Is(err, dbsql.ErrNoRows) {
 			return nil, domainerrors.ErrSheetDoesNotExist
 		}
@@ -231,8 +273,8 @@ func (db *Sheet) GetSheetByName(ctx context.Context, name string) (*sql.Sheet, e
 
 func (db *Sheet) GetAllSheets(ctx context.Context) ([]sql.Sheet, error) {
 	sheets := make([]sql.Sheet, 0)
-	query := db.dialect.Rebind("SELECT id, name, created_at, updated_at FROM sheets")
-	if err := db.db.SelectContext(ctx, &sheets, query); err != nil {
+	query, args := scopeToOwner(ctx, "SELECT id, name, is_template, created_at, updated_at FROM sheets", "owner_id")
+	if err := db.db.SelectContext(ctx, &sheets, db.dialect.Rebind(query), args...); err != nil {
 		return sheets, fmt.Errorf("failed to read records for sheets: %w", err)
 	}
 	return sheets, nil
@@ -240,8 +282,8 @@ func (db *Sheet) GetAllSheets(ctx context.Context) ([]sql.Sheet, error) {
 
 func (db *Sheet) UpdateSheetById(ctx context.Context, id int, sheet *sql.Sheet) (*sql.Sheet, error) {
 	sheet.Id = id
-	query := db.dialect.Rebind(`UPDATE sheets SET name = ? WHERE id = ?`)
-	res, err := db.db.ExecContext(ctx, query, sheet.Name, sheet.Id)
+	query, args := scopeToOwner(ctx, `UPDATE sheets SET name = ?, is_template = ? WHERE id = ?`, "owner_id", sheet.Name, sheet.IsTemplate, sheet.Id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
 	if err != nil {
 		return nil, db.dialect.ParseError(err, &entitySheet, fmt.Errorf("failed to update record for sheet id=%d: %w", id, err))
 	}
@@ -254,8 +296,8 @@ func (db *Sheet) UpdateSheetById(ctx context.Context, id int, sheet *sql.Sheet)
 }
 
 func (db *Sheet) DeleteSheetById(ctx context.Context, id int) error {
-	query := db.dialect.Rebind(`DELETE FROM sheets WHERE id = ?`)
-	res, err := db.db.ExecContext(ctx, query, id)
+	query, args := scopeToOwner(ctx, `DELETE FROM sheets WHERE id = ?`, "owner_id", id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
 	if err != nil {
 		return db.dialect.ParseError(err, nil, fmt.Errorf("failed to delete record for sheet id=%d: %w", id, err))
 	}
@@ -265,6 +307,50 @@ func (db *Sheet) DeleteSheetById(ctx context.Context, id int) error {
 	return nil
 }
 
+// CloneSheet creates a sheet named name from the sheet id in a single
+// transaction, along with a copy of its best shot (the latest of its best
+// rated shots) when copyBestShot is set. As in the shot stats, unrated shots
+// with a rating of 0 are never the best: no shot is copied from a sheet
+// without a rated shot. The new sheet is never a template. It returns the id
+// of the new sheet.
+func (db *Sheet) CloneSheet(ctx context.Context, id int, name string, copyBestShot bool) (int, error) {
+	tx, err := db.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var count int
+	query, args := scopeToOwner(ctx, "SELECT COUNT(*) FROM sheets WHERE id = ?", "owner_id", id)
+	if err := tx.GetContext(ctx, &count, db.dialect.Rebind(query), args...); err != nil {
+		return 0, fmt.Errorf("failed to read record for sheet id=%d from the database: %w", id, err)
+	}
+	if count == 0 {
+		return 0, domainerrors.ErrSheetDoesNotExist
+	}
+
+	cloneId, err := db.dialect.InsertID(ctx, tx, db.dialect.Rebind(`INSERT INTO sheets (name, owner_id) VALUES (?, ?)`), &entitySheet, name, ownerId(ctx))
+	if err != nil {
+		return 0, err
+	}
+
+	if copyBestShot {
+		query, args := scopeToOwner(ctx, `INSERT INTO
+	shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id)
+	SELECT ?, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, ?
+	FROM shots WHERE sheet_id = ? AND rating > 0`, "owner_id", cloneId, ownerId(ctx), id)
+		query += "\nORDER BY rating DESC, id DESC\nLIMIT 1"
+		if _, err := tx.ExecContext(ctx, db.dialect.Rebind(query), args...); err != nil {
+			return 0, db.dialect.ParseError(err, &entityShot, fmt.Errorf("failed to copy the best shot of sheet id=%d: %w", id, err))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit clone of sheet id=%d: %w", id, err)
+	}
+	return cloneId, nil
+}
+
 func (db *Sheet) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
 
 type Shot struct {
@@ -275,18 +361,21 @@ type Shot struct {
 func NewShot(db *sqlx.DB, dialect Dialect) *Shot { return &Shot{db: db, dialect: dialect} }
 
 func (db *Shot) CreateShot(ctx context.Context, shot *sql.Shot) (int, error) {
+	if err := db.checkReferences(ctx, shot); err != nil {
+		return 0, err
+	}
 	query := db.dialect.Rebind(`INSERT INTO
-	shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes)
-	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	// shot_time_ms stores milliseconds (not nanoseconds): the shots table's
 	// INT column cannot hold a realistic duration's raw nanosecond count.
-	return db.dialect.InsertID(ctx, db.db, query, &entityShot, shot.Sheet.Id, shot.Beans.Id, shot.GrindSetting, shot.QuantityIn, shot.QuantityOut, shot.ShotTime.Milliseconds(), shot.WaterTemperature, shot.Rating, shot.IsTooBitter, shot.IsTooSour, shot.ComparisonWithPreviousResult, shot.AdditionalNotes)
+	return db.dialect.InsertID(ctx, db.db, query, &entityShot, shot.Sheet.Id, shot.Beans.Id, shot.GrindSetting, shot.QuantityIn, shot.QuantityOut, shot.ShotTime.Milliseconds(), shot.WaterTemperature, shot.Rating, shot.IsTooBitter, shot.IsTooSour, shot.ComparisonWithPreviousResult, shot.AdditionalNotes, ownerId(ctx))
 }
 
 func (db *Shot) GetShotById(ctx context.Context, id int) (*sql.Shot, error) {
 	var shot sql.Shot
-	query := db.dialect.Rebind(shotQuery + "\nWHERE shots.id = ?")
-	if err := db.db.QueryRowxContext(ctx, query, id).StructScan(&shot); err != nil {
+	query, args := scopeToOwner(ctx, shotQuery+"\nWHERE shots.id = ?", "shots.owner_id", id)
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).StructScan(&shot); err != nil {
 		if errors.Is(err, dbsql.ErrNoRows) {
 			return nil, domainerrors.ErrShotDoesNotExist
 		}
@@ -298,7 +387,8 @@ func (db *Shot) GetShotById(ctx context.Context, id int) (*sql.Shot, error) {
 
 func (db *Shot) GetAllShots(ctx context.Context) ([]sql.Shot, error) {
 	shots := make([]sql.Shot, 0)
-	if err := db.db.SelectContext(ctx, &shots, db.dialect.Rebind(shotQuery)); err != nil {
+	query, args := scopeToOwner(ctx, shotQuery, "shots.owner_id")
+	if err := db.db.SelectContext(ctx, &shots, db.dialect.Rebind(query), args...); err != nil {
 		return shots, fmt.Errorf("failed to read records for shots: %w", err)
 	}
 	for i := range shots {
@@ -309,8 +399,8 @@ func (db *Shot) GetAllShots(ctx context.Context) ([]sql.Shot, error) {
 
 func (db *Shot) GetShotsBySheetId(ctx context.Context, sheetId int) ([]sql.Shot, error) {
 	shots := make([]sql.Shot, 0)
-	query := db.dialect.Rebind(shotQuery + "\nWHERE shots.sheet_id = ?")
-	if err := db.db.SelectContext(ctx, &shots, query, sheetId); err != nil {
+	query, args := scopeToOwner(ctx, shotQuery+"\nWHERE shots.sheet_id = ?", "shots.owner_id", sheetId)
+	if err := db.db.SelectContext(ctx, &shots, db.dialect.Rebind(query), args...); err != nil {
 		return shots, fmt.Errorf("failed to read records for shots with sheet_id=%d: %w", sheetId, err)
 	}
 	for i := range shots {
@@ -320,17 +410,22 @@ func (db *Shot) GetShotsBySheetId(ctx context.Context, sheetId int) ([]sql.Shot,
 }
 
 func (db *Shot) UpdateShotById(ctx context.Context, id int, shot *sql.Shot) (*sql.Shot, error) {
-	query := db.dialect.Rebind(`UPDATE shots SET
+	if err := db.checkReferences(ctx, shot); err != nil {
+		return nil, err
+	}
+	query, args := scopeToOwner(ctx, `UPDATE shots SET
 	sheet_id = ?, beans_id = ?, grind_setting = ?, quantity_in = ?, quantity_out = ?, shot_time_ms = ?, water_temperature = ?, rating = ?, is_too_bitter = ?, is_too_sour = ?, comparison_with_previous_result = ?, additional_notes = ?
-	WHERE id = ?`)
-	if _, err := db.db.ExecContext(ctx, query, shot.Sheet.Id, shot.Beans.Id, shot.GrindSetting, shot.QuantityIn, shot.QuantityOut, shot.ShotTime.Milliseconds(), shot.WaterTemperature, shot.Rating, shot.IsTooBitter, shot.IsTooSour, shot.ComparisonWithPreviousResult, shot.AdditionalNotes, id); err != nil {
+	WHERE id = ?`, "owner_id",
+		shot.Sheet.Id, shot.Beans.Id, shot.GrindSetting, shot.QuantityIn, shot.QuantityOut, shot.ShotTime.Milliseconds(), shot.WaterTemperature, shot.Rating, shot.IsTooBitter, shot.IsTooSour, shot.ComparisonWithPreviousResult, shot.AdditionalNotes, id)
+	if _, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...); err != nil {
 		return nil, db.dialect.ParseError(err, &entityShot, fmt.Errorf("failed to update record in the database: %w", err))
 	}
 	return shot, nil
 }
 
 func (db *Shot) DeleteShotById(ctx context.Context, id int) error {
-	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(`DELETE FROM shots WHERE id = ?`), id)
+	query, args := scopeToOwner(ctx, `DELETE FROM shots WHERE id = ?`, "owner_id", id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
 	if err != nil {
 		return db.dialect.ParseError(err, nil, fmt.Errorf("failed to delete record for shots id=%d: %w", id, err))
 	}
@@ -342,6 +437,15 @@ func (db *Shot) DeleteShotById(ctx context.Context, id int) error {
 
 func (db *Shot) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
 
+// checkReferences makes sure the sheet and the beans of a shot belong to the
+// same user as the shot.
+func (db *Shot) checkReferences(ctx context.Context, shot *sql.Shot) error {
+	if err := checkOwner(ctx, db.db, db.dialect, "sheets", shot.Sheet.Id, domainerrors.ErrSheetDoesNotExist); err != nil {
+		return err
+	}
+	return checkOwner(ctx, db.db, db.dialect, "beans", shot.Beans.Id, domainerrors.ErrBeansDoesNotExist)
+}
+
 const shotQuery = `
 SELECT
 	shots.id,
@@ -363,6 +467,9 @@ SELECT
 	beans.name as "beans.name",
 	beans.roast_date as "beans.roast_date",
 	beans.roast_level as "beans.roast_level",
+	beans.price as "beans.price",
+	beans.currency as "beans.currency",
+	beans.bag_weight as "beans.bag_weight",
 	roaster.id AS "beans.roaster.id",
 	roaster.name AS "beans.roaster.name",
 	roaster.created_at AS "beans.roaster.created_at",
@@ -0,0 +1,223 @@
+package shared
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type RoastBatch struct {
+	db      *sqlx.DB
+	dialect Dialect
+}
+
+func NewRoastBatch(db *sqlx.DB, dialect Dialect) *RoastBatch {
+	return &RoastBatch{db: db, dialect: dialect}
+}
+
+// CreateRoastBatch inserts a batch and its curve points in a single
+// transaction.
+func (db *RoastBatch) CreateRoastBatch(ctx context.Context, batch *sql.RoastBatch) (int, error) {
+	tx, err := db.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := checkGreenCoffeeOwner(ctx, tx, db.dialect, batch.GreenCoffeeId); err != nil {
+		return 0, err
+	}
+	query := db.dialect.Rebind(`INSERT INTO
+	roast_batches (green_coffee, roast_date, roast_level, green_weight, roasted_weight, charge_temperature, first_crack_time, development_time, end_temperature, green_coffee_id, owner_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	id, err := db.dialect.InsertID(ctx, tx, query, &entityRoastBatch, batch.GreenCoffee, batch.RoastDate, batch.RoastLevel, batch.GreenWeight, batch.RoastedWeight, batch.ChargeTemperature, batch.FirstCrackTime, batch.DevelopmentTime, batch.EndTemperature, batch.GreenCoffeeId, ownerId(ctx))
+	if err != nil {
+		return 0, err
+	}
+
+	if err := db.insertCurvePoints(ctx, tx, id, batch.CurvePoints); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit roast batch id=%d: %w", id, err)
+	}
+	return id, nil
+}
+
+// GetRoastBatchById returns the batch with its curve points, ordered by
+// elapsed time.
+func (db *RoastBatch) GetRoastBatchById(ctx context.Context, id int) (*sql.RoastBatch, error) {
+	var batch sql.RoastBatch
+	query, args := scopeToOwner(ctx, roastBatchQuery+"\nWHERE id = ?", "owner_id", id)
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).StructScan(&batch); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return nil, domainerrors.ErrRoastBatchDoesNotExist
+		}
+		return nil, fmt.Errorf("failed to read record for roast batch id=%d from the database: %w", id, err)
+	}
+
+	batch.CurvePoints = make([]sql.RoastCurvePoint, 0)
+	query = db.dialect.Rebind(`SELECT roast_batch_id, elapsed_time, temperature FROM roast_curve_points WHERE roast_batch_id = ? ORDER BY elapsed_time`)
+	if err := db.db.SelectContext(ctx, &batch.CurvePoints, query, id); err != nil {
+		return nil, fmt.Errorf("failed to read curve points for roast batch id=%d from the database: %w", id, err)
+	}
+	return &batch, nil
+}
+
+// GetAllRoastBatches returns every batch, without its curve points.
+func (db *RoastBatch) GetAllRoastBatches(ctx context.Context) ([]sql.RoastBatch, error) {
+	batches := make([]sql.RoastBatch, 0)
+	query, args := scopeToOwner(ctx, roastBatchQuery, "owner_id")
+	if err := db.db.SelectContext(ctx, &batches, db.dialect.Rebind(query), args...); err != nil {
+		return batches, fmt.Errorf("failed to read records for roast batches: %w", err)
+	}
+	return batches, nil
+}
+
+// UpdateRoastBatchById updates a batch and replaces its curve points in a
+// single transaction. The beans generated from the batch, if any, are left
+// untouched.
+func (db *RoastBatch) UpdateRoastBatchById(ctx context.Context, id int, batch *sql.RoastBatch) (*sql.RoastBatch, error) {
+	tx, err := db.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var existing int
+	query, args := scopeToOwner(ctx, `SELECT id FROM roast_batches WHERE id = ?`, "owner_id", id)
+	if err := tx.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).Scan(&existing); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return nil, domainerrors.ErrRoastBatchDoesNotExist
+		}
+		return nil, fmt.Errorf("failed to read record for roast batch id=%d from the database: %w", id, err)
+	}
+
+	if err := checkGreenCoffeeOwner(ctx, tx, db.dialect, batch.GreenCoffeeId); err != nil {
+		return nil, err
+	}
+	query = db.dialect.Rebind(`UPDATE roast_batches SET
+	green_coffee = ?, roast_date = ?, roast_level = ?, green_weight = ?, roasted_weight = ?, charge_temperature = ?, first_crack_time = ?, development_time = ?, end_temperature = ?, green_coffee_id = ?
+	WHERE id = ?`)
+	if _, err := tx.ExecContext(ctx, query, batch.GreenCoffee, batch.RoastDate, batch.RoastLevel, batch.GreenWeight, batch.RoastedWeight, batch.ChargeTemperature, batch.FirstCrackTime, batch.DevelopmentTime, batch.EndTemperature, batch.GreenCoffeeId, id); err != nil {
+		return nil, db.dialect.ParseError(err, &entityRoastBatch, fmt.Errorf("failed to update record for roast batch id=%d: %w", id, err))
+	}
+
+	if _, err := tx.ExecContext(ctx, db.dialect.Rebind(`DELETE FROM roast_curve_points WHERE roast_batch_id = ?`), id); err != nil {
+		return nil, fmt.Errorf("failed to delete curve points for roast batch id=%d: %w", id, err)
+	}
+	if err := db.insertCurvePoints(ctx, tx, id, batch.CurvePoints); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit roast batch id=%d: %w", id, err)
+	}
+	batch.Id = id
+	return batch, nil
+}
+
+// DeleteRoastBatchById deletes a batch. Its curve points are removed with it
+// by the ON DELETE CASCADE foreign key; the beans generated from it are kept.
+func (db *RoastBatch) DeleteRoastBatchById(ctx context.Context, id int) error {
+	query, args := scopeToOwner(ctx, `DELETE FROM roast_batches WHERE id = ?`, "owner_id", id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
+	if err != nil {
+		return db.dialect.ParseError(err, nil, fmt.Errorf("failed to delete record for roast batch id=%d: %w", id, err))
+	}
+	if row, _ := res.RowsAffected(); row != 1 {
+		return domainerrors.ErrRoastBatchDoesNotExist
+	}
+	return nil
+}
+
+// CreateBeansFromRoastBatch creates beans named after the batch's green
+// coffee, with its roast date and level, roasted by the roaster named
+// roasterName (created if missing), and links them to the batch. Everything
+// happens in a single transaction; it returns the id of the new beans.
+func (db *RoastBatch) CreateBeansFromRoastBatch(ctx context.Context, id int, roasterName string) (int, error) {
+	tx, err := db.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var batch sql.RoastBatch
+	query, args := scopeToOwner(ctx, roastBatchQuery+"\nWHERE id = ?", "owner_id", id)
+	if err := tx.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).StructScan(&batch); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return 0, domainerrors.ErrRoastBatchDoesNotExist
+		}
+		return 0, fmt.Errorf("failed to read record for roast batch id=%d from the database: %w", id, err)
+	}
+	if batch.BeansId != nil {
+		return 0, domainerrors.ErrRoastBatchBeansAlreadyExist
+	}
+
+	var roasterId int
+	query, args = scopeToOwner(ctx, `SELECT id FROM roasters WHERE name = ?`, "owner_id", roasterName)
+	err = tx.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).Scan(&roasterId)
+	if errors.Is(err, dbsql.ErrNoRows) {
+		roasterId, err = db.dialect.InsertID(ctx, tx, db.dialect.Rebind(`INSERT INTO roasters (name, owner_id) VALUES (?, ?)`), &entityRoaster, roasterName, ownerId(ctx))
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get roaster %q: %w", roasterName, err)
+	}
+
+	query = db.dialect.Rebind("INSERT INTO beans (name, roaster_id, roast_date, roast_level, owner_id) VALUES (?, ?, ?, ?, ?)")
+	beansId, err := db.dialect.InsertID(ctx, tx, query, &entityBeans, batch.GreenCoffee, roasterId, batch.RoastDate, batch.RoastLevel, ownerId(ctx))
+	if err != nil {
+		return 0, err
+	}
+
+	// The beans_id IS NULL guard makes a concurrent generation for the same
+	// batch fail instead of leaving orphan beans behind.
+	res, err := tx.ExecContext(ctx, db.dialect.Rebind(`UPDATE roast_batches SET beans_id = ? WHERE id = ? AND beans_id IS NULL`), beansId, id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to link beans id=%d to roast batch id=%d: %w", beansId, id, err)
+	}
+	if row, _ := res.RowsAffected(); row != 1 {
+		return 0, domainerrors.ErrRoastBatchBeansAlreadyExist
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit beans for roast batch id=%d: %w", id, err)
+	}
+	return beansId, nil
+}
+
+func (db *RoastBatch) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
+
+func (db *RoastBatch) insertCurvePoints(ctx context.Context, tx *sqlx.Tx, id int, points []sql.RoastCurvePoint) error {
+	query := db.dialect.Rebind(`INSERT INTO roast_curve_points (roast_batch_id, elapsed_time, temperature) VALUES (?, ?, ?)`)
+	for _, p := range points {
+		if _, err := tx.ExecContext(ctx, query, id, p.ElapsedTime, p.Temperature); err != nil {
+			return db.dialect.ParseError(err, nil, fmt.Errorf("failed to insert curve point for roast batch id=%d: %w", id, err))
+		}
+	}
+	return nil
+}
+
+const roastBatchQuery = `
+SELECT
+	id,
+	green_coffee,
+	roast_date,
+	roast_level,
+	green_weight,
+	roasted_weight,
+	charge_temperature,
+	first_crack_time,
+	development_time,
+	end_temperature,
+	green_coffee_id,
+	beans_id,
+	created_at,
+	updated_at
+FROM roast_batches`
@@ -0,0 +1,61 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type Seed struct {
+	db      *sqlx.DB
+	dialect Dialect
+}
+
+func NewSeed(db *sqlx.DB, dialect Dialect) *Seed {
+	return &Seed{db: db, dialect: dialect}
+}
+
+// ResetSeed deletes every cupping, roast batch, shot, beans, sheet and
+// roaster, of the authenticated user only if any, in a single transaction.
+// Each table is emptied before the tables it references: cupping scores
+// reference beans, and shots their sheet and beans.
+func (db *Seed) ResetSeed(ctx context.Context) (*sql.SeedReset, error) {
+	tx, err := db.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var reset sql.SeedReset
+	for _, table := range []struct {
+		name    string
+		deleted *int
+	}{
+		{"cupping_scores", nil},
+		{"cupping_sessions", &reset.CuppingSessions},
+		{"roast_batches", &reset.RoastBatches},
+		{"shots", &reset.Shots},
+		{"beans", &reset.Beans},
+		{"sheets", &reset.Sheets},
+		{"roasters", &reset.Roasters},
+	} {
+		query, args := scopeToOwner(ctx, "DELETE FROM "+table.name, "owner_id")
+		res, err := tx.ExecContext(ctx, db.dialect.Rebind(query), args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete records from %s: %w", table.name, err)
+		}
+		if table.deleted != nil {
+			rows, _ := res.RowsAffected()
+			*table.deleted = int(rows)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit reset: %w", err)
+	}
+	return &reset, nil
+}
+
+func (db *Seed) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
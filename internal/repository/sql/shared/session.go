@@ -0,0 +1,71 @@
+package shared
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type Session struct {
+	db      *sqlx.DB
+	dialect Dialect
+}
+
+func NewSession(db *sqlx.DB, dialect Dialect) *Session { return &Session{db: db, dialect: dialect} }
+
+func (db *Session) CreateSession(ctx context.Context, session *sql.Session) (int, error) {
+	query := db.dialect.Rebind(`INSERT INTO sessions (user_id, token_hash, csrf_token, expires_at) VALUES (?, ?, ?, ?)`)
+	return db.dialect.InsertID(ctx, db.db, query, &entitySession, session.UserId, session.TokenHash, session.CSRFToken, session.ExpiresAt)
+}
+
+// GetSessionByTokenHash returns the session whose token hashes to tokenHash,
+// expired or not.
+func (db *Session) GetSessionByTokenHash(ctx context.Context, tokenHash string) (*sql.Session, error) {
+	var session sql.Session
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(sessionQuery+"\nWHERE sessions.token_hash = ?"), tokenHash).StructScan(&session); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return nil, domainerrors.ErrSessionDoesNotExist
+		}
+		return nil, fmt.Errorf("failed to read record for session from the database: %w", err)
+	}
+	return &session, nil
+}
+
+// DeleteSessionByTokenHash deletes a session. Deleting a session which does
+// not exist is not an error, as logging out twice is not one either.
+func (db *Session) DeleteSessionByTokenHash(ctx context.Context, tokenHash string) error {
+	if _, err := db.db.ExecContext(ctx, db.dialect.Rebind(`DELETE FROM sessions WHERE token_hash = ?`), tokenHash); err != nil {
+		return fmt.Errorf("failed to delete record for session: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredSessions deletes the sessions expiring before the given time.
+func (db *Session) DeleteExpiredSessions(ctx context.Context, before time.Time) error {
+	if _, err := db.db.ExecContext(ctx, db.dialect.Rebind(`DELETE FROM sessions WHERE expires_at < ?`), before); err != nil {
+		return fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+	return nil
+}
+
+func (db *Session) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
+
+const sessionQuery = `
+SELECT
+	sessions.id,
+	sessions.user_id,
+	sessions.token_hash,
+	sessions.csrf_token,
+	sessions.expires_at,
+	sessions.created_at,
+	users.name AS user_name,
+	users.role AS user_role,
+	users.disabled AS user_disabled
+FROM sessions
+	INNER JOIN users ON sessions.user_id = users.id`
@@ -0,0 +1,86 @@
+package shared
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type ShareLink struct {
+	db      *sqlx.DB
+	dialect Dialect
+}
+
+func NewShareLink(db *sqlx.DB, dialect Dialect) *ShareLink {
+	return &ShareLink{db: db, dialect: dialect}
+}
+
+func (db *ShareLink) CreateShareLink(ctx context.Context, link *sql.ShareLink) (int, error) {
+	if err := checkOwner(ctx, db.db, db.dialect, "sheets", link.SheetId, domainerrors.ErrSheetDoesNotExist); err != nil {
+		return 0, err
+	}
+	query := db.dialect.Rebind(`INSERT INTO share_links (sheet_id, owner_id, expires_at) VALUES (?, ?, ?)`)
+	return db.dialect.InsertID(ctx, db.db, query, &entityShareLink, link.SheetId, ownerId(ctx), link.ExpiresAt)
+}
+
+// GetShareLinkById returns the share link id, revoked or not. Outside of an
+// authenticated request, such as when a shared sheet is viewed, any share link
+// is returned.
+func (db *ShareLink) GetShareLinkById(ctx context.Context, id int) (*sql.ShareLink, error) {
+	var link sql.ShareLink
+	query, args := scopeToOwner(ctx, shareLinkQuery+"\nWHERE id = ?", "owner_id", id)
+	if err := db.db.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).StructScan(&link); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return nil, domainerrors.ErrShareLinkDoesNotExist
+		}
+		return nil, fmt.Errorf("failed to read record for share link id=%d from the database: %w", id, err)
+	}
+	return &link, nil
+}
+
+func (db *ShareLink) GetShareLinksBySheetId(ctx context.Context, sheetId int) ([]sql.ShareLink, error) {
+	links := make([]sql.ShareLink, 0)
+	if err := checkOwner(ctx, db.db, db.dialect, "sheets", sheetId, domainerrors.ErrSheetDoesNotExist); err != nil {
+		return links, err
+	}
+	query := db.dialect.Rebind(shareLinkQuery + "\nWHERE sheet_id = ?\nORDER BY id")
+	if err := db.db.SelectContext(ctx, &links, query, sheetId); err != nil {
+		return links, fmt.Errorf("failed to read records for share links of sheet id=%d: %w", sheetId, err)
+	}
+	return links, nil
+}
+
+// RevokeShareLinkById revokes a share link at revokedAt. Revoking a link which
+// already is revoked keeps its first revocation date.
+func (db *ShareLink) RevokeShareLinkById(ctx context.Context, id int, revokedAt time.Time) error {
+	query, args := scopeToOwner(ctx, `UPDATE share_links SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?`, "owner_id", revokedAt, id)
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
+	if err != nil {
+		return db.dialect.ParseError(err, &entityShareLink, fmt.Errorf("failed to update record for share link id=%d: %w", id, err))
+	}
+	// MySQL does not count the rows an UPDATE leaves unchanged.
+	if row, _ := res.RowsAffected(); row == 0 {
+		if _, err := db.GetShareLinkById(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *ShareLink) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
+
+const shareLinkQuery = `
+SELECT
+	id,
+	sheet_id,
+	owner_id,
+	expires_at,
+	revoked_at,
+	created_at
+FROM share_links`
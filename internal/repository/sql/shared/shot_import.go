@@ -0,0 +1,230 @@
+package shared
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	sqlerrors "github.com/lescactus/espressoapi-go/internal/repository/sql/errors"
+)
+
+// importLookupBatchSize is the number of external ids looked up per query.
+const importLookupBatchSize = 500
+
+type ShotImport struct {
+	db      *sqlx.DB
+	dialect Dialect
+}
+
+func NewShotImport(db *sqlx.DB, dialect Dialect) *ShotImport {
+	return &ShotImport{db: db, dialect: dialect}
+}
+
+// GetImportedShotIds returns the ids of the shots already imported from
+// source, keyed by their external id. External ids never imported are
+// missing from the map.
+func (db *ShotImport) GetImportedShotIds(ctx context.Context, source string, externalIds []string) (map[string]int, error) {
+	return db.importedShotIds(ctx, db.db, source, externalIds)
+}
+
+// ImportShot creates the shot, its profile and the record of its import in a
+// single transaction, along with its sheet, roaster and beans when none of
+// these names exist. It returns ErrImportShotAlreadyExists when the shot was
+// already imported from the same source.
+func (db *ShotImport) ImportShot(ctx context.Context, imported *sql.ImportedShot) (int, error) {
+	tx, err := db.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	existing, err := db.importedShotIds(ctx, tx, imported.Source, []string{imported.ExternalId})
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := existing[imported.ExternalId]; ok {
+		return 0, domainerrors.ErrImportShotAlreadyExists
+	}
+
+	sheetId, _, err := db.getOrCreateByName(ctx, tx, "sheets", &entitySheet, imported.SheetName)
+	if err != nil {
+		return 0, err
+	}
+	roasterId, _, err := db.getOrCreateByName(ctx, tx, "roasters", &entityRoaster, imported.RoasterName)
+	if err != nil {
+		return 0, err
+	}
+
+	beansId, _, err := db.getOrCreateBeans(ctx, tx, &sql.Beans{
+		Name: imported.BeansName, Roaster: &sql.Roaster{Id: roasterId}, RoastDate: imported.RoastDate, RoastLevel: imported.RoastLevel,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	shot := imported.Shot
+	query := db.dialect.Rebind(`INSERT INTO
+	shots (sheet_id, beans_id, grind_setting, quantity_in, quantity_out, shot_time_ms, water_temperature, rating, is_too_bitter, is_too_sour, comparison_with_previous_result, additional_notes, owner_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	shotId, err := db.dialect.InsertID(ctx, tx, query, &entityShot, sheetId, beansId, shot.GrindSetting, shot.QuantityIn, shot.QuantityOut, shot.ShotTime.Milliseconds(), shot.WaterTemperature, shot.Rating, shot.IsTooBitter, shot.IsTooSour, shot.ComparisonWithPreviousResult, shot.AdditionalNotes, ownerId(ctx))
+	if err != nil {
+		return 0, err
+	}
+
+	if err := insertProfileSamples(ctx, tx, db.dialect, shotId, imported.Profile); err != nil {
+		return 0, err
+	}
+
+	query = db.dialect.Rebind(`INSERT INTO shot_imports (shot_id, source, external_id) VALUES (?, ?, ?)`)
+	if _, err := tx.ExecContext(ctx, query, shotId, imported.Source, imported.ExternalId); err != nil {
+		return 0, db.dialect.ParseError(err, nil, fmt.Errorf("failed to record the import of shot id=%d: %w", shotId, err))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit imported shot %s/%s: %w", imported.Source, imported.ExternalId, err)
+	}
+	return shotId, nil
+}
+
+// GetShotExternalIds returns the external ids of the shots imported from
+// source, keyed by the id of the shot.
+func (db *ShotImport) GetShotExternalIds(ctx context.Context, source string) (map[int]string, error) {
+	query, args := scopeToOwner(ctx, `SELECT shot_imports.shot_id, shot_imports.external_id FROM shot_imports
+	JOIN shots ON shots.id = shot_imports.shot_id
+	WHERE shot_imports.source = ?`, "shots.owner_id", source)
+	rows, err := db.db.QueryxContext(ctx, db.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records for imported shots from the database: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make(map[int]string)
+	for rows.Next() {
+		var shotId int
+		var externalId string
+		if err := rows.Scan(&shotId, &externalId); err != nil {
+			return nil, fmt.Errorf("failed to read records for imported shots from the database: %w", err)
+		}
+		ids[shotId] = externalId
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read records for imported shots from the database: %w", err)
+	}
+	return ids, nil
+}
+
+// GetOrCreateSheet returns the id of the sheet with the given name, creating
+// it if missing, and whether it was created.
+func (db *ShotImport) GetOrCreateSheet(ctx context.Context, name string) (int, bool, error) {
+	return db.inTx(ctx, func(tx *sqlx.Tx) (int, bool, error) {
+		return db.getOrCreateByName(ctx, tx, "sheets", &entitySheet, name)
+	})
+}
+
+// GetOrCreateRoaster returns the id of the roaster with the given name,
+// creating it if missing, and whether it was created.
+func (db *ShotImport) GetOrCreateRoaster(ctx context.Context, name string) (int, bool, error) {
+	return db.inTx(ctx, func(tx *sqlx.Tx) (int, bool, error) {
+		return db.getOrCreateByName(ctx, tx, "roasters", &entityRoaster, name)
+	})
+}
+
+// GetOrCreateBeans returns the id of the beans with the name of beans from
+// its roaster, creating them if missing, and whether they were created.
+func (db *ShotImport) GetOrCreateBeans(ctx context.Context, beans *sql.Beans) (int, bool, error) {
+	return db.inTx(ctx, func(tx *sqlx.Tx) (int, bool, error) {
+		return db.getOrCreateBeans(ctx, tx, beans)
+	})
+}
+
+func (db *ShotImport) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
+
+// inTx runs getOrCreate in a transaction, committed when it succeeds.
+func (db *ShotImport) inTx(ctx context.Context, getOrCreate func(tx *sqlx.Tx) (int, bool, error)) (int, bool, error) {
+	tx, err := db.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	id, created, err := getOrCreate(tx)
+	if err != nil {
+		return 0, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return id, created, nil
+}
+
+func (db *ShotImport) importedShotIds(ctx context.Context, q sqlx.QueryerContext, source string, externalIds []string) (map[string]int, error) {
+	ids := make(map[string]int, len(externalIds))
+	for start := 0; start < len(externalIds); start += importLookupBatchSize {
+		batch := externalIds[start:min(start+importLookupBatchSize, len(externalIds))]
+		query, args := scopeToOwner(ctx, `SELECT shot_imports.external_id, shot_imports.shot_id FROM shot_imports
+	JOIN shots ON shots.id = shot_imports.shot_id
+	WHERE shot_imports.source = ? AND shot_imports.external_id IN (?)`, "shots.owner_id", source, batch)
+		query, args, err := sqlx.In(query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build the query of imported shots: %w", err)
+		}
+		rows, err := q.QueryxContext(ctx, db.dialect.Rebind(query), args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read records for imported shots from the database: %w", err)
+		}
+		for rows.Next() {
+			var externalId string
+			var shotId int
+			if err := rows.Scan(&externalId, &shotId); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to read records for imported shots from the database: %w", err)
+			}
+			ids[externalId] = shotId
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read records for imported shots from the database: %w", err)
+		}
+	}
+	return ids, nil
+}
+
+// getOrCreateByName returns the id of the row of table, a table of named
+// records such as sheets or roasters, with the given name, inserting it if
+// missing, and whether it was inserted.
+func (db *ShotImport) getOrCreateByName(ctx context.Context, tx *sqlx.Tx, table string, entity *sqlerrors.Entity, name string) (int, bool, error) {
+	var id int
+	query, args := scopeToOwner(ctx, `SELECT id FROM `+table+` WHERE name = ?`, "owner_id", name)
+	err := tx.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).Scan(&id)
+	if errors.Is(err, dbsql.ErrNoRows) {
+		id, err = db.dialect.InsertID(ctx, tx, db.dialect.Rebind(`INSERT INTO `+table+` (name, owner_id) VALUES (?, ?)`), entity, name, ownerId(ctx))
+		return id, err == nil, err
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read record for %s name=%q from the database: %w", table, name, err)
+	}
+	return id, false, nil
+}
+
+// getOrCreateBeans returns the id of the beans with the name of beans from
+// the roaster of beans, inserting them if missing, and whether they were
+// inserted.
+func (db *ShotImport) getOrCreateBeans(ctx context.Context, tx *sqlx.Tx, beans *sql.Beans) (int, bool, error) {
+	var id int
+	query, args := scopeToOwner(ctx, `SELECT id FROM beans WHERE name = ? AND roaster_id = ?`, "owner_id", beans.Name, beans.Roaster.Id)
+	err := tx.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).Scan(&id)
+	if errors.Is(err, dbsql.ErrNoRows) {
+		query = db.dialect.Rebind("INSERT INTO beans (name, roaster_id, roast_date, roast_level, price, currency, bag_weight, owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?)")
+		id, err = db.dialect.InsertID(ctx, tx, query, &entityBeans, beans.Name, beans.Roaster.Id, beans.RoastDate, beans.RoastLevel, beans.Price, beans.Currency, beans.BagWeight, ownerId(ctx))
+		return id, err == nil, err
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read record for beans name=%q from the database: %w", beans.Name, err)
+	}
+	return id, false, nil
+}
@@ -0,0 +1,89 @@
+package shared
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+// profileInsertBatchSize is the number of samples inserted per statement, so
+// a profile of a few thousand samples takes a handful of round trips while
+// staying well below the placeholder limits of both databases.
+const profileInsertBatchSize = 500
+
+// GetShotProfileById returns the profile samples of the shot, ordered by
+// elapsed time. A shot without a profile has no samples.
+func (db *Shot) GetShotProfileById(ctx context.Context, id int) ([]sql.ShotProfileSample, error) {
+	samples := make([]sql.ShotProfileSample, 0)
+	if err := db.checkExists(ctx, db.db, id); err != nil {
+		return samples, err
+	}
+	query := db.dialect.Rebind(`SELECT shot_id, elapsed_time, pressure, flow, weight, temperature FROM shot_profile_samples WHERE shot_id = ? ORDER BY elapsed_time`)
+	if err := db.db.SelectContext(ctx, &samples, query, id); err != nil {
+		return samples, fmt.Errorf("failed to read profile samples for shot id=%d from the database: %w", id, err)
+	}
+	return samples, nil
+}
+
+// UpdateShotProfileById replaces the profile samples of the shot in a single
+// transaction. Without samples, the profile of the shot is removed.
+func (db *Shot) UpdateShotProfileById(ctx context.Context, id int, samples []sql.ShotProfileSample) error {
+	tx, err := db.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := db.checkExists(ctx, tx, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, db.dialect.Rebind(`DELETE FROM shot_profile_samples WHERE shot_id = ?`), id); err != nil {
+		return fmt.Errorf("failed to delete profile samples for shot id=%d: %w", id, err)
+	}
+	if err := insertProfileSamples(ctx, tx, db.dialect, id, samples); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit profile of shot id=%d: %w", id, err)
+	}
+	return nil
+}
+
+// insertProfileSamples inserts the profile samples of the shot id, in batches
+// of profileInsertBatchSize.
+func insertProfileSamples(ctx context.Context, tx *sqlx.Tx, dialect Dialect, id int, samples []sql.ShotProfileSample) error {
+	for start := 0; start < len(samples); start += profileInsertBatchSize {
+		batch := samples[start:min(start+profileInsertBatchSize, len(samples))]
+		query := `INSERT INTO shot_profile_samples (shot_id, elapsed_time, pressure, flow, weight, temperature) VALUES ` +
+			strings.Repeat("(?, ?, ?, ?, ?, ?), ", len(batch)-1) + "(?, ?, ?, ?, ?, ?)"
+		args := make([]any, 0, len(batch)*6)
+		for _, s := range batch {
+			args = append(args, id, s.ElapsedTime, s.Pressure, s.Flow, s.Weight, s.Temperature)
+		}
+		if _, err := tx.ExecContext(ctx, dialect.Rebind(query), args...); err != nil {
+			return dialect.ParseError(err, nil, fmt.Errorf("failed to insert profile samples for shot id=%d: %w", id, err))
+		}
+	}
+	return nil
+}
+
+// checkExists returns ErrShotDoesNotExist unless the shot id exists and, in an
+// authenticated request, belongs to the user.
+func (db *Shot) checkExists(ctx context.Context, q sqlx.QueryerContext, id int) error {
+	query, args := scopeToOwner(ctx, `SELECT id FROM shots WHERE id = ?`, "owner_id", id)
+	var existing int
+	if err := q.QueryRowxContext(ctx, db.dialect.Rebind(query), args...).Scan(&existing); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return domainerrors.ErrShotDoesNotExist
+		}
+		return fmt.Errorf("failed to read record for shot id=%d from the database: %w", id, err)
+	}
+	return nil
+}
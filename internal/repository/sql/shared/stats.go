@@ -0,0 +1,149 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+// shotStatsFilters are the conditions selecting the shots of each subject of
+// ShotStats, on its single placeholder, and the error returned when the
+// subject does not exist.
+var shotStatsFilters = map[sql.ShotStatsSubject]struct {
+	condition string
+	notFound  error
+}{
+	sql.ShotStatsOfBeans:   {"shots.beans_id = ?", domainerrors.ErrBeansDoesNotExist},
+	sql.ShotStatsOfRoaster: {"shots.beans_id IN (SELECT beans.id FROM beans WHERE beans.roaster_id = ?)", domainerrors.ErrRoasterDoesNotExist},
+	sql.ShotStatsOfSheet:   {"shots.sheet_id = ?", domainerrors.ErrSheetDoesNotExist},
+}
+
+type Stats struct {
+	db      *sqlx.DB
+	dialect Dialect
+}
+
+func NewStats(db *sqlx.DB, dialect Dialect) *Stats {
+	return &Stats{db: db, dialect: dialect}
+}
+
+// GetDailyConsumption returns, for each day with shots pulled in [from, to),
+// the number of shots, the coffee used and the average rating, oldest day
+// first, counting only the shots of the authenticated user if any. Days are
+// calendar days in timeZone, an IANA time zone name. Unrated shots, with a
+// rating of 0, are left out of the average, which is 0 on days without a
+// rated shot.
+func (db *Stats) GetDailyConsumption(ctx context.Context, from, to time.Time, timeZone string) ([]sql.DailyConsumption, error) {
+	query, args := scopeToOwner(ctx, `
+SELECT
+	`+db.dialect.LocalDate("shots.created_at")+` AS shot_date,
+	COUNT(*) AS shots,
+	SUM(shots.quantity_in) AS coffee_weight,
+	COUNT(NULLIF(shots.rating, 0)) AS rated_shots,
+	COALESCE(AVG(NULLIF(shots.rating, 0)), 0) AS average_rating
+FROM shots
+WHERE shots.created_at >= ?
+	AND shots.created_at < ?`, "shots.owner_id", timeZone, from, to)
+	query += "\nGROUP BY shot_date\nORDER BY shot_date"
+
+	// The day is NULL when the database does not know timeZone, which it
+	// would otherwise silently group every shot under.
+	var rows []struct {
+		Day           *time.Time `db:"shot_date"`
+		Shots         int        `db:"shots"`
+		CoffeeWeight  float64    `db:"coffee_weight"`
+		RatedShots    int        `db:"rated_shots"`
+		AverageRating float64    `db:"average_rating"`
+	}
+	days := make([]sql.DailyConsumption, 0)
+	if err := db.db.SelectContext(ctx, &rows, db.dialect.Rebind(query), args...); err != nil {
+		return days, fmt.Errorf("failed to read daily consumption: %w", err)
+	}
+	for _, row := range rows {
+		if row.Day == nil {
+			return days, fmt.Errorf("failed to read daily consumption in %s: %w", timeZone, domainerrors.ErrStatsTimeZoneIsUnknown)
+		}
+		days = append(days, sql.DailyConsumption{Day: *row.Day, Shots: row.Shots, CoffeeWeight: row.CoffeeWeight, RatedShots: row.RatedShots, AverageRating: row.AverageRating})
+	}
+	return days, nil
+}
+
+// GetShotStats aggregates the shots of the subject id, counting only the
+// shots of the authenticated user if any. It returns the not found error of
+// the subject when it does not exist or is not the user's. The ratings only
+// account for the rated shots, with a rating above 0: the median rating is
+// read at the middle of their ordered ratings, and the best shot is the
+// latest of the best rated ones.
+func (db *Stats) GetShotStats(ctx context.Context, subject sql.ShotStatsSubject, id int) (*sql.ShotStats, error) {
+	filter, ok := shotStatsFilters[subject]
+	if !ok {
+		return nil, fmt.Errorf("unsupported shot stats subject %q", subject)
+	}
+
+	var count int
+	query, args := scopeToOwner(ctx, "SELECT COUNT(*) FROM "+string(subject)+" WHERE id = ?", "owner_id", id)
+	if err := db.db.GetContext(ctx, &count, db.dialect.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("failed to read %s id=%d: %w", subject, id, err)
+	}
+	if count == 0 {
+		return nil, filter.notFound
+	}
+
+	var stats sql.ShotStats
+	query, args = scopeToOwner(ctx, `
+SELECT
+	COUNT(*) AS shots,
+	COUNT(NULLIF(shots.rating, 0)) AS rated_shots,
+	AVG(NULLIF(shots.rating, 0)) AS average_rating,
+	MAX(NULLIF(shots.rating, 0)) AS max_rating,
+	MIN(shots.grind_setting) AS min_grind_setting,
+	MAX(shots.grind_setting) AS max_grind_setting,
+	AVG(shots.quantity_out / NULLIF(shots.quantity_in, 0)) AS average_ratio,
+	AVG(shots.shot_time_ms) AS average_shot_time_ms,
+	AVG(CASE WHEN shots.is_too_bitter THEN 1.0 ELSE 0.0 END) AS bitter_share,
+	AVG(CASE WHEN shots.is_too_sour THEN 1.0 ELSE 0.0 END) AS sour_share
+FROM shots
+WHERE `+filter.condition, "shots.owner_id", id)
+	if err := db.db.GetContext(ctx, &stats, db.dialect.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("failed to read shot stats of %s id=%d: %w", subject, id, err)
+	}
+	if stats.RatedShots == 0 {
+		return &stats, nil
+	}
+
+	// The middle rating, or the two middle ones with an even count.
+	limit, offset := 1, (stats.RatedShots-1)/2
+	if stats.RatedShots%2 == 0 {
+		limit = 2
+	}
+	var ratings []float64
+	query, args = scopeToOwner(ctx, "SELECT shots.rating FROM shots WHERE "+filter.condition+" AND shots.rating > 0", "shots.owner_id", id)
+	query += "\nORDER BY shots.rating\nLIMIT ? OFFSET ?"
+	if err := db.db.SelectContext(ctx, &ratings, db.dialect.Rebind(query), append(args, limit, offset)...); err != nil {
+		return nil, fmt.Errorf("failed to read median rating of %s id=%d: %w", subject, id, err)
+	}
+	if len(ratings) > 0 {
+		var sum float64
+		for _, rating := range ratings {
+			sum += rating
+		}
+		median := sum / float64(len(ratings))
+		stats.MedianRating = &median
+	}
+
+	var bestShotId int
+	query, args = scopeToOwner(ctx, "SELECT shots.id FROM shots WHERE "+filter.condition+" AND shots.rating > 0", "shots.owner_id", id)
+	query += "\nORDER BY shots.rating DESC, shots.id DESC\nLIMIT 1"
+	if err := db.db.GetContext(ctx, &bestShotId, db.dialect.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("failed to read best shot of %s id=%d: %w", subject, id, err)
+	}
+	stats.BestShotId = &bestShotId
+
+	return &stats, nil
+}
+
+func (db *Stats) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
@@ -0,0 +1,163 @@
+package shared
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type User struct {
+	db      *sqlx.DB
+	dialect Dialect
+}
+
+func NewUser(db *sqlx.DB, dialect Dialect) *User { return &User{db: db, dialect: dialect} }
+
+func (db *User) CreateUser(ctx context.Context, user *sql.User) (int, error) {
+	query := db.dialect.Rebind(`INSERT INTO users (name, role) VALUES (?, ?)`)
+	return db.dialect.InsertID(ctx, db.db, query, &entityUser, user.Name, user.Role)
+}
+
+func (db *User) GetUserById(ctx context.Context, id int) (*sql.User, error) {
+	var user sql.User
+	query := db.dialect.Rebind("SELECT id, name, disabled, role, created_at, updated_at FROM users WHERE id = ?")
+	if err := db.db.QueryRowxContext(ctx, query, id).StructScan(&user); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return nil, domainerrors.ErrUserDoesNotExist
+		}
+		return nil, fmt.Errorf("failed to read record for user id=%d from the database: %w", id, err)
+	}
+	return &user, nil
+}
+
+func (db *User) GetUserByName(ctx context.Context, name string) (*sql.User, error) {
+	var user sql.User
+	query := db.dialect.Rebind("SELECT id, name, disabled, role, created_at, updated_at FROM users WHERE name = ?")
+	if err := db.db.QueryRowxContext(ctx, query, name).StructScan(&user); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return nil, domainerrors.ErrUserDoesNotExist
+		}
+		return nil, fmt.Errorf("failed to read record for user name=\"%s\" from the database: %w", name, err)
+	}
+	return &user, nil
+}
+
+func (db *User) GetAllUsers(ctx context.Context) ([]sql.User, error) {
+	users := make([]sql.User, 0)
+	query := db.dialect.Rebind("SELECT id, name, disabled, role, created_at, updated_at FROM users ORDER BY name")
+	if err := db.db.SelectContext(ctx, &users, query); err != nil {
+		return users, fmt.Errorf("failed to read records for users: %w", err)
+	}
+	return users, nil
+}
+
+// DisableUserByName disables a user. Disabling a user who already is
+// disabled is not an error.
+func (db *User) DisableUserByName(ctx context.Context, name string) error {
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(`UPDATE users SET disabled = ? WHERE name = ?`), true, name)
+	if err != nil {
+		return db.dialect.ParseError(err, &entityUser, fmt.Errorf("failed to update record for user name=\"%s\": %w", name, err))
+	}
+	// MySQL does not count the rows an UPDATE leaves unchanged.
+	if row, _ := res.RowsAffected(); row == 0 {
+		if _, err := db.GetUserByName(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetUserRoleByName sets the role of a user.
+func (db *User) SetUserRoleByName(ctx context.Context, name, role string) error {
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(`UPDATE users SET role = ? WHERE name = ?`), role, name)
+	if err != nil {
+		return db.dialect.ParseError(err, &entityUser, fmt.Errorf("failed to update record for user name=\"%s\": %w", name, err))
+	}
+	// MySQL does not count the rows an UPDATE leaves unchanged.
+	if row, _ := res.RowsAffected(); row == 0 {
+		if _, err := db.GetUserByName(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetUserPasswordHashByName sets the password hash of a user.
+func (db *User) SetUserPasswordHashByName(ctx context.Context, name, hash string) error {
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(`UPDATE users SET password_hash = ? WHERE name = ?`), hash, name)
+	if err != nil {
+		return db.dialect.ParseError(err, &entityUser, fmt.Errorf("failed to update record for user name=\"%s\": %w", name, err))
+	}
+	// MySQL does not count the rows an UPDATE leaves unchanged.
+	if row, _ := res.RowsAffected(); row == 0 {
+		if _, err := db.GetUserByName(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetUserPasswordHashByName returns the password hash of a user, or an empty
+// string when the user has no password.
+func (db *User) GetUserPasswordHashByName(ctx context.Context, name string) (string, error) {
+	var hash dbsql.NullString
+	query := db.dialect.Rebind("SELECT password_hash FROM users WHERE name = ?")
+	if err := db.db.QueryRowxContext(ctx, query, name).Scan(&hash); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return "", domainerrors.ErrUserDoesNotExist
+		}
+		return "", fmt.Errorf("failed to read password of user name=\"%s\" from the database: %w", name, err)
+	}
+	return hash.String, nil
+}
+
+// GetUserByOIDCSubject returns the user linked to the subject of an OpenID
+// Connect identity.
+func (db *User) GetUserByOIDCSubject(ctx context.Context, subject string) (*sql.User, error) {
+	var user sql.User
+	query := db.dialect.Rebind("SELECT id, name, disabled, role, created_at, updated_at FROM users WHERE oidc_subject = ?")
+	if err := db.db.QueryRowxContext(ctx, query, subject).StructScan(&user); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return nil, domainerrors.ErrUserDoesNotExist
+		}
+		return nil, fmt.Errorf("failed to read record for user oidc subject=\"%s\" from the database: %w", subject, err)
+	}
+	return &user, nil
+}
+
+// SetUserOIDCSubjectByName links a user to the subject of an OpenID Connect
+// identity.
+func (db *User) SetUserOIDCSubjectByName(ctx context.Context, name, subject string) error {
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(`UPDATE users SET oidc_subject = ? WHERE name = ?`), subject, name)
+	if err != nil {
+		return db.dialect.ParseError(err, &entityUser, fmt.Errorf("failed to update record for user name=\"%s\": %w", name, err))
+	}
+	// MySQL does not count the rows an UPDATE leaves unchanged.
+	if row, _ := res.RowsAffected(); row == 0 {
+		if _, err := db.GetUserByName(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetUserOIDCSubjectByName returns the subject of the OpenID Connect identity
+// linked to a user, or an empty string when the user is not linked to any.
+func (db *User) GetUserOIDCSubjectByName(ctx context.Context, name string) (string, error) {
+	var subject dbsql.NullString
+	query := db.dialect.Rebind("SELECT oidc_subject FROM users WHERE name = ?")
+	if err := db.db.QueryRowxContext(ctx, query, name).Scan(&subject); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return "", domainerrors.ErrUserDoesNotExist
+		}
+		return "", fmt.Errorf("failed to read oidc subject of user name=\"%s\" from the database: %w", name, err)
+	}
+	return subject.String, nil
+}
+
+func (db *User) Ping(ctx context.Context) error { return db.db.PingContext(ctx) }
@@ -0,0 +1,378 @@
+// Package transfer copies the records of an espressoapi-go database into
+// another one, which may be of another type, keeping their ids.
+package transfer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/shared"
+)
+
+// DefaultBatchSize is the number of rows read and inserted at once.
+const DefaultBatchSize = 500
+
+// Table is a table copied by a transfer.
+type Table struct {
+	Name string
+	// Key are the columns of the primary key, which order the rows.
+	Key []string
+	// Serial is set when the ids of the table come from a sequence.
+	Serial bool
+}
+
+// Tables are the tables copied by a transfer, each after the tables it
+// references.
+var Tables = []Table{
+	{Name: "users", Key: []string{"id"}, Serial: true},
+	{Name: "api_keys", Key: []string{"id"}, Serial: true},
+	{Name: "sessions", Key: []string{"id"}, Serial: true},
+	{Name: "roasters", Key: []string{"id"}, Serial: true},
+	{Name: "sheets", Key: []string{"id"}, Serial: true},
+	{Name: "green_coffees", Key: []string{"id"}, Serial: true},
+	{Name: "beans", Key: []string{"id"}, Serial: true},
+	{Name: "shots", Key: []string{"id"}, Serial: true},
+	{Name: "shot_profile_samples", Key: []string{"shot_id", "elapsed_time"}},
+	{Name: "shot_imports", Key: []string{"shot_id"}},
+	{Name: "share_links", Key: []string{"id"}, Serial: true},
+	{Name: "attachments", Key: []string{"id"}, Serial: true},
+	{Name: "cupping_sessions", Key: []string{"id"}, Serial: true},
+	{Name: "cupping_scores", Key: []string{"id"}, Serial: true},
+	{Name: "roast_batches", Key: []string{"id"}, Serial: true},
+	{Name: "roast_curve_points", Key: []string{"roast_batch_id", "elapsed_time"}},
+	{Name: "maintenance_tasks", Key: []string{"id"}, Serial: true},
+}
+
+// Database is one end of a transfer.
+type Database struct {
+	DB      *sqlx.DB
+	Dialect shared.Dialect
+}
+
+// TableResult is the outcome of the transfer of a table: its number of rows
+// and the checksums of its rows on both databases.
+type TableResult struct {
+	Table          string
+	SourceRows     int
+	TargetRows     int
+	SourceChecksum string
+	TargetChecksum string
+}
+
+// Matches tells whether the table holds the same rows on both databases.
+func (r TableResult) Matches() bool {
+	return r.SourceRows == r.TargetRows && r.SourceChecksum == r.TargetChecksum
+}
+
+type Transfer struct {
+	from      Database
+	to        Database
+	tables    []Table
+	batchSize int
+}
+
+// New returns a Transfer copying the tables of from into to, batchSize rows
+// at a time. DefaultBatchSize is used when batchSize is not positive.
+func New(from, to Database, batchSize int) *Transfer {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &Transfer{from: from, to: to, tables: Tables, batchSize: batchSize}
+}
+
+// Copy copies every table into the target database in a single transaction,
+// keeping the ids of the rows, then moves the id sequences of the target
+// past them. The tables of the target must be empty and
+// ErrTransferTargetNotEmpty is returned otherwise. It returns the number of
+// rows copied by table.
+func (t *Transfer) Copy(ctx context.Context) (map[string]int, error) {
+	tx, err := t.to.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, table := range t.tables {
+		var count int
+		if err := tx.GetContext(ctx, &count, "SELECT COUNT(*) FROM "+table.Name); err != nil {
+			return nil, fmt.Errorf("failed to count records for %s: %w", table.Name, err)
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("%w: %s has %d rows", domainerrors.ErrTransferTargetNotEmpty, table.Name, count)
+		}
+	}
+
+	copied := make(map[string]int, len(t.tables))
+	for _, table := range t.tables {
+		columns, targetTypes, err := t.columns(ctx, table, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = t.batches(ctx, t.from, table, columns, func(rows [][]any, _ []string) error {
+			query, args := insertBatch(table.Name, columns, targetTypes, rows)
+			if _, err := tx.ExecContext(ctx, t.to.Dialect.Rebind(query), args...); err != nil {
+				return fmt.Errorf("failed to insert records into %s: %w", table.Name, err)
+			}
+			copied[table.Name] += len(rows)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if table.Serial {
+			if err := t.to.Dialect.ResetSequence(ctx, tx, table.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return copied, nil
+}
+
+// Verify counts the rows of every table on both databases and computes their
+// checksums. The checksums do not depend on the type of the database: values
+// are compared as the application reads them, timestamps to the second.
+func (t *Transfer) Verify(ctx context.Context) ([]TableResult, error) {
+	results := make([]TableResult, 0, len(t.tables))
+	for _, table := range t.tables {
+		columns, _, err := t.columns(ctx, table, t.to.DB)
+		if err != nil {
+			return nil, err
+		}
+
+		result := TableResult{Table: table.Name}
+		result.SourceRows, result.SourceChecksum, err = t.checksum(ctx, t.from, table, columns)
+		if err != nil {
+			return nil, err
+		}
+		result.TargetRows, result.TargetChecksum, err = t.checksum(ctx, t.to, table, columns)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// columns returns the columns of table on the source database, and their
+// types on the target database, after checking target has the same ones.
+func (t *Transfer) columns(ctx context.Context, table Table, target sqlx.QueryerContext) ([]string, []string, error) {
+	columns, _, err := describe(ctx, t.from.DB, table.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	targetColumns, targetTypes, err := describe(ctx, target, table.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	types := make([]string, len(columns))
+	for i, column := range columns {
+		j := slices.Index(targetColumns, column)
+		if j < 0 {
+			return nil, nil, fmt.Errorf("%w: %s.%s is missing from the target", domainerrors.ErrTransferSchemaMismatch, table.Name, column)
+		}
+		types[i] = targetTypes[j]
+	}
+	for _, column := range targetColumns {
+		if !slices.Contains(columns, column) {
+			return nil, nil, fmt.Errorf("%w: %s.%s is missing from the source", domainerrors.ErrTransferSchemaMismatch, table.Name, column)
+		}
+	}
+	return columns, types, nil
+}
+
+// batches reads the rows of table from db ordered by its key, batchSize rows
+// at a time, and calls fn with each batch and the database types of columns.
+func (t *Transfer) batches(ctx context.Context, db Database, table Table, columns []string, fn func(rows [][]any, types []string) error) error {
+	query := db.Dialect.Rebind(fmt.Sprintf("SELECT %s FROM %s ORDER BY %s LIMIT ? OFFSET ?",
+		strings.Join(columns, ", "), table.Name, strings.Join(table.Key, ", ")))
+
+	for offset := 0; ; offset += t.batchSize {
+		rows, types, err := queryBatch(ctx, db.DB, query, t.batchSize, offset)
+		if err != nil {
+			return fmt.Errorf("failed to read records from %s: %w", table.Name, err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := fn(rows, types); err != nil {
+			return err
+		}
+		if len(rows) < t.batchSize {
+			return nil
+		}
+	}
+}
+
+func (t *Transfer) checksum(ctx context.Context, db Database, table Table, columns []string) (int, string, error) {
+	h := sha256.New()
+	count := 0
+	err := t.batches(ctx, db, table, columns, func(rows [][]any, types []string) error {
+		for _, row := range rows {
+			writeRow(h, row, types)
+		}
+		count += len(rows)
+		return nil
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	return count, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func queryBatch(ctx context.Context, db *sqlx.DB, query string, limit, offset int) ([][]any, []string, error) {
+	rows, err := db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, nil, err
+	}
+	types := make([]string, len(columnTypes))
+	for i, columnType := range columnTypes {
+		types[i] = columnType.DatabaseTypeName()
+	}
+
+	var batch [][]any
+	for rows.Next() {
+		row := make([]any, len(types))
+		dest := make([]any, len(types))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, nil, err
+		}
+		batch = append(batch, row)
+	}
+	return batch, types, rows.Err()
+}
+
+// describe returns the names and database types of the columns of table.
+func describe(ctx context.Context, db sqlx.QueryerContext, table string) ([]string, []string, error) {
+	rows, err := db.QueryxContext(ctx, "SELECT * FROM "+table+" WHERE 1 = 0")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the columns of %s: %w", table, err)
+	}
+	names := make([]string, len(columnTypes))
+	types := make([]string, len(columnTypes))
+	for i, columnType := range columnTypes {
+		names[i], types[i] = columnType.Name(), columnType.DatabaseTypeName()
+	}
+	return names, types, nil
+}
+
+// insertBatch returns the INSERT of rows into table, with its arguments
+// converted to the types of the target columns.
+func insertBatch(table string, columns, types []string, rows [][]any) (string, []any) {
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+	values := make([]string, len(rows))
+	args := make([]any, 0, len(rows)*len(columns))
+	for i, row := range rows {
+		values[i] = placeholders
+		for j, value := range row {
+			args = append(args, convert(value, types[j]))
+		}
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", table, strings.Join(columns, ", "), strings.Join(values, ", ")), args
+}
+
+// convert returns value, read from the source database, as the column of
+// type databaseType of the target database expects it. MySQL returns the
+// text columns as bytes, and stores booleans as TINYINT.
+func convert(value any, databaseType string) any {
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+	if databaseType != "BOOL" {
+		return value
+	}
+	switch v := value.(type) {
+	case int64:
+		return v != 0
+	case string:
+		return v != "0" && v != "f" && v != "false"
+	}
+	return value
+}
+
+// writeRow writes row to h in a form which does not depend on the type of
+// the database it was read from.
+func writeRow(h hash.Hash, row []any, types []string) {
+	for i, value := range row {
+		if i > 0 {
+			h.Write([]byte{0x1f})
+		}
+		h.Write([]byte(canonical(value, types[i])))
+	}
+	h.Write([]byte{0x1e})
+}
+
+// canonical returns value, of the column of type databaseType, as text which
+// is the same for MySQL and PostgreSQL: booleans as 0 or 1, numbers in their
+// shortest form, dates without a time and timestamps in UTC to the second,
+// which is the precision of MySQL.
+func canonical(value any, databaseType string) string {
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+	switch v := value.(type) {
+	case nil:
+		return "\x00"
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case time.Time:
+		if databaseType == "DATE" {
+			return v.Format(time.DateOnly)
+		}
+		return v.UTC().Round(time.Second).Format(time.RFC3339)
+	case string:
+		switch databaseType {
+		case "BOOL":
+			if v == "t" || v == "true" {
+				return "1"
+			}
+			if v == "f" || v == "false" {
+				return "0"
+			}
+		case "DECIMAL", "NUMERIC", "DOUBLE", "FLOAT", "FLOAT4", "FLOAT8":
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return strconv.FormatFloat(f, 'g', -1, 64)
+			}
+		}
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
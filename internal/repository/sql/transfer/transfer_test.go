@@ -0,0 +1,235 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/repository/sql/adapters"
+)
+
+var testTables = []Table{
+	{Name: "sheets", Key: []string{"id"}, Serial: true},
+	{Name: "shot_imports", Key: []string{"shot_id"}},
+}
+
+func newTestTransfer(t *testing.T, batchSize int) (*Transfer, sqlmock.Sqlmock, sqlmock.Sqlmock) {
+	t.Helper()
+	fromDB, from, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	toDB, to, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() {
+		fromDB.Close()
+		toDB.Close()
+	})
+
+	transfer := New(
+		Database{DB: sqlx.NewDb(fromDB, "mysql"), Dialect: adapters.MySQL()},
+		Database{DB: sqlx.NewDb(toDB, "pgx"), Dialect: adapters.PostgreSQL()},
+		batchSize,
+	)
+	transfer.tables = testTables
+	return transfer, from, to
+}
+
+// mysqlSheets and postgresSheets return the rows of the sheets table as both
+// drivers read them.
+func mysqlSheets() *sqlmock.Rows {
+	return sqlmock.NewRowsWithColumnDefinition(
+		sqlmock.NewColumn("id").OfType("INT", int64(0)),
+		sqlmock.NewColumn("name").OfType("VARCHAR", ""),
+		sqlmock.NewColumn("is_public").OfType("TINYINT", int64(0)),
+		sqlmock.NewColumn("created_at").OfType("TIMESTAMP", time.Time{}),
+	)
+}
+
+func postgresSheets() *sqlmock.Rows {
+	return sqlmock.NewRowsWithColumnDefinition(
+		sqlmock.NewColumn("id").OfType("INT4", int64(0)),
+		sqlmock.NewColumn("name").OfType("VARCHAR", ""),
+		sqlmock.NewColumn("is_public").OfType("BOOL", false),
+		sqlmock.NewColumn("created_at").OfType("TIMESTAMPTZ", time.Time{}),
+	)
+}
+
+func shotImports() *sqlmock.Rows {
+	return sqlmock.NewRowsWithColumnDefinition(
+		sqlmock.NewColumn("shot_id").OfType("INT", int64(0)),
+		sqlmock.NewColumn("source").OfType("VARCHAR", ""),
+	)
+}
+
+func TestCopy(t *testing.T) {
+	created := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	transfer, from, to := newTestTransfer(t, 2)
+
+	to.ExpectBegin()
+	to.ExpectQuery("SELECT COUNT(*) FROM sheets").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	to.ExpectQuery("SELECT COUNT(*) FROM shot_imports").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	from.ExpectQuery("SELECT * FROM sheets WHERE 1 = 0").WillReturnRows(mysqlSheets())
+	to.ExpectQuery("SELECT * FROM sheets WHERE 1 = 0").WillReturnRows(postgresSheets())
+	from.ExpectQuery("SELECT id, name, is_public, created_at FROM sheets ORDER BY id LIMIT ? OFFSET ?").WithArgs(2, 0).
+		WillReturnRows(mysqlSheets().AddRow(1, []byte("Gaggia"), 1, created).AddRow(3, []byte("Linea Mini"), 0, created))
+	to.ExpectExec("INSERT INTO sheets (id, name, is_public, created_at) VALUES ($1, $2, $3, $4), ($5, $6, $7, $8)").
+		WithArgs(1, "Gaggia", true, created, 3, "Linea Mini", false, created).WillReturnResult(sqlmock.NewResult(0, 2))
+	from.ExpectQuery("SELECT id, name, is_public, created_at FROM sheets ORDER BY id LIMIT ? OFFSET ?").WithArgs(2, 2).
+		WillReturnRows(mysqlSheets().AddRow(4, []byte("Silvia"), 0, created))
+	to.ExpectExec("INSERT INTO sheets (id, name, is_public, created_at) VALUES ($1, $2, $3, $4)").
+		WithArgs(4, "Silvia", false, created).WillReturnResult(sqlmock.NewResult(0, 1))
+	to.ExpectExec("SELECT setval(pg_get_serial_sequence('sheets', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM sheets").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	from.ExpectQuery("SELECT * FROM shot_imports WHERE 1 = 0").WillReturnRows(shotImports())
+	to.ExpectQuery("SELECT * FROM shot_imports WHERE 1 = 0").WillReturnRows(shotImports())
+	from.ExpectQuery("SELECT shot_id, source FROM shot_imports ORDER BY shot_id LIMIT ? OFFSET ?").WithArgs(2, 0).
+		WillReturnRows(shotImports())
+	to.ExpectCommit()
+
+	copied, err := transfer.Copy(context.Background())
+	if err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+	if copied["sheets"] != 3 || copied["shot_imports"] != 0 {
+		t.Errorf("Copy() = %v, want 3 sheets and no shot imports", copied)
+	}
+	if err := from.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations on the source: %s", err)
+	}
+	if err := to.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations on the target: %s", err)
+	}
+}
+
+func TestCopyTargetNotEmpty(t *testing.T) {
+	transfer, _, to := newTestTransfer(t, 2)
+
+	to.ExpectBegin()
+	to.ExpectQuery("SELECT COUNT(*) FROM sheets").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	to.ExpectRollback()
+
+	_, err := transfer.Copy(context.Background())
+	if !errors.Is(err, domainerrors.ErrTransferTargetNotEmpty) {
+		t.Fatalf("Copy() error = %v, want %v", err, domainerrors.ErrTransferTargetNotEmpty)
+	}
+	if err := to.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations on the target: %s", err)
+	}
+}
+
+func TestCopySchemaMismatch(t *testing.T) {
+	transfer, from, to := newTestTransfer(t, 2)
+
+	to.ExpectBegin()
+	to.ExpectQuery("SELECT COUNT(*) FROM sheets").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	to.ExpectQuery("SELECT COUNT(*) FROM shot_imports").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	from.ExpectQuery("SELECT * FROM sheets WHERE 1 = 0").WillReturnRows(mysqlSheets())
+	to.ExpectQuery("SELECT * FROM sheets WHERE 1 = 0").WillReturnRows(sqlmock.NewRowsWithColumnDefinition(
+		sqlmock.NewColumn("id").OfType("INT4", int64(0)),
+		sqlmock.NewColumn("name").OfType("VARCHAR", ""),
+	))
+	to.ExpectRollback()
+
+	_, err := transfer.Copy(context.Background())
+	if !errors.Is(err, domainerrors.ErrTransferSchemaMismatch) {
+		t.Fatalf("Copy() error = %v, want %v", err, domainerrors.ErrTransferSchemaMismatch)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	created := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	paris := time.FixedZone("CET", 3600)
+
+	tests := []struct {
+		name    string
+		target  *sqlmock.Rows
+		matches bool
+	}{
+		{
+			name:    "same rows read by both drivers match",
+			target:  postgresSheets().AddRow(1, "Gaggia", true, created.In(paris).Add(300*time.Millisecond)),
+			matches: true,
+		},
+		{
+			name:    "different values do not match",
+			target:  postgresSheets().AddRow(1, "Gaggia", false, created),
+			matches: false,
+		},
+		{
+			name:    "missing rows do not match",
+			target:  postgresSheets(),
+			matches: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfer, from, to := newTestTransfer(t, 10)
+			transfer.tables = testTables[:1]
+
+			from.ExpectQuery("SELECT * FROM sheets WHERE 1 = 0").WillReturnRows(mysqlSheets())
+			to.ExpectQuery("SELECT * FROM sheets WHERE 1 = 0").WillReturnRows(postgresSheets())
+			from.ExpectQuery("SELECT id, name, is_public, created_at FROM sheets ORDER BY id LIMIT ? OFFSET ?").WithArgs(10, 0).
+				WillReturnRows(mysqlSheets().AddRow(1, []byte("Gaggia"), 1, created))
+			to.ExpectQuery("SELECT id, name, is_public, created_at FROM sheets ORDER BY id LIMIT $1 OFFSET $2").WithArgs(10, 0).
+				WillReturnRows(tt.target)
+
+			results, err := transfer.Verify(context.Background())
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if len(results) != 1 || results[0].Table != "sheets" || results[0].SourceRows != 1 {
+				t.Fatalf("Verify() = %+v, want the sheets with 1 source row", results)
+			}
+			if got := results[0].Matches(); got != tt.matches {
+				t.Errorf("Verify() matches = %t, want %t: %+v", got, tt.matches, results[0])
+			}
+		})
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		name          string
+		mysql         any
+		mysqlType     string
+		postgres      any
+		postgresType  string
+		wantCanonical string
+	}{
+		{name: "boolean", mysql: int64(1), mysqlType: "TINYINT", postgres: true, postgresType: "BOOL", wantCanonical: "1"},
+		{name: "decimal", mysql: 18.5, mysqlType: "DOUBLE", postgres: "18.50", postgresType: "NUMERIC", wantCanonical: "18.5"},
+		{name: "text", mysql: []byte("sweet"), mysqlType: "VARCHAR", postgres: "sweet", postgresType: "VARCHAR", wantCanonical: "sweet"},
+		{name: "null", mysql: nil, mysqlType: "VARCHAR", postgres: nil, postgresType: "VARCHAR", wantCanonical: "\x00"},
+		{
+			name: "date", wantCanonical: "2026-03-01",
+			mysql: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), mysqlType: "DATE",
+			postgres: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), postgresType: "DATE",
+		},
+		{
+			name: "timestamp", wantCanonical: "2026-03-01T08:00:01Z",
+			mysql: time.Date(2026, 3, 1, 8, 0, 1, 0, time.UTC), mysqlType: "TIMESTAMP",
+			postgres: time.Date(2026, 3, 1, 9, 0, 0, 600000000, time.FixedZone("CET", 3600)), postgresType: "TIMESTAMPTZ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canonical(tt.mysql, tt.mysqlType); got != tt.wantCanonical {
+				t.Errorf("canonical(%v, %s) = %q, want %q", tt.mysql, tt.mysqlType, got, tt.wantCanonical)
+			}
+			if got := canonical(tt.postgres, tt.postgresType); got != tt.wantCanonical {
+				t.Errorf("canonical(%v, %s) = %q, want %q", tt.postgres, tt.postgresType, got, tt.wantCanonical)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// Scope is what an api key allows.
+type Scope string
+
+const (
+	// ScopeRead only allows reading.
+	ScopeRead Scope = "read"
+	// ScopeWrite allows reading and writing.
+	ScopeWrite Scope = "write"
+)
+
+// IsValid reports whether s is a known scope.
+func (s Scope) IsValid() bool {
+	return s == ScopeRead || s == ScopeWrite
+}
+
+// CanWrite reports whether s allows writing.
+func (s Scope) CanWrite() bool {
+	return s == ScopeWrite
+}
+
+const (
+	// secretPrefix starts every api key, so they are easy to recognise,
+	// for instance by secret scanners.
+	secretPrefix = "esp_"
+
+	// displayedPrefixLength is the length of the start of the secret kept
+	// in clear to tell keys apart.
+	displayedPrefixLength = len(secretPrefix) + 8
+
+	// lastUsedResolution is how often the last use of a key is recorded,
+	// so a busy key does not cost a write per request.
+	lastUsedResolution = time.Minute
+)
+
+// APIKey is a key a user authenticates to the REST API with. Only a hash of
+// its secret is stored.
+type APIKey struct {
+	Id         int
+	UserId     int
+	UserName   string
+	UserRole   auth.Role
+	Name       string
+	Prefix     string
+	Scope      Scope
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  *time.Time
+	UpdatedAt  *time.Time
+}
+
+// SQLToAPIKey converts a sql.APIKey object to an APIKey object.
+// If the input key is nil, it returns nil.
+func SQLToAPIKey(key *sql.APIKey) *APIKey {
+	if key == nil {
+		return nil
+	}
+
+	return &APIKey{
+		Id:         key.Id,
+		UserId:     key.UserId,
+		UserName:   key.UserName,
+		UserRole:   auth.Role(key.UserRole),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scope:      Scope(key.Scope),
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+		UpdatedAt:  key.UpdatedAt,
+	}
+}
+
+type Service interface {
+	CreateAPIKey(ctx context.Context, userName, name string, scope Scope) (*APIKey, string, error)
+	GetAllAPIKeys(ctx context.Context) ([]APIKey, error)
+	RevokeAPIKeyById(ctx context.Context, id int) error
+	Authenticate(ctx context.Context, secret string) (*APIKey, error)
+	Ping(ctx context.Context) error
+}
+
+type APIKeyService struct {
+	repository repository.APIKeyRepository
+	users      repository.UserRepository
+}
+
+var _ Service = (*APIKeyService)(nil)
+
+// now is replaced in tests.
+var now = time.Now
+
+func New(repo repository.APIKeyRepository, users repository.UserRepository) *APIKeyService {
+	return &APIKeyService{repository: repo, users: users}
+}
+
+// CreateAPIKey creates an api key for the user named userName. It returns the
+// key and its secret, which cannot be retrieved afterwards.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, userName, name string, scope Scope) (*APIKey, string, error) {
+	msg := "could not create api key"
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		err := errors.ErrAPIKeyNameIsEmpty
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, "", fmt.Errorf("%s: %w", msg, err)
+	}
+	if !scope.IsValid() {
+		err := errors.ErrAPIKeyScopeIsInvalid
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, "", fmt.Errorf("%s: %w", msg, err)
+	}
+
+	user, err := s.users.GetUserByName(ctx, strings.TrimSpace(userName))
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, "", fmt.Errorf("%s: %w", msg, err)
+	}
+	if user.Disabled {
+		err := errors.ErrUserIsDisabled
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, "", fmt.Errorf("%s: %w", msg, err)
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, "", fmt.Errorf("%s: %w", msg, err)
+	}
+
+	key := &sql.APIKey{
+		UserId: user.Id,
+		Name:   name,
+		Prefix: secret[:displayedPrefixLength],
+		Hash:   hashSecret(secret),
+		Scope:  string(scope),
+	}
+	id, err := s.repository.CreateAPIKey(ctx, key)
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, "", fmt.Errorf("%s: %w", msg, err)
+	}
+
+	created, err := s.repository.GetAPIKeyById(ctx, id)
+	if err != nil {
+		msg := "could not get created api key"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, "", fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToAPIKey(created), secret, nil
+}
+
+func (s *APIKeyService) GetAllAPIKeys(ctx context.Context) ([]APIKey, error) {
+	sqlKeys, err := s.repository.GetAllAPIKeys(ctx)
+	if err != nil {
+		msg := "could not get all api keys"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	keys := make([]APIKey, len(sqlKeys))
+	for i, v := range sqlKeys {
+		keys[i] = *SQLToAPIKey(&v)
+	}
+
+	return keys, nil
+}
+
+func (s *APIKeyService) RevokeAPIKeyById(ctx context.Context, id int) error {
+	if err := s.repository.RevokeAPIKeyById(ctx, id, now()); err != nil {
+		msg := "could not revoke api key by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// Authenticate returns the api key whose secret is secret. It fails with
+// errors.ErrAPIKeyIsInvalid for an unknown secret, errors.ErrAPIKeyIsRevoked
+// for a revoked key and errors.ErrUserIsDisabled when the user owning the key
+// is disabled. The last use of the key is recorded on success.
+func (s *APIKeyService) Authenticate(ctx context.Context, secret string) (*APIKey, error) {
+	msg := "could not authenticate api key"
+
+	key, err := s.repository.GetAPIKeyByHash(ctx, hashSecret(secret))
+	if stderrors.Is(err, errors.ErrAPIKeyDoesNotExist) {
+		err = errors.ErrAPIKeyIsInvalid
+	}
+	if err == nil && key.RevokedAt != nil {
+		err = errors.ErrAPIKeyIsRevoked
+	}
+	if err == nil && key.UserDisabled {
+		err = errors.ErrUserIsDisabled
+	}
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	usedAt := now()
+	if key.LastUsedAt == nil || usedAt.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		// Failing to record the last use must not lock the user out.
+		if err := s.repository.UpdateAPIKeyLastUsedAt(ctx, key.Id, usedAt); err != nil {
+			zerolog.Ctx(ctx).Err(err).Msg("could not record api key last use")
+		} else {
+			key.LastUsedAt = &usedAt
+		}
+	}
+
+	return SQLToAPIKey(key), nil
+}
+
+func (s *APIKeyService) Ping(ctx context.Context) error {
+	if err := s.repository.Ping(ctx); err != nil {
+		msg := "could not ping database"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// newSecret returns a new random api key secret.
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return secretPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashSecret returns the hash of an api key secret as stored. Secrets are
+// random and long enough for a fast hash to resist brute force, which also
+// lets them be looked up by hash.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
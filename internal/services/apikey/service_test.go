@@ -0,0 +1,257 @@
+package apikey
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type MockAPIKeyRepository struct {
+	keys      map[int]sql.APIKey
+	users     map[string]sql.User
+	lastUsed  map[int]time.Time
+	failTouch bool
+}
+
+func newMockRepository() *MockAPIKeyRepository {
+	return &MockAPIKeyRepository{
+		keys: map[int]sql.APIKey{},
+		users: map[string]sql.User{
+			"alice": {Id: 4, Name: "alice"},
+			"bob":   {Id: 5, Name: "bob", Disabled: true},
+		},
+		lastUsed: map[int]time.Time{},
+	}
+}
+
+func (m *MockAPIKeyRepository) CreateAPIKey(ctx context.Context, key *sql.APIKey) (int, error) {
+	key.Id = len(m.keys) + 1
+	for _, u := range m.users {
+		if u.Id == key.UserId {
+			key.UserName = u.Name
+			key.UserDisabled = u.Disabled
+		}
+	}
+	m.keys[key.Id] = *key
+	return key.Id, nil
+}
+
+func (m *MockAPIKeyRepository) GetAPIKeyById(ctx context.Context, id int) (*sql.APIKey, error) {
+	key, ok := m.keys[id]
+	if !ok {
+		return nil, errors.ErrAPIKeyDoesNotExist
+	}
+	return &key, nil
+}
+
+func (m *MockAPIKeyRepository) GetAPIKeyByHash(ctx context.Context, hash string) (*sql.APIKey, error) {
+	for _, key := range m.keys {
+		if key.Hash == hash {
+			return &key, nil
+		}
+	}
+	return nil, errors.ErrAPIKeyDoesNotExist
+}
+
+func (m *MockAPIKeyRepository) GetAllAPIKeys(ctx context.Context) ([]sql.APIKey, error) {
+	keys := make([]sql.APIKey, 0, len(m.keys))
+	for id := 1; id <= len(m.keys); id++ {
+		keys = append(keys, m.keys[id])
+	}
+	return keys, nil
+}
+
+func (m *MockAPIKeyRepository) RevokeAPIKeyById(ctx context.Context, id int, revokedAt time.Time) error {
+	key, ok := m.keys[id]
+	if !ok {
+		return errors.ErrAPIKeyDoesNotExist
+	}
+	key.RevokedAt = &revokedAt
+	m.keys[id] = key
+	return nil
+}
+
+func (m *MockAPIKeyRepository) UpdateAPIKeyLastUsedAt(ctx context.Context, id int, usedAt time.Time) error {
+	if m.failTouch {
+		return fmt.Errorf("mock error")
+	}
+	key := m.keys[id]
+	key.LastUsedAt = &usedAt
+	m.keys[id] = key
+	m.lastUsed[id] = usedAt
+	return nil
+}
+
+func (m *MockAPIKeyRepository) Ping(ctx context.Context) error { return nil }
+
+// The user repository methods are only there to satisfy the interface; the
+// api keys only look users up by name.
+func (m *MockAPIKeyRepository) CreateUser(ctx context.Context, user *sql.User) (int, error) {
+	return 0, fmt.Errorf("unexpected call")
+}
+
+func (m *MockAPIKeyRepository) GetUserById(ctx context.Context, id int) (*sql.User, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *MockAPIKeyRepository) GetUserByName(ctx context.Context, name string) (*sql.User, error) {
+	user, ok := m.users[name]
+	if !ok {
+		return nil, errors.ErrUserDoesNotExist
+	}
+	return &user, nil
+}
+
+func (m *MockAPIKeyRepository) GetAllUsers(ctx context.Context) ([]sql.User, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *MockAPIKeyRepository) DisableUserByName(ctx context.Context, name string) error {
+	return fmt.Errorf("unexpected call")
+}
+
+func (m *MockAPIKeyRepository) SetUserRoleByName(ctx context.Context, name, role string) error {
+	return fmt.Errorf("unexpected call")
+}
+
+func (m *MockAPIKeyRepository) SetUserPasswordHashByName(ctx context.Context, name, hash string) error {
+	return fmt.Errorf("unexpected call")
+}
+
+func (m *MockAPIKeyRepository) GetUserByOIDCSubject(ctx context.Context, subject string) (*sql.User, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *MockAPIKeyRepository) SetUserOIDCSubjectByName(ctx context.Context, name, subject string) error {
+	return fmt.Errorf("unexpected call")
+}
+
+func (m *MockAPIKeyRepository) GetUserOIDCSubjectByName(ctx context.Context, name string) (string, error) {
+	return "", fmt.Errorf("unexpected call")
+}
+
+func (m *MockAPIKeyRepository) GetUserPasswordHashByName(ctx context.Context, name string) (string, error) {
+	return "", fmt.Errorf("unexpected call")
+}
+
+var testNow = time.Date(2026, time.October, 18, 16, 0, 0, 0, time.UTC)
+
+func pinNow(t *testing.T, at time.Time) {
+	t.Helper()
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = time.Now })
+}
+
+func TestAPIKeyServiceCreateAPIKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		userName string
+		keyName  string
+		scope    Scope
+		wantErr  error
+	}{
+		{name: "Valid", userName: "alice", keyName: " grafana ", scope: ScopeRead},
+		{name: "Empty name", userName: "alice", keyName: " ", scope: ScopeRead, wantErr: errors.ErrAPIKeyNameIsEmpty},
+		{name: "Unknown scope", userName: "alice", keyName: "grafana", scope: "admin", wantErr: errors.ErrAPIKeyScopeIsInvalid},
+		{name: "Unknown user", userName: "carol", keyName: "grafana", scope: ScopeRead, wantErr: errors.ErrUserDoesNotExist},
+		{name: "Disabled user", userName: "bob", keyName: "grafana", scope: ScopeRead, wantErr: errors.ErrUserIsDisabled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			got, secret, err := New(repo, repo).CreateAPIKey(context.Background(), tt.userName, tt.keyName, tt.scope)
+			if tt.wantErr != nil {
+				if !stderrors.Is(err, tt.wantErr) {
+					t.Fatalf("APIKeyService.CreateAPIKey() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("APIKeyService.CreateAPIKey() error = %v", err)
+			}
+			if got.Name != "grafana" || got.UserId != 4 || got.Scope != ScopeRead {
+				t.Errorf("APIKeyService.CreateAPIKey() = %+v, want read key grafana of user 4", got)
+			}
+			if !strings.HasPrefix(secret, got.Prefix) || len(got.Prefix) != displayedPrefixLength {
+				t.Errorf("APIKeyService.CreateAPIKey() secret = %q, want it to start with %q", secret, got.Prefix)
+			}
+			if stored := repo.keys[got.Id]; stored.Hash == secret || stored.Hash != hashSecret(secret) {
+				t.Errorf("stored hash = %q, want the hash of the secret", stored.Hash)
+			}
+		})
+	}
+}
+
+func TestAPIKeyServiceAuthenticate(t *testing.T) {
+	repo := newMockRepository()
+	s := New(repo, repo)
+	key, secret, err := s.CreateAPIKey(context.Background(), "alice", "grafana", ScopeWrite)
+	if err != nil {
+		t.Fatalf("APIKeyService.CreateAPIKey() error = %v", err)
+	}
+
+	pinNow(t, testNow)
+	got, err := s.Authenticate(context.Background(), secret)
+	if err != nil {
+		t.Fatalf("APIKeyService.Authenticate() error = %v", err)
+	}
+	if got.Id != key.Id || got.UserName != "alice" || !got.Scope.CanWrite() || !repo.lastUsed[key.Id].Equal(testNow) {
+		t.Errorf("APIKeyService.Authenticate() = %+v, want the write key of alice used now", got)
+	}
+
+	// A use within the resolution is not recorded again.
+	pinNow(t, testNow.Add(30*time.Second))
+	if _, err := s.Authenticate(context.Background(), secret); err != nil {
+		t.Fatalf("APIKeyService.Authenticate() error = %v", err)
+	}
+	if !repo.lastUsed[key.Id].Equal(testNow) {
+		t.Errorf("last use = %v, want %v", repo.lastUsed[key.Id], testNow)
+	}
+
+	// Failing to record the last use does not fail authentication.
+	repo.failTouch = true
+	pinNow(t, testNow.Add(time.Hour))
+	if _, err := s.Authenticate(context.Background(), secret); err != nil {
+		t.Errorf("APIKeyService.Authenticate() error = %v, want nil", err)
+	}
+
+	if _, err := s.Authenticate(context.Background(), "esp_unknown"); !stderrors.Is(err, errors.ErrAPIKeyIsInvalid) {
+		t.Errorf("APIKeyService.Authenticate() error = %v, want %v", err, errors.ErrAPIKeyIsInvalid)
+	}
+
+	if err := s.RevokeAPIKeyById(context.Background(), key.Id); err != nil {
+		t.Fatalf("APIKeyService.RevokeAPIKeyById() error = %v", err)
+	}
+	if _, err := s.Authenticate(context.Background(), secret); !stderrors.Is(err, errors.ErrAPIKeyIsRevoked) {
+		t.Errorf("APIKeyService.Authenticate() error = %v, want %v", err, errors.ErrAPIKeyIsRevoked)
+	}
+}
+
+func TestAPIKeyServiceAuthenticateDisabledUser(t *testing.T) {
+	repo := newMockRepository()
+	s := New(repo, repo)
+	_, secret, err := s.CreateAPIKey(context.Background(), "alice", "grafana", ScopeRead)
+	if err != nil {
+		t.Fatalf("APIKeyService.CreateAPIKey() error = %v", err)
+	}
+	key := repo.keys[1]
+	key.UserDisabled = true
+	repo.keys[1] = key
+
+	if _, err := s.Authenticate(context.Background(), secret); !stderrors.Is(err, errors.ErrUserIsDisabled) {
+		t.Errorf("APIKeyService.Authenticate() error = %v, want %v", err, errors.ErrUserIsDisabled)
+	}
+}
+
+func TestAPIKeyServiceRevokeAPIKeyById(t *testing.T) {
+	repo := newMockRepository()
+	if err := New(repo, repo).RevokeAPIKeyById(context.Background(), 9); !stderrors.Is(err, errors.ErrAPIKeyDoesNotExist) {
+		t.Errorf("APIKeyService.RevokeAPIKeyById() error = %v, want %v", err, errors.ErrAPIKeyDoesNotExist)
+	}
+}
@@ -0,0 +1,129 @@
+package attachment
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+)
+
+const (
+	// MaxPixels is the largest number of pixels of an attached image, so
+	// decoding it for its thumbnail fits in memory.
+	MaxPixels = 40_000_000
+
+	// ThumbnailSize is the largest width and height of a thumbnail.
+	ThumbnailSize = 320
+
+	thumbnailContentType = "image/jpeg"
+	thumbnailQuality     = 80
+
+	// thumbnailSamples is the largest number of source pixels averaged along
+	// each axis for a pixel of a thumbnail.
+	thumbnailSamples = 8
+)
+
+// supportedContentTypes are the sniffed media types accepted for an
+// attachment, by name of their image package.
+var supportedContentTypes = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+type decodedImage struct {
+	image         image.Image
+	contentType   string
+	width, height int
+}
+
+// decodeImage sniffs the media type of data, which must be a supported image
+// no larger than MaxPixels, and decodes it. The type declared by the client is
+// never trusted.
+func decodeImage(data []byte) (*decodedImage, error) {
+	if len(data) == 0 {
+		return nil, errors.ErrAttachmentIsEmpty
+	}
+
+	contentType := http.DetectContentType(data)
+	format, ok := supportedContentTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: got %s", errors.ErrAttachmentTypeIsUnsupported, contentType)
+	}
+
+	cfg, cfgFormat, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfgFormat != format {
+		return nil, errors.ErrAttachmentImageIsInvalid
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, errors.ErrAttachmentImageIsInvalid
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > MaxPixels {
+		return nil, errors.ErrAttachmentImageIsTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.ErrAttachmentImageIsInvalid
+	}
+
+	return &decodedImage{image: img, contentType: contentType, width: cfg.Width, height: cfg.Height}, nil
+}
+
+// makeThumbnail returns src scaled down to fit in ThumbnailSize, encoded as a
+// JPEG. Transparent pixels are drawn over white, as JPEG has no alpha channel.
+func makeThumbnail(src image.Image) ([]byte, error) {
+	b := src.Bounds()
+	w, h := thumbnailBounds(b.Dx(), b.Dy())
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	// Each pixel of dst averages the box of src pixels it covers, sampling
+	// at most thumbnailSamples pixels along each axis of large boxes.
+	for y := range h {
+		y0, y1 := b.Min.Y+y*b.Dy()/h, b.Min.Y+(y+1)*b.Dy()/h
+		ystep := max(1, (y1-y0)/thumbnailSamples)
+		for x := range w {
+			x0, x1 := b.Min.X+x*b.Dx()/w, b.Min.X+(x+1)*b.Dx()/w
+			xstep := max(1, (x1-x0)/thumbnailSamples)
+
+			var r, g, bl, n uint64
+			for sy := y0; sy < y1; sy += ystep {
+				for sx := x0; sx < x1; sx += xstep {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr + 0xffff - ca)
+					g += uint64(cg + 0xffff - ca)
+					bl += uint64(cb + 0xffff - ca)
+					n++
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n >> 8)
+			dst.Pix[i+1] = uint8(g / n >> 8)
+			dst.Pix[i+2] = uint8(bl / n >> 8)
+			dst.Pix[i+3] = 0xff
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// thumbnailBounds returns the dimensions of the thumbnail of a w x h image,
+// keeping its aspect ratio. Images already small enough keep their size.
+func thumbnailBounds(w, h int) (int, int) {
+	if w <= ThumbnailSize && h <= ThumbnailSize {
+		return w, h
+	}
+	if w >= h {
+		return ThumbnailSize, max(1, h*ThumbnailSize/w)
+	}
+	return max(1, w*ThumbnailSize/h), ThumbnailSize
+}
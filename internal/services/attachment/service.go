@@ -0,0 +1,347 @@
+package attachment
+
+import (
+	"context"
+	"crypto/rand"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/lescactus/espressoapi-go/internal/blobstore"
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// maxFilenameLength is the length of the filename column.
+const maxFilenameLength = 255
+
+// blobKeyPrefix starts the keys of the blobs of every attachment.
+const blobKeyPrefix = "attachments/"
+
+// Attachment is a photo of either a shot or beans.
+type Attachment struct {
+	// The id for the attachment
+	Id int `json:"id"`
+
+	// The id of the shot the attachment belongs to, if any
+	ShotId *int `json:"shot_id"`
+
+	// The id of the beans the attachment belongs to, if any
+	BeansId *int `json:"beans_id"`
+
+	// The name of the uploaded file
+	Filename string `json:"filename"`
+
+	// The sniffed media type of the file, image/jpeg, image/png or image/gif
+	ContentType string `json:"content_type"`
+
+	// The size of the file, in bytes
+	Size int64 `json:"size"`
+
+	// The dimensions of the image, in pixels
+	Width  int `json:"width"`
+	Height int `json:"height"`
+
+	// The creation time of the attachment
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// Content is the file of an attachment or of its thumbnail, to be closed by
+// the caller.
+type Content struct {
+	io.ReadCloser
+
+	// The media type of the file
+	ContentType string
+
+	// The name to download the file as
+	Filename string
+}
+
+type Service interface {
+	CreateShotAttachment(ctx context.Context, shotId int, filename string, data []byte) (*Attachment, error)
+	CreateBeansAttachment(ctx context.Context, beansId int, filename string, data []byte) (*Attachment, error)
+	GetAttachmentById(ctx context.Context, id int) (*Attachment, error)
+	GetAttachmentsByShotId(ctx context.Context, shotId int) ([]Attachment, error)
+	GetAttachmentsByBeansId(ctx context.Context, beansId int) ([]Attachment, error)
+	GetAttachmentContent(ctx context.Context, id int, thumbnail bool) (*Content, error)
+	DeleteAttachmentById(ctx context.Context, id int) error
+	Ping(ctx context.Context) error
+}
+
+type AttachmentService struct {
+	repository repository.AttachmentRepository
+	store      blobstore.Store
+}
+
+var _ Service = (*AttachmentService)(nil)
+
+// New returns an attachment service keeping the files of the attachments in
+// store.
+func New(repo repository.AttachmentRepository, store blobstore.Store) *AttachmentService {
+	return &AttachmentService{repository: repo, store: store}
+}
+
+// SQLToAttachment converts a sql.Attachment object to an Attachment object.
+// If the input attachment is nil, it returns nil.
+func SQLToAttachment(attachment *sql.Attachment) *Attachment {
+	if attachment == nil {
+		return nil
+	}
+
+	return &Attachment{
+		Id:          attachment.Id,
+		ShotId:      attachment.ShotId,
+		BeansId:     attachment.BeansId,
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Width:       attachment.Width,
+		Height:      attachment.Height,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+// CreateShotAttachment attaches the image data, uploaded as filename, to the
+// shot shotId.
+func (s *AttachmentService) CreateShotAttachment(ctx context.Context, shotId int, filename string, data []byte) (*Attachment, error) {
+	return s.createAttachment(ctx, &sql.Attachment{ShotId: &shotId}, filename, data)
+}
+
+// CreateBeansAttachment attaches the image data, uploaded as filename, to the
+// beans beansId.
+func (s *AttachmentService) CreateBeansAttachment(ctx context.Context, beansId int, filename string, data []byte) (*Attachment, error) {
+	return s.createAttachment(ctx, &sql.Attachment{BeansId: &beansId}, filename, data)
+}
+
+// createAttachment checks data is a supported image, stores it along with its
+// thumbnail and records the attachment. The blobs are stored first, and
+// deleted again when the attachment cannot be recorded, so that no attachment
+// ever references a missing blob.
+func (s *AttachmentService) createAttachment(ctx context.Context, attachment *sql.Attachment, filename string, data []byte) (*Attachment, error) {
+	msg := "could not create attachment"
+
+	img, err := decodeImage(data)
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+	thumbnail, err := makeThumbnail(img.image)
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	attachment.Filename = cleanFilename(filename)
+	attachment.ContentType = img.contentType
+	attachment.Size = int64(len(data))
+	attachment.Width = img.width
+	attachment.Height = img.height
+	attachment.BlobKey = blobKeyPrefix + rand.Text()
+	attachment.ThumbnailKey = attachment.BlobKey + "-thumbnail"
+
+	if err := s.store.Put(ctx, attachment.BlobKey, attachment.ContentType, data); err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+	if err := s.store.Put(ctx, attachment.ThumbnailKey, thumbnailContentType, thumbnail); err != nil {
+		s.deleteBlobs(ctx, attachment)
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	id, err := s.repository.CreateAttachment(ctx, attachment)
+	if err != nil {
+		s.deleteBlobs(ctx, attachment)
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	created, err := s.repository.GetAttachmentById(ctx, id)
+	if err != nil {
+		msg := "could not get created attachment"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToAttachment(created), nil
+}
+
+func (s *AttachmentService) GetAttachmentById(ctx context.Context, id int) (*Attachment, error) {
+	attachment, err := s.repository.GetAttachmentById(ctx, id)
+	if err != nil {
+		msg := "could not get attachment by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToAttachment(attachment), nil
+}
+
+func (s *AttachmentService) GetAttachmentsByShotId(ctx context.Context, shotId int) ([]Attachment, error) {
+	sqlAttachments, err := s.repository.GetAttachmentsByShotId(ctx, shotId)
+	if err != nil {
+		msg := "could not get attachments by shot id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return sqlToAttachments(sqlAttachments), nil
+}
+
+func (s *AttachmentService) GetAttachmentsByBeansId(ctx context.Context, beansId int) ([]Attachment, error) {
+	sqlAttachments, err := s.repository.GetAttachmentsByBeansId(ctx, beansId)
+	if err != nil {
+		msg := "could not get attachments by beans id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return sqlToAttachments(sqlAttachments), nil
+}
+
+// GetAttachmentContent returns the file of the attachment id, or its JPEG
+// thumbnail when thumbnail is set.
+func (s *AttachmentService) GetAttachmentContent(ctx context.Context, id int, thumbnail bool) (*Content, error) {
+	msg := "could not get attachment content"
+
+	attachment, err := s.repository.GetAttachmentById(ctx, id)
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	key, contentType, filename := attachment.BlobKey, attachment.ContentType, attachment.Filename
+	if thumbnail {
+		key, contentType = attachment.ThumbnailKey, thumbnailContentType
+		filename = strings.TrimSuffix(filename, path.Ext(filename)) + "-thumbnail.jpg"
+	}
+
+	r, err := s.store.Get(ctx, key)
+	if stderrors.Is(err, blobstore.ErrBlobDoesNotExist) {
+		err = fmt.Errorf("%w: missing blob %q", errors.ErrAttachmentDoesNotExist, key)
+	}
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return &Content{ReadCloser: r, ContentType: contentType, Filename: filename}, nil
+}
+
+// DeleteAttachmentById deletes the attachment id, then its blobs. A blob left
+// behind when the store fails is only logged, as the attachment is gone.
+func (s *AttachmentService) DeleteAttachmentById(ctx context.Context, id int) error {
+	msg := "could not delete attachment by id"
+
+	attachment, err := s.repository.GetAttachmentById(ctx, id)
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+
+	if err := s.repository.DeleteAttachmentById(ctx, id); err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+
+	s.deleteBlobs(ctx, attachment)
+	return nil
+}
+
+// SweepBlobs deletes the blobs of the store no attachment references anymore,
+// such as those of the attachments deleted along with their shot or beans, and
+// returns how many it deleted. Only the blobs written before olderThan are
+// deleted, since a blob is stored before its attachment is recorded.
+func (s *AttachmentService) SweepBlobs(ctx context.Context, olderThan time.Time) (int, error) {
+	msg := "could not sweep attachment blobs"
+
+	keys, err := s.repository.GetAllBlobKeys(ctx)
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return 0, fmt.Errorf("%s: %w", msg, err)
+	}
+	inUse := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		inUse[key] = true
+	}
+
+	blobs, err := s.store.List(ctx, blobKeyPrefix)
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return 0, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	deleted := 0
+	var errs []error
+	for _, blob := range blobs {
+		if inUse[blob.Key] || !blob.ModTime.Before(olderThan) {
+			continue
+		}
+		if err := s.store.Delete(ctx, blob.Key); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		deleted++
+	}
+	if err := stderrors.Join(errs...); err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return deleted, fmt.Errorf("%s: %w", msg, err)
+	}
+	return deleted, nil
+}
+
+func (s *AttachmentService) Ping(ctx context.Context) error {
+	if err := s.repository.Ping(ctx); err != nil {
+		msg := "could not ping database"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// deleteBlobs deletes the file and the thumbnail of attachment, logging the
+// failures.
+func (s *AttachmentService) deleteBlobs(ctx context.Context, attachment *sql.Attachment) {
+	for _, key := range []string{attachment.BlobKey, attachment.ThumbnailKey} {
+		if err := s.store.Delete(ctx, key); err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Str("blob_key", key).Msg("could not delete blob of attachment")
+		}
+	}
+}
+
+func sqlToAttachments(sqlAttachments []sql.Attachment) []Attachment {
+	attachments := make([]Attachment, len(sqlAttachments))
+	for i, v := range sqlAttachments {
+		attachments[i] = *SQLToAttachment(&v)
+	}
+	return attachments
+}
+
+// cleanFilename returns the base name of filename, as some browsers send the
+// full path of the uploaded file, without control characters and cut to fit
+// in the database.
+func cleanFilename(filename string) string {
+	filename = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, filename)
+	filename = strings.TrimSpace(path.Base(strings.ReplaceAll(filename, `\`, "/")))
+	if filename == "" || filename == "." || filename == ".." || filename == "/" {
+		return "attachment"
+	}
+	for len(filename) > maxFilenameLength {
+		_, size := utf8.DecodeLastRuneInString(filename)
+		filename = filename[:len(filename)-size]
+	}
+	return filename
+}
@@ -0,0 +1,395 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	stderrors "errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/blobstore"
+	"github.com/lescactus/espressoapi-go/internal/blobstore/local"
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type MockAttachmentRepository struct {
+	attachments map[int]sql.Attachment
+	shots       map[int]bool
+	beans       map[int]bool
+	nextId      int
+}
+
+func newMockRepository() *MockAttachmentRepository {
+	return &MockAttachmentRepository{
+		attachments: map[int]sql.Attachment{},
+		shots:       map[int]bool{1: true},
+		beans:       map[int]bool{2: true},
+	}
+}
+
+func (m *MockAttachmentRepository) CreateAttachment(ctx context.Context, attachment *sql.Attachment) (int, error) {
+	if attachment.ShotId != nil && !m.shots[*attachment.ShotId] {
+		return 0, errors.ErrShotDoesNotExist
+	}
+	if attachment.BeansId != nil && !m.beans[*attachment.BeansId] {
+		return 0, errors.ErrBeansDoesNotExist
+	}
+	m.nextId++
+	attachment.Id = m.nextId
+	m.attachments[attachment.Id] = *attachment
+	return attachment.Id, nil
+}
+
+func (m *MockAttachmentRepository) GetAttachmentById(ctx context.Context, id int) (*sql.Attachment, error) {
+	attachment, ok := m.attachments[id]
+	if !ok {
+		return nil, errors.ErrAttachmentDoesNotExist
+	}
+	return &attachment, nil
+}
+
+func (m *MockAttachmentRepository) GetAttachmentsByShotId(ctx context.Context, shotId int) ([]sql.Attachment, error) {
+	if !m.shots[shotId] {
+		return nil, errors.ErrShotDoesNotExist
+	}
+	attachments := make([]sql.Attachment, 0)
+	for id := 1; id <= m.nextId; id++ {
+		if a, ok := m.attachments[id]; ok && a.ShotId != nil && *a.ShotId == shotId {
+			attachments = append(attachments, a)
+		}
+	}
+	return attachments, nil
+}
+
+func (m *MockAttachmentRepository) GetAttachmentsByBeansId(ctx context.Context, beansId int) ([]sql.Attachment, error) {
+	if !m.beans[beansId] {
+		return nil, errors.ErrBeansDoesNotExist
+	}
+	attachments := make([]sql.Attachment, 0)
+	for id := 1; id <= m.nextId; id++ {
+		if a, ok := m.attachments[id]; ok && a.BeansId != nil && *a.BeansId == beansId {
+			attachments = append(attachments, a)
+		}
+	}
+	return attachments, nil
+}
+
+func (m *MockAttachmentRepository) DeleteAttachmentById(ctx context.Context, id int) error {
+	if _, ok := m.attachments[id]; !ok {
+		return errors.ErrAttachmentDoesNotExist
+	}
+	delete(m.attachments, id)
+	return nil
+}
+
+func (m *MockAttachmentRepository) GetAllBlobKeys(ctx context.Context) ([]string, error) {
+	keys := make([]string, 0, 2*len(m.attachments))
+	for _, a := range m.attachments {
+		keys = append(keys, a.BlobKey, a.ThumbnailKey)
+	}
+	return keys, nil
+}
+
+func (m *MockAttachmentRepository) Ping(ctx context.Context) error { return nil }
+
+func newTestService(t *testing.T) (*AttachmentService, *MockAttachmentRepository, blobstore.Store) {
+	t.Helper()
+	store, err := local.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("local.New() error = %v", err)
+	}
+	repo := newMockRepository()
+	return New(repo, store), repo, store
+}
+
+// encodePNG returns a w x h PNG, half transparent.
+func encodePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, color.NRGBA{R: 0x80, A: uint8(0xff * (x % 2))})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withPNGSize returns data, a PNG, claiming to be w x h in its header.
+func withPNGSize(data []byte, w, h uint32) []byte {
+	data = bytes.Clone(data)
+	// The IHDR chunk follows the 8 bytes signature and its own length.
+	ihdr := data[12:29]
+	binary.BigEndian.PutUint32(ihdr[4:8], w)
+	binary.BigEndian.PutUint32(ihdr[8:12], h)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(ihdr))
+	return data
+}
+
+func TestAttachmentServiceCreateShotAttachment(t *testing.T) {
+	ctx := context.Background()
+	s, repo, store := newTestService(t)
+
+	data := encodePNG(t, 640, 480)
+	got, err := s.CreateShotAttachment(ctx, 1, `C:\Users\barista\crema.png`, data)
+	if err != nil {
+		t.Fatalf("AttachmentService.CreateShotAttachment() error = %v", err)
+	}
+	if got.ShotId == nil || *got.ShotId != 1 || got.BeansId != nil {
+		t.Errorf("AttachmentService.CreateShotAttachment() = %+v, want an attachment of shot 1", got)
+	}
+	if got.Filename != "crema.png" || got.ContentType != "image/png" || got.Size != int64(len(data)) || got.Width != 640 || got.Height != 480 {
+		t.Errorf("AttachmentService.CreateShotAttachment() = %+v, want crema.png, a 640x480 image/png of %d bytes", got, len(data))
+	}
+
+	stored := repo.attachments[got.Id]
+	r, err := store.Get(ctx, stored.BlobKey)
+	if err != nil {
+		t.Fatalf("store.Get() error = %v", err)
+	}
+	content, _ := io.ReadAll(r)
+	r.Close()
+	if !bytes.Equal(content, data) {
+		t.Errorf("stored content differs from the uploaded one")
+	}
+
+	thumbnail, err := s.GetAttachmentContent(ctx, got.Id, true)
+	if err != nil {
+		t.Fatalf("AttachmentService.GetAttachmentContent() error = %v", err)
+	}
+	defer thumbnail.Close()
+	if thumbnail.ContentType != "image/jpeg" || thumbnail.Filename != "crema-thumbnail.jpg" {
+		t.Errorf("AttachmentService.GetAttachmentContent() = %q as %q, want image/jpeg as crema-thumbnail.jpg", thumbnail.ContentType, thumbnail.Filename)
+	}
+	cfg, err := jpeg.DecodeConfig(thumbnail)
+	if err != nil {
+		t.Fatalf("thumbnail is not a JPEG: %v", err)
+	}
+	if cfg.Width != ThumbnailSize || cfg.Height != 240 {
+		t.Errorf("thumbnail is %dx%d, want %dx240", cfg.Width, cfg.Height, ThumbnailSize)
+	}
+}
+
+func TestAttachmentServiceCreateAttachment_Invalid(t *testing.T) {
+	valid := encodePNG(t, 2, 2)
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "Empty file", data: nil, wantErr: errors.ErrAttachmentIsEmpty},
+		{name: "Text file", data: []byte("not a picture"), wantErr: errors.ErrAttachmentTypeIsUnsupported},
+		{name: "PDF file", data: []byte("%PDF-1.7\n"), wantErr: errors.ErrAttachmentTypeIsUnsupported},
+		{name: "Truncated PNG", data: valid[:40], wantErr: errors.ErrAttachmentImageIsInvalid},
+		{name: "Too many pixels", data: withPNGSize(valid, 8000, 6000), wantErr: errors.ErrAttachmentImageIsTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo, _ := newTestService(t)
+			_, err := s.CreateBeansAttachment(context.Background(), 2, "beans.png", tt.data)
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Errorf("AttachmentService.CreateBeansAttachment() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(repo.attachments) != 0 {
+				t.Errorf("expected no attachment to be recorded, got %d", len(repo.attachments))
+			}
+		})
+	}
+}
+
+func TestAttachmentServiceCreateAttachment_DeletesBlobsOnFailure(t *testing.T) {
+	ctx := context.Background()
+	s, _, _ := newTestService(t)
+	store := &recordingStore{Store: s.store}
+	s.store = store
+
+	_, err := s.CreateShotAttachment(ctx, 9, "crema.png", encodePNG(t, 2, 2))
+	if !stderrors.Is(err, errors.ErrShotDoesNotExist) {
+		t.Fatalf("AttachmentService.CreateShotAttachment() error = %v, want %v", err, errors.ErrShotDoesNotExist)
+	}
+	if len(store.put) != 2 {
+		t.Fatalf("expected the file and its thumbnail to be stored, got %v", store.put)
+	}
+	for _, key := range store.put {
+		if _, err := store.Get(ctx, key); !stderrors.Is(err, blobstore.ErrBlobDoesNotExist) {
+			t.Errorf("expected blob %q to be deleted, got error %v", key, err)
+		}
+	}
+}
+
+func TestAttachmentServiceDeleteAttachmentById(t *testing.T) {
+	ctx := context.Background()
+	s, repo, store := newTestService(t)
+
+	created, err := s.CreateBeansAttachment(ctx, 2, "beans.png", encodePNG(t, 2, 2))
+	if err != nil {
+		t.Fatalf("AttachmentService.CreateBeansAttachment() error = %v", err)
+	}
+	stored := repo.attachments[created.Id]
+
+	if err := s.DeleteAttachmentById(ctx, created.Id); err != nil {
+		t.Fatalf("AttachmentService.DeleteAttachmentById() error = %v", err)
+	}
+	for _, key := range []string{stored.BlobKey, stored.ThumbnailKey} {
+		if _, err := store.Get(ctx, key); !stderrors.Is(err, blobstore.ErrBlobDoesNotExist) {
+			t.Errorf("expected blob %q to be deleted, got error %v", key, err)
+		}
+	}
+
+	if err := s.DeleteAttachmentById(ctx, created.Id); !stderrors.Is(err, errors.ErrAttachmentDoesNotExist) {
+		t.Errorf("AttachmentService.DeleteAttachmentById() error = %v, want %v", err, errors.ErrAttachmentDoesNotExist)
+	}
+}
+
+func TestAttachmentServiceSweepBlobs(t *testing.T) {
+	ctx := context.Background()
+	s, repo, store := newTestService(t)
+
+	kept, err := s.CreateShotAttachment(ctx, 1, "kept.png", encodePNG(t, 8, 8))
+	if err != nil {
+		t.Fatalf("AttachmentService.CreateShotAttachment() error = %v", err)
+	}
+	orphan, err := s.CreateBeansAttachment(ctx, 2, "orphan.png", encodePNG(t, 8, 8))
+	if err != nil {
+		t.Fatalf("AttachmentService.CreateBeansAttachment() error = %v", err)
+	}
+	orphanKey := repo.attachments[orphan.Id].BlobKey
+	// The beans are deleted, taking their attachment along in the database.
+	delete(repo.attachments, orphan.Id)
+
+	if n, err := s.SweepBlobs(ctx, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+		t.Fatalf("AttachmentService.SweepBlobs() of recent blobs = %d, %v, want 0, nil", n, err)
+	}
+
+	n, err := s.SweepBlobs(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("AttachmentService.SweepBlobs() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("AttachmentService.SweepBlobs() = %d, want the file and thumbnail of the orphan", n)
+	}
+	if _, err := store.Get(ctx, orphanKey); !stderrors.Is(err, blobstore.ErrBlobDoesNotExist) {
+		t.Errorf("orphan blob still stored, Get() error = %v", err)
+	}
+	content, err := s.GetAttachmentContent(ctx, kept.Id, true)
+	if err != nil {
+		t.Fatalf("thumbnail of the kept attachment was swept: %v", err)
+	}
+	content.Close()
+}
+
+func TestAttachmentServiceGetAttachmentContent_MissingBlob(t *testing.T) {
+	ctx := context.Background()
+	s, repo, store := newTestService(t)
+
+	created, err := s.CreateShotAttachment(ctx, 1, "crema.png", encodePNG(t, 2, 2))
+	if err != nil {
+		t.Fatalf("AttachmentService.CreateShotAttachment() error = %v", err)
+	}
+	store.Delete(ctx, repo.attachments[created.Id].BlobKey)
+
+	if _, err := s.GetAttachmentContent(ctx, created.Id, false); !stderrors.Is(err, errors.ErrAttachmentDoesNotExist) {
+		t.Errorf("AttachmentService.GetAttachmentContent() error = %v, want %v", err, errors.ErrAttachmentDoesNotExist)
+	}
+}
+
+func TestAttachmentServiceGetAttachmentsByShotId(t *testing.T) {
+	ctx := context.Background()
+	s, _, _ := newTestService(t)
+
+	for range 2 {
+		if _, err := s.CreateShotAttachment(ctx, 1, "crema.png", encodePNG(t, 2, 2)); err != nil {
+			t.Fatalf("AttachmentService.CreateShotAttachment() error = %v", err)
+		}
+	}
+	if _, err := s.CreateBeansAttachment(ctx, 2, "beans.png", encodePNG(t, 2, 2)); err != nil {
+		t.Fatalf("AttachmentService.CreateBeansAttachment() error = %v", err)
+	}
+
+	got, err := s.GetAttachmentsByShotId(ctx, 1)
+	if err != nil {
+		t.Fatalf("AttachmentService.GetAttachmentsByShotId() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Id != 1 || got[1].Id != 2 {
+		t.Errorf("AttachmentService.GetAttachmentsByShotId() = %+v, want attachments 1 and 2", got)
+	}
+
+	if _, err := s.GetAttachmentsByShotId(ctx, 9); !stderrors.Is(err, errors.ErrShotDoesNotExist) {
+		t.Errorf("AttachmentService.GetAttachmentsByShotId() error = %v, want %v", err, errors.ErrShotDoesNotExist)
+	}
+}
+
+func TestMakeThumbnail_TransparencyOverWhite(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	thumbnail, err := makeThumbnail(src)
+	if err != nil {
+		t.Fatalf("makeThumbnail() error = %v", err)
+	}
+	img, err := jpeg.Decode(bytes.NewReader(thumbnail))
+	if err != nil {
+		t.Fatalf("jpeg.Decode() error = %v", err)
+	}
+	if r, g, b, _ := img.At(1, 1).RGBA(); r>>8 < 0xf0 || g>>8 < 0xf0 || b>>8 < 0xf0 {
+		t.Errorf("transparent pixel = (%d, %d, %d), want white", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestThumbnailBounds(t *testing.T) {
+	tests := []struct {
+		w, h         int
+		wantW, wantH int
+	}{
+		{w: 100, h: 50, wantW: 100, wantH: 50},
+		{w: 640, h: 480, wantW: 320, wantH: 240},
+		{w: 480, h: 640, wantW: 240, wantH: 320},
+		{w: 10000, h: 10, wantW: 320, wantH: 1},
+	}
+	for _, tt := range tests {
+		if w, h := thumbnailBounds(tt.w, tt.h); w != tt.wantW || h != tt.wantH {
+			t.Errorf("thumbnailBounds(%d, %d) = %d, %d, want %d, %d", tt.w, tt.h, w, h, tt.wantW, tt.wantH)
+		}
+	}
+}
+
+func TestCleanFilename(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     string
+	}{
+		{filename: "crema.png", want: "crema.png"},
+		{filename: "/home/barista/crema.png", want: "crema.png"},
+		{filename: `C:\Users\barista\crema.png`, want: "crema.png"},
+		{filename: "cre\r\nma.png", want: "crema.png"},
+		{filename: "", want: "attachment"},
+		{filename: "../", want: "attachment"},
+		{filename: strings.Repeat("é", 200), want: strings.Repeat("é", 127)},
+	}
+	for _, tt := range tests {
+		if got := cleanFilename(tt.filename); got != tt.want {
+			t.Errorf("cleanFilename(%q) = %q, want %q", tt.filename, got, tt.want)
+		}
+	}
+}
+
+// recordingStore records the keys of the blobs put in its store.
+type recordingStore struct {
+	blobstore.Store
+	put []string
+}
+
+func (s *recordingStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	s.put = append(s.put, key)
+	return s.Store.Put(ctx, key, contentType, data)
+}
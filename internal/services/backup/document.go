@@ -0,0 +1,144 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+// Version is the version of the backups written, bumped whenever their
+// format changes in a way older versions cannot restore. Version 2 added the
+// owners of the records.
+const Version = 2
+
+// gzipMagic starts every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Backup is a database-agnostic backup of the sheets, roasters, beans and
+// shots, with their ids, timestamps and owners, and of the users owning them.
+type Backup struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Users     []User    `json:"users"`
+	Sheets    []Sheet   `json:"sheets"`
+	Roasters  []Roaster `json:"roasters"`
+	Beans     []Beans   `json:"beans"`
+	Shots     []Shot    `json:"shots"`
+}
+
+// User is an owner of records. Its credentials are not backed up: a user
+// restored into a database without one of the same name has to be given a
+// password or API key again.
+type User struct {
+	Id        int        `json:"id"`
+	Name      string     `json:"name"`
+	Role      string     `json:"role"`
+	Disabled  bool       `json:"disabled"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+type Sheet struct {
+	Id         int        `json:"id"`
+	OwnerId    *int       `json:"owner_id"`
+	Name       string     `json:"name"`
+	IsTemplate bool       `json:"is_template"`
+	CreatedAt  *time.Time `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
+type Roaster struct {
+	Id        int        `json:"id"`
+	OwnerId   *int       `json:"owner_id"`
+	Name      string     `json:"name"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+type Beans struct {
+	Id            int            `json:"id"`
+	OwnerId       *int           `json:"owner_id"`
+	Name          string         `json:"name"`
+	RoasterId     int            `json:"roaster_id"`
+	RoastDate     *time.Time     `json:"roast_date"`
+	RoastLevel    sql.RoastLevel `json:"roast_level"`
+	GreenCoffeeId *int           `json:"green_coffee_id"`
+	GreenWeight   float64        `json:"green_weight"`
+	Price         float64        `json:"price"`
+	Currency      string         `json:"currency"`
+	BagWeight     float64        `json:"bag_weight"`
+	CreatedAt     *time.Time     `json:"created_at"`
+	UpdatedAt     *time.Time     `json:"updated_at"`
+}
+
+type Shot struct {
+	Id                           int                              `json:"id"`
+	OwnerId                      *int                             `json:"owner_id"`
+	SheetId                      int                              `json:"sheet_id"`
+	BeansId                      int                              `json:"beans_id"`
+	GrindSetting                 int                              `json:"grind_setting"`
+	QuantityIn                   float64                          `json:"quantity_in"`
+	QuantityOut                  float64                          `json:"quantity_out"`
+	ShotTimeMs                   int64                            `json:"shot_time_ms"`
+	WaterTemperature             float64                          `json:"water_temperature"`
+	Rating                       float64                          `json:"rating"`
+	IsTooBitter                  bool                             `json:"is_too_bitter"`
+	IsTooSour                    bool                             `json:"is_too_sour"`
+	ComparisonWithPreviousResult sql.ComparisonWithPreviousResult `json:"comparison_with_previous_result"`
+	AdditionalNotes              string                           `json:"additional_notes"`
+	CreatedAt                    *time.Time                       `json:"created_at"`
+	UpdatedAt                    *time.Time                       `json:"updated_at"`
+}
+
+// Write writes backup to w as indented JSON, gzipped when compress is set.
+func Write(w io.Writer, backup *Backup, compress bool) error {
+	if !compress {
+		return encode(w, backup)
+	}
+	zw := gzip.NewWriter(w)
+	if err := encode(zw, backup); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func encode(w io.Writer, backup *Backup) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(backup)
+}
+
+// Read reads a backup written by Write, gzipped or not. It returns
+// ErrBackupIsInvalid when r holds no backup, and ErrBackupVersionUnsupported
+// when the backup was written by a newer version.
+func Read(r io.Reader) (*Backup, error) {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(len(gzipMagic)); bytes.Equal(magic, gzipMagic) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errors.ErrBackupIsInvalid, err)
+		}
+		defer zr.Close()
+		r = zr
+	} else {
+		r = br
+	}
+
+	var backup Backup
+	if err := json.NewDecoder(r).Decode(&backup); err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrBackupIsInvalid, err)
+	}
+	if backup.Version < 1 {
+		return nil, errors.ErrBackupIsInvalid
+	}
+	if backup.Version > Version {
+		return nil, fmt.Errorf("%w: version %d, expected at most %d", errors.ErrBackupVersionUnsupported, backup.Version, Version)
+	}
+	return &backup, nil
+}
@@ -0,0 +1,64 @@
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+)
+
+func TestWriteRead(t *testing.T) {
+	created := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	backup := &Backup{
+		Version:   Version,
+		CreatedAt: created,
+		Sheets:    []Sheet{{Id: 3, Name: "Gaggia", CreatedAt: &created}},
+		Roasters:  []Roaster{},
+		Beans:     []Beans{},
+		Shots:     []Shot{{Id: 9, SheetId: 3, BeansId: 5, ShotTimeMs: 28500, AdditionalNotes: "sweet"}},
+	}
+
+	for _, compress := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := Write(&buf, backup, compress); err != nil {
+			t.Fatalf("Write(compress=%t) error = %v", compress, err)
+		}
+		if got := bytes.HasPrefix(buf.Bytes(), gzipMagic); got != compress {
+			t.Errorf("Write(compress=%t) gzipped = %t", compress, got)
+		}
+
+		got, err := Read(&buf)
+		if err != nil {
+			t.Fatalf("Read(compress=%t) error = %v", compress, err)
+		}
+		if !reflect.DeepEqual(got, backup) {
+			t.Errorf("Read(compress=%t) = %+v, want %+v", compress, got, backup)
+		}
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{name: "not json", data: "id,name\n", want: domainerrors.ErrBackupIsInvalid},
+		{name: "truncated gzip", data: "\x1f\x8b\x08", want: domainerrors.ErrBackupIsInvalid},
+		{name: "no version", data: `{"sheets": []}`, want: domainerrors.ErrBackupIsInvalid},
+		{name: "newer version", data: `{"version": 3}`, want: domainerrors.ErrBackupVersionUnsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.data))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Read() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Package backup backs up the sheets, roasters, beans and shots into a
+// versioned document independent of the database, and restores them with
+// their ids and timestamps, possibly into another kind of database.
+package backup
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// Count is the number of records of a kind restored, and of those skipped
+// because a record with the same id already existed.
+type Count struct {
+	Restored int `json:"restored"`
+	Skipped  int `json:"skipped"`
+}
+
+// Report is the outcome of the restore of a backup. Users are skipped when
+// one with the same name already exists.
+type Report struct {
+	Users    Count `json:"users"`
+	Sheets   Count `json:"sheets"`
+	Roasters Count `json:"roasters"`
+	Beans    Count `json:"beans"`
+	Shots    Count `json:"shots"`
+}
+
+type Service interface {
+	Backup(ctx context.Context) (*Backup, error)
+	Restore(ctx context.Context, backup *Backup, merge bool) (*Report, error)
+}
+
+type BackupService struct {
+	sheets   repository.SheetRepository
+	roasters repository.RoasterRepository
+	beans    repository.BeansRepository
+	shots    repository.ShotRepository
+	backups  repository.BackupRepository
+}
+
+var _ Service = (*BackupService)(nil)
+
+func New(sheets repository.SheetRepository, roasters repository.RoasterRepository, beans repository.BeansRepository, shots repository.ShotRepository, backups repository.BackupRepository) *BackupService {
+	return &BackupService{sheets: sheets, roasters: roasters, beans: beans, shots: shots, backups: backups}
+}
+
+// Backup reads every sheet, roaster, beans and shot with its owner, ordered
+// by id, and the users owning them.
+func (s *BackupService) Backup(ctx context.Context) (*Backup, error) {
+	fail := func(msg string, err error) (*Backup, error) {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	sheets, err := s.sheets.GetAllSheets(ctx)
+	if err != nil {
+		return fail("could not get all sheets", err)
+	}
+	roasters, err := s.roasters.GetAllRoasters(ctx)
+	if err != nil {
+		return fail("could not get all roasters", err)
+	}
+	beans, err := s.beans.GetAllBeans(ctx)
+	if err != nil {
+		return fail("could not get all beans", err)
+	}
+	shots, err := s.shots.GetAllShots(ctx)
+	if err != nil {
+		return fail("could not get all shots", err)
+	}
+	owners, err := s.backups.GetBackupOwners(ctx)
+	if err != nil {
+		return fail("could not get the owners of the records", err)
+	}
+	owner := func(byId map[int]int, id int) *int {
+		if ownerId, ok := byId[id]; ok {
+			return &ownerId
+		}
+		return nil
+	}
+
+	backup := &Backup{
+		Version:   Version,
+		CreatedAt: time.Now().UTC(),
+		Users:     make([]User, 0, len(owners.Users)),
+		Sheets:    make([]Sheet, 0, len(sheets)),
+		Roasters:  make([]Roaster, 0, len(roasters)),
+		Beans:     make([]Beans, 0, len(beans)),
+		Shots:     make([]Shot, 0, len(shots)),
+	}
+	for _, u := range owners.Users {
+		backup.Users = append(backup.Users, User{Id: u.Id, Name: u.Name, Role: u.Role, Disabled: u.Disabled, CreatedAt: u.CreatedAt})
+	}
+	for _, sh := range sheets {
+		backup.Sheets = append(backup.Sheets, Sheet{Id: sh.Id, OwnerId: owner(owners.Sheets, sh.Id), Name: sh.Name, IsTemplate: sh.IsTemplate, CreatedAt: sh.CreatedAt, UpdatedAt: sh.UpdatedAt})
+	}
+	for _, r := range roasters {
+		backup.Roasters = append(backup.Roasters, Roaster{Id: r.Id, OwnerId: owner(owners.Roasters, r.Id), Name: r.Name, CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt})
+	}
+	for _, b := range beans {
+		backup.Beans = append(backup.Beans, Beans{
+			Id: b.Id, OwnerId: owner(owners.Beans, b.Id), Name: b.Name, RoasterId: b.Roaster.Id, RoastDate: b.RoastDate, RoastLevel: b.RoastLevel,
+			GreenCoffeeId: b.GreenCoffeeId, GreenWeight: b.GreenWeight, Price: b.Price, Currency: b.Currency, BagWeight: b.BagWeight,
+			CreatedAt: b.CreatedAt, UpdatedAt: b.UpdatedAt,
+		})
+	}
+	for _, sh := range shots {
+		backup.Shots = append(backup.Shots, Shot{
+			Id: sh.Id, OwnerId: owner(owners.Shots, sh.Id), SheetId: sh.Sheet.Id, BeansId: sh.Beans.Id, GrindSetting: sh.GrindSetting,
+			QuantityIn: sh.QuantityIn, QuantityOut: sh.QuantityOut, ShotTimeMs: sh.ShotTime.Milliseconds(),
+			WaterTemperature: sh.WaterTemperature, Rating: sh.Rating, IsTooBitter: sh.IsTooBitter, IsTooSour: sh.IsTooSour,
+			ComparisonWithPreviousResult: sh.ComparisonWithPreviousResult, AdditionalNotes: sh.AdditionalNotes,
+			CreatedAt: sh.CreatedAt, UpdatedAt: sh.UpdatedAt,
+		})
+	}
+
+	slices.SortFunc(backup.Sheets, func(a, b Sheet) int { return cmp.Compare(a.Id, b.Id) })
+	slices.SortFunc(backup.Roasters, func(a, b Roaster) int { return cmp.Compare(a.Id, b.Id) })
+	slices.SortFunc(backup.Beans, func(a, b Beans) int { return cmp.Compare(a.Id, b.Id) })
+	slices.SortFunc(backup.Shots, func(a, b Shot) int { return cmp.Compare(a.Id, b.Id) })
+	return backup, nil
+}
+
+// Restore restores backup in a single transaction, keeping the ids and
+// timestamps of its records. Unless merge is set, the database must hold no
+// sheets, roasters, beans or shots; with merge, the records whose id already
+// exists are kept as they are and reported as skipped when they are the same
+// records, and the restore fails with ErrBackupRestoreConflict otherwise.
+//
+// The records are owned by the user ctx is authenticated as, if any, and by
+// the user with the name of their owner in the backup otherwise, created
+// when missing.
+func (s *BackupService) Restore(ctx context.Context, backup *Backup, merge bool) (*Report, error) {
+	records := &sql.Backup{
+		Sheets:   make([]sql.Sheet, 0, len(backup.Sheets)),
+		Roasters: make([]sql.Roaster, 0, len(backup.Roasters)),
+		Beans:    make([]sql.Beans, 0, len(backup.Beans)),
+		Shots:    make([]sql.Shot, 0, len(backup.Shots)),
+		Owners: sql.BackupOwners{
+			Users:    make([]sql.User, 0, len(backup.Users)),
+			Sheets:   make(map[int]int),
+			Roasters: make(map[int]int),
+			Beans:    make(map[int]int),
+			Shots:    make(map[int]int),
+		},
+	}
+	setOwner := func(byId map[int]int, id int, ownerId *int) {
+		if ownerId != nil {
+			byId[id] = *ownerId
+		}
+	}
+	for _, u := range backup.Users {
+		records.Owners.Users = append(records.Owners.Users, sql.User{Id: u.Id, Name: u.Name, Role: u.Role, Disabled: u.Disabled, CreatedAt: u.CreatedAt})
+	}
+	for _, sh := range backup.Sheets {
+		setOwner(records.Owners.Sheets, sh.Id, sh.OwnerId)
+		records.Sheets = append(records.Sheets, sql.Sheet{Id: sh.Id, Name: sh.Name, IsTemplate: sh.IsTemplate, CreatedAt: sh.CreatedAt, UpdatedAt: sh.UpdatedAt})
+	}
+	for _, r := range backup.Roasters {
+		setOwner(records.Owners.Roasters, r.Id, r.OwnerId)
+		records.Roasters = append(records.Roasters, sql.Roaster{Id: r.Id, Name: r.Name, CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt})
+	}
+	for _, b := range backup.Beans {
+		setOwner(records.Owners.Beans, b.Id, b.OwnerId)
+		records.Beans = append(records.Beans, sql.Beans{
+			Id: b.Id, Name: b.Name, Roaster: &sql.Roaster{Id: b.RoasterId}, RoastDate: b.RoastDate, RoastLevel: b.RoastLevel,
+			GreenCoffeeId: b.GreenCoffeeId, GreenWeight: b.GreenWeight, Price: b.Price, Currency: b.Currency, BagWeight: b.BagWeight,
+			CreatedAt: b.CreatedAt, UpdatedAt: b.UpdatedAt,
+		})
+	}
+	for _, sh := range backup.Shots {
+		setOwner(records.Owners.Shots, sh.Id, sh.OwnerId)
+		records.Shots = append(records.Shots, sql.Shot{
+			Id: sh.Id, Sheet: &sql.Sheet{Id: sh.SheetId}, Beans: &sql.Beans{Id: sh.BeansId}, GrindSetting: sh.GrindSetting,
+			QuantityIn: sh.QuantityIn, QuantityOut: sh.QuantityOut, ShotTime: time.Duration(sh.ShotTimeMs) * time.Millisecond,
+			WaterTemperature: sh.WaterTemperature, Rating: sh.Rating, IsTooBitter: sh.IsTooBitter, IsTooSour: sh.IsTooSour,
+			ComparisonWithPreviousResult: sh.ComparisonWithPreviousResult, AdditionalNotes: sh.AdditionalNotes,
+			CreatedAt: sh.CreatedAt, UpdatedAt: sh.UpdatedAt,
+		})
+	}
+
+	result, err := s.backups.RestoreBackup(ctx, records, merge)
+	if err != nil {
+		msg := "could not restore backup"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+	return &Report{
+		Users:    Count(result.Users),
+		Sheets:   Count(result.Sheets),
+		Roasters: Count(result.Roasters),
+		Beans:    Count(result.Beans),
+		Shots:    Count(result.Shots),
+	}, nil
+}
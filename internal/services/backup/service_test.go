@@ -0,0 +1,226 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+// MockRepository serves the sheets, roasters, beans and shots of a backup
+// and their owners, and records the backup restored.
+type MockRepository struct {
+	sheets   []sql.Sheet
+	roasters []sql.Roaster
+	beans    []sql.Beans
+	shots    []sql.Shot
+	owners   sql.BackupOwners
+	err      error
+
+	restored *sql.Backup
+	merge    bool
+	result   *sql.RestoreResult
+}
+
+func (m *MockRepository) CreateSheet(ctx context.Context, sheet *sql.Sheet) error { return nil }
+func (m *MockRepository) GetSheetById(ctx context.Context, id int) (*sql.Sheet, error) {
+	return nil, nil
+}
+func (m *MockRepository) GetSheetByName(ctx context.Context, name string) (*sql.Sheet, error) {
+	return nil, nil
+}
+func (m *MockRepository) GetAllSheets(ctx context.Context) ([]sql.Sheet, error) {
+	return m.sheets, m.err
+}
+func (m *MockRepository) UpdateSheetById(ctx context.Context, id int, sheet *sql.Sheet) (*sql.Sheet, error) {
+	return nil, nil
+}
+func (m *MockRepository) DeleteSheetById(ctx context.Context, id int) error { return nil }
+func (m *MockRepository) CloneSheet(ctx context.Context, id int, name string, copyBestShot bool) (int, error) {
+	return 0, nil
+}
+
+func (m *MockRepository) CreateRoaster(ctx context.Context, roaster *sql.Roaster) error { return nil }
+func (m *MockRepository) GetRoasterById(ctx context.Context, id int) (*sql.Roaster, error) {
+	return nil, nil
+}
+func (m *MockRepository) GetRoasterByName(ctx context.Context, name string) (*sql.Roaster, error) {
+	return nil, nil
+}
+func (m *MockRepository) GetAllRoasters(ctx context.Context) ([]sql.Roaster, error) {
+	return m.roasters, nil
+}
+func (m *MockRepository) UpdateRoasterById(ctx context.Context, id int, roaster *sql.Roaster) (*sql.Roaster, error) {
+	return nil, nil
+}
+func (m *MockRepository) DeleteRoasterById(ctx context.Context, id int) error { return nil }
+
+func (m *MockRepository) CreateBeans(ctx context.Context, beans *sql.Beans) (int, error) {
+	return 0, nil
+}
+func (m *MockRepository) GetBeansById(ctx context.Context, id int) (*sql.Beans, error) {
+	return nil, nil
+}
+func (m *MockRepository) GetAllBeans(ctx context.Context) ([]sql.Beans, error) {
+	return m.beans, nil
+}
+func (m *MockRepository) UpdateBeansById(ctx context.Context, id int, beans *sql.Beans) (*sql.Beans, error) {
+	return nil, nil
+}
+func (m *MockRepository) DeleteBeansById(ctx context.Context, id int) error { return nil }
+
+func (m *MockRepository) CreateShot(ctx context.Context, shot *sql.Shot) (int, error) {
+	return 0, nil
+}
+func (m *MockRepository) GetShotById(ctx context.Context, id int) (*sql.Shot, error) {
+	return nil, nil
+}
+func (m *MockRepository) GetAllShots(ctx context.Context) ([]sql.Shot, error) {
+	return m.shots, nil
+}
+func (m *MockRepository) GetShotsBySheetId(ctx context.Context, sheetId int) ([]sql.Shot, error) {
+	return nil, nil
+}
+func (m *MockRepository) UpdateShotById(ctx context.Context, id int, shot *sql.Shot) (*sql.Shot, error) {
+	return nil, nil
+}
+func (m *MockRepository) DeleteShotById(ctx context.Context, id int) error { return nil }
+func (m *MockRepository) GetShotProfileById(ctx context.Context, id int) ([]sql.ShotProfileSample, error) {
+	return nil, nil
+}
+func (m *MockRepository) UpdateShotProfileById(ctx context.Context, id int, samples []sql.ShotProfileSample) error {
+	return nil
+}
+
+func (m *MockRepository) GetBackupOwners(ctx context.Context) (*sql.BackupOwners, error) {
+	return &m.owners, m.err
+}
+
+func (m *MockRepository) RestoreBackup(ctx context.Context, backup *sql.Backup, merge bool) (*sql.RestoreResult, error) {
+	m.restored, m.merge = backup, merge
+	return m.result, m.err
+}
+
+func (m *MockRepository) Ping(ctx context.Context) error { return nil }
+
+func newTestService(m *MockRepository) *BackupService {
+	return New(m, m, m, m, m)
+}
+
+func TestBackup(t *testing.T) {
+	created := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	greenCoffeeId := 2
+	m := &MockRepository{
+		sheets:   []sql.Sheet{{Id: 7, Name: "Linea Mini", IsTemplate: true, CreatedAt: &created}, {Id: 3, Name: "Gaggia", CreatedAt: &created, UpdatedAt: &created}},
+		roasters: []sql.Roaster{{Id: 4, Name: "Square Mile", CreatedAt: &created}},
+		beans: []sql.Beans{{Id: 5, Name: "Red Brick", Roaster: &sql.Roaster{Id: 4, Name: "Square Mile"}, RoastLevel: sql.RoastLevelMedium,
+			GreenCoffeeId: &greenCoffeeId, Price: 14.5, Currency: "GBP", BagWeight: 350, CreatedAt: &created}},
+		shots: []sql.Shot{{Id: 9, Sheet: &sql.Sheet{Id: 3}, Beans: &sql.Beans{Id: 5}, GrindSetting: 12, QuantityIn: 18, QuantityOut: 36,
+			ShotTime: 28500 * time.Millisecond, WaterTemperature: 93, Rating: 8, IsTooSour: true, ComparisonWithPreviousResult: sql.Better,
+			AdditionalNotes: "sweet", CreatedAt: &created}},
+		owners: sql.BackupOwners{
+			Users:  []sql.User{{Id: 2, Name: "alice", Role: "admin", CreatedAt: &created}},
+			Sheets: map[int]int{7: 2},
+			Shots:  map[int]int{9: 2},
+		},
+	}
+	ownerId := 2
+
+	backup, err := newTestService(m).Backup(context.Background())
+	if err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+
+	if backup.Version != Version || backup.CreatedAt.IsZero() {
+		t.Errorf("Backup() version = %d, created at %v, want version %d and a creation time", backup.Version, backup.CreatedAt, Version)
+	}
+	wantUsers := []User{{Id: 2, Name: "alice", Role: "admin", CreatedAt: &created}}
+	if !reflect.DeepEqual(backup.Users, wantUsers) {
+		t.Errorf("Backup() users = %+v, want %+v", backup.Users, wantUsers)
+	}
+	wantSheets := []Sheet{{Id: 3, Name: "Gaggia", CreatedAt: &created, UpdatedAt: &created}, {Id: 7, OwnerId: &ownerId, Name: "Linea Mini", IsTemplate: true, CreatedAt: &created}}
+	if !reflect.DeepEqual(backup.Sheets, wantSheets) {
+		t.Errorf("Backup() sheets = %+v, want %+v ordered by id", backup.Sheets, wantSheets)
+	}
+	wantBeans := []Beans{{Id: 5, Name: "Red Brick", RoasterId: 4, RoastLevel: sql.RoastLevelMedium,
+		GreenCoffeeId: &greenCoffeeId, Price: 14.5, Currency: "GBP", BagWeight: 350, CreatedAt: &created}}
+	if !reflect.DeepEqual(backup.Beans, wantBeans) {
+		t.Errorf("Backup() beans = %+v, want %+v", backup.Beans, wantBeans)
+	}
+	wantShots := []Shot{{Id: 9, OwnerId: &ownerId, SheetId: 3, BeansId: 5, GrindSetting: 12, QuantityIn: 18, QuantityOut: 36, ShotTimeMs: 28500,
+		WaterTemperature: 93, Rating: 8, IsTooSour: true, ComparisonWithPreviousResult: sql.Better, AdditionalNotes: "sweet", CreatedAt: &created}}
+	if !reflect.DeepEqual(backup.Shots, wantShots) {
+		t.Errorf("Backup() shots = %+v, want %+v", backup.Shots, wantShots)
+	}
+	if len(backup.Roasters) != 1 || backup.Roasters[0].Name != "Square Mile" {
+		t.Errorf("Backup() roasters = %+v, want Square Mile", backup.Roasters)
+	}
+}
+
+func TestBackupError(t *testing.T) {
+	errDatabase := errors.New("connection refused")
+
+	_, err := newTestService(&MockRepository{err: errDatabase}).Backup(context.Background())
+	if !errors.Is(err, errDatabase) {
+		t.Fatalf("Backup() error = %v, want %v", err, errDatabase)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	created := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	ownerId := 2
+	m := &MockRepository{result: &sql.RestoreResult{
+		Users:  sql.RestoreCount{Restored: 1},
+		Sheets: sql.RestoreCount{Restored: 1}, Roasters: sql.RestoreCount{Skipped: 1},
+		Beans: sql.RestoreCount{Restored: 1}, Shots: sql.RestoreCount{Restored: 1},
+	}}
+	backup := &Backup{
+		Version:  Version,
+		Users:    []User{{Id: 2, Name: "alice", Role: "user"}},
+		Sheets:   []Sheet{{Id: 3, Name: "Gaggia", CreatedAt: &created}},
+		Roasters: []Roaster{{Id: 4, Name: "Square Mile"}},
+		Beans:    []Beans{{Id: 5, OwnerId: &ownerId, Name: "Red Brick", RoasterId: 4, RoastLevel: sql.RoastLevelDark}},
+		Shots:    []Shot{{Id: 9, SheetId: 3, BeansId: 5, ShotTimeMs: 28500, Rating: 8, UpdatedAt: &created}},
+	}
+
+	report, err := newTestService(m).Restore(context.Background(), backup, true)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	want := Report{Users: Count{Restored: 1}, Sheets: Count{Restored: 1}, Roasters: Count{Skipped: 1}, Beans: Count{Restored: 1}, Shots: Count{Restored: 1}}
+	if *report != want {
+		t.Errorf("Restore() = %+v, want %+v", *report, want)
+	}
+	if !m.merge {
+		t.Error("Restore() did not merge")
+	}
+	wantRecords := &sql.Backup{
+		Sheets:   []sql.Sheet{{Id: 3, Name: "Gaggia", CreatedAt: &created}},
+		Roasters: []sql.Roaster{{Id: 4, Name: "Square Mile"}},
+		Beans:    []sql.Beans{{Id: 5, Name: "Red Brick", Roaster: &sql.Roaster{Id: 4}, RoastLevel: sql.RoastLevelDark}},
+		Shots:    []sql.Shot{{Id: 9, Sheet: &sql.Sheet{Id: 3}, Beans: &sql.Beans{Id: 5}, ShotTime: 28500 * time.Millisecond, Rating: 8, UpdatedAt: &created}},
+		Owners: sql.BackupOwners{
+			Users:    []sql.User{{Id: 2, Name: "alice", Role: "user"}},
+			Sheets:   map[int]int{},
+			Roasters: map[int]int{},
+			Beans:    map[int]int{5: 2},
+			Shots:    map[int]int{},
+		},
+	}
+	if !reflect.DeepEqual(m.restored, wantRecords) {
+		t.Errorf("Restore() restored %+v, want %+v", m.restored, wantRecords)
+	}
+}
+
+func TestRestoreError(t *testing.T) {
+	errDatabase := errors.New("connection refused")
+
+	_, err := newTestService(&MockRepository{err: errDatabase}).Restore(context.Background(), &Backup{Version: Version}, false)
+	if !errors.Is(err, errDatabase) {
+		t.Fatalf("Restore() error = %v, want %v", err, errDatabase)
+	}
+}
@@ -3,6 +3,7 @@ package bean
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/lescactus/espressoapi-go/internal/errors"
@@ -15,17 +16,25 @@ import (
 
 // Bean
 //
-// Beans have a name, a roaster, a roast date and a roast level.
+// Beans have a name, a roaster, a roast date and a roast level. Beans roasted
+// from a green coffee stock record it, with the green weight, in grams, drawn
+// from it. Price is what a bag of BagWeight grams cost, in Currency, and is
+// what the cost of a shot is derived from.
 //
 // swagger:model
 type Bean struct {
-	Id         int              `json:"id"`
-	Roaster    *roaster.Roaster `json:"roaster"`
-	Name       string           `json:"name"`
-	RoastDate  *time.Time       `json:"roast_date"`
-	RoastLevel sql.RoastLevel   `json:"roast_level"`
-	CreatedAt  *time.Time       `json:"created_at"`
-	UpdatedAt  *time.Time       `json:"updated_at"`
+	Id            int              `json:"id"`
+	Roaster       *roaster.Roaster `json:"roaster"`
+	Name          string           `json:"name"`
+	RoastDate     *time.Time       `json:"roast_date"`
+	RoastLevel    sql.RoastLevel   `json:"roast_level"`
+	GreenCoffeeId *int             `json:"green_coffee_id"`
+	GreenWeight   float64          `json:"green_weight"`
+	Price         float64          `json:"price"`
+	Currency      string           `json:"currency"`
+	BagWeight     float64          `json:"bag_weight"`
+	CreatedAt     *time.Time       `json:"created_at"`
+	UpdatedAt     *time.Time       `json:"updated_at"`
 }
 
 // SQLToBean converts a sql.Beans object to a Bean object.
@@ -42,6 +51,11 @@ func SQLToBean(bean *sql.Beans) *Bean {
 	b.Name = bean.Name
 	b.RoastDate = bean.RoastDate
 	b.RoastLevel = bean.RoastLevel
+	b.GreenCoffeeId = bean.GreenCoffeeId
+	b.GreenWeight = bean.GreenWeight
+	b.Price = bean.Price
+	b.Currency = bean.Currency
+	b.BagWeight = bean.BagWeight
 	b.CreatedAt = bean.CreatedAt
 	b.UpdatedAt = bean.UpdatedAt
 
@@ -62,6 +76,11 @@ func BeanToSQL(bean *Bean) *sql.Beans {
 	sqlBeans.Name = bean.Name
 	sqlBeans.RoastDate = bean.RoastDate
 	sqlBeans.RoastLevel = bean.RoastLevel
+	sqlBeans.GreenCoffeeId = bean.GreenCoffeeId
+	sqlBeans.GreenWeight = bean.GreenWeight
+	sqlBeans.Price = bean.Price
+	sqlBeans.Currency = bean.Currency
+	sqlBeans.BagWeight = bean.BagWeight
 	sqlBeans.CreatedAt = bean.CreatedAt
 	sqlBeans.UpdatedAt = bean.UpdatedAt
 
@@ -107,6 +126,17 @@ func (b *BeanService) CreateBean(ctx context.Context, bean *Bean) (*Bean, error)
 		zerolog.Ctx(ctx).Err(err).Msg(msg)
 		return nil, fmt.Errorf("%s: %w", msg, err)
 	}
+	if bean.GreenWeight < 0 {
+		err := errors.ErrBeansGreenWeightOutOfRange
+		msg := "could not create beans"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+	if err := validateCost(bean); err != nil {
+		msg := "could not create beans"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
 
 	id, err := b.repository.CreateBeans(ctx, BeanToSQL(bean))
 	if err != nil {
@@ -125,6 +155,32 @@ func (b *BeanService) CreateBean(ctx context.Context, bean *Bean) (*Bean, error)
 	return createdBean, nil
 }
 
+// validateCost checks the price, bag weight and currency of the given beans.
+// The currency is normalised to its upper case ISO 4217 form; it may be left
+// empty when no price is known.
+func validateCost(bean *Bean) error {
+	if bean.Price < 0 {
+		return errors.ErrBeansPriceOutOfRange
+	}
+	if bean.BagWeight < 0 {
+		return errors.ErrBeansBagWeightOutOfRange
+	}
+
+	bean.Currency = strings.ToUpper(strings.TrimSpace(bean.Currency))
+	if bean.Currency == "" {
+		return nil
+	}
+	if len(bean.Currency) != 3 {
+		return errors.ErrBeansCurrencyIsInvalid
+	}
+	for _, r := range bean.Currency {
+		if r < 'A' || r > 'Z' {
+			return errors.ErrBeansCurrencyIsInvalid
+		}
+	}
+	return nil
+}
+
 func (b *BeanService) GetBeanById(ctx context.Context, id int) (*Bean, error) {
 	bean, err := b.repository.GetBeansById(ctx, id)
 	if err != nil {
@@ -172,6 +228,17 @@ func (b *BeanService) UpdateBeanById(ctx context.Context, id int, bean *Bean) (*
 		zerolog.Ctx(ctx).Err(err).Msg(msg)
 		return nil, fmt.Errorf("%s: %w", msg, err)
 	}
+	if bean.GreenWeight < 0 {
+		err := errors.ErrBeansGreenWeightOutOfRange
+		msg := "could not update beans by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+	if err := validateCost(bean); err != nil {
+		msg := "could not update beans by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
 
 	bean.Id = id
 	sqlBean := BeanToSQL(bean)
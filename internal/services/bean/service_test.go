@@ -2,6 +2,7 @@ package bean
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -148,6 +149,27 @@ func TestBeanServiceCreateBean(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name:    "Green weight is negative",
+			fields:  fields{&MockBeanRepository{}},
+			args:    args{context.TODO(), &Bean{Name: "bean01", GreenWeight: -1, Roaster: &roaster.Roaster{Id: 1, Name: "roaster01"}}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Price is negative",
+			fields:  fields{&MockBeanRepository{}},
+			args:    args{context.TODO(), &Bean{Name: "bean01", Price: -1, Roaster: &roaster.Roaster{Id: 1, Name: "roaster01"}}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Currency is invalid",
+			fields:  fields{&MockBeanRepository{}},
+			args:    args{context.TODO(), &Bean{Name: "bean01", Price: 12, Currency: "euro", Roaster: &roaster.Roaster{Id: 1, Name: "roaster01"}}},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "No error",
 			fields:  fields{&MockBeanRepository{}},
@@ -563,3 +585,30 @@ func TestSQLToBean(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCost(t *testing.T) {
+	tests := []struct {
+		name         string
+		bean         Bean
+		wantCurrency string
+		wantErr      error
+	}{
+		{name: "No price", bean: Bean{}, wantCurrency: ""},
+		{name: "Currency is normalised", bean: Bean{Price: 14.5, Currency: " eur ", BagWeight: 250}, wantCurrency: "EUR"},
+		{name: "Negative price", bean: Bean{Price: -0.01}, wantErr: errors.ErrBeansPriceOutOfRange},
+		{name: "Negative bag weight", bean: Bean{BagWeight: -250}, wantErr: errors.ErrBeansBagWeightOutOfRange},
+		{name: "Currency too long", bean: Bean{Currency: "EURO"}, wantErr: errors.ErrBeansCurrencyIsInvalid},
+		{name: "Currency with digits", bean: Bean{Currency: "E1R"}, wantErr: errors.ErrBeansCurrencyIsInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCost(&tt.bean)
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("validateCost() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && tt.bean.Currency != tt.wantCurrency {
+				t.Errorf("validateCost() currency = %q, want %q", tt.bean.Currency, tt.wantCurrency)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package beanconqueror
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+// backupFileName is the name of the backup in the zip archives written by
+// Beanconqueror.
+const backupFileName = "Beanconqueror.json"
+
+// Backup is a Beanconqueror backup, restricted to the collections mapped onto
+// sheets, roasters, beans and shots.
+type Backup struct {
+	Beans        []Bean        `json:"BEANS"`
+	Brews        []Brew        `json:"BREWS"`
+	Mills        []Mill        `json:"MILL"`
+	Preparations []Preparation `json:"PREPARATION"`
+	Settings     []Settings    `json:"SETTINGS,omitempty"`
+}
+
+// Config identifies a record of a backup.
+type Config struct {
+	UUID          string `json:"uuid"`
+	UnixTimestamp int64  `json:"unix_timestamp"`
+}
+
+// Bean is a bag of beans. Beanconqueror has no roasters of its own: the
+// roaster is a property of the beans.
+type Bean struct {
+	Config       Config  `json:"config"`
+	Name         string  `json:"name"`
+	Roaster      string  `json:"roaster"`
+	RoastingDate string  `json:"roastingDate"`
+	Roast        string  `json:"roast"`
+	Weight       float64 `json:"weight"`
+	Cost         float64 `json:"cost"`
+	Note         string  `json:"note"`
+	Finished     bool    `json:"finished"`
+}
+
+// Preparation is a brewing method, such as a portafilter or a V60.
+type Preparation struct {
+	Config   Config `json:"config"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Finished bool   `json:"finished"`
+}
+
+// Mill is a grinder.
+type Mill struct {
+	Config   Config `json:"config"`
+	Name     string `json:"name"`
+	Finished bool   `json:"finished"`
+}
+
+// Brew is a cup brewed from beans, with a preparation and a mill referenced
+// by their uuid. The beverage quantity is the yield weighed in the cup, and
+// the quantity the water poured.
+type Brew struct {
+	Config                   Config  `json:"config"`
+	Bean                     string  `json:"bean"`
+	MethodOfPreparation      string  `json:"method_of_preparation"`
+	Mill                     string  `json:"mill"`
+	GrindSize                string  `json:"grind_size"`
+	GrindWeight              float64 `json:"grind_weight"`
+	BrewTemperature          float64 `json:"brew_temperature"`
+	BrewTime                 float64 `json:"brew_time"`
+	BrewTimeMilliseconds     float64 `json:"brew_time_milliseconds"`
+	BrewQuantity             float64 `json:"brew_quantity"`
+	BrewBeverageQuantity     float64 `json:"brew_beverage_quantity"`
+	BrewBeverageQuantityType string  `json:"brew_beverage_quantity_type"`
+	Rating                   float64 `json:"rating"`
+	Note                     string  `json:"note"`
+}
+
+// Settings holds the settings of a backup which change the meaning of a
+// brew, such as the scale of its rating. Backups hold a single one.
+type Settings struct {
+	BrewRating float64 `json:"brew_rating"`
+}
+
+// ParseBackup reads a backup from data, either the JSON backup of
+// Beanconqueror or the zip archive holding it. It returns
+// ErrImportBackupIsInvalid when data is neither.
+func ParseBackup(data []byte) (*Backup, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		var err error
+		if data, err = unzipBackup(data); err != nil {
+			return nil, errors.ErrImportBackupIsInvalid
+		}
+	}
+
+	var b Backup
+	if err := json.Unmarshal(data, &b); err != nil || b.Beans == nil || b.Brews == nil {
+		return nil, errors.ErrImportBackupIsInvalid
+	}
+	return &b, nil
+}
+
+// unzipBackup returns the content of the backup in the zip archive data.
+func unzipBackup(data []byte) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range zr.File {
+		if path.Base(f.Name) != backupFileName {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+// roastLevels maps the roasts of Beanconqueror, named after the roast degrees
+// of the SCA, onto roast levels. Unknown and custom roasts are medium.
+var roastLevels = map[string]sql.RoastLevel{
+	"CINNAMON_ROAST":       sql.RoastLevelLight,
+	"AMERICAN_ROAST":       sql.RoastLevelLight,
+	"NEW_ENGLAND_ROAST":    sql.RoastLevelLight,
+	"HALF_CITY_ROAST":      sql.RoastLevelLight,
+	"MODERATE_LIGHT_ROAST": sql.RoastLevelLightToMedium,
+	"CITY_ROAST":           sql.RoastLevelLightToMedium,
+	"CITY_PLUS_ROAST":      sql.RoastLevelMedium,
+	"FULL_CITY_ROAST":      sql.RoastLevelMedium,
+	"FULL_CITY_PLUS_ROAST": sql.RoastLevelMediumToDark,
+	"ITALIAN_ROAST":        sql.RoastLevelDark,
+	"VIEANNA_ROAST":        sql.RoastLevelDark,
+	"FRENCH_ROAST":         sql.RoastLevelDark,
+}
+
+// roasts maps roast levels back onto the roasts of Beanconqueror, such that
+// a backup exported and imported again keeps its roast levels.
+var roasts = map[sql.RoastLevel]string{
+	sql.RoastLevelLight:         "HALF_CITY_ROAST",
+	sql.RoastLevelLightToMedium: "CITY_ROAST",
+	sql.RoastLevelMedium:        "FULL_CITY_ROAST",
+	sql.RoastLevelMediumToDark:  "FULL_CITY_PLUS_ROAST",
+	sql.RoastLevelDark:          "FRENCH_ROAST",
+}
+
+func parseRoastLevel(roast string) sql.RoastLevel {
+	if level, ok := roastLevels[strings.ToUpper(strings.TrimSpace(roast))]; ok {
+		return level
+	}
+	return sql.RoastLevelMedium
+}
+
+// parseRoastingDate returns the day of s, an ISO 8601 timestamp, or nil if s
+// is empty or not a timestamp. Beanconqueror records the local midnight of
+// the day in UTC, which is rounded to the nearest midnight to get the day
+// back whatever the time zone of the phone.
+func parseRoastingDate(s string) *time.Time {
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(s))
+	if err != nil {
+		return nil
+	}
+	t = t.UTC().Add(12 * time.Hour)
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return &t
+}
@@ -0,0 +1,107 @@
+package beanconqueror
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+// testBackup is a trimmed down Beanconqueror backup: two preparations, two
+// beans from the same roaster, a mill and three brews, the last one of beans
+// missing from the backup.
+const testBackup = `{
+  "BEANS": [
+    {"config": {"uuid": "b-1", "unix_timestamp": 1697000000}, "name": "Red Brick", "roaster": "Square Mile",
+     "roastingDate": "2026-09-30T22:00:00.000Z", "roast": "FULL_CITY_PLUS_ROAST", "weight": 350, "cost": 14.5, "finished": false},
+    {"config": {"uuid": "b-2", "unix_timestamp": 1697000001}, "name": "Kamwangi", "roaster": "Square Mile",
+     "roastingDate": "", "roast": "UNKNOWN", "weight": 250, "cost": 0}
+  ],
+  "BREWS": [
+    {"config": {"uuid": "w-1", "unix_timestamp": 1697100000}, "bean": "b-1", "method_of_preparation": "p-1", "mill": "m-1",
+     "grind_size": "12.4", "grind_weight": 18, "brew_temperature": 94, "brew_time": 28, "brew_time_milliseconds": 500,
+     "brew_quantity": 0, "brew_beverage_quantity": 36.5, "brew_beverage_quantity_type": "GR", "rating": 4, "note": "sweet"},
+    {"config": {"uuid": "w-2", "unix_timestamp": 1697100001}, "bean": "b-2", "method_of_preparation": "p-2", "mill": "",
+     "grind_size": "fine", "grind_weight": 15, "brew_temperature": 0, "brew_time": 150,
+     "brew_quantity": 250, "brew_beverage_quantity": 0, "rating": 2, "note": ""},
+    {"config": {"uuid": "w-3", "unix_timestamp": 1697100002}, "bean": "b-9", "method_of_preparation": "p-1", "grind_weight": 18}
+  ],
+  "MILL": [{"config": {"uuid": "m-1"}, "name": "Niche Zero", "finished": false}],
+  "PREPARATION": [
+    {"config": {"uuid": "p-1"}, "name": "Linea Mini", "type": "PORTAFILTER"},
+    {"config": {"uuid": "p-2"}, "name": "V60", "type": "V60"}
+  ],
+  "SETTINGS": [{"brew_rating": 5}],
+  "VERSION": []
+}`
+
+func TestParseBackup(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	fw, err := zw.Create("Beanconqueror.json")
+	if err != nil {
+		t.Fatalf("create zip entry: %v", err)
+	}
+	fw.Write([]byte(`{"BEANS": [], "BREWS": [{"config": {"uuid": "w-1"}}]}`))
+	zw.Close()
+
+	tests := []struct {
+		name    string
+		data    []byte
+		brews   int
+		wantErr error
+	}{
+		{name: "json backup", data: []byte("\xef\xbb\xbf" + `{"BEANS": [], "BREWS": [], "MILL": []}`), brews: 0},
+		{name: "zip archive", data: archive.Bytes(), brews: 1},
+		{name: "json without brews", data: []byte(`{"BEANS": []}`), wantErr: domainerrors.ErrImportBackupIsInvalid},
+		{name: "not json", data: []byte("clock 1700000000"), wantErr: domainerrors.ErrImportBackupIsInvalid},
+		{name: "truncated zip archive", data: archive.Bytes()[:40], wantErr: domainerrors.ErrImportBackupIsInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup, err := ParseBackup(tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseBackup() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && len(backup.Brews) != tt.brews {
+				t.Errorf("ParseBackup() brews = %d, want %d", len(backup.Brews), tt.brews)
+			}
+		})
+	}
+}
+
+func TestParseRoastingDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "2026-09-30T22:00:00.000Z", want: "2026-10-01"},
+		{in: "2026-10-01T05:00:00Z", want: "2026-10-01"},
+		{in: "2026-10-01T00:00:00+02:00", want: "2026-10-01"},
+		{in: "", want: ""},
+		{in: "yesterday", want: ""},
+	}
+
+	for _, tt := range tests {
+		got := parseRoastingDate(tt.in)
+		if (got == nil) != (tt.want == "") || (got != nil && got.Format(time.DateOnly) != tt.want) {
+			t.Errorf("parseRoastingDate(%q) = %v, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRoastLevelsRoundTrip(t *testing.T) {
+	for level := sql.RoastLevelLight; level <= sql.RoastLevelDark; level++ {
+		if got := parseRoastLevel(roasts[level]); got != level {
+			t.Errorf("parseRoastLevel(%q) = %v, want %v", roasts[level], got, level)
+		}
+	}
+	if got := parseRoastLevel("CUSTOM_ROAST"); got != sql.RoastLevelMedium {
+		t.Errorf("parseRoastLevel(CUSTOM_ROAST) = %v, want medium", got)
+	}
+}
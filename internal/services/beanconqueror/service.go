@@ -0,0 +1,443 @@
+// Package beanconqueror imports and exports the backups of the Beanconqueror
+// mobile app: its preparations as sheets, the roasters named by its beans,
+// its beans and its brews as shots.
+package beanconqueror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// Source is the source of the imported brews, recorded to detect the
+	// brews imported twice.
+	Source = "beanconqueror"
+
+	// DefaultSheetName is the sheet of the brews without a preparation.
+	DefaultSheetName = "Beanconqueror"
+
+	// defaultBrewRating is the highest rating of a brew, unless the settings
+	// of the backup give another one.
+	defaultBrewRating = 5.0
+
+	// defaultWaterTemperature is recorded for brews without a temperature,
+	// as for shots created through the API.
+	defaultWaterTemperature = 93.0
+
+	// maxNotesLength is the size of the additional_notes column.
+	maxNotesLength = 511
+
+	unknownRoaster = "Unknown roaster"
+	unknownBeans   = "Unknown beans"
+
+	// exportPreparationType is the type of the preparations exported from
+	// sheets, which are all about espresso.
+	exportPreparationType = "PORTAFILTER"
+)
+
+// Kind is the kind of a record of a backup.
+//
+// swagger:model BeanconquerorKind
+// enum: preparation,roaster,beans,brew
+type Kind string
+
+const (
+	KindPreparation Kind = "preparation"
+	KindRoaster     Kind = "roaster"
+	KindBeans       Kind = "beans"
+	KindBrew        Kind = "brew"
+)
+
+// Status is the outcome of the import of a record of a backup.
+//
+// swagger:model BeanconquerorStatus
+// enum: created,existing,failed
+type Status string
+
+const (
+	// StatusCreated is a record imported.
+	StatusCreated Status = "created"
+	// StatusExisting is a record imported before, or matching an existing
+	// one by name.
+	StatusExisting Status = "existing"
+	// StatusFailed is a record which could not be imported.
+	StatusFailed Status = "failed"
+)
+
+// Mapping maps a record of a backup onto what it was imported as: a
+// preparation onto a sheet, a roaster onto a roaster, beans onto beans and
+// a brew onto a shot.
+//
+// swagger:model BeanconquerorMapping
+type Mapping struct {
+	// The kind of the record
+	Kind Kind `json:"kind"`
+
+	// The uuid of the record in the backup. Roasters have none.
+	ExternalId string `json:"external_id,omitempty"`
+
+	// The name of the record, or of the beans of a brew
+	Name string `json:"name"`
+
+	// The id of the sheet, roaster, beans or shot the record maps onto
+	Id *int `json:"id,omitempty"`
+
+	// The outcome of the import of the record: created, existing or failed
+	Status Status `json:"status"`
+
+	// Why the record could not be imported
+	Error string `json:"error,omitempty"`
+}
+
+// Report is the outcome of the import of a backup
+//
+// swagger:model BeanconquerorImportReport
+type Report struct {
+	// The number of records created
+	Created int `json:"created"`
+
+	// The number of records which existed already
+	Existing int `json:"existing"`
+
+	// The number of records which could not be imported
+	Failed int `json:"failed"`
+
+	// The mapping of every record, preparations first, then roasters, beans
+	// and brews
+	Mappings []Mapping `json:"mappings"`
+}
+
+// add records the outcome of the import of m as the record id. It returns err
+// when it is not about the record but about the database, which stops the
+// import.
+func (r *Report) add(m Mapping, id int, created bool, err error) error {
+	switch {
+	case errors.Is(err, domainerrors.ErrImportShotAlreadyExists):
+		m.Status = StatusExisting
+	case errors.Is(err, domainerrors.ErrSheetAlreadyExists), errors.Is(err, domainerrors.ErrRoasterAlreadyExists),
+		errors.Is(err, domainerrors.ErrShotTimeOutOfRange), errors.Is(err, domainerrors.ErrImportBrewIsInvalid):
+		m.Status, m.Error = StatusFailed, err.Error()
+	case err != nil:
+		return err
+	case created:
+		m.Status, m.Id = StatusCreated, &id
+	default:
+		m.Status, m.Id = StatusExisting, &id
+	}
+
+	switch m.Status {
+	case StatusCreated:
+		r.Created++
+	case StatusExisting:
+		r.Existing++
+	case StatusFailed:
+		r.Failed++
+	}
+	r.Mappings = append(r.Mappings, m)
+	return nil
+}
+
+type Service interface {
+	Import(ctx context.Context, data []byte) (*Report, error)
+	Export(ctx context.Context) (*Backup, error)
+}
+
+type BeanconquerorService struct {
+	imports repository.ShotImportRepository
+	sheets  repository.SheetRepository
+	beans   repository.BeansRepository
+	shots   repository.ShotRepository
+}
+
+var _ Service = (*BeanconquerorService)(nil)
+
+func New(imports repository.ShotImportRepository, sheets repository.SheetRepository, beans repository.BeansRepository, shots repository.ShotRepository) *BeanconquerorService {
+	return &BeanconquerorService{imports: imports, sheets: sheets, beans: beans, shots: shots}
+}
+
+// Import imports the backup data. Preparations, roasters and beans are
+// matched by name with the existing sheets, roasters and beans, and created
+// when missing; brews are imported as shots once, so that importing the
+// same backup again only reports existing records. Mills have no
+// counterpart: the mill of a brew is kept in the notes of its shot.
+func (s *BeanconquerorService) Import(ctx context.Context, data []byte) (*Report, error) {
+	backup, err := ParseBackup(data)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Mappings: make([]Mapping, 0, len(backup.Preparations)+2*len(backup.Beans)+len(backup.Brews))}
+	fail := func(msg string, err error) (*Report, error) {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	sheets := make(map[string]string, len(backup.Preparations))
+	for _, p := range backup.Preparations {
+		name := orDefault(p.Name, DefaultSheetName)
+		sheets[p.Config.UUID] = name
+		id, created, err := s.imports.GetOrCreateSheet(ctx, name)
+		if err := report.add(Mapping{Kind: KindPreparation, ExternalId: p.Config.UUID, Name: name}, id, created, err); err != nil {
+			return fail("could not import preparation", err)
+		}
+	}
+
+	// roasters holds the id of each roaster, or why it could not be
+	// imported for its beans to fail along with it.
+	type roaster struct {
+		id  int
+		err error
+	}
+	roasters := make(map[string]roaster)
+	for _, b := range backup.Beans {
+		name := orDefault(b.Roaster, unknownRoaster)
+		if _, ok := roasters[name]; ok {
+			continue
+		}
+		id, created, err := s.imports.GetOrCreateRoaster(ctx, name)
+		if err := report.add(Mapping{Kind: KindRoaster, Name: name}, id, created, err); err != nil {
+			return fail("could not import roaster", err)
+		}
+		roasters[name] = roaster{id: id, err: err}
+	}
+
+	beans := make(map[string]Bean, len(backup.Beans))
+	for _, b := range backup.Beans {
+		beans[b.Config.UUID] = b
+		name := orDefault(b.Name, unknownBeans)
+		r := roasters[orDefault(b.Roaster, unknownRoaster)]
+		id, created, err := 0, false, r.err
+		if err == nil {
+			id, created, err = s.imports.GetOrCreateBeans(ctx, &sql.Beans{
+				Name: name, Roaster: &sql.Roaster{Id: r.id},
+				RoastDate: parseRoastingDate(b.RoastingDate), RoastLevel: parseRoastLevel(b.Roast),
+				Price: max(b.Cost, 0), BagWeight: max(b.Weight, 0),
+			})
+		}
+		if err := report.add(Mapping{Kind: KindBeans, ExternalId: b.Config.UUID, Name: name}, id, created, err); err != nil {
+			return fail("could not import beans", err)
+		}
+	}
+
+	uuids := make([]string, 0, len(backup.Brews))
+	for _, b := range backup.Brews {
+		if b.Config.UUID != "" {
+			uuids = append(uuids, b.Config.UUID)
+		}
+	}
+	existing, err := s.imports.GetImportedShotIds(ctx, Source, uuids)
+	if err != nil {
+		return fail("could not get imported brews", err)
+	}
+
+	mills := make(map[string]string, len(backup.Mills))
+	for _, m := range backup.Mills {
+		mills[m.Config.UUID] = strings.TrimSpace(m.Name)
+	}
+	brewRating := defaultBrewRating
+	if len(backup.Settings) > 0 && backup.Settings[0].BrewRating > 0 {
+		brewRating = backup.Settings[0].BrewRating
+	}
+	for _, b := range backup.Brews {
+		bean, ok := beans[b.Bean]
+		m := Mapping{Kind: KindBrew, ExternalId: b.Config.UUID, Name: orDefault(bean.Name, unknownBeans)}
+		if id, ok := existing[b.Config.UUID]; ok {
+			_ = report.add(m, id, false, nil)
+			continue
+		}
+
+		var id int
+		var err error
+		if !ok || b.Config.UUID == "" {
+			err = domainerrors.ErrImportBrewIsInvalid
+		} else {
+			id, err = s.importBrew(ctx, b, bean, orDefault(sheets[b.MethodOfPreparation], DefaultSheetName), mills[b.Mill], brewRating)
+		}
+		if err := report.add(m, id, true, err); err != nil {
+			return fail("could not import brew", err)
+		}
+	}
+
+	return report, nil
+}
+
+// importBrew imports the brew b, of the beans bean, as a shot of the sheet,
+// with the mill in its notes. The rating of the brew is out of brewRating.
+func (s *BeanconquerorService) importBrew(ctx context.Context, b Brew, bean Bean, sheet, mill string, brewRating float64) (int, error) {
+	shotTime := shot.SecondsToDuration(max(b.BrewTime, 0)) + time.Duration(max(b.BrewTimeMilliseconds, 0))*time.Millisecond
+	if shotTime > shot.MaxShotTime {
+		return 0, domainerrors.ErrShotTimeOutOfRange
+	}
+
+	var notes []string
+	if mill != "" {
+		notes = append(notes, "Mill: "+mill)
+	}
+	grindSize := strings.TrimSpace(b.GrindSize)
+	grind, err := strconv.ParseFloat(strings.ReplaceAll(grindSize, ",", "."), 64)
+	if err != nil || math.IsNaN(grind) || math.IsInf(grind, 0) || grind < 0 {
+		grind = 0
+		if grindSize != "" {
+			notes = append(notes, "Grind: "+grindSize)
+		}
+	}
+	if note := strings.TrimSpace(b.Note); note != "" {
+		notes = append(notes, note)
+	}
+
+	yield := b.BrewBeverageQuantity
+	if yield <= 0 {
+		yield = b.BrewQuantity
+	}
+	temperature := b.BrewTemperature
+	if temperature <= 0 {
+		temperature = defaultWaterTemperature
+	}
+
+	return s.imports.ImportShot(ctx, &sql.ImportedShot{
+		Source:      Source,
+		ExternalId:  b.Config.UUID,
+		SheetName:   sheet,
+		RoasterName: orDefault(bean.Roaster, unknownRoaster),
+		BeansName:   orDefault(bean.Name, unknownBeans),
+		RoastDate:   parseRoastingDate(bean.RoastingDate),
+		RoastLevel:  parseRoastLevel(bean.Roast),
+		Shot: &sql.Shot{
+			GrindSetting:                 int(math.Round(grind)),
+			QuantityIn:                   max(b.GrindWeight, 0),
+			QuantityOut:                  max(yield, 0),
+			ShotTime:                     shotTime,
+			WaterTemperature:             temperature,
+			Rating:                       min(max(b.Rating*10/brewRating, 0), 10),
+			ComparisonWithPreviousResult: sql.Unknown,
+			AdditionalNotes:              truncateNotes(strings.Join(notes, "\n")),
+		},
+	})
+}
+
+// Export returns the sheets, beans and shots as a Beanconqueror backup. The
+// brews imported from Beanconqueror keep their uuid; the other records get
+// one made of their kind and id, such as "espressoapi-shot-12". Roasters
+// without beans and the profiles of the shots have no counterpart and are
+// left out.
+func (s *BeanconquerorService) Export(ctx context.Context) (*Backup, error) {
+	fail := func(msg string, err error) (*Backup, error) {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	sheets, err := s.sheets.GetAllSheets(ctx)
+	if err != nil {
+		return fail("could not get all sheets", err)
+	}
+	beans, err := s.beans.GetAllBeans(ctx)
+	if err != nil {
+		return fail("could not get all beans", err)
+	}
+	shots, err := s.shots.GetAllShots(ctx)
+	if err != nil {
+		return fail("could not get all shots", err)
+	}
+	externalIds, err := s.imports.GetShotExternalIds(ctx, Source)
+	if err != nil {
+		return fail("could not get imported brews", err)
+	}
+
+	backup := &Backup{
+		Beans:        make([]Bean, len(beans)),
+		Brews:        make([]Brew, len(shots)),
+		Mills:        []Mill{},
+		Preparations: make([]Preparation, len(sheets)),
+	}
+	for i, sh := range sheets {
+		backup.Preparations[i] = Preparation{
+			Config: exportConfig("sheet", sh.Id, sh.CreatedAt),
+			Name:   sh.Name,
+			Type:   exportPreparationType,
+		}
+	}
+	for i, b := range beans {
+		bean := Bean{
+			Config: exportConfig("beans", b.Id, b.CreatedAt),
+			Name:   b.Name,
+			Roast:  roasts[b.RoastLevel],
+			Weight: b.BagWeight,
+			Cost:   b.Price,
+		}
+		if b.Roaster != nil {
+			bean.Roaster = b.Roaster.Name
+		}
+		if b.RoastDate != nil {
+			bean.RoastingDate = b.RoastDate.UTC().Format(time.RFC3339)
+		}
+		backup.Beans[i] = bean
+	}
+	for i, sh := range shots {
+		config := exportConfig("shot", sh.Id, sh.CreatedAt)
+		if uuid, ok := externalIds[sh.Id]; ok {
+			config.UUID = uuid
+		}
+		brew := Brew{
+			Config:                   config,
+			GrindSize:                strconv.Itoa(sh.GrindSetting),
+			GrindWeight:              sh.QuantityIn,
+			BrewTemperature:          sh.WaterTemperature,
+			BrewTime:                 math.Floor(sh.ShotTime.Seconds()),
+			BrewTimeMilliseconds:     float64((sh.ShotTime % time.Second).Milliseconds()),
+			BrewBeverageQuantity:     sh.QuantityOut,
+			BrewBeverageQuantityType: "GR",
+			Rating:                   sh.Rating * defaultBrewRating / 10,
+			Note:                     sh.AdditionalNotes,
+		}
+		if sh.Sheet != nil {
+			brew.MethodOfPreparation = exportUUID("sheet", sh.Sheet.Id)
+		}
+		if sh.Beans != nil {
+			brew.Bean = exportUUID("beans", sh.Beans.Id)
+		}
+		backup.Brews[i] = brew
+	}
+	return backup, nil
+}
+
+// exportUUID returns the uuid of the record id of the given kind in an
+// exported backup.
+func exportUUID(kind string, id int) string {
+	return "espressoapi-" + kind + "-" + strconv.Itoa(id)
+}
+
+func exportConfig(kind string, id int, createdAt *time.Time) Config {
+	c := Config{UUID: exportUUID(kind, id)}
+	if createdAt != nil {
+		c.UnixTimestamp = createdAt.Unix()
+	}
+	return c
+}
+
+// truncateNotes cuts notes to the size of the additional_notes column.
+func truncateNotes(notes string) string {
+	for len(notes) > maxNotesLength {
+		_, size := utf8.DecodeLastRuneInString(notes)
+		notes = notes[:len(notes)-size]
+	}
+	return notes
+}
+
+func orDefault(s, fallback string) string {
+	if s = strings.TrimSpace(s); s != "" {
+		return s
+	}
+	return fallback
+}
@@ -0,0 +1,360 @@
+package beanconqueror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+// MockRepository is an in-memory store of sheets, roasters, beans and
+// imported shots, keyed by name as the SQL repositories look them up.
+type MockRepository struct {
+	nextId   int
+	sheets   map[string]int
+	roasters map[string]int
+	beans    map[string]int
+	imported map[string]int
+	calls    []*sql.ImportedShot
+	err      error
+
+	allSheets []sql.Sheet
+	allBeans  []sql.Beans
+	allShots  []sql.Shot
+}
+
+func newMockRepository() *MockRepository {
+	return &MockRepository{
+		nextId:   100,
+		sheets:   map[string]int{},
+		roasters: map[string]int{},
+		beans:    map[string]int{},
+		imported: map[string]int{},
+	}
+}
+
+func (m *MockRepository) getOrCreate(ids map[string]int, key string) (int, bool, error) {
+	if m.err != nil {
+		return 0, false, m.err
+	}
+	if id, ok := ids[key]; ok {
+		return id, false, nil
+	}
+	m.nextId++
+	ids[key] = m.nextId
+	return m.nextId, true, nil
+}
+
+func (m *MockRepository) GetImportedShotIds(ctx context.Context, source string, externalIds []string) (map[string]int, error) {
+	ids := make(map[string]int)
+	for _, id := range externalIds {
+		if shotId, ok := m.imported[id]; ok {
+			ids[id] = shotId
+		}
+	}
+	return ids, nil
+}
+
+func (m *MockRepository) ImportShot(ctx context.Context, shot *sql.ImportedShot) (int, error) {
+	m.calls = append(m.calls, shot)
+	if _, ok := m.imported[shot.ExternalId]; ok {
+		return 0, domainerrors.ErrImportShotAlreadyExists
+	}
+	m.nextId++
+	m.imported[shot.ExternalId] = m.nextId
+	return m.nextId, nil
+}
+
+func (m *MockRepository) GetShotExternalIds(ctx context.Context, source string) (map[int]string, error) {
+	ids := make(map[int]string)
+	for externalId, id := range m.imported {
+		ids[id] = externalId
+	}
+	return ids, nil
+}
+
+func (m *MockRepository) GetOrCreateSheet(ctx context.Context, name string) (int, bool, error) {
+	return m.getOrCreate(m.sheets, name)
+}
+
+func (m *MockRepository) GetOrCreateRoaster(ctx context.Context, name string) (int, bool, error) {
+	return m.getOrCreate(m.roasters, name)
+}
+
+func (m *MockRepository) GetOrCreateBeans(ctx context.Context, beans *sql.Beans) (int, bool, error) {
+	return m.getOrCreate(m.beans, beans.Name+"/"+strconv.Itoa(beans.Roaster.Id))
+}
+
+func (m *MockRepository) Ping(ctx context.Context) error { return nil }
+
+func (m *MockRepository) CreateSheet(ctx context.Context, sheet *sql.Sheet) error { return nil }
+
+func (m *MockRepository) GetSheetById(ctx context.Context, id int) (*sql.Sheet, error) {
+	return nil, nil
+}
+
+func (m *MockRepository) GetSheetByName(ctx context.Context, name string) (*sql.Sheet, error) {
+	return nil, nil
+}
+
+func (m *MockRepository) GetAllSheets(ctx context.Context) ([]sql.Sheet, error) {
+	return m.allSheets, m.err
+}
+
+func (m *MockRepository) UpdateSheetById(ctx context.Context, id int, sheet *sql.Sheet) (*sql.Sheet, error) {
+	return nil, nil
+}
+
+func (m *MockRepository) DeleteSheetById(ctx context.Context, id int) error { return nil }
+func (m *MockRepository) CloneSheet(ctx context.Context, id int, name string, copyBestShot bool) (int, error) {
+	return 0, nil
+}
+
+func (m *MockRepository) CreateBeans(ctx context.Context, beans *sql.Beans) (int, error) {
+	return 0, nil
+}
+
+func (m *MockRepository) GetBeansById(ctx context.Context, id int) (*sql.Beans, error) {
+	return nil, nil
+}
+
+func (m *MockRepository) GetAllBeans(ctx context.Context) ([]sql.Beans, error) {
+	return m.allBeans, m.err
+}
+
+func (m *MockRepository) UpdateBeansById(ctx context.Context, id int, beans *sql.Beans) (*sql.Beans, error) {
+	return nil, nil
+}
+
+func (m *MockRepository) DeleteBeansById(ctx context.Context, id int) error { return nil }
+
+func (m *MockRepository) CreateShot(ctx context.Context, shot *sql.Shot) (int, error) {
+	return 0, nil
+}
+
+func (m *MockRepository) GetShotById(ctx context.Context, id int) (*sql.Shot, error) {
+	return nil, nil
+}
+
+func (m *MockRepository) GetAllShots(ctx context.Context) ([]sql.Shot, error) {
+	return m.allShots, m.err
+}
+
+func (m *MockRepository) GetShotsBySheetId(ctx context.Context, sheetId int) ([]sql.Shot, error) {
+	return nil, nil
+}
+
+func (m *MockRepository) UpdateShotById(ctx context.Context, id int, shot *sql.Shot) (*sql.Shot, error) {
+	return nil, nil
+}
+
+func (m *MockRepository) DeleteShotById(ctx context.Context, id int) error { return nil }
+
+func (m *MockRepository) GetShotProfileById(ctx context.Context, id int) ([]sql.ShotProfileSample, error) {
+	return nil, nil
+}
+
+func (m *MockRepository) UpdateShotProfileById(ctx context.Context, id int, samples []sql.ShotProfileSample) error {
+	return nil
+}
+
+func newTestService(repo *MockRepository) *BeanconquerorService {
+	return New(repo, repo, repo, repo)
+}
+
+func TestImport(t *testing.T) {
+	repo := newMockRepository()
+	repo.sheets["Linea Mini"] = 3
+
+	report, err := newTestService(repo).Import(context.Background(), []byte(testBackup))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	type mapping struct {
+		kind   Kind
+		id     string
+		status Status
+	}
+	want := []mapping{
+		{KindPreparation, "p-1", StatusExisting},
+		{KindPreparation, "p-2", StatusCreated},
+		{KindRoaster, "", StatusCreated},
+		{KindBeans, "b-1", StatusCreated},
+		{KindBeans, "b-2", StatusCreated},
+		{KindBrew, "w-1", StatusCreated},
+		{KindBrew, "w-2", StatusCreated},
+		{KindBrew, "w-3", StatusFailed},
+	}
+	if len(report.Mappings) != len(want) {
+		t.Fatalf("Import() mappings = %+v, want %d", report.Mappings, len(want))
+	}
+	for i, m := range report.Mappings {
+		if m.Kind != want[i].kind || m.ExternalId != want[i].id || m.Status != want[i].status {
+			t.Errorf("Import() mapping %d = %+v, want %+v", i, m, want[i])
+		}
+	}
+	if report.Created != 6 || report.Existing != 1 || report.Failed != 1 {
+		t.Errorf("Import() report counts = %d created, %d existing, %d failed", report.Created, report.Existing, report.Failed)
+	}
+	if id := report.Mappings[0].Id; id == nil || *id != 3 {
+		t.Errorf("Import() existing preparation should map onto sheet 3, got %v", id)
+	}
+	if got := report.Mappings[7].Error; got != domainerrors.ErrImportBrewIsInvalid.Error() {
+		t.Errorf("Import() error of a brew without beans = %q", got)
+	}
+
+	if len(repo.calls) != 2 {
+		t.Fatalf("Import() imported %d shots, want 2", len(repo.calls))
+	}
+	espresso := repo.calls[0]
+	roastDate := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	wantEspresso := &sql.ImportedShot{
+		Source: Source, ExternalId: "w-1",
+		SheetName: "Linea Mini", RoasterName: "Square Mile", BeansName: "Red Brick",
+		RoastDate: &roastDate, RoastLevel: sql.RoastLevelMediumToDark,
+		Shot: &sql.Shot{
+			GrindSetting: 12, QuantityIn: 18, QuantityOut: 36.5, ShotTime: 28500 * time.Millisecond,
+			WaterTemperature: 94, Rating: 8, ComparisonWithPreviousResult: sql.Unknown,
+			AdditionalNotes: "Mill: Niche Zero\nsweet",
+		},
+	}
+	if !reflect.DeepEqual(espresso, wantEspresso) {
+		t.Errorf("Import() shot = %+v %+v, want %+v %+v", espresso, espresso.Shot, wantEspresso, wantEspresso.Shot)
+	}
+	filter := repo.calls[1].Shot
+	if repo.calls[1].SheetName != "V60" || filter.GrindSetting != 0 || filter.AdditionalNotes != "Grind: fine" ||
+		filter.QuantityOut != 250 || filter.WaterTemperature != defaultWaterTemperature || filter.Rating != 4 {
+		t.Errorf("Import() filter brew = %+v in sheet %q", filter, repo.calls[1].SheetName)
+	}
+}
+
+func TestImportTwiceOnlyReportsExistingRecords(t *testing.T) {
+	repo := newMockRepository()
+	service := newTestService(repo)
+	first, err := service.Import(context.Background(), []byte(testBackup))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	second, err := service.Import(context.Background(), []byte(testBackup))
+	if err != nil {
+		t.Fatalf("Import() again error = %v", err)
+	}
+
+	if second.Created != 0 || second.Existing != 7 || second.Failed != 1 {
+		t.Errorf("Import() again report counts = %d created, %d existing, %d failed", second.Created, second.Existing, second.Failed)
+	}
+	for i, m := range second.Mappings {
+		if m.Status == StatusFailed {
+			continue
+		}
+		if m.Id == nil || first.Mappings[i].Id == nil || *m.Id != *first.Mappings[i].Id {
+			t.Errorf("Import() again mapping %d = %+v, want the id of %+v", i, m, first.Mappings[i])
+		}
+	}
+	if len(repo.calls) != 2 {
+		t.Errorf("Import() again imported %d shots, want none more than the first 2", len(repo.calls))
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	repo := newMockRepository()
+	if _, err := newTestService(repo).Import(context.Background(), []byte("not a backup")); !errors.Is(err, domainerrors.ErrImportBackupIsInvalid) {
+		t.Errorf("Import() error = %v, want %v", err, domainerrors.ErrImportBackupIsInvalid)
+	}
+
+	repo.err = errors.New("connection refused")
+	if _, err := newTestService(repo).Import(context.Background(), []byte(testBackup)); !errors.Is(err, repo.err) {
+		t.Errorf("Import() error = %v, want %v", err, repo.err)
+	}
+
+	repo.err = domainerrors.ErrRoasterAlreadyExists
+	report, err := newTestService(repo).Import(context.Background(), []byte(testBackup))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	for _, m := range report.Mappings {
+		if m.Kind == KindBeans && m.Status != StatusFailed {
+			t.Errorf("Import() beans of a failed roaster = %+v, want failed", m)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	repo := newMockRepository()
+	created := time.Date(2026, time.October, 18, 8, 0, 0, 0, time.UTC)
+	roastDate := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	sheet := sql.Sheet{Id: 1, Name: "Linea Mini", CreatedAt: &created}
+	beans := sql.Beans{Id: 2, Name: "Red Brick", Roaster: &sql.Roaster{Id: 3, Name: "Square Mile"}, RoastDate: &roastDate,
+		RoastLevel: sql.RoastLevelMediumToDark, Price: 14.5, BagWeight: 350}
+	repo.allSheets = []sql.Sheet{sheet}
+	repo.allBeans = []sql.Beans{beans}
+	repo.allShots = []sql.Shot{
+		{Id: 4, Sheet: &sheet, Beans: &beans, GrindSetting: 12, QuantityIn: 18, QuantityOut: 36.5, ShotTime: 28500 * time.Millisecond,
+			WaterTemperature: 94, Rating: 8, AdditionalNotes: "sweet", CreatedAt: &created},
+		{Id: 5, Sheet: &sheet, Beans: &beans, ShotTime: 30 * time.Second},
+	}
+	repo.imported["w-1"] = 5
+
+	backup, err := newTestService(repo).Export(context.Background())
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	want := &Backup{
+		Beans: []Bean{{Config: Config{UUID: "espressoapi-beans-2"}, Name: "Red Brick", Roaster: "Square Mile",
+			RoastingDate: "2026-10-01T00:00:00Z", Roast: "FULL_CITY_PLUS_ROAST", Weight: 350, Cost: 14.5}},
+		Brews: []Brew{
+			{Config: Config{UUID: "espressoapi-shot-4", UnixTimestamp: created.Unix()}, Bean: "espressoapi-beans-2", MethodOfPreparation: "espressoapi-sheet-1",
+				GrindSize: "12", GrindWeight: 18, BrewTemperature: 94, BrewTime: 28, BrewTimeMilliseconds: 500,
+				BrewBeverageQuantity: 36.5, BrewBeverageQuantityType: "GR", Rating: 4, Note: "sweet"},
+			{Config: Config{UUID: "w-1"}, Bean: "espressoapi-beans-2", MethodOfPreparation: "espressoapi-sheet-1",
+				GrindSize: "0", BrewTime: 30, BrewBeverageQuantityType: "GR"},
+		},
+		Mills:        []Mill{},
+		Preparations: []Preparation{{Config: Config{UUID: "espressoapi-sheet-1", UnixTimestamp: created.Unix()}, Name: "Linea Mini", Type: "PORTAFILTER"}},
+	}
+	if !reflect.DeepEqual(backup, want) {
+		t.Errorf("Export() = %+v, want %+v", backup, want)
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	source := newMockRepository()
+	sheet := sql.Sheet{Id: 1, Name: "Linea Mini"}
+	beans := sql.Beans{Id: 2, Name: "Red Brick", Roaster: &sql.Roaster{Id: 3, Name: "Square Mile"}, RoastLevel: sql.RoastLevelLight}
+	source.allSheets = []sql.Sheet{sheet}
+	source.allBeans = []sql.Beans{beans}
+	source.allShots = []sql.Shot{{Id: 4, Sheet: &sheet, Beans: &beans, GrindSetting: 9, QuantityIn: 18, QuantityOut: 40,
+		ShotTime: 31200 * time.Millisecond, WaterTemperature: 92, Rating: 7, AdditionalNotes: "bright"}}
+	backup, err := newTestService(source).Export(context.Background())
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	data, err := json.Marshal(backup)
+	if err != nil {
+		t.Fatalf("marshal backup: %v", err)
+	}
+
+	target := newMockRepository()
+	if _, err := newTestService(target).Import(context.Background(), data); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if len(target.calls) != 1 {
+		t.Fatalf("Import() imported %d shots, want 1", len(target.calls))
+	}
+	got := target.calls[0]
+	want := source.allShots[0]
+	want.Id, want.Sheet, want.Beans, want.ComparisonWithPreviousResult = 0, nil, nil, sql.Unknown
+	if !reflect.DeepEqual(*got.Shot, want) || got.SheetName != "Linea Mini" || got.BeansName != "Red Brick" || got.RoastLevel != sql.RoastLevelLight {
+		t.Errorf("Import() of an export = %+v %+v, want %+v", got, got.Shot, want)
+	}
+}
@@ -0,0 +1,440 @@
+package cupping
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// MinAttributeScore and MaxAttributeScore bound every attribute of the
+	// SCA cupping form.
+	MinAttributeScore = 0.0
+	MaxAttributeScore = 10.0
+
+	participantsSeparator = ","
+)
+
+// CuppingSession
+//
+// A cupping session is a dated tasting, with one or more participants,
+// in which several beans are scored on the SCA cupping form.
+//
+// swagger:model
+type CuppingSession struct {
+	// The id for the cupping session
+	Id int `json:"id"`
+
+	// The date the cupping took place
+	SessionDate *time.Time `json:"session_date"`
+
+	// The people who took part in the cupping
+	Participants []string `json:"participants"`
+
+	// The score entries recorded during the session. Only returned when
+	// fetching a single session.
+	Scores []CuppingScore `json:"scores,omitempty"`
+
+	// The creation date of the cupping session
+	CreatedAt *time.Time `json:"created_at"`
+
+	// The last update date of the cupping session
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// CuppingScore
+//
+// A cupping score is one beans' entry on the SCA cupping form of a session.
+// Each attribute is scored between 0 and 10; the total is their sum.
+//
+// swagger:model
+type CuppingScore struct {
+	Id          int        `json:"id"`
+	SessionId   int        `json:"session_id"`
+	SessionDate *time.Time `json:"session_date"`
+	Beans       *bean.Bean `json:"beans"`
+	Fragrance   float64    `json:"fragrance"`
+	Flavor      float64    `json:"flavor"`
+	Aftertaste  float64    `json:"aftertaste"`
+	Acidity     float64    `json:"acidity"`
+	Body        float64    `json:"body"`
+	Balance     float64    `json:"balance"`
+	Uniformity  float64    `json:"uniformity"`
+	CleanCup    float64    `json:"clean_cup"`
+	Sweetness   float64    `json:"sweetness"`
+	Overall     float64    `json:"overall"`
+	Total       float64    `json:"total"`
+	Notes       string     `json:"notes"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+// attributes returns the ten SCA form attributes in form order.
+func (s *CuppingScore) attributes() []float64 {
+	return []float64{
+		s.Fragrance, s.Flavor, s.Aftertaste, s.Acidity, s.Body,
+		s.Balance, s.Uniformity, s.CleanCup, s.Sweetness, s.Overall,
+	}
+}
+
+// ComputeTotal returns the sum of the ten form attributes.
+func (s *CuppingScore) ComputeTotal() float64 {
+	var total float64
+	for _, v := range s.attributes() {
+		total += v
+	}
+	return total
+}
+
+// SQLToCuppingSession converts a *sql.CuppingSession object to a
+// *CuppingSession object. If the input session is nil, it returns nil.
+func SQLToCuppingSession(session *sql.CuppingSession) *CuppingSession {
+	if session == nil {
+		return nil
+	}
+
+	s := new(CuppingSession)
+	s.Id = session.Id
+	s.SessionDate = session.SessionDate
+	s.Participants = splitParticipants(session.Participants)
+	s.CreatedAt = session.CreatedAt
+	s.UpdatedAt = session.UpdatedAt
+
+	return s
+}
+
+// CuppingSessionToSQL converts a CuppingSession object to its SQL
+// representation. If the input session is nil, it returns nil.
+func CuppingSessionToSQL(session *CuppingSession) *sql.CuppingSession {
+	if session == nil {
+		return nil
+	}
+
+	s := new(sql.CuppingSession)
+	s.Id = session.Id
+	s.SessionDate = session.SessionDate
+	s.Participants = strings.Join(NormalizeParticipants(session.Participants), participantsSeparator+" ")
+	s.CreatedAt = session.CreatedAt
+	s.UpdatedAt = session.UpdatedAt
+
+	return s
+}
+
+// SQLToCuppingScore converts a *sql.CuppingScore object to a *CuppingScore
+// object and computes its total. If the input score is nil, it returns nil.
+func SQLToCuppingScore(score *sql.CuppingScore) *CuppingScore {
+	if score == nil {
+		return nil
+	}
+
+	s := new(CuppingScore)
+	s.Id = score.Id
+	s.SessionId = score.SessionId
+	s.SessionDate = score.SessionDate
+	s.Beans = bean.SQLToBean(score.Beans)
+	s.Fragrance = score.Fragrance
+	s.Flavor = score.Flavor
+	s.Aftertaste = score.Aftertaste
+	s.Acidity = score.Acidity
+	s.Body = score.Body
+	s.Balance = score.Balance
+	s.Uniformity = score.Uniformity
+	s.CleanCup = score.CleanCup
+	s.Sweetness = score.Sweetness
+	s.Overall = score.Overall
+	s.Total = s.ComputeTotal()
+	s.Notes = score.Notes
+	s.CreatedAt = score.CreatedAt
+	s.UpdatedAt = score.UpdatedAt
+
+	return s
+}
+
+// CuppingScoreToSQL converts a CuppingScore object to its SQL
+// representation. If the input score is nil, it returns nil.
+func CuppingScoreToSQL(score *CuppingScore) *sql.CuppingScore {
+	if score == nil {
+		return nil
+	}
+
+	s := new(sql.CuppingScore)
+	s.Id = score.Id
+	s.SessionId = score.SessionId
+	s.SessionDate = score.SessionDate
+	s.Beans = bean.BeanToSQL(score.Beans)
+	s.Fragrance = score.Fragrance
+	s.Flavor = score.Flavor
+	s.Aftertaste = score.Aftertaste
+	s.Acidity = score.Acidity
+	s.Body = score.Body
+	s.Balance = score.Balance
+	s.Uniformity = score.Uniformity
+	s.CleanCup = score.CleanCup
+	s.Sweetness = score.Sweetness
+	s.Overall = score.Overall
+	s.Notes = score.Notes
+	s.CreatedAt = score.CreatedAt
+	s.UpdatedAt = score.UpdatedAt
+
+	return s
+}
+
+// NormalizeParticipants trims every participant name and drops empty ones.
+// Commas are the storage separator, so they cannot appear inside a name:
+// a name containing one is split into several participants.
+func NormalizeParticipants(participants []string) []string {
+	normalized := make([]string, 0, len(participants))
+	for _, p := range participants {
+		for _, name := range strings.Split(p, participantsSeparator) {
+			if name = strings.TrimSpace(name); name != "" {
+				normalized = append(normalized, name)
+			}
+		}
+	}
+	return normalized
+}
+
+func splitParticipants(participants string) []string {
+	return NormalizeParticipants([]string{participants})
+}
+
+type Service interface {
+	CreateCuppingSession(ctx context.Context, session *CuppingSession) (*CuppingSession, error)
+	GetCuppingSessionById(ctx context.Context, id int) (*CuppingSession, error)
+	GetAllCuppingSessions(ctx context.Context) ([]CuppingSession, error)
+	UpdateCuppingSessionById(ctx context.Context, id int, session *CuppingSession) (*CuppingSession, error)
+	DeleteCuppingSessionById(ctx context.Context, id int) error
+	CreateCuppingScore(ctx context.Context, score *CuppingScore) (*CuppingScore, error)
+	GetCuppingScoreById(ctx context.Context, id int) (*CuppingScore, error)
+	GetCuppingScoresByBeansId(ctx context.Context, beansId int) ([]CuppingScore, error)
+	UpdateCuppingScoreById(ctx context.Context, id int, score *CuppingScore) (*CuppingScore, error)
+	DeleteCuppingScoreById(ctx context.Context, id int) error
+	Ping(ctx context.Context) error
+}
+
+type CuppingService struct {
+	repository repository.CuppingRepository
+}
+
+var _ Service = (*CuppingService)(nil)
+
+func New(repo repository.CuppingRepository) *CuppingService {
+	return &CuppingService{repository: repo}
+}
+
+func (s *CuppingService) CreateCuppingSession(ctx context.Context, session *CuppingSession) (*CuppingSession, error) {
+	if session == nil || session.SessionDate == nil {
+		err := errors.ErrCuppingSessionDateIsEmpty
+		msg := "could not create cupping session"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	id, err := s.repository.CreateCuppingSession(ctx, CuppingSessionToSQL(session))
+	if err != nil {
+		msg := "could not create cupping session"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	created, err := s.GetCuppingSessionById(ctx, id)
+	if err != nil {
+		msg := "could not get newly created cupping session"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return created, nil
+}
+
+// GetCuppingSessionById returns the session with its score entries.
+func (s *CuppingService) GetCuppingSessionById(ctx context.Context, id int) (*CuppingSession, error) {
+	sqlSession, err := s.repository.GetCuppingSessionById(ctx, id)
+	if err != nil {
+		msg := "could not get cupping session by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	sqlScores, err := s.repository.GetCuppingScoresBySessionId(ctx, id)
+	if err != nil {
+		msg := "could not get cupping scores by session id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	session := SQLToCuppingSession(sqlSession)
+	session.Scores = make([]CuppingScore, len(sqlScores))
+	for i, v := range sqlScores {
+		session.Scores[i] = *SQLToCuppingScore(&v)
+	}
+
+	return session, nil
+}
+
+func (s *CuppingService) GetAllCuppingSessions(ctx context.Context) ([]CuppingSession, error) {
+	sqlSessions, err := s.repository.GetAllCuppingSessions(ctx)
+	if err != nil {
+		msg := "could not get all cupping sessions"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	sessions := make([]CuppingSession, len(sqlSessions))
+	for i, v := range sqlSessions {
+		sessions[i] = *SQLToCuppingSession(&v)
+	}
+
+	return sessions, nil
+}
+
+func (s *CuppingService) UpdateCuppingSessionById(ctx context.Context, id int, session *CuppingSession) (*CuppingSession, error) {
+	if session == nil || session.SessionDate == nil {
+		err := errors.ErrCuppingSessionDateIsEmpty
+		msg := "could not update cupping session by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	session.Id = id
+	if _, err := s.repository.UpdateCuppingSessionById(ctx, id, CuppingSessionToSQL(session)); err != nil {
+		msg := "could not update cupping session by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	updated, err := s.GetCuppingSessionById(ctx, id)
+	if err != nil {
+		msg := "could not get updated cupping session"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return updated, nil
+}
+
+func (s *CuppingService) DeleteCuppingSessionById(ctx context.Context, id int) error {
+	if err := s.repository.DeleteCuppingSessionById(ctx, id); err != nil {
+		msg := "could not delete cupping session by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+func (s *CuppingService) CreateCuppingScore(ctx context.Context, score *CuppingScore) (*CuppingScore, error) {
+	if err := validateCuppingScore(score); err != nil {
+		msg := "could not create cupping score"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	id, err := s.repository.CreateCuppingScore(ctx, CuppingScoreToSQL(score))
+	if err != nil {
+		msg := "could not create cupping score"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	created, err := s.GetCuppingScoreById(ctx, id)
+	if err != nil {
+		msg := "could not get newly created cupping score"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return created, nil
+}
+
+func (s *CuppingService) GetCuppingScoreById(ctx context.Context, id int) (*CuppingScore, error) {
+	score, err := s.repository.GetCuppingScoreById(ctx, id)
+	if err != nil {
+		msg := "could not get cupping score by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToCuppingScore(score), nil
+}
+
+func (s *CuppingService) GetCuppingScoresByBeansId(ctx context.Context, beansId int) ([]CuppingScore, error) {
+	sqlScores, err := s.repository.GetCuppingScoresByBeansId(ctx, beansId)
+	if err != nil {
+		msg := "could not get cupping scores by beans id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	scores := make([]CuppingScore, len(sqlScores))
+	for i, v := range sqlScores {
+		scores[i] = *SQLToCuppingScore(&v)
+	}
+
+	return scores, nil
+}
+
+func (s *CuppingService) UpdateCuppingScoreById(ctx context.Context, id int, score *CuppingScore) (*CuppingScore, error) {
+	if err := validateCuppingScore(score); err != nil {
+		msg := "could not update cupping score by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	score.Id = id
+	if _, err := s.repository.UpdateCuppingScoreById(ctx, id, CuppingScoreToSQL(score)); err != nil {
+		msg := "could not update cupping score by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	updated, err := s.GetCuppingScoreById(ctx, id)
+	if err != nil {
+		msg := "could not get updated cupping score"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return updated, nil
+}
+
+func (s *CuppingService) DeleteCuppingScoreById(ctx context.Context, id int) error {
+	if err := s.repository.DeleteCuppingScoreById(ctx, id); err != nil {
+		msg := "could not delete cupping score by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+func (s *CuppingService) Ping(ctx context.Context) error {
+	if err := s.repository.Ping(ctx); err != nil {
+		msg := "could not ping database"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// validateCuppingScore rejects a nil score, a score without beans and any
+// attribute outside [MinAttributeScore, MaxAttributeScore].
+func validateCuppingScore(score *CuppingScore) error {
+	if score == nil {
+		return errors.ErrCuppingScoreIsNil
+	}
+	if score.Beans == nil {
+		return errors.ErrBeansIsNil
+	}
+	for _, v := range score.attributes() {
+		if v < MinAttributeScore || v > MaxAttributeScore {
+			return errors.ErrCuppingScoreOutOfRange
+		}
+	}
+	return nil
+}
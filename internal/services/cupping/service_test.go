@@ -0,0 +1,311 @@
+package cupping
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+)
+
+var (
+	sessionDate = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+)
+
+type IsErrorCtxKey string
+
+type MockCuppingRepository struct {
+	createdSession *sql.CuppingSession
+	createdScore   *sql.CuppingScore
+}
+
+func (m *MockCuppingRepository) CreateCuppingSession(ctx context.Context, session *sql.CuppingSession) (int, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return 0, fmt.Errorf("mock error")
+	}
+	m.createdSession = session
+	return 1, nil
+}
+
+func (m *MockCuppingRepository) GetCuppingSessionById(ctx context.Context, id int) (*sql.CuppingSession, error) {
+	if id != 1 {
+		return nil, errors.ErrCuppingSessionDoesNotExist
+	}
+	return &sql.CuppingSession{Id: 1, SessionDate: &sessionDate, Participants: "alice, bob"}, nil
+}
+
+func (m *MockCuppingRepository) GetAllCuppingSessions(ctx context.Context) ([]sql.CuppingSession, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return nil, fmt.Errorf("mock error")
+	}
+	return []sql.CuppingSession{
+		{Id: 1, SessionDate: &sessionDate, Participants: "alice"},
+		{Id: 2, SessionDate: &sessionDate, Participants: ""},
+	}, nil
+}
+
+func (m *MockCuppingRepository) UpdateCuppingSessionById(ctx context.Context, id int, session *sql.CuppingSession) (*sql.CuppingSession, error) {
+	if id != 1 {
+		return nil, errors.ErrCuppingSessionDoesNotExist
+	}
+	return session, nil
+}
+
+func (m *MockCuppingRepository) DeleteCuppingSessionById(ctx context.Context, id int) error {
+	if id != 1 {
+		return errors.ErrCuppingSessionDoesNotExist
+	}
+	return nil
+}
+
+func (m *MockCuppingRepository) CreateCuppingScore(ctx context.Context, score *sql.CuppingScore) (int, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return 0, errors.ErrCuppingScoreAlreadyExists
+	}
+	m.createdScore = score
+	return 1, nil
+}
+
+func (m *MockCuppingRepository) GetCuppingScoreById(ctx context.Context, id int) (*sql.CuppingScore, error) {
+	if id != 1 {
+		return nil, errors.ErrCuppingScoreDoesNotExist
+	}
+	return testSQLScore(), nil
+}
+
+func (m *MockCuppingRepository) GetCuppingScoresBySessionId(ctx context.Context, sessionId int) ([]sql.CuppingScore, error) {
+	return []sql.CuppingScore{*testSQLScore()}, nil
+}
+
+func (m *MockCuppingRepository) GetCuppingScoresByBeansId(ctx context.Context, beansId int) ([]sql.CuppingScore, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return nil, fmt.Errorf("mock error")
+	}
+	return []sql.CuppingScore{*testSQLScore()}, nil
+}
+
+func (m *MockCuppingRepository) UpdateCuppingScoreById(ctx context.Context, id int, score *sql.CuppingScore) (*sql.CuppingScore, error) {
+	if id != 1 {
+		return nil, errors.ErrCuppingScoreDoesNotExist
+	}
+	return score, nil
+}
+
+func (m *MockCuppingRepository) DeleteCuppingScoreById(ctx context.Context, id int) error {
+	if id != 1 {
+		return errors.ErrCuppingScoreDoesNotExist
+	}
+	return nil
+}
+
+func (m *MockCuppingRepository) Ping(ctx context.Context) error {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return fmt.Errorf("mock error")
+	}
+	return nil
+}
+
+func testSQLScore() *sql.CuppingScore {
+	return &sql.CuppingScore{
+		Id: 1, SessionId: 1, SessionDate: &sessionDate,
+		Beans:     &sql.Beans{Id: 2, Name: "beans01", Roaster: &sql.Roaster{Id: 3, Name: "roaster01"}},
+		Fragrance: 8, Flavor: 8, Aftertaste: 7.5, Acidity: 7.75, Body: 7.5,
+		Balance: 7.75, Uniformity: 10, CleanCup: 10, Sweetness: 10, Overall: 8,
+	}
+}
+
+func testScore() *CuppingScore {
+	return &CuppingScore{
+		SessionId: 1, Beans: &bean.Bean{Id: 2},
+		Fragrance: 8, Flavor: 8, Aftertaste: 7.5, Acidity: 7.75, Body: 7.5,
+		Balance: 7.75, Uniformity: 10, CleanCup: 10, Sweetness: 10, Overall: 8,
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		repo repository.CuppingRepository
+		want *CuppingService
+	}{
+		{name: "nil args", repo: nil, want: &CuppingService{nil}},
+		{name: "non nil args", repo: &MockCuppingRepository{}, want: &CuppingService{&MockCuppingRepository{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.repo); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("New() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLToCuppingScoreComputesTotal(t *testing.T) {
+	if got := SQLToCuppingScore(nil); got != nil {
+		t.Errorf("SQLToCuppingScore(nil) = %v, want nil", got)
+	}
+
+	got := SQLToCuppingScore(testSQLScore())
+	if got.Total != 84.5 {
+		t.Errorf("Total = %v, want 84.5", got.Total)
+	}
+	if got.Beans == nil || got.Beans.Roaster == nil || got.Beans.Roaster.Name != "roaster01" {
+		t.Errorf("Beans = %+v, want beans with roaster01", got.Beans)
+	}
+}
+
+func TestNormalizeParticipants(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{name: "nil", input: nil, want: []string{}},
+		{name: "trims and drops empty names", input: []string{" alice ", "", "  "}, want: []string{"alice"}},
+		{name: "splits comma separated names", input: []string{"alice, bob,,carol"}, want: []string{"alice", "bob", "carol"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeParticipants(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NormalizeParticipants() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCuppingServiceCreateCuppingSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		session *CuppingSession
+		wantErr error
+		anyErr  bool
+	}{
+		{name: "nil session", ctx: context.Background(), session: nil, wantErr: errors.ErrCuppingSessionDateIsEmpty},
+		{name: "missing date", ctx: context.Background(), session: &CuppingSession{Participants: []string{"alice"}}, wantErr: errors.ErrCuppingSessionDateIsEmpty},
+		{name: "repository error", ctx: context.WithValue(context.Background(), IsErrorCtxKey("isError"), true), session: &CuppingSession{SessionDate: &sessionDate}, anyErr: true},
+		{name: "created", ctx: context.Background(), session: &CuppingSession{SessionDate: &sessionDate, Participants: []string{" alice", "bob "}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockCuppingRepository{}
+			got, err := New(repo).CreateCuppingSession(tt.ctx, tt.session)
+			if tt.wantErr != nil {
+				if !stderrors.Is(err, tt.wantErr) {
+					t.Fatalf("CreateCuppingSession() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.anyErr {
+				if err == nil {
+					t.Fatal("CreateCuppingSession() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateCuppingSession() error = %v", err)
+			}
+			if repo.createdSession.Participants != "alice, bob" {
+				t.Errorf("stored participants = %q, want %q", repo.createdSession.Participants, "alice, bob")
+			}
+			if len(got.Scores) != 1 || got.Scores[0].Total != 84.5 {
+				t.Errorf("Scores = %+v, want one score totalling 84.5", got.Scores)
+			}
+		})
+	}
+}
+
+func TestCuppingServiceGetCuppingSessionById(t *testing.T) {
+	s := New(&MockCuppingRepository{})
+
+	got, err := s.GetCuppingSessionById(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetCuppingSessionById() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Participants, []string{"alice", "bob"}) {
+		t.Errorf("Participants = %#v, want alice and bob", got.Participants)
+	}
+
+	if _, err := s.GetCuppingSessionById(context.Background(), 2); !stderrors.Is(err, errors.ErrCuppingSessionDoesNotExist) {
+		t.Errorf("GetCuppingSessionById() error = %v, want %v", err, errors.ErrCuppingSessionDoesNotExist)
+	}
+}
+
+func TestCuppingServiceGetAllCuppingSessions(t *testing.T) {
+	s := New(&MockCuppingRepository{})
+
+	got, err := s.GetAllCuppingSessions(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllCuppingSessions() error = %v", err)
+	}
+	if len(got) != 2 || len(got[1].Participants) != 0 {
+		t.Errorf("GetAllCuppingSessions() = %+v, want two sessions, the second without participants", got)
+	}
+
+	if _, err := s.GetAllCuppingSessions(context.WithValue(context.Background(), IsErrorCtxKey("isError"), true)); err == nil {
+		t.Error("GetAllCuppingSessions() error = nil, want error")
+	}
+}
+
+func TestCuppingServiceCreateCuppingScore(t *testing.T) {
+	outOfRange := testScore()
+	outOfRange.Sweetness = 10.5
+	negative := testScore()
+	negative.Body = -1
+	withoutBeans := testScore()
+	withoutBeans.Beans = nil
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		score   *CuppingScore
+		wantErr error
+	}{
+		{name: "nil score", ctx: context.Background(), score: nil, wantErr: errors.ErrCuppingScoreIsNil},
+		{name: "missing beans", ctx: context.Background(), score: withoutBeans, wantErr: errors.ErrBeansIsNil},
+		{name: "attribute above range", ctx: context.Background(), score: outOfRange, wantErr: errors.ErrCuppingScoreOutOfRange},
+		{name: "attribute below range", ctx: context.Background(), score: negative, wantErr: errors.ErrCuppingScoreOutOfRange},
+		{name: "repository error", ctx: context.WithValue(context.Background(), IsErrorCtxKey("isError"), true), score: testScore(), wantErr: errors.ErrCuppingScoreAlreadyExists},
+		{name: "created", ctx: context.Background(), score: testScore()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(&MockCuppingRepository{}).CreateCuppingScore(tt.ctx, tt.score)
+			if tt.wantErr != nil {
+				if !stderrors.Is(err, tt.wantErr) {
+					t.Fatalf("CreateCuppingScore() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateCuppingScore() error = %v", err)
+			}
+			if got.Id != 1 || got.Total != 84.5 {
+				t.Errorf("CreateCuppingScore() = %+v, want id 1 totalling 84.5", got)
+			}
+		})
+	}
+}
+
+func TestCuppingServiceUpdateAndDelete(t *testing.T) {
+	s := New(&MockCuppingRepository{})
+
+	if _, err := s.UpdateCuppingSessionById(context.Background(), 2, &CuppingSession{SessionDate: &sessionDate}); !stderrors.Is(err, errors.ErrCuppingSessionDoesNotExist) {
+		t.Errorf("UpdateCuppingSessionById() error = %v, want %v", err, errors.ErrCuppingSessionDoesNotExist)
+	}
+	if _, err := s.UpdateCuppingScoreById(context.Background(), 2, testScore()); !stderrors.Is(err, errors.ErrCuppingScoreDoesNotExist) {
+		t.Errorf("UpdateCuppingScoreById() error = %v, want %v", err, errors.ErrCuppingScoreDoesNotExist)
+	}
+	if err := s.DeleteCuppingSessionById(context.Background(), 1); err != nil {
+		t.Errorf("DeleteCuppingSessionById() error = %v", err)
+	}
+	if err := s.DeleteCuppingScoreById(context.Background(), 2); !stderrors.Is(err, errors.ErrCuppingScoreDoesNotExist) {
+		t.Errorf("DeleteCuppingScoreById() error = %v, want %v", err, errors.ErrCuppingScoreDoesNotExist)
+	}
+}
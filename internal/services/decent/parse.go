@@ -0,0 +1,411 @@
+package decent
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+)
+
+const (
+	// defaultWaterTemperature is recorded for shots without any temperature,
+	// as for shots created through the API.
+	defaultWaterTemperature = 93.0
+
+	// maxNotesLength is the size of the additional_notes column.
+	maxNotesLength = 511
+
+	unknownRoaster = "Unknown roaster"
+	unknownBeans   = "Unknown beans"
+)
+
+// record holds the fields of a shot file, as text, whatever its format.
+type record struct {
+	start       string
+	dose        string
+	yield       string
+	duration    string
+	temperature string
+	enjoyment   string
+	grind       string
+	brand       string
+	beans       string
+	roastDate   string
+	roastLevel  string
+	notes       string
+	profile     string
+
+	times        []string
+	pressure     []string
+	flow         []string
+	weight       []string
+	temperatures []string
+}
+
+// Parse reads a shot from data, either a visualizer.coffee JSON file or a
+// .shot file of the DE1 app. It returns ErrImportFileIsInvalid when data is
+// neither.
+func Parse(data []byte) (*sql.ImportedShot, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	var r *record
+	var err error
+	if json.Valid(data) {
+		r, err = parseVisualizer(data)
+	} else {
+		r, err = parseShotFile(data)
+	}
+	if err != nil {
+		return nil, errors.ErrImportFileIsInvalid
+	}
+	return r.toImportedShot(data)
+}
+
+// parseShotFile reads the Tcl dictionary of a .shot file. The settings of
+// the shot, such as the beans and the dose, are in its nested settings
+// dictionary; the time series are at the top level.
+func parseShotFile(data []byte) (*record, error) {
+	top, err := parseTclDict(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := top["espresso_elapsed"]; !ok {
+		return nil, errors.ErrImportFileIsInvalid
+	}
+	settings, err := parseTclDict(top["settings"])
+	if err != nil {
+		settings = map[string]string{}
+	}
+	get := func(keys ...string) string {
+		for _, key := range keys {
+			if v := strings.TrimSpace(top[key]); v != "" {
+				return v
+			}
+			if v := strings.TrimSpace(settings[key]); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	list := func(key string) []string {
+		words, _ := parseTclList(top[key])
+		return words
+	}
+
+	return &record{
+		start:        get("clock"),
+		dose:         get("grinder_dose_weight", "DSx_bean_weight"),
+		yield:        get("drink_weight"),
+		temperature:  get("espresso_temperature"),
+		enjoyment:    get("espresso_enjoyment"),
+		grind:        get("grinder_setting"),
+		brand:        get("bean_brand"),
+		beans:        get("bean_type"),
+		roastDate:    get("roast_date"),
+		roastLevel:   get("roast_level"),
+		notes:        get("espresso_notes"),
+		profile:      get("profile_title"),
+		times:        list("espresso_elapsed"),
+		pressure:     list("espresso_pressure"),
+		flow:         list("espresso_flow"),
+		weight:       list("espresso_weight"),
+		temperatures: list("espresso_temperature_basket"),
+	}, nil
+}
+
+// jsonValue is a JSON string, number or null, as text: visualizer.coffee
+// writes numbers either way.
+type jsonValue string
+
+func (v *jsonValue) UnmarshalJSON(data []byte) error {
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*v = jsonValue(s)
+		return nil
+	}
+	if string(data) == "null" {
+		*v = ""
+		return nil
+	}
+	*v = jsonValue(data)
+	return nil
+}
+
+// visualizerShot is the JSON of a shot downloaded from visualizer.coffee.
+type visualizerShot struct {
+	StartTime         jsonValue              `json:"start_time"`
+	Duration          jsonValue              `json:"duration"`
+	BeanWeight        jsonValue              `json:"bean_weight"`
+	DrinkWeight       jsonValue              `json:"drink_weight"`
+	EspressoEnjoyment jsonValue              `json:"espresso_enjoyment"`
+	GrinderSetting    jsonValue              `json:"grinder_setting"`
+	BeanBrand         jsonValue              `json:"bean_brand"`
+	BeanType          jsonValue              `json:"bean_type"`
+	RoastDate         jsonValue              `json:"roast_date"`
+	RoastLevel        jsonValue              `json:"roast_level"`
+	EspressoNotes     jsonValue              `json:"espresso_notes"`
+	ProfileTitle      jsonValue              `json:"profile_title"`
+	Timeframe         []jsonValue            `json:"timeframe"`
+	Data              map[string][]jsonValue `json:"data"`
+}
+
+func parseVisualizer(data []byte) (*record, error) {
+	var v visualizerShot
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	if v.StartTime == "" && len(v.Timeframe) == 0 {
+		return nil, errors.ErrImportFileIsInvalid
+	}
+	texts := func(values []jsonValue) []string {
+		s := make([]string, len(values))
+		for i, value := range values {
+			s[i] = string(value)
+		}
+		return s
+	}
+	temperatures := v.Data["espresso_temperature_basket"]
+	if len(temperatures) == 0 {
+		temperatures = v.Data["espresso_temperature_mix"]
+	}
+
+	return &record{
+		start:        string(v.StartTime),
+		dose:         string(v.BeanWeight),
+		yield:        string(v.DrinkWeight),
+		duration:     string(v.Duration),
+		enjoyment:    string(v.EspressoEnjoyment),
+		grind:        string(v.GrinderSetting),
+		brand:        string(v.BeanBrand),
+		beans:        string(v.BeanType),
+		roastDate:    string(v.RoastDate),
+		roastLevel:   string(v.RoastLevel),
+		notes:        string(v.EspressoNotes),
+		profile:      string(v.ProfileTitle),
+		times:        texts(v.Timeframe),
+		pressure:     texts(v.Data["espresso_pressure"]),
+		flow:         texts(v.Data["espresso_flow"]),
+		weight:       texts(v.Data["espresso_weight"]),
+		temperatures: texts(temperatures),
+	}, nil
+}
+
+// toImportedShot maps the record onto a shot and its profile. The external
+// id of the shot is the Unix time it started at, so that the same shot is
+// recognized from either format, or a hash of data when the start is unknown.
+func (r *record) toImportedShot(data []byte) (*sql.ImportedShot, error) {
+	profile := r.profileSamples()
+
+	shotTime := parseDuration(r.duration)
+	if shotTime == 0 && len(profile) > 0 {
+		shotTime = time.Duration(profile[len(profile)-1].ElapsedTime) * time.Millisecond
+	}
+	if shotTime > shot.MaxShotTime {
+		return nil, errors.ErrShotTimeOutOfRange
+	}
+
+	yield := parseNumber(r.yield)
+	if yield == 0 {
+		for i := len(profile) - 1; i >= 0; i-- {
+			if w := profile[i].Weight; w != nil {
+				yield = *w
+				break
+			}
+		}
+	}
+
+	temperature := parseNumber(r.temperature)
+	if temperature == 0 {
+		temperature = meanTemperature(profile)
+	}
+	if temperature == 0 {
+		temperature = defaultWaterTemperature
+	}
+
+	externalId := startTime(r.start)
+	if externalId == "" {
+		sum := sha256.Sum256(data)
+		externalId = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	return &sql.ImportedShot{
+		Source:      Source,
+		ExternalId:  externalId,
+		RoasterName: orDefault(r.brand, unknownRoaster),
+		BeansName:   orDefault(r.beans, unknownBeans),
+		RoastDate:   parseDate(r.roastDate),
+		RoastLevel:  parseRoastLevel(r.roastLevel),
+		Shot: &sql.Shot{
+			GrindSetting:                 int(math.Round(parseNumber(r.grind))),
+			QuantityIn:                   parseNumber(r.dose),
+			QuantityOut:                  math.Round(yield*10) / 10,
+			ShotTime:                     shotTime,
+			WaterTemperature:             math.Round(temperature*10) / 10,
+			Rating:                       min(max(parseNumber(r.enjoyment)/10, 0), 10),
+			ComparisonWithPreviousResult: sql.Unknown,
+			AdditionalNotes:              r.additionalNotes(),
+		},
+		Profile: profile,
+	}, nil
+}
+
+// profileSamples aligns the time series into samples. Samples out of the
+// shot time range or not after the previous one are dropped, negative
+// readings, such as a scale drifting below zero, are recorded as 0, and long
+// profiles are thinned out to at most shot.MaxProfileSamples samples.
+func (r *record) profileSamples() []sql.ShotProfileSample {
+	at := func(series []string, i int) *float64 {
+		if i >= len(series) {
+			return nil
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(series[i]), 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil
+		}
+		v = max(v, 0)
+		return &v
+	}
+
+	samples := make([]sql.ShotProfileSample, 0, len(r.times))
+	for i := range r.times {
+		t := at(r.times, i)
+		if t == nil {
+			continue
+		}
+		elapsed := int(math.Round(*t * 1000))
+		if elapsed > int(shot.MaxShotTime.Milliseconds()) || (len(samples) > 0 && elapsed <= samples[len(samples)-1].ElapsedTime) {
+			continue
+		}
+		samples = append(samples, sql.ShotProfileSample{
+			ElapsedTime: elapsed,
+			Pressure:    at(r.pressure, i),
+			Flow:        at(r.flow, i),
+			Weight:      at(r.weight, i),
+			Temperature: at(r.temperatures, i),
+		})
+	}
+
+	if len(samples) > shot.MaxProfileSamples {
+		step := (len(samples) + shot.MaxProfileSamples - 1) / shot.MaxProfileSamples
+		thinned := samples[:0]
+		for i := 0; i < len(samples); i += step {
+			thinned = append(thinned, samples[i])
+		}
+		samples = thinned
+	}
+	return samples
+}
+
+// additionalNotes returns the notes of the shot, preceded by the title of
+// the profile it was pulled with.
+func (r *record) additionalNotes() string {
+	var lines []string
+	if r.profile != "" {
+		lines = append(lines, "Profile: "+r.profile)
+	}
+	if r.notes != "" {
+		lines = append(lines, r.notes)
+	}
+	notes := strings.Join(lines, "\n")
+	for len(notes) > maxNotesLength {
+		_, size := utf8.DecodeLastRuneInString(notes)
+		notes = notes[:len(notes)-size]
+	}
+	return notes
+}
+
+// meanTemperature returns the mean of the temperature readings of the
+// profile, or 0 without any.
+func meanTemperature(profile []sql.ShotProfileSample) float64 {
+	var sum float64
+	var n int
+	for _, s := range profile {
+		if s.Temperature != nil {
+			sum += *s.Temperature
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+// parseNumber returns the number s holds, or 0 if it holds none or a
+// negative one.
+func parseNumber(s string) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+		return 0
+	}
+	return v
+}
+
+// parseDuration returns the duration of s, a number of seconds.
+func parseDuration(s string) time.Duration {
+	return shot.SecondsToDuration(parseNumber(s))
+}
+
+// startTime returns the Unix time of s, either a Unix time already or an
+// RFC 3339 timestamp, as text; or "" if s is neither.
+func startTime(s string) string {
+	s = strings.TrimSpace(s)
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil && v > 0 {
+		return strconv.FormatInt(v, 10)
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return strconv.FormatInt(t.Unix(), 10)
+	}
+	return ""
+}
+
+// dateLayouts are the layouts roast dates are commonly typed in.
+var dateLayouts = []string{"2006-01-02", "2006/01/02", "02.01.2006", time.RFC3339}
+
+func parseDate(s string) *time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+			return &t
+		}
+	}
+	return nil
+}
+
+// parseRoastLevel maps a roast level typed as text, such as "Light" or
+// "medium-dark", onto a roast level, medium when unknown.
+func parseRoastLevel(s string) sql.RoastLevel {
+	s = strings.ToLower(s)
+	light, dark := strings.Contains(s, "light"), strings.Contains(s, "dark")
+	switch {
+	case light && strings.Contains(s, "medium"):
+		return sql.RoastLevelLightToMedium
+	case dark && strings.Contains(s, "medium"):
+		return sql.RoastLevelMediumToDark
+	case light:
+		return sql.RoastLevelLight
+	case dark:
+		return sql.RoastLevelDark
+	}
+	return sql.RoastLevelMedium
+}
+
+func orDefault(s, fallback string) string {
+	if s = strings.TrimSpace(s); s != "" {
+		return s
+	}
+	return fallback
+}
@@ -0,0 +1,171 @@
+package decent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+)
+
+const testShotFile = `clock 1700000000
+espresso_elapsed {0.0 0.25 0.5 0.5 27.9}
+espresso_pressure {0.0 1.2 8.9 9.0 6.1}
+espresso_flow {0.0 0.5 2.0 2.1 1.8}
+espresso_weight {-0.1 0.0 3.2 3.3 36.4}
+espresso_temperature_basket {92.0 92.5 93.0 93.0 93.5}
+settings {
+	bean_brand {Square Mile}
+	bean_type "Red Brick"
+	roast_date 2023-11-01
+	roast_level {Medium-Dark}
+	grinder_setting 11.5
+	grinder_dose_weight 18.0
+	drink_weight 36.2
+	espresso_enjoyment 75
+	espresso_notes {A bit {sour}}
+	profile_title {Best practice}
+}
+`
+
+const testVisualizerFile = `{
+  "id": "0b1c2d3e",
+  "start_time": "2023-11-14T22:13:20.000Z",
+  "duration": "28.0",
+  "bean_weight": "18",
+  "drink_weight": null,
+  "espresso_enjoyment": 80,
+  "grinder_setting": 12,
+  "bean_brand": "Square Mile",
+  "bean_type": "Red Brick",
+  "roast_level": "light",
+  "timeframe": ["0.0", "10.0", "28.0"],
+  "data": {
+    "espresso_pressure": ["0.0", "9.0", "6.0"],
+    "espresso_weight": ["0.0", "12.0", "38.05"],
+    "espresso_temperature_mix": ["90.0", "91.0", "92.0"]
+  }
+}`
+
+func TestParseShotFile(t *testing.T) {
+	got, err := Parse([]byte(testShotFile))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if got.Source != Source || got.ExternalId != "1700000000" {
+		t.Errorf("Parse() source = %q/%q, want decent/1700000000", got.Source, got.ExternalId)
+	}
+	if got.RoasterName != "Square Mile" || got.BeansName != "Red Brick" || got.RoastLevel != sql.RoastLevelMediumToDark {
+		t.Errorf("Parse() beans = %q by %q, roast level %d", got.BeansName, got.RoasterName, got.RoastLevel)
+	}
+	if got.RoastDate == nil || !got.RoastDate.Equal(time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Parse() roast date = %v, want 2023-11-01", got.RoastDate)
+	}
+	want := sql.Shot{
+		GrindSetting: 12, QuantityIn: 18, QuantityOut: 36.2, ShotTime: 27900 * time.Millisecond, WaterTemperature: 92.8, Rating: 7.5,
+		ComparisonWithPreviousResult: sql.Unknown, AdditionalNotes: "Profile: Best practice\nA bit {sour}",
+	}
+	if *got.Shot != want {
+		t.Errorf("Parse() shot = %+v, want %+v", *got.Shot, want)
+	}
+
+	if len(got.Profile) != 4 {
+		t.Fatalf("Parse() profile has %d samples, want 4 without the repeated time", len(got.Profile))
+	}
+	first, last := got.Profile[0], got.Profile[3]
+	if first.ElapsedTime != 0 || *first.Weight != 0 {
+		t.Errorf("Parse() first sample = %+v, want a weight clamped to 0", first)
+	}
+	if last.ElapsedTime != 27900 || *last.Pressure != 6.1 || *last.Flow != 1.8 || *last.Weight != 36.4 || *last.Temperature != 93.5 {
+		t.Errorf("Parse() last sample = %+v", last)
+	}
+}
+
+func TestParseVisualizer(t *testing.T) {
+	got, err := Parse([]byte(testVisualizerFile))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	// The start time of the visualizer shot is the clock of the .shot file.
+	if got.ExternalId != "1700000000" {
+		t.Errorf("Parse() external id = %q, want 1700000000", got.ExternalId)
+	}
+	if got.RoastLevel != sql.RoastLevelLight || got.RoastDate != nil {
+		t.Errorf("Parse() roast = %d, %v", got.RoastLevel, got.RoastDate)
+	}
+	s := got.Shot
+	if s.GrindSetting != 12 || s.QuantityIn != 18 || s.QuantityOut != 38.1 || s.ShotTime != 28*time.Second || s.WaterTemperature != 91 || s.Rating != 8 {
+		t.Errorf("Parse() shot = %+v, want the yield of the last weight and the mean temperature", *s)
+	}
+	if len(got.Profile) != 3 || got.Profile[1].ElapsedTime != 10000 || *got.Profile[1].Pressure != 9 || got.Profile[1].Flow != nil {
+		t.Errorf("Parse() profile = %+v", got.Profile)
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	got, err := Parse([]byte("espresso_elapsed {}\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got.RoasterName != unknownRoaster || got.BeansName != unknownBeans || got.RoastLevel != sql.RoastLevelMedium {
+		t.Errorf("Parse() beans = %q by %q, roast level %d", got.BeansName, got.RoasterName, got.RoastLevel)
+	}
+	if got.Shot.WaterTemperature != defaultWaterTemperature {
+		t.Errorf("Parse() temperature = %v, want %v", got.Shot.WaterTemperature, defaultWaterTemperature)
+	}
+	if !strings.HasPrefix(got.ExternalId, "sha256:") {
+		t.Errorf("Parse() external id = %q, want a hash of the file without a clock", got.ExternalId)
+	}
+}
+
+func TestParseThinsOutLongProfiles(t *testing.T) {
+	var times strings.Builder
+	for i := range 2*shot.MaxProfileSamples + 1 {
+		fmt.Fprintf(&times, "%g ", float64(i)/100)
+	}
+	got, err := Parse([]byte("clock 1\nespresso_elapsed {" + times.String() + "}\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if n := len(got.Profile); n > shot.MaxProfileSamples || n < shot.MaxProfileSamples/2 {
+		t.Errorf("Parse() profile has %d samples, want at most %d", n, shot.MaxProfileSamples)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{name: "empty", data: "", want: domainerrors.ErrImportFileIsInvalid},
+		{name: "unbalanced braces", data: "clock 1\nespresso_elapsed {0.0 0.25", want: domainerrors.ErrImportFileIsInvalid},
+		{name: "not a shot", data: "hello world", want: domainerrors.ErrImportFileIsInvalid},
+		{name: "unrelated JSON", data: `{"name": "not a shot"}`, want: domainerrors.ErrImportFileIsInvalid},
+		{name: "shot too long", data: `{"start_time": "2023-11-14T22:13:20Z", "duration": 3601}`, want: domainerrors.ErrShotTimeOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.data)); !errors.Is(err, tt.want) {
+				t.Errorf("Parse() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTclList(t *testing.T) {
+	got, err := parseTclList(`a {b {c d}} "e \"f\"" g\ h {}`)
+	if err != nil {
+		t.Fatalf("parseTclList() error = %v", err)
+	}
+	want := []string{"a", "b {c d}", `e "f"`, "g h", ""}
+	if fmt.Sprint(got) != fmt.Sprint(want) || len(got) != len(want) {
+		t.Errorf("parseTclList() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,195 @@
+// Package decent imports the shots recorded by a Decent Espresso machine,
+// from the .shot files of the DE1 app or the JSON files downloaded from
+// visualizer.coffee.
+package decent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// Source is the source of the imported shots, recorded to detect the
+	// shots imported twice.
+	Source = "decent"
+
+	// DefaultSheetName is the sheet the shots are imported in, unless
+	// another one is given.
+	DefaultSheetName = "Decent"
+)
+
+// Status is the outcome of the import of a file.
+//
+// enum: imported,new,duplicate,failed
+type Status string
+
+const (
+	// StatusImported is a shot imported.
+	StatusImported Status = "imported"
+	// StatusNew is a shot a dry run would have imported.
+	StatusNew Status = "new"
+	// StatusDuplicate is a shot imported before, or twice in the same import.
+	StatusDuplicate Status = "duplicate"
+	// StatusFailed is a file which is not a shot, or a shot which could not
+	// be imported.
+	StatusFailed Status = "failed"
+)
+
+// File is a file to import.
+type File struct {
+	Name string
+	Data []byte
+}
+
+// Options are the options of an import.
+type Options struct {
+	// Sheet is the name of the sheet to import the shots in, created if
+	// missing. DefaultSheetName when empty.
+	Sheet string
+
+	// DryRun only reports what would be imported.
+	DryRun bool
+}
+
+// Report is the outcome of an import
+//
+// swagger:model DecentImportReport
+type Report struct {
+	// Whether nothing was imported, only reported
+	DryRun bool `json:"dry_run"`
+
+	// The number of shots imported, or which would be by a dry run
+	Imported int `json:"imported"`
+
+	// The number of shots already imported
+	Duplicates int `json:"duplicates"`
+
+	// The number of files which could not be imported
+	Failed int `json:"failed"`
+
+	// The outcome of every file, in the order they were given
+	Files []FileReport `json:"files"`
+}
+
+// FileReport is the outcome of the import of a file.
+//
+// swagger:model DecentImportFileReport
+type FileReport struct {
+	// The name of the file
+	Name string `json:"name"`
+
+	// The outcome of the import of the file: imported, new (in a dry run),
+	// duplicate or failed
+	Status Status `json:"status"`
+
+	// The id of the shot imported, or of the shot imported before for a
+	// duplicate
+	ShotId *int `json:"shot_id,omitempty"`
+
+	// Why the file could not be imported
+	Error string `json:"error,omitempty"`
+}
+
+type Service interface {
+	Import(ctx context.Context, files []File, opts Options) (*Report, error)
+}
+
+type DecentService struct {
+	repository repository.ShotImportRepository
+}
+
+var _ Service = (*DecentService)(nil)
+
+func New(repo repository.ShotImportRepository) *DecentService {
+	return &DecentService{repository: repo}
+}
+
+// Import imports the shots of files. A shot is imported along with its
+// profile, in the sheet of opts, and with its roaster and beans, created if
+// missing. Each shot is imported in its own transaction: files which are not
+// shots, or shots imported before, do not stop the import, but a database
+// failure does, leaving the shots imported until then.
+func (s *DecentService) Import(ctx context.Context, files []File, opts Options) (*Report, error) {
+	if len(files) == 0 {
+		return nil, domainerrors.ErrImportHasNoFiles
+	}
+	if opts.Sheet == "" {
+		opts.Sheet = DefaultSheetName
+	}
+
+	report := &Report{DryRun: opts.DryRun, Files: make([]FileReport, len(files))}
+	shots := make([]*sql.ImportedShot, len(files))
+	var externalIds []string
+	for i, f := range files {
+		report.Files[i].Name = f.Name
+		imported, err := Parse(f.Data)
+		if err != nil {
+			report.Files[i].Status, report.Files[i].Error = StatusFailed, err.Error()
+			continue
+		}
+		imported.SheetName = opts.Sheet
+		shots[i] = imported
+		externalIds = append(externalIds, imported.ExternalId)
+	}
+
+	existing, err := s.repository.GetImportedShotIds(ctx, Source, externalIds)
+	if err != nil {
+		msg := "could not get imported shots"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	seen := make(map[string]*int, len(externalIds))
+	for i, imported := range shots {
+		file := &report.Files[i]
+		if imported == nil {
+			continue
+		}
+		if id, ok := existing[imported.ExternalId]; ok {
+			file.Status, file.ShotId = StatusDuplicate, &id
+			continue
+		}
+		if id, ok := seen[imported.ExternalId]; ok {
+			file.Status, file.ShotId = StatusDuplicate, id
+			continue
+		}
+
+		if opts.DryRun {
+			file.Status = StatusNew
+			seen[imported.ExternalId] = nil
+			continue
+		}
+		id, err := s.repository.ImportShot(ctx, imported)
+		switch {
+		case errors.Is(err, domainerrors.ErrImportShotAlreadyExists):
+			file.Status = StatusDuplicate
+		case errors.Is(err, domainerrors.ErrSheetAlreadyExists), errors.Is(err, domainerrors.ErrRoasterAlreadyExists):
+			file.Status, file.Error = StatusFailed, err.Error()
+		case err != nil:
+			msg := "could not import shot"
+			zerolog.Ctx(ctx).Err(err).Str("file", file.Name).Msg(msg)
+			return nil, fmt.Errorf("%s from %q: %w", msg, file.Name, err)
+		default:
+			file.Status, file.ShotId = StatusImported, &id
+			seen[imported.ExternalId] = &id
+		}
+	}
+
+	for _, f := range report.Files {
+		switch f.Status {
+		case StatusImported, StatusNew:
+			report.Imported++
+		case StatusDuplicate:
+			report.Duplicates++
+		case StatusFailed:
+			report.Failed++
+		}
+	}
+	return report, nil
+}
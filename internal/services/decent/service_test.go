@@ -0,0 +1,128 @@
+package decent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type MockShotImportRepository struct {
+	imported map[string]int
+	nextId   int
+	err      error
+	calls    []*sql.ImportedShot
+}
+
+func (m *MockShotImportRepository) GetImportedShotIds(ctx context.Context, source string, externalIds []string) (map[string]int, error) {
+	ids := make(map[string]int)
+	for _, id := range externalIds {
+		if shotId, ok := m.imported[id]; ok {
+			ids[id] = shotId
+		}
+	}
+	return ids, nil
+}
+
+func (m *MockShotImportRepository) ImportShot(ctx context.Context, shot *sql.ImportedShot) (int, error) {
+	m.calls = append(m.calls, shot)
+	if m.err != nil {
+		return 0, m.err
+	}
+	m.nextId++
+	return m.nextId, nil
+}
+
+func (m *MockShotImportRepository) GetShotExternalIds(ctx context.Context, source string) (map[int]string, error) {
+	return nil, nil
+}
+
+func (m *MockShotImportRepository) GetOrCreateSheet(ctx context.Context, name string) (int, bool, error) {
+	return 0, false, nil
+}
+
+func (m *MockShotImportRepository) GetOrCreateRoaster(ctx context.Context, name string) (int, bool, error) {
+	return 0, false, nil
+}
+
+func (m *MockShotImportRepository) GetOrCreateBeans(ctx context.Context, beans *sql.Beans) (int, bool, error) {
+	return 0, false, nil
+}
+
+func (m *MockShotImportRepository) Ping(ctx context.Context) error { return nil }
+
+func TestImport(t *testing.T) {
+	repo := &MockShotImportRepository{imported: map[string]int{"1600000000": 4}, nextId: 10}
+	files := []File{
+		{Name: "a.shot", Data: []byte(testShotFile)},
+		{Name: "b.json", Data: []byte(testVisualizerFile)},
+		{Name: "old.shot", Data: []byte("clock 1600000000\nespresso_elapsed {}")},
+		{Name: "notes.txt", Data: []byte("not a shot")},
+	}
+
+	report, err := New(repo).Import(context.Background(), files, Options{})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if report.Imported != 1 || report.Duplicates != 2 || report.Failed != 1 || report.DryRun {
+		t.Errorf("Import() report = %+v", report)
+	}
+	want := []Status{StatusImported, StatusDuplicate, StatusDuplicate, StatusFailed}
+	for i, f := range report.Files {
+		if f.Name != files[i].Name || f.Status != want[i] {
+			t.Errorf("Import() file %d = %+v, want status %s", i, f, want[i])
+		}
+	}
+	if id := report.Files[1].ShotId; id == nil || *id != 11 {
+		t.Errorf("Import() duplicate in the same import should point to shot 11, got %v", id)
+	}
+	if id := report.Files[2].ShotId; id == nil || *id != 4 {
+		t.Errorf("Import() duplicate of a previous import should point to shot 4, got %v", id)
+	}
+	if report.Files[3].Error != domainerrors.ErrImportFileIsInvalid.Error() {
+		t.Errorf("Import() error of an invalid file = %q", report.Files[3].Error)
+	}
+	if len(repo.calls) != 1 || repo.calls[0].SheetName != DefaultSheetName {
+		t.Errorf("Import() imported %+v, want a single shot in the default sheet", repo.calls)
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	repo := &MockShotImportRepository{}
+	report, err := New(repo).Import(context.Background(), []File{{Name: "a.shot", Data: []byte(testShotFile)}}, Options{Sheet: "DE1", DryRun: true})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if !report.DryRun || report.Imported != 1 || report.Files[0].Status != StatusNew || report.Files[0].ShotId != nil {
+		t.Errorf("Import() report = %+v", report)
+	}
+	if len(repo.calls) != 0 {
+		t.Errorf("Import() dry run imported %d shots", len(repo.calls))
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	files := []File{{Name: "a.shot", Data: []byte(testShotFile)}}
+
+	if _, err := New(&MockShotImportRepository{}).Import(context.Background(), nil, Options{}); !errors.Is(err, domainerrors.ErrImportHasNoFiles) {
+		t.Errorf("Import() error = %v, want %v", err, domainerrors.ErrImportHasNoFiles)
+	}
+
+	report, err := New(&MockShotImportRepository{err: domainerrors.ErrSheetAlreadyExists}).Import(context.Background(), files, Options{})
+	if err != nil || report.Failed != 1 || report.Files[0].Error != domainerrors.ErrSheetAlreadyExists.Error() {
+		t.Errorf("Import() = %+v, %v, want the file failed on the sheet of another user", report, err)
+	}
+
+	report, err = New(&MockShotImportRepository{err: domainerrors.ErrImportShotAlreadyExists}).Import(context.Background(), files, Options{})
+	if err != nil || report.Duplicates != 1 {
+		t.Errorf("Import() = %+v, %v, want a duplicate imported concurrently", report, err)
+	}
+
+	dbErr := errors.New("connection refused")
+	if _, err := New(&MockShotImportRepository{err: dbErr}).Import(context.Background(), files, Options{}); !errors.Is(err, dbErr) {
+		t.Errorf("Import() error = %v, want %v", err, dbErr)
+	}
+}
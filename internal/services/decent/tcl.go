@@ -0,0 +1,83 @@
+package decent
+
+import (
+	"errors"
+	"strings"
+)
+
+var errTclUnbalanced = errors.New("unbalanced braces or quotes")
+
+// parseTclList splits s into the words of a Tcl list: words are separated by
+// white space, and either bare, "quoted" or {braced}. Braces nest, and the
+// content of a braced word is kept as is so it can be parsed as a list in
+// turn. Backslashes escape the next character.
+func parseTclList(s string) ([]string, error) {
+	var words []string
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case ' ', '\t', '\n', '\r':
+			i++
+			continue
+		}
+
+		var word strings.Builder
+		switch s[i] {
+		case '{':
+			depth := 1
+			j := i + 1
+			for ; j < len(s) && depth > 0; j++ {
+				switch s[j] {
+				case '\\':
+					j++
+				case '{':
+					depth++
+				case '}':
+					depth--
+				}
+			}
+			if depth > 0 {
+				return nil, errTclUnbalanced
+			}
+			word.WriteString(s[i+1 : j-1])
+			i = j
+		case '"':
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				word.WriteByte(s[j])
+			}
+			if j >= len(s) {
+				return nil, errTclUnbalanced
+			}
+			i = j + 1
+		default:
+			for ; i < len(s) && !strings.ContainsRune(" \t\n\r", rune(s[i])); i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				word.WriteByte(s[i])
+			}
+		}
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// parseTclDict parses s as a Tcl list of alternating keys and values. A key
+// appearing twice keeps its last value.
+func parseTclDict(s string) (map[string]string, error) {
+	words, err := parseTclList(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(words)%2 != 0 {
+		return nil, errors.New("odd number of words in dictionary")
+	}
+	dict := make(map[string]string, len(words)/2)
+	for i := 0; i < len(words); i += 2 {
+		dict[words[i]] = words[i+1]
+	}
+	return dict, nil
+}
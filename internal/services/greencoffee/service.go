@@ -0,0 +1,233 @@
+package greencoffee
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// GreenCoffee
+//
+// A green coffee is a purchase of unroasted coffee. Its stock goes down as
+// roast batches and beans reference it with the green weight they used.
+//
+// swagger:model
+type GreenCoffee struct {
+	// The id for the green coffee
+	Id int `json:"id"`
+
+	// The origin of the green coffee
+	Origin string `json:"origin"`
+
+	// The supplier the green coffee was bought from
+	Supplier string `json:"supplier"`
+
+	// The weight purchased, in kilograms
+	PurchaseWeight float64 `json:"purchase_weight"`
+
+	// The total price paid for the purchase
+	Price float64 `json:"price"`
+
+	// The date the green coffee arrived
+	ArrivalDate *time.Time `json:"arrival_date"`
+
+	// The moisture content, in percent
+	Moisture float64 `json:"moisture"`
+
+	// The weight left in stock, in kilograms. It is negative when more was
+	// roasted than purchased.
+	RemainingWeight float64 `json:"remaining_weight"`
+
+	// The price paid per kilogram purchased
+	CostPerKilogram float64 `json:"cost_per_kilogram"`
+
+	// The creation date of the green coffee
+	CreatedAt *time.Time `json:"created_at"`
+
+	// The last update date of the green coffee
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// SQLToGreenCoffee converts a *sql.GreenCoffee object to a *GreenCoffee
+// object and computes its remaining weight, rounded to the gram, and its
+// cost per kilogram, rounded to two decimals. If the input is nil, it
+// returns nil.
+func SQLToGreenCoffee(greenCoffee *sql.GreenCoffee) *GreenCoffee {
+	if greenCoffee == nil {
+		return nil
+	}
+
+	g := new(GreenCoffee)
+	g.Id = greenCoffee.Id
+	g.Origin = greenCoffee.Origin
+	g.Supplier = greenCoffee.Supplier
+	g.PurchaseWeight = greenCoffee.PurchaseWeight
+	g.Price = greenCoffee.Price
+	g.ArrivalDate = greenCoffee.ArrivalDate
+	g.Moisture = greenCoffee.Moisture
+	g.RemainingWeight = math.Round(greenCoffee.PurchaseWeight*1000-greenCoffee.ConsumedWeight) / 1000
+	if greenCoffee.PurchaseWeight > 0 {
+		g.CostPerKilogram = math.Round(greenCoffee.Price/greenCoffee.PurchaseWeight*100) / 100
+	}
+	g.CreatedAt = greenCoffee.CreatedAt
+	g.UpdatedAt = greenCoffee.UpdatedAt
+
+	return g
+}
+
+// GreenCoffeeToSQL converts a GreenCoffee object to its SQL representation.
+// If the input is nil, it returns nil.
+func GreenCoffeeToSQL(greenCoffee *GreenCoffee) *sql.GreenCoffee {
+	if greenCoffee == nil {
+		return nil
+	}
+
+	g := new(sql.GreenCoffee)
+	g.Id = greenCoffee.Id
+	g.Origin = greenCoffee.Origin
+	g.Supplier = greenCoffee.Supplier
+	g.PurchaseWeight = greenCoffee.PurchaseWeight
+	g.Price = greenCoffee.Price
+	g.ArrivalDate = greenCoffee.ArrivalDate
+	g.Moisture = greenCoffee.Moisture
+	g.CreatedAt = greenCoffee.CreatedAt
+	g.UpdatedAt = greenCoffee.UpdatedAt
+
+	return g
+}
+
+type Service interface {
+	CreateGreenCoffee(ctx context.Context, greenCoffee *GreenCoffee) (*GreenCoffee, error)
+	GetGreenCoffeeById(ctx context.Context, id int) (*GreenCoffee, error)
+	GetAllGreenCoffees(ctx context.Context) ([]GreenCoffee, error)
+	UpdateGreenCoffeeById(ctx context.Context, id int, greenCoffee *GreenCoffee) (*GreenCoffee, error)
+	DeleteGreenCoffeeById(ctx context.Context, id int) error
+	Ping(ctx context.Context) error
+}
+
+type GreenCoffeeService struct {
+	repository repository.GreenCoffeeRepository
+}
+
+var _ Service = (*GreenCoffeeService)(nil)
+
+func New(repo repository.GreenCoffeeRepository) *GreenCoffeeService {
+	return &GreenCoffeeService{repository: repo}
+}
+
+func (s *GreenCoffeeService) CreateGreenCoffee(ctx context.Context, greenCoffee *GreenCoffee) (*GreenCoffee, error) {
+	if err := validateGreenCoffee(greenCoffee); err != nil {
+		msg := "could not create green coffee"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	id, err := s.repository.CreateGreenCoffee(ctx, GreenCoffeeToSQL(greenCoffee))
+	if err != nil {
+		msg := "could not create green coffee"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	created, err := s.GetGreenCoffeeById(ctx, id)
+	if err != nil {
+		msg := "could not get newly created green coffee"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return created, nil
+}
+
+func (s *GreenCoffeeService) GetGreenCoffeeById(ctx context.Context, id int) (*GreenCoffee, error) {
+	greenCoffee, err := s.repository.GetGreenCoffeeById(ctx, id)
+	if err != nil {
+		msg := "could not get green coffee by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToGreenCoffee(greenCoffee), nil
+}
+
+func (s *GreenCoffeeService) GetAllGreenCoffees(ctx context.Context) ([]GreenCoffee, error) {
+	sqlGreenCoffees, err := s.repository.GetAllGreenCoffees(ctx)
+	if err != nil {
+		msg := "could not get all green coffees"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	greenCoffees := make([]GreenCoffee, len(sqlGreenCoffees))
+	for i, v := range sqlGreenCoffees {
+		greenCoffees[i] = *SQLToGreenCoffee(&v)
+	}
+
+	return greenCoffees, nil
+}
+
+func (s *GreenCoffeeService) UpdateGreenCoffeeById(ctx context.Context, id int, greenCoffee *GreenCoffee) (*GreenCoffee, error) {
+	if err := validateGreenCoffee(greenCoffee); err != nil {
+		msg := "could not update green coffee by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	greenCoffee.Id = id
+	updated, err := s.repository.UpdateGreenCoffeeById(ctx, id, GreenCoffeeToSQL(greenCoffee))
+	if err != nil {
+		msg := "could not update green coffee by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToGreenCoffee(updated), nil
+}
+
+func (s *GreenCoffeeService) DeleteGreenCoffeeById(ctx context.Context, id int) error {
+	if err := s.repository.DeleteGreenCoffeeById(ctx, id); err != nil {
+		msg := "could not delete green coffee by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+func (s *GreenCoffeeService) Ping(ctx context.Context) error {
+	if err := s.repository.Ping(ctx); err != nil {
+		msg := "could not ping database"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// validateGreenCoffee rejects a nil green coffee, a green coffee without
+// origin, and out of range purchase weight, price or moisture.
+func validateGreenCoffee(greenCoffee *GreenCoffee) error {
+	if greenCoffee == nil {
+		return errors.ErrGreenCoffeeIsNil
+	}
+	greenCoffee.Origin = strings.TrimSpace(greenCoffee.Origin)
+	greenCoffee.Supplier = strings.TrimSpace(greenCoffee.Supplier)
+	if greenCoffee.Origin == "" {
+		return errors.ErrGreenCoffeeOriginIsEmpty
+	}
+	if greenCoffee.PurchaseWeight <= 0 {
+		return errors.ErrGreenCoffeePurchaseWeightOutOfRange
+	}
+	if greenCoffee.Price < 0 {
+		return errors.ErrGreenCoffeePriceOutOfRange
+	}
+	if greenCoffee.Moisture < 0 || greenCoffee.Moisture > 100 {
+		return errors.ErrGreenCoffeeMoistureOutOfRange
+	}
+	return nil
+}
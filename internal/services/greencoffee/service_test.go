@@ -0,0 +1,218 @@
+package greencoffee
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+)
+
+var (
+	arrivalDate = time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type IsErrorCtxKey string
+
+type MockGreenCoffeeRepository struct {
+	created *sql.GreenCoffee
+	updated *sql.GreenCoffee
+}
+
+func (m *MockGreenCoffeeRepository) CreateGreenCoffee(ctx context.Context, greenCoffee *sql.GreenCoffee) (int, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return 0, fmt.Errorf("mock error")
+	}
+	m.created = greenCoffee
+	return 1, nil
+}
+
+func (m *MockGreenCoffeeRepository) GetGreenCoffeeById(ctx context.Context, id int) (*sql.GreenCoffee, error) {
+	if id != 1 {
+		return nil, errors.ErrGreenCoffeeDoesNotExist
+	}
+	return testSQLGreenCoffee(), nil
+}
+
+func (m *MockGreenCoffeeRepository) GetAllGreenCoffees(ctx context.Context) ([]sql.GreenCoffee, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return nil, fmt.Errorf("mock error")
+	}
+	return []sql.GreenCoffee{*testSQLGreenCoffee()}, nil
+}
+
+func (m *MockGreenCoffeeRepository) UpdateGreenCoffeeById(ctx context.Context, id int, greenCoffee *sql.GreenCoffee) (*sql.GreenCoffee, error) {
+	if id != 1 {
+		return nil, errors.ErrGreenCoffeeDoesNotExist
+	}
+	m.updated = greenCoffee
+	updated := *greenCoffee
+	updated.ConsumedWeight = 500
+	return &updated, nil
+}
+
+func (m *MockGreenCoffeeRepository) DeleteGreenCoffeeById(ctx context.Context, id int) error {
+	if id != 1 {
+		return errors.ErrGreenCoffeeDoesNotExist
+	}
+	return nil
+}
+
+func (m *MockGreenCoffeeRepository) Ping(ctx context.Context) error {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return fmt.Errorf("mock error")
+	}
+	return nil
+}
+
+func testSQLGreenCoffee() *sql.GreenCoffee {
+	return &sql.GreenCoffee{
+		Id: 1, Origin: "Ethiopia Guji", Supplier: "Green Traders", PurchaseWeight: 3, Price: 50, ArrivalDate: &arrivalDate, Moisture: 10.5,
+		ConsumedWeight: 1250.4,
+	}
+}
+
+func testGreenCoffee() *GreenCoffee {
+	return &GreenCoffee{Origin: " Ethiopia Guji ", Supplier: "Green Traders", PurchaseWeight: 3, Price: 50, ArrivalDate: &arrivalDate, Moisture: 10.5}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		repo repository.GreenCoffeeRepository
+		want *GreenCoffeeService
+	}{
+		{name: "nil args", repo: nil, want: &GreenCoffeeService{nil}},
+		{name: "non nil args", repo: &MockGreenCoffeeRepository{}, want: &GreenCoffeeService{&MockGreenCoffeeRepository{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.repo); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("New() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLToGreenCoffeeComputesStockAndCost(t *testing.T) {
+	if got := SQLToGreenCoffee(nil); got != nil {
+		t.Errorf("SQLToGreenCoffee(nil) = %v, want nil", got)
+	}
+
+	got := SQLToGreenCoffee(testSQLGreenCoffee())
+	if got.RemainingWeight != 1.75 {
+		t.Errorf("RemainingWeight = %v, want 1.75", got.RemainingWeight)
+	}
+	if got.CostPerKilogram != 16.67 {
+		t.Errorf("CostPerKilogram = %v, want 16.67", got.CostPerKilogram)
+	}
+
+	overdrawn := testSQLGreenCoffee()
+	overdrawn.ConsumedWeight = 3500
+	if got := SQLToGreenCoffee(overdrawn); got.RemainingWeight != -0.5 {
+		t.Errorf("RemainingWeight = %v, want -0.5 when more was roasted than purchased", got.RemainingWeight)
+	}
+}
+
+func TestGreenCoffeeServiceCreateGreenCoffee(t *testing.T) {
+	withoutOrigin := testGreenCoffee()
+	withoutOrigin.Origin = "  "
+	withoutWeight := testGreenCoffee()
+	withoutWeight.PurchaseWeight = 0
+	negativePrice := testGreenCoffee()
+	negativePrice.Price = -1
+	soaked := testGreenCoffee()
+	soaked.Moisture = 101
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		greenCoffee *GreenCoffee
+		wantErr     error
+		anyErr      bool
+	}{
+		{name: "nil green coffee", ctx: context.Background(), greenCoffee: nil, wantErr: errors.ErrGreenCoffeeIsNil},
+		{name: "blank origin", ctx: context.Background(), greenCoffee: withoutOrigin, wantErr: errors.ErrGreenCoffeeOriginIsEmpty},
+		{name: "no purchase weight", ctx: context.Background(), greenCoffee: withoutWeight, wantErr: errors.ErrGreenCoffeePurchaseWeightOutOfRange},
+		{name: "negative price", ctx: context.Background(), greenCoffee: negativePrice, wantErr: errors.ErrGreenCoffeePriceOutOfRange},
+		{name: "moisture above 100", ctx: context.Background(), greenCoffee: soaked, wantErr: errors.ErrGreenCoffeeMoistureOutOfRange},
+		{name: "repository error", ctx: context.WithValue(context.Background(), IsErrorCtxKey("isError"), true), greenCoffee: testGreenCoffee(), anyErr: true},
+		{name: "created", ctx: context.Background(), greenCoffee: testGreenCoffee()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockGreenCoffeeRepository{}
+			got, err := New(repo).CreateGreenCoffee(tt.ctx, tt.greenCoffee)
+			if tt.wantErr != nil {
+				if !stderrors.Is(err, tt.wantErr) {
+					t.Fatalf("CreateGreenCoffee() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.anyErr {
+				if err == nil {
+					t.Fatal("CreateGreenCoffee() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateGreenCoffee() error = %v", err)
+			}
+			if repo.created.Origin != "Ethiopia Guji" {
+				t.Errorf("stored origin = %q, want it trimmed", repo.created.Origin)
+			}
+			if got.Id != 1 || got.RemainingWeight != 1.75 {
+				t.Errorf("CreateGreenCoffee() = %+v, want id 1 with 1.75 kg remaining", got)
+			}
+		})
+	}
+}
+
+func TestGreenCoffeeServiceGetAllGreenCoffees(t *testing.T) {
+	s := New(&MockGreenCoffeeRepository{})
+
+	got, err := s.GetAllGreenCoffees(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllGreenCoffees() error = %v", err)
+	}
+	if len(got) != 1 || got[0].CostPerKilogram != 16.67 {
+		t.Errorf("GetAllGreenCoffees() = %+v, want one green coffee with its cost per kilogram", got)
+	}
+
+	if _, err := s.GetAllGreenCoffees(context.WithValue(context.Background(), IsErrorCtxKey("isError"), true)); err == nil {
+		t.Error("GetAllGreenCoffees() error = nil, want error")
+	}
+}
+
+func TestGreenCoffeeServiceUpdateGreenCoffeeById(t *testing.T) {
+	repo := &MockGreenCoffeeRepository{}
+	s := New(repo)
+
+	got, err := s.UpdateGreenCoffeeById(context.Background(), 1, testGreenCoffee())
+	if err != nil {
+		t.Fatalf("UpdateGreenCoffeeById() error = %v", err)
+	}
+	if repo.updated.Id != 1 || got.RemainingWeight != 2.5 {
+		t.Errorf("UpdateGreenCoffeeById() = %+v, want id 1 with 2.5 kg remaining", got)
+	}
+
+	if _, err := s.UpdateGreenCoffeeById(context.Background(), 2, testGreenCoffee()); !stderrors.Is(err, errors.ErrGreenCoffeeDoesNotExist) {
+		t.Errorf("UpdateGreenCoffeeById() error = %v, want %v", err, errors.ErrGreenCoffeeDoesNotExist)
+	}
+}
+
+func TestGreenCoffeeServiceDeleteGreenCoffeeById(t *testing.T) {
+	s := New(&MockGreenCoffeeRepository{})
+
+	if err := s.DeleteGreenCoffeeById(context.Background(), 1); err != nil {
+		t.Errorf("DeleteGreenCoffeeById() error = %v", err)
+	}
+	if err := s.DeleteGreenCoffeeById(context.Background(), 2); !stderrors.Is(err, errors.ErrGreenCoffeeDoesNotExist) {
+		t.Errorf("DeleteGreenCoffeeById() error = %v, want %v", err, errors.ErrGreenCoffeeDoesNotExist)
+	}
+}
@@ -0,0 +1,360 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// TaskType is the kind of maintenance a task is about.
+type TaskType string
+
+const (
+	TaskTypeBackflush       TaskType = "backflush"
+	TaskTypeDescale         TaskType = "descale"
+	TaskTypeBurrChange      TaskType = "burr_change"
+	TaskTypeGasketChange    TaskType = "gasket_change"
+	TaskTypeWaterFilter     TaskType = "water_filter"
+	TaskTypeGrinderCleaning TaskType = "grinder_cleaning"
+	TaskTypeOther           TaskType = "other"
+)
+
+// TaskTypes lists the task types, in the order they are offered.
+var TaskTypes = []TaskType{
+	TaskTypeBackflush,
+	TaskTypeDescale,
+	TaskTypeBurrChange,
+	TaskTypeGasketChange,
+	TaskTypeWaterFilter,
+	TaskTypeGrinderCleaning,
+	TaskTypeOther,
+}
+
+// IsValid reports whether t is a known task type.
+func (t TaskType) IsValid() bool {
+	for _, v := range TaskTypes {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Label returns the task type for display, such as "Burr change".
+func (t TaskType) Label() string {
+	s := strings.ReplaceAll(string(t), "_", " ")
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// Equipment is what a maintenance task is linked to, if anything.
+type Equipment string
+
+const (
+	EquipmentNone    Equipment = ""
+	EquipmentMachine Equipment = "machine"
+	EquipmentGrinder Equipment = "grinder"
+)
+
+// IsValid reports whether e is a known equipment, or none.
+func (e Equipment) IsValid() bool {
+	return e == EquipmentNone || e == EquipmentMachine || e == EquipmentGrinder
+}
+
+// MaintenanceTask
+//
+// A maintenance task is a recurring chore on the espresso machine or the
+// grinder, such as a backflush or a burr change. It is due once as many
+// shots as its interval in shots were pulled, or as many days as its
+// interval in days went by, since it was last done.
+//
+// swagger:model
+type MaintenanceTask struct {
+	// The id for the maintenance task
+	Id int `json:"id"`
+
+	// The kind of maintenance
+	Type TaskType `json:"type"`
+
+	// The equipment the task is for: machine, grinder or empty
+	Equipment Equipment `json:"equipment"`
+
+	// The name of the machine or grinder, such as "Linea Mini"
+	EquipmentName string `json:"equipment_name"`
+
+	// The number of shots between two completions
+	IntervalShots *int `json:"interval_shots"`
+
+	// The number of days between two completions
+	IntervalDays *int `json:"interval_days"`
+
+	// When the task was last done. Null when it never was.
+	LastDoneAt *time.Time `json:"last_done_at"`
+
+	// The number of shots pulled since the task was last done, or since it
+	// was created when it never was
+	ShotsSinceDone int `json:"shots_since_done"`
+
+	// The number of whole days since the task was last done, or since it was
+	// created when it never was
+	DaysSinceDone int `json:"days_since_done"`
+
+	// The shots left before the task is due. Negative when it is overdue,
+	// null without an interval in shots.
+	ShotsRemaining *int `json:"shots_remaining"`
+
+	// The days left before the task is due. Negative when it is overdue,
+	// null without an interval in days.
+	DaysRemaining *int `json:"days_remaining"`
+
+	// Whether either interval was reached
+	Due bool `json:"due"`
+
+	// The creation date of the maintenance task
+	CreatedAt *time.Time `json:"created_at"`
+
+	// The last update date of the maintenance task
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// SQLToMaintenanceTask converts a *sql.MaintenanceTask object to a
+// *MaintenanceTask object and computes, as of now, how far it is from being
+// due. If the input is nil, it returns nil.
+func SQLToMaintenanceTask(task *sql.MaintenanceTask, now time.Time) *MaintenanceTask {
+	if task == nil {
+		return nil
+	}
+
+	m := new(MaintenanceTask)
+	m.Id = task.Id
+	m.Type = TaskType(task.Type)
+	m.Equipment = Equipment(task.Equipment)
+	m.EquipmentName = task.EquipmentName
+	m.IntervalShots = task.IntervalShots
+	m.IntervalDays = task.IntervalDays
+	m.LastDoneAt = task.LastDoneAt
+	m.CreatedAt = task.CreatedAt
+	m.UpdatedAt = task.UpdatedAt
+
+	m.ShotsSinceDone = task.ShotsSinceDone
+	since := task.LastDoneAt
+	if since == nil {
+		since = task.CreatedAt
+	}
+	if since != nil && now.After(*since) {
+		m.DaysSinceDone = int(now.Sub(*since).Hours() / 24)
+	}
+	if task.IntervalShots != nil {
+		remaining := *task.IntervalShots - m.ShotsSinceDone
+		m.ShotsRemaining = &remaining
+		m.Due = m.Due || remaining <= 0
+	}
+	if task.IntervalDays != nil {
+		remaining := *task.IntervalDays - m.DaysSinceDone
+		m.DaysRemaining = &remaining
+		m.Due = m.Due || remaining <= 0
+	}
+
+	return m
+}
+
+// MaintenanceTaskToSQL converts a MaintenanceTask object to its SQL
+// representation. If the input is nil, it returns nil.
+func MaintenanceTaskToSQL(task *MaintenanceTask) *sql.MaintenanceTask {
+	if task == nil {
+		return nil
+	}
+
+	m := new(sql.MaintenanceTask)
+	m.Id = task.Id
+	m.Type = string(task.Type)
+	m.Equipment = string(task.Equipment)
+	m.EquipmentName = task.EquipmentName
+	m.IntervalShots = task.IntervalShots
+	m.IntervalDays = task.IntervalDays
+	m.LastDoneAt = task.LastDoneAt
+	m.CreatedAt = task.CreatedAt
+	m.UpdatedAt = task.UpdatedAt
+
+	return m
+}
+
+type Service interface {
+	CreateMaintenanceTask(ctx context.Context, task *MaintenanceTask) (*MaintenanceTask, error)
+	GetMaintenanceTaskById(ctx context.Context, id int) (*MaintenanceTask, error)
+	GetAllMaintenanceTasks(ctx context.Context) ([]MaintenanceTask, error)
+	GetDueMaintenanceTasks(ctx context.Context) ([]MaintenanceTask, error)
+	UpdateMaintenanceTaskById(ctx context.Context, id int, task *MaintenanceTask) (*MaintenanceTask, error)
+	CompleteMaintenanceTaskById(ctx context.Context, id int) (*MaintenanceTask, error)
+	DeleteMaintenanceTaskById(ctx context.Context, id int) error
+	Ping(ctx context.Context) error
+}
+
+type MaintenanceService struct {
+	repository repository.MaintenanceTaskRepository
+}
+
+var _ Service = (*MaintenanceService)(nil)
+
+// now is swapped out in tests to pin the current time.
+var now = time.Now
+
+func New(repo repository.MaintenanceTaskRepository) *MaintenanceService {
+	return &MaintenanceService{repository: repo}
+}
+
+func (s *MaintenanceService) CreateMaintenanceTask(ctx context.Context, task *MaintenanceTask) (*MaintenanceTask, error) {
+	if err := validateMaintenanceTask(task); err != nil {
+		msg := "could not create maintenance task"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	id, err := s.repository.CreateMaintenanceTask(ctx, MaintenanceTaskToSQL(task))
+	if err != nil {
+		msg := "could not create maintenance task"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	created, err := s.GetMaintenanceTaskById(ctx, id)
+	if err != nil {
+		msg := "could not get newly created maintenance task"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return created, nil
+}
+
+func (s *MaintenanceService) GetMaintenanceTaskById(ctx context.Context, id int) (*MaintenanceTask, error) {
+	task, err := s.repository.GetMaintenanceTaskById(ctx, id)
+	if err != nil {
+		msg := "could not get maintenance task by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToMaintenanceTask(task, now()), nil
+}
+
+func (s *MaintenanceService) GetAllMaintenanceTasks(ctx context.Context) ([]MaintenanceTask, error) {
+	sqlTasks, err := s.repository.GetAllMaintenanceTasks(ctx)
+	if err != nil {
+		msg := "could not get all maintenance tasks"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	t := now()
+	tasks := make([]MaintenanceTask, len(sqlTasks))
+	for i, v := range sqlTasks {
+		tasks[i] = *SQLToMaintenanceTask(&v, t)
+	}
+
+	return tasks, nil
+}
+
+// GetDueMaintenanceTasks returns the maintenance tasks whose interval in
+// shots or days was reached.
+func (s *MaintenanceService) GetDueMaintenanceTasks(ctx context.Context) ([]MaintenanceTask, error) {
+	tasks, err := s.GetAllMaintenanceTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	due := make([]MaintenanceTask, 0)
+	for _, task := range tasks {
+		if task.Due {
+			due = append(due, task)
+		}
+	}
+
+	return due, nil
+}
+
+func (s *MaintenanceService) UpdateMaintenanceTaskById(ctx context.Context, id int, task *MaintenanceTask) (*MaintenanceTask, error) {
+	if err := validateMaintenanceTask(task); err != nil {
+		msg := "could not update maintenance task by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	task.Id = id
+	updated, err := s.repository.UpdateMaintenanceTaskById(ctx, id, MaintenanceTaskToSQL(task))
+	if err != nil {
+		msg := "could not update maintenance task by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToMaintenanceTask(updated, now()), nil
+}
+
+// CompleteMaintenanceTaskById records the maintenance task as done now,
+// which restarts both its intervals.
+func (s *MaintenanceService) CompleteMaintenanceTaskById(ctx context.Context, id int) (*MaintenanceTask, error) {
+	t := now()
+	completed, err := s.repository.CompleteMaintenanceTaskById(ctx, id, t.UTC())
+	if err != nil {
+		msg := "could not complete maintenance task by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToMaintenanceTask(completed, t), nil
+}
+
+func (s *MaintenanceService) DeleteMaintenanceTaskById(ctx context.Context, id int) error {
+	if err := s.repository.DeleteMaintenanceTaskById(ctx, id); err != nil {
+		msg := "could not delete maintenance task by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+func (s *MaintenanceService) Ping(ctx context.Context) error {
+	if err := s.repository.Ping(ctx); err != nil {
+		msg := "could not ping database"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// validateMaintenanceTask rejects a nil task, an unknown type or equipment,
+// a task without a positive interval in shots or days, and a last done date
+// in the future.
+func validateMaintenanceTask(task *MaintenanceTask) error {
+	if task == nil {
+		return errors.ErrMaintenanceTaskIsNil
+	}
+	task.Type = TaskType(strings.ToLower(strings.TrimSpace(string(task.Type))))
+	task.Equipment = Equipment(strings.ToLower(strings.TrimSpace(string(task.Equipment))))
+	task.EquipmentName = strings.TrimSpace(task.EquipmentName)
+	if !task.Type.IsValid() {
+		return errors.ErrMaintenanceTaskTypeIsInvalid
+	}
+	if !task.Equipment.IsValid() {
+		return errors.ErrMaintenanceTaskEquipmentIsInvalid
+	}
+	if task.IntervalShots == nil && task.IntervalDays == nil {
+		return errors.ErrMaintenanceTaskIntervalOutOfRange
+	}
+	if (task.IntervalShots != nil && *task.IntervalShots <= 0) || (task.IntervalDays != nil && *task.IntervalDays <= 0) {
+		return errors.ErrMaintenanceTaskIntervalOutOfRange
+	}
+	if task.LastDoneAt != nil && task.LastDoneAt.After(now()) {
+		return errors.ErrMaintenanceTaskLastDoneIsInFuture
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package maintenance
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type IsErrorCtxKey string
+
+type MockMaintenanceTaskRepository struct {
+	tasks  map[int]sql.MaintenanceTask
+	doneAt time.Time
+}
+
+func (m *MockMaintenanceTaskRepository) CreateMaintenanceTask(ctx context.Context, task *sql.MaintenanceTask) (int, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return 0, fmt.Errorf("mock error")
+	}
+	task.Id = len(m.tasks) + 1
+	task.CreatedAt = &testNow
+	m.tasks[task.Id] = *task
+	return task.Id, nil
+}
+
+func (m *MockMaintenanceTaskRepository) GetMaintenanceTaskById(ctx context.Context, id int) (*sql.MaintenanceTask, error) {
+	task, ok := m.tasks[id]
+	if !ok {
+		return nil, errors.ErrMaintenanceTaskDoesNotExist
+	}
+	return &task, nil
+}
+
+func (m *MockMaintenanceTaskRepository) GetAllMaintenanceTasks(ctx context.Context) ([]sql.MaintenanceTask, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return nil, fmt.Errorf("mock error")
+	}
+	tasks := make([]sql.MaintenanceTask, 0, len(m.tasks))
+	for id := 1; id <= len(m.tasks); id++ {
+		tasks = append(tasks, m.tasks[id])
+	}
+	return tasks, nil
+}
+
+func (m *MockMaintenanceTaskRepository) UpdateMaintenanceTaskById(ctx context.Context, id int, task *sql.MaintenanceTask) (*sql.MaintenanceTask, error) {
+	if _, ok := m.tasks[id]; !ok {
+		return nil, errors.ErrMaintenanceTaskDoesNotExist
+	}
+	m.tasks[id] = *task
+	return task, nil
+}
+
+func (m *MockMaintenanceTaskRepository) CompleteMaintenanceTaskById(ctx context.Context, id int, doneAt time.Time) (*sql.MaintenanceTask, error) {
+	task, ok := m.tasks[id]
+	if !ok {
+		return nil, errors.ErrMaintenanceTaskDoesNotExist
+	}
+	m.doneAt = doneAt
+	task.LastDoneAt = &doneAt
+	task.ShotsSinceDone = 0
+	m.tasks[id] = task
+	return &task, nil
+}
+
+func (m *MockMaintenanceTaskRepository) DeleteMaintenanceTaskById(ctx context.Context, id int) error {
+	if _, ok := m.tasks[id]; !ok {
+		return errors.ErrMaintenanceTaskDoesNotExist
+	}
+	delete(m.tasks, id)
+	return nil
+}
+
+func (m *MockMaintenanceTaskRepository) Ping(ctx context.Context) error {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return fmt.Errorf("mock error")
+	}
+	return nil
+}
+
+var testNow = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+func pinNow(t *testing.T) {
+	t.Helper()
+	now = func() time.Time { return testNow }
+	t.Cleanup(func() { now = time.Now })
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestSQLToMaintenanceTask(t *testing.T) {
+	lastDone := testNow.AddDate(0, 0, -30).Add(-time.Hour)
+	created := testNow.AddDate(0, 0, -100)
+	tests := []struct {
+		name               string
+		task               *sql.MaintenanceTask
+		wantDays           int
+		wantShotsRemaining *int
+		wantDaysRemaining  *int
+		wantDue            bool
+	}{
+		{name: "Nil", task: nil},
+		{
+			name:               "Shots interval not reached",
+			task:               &sql.MaintenanceTask{Type: "backflush", IntervalShots: intPtr(200), LastDoneAt: &lastDone, ShotsSinceDone: 150},
+			wantDays:           30,
+			wantShotsRemaining: intPtr(50),
+		},
+		{
+			name:               "Shots interval overdue",
+			task:               &sql.MaintenanceTask{Type: "backflush", IntervalShots: intPtr(200), LastDoneAt: &lastDone, ShotsSinceDone: 214},
+			wantDays:           30,
+			wantShotsRemaining: intPtr(-14),
+			wantDue:            true,
+		},
+		{
+			name:               "Days interval reached first",
+			task:               &sql.MaintenanceTask{Type: "descale", IntervalShots: intPtr(1000), IntervalDays: intPtr(30), LastDoneAt: &lastDone, ShotsSinceDone: 10},
+			wantDays:           30,
+			wantShotsRemaining: intPtr(990),
+			wantDaysRemaining:  intPtr(0),
+			wantDue:            true,
+		},
+		{
+			name:              "Never done counts from creation",
+			task:              &sql.MaintenanceTask{Type: "water_filter", IntervalDays: intPtr(90), CreatedAt: &created},
+			wantDays:          100,
+			wantDaysRemaining: intPtr(-10),
+			wantDue:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SQLToMaintenanceTask(tt.task, testNow)
+			if tt.task == nil {
+				if got != nil {
+					t.Errorf("SQLToMaintenanceTask() = %+v, want nil", got)
+				}
+				return
+			}
+			if got.DaysSinceDone != tt.wantDays || got.Due != tt.wantDue {
+				t.Errorf("SQLToMaintenanceTask() days = %d, due = %v, want %d and %v", got.DaysSinceDone, got.Due, tt.wantDays, tt.wantDue)
+			}
+			if fmt.Sprint(deref(got.ShotsRemaining)) != fmt.Sprint(deref(tt.wantShotsRemaining)) || fmt.Sprint(deref(got.DaysRemaining)) != fmt.Sprint(deref(tt.wantDaysRemaining)) {
+				t.Errorf("SQLToMaintenanceTask() remaining = %v shots, %v days, want %v and %v", deref(got.ShotsRemaining), deref(got.DaysRemaining), deref(tt.wantShotsRemaining), deref(tt.wantDaysRemaining))
+			}
+		})
+	}
+}
+
+func deref(v *int) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+func TestMaintenanceServiceCreateMaintenanceTask(t *testing.T) {
+	pinNow(t)
+	future := testNow.Add(time.Hour)
+	tests := []struct {
+		name    string
+		task    *MaintenanceTask
+		wantErr error
+	}{
+		{name: "Valid", task: &MaintenanceTask{Type: " Backflush ", Equipment: "Machine", EquipmentName: " Linea Mini ", IntervalShots: intPtr(200)}},
+		{name: "Nil", task: nil, wantErr: errors.ErrMaintenanceTaskIsNil},
+		{name: "Unknown type", task: &MaintenanceTask{Type: "polish", IntervalDays: intPtr(7)}, wantErr: errors.ErrMaintenanceTaskTypeIsInvalid},
+		{name: "Unknown equipment", task: &MaintenanceTask{Type: "descale", Equipment: "kettle", IntervalDays: intPtr(7)}, wantErr: errors.ErrMaintenanceTaskEquipmentIsInvalid},
+		{name: "No interval", task: &MaintenanceTask{Type: "descale"}, wantErr: errors.ErrMaintenanceTaskIntervalOutOfRange},
+		{name: "Zero interval", task: &MaintenanceTask{Type: "descale", IntervalShots: intPtr(100), IntervalDays: intPtr(0)}, wantErr: errors.ErrMaintenanceTaskIntervalOutOfRange},
+		{name: "Done in the future", task: &MaintenanceTask{Type: "descale", IntervalDays: intPtr(60), LastDoneAt: &future}, wantErr: errors.ErrMaintenanceTaskLastDoneIsInFuture},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&MockMaintenanceTaskRepository{tasks: map[int]sql.MaintenanceTask{}})
+			got, err := s.CreateMaintenanceTask(context.Background(), tt.task)
+			if tt.wantErr != nil {
+				if !stderrors.Is(err, tt.wantErr) {
+					t.Fatalf("MaintenanceService.CreateMaintenanceTask() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MaintenanceService.CreateMaintenanceTask() error = %v", err)
+			}
+			if got.Id != 1 || got.Type != TaskTypeBackflush || got.Equipment != EquipmentMachine || got.EquipmentName != "Linea Mini" || *got.ShotsRemaining != 200 || got.Due {
+				t.Errorf("MaintenanceService.CreateMaintenanceTask() = %+v, want a normalised backflush not yet due", got)
+			}
+		})
+	}
+}
+
+func TestMaintenanceServiceGetDueMaintenanceTasks(t *testing.T) {
+	pinNow(t)
+	lastDone := testNow.AddDate(0, 0, -10)
+	repo := &MockMaintenanceTaskRepository{tasks: map[int]sql.MaintenanceTask{
+		1: {Id: 1, Type: "backflush", IntervalShots: intPtr(200), LastDoneAt: &lastDone, ShotsSinceDone: 250},
+		2: {Id: 2, Type: "descale", IntervalDays: intPtr(60), LastDoneAt: &lastDone},
+		3: {Id: 3, Type: "gasket_change", IntervalDays: intPtr(10), LastDoneAt: &lastDone},
+	}}
+
+	got, err := New(repo).GetDueMaintenanceTasks(context.Background())
+	if err != nil {
+		t.Fatalf("MaintenanceService.GetDueMaintenanceTasks() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Id != 1 || got[1].Id != 3 {
+		t.Errorf("MaintenanceService.GetDueMaintenanceTasks() = %+v, want tasks 1 and 3", got)
+	}
+
+	if _, err := New(repo).GetDueMaintenanceTasks(context.WithValue(context.Background(), IsErrorCtxKey("isError"), true)); err == nil {
+		t.Error("MaintenanceService.GetDueMaintenanceTasks() error = nil, want an error")
+	}
+}
+
+func TestMaintenanceServiceCompleteMaintenanceTaskById(t *testing.T) {
+	pinNow(t)
+	repo := &MockMaintenanceTaskRepository{tasks: map[int]sql.MaintenanceTask{
+		1: {Id: 1, Type: "backflush", IntervalShots: intPtr(200), CreatedAt: &testNow, ShotsSinceDone: 250},
+	}}
+	s := New(repo)
+
+	got, err := s.CompleteMaintenanceTaskById(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("MaintenanceService.CompleteMaintenanceTaskById() error = %v", err)
+	}
+	if !repo.doneAt.Equal(testNow) || got.Due || *got.ShotsRemaining != 200 {
+		t.Errorf("MaintenanceService.CompleteMaintenanceTaskById() = %+v, want done now and no longer due", got)
+	}
+
+	if _, err := s.CompleteMaintenanceTaskById(context.Background(), 9); !stderrors.Is(err, errors.ErrMaintenanceTaskDoesNotExist) {
+		t.Errorf("MaintenanceService.CompleteMaintenanceTaskById() error = %v, want %v", err, errors.ErrMaintenanceTaskDoesNotExist)
+	}
+}
+
+func TestMaintenanceServiceUpdateAndDelete(t *testing.T) {
+	pinNow(t)
+	repo := &MockMaintenanceTaskRepository{tasks: map[int]sql.MaintenanceTask{
+		1: {Id: 1, Type: "backflush", IntervalShots: intPtr(200)},
+	}}
+	s := New(repo)
+
+	got, err := s.UpdateMaintenanceTaskById(context.Background(), 1, &MaintenanceTask{Type: "backflush", IntervalShots: intPtr(150)})
+	if err != nil {
+		t.Fatalf("MaintenanceService.UpdateMaintenanceTaskById() error = %v", err)
+	}
+	if got.Id != 1 || *got.IntervalShots != 150 {
+		t.Errorf("MaintenanceService.UpdateMaintenanceTaskById() = %+v, want an interval of 150 shots", got)
+	}
+	if _, err := s.UpdateMaintenanceTaskById(context.Background(), 1, &MaintenanceTask{Type: "backflush"}); !stderrors.Is(err, errors.ErrMaintenanceTaskIntervalOutOfRange) {
+		t.Errorf("MaintenanceService.UpdateMaintenanceTaskById() error = %v, want %v", err, errors.ErrMaintenanceTaskIntervalOutOfRange)
+	}
+
+	if err := s.DeleteMaintenanceTaskById(context.Background(), 1); err != nil {
+		t.Errorf("MaintenanceService.DeleteMaintenanceTaskById() error = %v", err)
+	}
+	if err := s.DeleteMaintenanceTaskById(context.Background(), 1); !stderrors.Is(err, errors.ErrMaintenanceTaskDoesNotExist) {
+		t.Errorf("MaintenanceService.DeleteMaintenanceTaskById() error = %v, want %v", err, errors.ErrMaintenanceTaskDoesNotExist)
+	}
+}
+
+func TestTaskTypeLabel(t *testing.T) {
+	if got := TaskTypeBurrChange.Label(); got != "Burr change" {
+		t.Errorf("TaskType.Label() = %q, want %q", got, "Burr change")
+	}
+}
+
+func TestMaintenanceServicePing(t *testing.T) {
+	s := New(&MockMaintenanceTaskRepository{})
+	if err := s.Ping(context.Background()); err != nil {
+		t.Errorf("MaintenanceService.Ping() error = %v", err)
+	}
+	if err := s.Ping(context.WithValue(context.Background(), IsErrorCtxKey("isError"), true)); err == nil {
+		t.Error("MaintenanceService.Ping() error = nil, want an error")
+	}
+}
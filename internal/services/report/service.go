@@ -0,0 +1,226 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/rs/zerolog"
+)
+
+// GroupBy is how the shots of a spend report are grouped.
+type GroupBy string
+
+const (
+	GroupByMonth   GroupBy = "month"
+	GroupByRoaster GroupBy = "roaster"
+	GroupByBeans   GroupBy = "beans"
+)
+
+// IsValid reports whether g is a supported grouping.
+func (g GroupBy) IsValid() bool {
+	return g == GroupByMonth || g == GroupByRoaster || g == GroupByBeans
+}
+
+// SpendReport
+//
+// A spend report sums the cost of the shots pulled over a range of days,
+// grouped by month, roaster or beans. Amounts in different currencies are
+// never added together: a group and a total are per currency. Shots from
+// beans without a price are left out.
+//
+// swagger:model
+type SpendReport struct {
+	// The first day of the report, if bounded
+	From *time.Time `json:"from"`
+
+	// The last day of the report, inclusive, if bounded
+	To *time.Time `json:"to"`
+
+	// How the shots are grouped
+	GroupBy GroupBy `json:"group_by"`
+
+	// The spend of each group
+	Groups []SpendGroup `json:"groups"`
+
+	// The spend over the whole range, per currency
+	Totals []SpendTotal `json:"totals"`
+}
+
+// SpendGroup is the spend of a month, a roaster or beans in one currency.
+//
+// swagger:model
+type SpendGroup struct {
+	// The month as YYYY-MM, or the id of the roaster or beans
+	Key string `json:"key"`
+
+	// A human label for the group
+	Label string `json:"label"`
+
+	SpendTotal
+}
+
+// SpendTotal is the spend in one currency.
+//
+// swagger:model
+type SpendTotal struct {
+	// The ISO 4217 currency of the spend. Empty when the beans have none.
+	Currency string `json:"currency"`
+
+	// The number of shots pulled
+	Shots int `json:"shots"`
+
+	// The weight of coffee used, in grams
+	CoffeeWeight float64 `json:"coffee_weight"`
+
+	// The cost of the shots pulled
+	Spend float64 `json:"spend"`
+}
+
+func (t *SpendTotal) add(quantityIn, cost float64) {
+	t.Shots++
+	t.CoffeeWeight += quantityIn
+	t.Spend += cost
+}
+
+func (t *SpendTotal) round() {
+	t.CoffeeWeight = math.Round(t.CoffeeWeight*10) / 10
+	t.Spend = math.Round(t.Spend*100) / 100
+}
+
+type Service interface {
+	GetSpendReport(ctx context.Context, from, to *time.Time, groupBy GroupBy) (*SpendReport, error)
+	Ping(ctx context.Context) error
+}
+
+type ReportService struct {
+	repository repository.ReportRepository
+}
+
+var _ Service = (*ReportService)(nil)
+
+func New(repo repository.ReportRepository) *ReportService {
+	return &ReportService{repository: repo}
+}
+
+// GetSpendReport reports the spend between the from and to days, both
+// included. Either bound may be nil, and groupBy defaults to month. Each shot
+// is costed as on its own, so the report adds up to the shots' cost_per_shot.
+func (r *ReportService) GetSpendReport(ctx context.Context, from, to *time.Time, groupBy GroupBy) (*SpendReport, error) {
+	if groupBy == "" {
+		groupBy = GroupByMonth
+	}
+	if !groupBy.IsValid() {
+		err := errors.ErrSpendReportGroupByIsInvalid
+		msg := "could not get spend report"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+	if from != nil && to != nil && from.After(*to) {
+		err := errors.ErrSpendReportRangeIsInvalid
+		msg := "could not get spend report"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	var until *time.Time
+	if to != nil {
+		t := to.AddDate(0, 0, 1)
+		until = &t
+	}
+
+	costs, err := r.repository.GetShotCosts(ctx, from, until)
+	if err != nil {
+		msg := "could not get shot costs"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	type groupKey struct{ key, currency string }
+	groups := make(map[groupKey]*SpendGroup)
+	totals := make(map[string]*SpendTotal)
+	for _, c := range costs {
+		cost, ok := shot.CostPerShot(c.Price, c.BagWeight, c.QuantityIn)
+		if !ok || c.CreatedAt == nil {
+			continue
+		}
+
+		var key, label string
+		switch groupBy {
+		case GroupByMonth:
+			month := c.CreatedAt.UTC()
+			key, label = month.Format("2006-01"), month.Format("January 2006")
+		case GroupByRoaster:
+			key, label = strconv.Itoa(c.RoasterId), c.RoasterName
+		case GroupByBeans:
+			key, label = strconv.Itoa(c.BeansId), c.BeansName+" ("+c.RoasterName+")"
+		}
+
+		g, ok := groups[groupKey{key, c.Currency}]
+		if !ok {
+			g = &SpendGroup{Key: key, Label: label, SpendTotal: SpendTotal{Currency: c.Currency}}
+			groups[groupKey{key, c.Currency}] = g
+		}
+		g.add(c.QuantityIn, cost)
+
+		t, ok := totals[c.Currency]
+		if !ok {
+			t = &SpendTotal{Currency: c.Currency}
+			totals[c.Currency] = t
+		}
+		t.add(c.QuantityIn, cost)
+	}
+
+	report := &SpendReport{
+		From:    from,
+		To:      to,
+		GroupBy: groupBy,
+		Groups:  make([]SpendGroup, 0, len(groups)),
+		Totals:  make([]SpendTotal, 0, len(totals)),
+	}
+	for _, g := range groups {
+		g.round()
+		report.Groups = append(report.Groups, *g)
+	}
+	for _, t := range totals {
+		t.round()
+		report.Totals = append(report.Totals, *t)
+	}
+
+	// Months read chronologically; roasters and beans from the biggest spend.
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if groupBy != GroupByMonth {
+			if a.Spend != b.Spend {
+				return a.Spend > b.Spend
+			}
+			if a.Label != b.Label {
+				return a.Label < b.Label
+			}
+		}
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		return a.Currency < b.Currency
+	})
+	sort.Slice(report.Totals, func(i, j int) bool {
+		return report.Totals[i].Currency < report.Totals[j].Currency
+	})
+
+	return report, nil
+}
+
+func (r *ReportService) Ping(ctx context.Context) error {
+	if err := r.repository.Ping(ctx); err != nil {
+		msg := "could not ping database"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
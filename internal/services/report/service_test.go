@@ -0,0 +1,156 @@
+package report
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type IsErrorCtxKey string
+
+type MockReportRepository struct {
+	costs    []sql.ShotCost
+	from, to *time.Time
+}
+
+func (m *MockReportRepository) GetShotCosts(ctx context.Context, from, to *time.Time) ([]sql.ShotCost, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return nil, fmt.Errorf("mock error")
+	}
+	m.from, m.to = from, to
+	return m.costs, nil
+}
+
+func (m *MockReportRepository) Ping(ctx context.Context) error {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return fmt.Errorf("mock error")
+	}
+	return nil
+}
+
+func date(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func testShotCosts() []sql.ShotCost {
+	return []sql.ShotCost{
+		{ShotId: 1, QuantityIn: 18, CreatedAt: date(2026, time.August, 30), BeansId: 1, BeansName: "Guji", RoasterId: 1, RoasterName: "Alpha", Price: 15, Currency: "EUR", BagWeight: 250},
+		{ShotId: 2, QuantityIn: 18, CreatedAt: date(2026, time.September, 2), BeansId: 1, BeansName: "Guji", RoasterId: 1, RoasterName: "Alpha", Price: 15, Currency: "EUR", BagWeight: 250},
+		{ShotId: 3, QuantityIn: 20, CreatedAt: date(2026, time.September, 3), BeansId: 2, BeansName: "Huila", RoasterId: 2, RoasterName: "Beta", Price: 40, Currency: "EUR", BagWeight: 1000},
+		{ShotId: 4, QuantityIn: 18, CreatedAt: date(2026, time.September, 4), BeansId: 3, BeansName: "Kenya AA", RoasterId: 2, RoasterName: "Beta", Price: 20, Currency: "USD", BagWeight: 340},
+	}
+}
+
+func TestReportServiceGetSpendReport(t *testing.T) {
+	tests := []struct {
+		name       string
+		groupBy    GroupBy
+		wantGroups []SpendGroup
+	}{
+		{
+			name:    "By month, chronologically",
+			groupBy: "",
+			wantGroups: []SpendGroup{
+				{Key: "2026-08", Label: "August 2026", SpendTotal: SpendTotal{Currency: "EUR", Shots: 1, CoffeeWeight: 18, Spend: 1.08}},
+				{Key: "2026-09", Label: "September 2026", SpendTotal: SpendTotal{Currency: "EUR", Shots: 2, CoffeeWeight: 38, Spend: 1.88}},
+				{Key: "2026-09", Label: "September 2026", SpendTotal: SpendTotal{Currency: "USD", Shots: 1, CoffeeWeight: 18, Spend: 1.06}},
+			},
+		},
+		{
+			name:    "By roaster, biggest spend first",
+			groupBy: GroupByRoaster,
+			wantGroups: []SpendGroup{
+				{Key: "1", Label: "Alpha", SpendTotal: SpendTotal{Currency: "EUR", Shots: 2, CoffeeWeight: 36, Spend: 2.16}},
+				{Key: "2", Label: "Beta", SpendTotal: SpendTotal{Currency: "USD", Shots: 1, CoffeeWeight: 18, Spend: 1.06}},
+				{Key: "2", Label: "Beta", SpendTotal: SpendTotal{Currency: "EUR", Shots: 1, CoffeeWeight: 20, Spend: 0.8}},
+			},
+		},
+		{
+			name:    "By beans",
+			groupBy: GroupByBeans,
+			wantGroups: []SpendGroup{
+				{Key: "1", Label: "Guji (Alpha)", SpendTotal: SpendTotal{Currency: "EUR", Shots: 2, CoffeeWeight: 36, Spend: 2.16}},
+				{Key: "3", Label: "Kenya AA (Beta)", SpendTotal: SpendTotal{Currency: "USD", Shots: 1, CoffeeWeight: 18, Spend: 1.06}},
+				{Key: "2", Label: "Huila (Beta)", SpendTotal: SpendTotal{Currency: "EUR", Shots: 1, CoffeeWeight: 20, Spend: 0.8}},
+			},
+		},
+	}
+	wantTotals := []SpendTotal{
+		{Currency: "EUR", Shots: 3, CoffeeWeight: 56, Spend: 2.96},
+		{Currency: "USD", Shots: 1, CoffeeWeight: 18, Spend: 1.06},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(&MockReportRepository{costs: testShotCosts()})
+			got, err := r.GetSpendReport(context.Background(), nil, nil, tt.groupBy)
+			if err != nil {
+				t.Fatalf("ReportService.GetSpendReport() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Groups, tt.wantGroups) {
+				t.Errorf("ReportService.GetSpendReport() groups = %+v, want %+v", got.Groups, tt.wantGroups)
+			}
+			if !reflect.DeepEqual(got.Totals, wantTotals) {
+				t.Errorf("ReportService.GetSpendReport() totals = %+v, want %+v", got.Totals, wantTotals)
+			}
+		})
+	}
+}
+
+func TestReportServiceGetSpendReportRange(t *testing.T) {
+	repo := &MockReportRepository{}
+	from, to := date(2026, time.September, 1), date(2026, time.September, 30)
+
+	got, err := New(repo).GetSpendReport(context.Background(), from, to, GroupByMonth)
+	if err != nil {
+		t.Fatalf("ReportService.GetSpendReport() error = %v", err)
+	}
+	if !repo.from.Equal(*from) || !repo.to.Equal(*date(2026, time.October, 1)) {
+		t.Errorf("GetShotCosts() range = [%v, %v), want the to day included", repo.from, repo.to)
+	}
+	if got.From != from || got.To != to || len(got.Groups) != 0 || len(got.Totals) != 0 {
+		t.Errorf("ReportService.GetSpendReport() = %+v, want an empty report over the requested days", got)
+	}
+}
+
+func TestReportServiceGetSpendReportErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		from    *time.Time
+		to      *time.Time
+		groupBy GroupBy
+		wantErr error
+	}{
+		{name: "Invalid group by", ctx: context.Background(), groupBy: "week", wantErr: errors.ErrSpendReportGroupByIsInvalid},
+		{name: "From after to", ctx: context.Background(), from: date(2026, time.October, 2), to: date(2026, time.October, 1), wantErr: errors.ErrSpendReportRangeIsInvalid},
+		{name: "Repository error", ctx: context.WithValue(context.Background(), IsErrorCtxKey("isError"), true)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(&MockReportRepository{}).GetSpendReport(tt.ctx, tt.from, tt.to, tt.groupBy)
+			if err == nil {
+				t.Fatal("ReportService.GetSpendReport() error = nil, want an error")
+			}
+			if tt.wantErr != nil && !stderrors.Is(err, tt.wantErr) {
+				t.Errorf("ReportService.GetSpendReport() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReportServicePing(t *testing.T) {
+	r := New(&MockReportRepository{})
+	if err := r.Ping(context.Background()); err != nil {
+		t.Errorf("ReportService.Ping() error = %v", err)
+	}
+	if err := r.Ping(context.WithValue(context.Background(), IsErrorCtxKey("isError"), true)); err == nil {
+		t.Error("ReportService.Ping() error = nil, want an error")
+	}
+}
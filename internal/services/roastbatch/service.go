@@ -0,0 +1,334 @@
+package roastbatch
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// SelfRoasterName is the name of the roaster given to beans generated from a
+// home roast batch. It is created on first use.
+const SelfRoasterName = "self"
+
+// RoastBatch
+//
+// A roast batch is a home roast: how much green coffee went in, how much
+// roasted coffee came out, the key temperatures and times of the roast and,
+// optionally, its time/temperature curve. Beans can be generated from a
+// batch once it is roasted.
+//
+// swagger:model
+type RoastBatch struct {
+	// The id for the roast batch
+	Id int `json:"id"`
+
+	// The name of the green coffee that was roasted
+	GreenCoffee string `json:"green_coffee"`
+
+	// The date of the roast
+	RoastDate *time.Time `json:"roast_date"`
+
+	// The roast level of the batch
+	RoastLevel sql.RoastLevel `json:"roast_level"`
+
+	// The weight of green coffee charged, in grams
+	GreenWeight float64 `json:"green_weight"`
+
+	// The weight of roasted coffee, in grams
+	RoastedWeight float64 `json:"roasted_weight"`
+
+	// The weight lost during the roast, in percent of the green weight
+	WeightLoss float64 `json:"weight_loss"`
+
+	// The charge temperature, in degrees
+	ChargeTemperature float64 `json:"charge_temperature"`
+
+	// The time of first crack, in seconds since charge
+	FirstCrackTime int `json:"first_crack_time"`
+
+	// The development time after first crack, in seconds
+	DevelopmentTime int `json:"development_time"`
+
+	// The end temperature, in degrees
+	EndTemperature float64 `json:"end_temperature"`
+
+	// The time/temperature curve of the roast, ordered by time. Only returned
+	// when fetching a single batch.
+	CurvePoints []CurvePoint `json:"curve_points,omitempty"`
+
+	// The id of the green coffee stock the batch was roasted from, if any
+	GreenCoffeeId *int `json:"green_coffee_id"`
+
+	// The id of the beans generated from the batch, if any
+	BeansId *int `json:"beans_id"`
+
+	// The creation date of the roast batch
+	CreatedAt *time.Time `json:"created_at"`
+
+	// The last update date of the roast batch
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// CurvePoint is one time/temperature reading of a roast curve.
+//
+// swagger:model
+type CurvePoint struct {
+	// The time of the reading, in seconds since charge
+	Time int `json:"time"`
+
+	// The temperature of the reading, in degrees
+	Temperature float64 `json:"temperature"`
+}
+
+// ComputeWeightLoss returns the weight lost during the roast in percent of
+// the green weight, rounded to two decimals, or 0 if the green weight is not
+// positive.
+func (b *RoastBatch) ComputeWeightLoss() float64 {
+	if b.GreenWeight <= 0 {
+		return 0
+	}
+	return math.Round((b.GreenWeight-b.RoastedWeight)/b.GreenWeight*100*100) / 100
+}
+
+// SQLToRoastBatch converts a *sql.RoastBatch object to a *RoastBatch object
+// and computes its weight loss. If the input batch is nil, it returns nil.
+func SQLToRoastBatch(batch *sql.RoastBatch) *RoastBatch {
+	if batch == nil {
+		return nil
+	}
+
+	b := new(RoastBatch)
+	b.Id = batch.Id
+	b.GreenCoffee = batch.GreenCoffee
+	b.RoastDate = batch.RoastDate
+	b.RoastLevel = batch.RoastLevel
+	b.GreenWeight = batch.GreenWeight
+	b.RoastedWeight = batch.RoastedWeight
+	b.WeightLoss = b.ComputeWeightLoss()
+	b.ChargeTemperature = batch.ChargeTemperature
+	b.FirstCrackTime = batch.FirstCrackTime
+	b.DevelopmentTime = batch.DevelopmentTime
+	b.EndTemperature = batch.EndTemperature
+	b.GreenCoffeeId = batch.GreenCoffeeId
+	b.BeansId = batch.BeansId
+	b.CreatedAt = batch.CreatedAt
+	b.UpdatedAt = batch.UpdatedAt
+
+	if batch.CurvePoints != nil {
+		b.CurvePoints = make([]CurvePoint, len(batch.CurvePoints))
+		for i, p := range batch.CurvePoints {
+			b.CurvePoints[i] = CurvePoint{Time: p.ElapsedTime, Temperature: p.Temperature}
+		}
+	}
+
+	return b
+}
+
+// RoastBatchToSQL converts a RoastBatch object to its SQL representation.
+// If the input batch is nil, it returns nil.
+func RoastBatchToSQL(batch *RoastBatch) *sql.RoastBatch {
+	if batch == nil {
+		return nil
+	}
+
+	b := new(sql.RoastBatch)
+	b.Id = batch.Id
+	b.GreenCoffee = batch.GreenCoffee
+	b.RoastDate = batch.RoastDate
+	b.RoastLevel = batch.RoastLevel
+	b.GreenWeight = batch.GreenWeight
+	b.RoastedWeight = batch.RoastedWeight
+	b.ChargeTemperature = batch.ChargeTemperature
+	b.FirstCrackTime = batch.FirstCrackTime
+	b.DevelopmentTime = batch.DevelopmentTime
+	b.EndTemperature = batch.EndTemperature
+	b.GreenCoffeeId = batch.GreenCoffeeId
+	b.BeansId = batch.BeansId
+	b.CreatedAt = batch.CreatedAt
+	b.UpdatedAt = batch.UpdatedAt
+
+	b.CurvePoints = make([]sql.RoastCurvePoint, len(batch.CurvePoints))
+	for i, p := range batch.CurvePoints {
+		b.CurvePoints[i] = sql.RoastCurvePoint{RoastBatchId: batch.Id, ElapsedTime: p.Time, Temperature: p.Temperature}
+	}
+
+	return b
+}
+
+type Service interface {
+	CreateRoastBatch(ctx context.Context, batch *RoastBatch) (*RoastBatch, error)
+	GetRoastBatchById(ctx context.Context, id int) (*RoastBatch, error)
+	GetAllRoastBatches(ctx context.Context) ([]RoastBatch, error)
+	UpdateRoastBatchById(ctx context.Context, id int, batch *RoastBatch) (*RoastBatch, error)
+	DeleteRoastBatchById(ctx context.Context, id int) error
+	CreateBeansFromRoastBatch(ctx context.Context, id int) (*RoastBatch, error)
+	Ping(ctx context.Context) error
+}
+
+type RoastBatchService struct {
+	repository repository.RoastBatchRepository
+}
+
+var _ Service = (*RoastBatchService)(nil)
+
+func New(repo repository.RoastBatchRepository) *RoastBatchService {
+	return &RoastBatchService{repository: repo}
+}
+
+func (s *RoastBatchService) CreateRoastBatch(ctx context.Context, batch *RoastBatch) (*RoastBatch, error) {
+	if err := validateRoastBatch(batch); err != nil {
+		msg := "could not create roast batch"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	id, err := s.repository.CreateRoastBatch(ctx, RoastBatchToSQL(batch))
+	if err != nil {
+		msg := "could not create roast batch"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	created, err := s.GetRoastBatchById(ctx, id)
+	if err != nil {
+		msg := "could not get newly created roast batch"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return created, nil
+}
+
+func (s *RoastBatchService) GetRoastBatchById(ctx context.Context, id int) (*RoastBatch, error) {
+	batch, err := s.repository.GetRoastBatchById(ctx, id)
+	if err != nil {
+		msg := "could not get roast batch by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToRoastBatch(batch), nil
+}
+
+func (s *RoastBatchService) GetAllRoastBatches(ctx context.Context) ([]RoastBatch, error) {
+	sqlBatches, err := s.repository.GetAllRoastBatches(ctx)
+	if err != nil {
+		msg := "could not get all roast batches"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	batches := make([]RoastBatch, len(sqlBatches))
+	for i, v := range sqlBatches {
+		batches[i] = *SQLToRoastBatch(&v)
+	}
+
+	return batches, nil
+}
+
+func (s *RoastBatchService) UpdateRoastBatchById(ctx context.Context, id int, batch *RoastBatch) (*RoastBatch, error) {
+	if err := validateRoastBatch(batch); err != nil {
+		msg := "could not update roast batch by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	batch.Id = id
+	if _, err := s.repository.UpdateRoastBatchById(ctx, id, RoastBatchToSQL(batch)); err != nil {
+		msg := "could not update roast batch by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	updated, err := s.GetRoastBatchById(ctx, id)
+	if err != nil {
+		msg := "could not get updated roast batch"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return updated, nil
+}
+
+func (s *RoastBatchService) DeleteRoastBatchById(ctx context.Context, id int) error {
+	if err := s.repository.DeleteRoastBatchById(ctx, id); err != nil {
+		msg := "could not delete roast batch by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// CreateBeansFromRoastBatch generates beans from the batch: named after its
+// green coffee, with its roast date and level, and roasted by the
+// SelfRoasterName roaster. It returns the batch, now linked to the beans.
+func (s *RoastBatchService) CreateBeansFromRoastBatch(ctx context.Context, id int) (*RoastBatch, error) {
+	beansId, err := s.repository.CreateBeansFromRoastBatch(ctx, id, SelfRoasterName)
+	if err != nil {
+		msg := "could not create beans from roast batch"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+	zerolog.Ctx(ctx).Debug().Int("roast_batch_id", id).Int("beans_id", beansId).Msg("beans created from roast batch")
+
+	batch, err := s.GetRoastBatchById(ctx, id)
+	if err != nil {
+		msg := "could not get roast batch after creating its beans"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return batch, nil
+}
+
+func (s *RoastBatchService) Ping(ctx context.Context) error {
+	if err := s.repository.Ping(ctx); err != nil {
+		msg := "could not ping database"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// validateRoastBatch rejects a nil batch, a batch without green coffee or
+// roast date, out of range roast level, weights or times, and a curve with
+// negative or duplicate times. The curve points are sorted by time in place.
+func validateRoastBatch(batch *RoastBatch) error {
+	if batch == nil {
+		return errors.ErrRoastBatchIsNil
+	}
+	batch.GreenCoffee = strings.TrimSpace(batch.GreenCoffee)
+	if batch.GreenCoffee == "" {
+		return errors.ErrRoastBatchGreenCoffeeIsEmpty
+	}
+	if batch.RoastDate == nil {
+		return errors.ErrRoastBatchRoastDateIsEmpty
+	}
+	if !batch.RoastLevel.IsValid() {
+		return errors.ErrRoastBatchRoastLevelOutOfRange
+	}
+	if batch.GreenWeight <= 0 || batch.RoastedWeight < 0 || batch.RoastedWeight > batch.GreenWeight {
+		return errors.ErrRoastBatchWeightOutOfRange
+	}
+	if batch.FirstCrackTime < 0 || batch.DevelopmentTime < 0 {
+		return errors.ErrRoastBatchTimeOutOfRange
+	}
+
+	sort.SliceStable(batch.CurvePoints, func(i, j int) bool {
+		return batch.CurvePoints[i].Time < batch.CurvePoints[j].Time
+	})
+	for i, p := range batch.CurvePoints {
+		if p.Time < 0 || (i > 0 && p.Time == batch.CurvePoints[i-1].Time) {
+			return errors.ErrRoastBatchCurveIsInvalid
+		}
+	}
+	return nil
+}
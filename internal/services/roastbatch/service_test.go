@@ -0,0 +1,248 @@
+package roastbatch
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+)
+
+var (
+	roastDate = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+)
+
+type IsErrorCtxKey string
+
+type MockRoastBatchRepository struct {
+	created     *sql.RoastBatch
+	roasterName string
+	beansId     *int
+}
+
+func (m *MockRoastBatchRepository) CreateRoastBatch(ctx context.Context, batch *sql.RoastBatch) (int, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return 0, fmt.Errorf("mock error")
+	}
+	m.created = batch
+	return 1, nil
+}
+
+func (m *MockRoastBatchRepository) GetRoastBatchById(ctx context.Context, id int) (*sql.RoastBatch, error) {
+	if id != 1 {
+		return nil, errors.ErrRoastBatchDoesNotExist
+	}
+	batch := testSQLBatch()
+	batch.BeansId = m.beansId
+	return batch, nil
+}
+
+func (m *MockRoastBatchRepository) GetAllRoastBatches(ctx context.Context) ([]sql.RoastBatch, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return nil, fmt.Errorf("mock error")
+	}
+	batch := testSQLBatch()
+	batch.CurvePoints = nil
+	return []sql.RoastBatch{*batch}, nil
+}
+
+func (m *MockRoastBatchRepository) UpdateRoastBatchById(ctx context.Context, id int, batch *sql.RoastBatch) (*sql.RoastBatch, error) {
+	if id != 1 {
+		return nil, errors.ErrRoastBatchDoesNotExist
+	}
+	return batch, nil
+}
+
+func (m *MockRoastBatchRepository) DeleteRoastBatchById(ctx context.Context, id int) error {
+	if id != 1 {
+		return errors.ErrRoastBatchDoesNotExist
+	}
+	return nil
+}
+
+func (m *MockRoastBatchRepository) CreateBeansFromRoastBatch(ctx context.Context, id int, roasterName string) (int, error) {
+	if id != 1 {
+		return 0, errors.ErrRoastBatchDoesNotExist
+	}
+	if m.beansId != nil {
+		return 0, errors.ErrRoastBatchBeansAlreadyExist
+	}
+	beansId := 5
+	m.roasterName = roasterName
+	m.beansId = &beansId
+	return beansId, nil
+}
+
+func (m *MockRoastBatchRepository) Ping(ctx context.Context) error {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return fmt.Errorf("mock error")
+	}
+	return nil
+}
+
+func testSQLBatch() *sql.RoastBatch {
+	return &sql.RoastBatch{
+		Id: 1, GreenCoffee: "Ethiopia Guji", RoastDate: &roastDate, RoastLevel: sql.RoastLevelLight,
+		GreenWeight: 250, RoastedWeight: 215, ChargeTemperature: 200, FirstCrackTime: 480, DevelopmentTime: 75, EndTemperature: 205,
+		CurvePoints: []sql.RoastCurvePoint{{RoastBatchId: 1, ElapsedTime: 0, Temperature: 200}, {RoastBatchId: 1, ElapsedTime: 60, Temperature: 110}},
+	}
+}
+
+func testBatch() *RoastBatch {
+	return &RoastBatch{
+		GreenCoffee: "Ethiopia Guji", RoastDate: &roastDate, RoastLevel: sql.RoastLevelLight,
+		GreenWeight: 250, RoastedWeight: 215,
+		CurvePoints: []CurvePoint{{Time: 60, Temperature: 110}, {Time: 0, Temperature: 200}},
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		repo repository.RoastBatchRepository
+		want *RoastBatchService
+	}{
+		{name: "nil args", repo: nil, want: &RoastBatchService{nil}},
+		{name: "non nil args", repo: &MockRoastBatchRepository{}, want: &RoastBatchService{&MockRoastBatchRepository{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.repo); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("New() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLToRoastBatchComputesWeightLoss(t *testing.T) {
+	if got := SQLToRoastBatch(nil); got != nil {
+		t.Errorf("SQLToRoastBatch(nil) = %v, want nil", got)
+	}
+
+	got := SQLToRoastBatch(testSQLBatch())
+	if got.WeightLoss != 14 {
+		t.Errorf("WeightLoss = %v, want 14", got.WeightLoss)
+	}
+	want := []CurvePoint{{Time: 0, Temperature: 200}, {Time: 60, Temperature: 110}}
+	if !reflect.DeepEqual(got.CurvePoints, want) {
+		t.Errorf("CurvePoints = %+v, want %+v", got.CurvePoints, want)
+	}
+}
+
+func TestRoastBatchServiceCreateRoastBatch(t *testing.T) {
+	withBlankCoffee := testBatch()
+	withBlankCoffee.GreenCoffee = "  "
+	withoutDate := testBatch()
+	withoutDate.RoastDate = nil
+	darkerThanDark := testBatch()
+	darkerThanDark.RoastLevel = 5
+	gainedWeight := testBatch()
+	gainedWeight.RoastedWeight = 300
+	negativeTime := testBatch()
+	negativeTime.DevelopmentTime = -1
+	duplicateTime := testBatch()
+	duplicateTime.CurvePoints = append(duplicateTime.CurvePoints, CurvePoint{Time: 60, Temperature: 120})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		batch   *RoastBatch
+		wantErr error
+		anyErr  bool
+	}{
+		{name: "nil batch", ctx: context.Background(), batch: nil, wantErr: errors.ErrRoastBatchIsNil},
+		{name: "blank green coffee", ctx: context.Background(), batch: withBlankCoffee, wantErr: errors.ErrRoastBatchGreenCoffeeIsEmpty},
+		{name: "missing roast date", ctx: context.Background(), batch: withoutDate, wantErr: errors.ErrRoastBatchRoastDateIsEmpty},
+		{name: "roast level out of range", ctx: context.Background(), batch: darkerThanDark, wantErr: errors.ErrRoastBatchRoastLevelOutOfRange},
+		{name: "roasted heavier than green", ctx: context.Background(), batch: gainedWeight, wantErr: errors.ErrRoastBatchWeightOutOfRange},
+		{name: "negative development time", ctx: context.Background(), batch: negativeTime, wantErr: errors.ErrRoastBatchTimeOutOfRange},
+		{name: "duplicate curve time", ctx: context.Background(), batch: duplicateTime, wantErr: errors.ErrRoastBatchCurveIsInvalid},
+		{name: "repository error", ctx: context.WithValue(context.Background(), IsErrorCtxKey("isError"), true), batch: testBatch(), anyErr: true},
+		{name: "created", ctx: context.Background(), batch: testBatch()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockRoastBatchRepository{}
+			got, err := New(repo).CreateRoastBatch(tt.ctx, tt.batch)
+			if tt.wantErr != nil {
+				if !stderrors.Is(err, tt.wantErr) {
+					t.Fatalf("CreateRoastBatch() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.anyErr {
+				if err == nil {
+					t.Fatal("CreateRoastBatch() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateRoastBatch() error = %v", err)
+			}
+			if repo.created.CurvePoints[0].ElapsedTime != 0 || repo.created.CurvePoints[1].ElapsedTime != 60 {
+				t.Errorf("stored curve = %+v, want points sorted by time", repo.created.CurvePoints)
+			}
+			if got.Id != 1 || got.WeightLoss != 14 {
+				t.Errorf("CreateRoastBatch() = %+v, want id 1 with 14%% weight loss", got)
+			}
+		})
+	}
+}
+
+func TestRoastBatchServiceGetAllRoastBatches(t *testing.T) {
+	s := New(&MockRoastBatchRepository{})
+
+	got, err := s.GetAllRoastBatches(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllRoastBatches() error = %v", err)
+	}
+	if len(got) != 1 || got[0].CurvePoints != nil {
+		t.Errorf("GetAllRoastBatches() = %+v, want one batch without curve", got)
+	}
+
+	if _, err := s.GetAllRoastBatches(context.WithValue(context.Background(), IsErrorCtxKey("isError"), true)); err == nil {
+		t.Error("GetAllRoastBatches() error = nil, want error")
+	}
+}
+
+func TestRoastBatchServiceCreateBeansFromRoastBatch(t *testing.T) {
+	repo := &MockRoastBatchRepository{}
+	s := New(repo)
+
+	got, err := s.CreateBeansFromRoastBatch(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("CreateBeansFromRoastBatch() error = %v", err)
+	}
+	if repo.roasterName != SelfRoasterName {
+		t.Errorf("roaster name = %q, want %q", repo.roasterName, SelfRoasterName)
+	}
+	if got.BeansId == nil || *got.BeansId != 5 {
+		t.Errorf("BeansId = %v, want 5", got.BeansId)
+	}
+
+	if _, err := s.CreateBeansFromRoastBatch(context.Background(), 1); !stderrors.Is(err, errors.ErrRoastBatchBeansAlreadyExist) {
+		t.Errorf("CreateBeansFromRoastBatch() error = %v, want %v", err, errors.ErrRoastBatchBeansAlreadyExist)
+	}
+	if _, err := s.CreateBeansFromRoastBatch(context.Background(), 2); !stderrors.Is(err, errors.ErrRoastBatchDoesNotExist) {
+		t.Errorf("CreateBeansFromRoastBatch() error = %v, want %v", err, errors.ErrRoastBatchDoesNotExist)
+	}
+}
+
+func TestRoastBatchServiceUpdateAndDelete(t *testing.T) {
+	s := New(&MockRoastBatchRepository{})
+
+	if _, err := s.UpdateRoastBatchById(context.Background(), 2, testBatch()); !stderrors.Is(err, errors.ErrRoastBatchDoesNotExist) {
+		t.Errorf("UpdateRoastBatchById() error = %v, want %v", err, errors.ErrRoastBatchDoesNotExist)
+	}
+	if _, err := s.UpdateRoastBatchById(context.Background(), 1, testBatch()); err != nil {
+		t.Errorf("UpdateRoastBatchById() error = %v", err)
+	}
+	if err := s.DeleteRoastBatchById(context.Background(), 2); !stderrors.Is(err, errors.ErrRoastBatchDoesNotExist) {
+		t.Errorf("DeleteRoastBatchById() error = %v, want %v", err, errors.ErrRoastBatchDoesNotExist)
+	}
+}
@@ -0,0 +1,194 @@
+package seed
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+)
+
+var (
+	roasterNames = []string{
+		"Square Mile", "Tim Wendelboe", "La Cabra", "Onyx", "Gardelli", "Origin",
+		"Friedhats", "Coffee Collective", "April", "Sey", "Manhattan", "Heart",
+	}
+	origins = []string{
+		"Ethiopia Guji", "Ethiopia Yirgacheffe", "Kenya Nyeri", "Colombia Huila",
+		"Brazil Cerrado", "Guatemala Huehuetenango", "Rwanda Nyamasheke",
+		"Costa Rica Tarrazú", "Honduras Santa Bárbara", "Peru Cajamarca",
+	}
+	processes = []string{"Washed", "Natural", "Honey", "Anaerobic"}
+	machines  = []string{
+		"Linea Mini", "Gaggia Classic", "Rancilio Silvia", "Decent DE1",
+		"Lelit Bianca", "Profitec Pro 600", "Flair 58", "La Pavoni",
+	}
+)
+
+// Generated shots aim at a 1:2 ratio in about 28 seconds, the usual starting
+// point of a dial-in.
+const (
+	targetShotTime = 28.0
+	targetRatio    = 2.0
+	secondsPerStep = 3.5
+)
+
+// beansPlan is beans to create, by the roaster at index roaster.
+type beansPlan struct {
+	roaster int
+	beans   bean.Bean
+}
+
+// sheetPlan is a sheet to create, holding the dial-in of the beans at index
+// beans.
+type sheetPlan struct {
+	name  string
+	beans int
+	shots []shot.Shot
+}
+
+type plan struct {
+	roasters []string
+	beans    []beansPlan
+	sheets   []sheetPlan
+}
+
+// generate returns the records described by opts. The same options always
+// generate the same records.
+func generate(opts Options) plan {
+	r := rand.New(rand.NewPCG(uint64(opts.Seed), uint64(opts.Seed)))
+	var p plan
+
+	names := newUniqueNames()
+	for i := 0; i < opts.Roasters; i++ {
+		p.roasters = append(p.roasters, names.next(roasterNames[r.IntN(len(roasterNames))]))
+	}
+
+	for i := range p.roasters {
+		for j := 0; j < opts.BeansPerRoaster; j++ {
+			roastDate := opts.Today.AddDate(0, 0, -(4 + r.IntN(35)))
+			bagWeight := []float64{250, 250, 340, 1000}[r.IntN(4)]
+			p.beans = append(p.beans, beansPlan{
+				roaster: i,
+				beans: bean.Bean{
+					Name:       fmt.Sprintf("%s %s", origins[r.IntN(len(origins))], processes[r.IntN(len(processes))]),
+					RoastDate:  &roastDate,
+					RoastLevel: roastLevel(r),
+					Price:      math.Round((bagWeight/250*(11+r.Float64()*9))*100) / 100,
+					Currency:   "EUR",
+					BagWeight:  bagWeight,
+				},
+			})
+		}
+	}
+
+	if len(p.beans) == 0 {
+		return p
+	}
+	for i := 0; i < opts.Sheets; i++ {
+		beans := r.IntN(len(p.beans))
+		p.sheets = append(p.sheets, sheetPlan{
+			name:  names.next(fmt.Sprintf("%s · %s", machines[r.IntN(len(machines))], p.beans[beans].beans.Name)),
+			beans: beans,
+			shots: dialIn(r, opts.ShotsPerSheet, p.beans[beans].beans.RoastLevel),
+		})
+	}
+	return p
+}
+
+// roastLevel picks a roast level, espresso roasts being the most likely.
+func roastLevel(r *rand.Rand) sql.RoastLevel {
+	return []sql.RoastLevel{
+		sql.RoastLevelLight, sql.RoastLevelLightToMedium, sql.RoastLevelLightToMedium,
+		sql.RoastLevelMedium, sql.RoastLevelMedium, sql.RoastLevelMedium,
+		sql.RoastLevelMediumToDark, sql.RoastLevelDark,
+	}[r.IntN(8)]
+}
+
+// dialIn returns n shots dialing in beans of the given roast level: the
+// first ones are ground too fine or too coarse, and every next shot moves the
+// grind setting towards the one pulling a 28 seconds shot, the ratings rising
+// as the shots get closer to it.
+func dialIn(r *rand.Rand, n int, level sql.RoastLevel) []shot.Shot {
+	// Lighter roasts need a finer grind and a hotter water.
+	ideal := 16 - 2*int(sql.RoastLevelDark-level) + r.IntN(5)
+	grind := ideal + []int{-4, -3, -2, 2, 3, 4}[r.IntN(6)]
+	temperature := 95 - float64(level)
+
+	shots := make([]shot.Shot, 0, n)
+	previousRating := -1.0
+	for i := 0; i < n; i++ {
+		quantityIn := 18.0
+		seconds := targetShotTime + float64(ideal-grind)*secondsPerStep + r.NormFloat64()*1.2
+		quantityOut := math.Round((quantityIn*targetRatio+r.NormFloat64()*1.5)*10) / 10
+		seconds = math.Max(12, seconds)
+
+		timeOff := math.Abs(seconds - targetShotTime)
+		ratioOff := math.Abs(quantityOut/quantityIn - targetRatio)
+		rating := 9.5 - timeOff*0.35 - ratioOff*4 + r.NormFloat64()*0.4
+		rating = math.Round(math.Min(10, math.Max(1, rating))*2) / 2
+
+		s := shot.Shot{
+			GrindSetting:                 grind,
+			QuantityIn:                   quantityIn,
+			QuantityOut:                  quantityOut,
+			ShotTime:                     shot.SecondsToDuration(math.Round(seconds*10) / 10),
+			WaterTemperature:             temperature,
+			Rating:                       rating,
+			IsTooSour:                    seconds < targetShotTime-3,
+			IsTooBitter:                  seconds > targetShotTime+3,
+			ComparisonWithPreviousResult: compare(previousRating, rating),
+		}
+		switch {
+		case s.IsTooSour:
+			s.AdditionalNotes = "Fast and sour, grinding finer"
+			grind -= step(r, seconds)
+		case s.IsTooBitter:
+			s.AdditionalNotes = "Slow and bitter, grinding coarser"
+			grind += step(r, seconds)
+		default:
+			s.AdditionalNotes = "Balanced and sweet"
+		}
+		shots = append(shots, s)
+		previousRating = rating
+	}
+	return shots
+}
+
+// step is by how much to move the grind setting after a shot of seconds:
+// further when further from the target.
+func step(r *rand.Rand, seconds float64) int {
+	if math.Abs(seconds-targetShotTime) > 2*secondsPerStep && r.IntN(2) == 0 {
+		return 2
+	}
+	return 1
+}
+
+func compare(previous, rating float64) sql.ComparisonWithPreviousResult {
+	switch {
+	case previous < 0:
+		return sql.Unknown
+	case rating > previous:
+		return sql.Better
+	case rating < previous:
+		return sql.Worst
+	default:
+		return sql.Same
+	}
+}
+
+// uniqueNames numbers the names given more than once, since sheets and
+// roasters must have unique names.
+type uniqueNames map[string]int
+
+func newUniqueNames() uniqueNames { return uniqueNames{} }
+
+func (u uniqueNames) next(name string) string {
+	u[name]++
+	if n := u[name]; n > 1 {
+		return fmt.Sprintf("%s %d", name, n)
+	}
+	return name
+}
@@ -0,0 +1,72 @@
+package seed
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	opts := testOptions()
+
+	if !reflect.DeepEqual(generate(opts), generate(opts)) {
+		t.Error("generate() with the same seed generated different records")
+	}
+
+	other := opts
+	other.Seed = 2
+	if reflect.DeepEqual(generate(opts), generate(other)) {
+		t.Error("generate() with different seeds generated the same records")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	opts := testOptions()
+	opts.Roasters, opts.BeansPerRoaster, opts.Sheets, opts.ShotsPerSheet = 15, 2, 30, 10
+
+	p := generate(opts)
+
+	names := map[string]bool{}
+	for _, name := range p.roasters {
+		if names[name] {
+			t.Errorf("generate() roaster %q is not unique", name)
+		}
+		names[name] = true
+	}
+	for _, b := range p.beans {
+		age := opts.Today.Sub(*b.beans.RoastDate)
+		if age < 4*24*time.Hour || age > 40*24*time.Hour {
+			t.Errorf("generate() beans %q roasted %v ago, want between 4 and 40 days", b.beans.Name, age)
+		}
+		if !b.beans.RoastLevel.IsValid() || b.beans.Price <= 0 || b.beans.BagWeight <= 0 {
+			t.Errorf("generate() beans = %+v, want a valid roast level, price and bag weight", b.beans)
+		}
+	}
+	for _, s := range p.sheets {
+		if names[s.name] {
+			t.Errorf("generate() sheet %q is not unique", s.name)
+		}
+		names[s.name] = true
+		if len(s.shots) != opts.ShotsPerSheet {
+			t.Fatalf("generate() sheet %q has %d shots, want %d", s.name, len(s.shots), opts.ShotsPerSheet)
+		}
+
+		first, last := s.shots[0], s.shots[len(s.shots)-1]
+		if first.ComparisonWithPreviousResult != sql.Unknown {
+			t.Errorf("generate() first shot of %q compares %v to no shot", s.name, first.ComparisonWithPreviousResult)
+		}
+		if d := last.ShotTime.Seconds() - targetShotTime; d < -6 || d > 6 {
+			t.Errorf("generate() last shot of %q pulled in %v, want close to %vs", s.name, last.ShotTime, targetShotTime)
+		}
+		if first.IsTooBitter == first.IsTooSour || first.Rating >= last.Rating {
+			t.Errorf("generate() dial-in of %q starts with %+v and ends with %+v, want it to improve", s.name, first, last)
+		}
+		for _, shot := range s.shots {
+			if shot.Rating < 0 || shot.Rating > 10 || !shot.ComparisonWithPreviousResult.IsValid() {
+				t.Errorf("generate() shot = %+v, want a valid rating and comparison", shot)
+			}
+		}
+	}
+}
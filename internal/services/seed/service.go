@@ -0,0 +1,193 @@
+// Package seed fills the database with demo roasters, beans, sheets and
+// shots, for onboarding and screenshots. The records are generated from a
+// seed, the same seed always generating the same records, and created
+// through their services, so that they are validated as if they were created
+// through the API.
+package seed
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/rs/zerolog"
+)
+
+// Options are the records to generate.
+type Options struct {
+	// Seed of the generator: the same seed generates the same records.
+	Seed int64
+	// Roasters is the number of roasters to create.
+	Roasters int
+	// BeansPerRoaster is the number of beans to create for each roaster.
+	BeansPerRoaster int
+	// Sheets is the number of sheets to create, each dialing in beans.
+	Sheets int
+	// ShotsPerSheet is the number of shots of each sheet.
+	ShotsPerSheet int
+	// Today is the day the roast dates of the beans are relative to.
+	Today time.Time
+}
+
+// DefaultOptions are the options of a seed command run without flags.
+var DefaultOptions = Options{
+	Seed:            1,
+	Roasters:        3,
+	BeansPerRoaster: 2,
+	Sheets:          4,
+	ShotsPerSheet:   8,
+}
+
+// Count is a number of records of each kind. Cuppings and roasts are only
+// ever deleted.
+type Count struct {
+	Roasters int
+	Beans    int
+	Sheets   int
+	Shots    int
+	Cuppings int
+	Roasts   int
+}
+
+// Report is the number of records deleted by a reset, and created.
+type Report struct {
+	Deleted Count
+	Created Count
+}
+
+type Service interface {
+	Seed(ctx context.Context, opts Options, reset bool) (*Report, error)
+}
+
+type SeedService struct {
+	sheets   sheet.Service
+	roasters roaster.Service
+	beans    bean.Service
+	shots    shot.Service
+	seeds    repository.SeedRepository
+}
+
+var _ Service = (*SeedService)(nil)
+
+func New(sheets sheet.Service, roasters roaster.Service, beans bean.Service, shots shot.Service, seeds repository.SeedRepository) *SeedService {
+	return &SeedService{
+		sheets:   sheets,
+		roasters: roasters,
+		beans:    beans,
+		shots:    shots,
+		seeds:    seeds,
+	}
+}
+
+// Seed creates the records generated from opts. With reset, every cupping,
+// roast, shot, beans, sheet and roaster is deleted first, in a single
+// transaction. Nothing is created when a roaster or sheet to create already
+// exists, as after a former seed with the same seed: ErrSeedRecordExists is
+// returned instead.
+func (s *SeedService) Seed(ctx context.Context, opts Options, reset bool) (*Report, error) {
+	fail := func(msg string, err error) (*Report, error) {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	var report Report
+	if reset {
+		deleted, err := s.seeds.ResetSeed(ctx)
+		if err != nil {
+			return fail("could not reset the database", err)
+		}
+		report.Deleted = Count{
+			Roasters: deleted.Roasters,
+			Beans:    deleted.Beans,
+			Sheets:   deleted.Sheets,
+			Shots:    deleted.Shots,
+			Cuppings: deleted.CuppingSessions,
+			Roasts:   deleted.RoastBatches,
+		}
+	}
+
+	p := generate(opts)
+	if err := s.checkConflicts(ctx, p); err != nil {
+		return fail("could not seed the database", err)
+	}
+
+	roasters := make([]*roaster.Roaster, len(p.roasters))
+	for i, name := range p.roasters {
+		created, err := s.roasters.CreateRoasterByName(ctx, name)
+		if err != nil {
+			return fail("could not create roaster", err)
+		}
+		roasters[i] = created
+		report.Created.Roasters++
+	}
+
+	beans := make([]*bean.Bean, len(p.beans))
+	for i, b := range p.beans {
+		b.beans.Roaster = roasters[b.roaster]
+		created, err := s.beans.CreateBean(ctx, &b.beans)
+		if err != nil {
+			return fail("could not create beans", err)
+		}
+		beans[i] = created
+		report.Created.Beans++
+	}
+
+	for _, sp := range p.sheets {
+		created, err := s.sheets.CreateSheetByName(ctx, sp.name)
+		if err != nil {
+			return fail("could not create sheet", err)
+		}
+		report.Created.Sheets++
+
+		for _, sh := range sp.shots {
+			sh.Sheet = created
+			sh.Beans = beans[sp.beans]
+			if _, err := s.shots.CreateShot(ctx, &sh); err != nil {
+				return fail("could not create shot", err)
+			}
+			report.Created.Shots++
+		}
+	}
+
+	return &report, nil
+}
+
+// checkConflicts returns ErrSeedRecordExists when a roaster or sheet of p
+// already exists. Names are unique, and beans belong to the roasters
+// created along.
+func (s *SeedService) checkConflicts(ctx context.Context, p plan) error {
+	roasters, err := s.roasters.GetAllRoasters(ctx)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(roasters))
+	for _, r := range roasters {
+		existing[r.Name] = true
+	}
+	for _, name := range p.roasters {
+		if existing[name] {
+			return fmt.Errorf("%w: roaster %q", errors.ErrSeedRecordExists, name)
+		}
+	}
+
+	sheets, err := s.sheets.GetAllSheets(ctx)
+	if err != nil {
+		return err
+	}
+	existing = make(map[string]bool, len(sheets))
+	for _, sh := range sheets {
+		existing[sh.Name] = true
+	}
+	for _, sp := range p.sheets {
+		if existing[sp.name] {
+			return fmt.Errorf("%w: sheet %q", errors.ErrSeedRecordExists, sp.name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+)
+
+// MockServices is an in-memory store of sheets, roasters, beans and shots
+// implementing their services and the seed repository, which records the
+// deletions in order.
+type MockServices struct {
+	nextId   int
+	sheets   []sheet.Sheet
+	roasters []roaster.Roaster
+	beans    []bean.Bean
+	shots    []shot.Shot
+	cuppings int
+	deleted  []string
+	err      error
+}
+
+// ResetSeed empties the store, as a single deletion.
+func (m *MockServices) ResetSeed(ctx context.Context) (*sql.SeedReset, error) {
+	reset := &sql.SeedReset{CuppingSessions: m.cuppings, Shots: len(m.shots), Beans: len(m.beans), Sheets: len(m.sheets), Roasters: len(m.roasters)}
+	m.deleted = append(m.deleted, "reset")
+	m.cuppings, m.shots, m.beans, m.sheets, m.roasters = 0, nil, nil, nil, nil
+	return reset, nil
+}
+
+func (m *MockServices) id() int {
+	m.nextId++
+	return m.nextId
+}
+
+func (m *MockServices) CreateSheet(ctx context.Context, s *sheet.Sheet) (*sheet.Sheet, error) {
+	m.sheets = append(m.sheets, sheet.Sheet{Id: m.id(), Name: s.Name, IsTemplate: s.IsTemplate})
+	return &m.sheets[len(m.sheets)-1], nil
+}
+func (m *MockServices) CreateSheetByName(ctx context.Context, name string) (*sheet.Sheet, error) {
+	return m.CreateSheet(ctx, &sheet.Sheet{Name: name})
+}
+func (m *MockServices) GetSheetById(ctx context.Context, id int) (*sheet.Sheet, error) {
+	return nil, nil
+}
+func (m *MockServices) GetAllSheets(ctx context.Context) ([]sheet.Sheet, error) { return m.sheets, nil }
+func (m *MockServices) UpdateSheetById(ctx context.Context, id int, s *sheet.Sheet) (*sheet.Sheet, error) {
+	return nil, nil
+}
+func (m *MockServices) DeleteSheetById(ctx context.Context, id int) error {
+	m.deleted = append(m.deleted, fmt.Sprintf("sheet %d", id))
+	return nil
+}
+
+func (m *MockServices) CloneSheet(ctx context.Context, id int, name string, copyBestShot bool) (*sheet.Sheet, error) {
+	return nil, nil
+}
+
+func (m *MockServices) CreateRoasterByName(ctx context.Context, name string) (*roaster.Roaster, error) {
+	m.roasters = append(m.roasters, roaster.Roaster{Id: m.id(), Name: name})
+	return &m.roasters[len(m.roasters)-1], nil
+}
+func (m *MockServices) GetRoasterById(ctx context.Context, id int) (*roaster.Roaster, error) {
+	return nil, nil
+}
+func (m *MockServices) GetAllRoasters(ctx context.Context) ([]roaster.Roaster, error) {
+	return m.roasters, nil
+}
+func (m *MockServices) UpdateRoasterById(ctx context.Context, id int, r *roaster.Roaster) (*roaster.Roaster, error) {
+	return nil, nil
+}
+func (m *MockServices) DeleteRoasterById(ctx context.Context, id int) error {
+	m.deleted = append(m.deleted, fmt.Sprintf("roaster %d", id))
+	return nil
+}
+
+func (m *MockServices) CreateBean(ctx context.Context, b *bean.Bean) (*bean.Bean, error) {
+	if b.Roaster == nil || b.Roaster.Id == 0 {
+		return nil, errors.New("beans without roaster")
+	}
+	created := *b
+	created.Id = m.id()
+	m.beans = append(m.beans, created)
+	return &created, nil
+}
+func (m *MockServices) GetBeanById(ctx context.Context, id int) (*bean.Bean, error) { return nil, nil }
+func (m *MockServices) GetAllBeans(ctx context.Context) ([]bean.Bean, error)        { return m.beans, nil }
+func (m *MockServices) UpdateBeanById(ctx context.Context, id int, b *bean.Bean) (*bean.Bean, error) {
+	return nil, nil
+}
+func (m *MockServices) DeleteBeanById(ctx context.Context, id int) error {
+	m.deleted = append(m.deleted, fmt.Sprintf("beans %d", id))
+	return nil
+}
+
+func (m *MockServices) CreateShot(ctx context.Context, s *shot.Shot) (*shot.Shot, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if s.Sheet == nil || s.Sheet.Id == 0 || s.Beans == nil || s.Beans.Id == 0 {
+		return nil, errors.New("shot without sheet or beans")
+	}
+	created := *s
+	created.Id = m.id()
+	m.shots = append(m.shots, created)
+	return &created, nil
+}
+func (m *MockServices) GetShotById(ctx context.Context, id int) (*shot.Shot, error) { return nil, nil }
+func (m *MockServices) GetAllShots(ctx context.Context) ([]shot.Shot, error)        { return m.shots, nil }
+func (m *MockServices) GetShotsBySheetId(ctx context.Context, sheetId int) ([]shot.Shot, error) {
+	return nil, nil
+}
+func (m *MockServices) UpdateShotById(ctx context.Context, id int, s *shot.Shot) (*shot.Shot, error) {
+	return nil, nil
+}
+func (m *MockServices) DeleteShotById(ctx context.Context, id int) error {
+	m.deleted = append(m.deleted, fmt.Sprintf("shot %d", id))
+	return nil
+}
+func (m *MockServices) GetShotProfileById(ctx context.Context, id int) (*shot.Profile, error) {
+	return nil, nil
+}
+func (m *MockServices) UpdateShotProfileById(ctx context.Context, id int, profile *shot.Profile) (*shot.Profile, error) {
+	return nil, nil
+}
+func (m *MockServices) CompareShots(ctx context.Context, ids []int) (*shot.Comparison, error) {
+	return nil, nil
+}
+func (m *MockServices) DuplicateShot(ctx context.Context, id int, overrides shot.Overrides) (*shot.Shot, error) {
+	return nil, nil
+}
+func (m *MockServices) Ping(ctx context.Context) error { return nil }
+
+func newTestService(m *MockServices) *SeedService {
+	return New(m, m, m, m, m)
+}
+
+func testOptions() Options {
+	opts := DefaultOptions
+	opts.Today = time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	return opts
+}
+
+func TestSeed(t *testing.T) {
+	m := &MockServices{}
+
+	report, err := newTestService(m).Seed(context.Background(), testOptions(), false)
+	if err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+
+	want := Report{Created: Count{Roasters: 3, Beans: 6, Sheets: 4, Shots: 32}}
+	if *report != want {
+		t.Errorf("Seed() = %+v, want %+v", *report, want)
+	}
+	if len(m.roasters) != 3 || len(m.beans) != 6 || len(m.sheets) != 4 || len(m.shots) != 32 {
+		t.Errorf("Seed() created %d roasters, %d beans, %d sheets and %d shots", len(m.roasters), len(m.beans), len(m.sheets), len(m.shots))
+	}
+	if len(m.deleted) != 0 {
+		t.Errorf("Seed() deleted %v without reset", m.deleted)
+	}
+}
+
+func TestSeedReset(t *testing.T) {
+	m := &MockServices{
+		roasters: []roaster.Roaster{{Id: 1, Name: "Square Mile"}},
+		beans:    []bean.Bean{{Id: 2, Name: "Red Brick"}},
+		sheets:   []sheet.Sheet{{Id: 3, Name: "Gaggia"}},
+		shots:    []shot.Shot{{Id: 4}, {Id: 5}},
+		cuppings: 1,
+		nextId:   5,
+	}
+
+	report, err := newTestService(m).Seed(context.Background(), testOptions(), true)
+	if err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+
+	if want := (Count{Roasters: 1, Beans: 1, Sheets: 1, Shots: 2, Cuppings: 1}); report.Deleted != want {
+		t.Errorf("Seed() deleted = %+v, want %+v", report.Deleted, want)
+	}
+	if fmt.Sprint(m.deleted) != "[reset]" {
+		t.Errorf("Seed() deleted %v, want a single reset", m.deleted)
+	}
+	if len(m.roasters) != 3 || len(m.shots) != 32 {
+		t.Errorf("Seed() kept %d roasters and %d shots after the reset, want 3 and 32", len(m.roasters), len(m.shots))
+	}
+}
+
+func TestSeedTwice(t *testing.T) {
+	m := &MockServices{}
+	if _, err := newTestService(m).Seed(context.Background(), testOptions(), false); err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+
+	_, err := newTestService(m).Seed(context.Background(), testOptions(), false)
+	if !errors.Is(err, domainerrors.ErrSeedRecordExists) {
+		t.Fatalf("Seed() error = %v, want %v", err, domainerrors.ErrSeedRecordExists)
+	}
+	if len(m.roasters) != 3 || len(m.shots) != 32 {
+		t.Errorf("Seed() created %d roasters and %d shots, want none more than the first seed", len(m.roasters), len(m.shots))
+	}
+
+	if _, err := newTestService(m).Seed(context.Background(), testOptions(), true); err != nil {
+		t.Fatalf("Seed() with reset error = %v", err)
+	}
+}
+
+func TestSeedError(t *testing.T) {
+	errDatabase := errors.New("connection refused")
+
+	_, err := newTestService(&MockServices{err: errDatabase}).Seed(context.Background(), testOptions(), false)
+	if !errors.Is(err, errDatabase) {
+		t.Fatalf("Seed() error = %v, want %v", err, errDatabase)
+	}
+}
@@ -0,0 +1,213 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// lifetime is how long a session lasts after logging in.
+const lifetime = 7 * 24 * time.Hour
+
+// Session is a web UI login. The token identifying it is only known to the
+// browser: a hash of it is stored.
+type Session struct {
+	Id        int
+	UserId    int
+	UserName  string
+	UserRole  auth.Role
+	CSRFToken string
+	ExpiresAt time.Time
+}
+
+// SQLToSession converts a sql.Session object to a Session object.
+// If the input session is nil, it returns nil.
+func SQLToSession(session *sql.Session) *Session {
+	if session == nil {
+		return nil
+	}
+
+	return &Session{
+		Id:        session.Id,
+		UserId:    session.UserId,
+		UserName:  session.UserName,
+		UserRole:  auth.Role(session.UserRole),
+		CSRFToken: session.CSRFToken,
+		ExpiresAt: session.ExpiresAt,
+	}
+}
+
+type Service interface {
+	Login(ctx context.Context, userName, password string) (*Session, string, error)
+	StartSession(ctx context.Context, user *auth.User) (*Session, string, error)
+	Authenticate(ctx context.Context, token string) (*Session, error)
+	Logout(ctx context.Context, token string) error
+	Ping(ctx context.Context) error
+}
+
+type SessionService struct {
+	repository repository.SessionRepository
+	users      repository.UserRepository
+}
+
+var _ Service = (*SessionService)(nil)
+
+// now is replaced in tests.
+var now = time.Now
+
+// dummyPasswordHash is checked against when a user does not exist or has no
+// password, so that a login takes as long whether the user exists or not.
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := auth.HashPassword("")
+	return hash
+})
+
+func New(repo repository.SessionRepository, users repository.UserRepository) *SessionService {
+	return &SessionService{repository: repo, users: users}
+}
+
+// Login checks the password of the user named userName and opens a session
+// for them. It returns the session and its token, to be handed to the browser.
+// Any unknown user or wrong password gives errors.ErrInvalidCredentials.
+func (s *SessionService) Login(ctx context.Context, userName, password string) (*Session, string, error) {
+	msg := "could not log in"
+	userName = strings.TrimSpace(userName)
+
+	hash, err := s.users.GetUserPasswordHashByName(ctx, userName)
+	if err != nil && !stderrors.Is(err, errors.ErrUserDoesNotExist) {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, "", fmt.Errorf("%s: %w", msg, err)
+	}
+	if hash == "" {
+		auth.CheckPassword(dummyPasswordHash(), password)
+		err := errors.ErrInvalidCredentials
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, "", fmt.Errorf("%s: %w", msg, err)
+	}
+	if !auth.CheckPassword(hash, password) {
+		err := errors.ErrInvalidCredentials
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, "", fmt.Errorf("%s: %w", msg, err)
+	}
+
+	user, err := s.users.GetUserByName(ctx, userName)
+	if err == nil && user.Disabled {
+		err = errors.ErrUserIsDisabled
+	}
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, "", fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return s.StartSession(ctx, &auth.User{Id: user.Id, Name: user.Name, Role: auth.Role(user.Role)})
+}
+
+// StartSession opens a session for a user already authenticated, for
+// instance by an OpenID Connect provider. It returns the session and its
+// token, to be handed to the browser.
+func (s *SessionService) StartSession(ctx context.Context, user *auth.User) (*Session, string, error) {
+	msg := "could not start session"
+
+	// Logging in is rare enough to clean up the expired sessions.
+	loggedInAt := now()
+	if err := s.repository.DeleteExpiredSessions(ctx, loggedInAt); err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg("could not delete expired sessions")
+	}
+
+	token, err := newToken()
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, "", fmt.Errorf("%s: %w", msg, err)
+	}
+	csrfToken, err := newToken()
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, "", fmt.Errorf("%s: %w", msg, err)
+	}
+
+	session := &sql.Session{
+		UserId:    user.Id,
+		TokenHash: hashToken(token),
+		CSRFToken: csrfToken,
+		ExpiresAt: loggedInAt.Add(lifetime).UTC().Truncate(time.Second),
+		UserName:  user.Name,
+		UserRole:  string(user.Role),
+	}
+	if session.Id, err = s.repository.CreateSession(ctx, session); err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, "", fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToSession(session), token, nil
+}
+
+// Authenticate returns the session identified by token. It returns
+// errors.ErrSessionIsInvalid for an unknown or expired session and
+// errors.ErrUserIsDisabled when the user owning the session is disabled.
+func (s *SessionService) Authenticate(ctx context.Context, token string) (*Session, error) {
+	msg := "could not authenticate session"
+
+	session, err := s.repository.GetSessionByTokenHash(ctx, hashToken(token))
+	if stderrors.Is(err, errors.ErrSessionDoesNotExist) {
+		err = errors.ErrSessionIsInvalid
+	}
+	if err == nil && !now().Before(session.ExpiresAt) {
+		err = errors.ErrSessionIsInvalid
+	}
+	if err == nil && session.UserDisabled {
+		err = errors.ErrUserIsDisabled
+	}
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToSession(session), nil
+}
+
+// Logout ends the session identified by token.
+func (s *SessionService) Logout(ctx context.Context, token string) error {
+	if err := s.repository.DeleteSessionByTokenHash(ctx, hashToken(token)); err != nil {
+		msg := "could not log out"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+func (s *SessionService) Ping(ctx context.Context) error {
+	if err := s.repository.Ping(ctx); err != nil {
+		msg := "could not ping database"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// newToken returns a new random session or CSRF token, 43 characters long.
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hash of a session token as stored.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
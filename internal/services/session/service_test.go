@@ -0,0 +1,242 @@
+package session
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type MockSessionRepository struct {
+	sessions  map[string]sql.Session
+	users     map[string]sql.User
+	passwords map[string]string
+	purgedAt  time.Time
+}
+
+func newMockRepository(t *testing.T) *MockSessionRepository {
+	t.Helper()
+	hash, err := auth.HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("auth.HashPassword() error = %v", err)
+	}
+	return &MockSessionRepository{
+		sessions: map[string]sql.Session{},
+		users: map[string]sql.User{
+			"alice": {Id: 4, Name: "alice"},
+			"bob":   {Id: 5, Name: "bob", Disabled: true},
+			"carol": {Id: 6, Name: "carol"},
+		},
+		passwords: map[string]string{"alice": hash, "bob": hash},
+	}
+}
+
+func (m *MockSessionRepository) CreateSession(ctx context.Context, session *sql.Session) (int, error) {
+	session.Id = len(m.sessions) + 1
+	m.sessions[session.TokenHash] = *session
+	return session.Id, nil
+}
+
+func (m *MockSessionRepository) GetSessionByTokenHash(ctx context.Context, tokenHash string) (*sql.Session, error) {
+	session, ok := m.sessions[tokenHash]
+	if !ok {
+		return nil, errors.ErrSessionDoesNotExist
+	}
+	for _, u := range m.users {
+		if u.Id == session.UserId {
+			session.UserName = u.Name
+			session.UserDisabled = u.Disabled
+		}
+	}
+	return &session, nil
+}
+
+func (m *MockSessionRepository) DeleteSessionByTokenHash(ctx context.Context, tokenHash string) error {
+	delete(m.sessions, tokenHash)
+	return nil
+}
+
+func (m *MockSessionRepository) DeleteExpiredSessions(ctx context.Context, before time.Time) error {
+	m.purgedAt = before
+	return nil
+}
+
+func (m *MockSessionRepository) Ping(ctx context.Context) error { return nil }
+
+// The user repository methods not used by sessions are only there to satisfy
+// the interface.
+func (m *MockSessionRepository) CreateUser(ctx context.Context, user *sql.User) (int, error) {
+	return 0, fmt.Errorf("unexpected call")
+}
+
+func (m *MockSessionRepository) GetUserById(ctx context.Context, id int) (*sql.User, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *MockSessionRepository) GetUserByName(ctx context.Context, name string) (*sql.User, error) {
+	user, ok := m.users[name]
+	if !ok {
+		return nil, errors.ErrUserDoesNotExist
+	}
+	return &user, nil
+}
+
+func (m *MockSessionRepository) GetAllUsers(ctx context.Context) ([]sql.User, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *MockSessionRepository) DisableUserByName(ctx context.Context, name string) error {
+	return fmt.Errorf("unexpected call")
+}
+
+func (m *MockSessionRepository) SetUserRoleByName(ctx context.Context, name, role string) error {
+	return fmt.Errorf("unexpected call")
+}
+
+func (m *MockSessionRepository) SetUserPasswordHashByName(ctx context.Context, name, hash string) error {
+	return fmt.Errorf("unexpected call")
+}
+
+func (m *MockSessionRepository) GetUserByOIDCSubject(ctx context.Context, subject string) (*sql.User, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *MockSessionRepository) SetUserOIDCSubjectByName(ctx context.Context, name, subject string) error {
+	return fmt.Errorf("unexpected call")
+}
+
+func (m *MockSessionRepository) GetUserOIDCSubjectByName(ctx context.Context, name string) (string, error) {
+	return "", fmt.Errorf("unexpected call")
+}
+
+func (m *MockSessionRepository) GetUserPasswordHashByName(ctx context.Context, name string) (string, error) {
+	if _, ok := m.users[name]; !ok {
+		return "", errors.ErrUserDoesNotExist
+	}
+	return m.passwords[name], nil
+}
+
+var testNow = time.Date(2026, time.October, 18, 17, 0, 0, 0, time.UTC)
+
+func pinNow(t *testing.T, at time.Time) {
+	t.Helper()
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = time.Now })
+}
+
+func TestSessionServiceLogin(t *testing.T) {
+	tests := []struct {
+		name     string
+		userName string
+		password string
+		wantErr  error
+	}{
+		{name: "Valid", userName: " alice ", password: "correct horse"},
+		{name: "Wrong password", userName: "alice", password: "battery staple", wantErr: errors.ErrInvalidCredentials},
+		{name: "Unknown user", userName: "dave", password: "correct horse", wantErr: errors.ErrInvalidCredentials},
+		{name: "User without password", userName: "carol", password: "", wantErr: errors.ErrInvalidCredentials},
+		{name: "Disabled user", userName: "bob", password: "correct horse", wantErr: errors.ErrUserIsDisabled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pinNow(t, testNow)
+			repo := newMockRepository(t)
+			got, token, err := New(repo, repo).Login(context.Background(), tt.userName, tt.password)
+			if tt.wantErr != nil {
+				if !stderrors.Is(err, tt.wantErr) {
+					t.Fatalf("SessionService.Login() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.sessions) != 0 {
+					t.Errorf("SessionService.Login() created a session on error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SessionService.Login() error = %v", err)
+			}
+			if got.UserId != 4 || got.UserName != "alice" || got.CSRFToken == "" || !got.ExpiresAt.Equal(testNow.Add(lifetime)) {
+				t.Errorf("SessionService.Login() = %+v, want a week long session of alice", got)
+			}
+			if _, ok := repo.sessions[hashToken(token)]; !ok {
+				t.Errorf("stored session is not keyed by the hash of the token")
+			}
+			if !repo.purgedAt.Equal(testNow) {
+				t.Errorf("expired sessions purged before %v, want %v", repo.purgedAt, testNow)
+			}
+		})
+	}
+}
+
+func TestSessionServiceAuthenticate(t *testing.T) {
+	pinNow(t, testNow)
+	repo := newMockRepository(t)
+	s := New(repo, repo)
+	login, token, err := s.Login(context.Background(), "alice", "correct horse")
+	if err != nil {
+		t.Fatalf("SessionService.Login() error = %v", err)
+	}
+
+	got, err := s.Authenticate(context.Background(), token)
+	if err != nil {
+		t.Fatalf("SessionService.Authenticate() error = %v", err)
+	}
+	if *got != *login {
+		t.Errorf("SessionService.Authenticate() = %+v, want %+v", got, login)
+	}
+
+	if _, err := s.Authenticate(context.Background(), "unknown"); !stderrors.Is(err, errors.ErrSessionIsInvalid) {
+		t.Errorf("SessionService.Authenticate() error = %v, want %v", err, errors.ErrSessionIsInvalid)
+	}
+
+	pinNow(t, testNow.Add(lifetime))
+	if _, err := s.Authenticate(context.Background(), token); !stderrors.Is(err, errors.ErrSessionIsInvalid) {
+		t.Errorf("SessionService.Authenticate() of an expired session error = %v, want %v", err, errors.ErrSessionIsInvalid)
+	}
+
+	pinNow(t, testNow)
+	alice := repo.users["alice"]
+	alice.Disabled = true
+	repo.users["alice"] = alice
+	if _, err := s.Authenticate(context.Background(), token); !stderrors.Is(err, errors.ErrUserIsDisabled) {
+		t.Errorf("SessionService.Authenticate() error = %v, want %v", err, errors.ErrUserIsDisabled)
+	}
+}
+
+func TestSessionServiceLogout(t *testing.T) {
+	repo := newMockRepository(t)
+	s := New(repo, repo)
+	_, token, err := s.Login(context.Background(), "alice", "correct horse")
+	if err != nil {
+		t.Fatalf("SessionService.Login() error = %v", err)
+	}
+
+	if err := s.Logout(context.Background(), token); err != nil {
+		t.Fatalf("SessionService.Logout() error = %v", err)
+	}
+	if _, err := s.Authenticate(context.Background(), token); !stderrors.Is(err, errors.ErrSessionIsInvalid) {
+		t.Errorf("SessionService.Authenticate() after logout error = %v, want %v", err, errors.ErrSessionIsInvalid)
+	}
+}
+
+func TestSessionServiceStartSession(t *testing.T) {
+	pinNow(t, testNow)
+	repo := newMockRepository(t)
+	s := New(repo, repo)
+
+	// carol has no password but may be authenticated otherwise.
+	got, token, err := s.StartSession(context.Background(), &auth.User{Id: 6, Name: "carol"})
+	if err != nil {
+		t.Fatalf("SessionService.StartSession() error = %v", err)
+	}
+	if got.UserId != 6 || got.UserName != "carol" || !got.ExpiresAt.Equal(testNow.Add(lifetime)) {
+		t.Errorf("SessionService.StartSession() = %+v, want a week long session of carol", got)
+	}
+	if _, err := s.Authenticate(context.Background(), token); err != nil {
+		t.Errorf("SessionService.Authenticate() error = %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+package share
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	stderrors "errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// DefaultLifetime is how long a share link is valid when no expiry is
+	// given.
+	DefaultLifetime = 7 * 24 * time.Hour
+
+	// MaxLifetime is the longest a share link can be valid.
+	MaxLifetime = 365 * 24 * time.Hour
+)
+
+// ShareLink gives read-only access to a sheet to anyone knowing its token,
+// until it expires or is revoked.
+type ShareLink struct {
+	// The id for the share link
+	Id int `json:"id"`
+
+	// The id of the shared sheet
+	SheetId int `json:"sheet_id"`
+
+	// The signed token giving access to the sheet
+	Token string `json:"token"`
+
+	// When the share link stops giving access to the sheet
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// When the share link was revoked, if it was
+	RevokedAt *time.Time `json:"revoked_at"`
+
+	// The creation time of the share link
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// IsActive reports whether the link is neither revoked nor expired at t.
+func (l *ShareLink) IsActive(t time.Time) bool {
+	return l.RevokedAt == nil && t.Before(l.ExpiresAt)
+}
+
+type Service interface {
+	CreateShareLink(ctx context.Context, sheetId int, expiresAt *time.Time) (*ShareLink, error)
+	GetShareLinksBySheetId(ctx context.Context, sheetId int) ([]ShareLink, error)
+	RevokeShareLinkById(ctx context.Context, id int) error
+	ResolveToken(ctx context.Context, token string) (*ShareLink, error)
+	Ping(ctx context.Context) error
+}
+
+type ShareLinkService struct {
+	repository repository.ShareLinkRepository
+	secret     []byte
+}
+
+var _ Service = (*ShareLinkService)(nil)
+
+// now is replaced in tests.
+var now = time.Now
+
+// New returns a share link service signing its tokens with secret. Changing
+// the secret invalidates every token already handed out.
+func New(repo repository.ShareLinkRepository, secret []byte) *ShareLinkService {
+	return &ShareLinkService{repository: repo, secret: secret}
+}
+
+// SQLToShareLink converts a sql.ShareLink object to a ShareLink object, whose
+// token is signed with secret.
+// If the input link is nil, it returns nil.
+func SQLToShareLink(link *sql.ShareLink, secret []byte) *ShareLink {
+	if link == nil {
+		return nil
+	}
+
+	return &ShareLink{
+		Id:        link.Id,
+		SheetId:   link.SheetId,
+		Token:     signToken(secret, link.Id, link.ExpiresAt),
+		ExpiresAt: link.ExpiresAt,
+		RevokedAt: link.RevokedAt,
+		CreatedAt: link.CreatedAt,
+	}
+}
+
+// CreateShareLink creates a share link for the sheet sheetId, valid until
+// expiresAt or for DefaultLifetime when expiresAt is nil.
+func (s *ShareLinkService) CreateShareLink(ctx context.Context, sheetId int, expiresAt *time.Time) (*ShareLink, error) {
+	msg := "could not create share link"
+
+	t := now()
+	expiry := t.Add(DefaultLifetime)
+	if expiresAt != nil {
+		expiry = *expiresAt
+	}
+	// Tokens carry the expiry in seconds, as the database stores it.
+	expiry = expiry.UTC().Truncate(time.Second)
+	if !expiry.After(t) || expiry.Sub(t) > MaxLifetime {
+		err := errors.ErrShareLinkExpiryIsInvalid
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	id, err := s.repository.CreateShareLink(ctx, &sql.ShareLink{SheetId: sheetId, ExpiresAt: expiry})
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	created, err := s.repository.GetShareLinkById(ctx, id)
+	if err != nil {
+		msg := "could not get created share link"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToShareLink(created, s.secret), nil
+}
+
+func (s *ShareLinkService) GetShareLinksBySheetId(ctx context.Context, sheetId int) ([]ShareLink, error) {
+	sqlLinks, err := s.repository.GetShareLinksBySheetId(ctx, sheetId)
+	if err != nil {
+		msg := "could not get share links by sheet id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	links := make([]ShareLink, len(sqlLinks))
+	for i, v := range sqlLinks {
+		links[i] = *SQLToShareLink(&v, s.secret)
+	}
+
+	return links, nil
+}
+
+func (s *ShareLinkService) RevokeShareLinkById(ctx context.Context, id int) error {
+	if err := s.repository.RevokeShareLinkById(ctx, id, now()); err != nil {
+		msg := "could not revoke share link by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// ResolveToken returns the share link token was signed for. It fails with
+// errors.ErrShareLinkIsInvalid when the token is malformed, forged, expired or
+// revoked, or when its link does not exist anymore.
+func (s *ShareLinkService) ResolveToken(ctx context.Context, token string) (*ShareLink, error) {
+	msg := "could not resolve share link token"
+
+	id, expiresAt, ok := parseToken(token)
+	if !ok || !hmac.Equal([]byte(token), []byte(signToken(s.secret, id, expiresAt))) || !now().Before(expiresAt) {
+		err := errors.ErrShareLinkIsInvalid
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	link, err := s.repository.GetShareLinkById(ctx, id)
+	if stderrors.Is(err, errors.ErrShareLinkDoesNotExist) {
+		err = errors.ErrShareLinkIsInvalid
+	}
+	// A link whose expiry does not match the token is not the one the
+	// token was signed for.
+	if err == nil && (link.RevokedAt != nil || !link.ExpiresAt.Equal(expiresAt)) {
+		err = errors.ErrShareLinkIsInvalid
+	}
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToShareLink(link, s.secret), nil
+}
+
+func (s *ShareLinkService) Ping(ctx context.Context) error {
+	if err := s.repository.Ping(ctx); err != nil {
+		msg := "could not ping database"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// signToken returns the token of the share link id expiring at expiresAt:
+// "<id>.<expiry as unix seconds>.<signature>". Tokens are derived rather than
+// stored, so they can only be handed out again by the holder of the secret.
+func signToken(secret []byte, id int, expiresAt time.Time) string {
+	payload := strconv.Itoa(id) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// parseToken returns the share link id and expiry a token claims, without
+// checking its signature.
+func parseToken(token string) (int, time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, time.Time{}, false
+	}
+	id, err := strconv.Atoi(parts[0])
+	if err != nil || id <= 0 {
+		return 0, time.Time{}, false
+	}
+	exp, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	return id, time.Unix(exp, 0).UTC(), true
+}
@@ -0,0 +1,200 @@
+package share
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type MockShareLinkRepository struct {
+	links  map[int]sql.ShareLink
+	sheets map[int]bool
+}
+
+func newMockRepository() *MockShareLinkRepository {
+	return &MockShareLinkRepository{
+		links:  map[int]sql.ShareLink{},
+		sheets: map[int]bool{3: true},
+	}
+}
+
+func (m *MockShareLinkRepository) CreateShareLink(ctx context.Context, link *sql.ShareLink) (int, error) {
+	if !m.sheets[link.SheetId] {
+		return 0, errors.ErrSheetDoesNotExist
+	}
+	link.Id = len(m.links) + 1
+	createdAt := testNow
+	link.CreatedAt = &createdAt
+	m.links[link.Id] = *link
+	return link.Id, nil
+}
+
+func (m *MockShareLinkRepository) GetShareLinkById(ctx context.Context, id int) (*sql.ShareLink, error) {
+	link, ok := m.links[id]
+	if !ok {
+		return nil, errors.ErrShareLinkDoesNotExist
+	}
+	return &link, nil
+}
+
+func (m *MockShareLinkRepository) GetShareLinksBySheetId(ctx context.Context, sheetId int) ([]sql.ShareLink, error) {
+	if !m.sheets[sheetId] {
+		return nil, errors.ErrSheetDoesNotExist
+	}
+	links := make([]sql.ShareLink, 0, len(m.links))
+	for id := 1; id <= len(m.links); id++ {
+		if m.links[id].SheetId == sheetId {
+			links = append(links, m.links[id])
+		}
+	}
+	return links, nil
+}
+
+func (m *MockShareLinkRepository) RevokeShareLinkById(ctx context.Context, id int, revokedAt time.Time) error {
+	link, ok := m.links[id]
+	if !ok {
+		return errors.ErrShareLinkDoesNotExist
+	}
+	link.RevokedAt = &revokedAt
+	m.links[id] = link
+	return nil
+}
+
+func (m *MockShareLinkRepository) Ping(ctx context.Context) error { return nil }
+
+var (
+	testNow    = time.Date(2026, time.October, 18, 20, 0, 0, 0, time.UTC)
+	testSecret = []byte("0123456789abcdef0123456789abcdef")
+)
+
+func pinNow(t *testing.T, at time.Time) {
+	t.Helper()
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = time.Now })
+}
+
+func TestShareLinkServiceCreateShareLink(t *testing.T) {
+	inADay := testNow.Add(24*time.Hour + 500*time.Millisecond)
+	past := testNow.Add(-time.Minute)
+	tooLate := testNow.Add(MaxLifetime + time.Hour)
+	tests := []struct {
+		name      string
+		sheetId   int
+		expiresAt *time.Time
+		want      time.Time
+		wantErr   error
+	}{
+		{name: "Default lifetime", sheetId: 3, want: testNow.Add(DefaultLifetime)},
+		{name: "Given expiry is truncated to the second", sheetId: 3, expiresAt: &inADay, want: testNow.Add(24 * time.Hour)},
+		{name: "Expiry in the past", sheetId: 3, expiresAt: &past, wantErr: errors.ErrShareLinkExpiryIsInvalid},
+		{name: "Expiry too far", sheetId: 3, expiresAt: &tooLate, wantErr: errors.ErrShareLinkExpiryIsInvalid},
+		{name: "Unknown sheet", sheetId: 9, wantErr: errors.ErrSheetDoesNotExist},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pinNow(t, testNow)
+			got, err := New(newMockRepository(), testSecret).CreateShareLink(context.Background(), tt.sheetId, tt.expiresAt)
+			if tt.wantErr != nil {
+				if !stderrors.Is(err, tt.wantErr) {
+					t.Fatalf("ShareLinkService.CreateShareLink() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ShareLinkService.CreateShareLink() error = %v", err)
+			}
+			if got.SheetId != tt.sheetId || !got.ExpiresAt.Equal(tt.want) || !got.IsActive(testNow) {
+				t.Errorf("ShareLinkService.CreateShareLink() = %+v, want an active link of sheet %d expiring at %v", got, tt.sheetId, tt.want)
+			}
+			if !strings.HasPrefix(got.Token, "1.") {
+				t.Errorf("ShareLinkService.CreateShareLink() token = %q, want it to start with the link id", got.Token)
+			}
+		})
+	}
+}
+
+func TestShareLinkServiceResolveToken(t *testing.T) {
+	repo := newMockRepository()
+	s := New(repo, testSecret)
+	pinNow(t, testNow)
+	link, err := s.CreateShareLink(context.Background(), 3, nil)
+	if err != nil {
+		t.Fatalf("ShareLinkService.CreateShareLink() error = %v", err)
+	}
+
+	got, err := s.ResolveToken(context.Background(), link.Token)
+	if err != nil {
+		t.Fatalf("ShareLinkService.ResolveToken() error = %v", err)
+	}
+	if got.Id != link.Id || got.SheetId != 3 || got.Token != link.Token {
+		t.Errorf("ShareLinkService.ResolveToken() = %+v, want %+v", got, link)
+	}
+
+	parts := strings.Split(link.Token, ".")
+	invalid := map[string]string{
+		"empty":             "",
+		"malformed":         "not-a-token",
+		"forged signature":  parts[0] + "." + parts[1] + ".c2lnbmF0dXJl",
+		"extended expiry":   parts[0] + ".4102444800." + parts[2],
+		"other link":        "2." + parts[1] + "." + parts[2],
+		"padded id":         "0" + link.Token,
+		"other secret":      mustCreate(t, New(repo, []byte("fedcba9876543210fedcba9876543210"))).Token,
+		"unknown link":      signToken(testSecret, 42, link.ExpiresAt),
+		"mismatched expiry": signToken(testSecret, link.Id, link.ExpiresAt.Add(-time.Hour)),
+	}
+	for name, token := range invalid {
+		if _, err := s.ResolveToken(context.Background(), token); !stderrors.Is(err, errors.ErrShareLinkIsInvalid) {
+			t.Errorf("ShareLinkService.ResolveToken(%s) error = %v, want %v", name, err, errors.ErrShareLinkIsInvalid)
+		}
+	}
+
+	pinNow(t, link.ExpiresAt)
+	if _, err := s.ResolveToken(context.Background(), link.Token); !stderrors.Is(err, errors.ErrShareLinkIsInvalid) {
+		t.Errorf("ShareLinkService.ResolveToken() of an expired link error = %v, want %v", err, errors.ErrShareLinkIsInvalid)
+	}
+
+	pinNow(t, testNow)
+	if err := s.RevokeShareLinkById(context.Background(), link.Id); err != nil {
+		t.Fatalf("ShareLinkService.RevokeShareLinkById() error = %v", err)
+	}
+	if _, err := s.ResolveToken(context.Background(), link.Token); !stderrors.Is(err, errors.ErrShareLinkIsInvalid) {
+		t.Errorf("ShareLinkService.ResolveToken() of a revoked link error = %v, want %v", err, errors.ErrShareLinkIsInvalid)
+	}
+}
+
+func TestShareLinkServiceGetShareLinksBySheetId(t *testing.T) {
+	repo := newMockRepository()
+	s := New(repo, testSecret)
+	pinNow(t, testNow)
+	first := mustCreate(t, s)
+	second := mustCreate(t, s)
+	if err := s.RevokeShareLinkById(context.Background(), first.Id); err != nil {
+		t.Fatalf("ShareLinkService.RevokeShareLinkById() error = %v", err)
+	}
+
+	got, err := s.GetShareLinksBySheetId(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("ShareLinkService.GetShareLinksBySheetId() error = %v", err)
+	}
+	if len(got) != 2 || got[0].IsActive(testNow) || !got[1].IsActive(testNow) || got[1].Token != second.Token {
+		t.Errorf("ShareLinkService.GetShareLinksBySheetId() = %+v, want revoked link %d and active link %d", got, first.Id, second.Id)
+	}
+
+	if err := s.RevokeShareLinkById(context.Background(), 42); !stderrors.Is(err, errors.ErrShareLinkDoesNotExist) {
+		t.Errorf("ShareLinkService.RevokeShareLinkById() error = %v, want %v", err, errors.ErrShareLinkDoesNotExist)
+	}
+}
+
+func mustCreate(t *testing.T, s *ShareLinkService) *ShareLink {
+	t.Helper()
+	link, err := s.CreateShareLink(context.Background(), 3, nil)
+	if err != nil {
+		t.Fatalf("ShareLinkService.CreateShareLink() error = %v", err)
+	}
+	return link
+}
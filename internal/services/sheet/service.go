@@ -3,6 +3,7 @@ package sheet
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/lescactus/espressoapi-go/internal/errors"
@@ -26,6 +27,9 @@ type Sheet struct {
 	// The name for the sheet
 	Name string `json:"name"`
 
+	// Whether the sheet is a template new sheets can start from
+	IsTemplate bool `json:"is_template"`
+
 	// The creation date of the sheet
 	CreatedAt *time.Time `json:"created_at"`
 
@@ -44,6 +48,7 @@ func SQLToSheet(sheet *sql.Sheet) *Sheet {
 	s := new(Sheet)
 	s.Id = sheet.Id
 	s.Name = sheet.Name
+	s.IsTemplate = sheet.IsTemplate
 	s.CreatedAt = sheet.CreatedAt
 	s.UpdatedAt = sheet.UpdatedAt
 
@@ -62,6 +67,7 @@ func SheetToSQL(sheet *Sheet) *sql.Sheet {
 
 	sqlSheet.Id = sheet.Id
 	sqlSheet.Name = sheet.Name
+	sqlSheet.IsTemplate = sheet.IsTemplate
 	sqlSheet.CreatedAt = sheet.CreatedAt
 	sqlSheet.UpdatedAt = sheet.UpdatedAt
 
@@ -69,11 +75,13 @@ func SheetToSQL(sheet *Sheet) *sql.Sheet {
 }
 
 type Service interface {
+	CreateSheet(ctx context.Context, sheet *Sheet) (*Sheet, error)
 	CreateSheetByName(ctx context.Context, name string) (*Sheet, error)
 	GetSheetById(ctx context.Context, id int) (*Sheet, error)
 	GetAllSheets(ctx context.Context) ([]Sheet, error)
 	UpdateSheetById(ctx context.Context, id int, sheet *Sheet) (*Sheet, error)
 	DeleteSheetById(ctx context.Context, id int) error
+	CloneSheet(ctx context.Context, id int, name string, copyBestShot bool) (*Sheet, error)
 	Ping(ctx context.Context) error
 }
 
@@ -87,17 +95,18 @@ func New(repo repository.SheetRepository) *SheetService {
 	return &SheetService{repository: repo}
 }
 
-func (s *SheetService) CreateSheetByName(ctx context.Context, name string) (*Sheet, error) {
-	if name == "" {
+// CreateSheet creates a sheet with the name and template flag of sheet.
+func (s *SheetService) CreateSheet(ctx context.Context, sheet *Sheet) (*Sheet, error) {
+	if sheet.Name == "" {
 		err := errors.ErrSheetNameIsEmpty
 		msg := "could not create sheet"
 		zerolog.Ctx(ctx).Err(err).Msg(msg)
 		return nil, fmt.Errorf("%s: %w", msg, err)
 	}
 
-	sheet := sql.Sheet{Name: name}
+	sqlSheet := sql.Sheet{Name: sheet.Name, IsTemplate: sheet.IsTemplate}
 
-	err := s.repository.CreateSheet(ctx, &sheet)
+	err := s.repository.CreateSheet(ctx, &sqlSheet)
 	if err != nil {
 		msg := "could not create sheet"
 		zerolog.Ctx(ctx).Err(err).Msg(msg)
@@ -105,7 +114,11 @@ func (s *SheetService) CreateSheetByName(ctx context.Context, name string) (*She
 	}
 
 	// Will return the full Sheet as it exists in the DB instead of just the name
-	return s.getSheetByName(ctx, name)
+	return s.getSheetByName(ctx, sheet.Name)
+}
+
+func (s *SheetService) CreateSheetByName(ctx context.Context, name string) (*Sheet, error) {
+	return s.CreateSheet(ctx, &Sheet{Name: name})
 }
 
 func (s *SheetService) GetSheetById(ctx context.Context, id int) (*Sheet, error) {
@@ -171,6 +184,37 @@ func (s *SheetService) DeleteSheetById(ctx context.Context, id int) error {
 	return nil
 }
 
+// CloneSheet creates a sheet named name from the sheet id, to dial in a new
+// bag the same way. With copyBestShot, the best shot of the sheet is copied
+// into the new sheet as its starting point. The new sheet is not a template.
+// The name is trimmed of its surrounding spaces, as sheets created from a
+// form are.
+func (s *SheetService) CloneSheet(ctx context.Context, id int, name string, copyBestShot bool) (*Sheet, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		err := errors.ErrSheetNameIsEmpty
+		msg := "could not clone sheet"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	cloneId, err := s.repository.CloneSheet(ctx, id, name, copyBestShot)
+	if err != nil {
+		msg := "could not clone sheet"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	clone, err := s.GetSheetById(ctx, cloneId)
+	if err != nil {
+		msg := "could not get cloned sheet"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return clone, nil
+}
+
 func (s *SheetService) Ping(ctx context.Context) error {
 	if err := s.repository.Ping(ctx); err != nil {
 		msg := "could not ping database"
@@ -2,6 +2,7 @@ package sheet
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -90,6 +91,21 @@ func (m *MockSheetRepository) DeleteSheetById(ctx context.Context, id int) error
 	return nil
 }
 
+func (m *MockSheetRepository) CloneSheet(ctx context.Context, id int, name string, copyBestShot bool) (int, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return 0, fmt.Errorf("mock error")
+	}
+
+	switch {
+	case name == "duplicatesheet":
+		return 0, errors.ErrSheetAlreadyExists
+	case id == 1:
+		return 1, nil
+	default:
+		return 0, errors.ErrSheetDoesNotExist
+	}
+}
+
 func (m *MockSheetRepository) Ping(ctx context.Context) error {
 	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
 		return fmt.Errorf("mock error")
@@ -235,6 +251,37 @@ func TestSheetCreateSheetByName(t *testing.T) {
 	}
 }
 
+// creatingSheetRepository records the sheet created through it.
+type creatingSheetRepository struct {
+	MockSheetRepository
+	created *sql.Sheet
+}
+
+func (m *creatingSheetRepository) CreateSheet(ctx context.Context, sheet *sql.Sheet) error {
+	m.created = sheet
+	return nil
+}
+
+func TestSheetCreateSheet(t *testing.T) {
+	repo := &creatingSheetRepository{}
+	s := &SheetService{repository: repo}
+
+	got, err := s.CreateSheet(context.TODO(), &Sheet{Name: "template", IsTemplate: true})
+	if err != nil {
+		t.Fatalf("Sheet.CreateSheet() error = %v", err)
+	}
+	if repo.created == nil || repo.created.Name != "template" || !repo.created.IsTemplate {
+		t.Errorf("SheetRepository.CreateSheet() got %+v, want the template sheet", repo.created)
+	}
+	if got == nil || got.Id != 1 {
+		t.Errorf("Sheet.CreateSheet() = %v, want the sheet as stored", got)
+	}
+
+	if _, err := s.CreateSheet(context.TODO(), &Sheet{IsTemplate: true}); !stderrors.Is(err, errors.ErrSheetNameIsEmpty) {
+		t.Errorf("Sheet.CreateSheet() error = %v, want %v", err, errors.ErrSheetNameIsEmpty)
+	}
+}
+
 func TestSheetGetSheetById(t *testing.T) {
 	type fields struct {
 		repository repository.SheetRepository
@@ -496,6 +543,77 @@ func TestSheetServiceDeleteSheetById(t *testing.T) {
 	}
 }
 
+func TestSheetServiceCloneSheet(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		id      int
+		newName string
+		want    *Sheet
+		wantErr error
+	}{
+		{
+			name:    "Sheet found - no error",
+			ctx:     context.WithValue(context.Background(), IsErrorCtxKey("isError"), false),
+			id:      1,
+			newName: "newbag",
+			want:    &Sheet{Id: 1, Name: "sheet01name", CreatedAt: &now, UpdatedAt: &now},
+		},
+		{
+			name:    "Empty name",
+			ctx:     context.Background(),
+			id:      1,
+			wantErr: errors.ErrSheetNameIsEmpty,
+		},
+		{
+			name:    "Blank name",
+			ctx:     context.Background(),
+			id:      1,
+			newName: "   ",
+			wantErr: errors.ErrSheetNameIsEmpty,
+		},
+		{
+			name:    "Sheet already exists",
+			ctx:     context.Background(),
+			id:      1,
+			newName: "duplicatesheet",
+			wantErr: errors.ErrSheetAlreadyExists,
+		},
+		{
+			name:    "Name is trimmed",
+			ctx:     context.Background(),
+			id:      1,
+			newName: "  duplicatesheet ",
+			wantErr: errors.ErrSheetAlreadyExists,
+		},
+		{
+			name:    "Sheet not found",
+			ctx:     context.Background(),
+			id:      2,
+			newName: "newbag",
+			wantErr: errors.ErrSheetDoesNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SheetService{repository: &MockSheetRepository{}}
+			got, err := s.CloneSheet(tt.ctx, tt.id, tt.newName, true)
+			if tt.wantErr != nil {
+				if !stderrors.Is(err, tt.wantErr) {
+					t.Fatalf("SheetService.CloneSheet() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SheetService.CloneSheet() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SheetService.CloneSheet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSheetServicePing(t *testing.T) {
 	type fields struct {
 		repository repository.SheetRepository
@@ -546,16 +664,18 @@ func TestSQLToSheet(t *testing.T) {
 		{
 			name: "Non nil",
 			args: args{&sql.Sheet{
-				Id:        1,
-				Name:      "sheet01",
-				CreatedAt: &now,
-				UpdatedAt: &now,
+				Id:         1,
+				Name:       "sheet01",
+				IsTemplate: true,
+				CreatedAt:  &now,
+				UpdatedAt:  &now,
 			}},
 			want: &Sheet{
-				Id:        1,
-				Name:      "sheet01",
-				CreatedAt: &now,
-				UpdatedAt: &now,
+				Id:         1,
+				Name:       "sheet01",
+				IsTemplate: true,
+				CreatedAt:  &now,
+				UpdatedAt:  &now,
 			},
 		},
 		{
@@ -585,16 +705,18 @@ func TestSheetToSQL(t *testing.T) {
 		{
 			name: "Non nil",
 			args: args{&Sheet{
-				Id:        1,
-				Name:      "sheet01",
-				CreatedAt: &now,
-				UpdatedAt: &now,
+				Id:         1,
+				Name:       "sheet01",
+				IsTemplate: true,
+				CreatedAt:  &now,
+				UpdatedAt:  &now,
 			}},
 			want: &sql.Sheet{
-				Id:        1,
-				Name:      "sheet01",
-				CreatedAt: &now,
-				UpdatedAt: &now,
+				Id:         1,
+				Name:       "sheet01",
+				IsTemplate: true,
+				CreatedAt:  &now,
+				UpdatedAt:  &now,
 			},
 		},
 		{
@@ -0,0 +1,164 @@
+package shot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/rs/zerolog"
+)
+
+// MaxComparedShots is the maximum number of shots compared at once, as many
+// as fit side by side on a page.
+const MaxComparedShots = 6
+
+// Comparison is shots set side by side, the first one being the reference the
+// others are compared with.
+//
+// swagger:model
+type Comparison struct {
+	// The compared shots, in the requested order
+	Shots []Shot `json:"shots"`
+
+	// The differences of every shot with the first one, in the same order
+	Deltas []Delta `json:"deltas"`
+
+	// The parameters which are not the same for every shot, by their field
+	// name
+	Differences []string `json:"differences"`
+}
+
+// Delta is how much a shot differs from the reference shot of a comparison:
+// its value minus the value of the reference.
+//
+// swagger:model
+type Delta struct {
+	// The id of the shot
+	ShotId int `json:"shot_id"`
+
+	// The difference of grind setting
+	GrindSetting int `json:"grind_setting"`
+
+	// The difference of dose, in grams
+	QuantityIn float64 `json:"quantity_in"`
+
+	// The difference of yield, in grams
+	QuantityOut float64 `json:"quantity_out"`
+
+	// The difference of shot time
+	ShotTime time.Duration `json:"shot_time"`
+
+	// The difference of water temperature, in degrees
+	WaterTemperature float64 `json:"water_temperature"`
+
+	// The difference of rating
+	Rating float64 `json:"rating"`
+}
+
+// comparedParameters are the parameters checked for differences, by their
+// field name.
+var comparedParameters = []struct {
+	name  string
+	value func(Shot) any
+}{
+	{"sheet", func(s Shot) any {
+		if s.Sheet == nil {
+			return 0
+		}
+		return s.Sheet.Id
+	}},
+	{"beans", func(s Shot) any {
+		if s.Beans == nil {
+			return 0
+		}
+		return s.Beans.Id
+	}},
+	{"grind_setting", func(s Shot) any { return s.GrindSetting }},
+	{"quantity_in", func(s Shot) any { return s.QuantityIn }},
+	{"quantity_out", func(s Shot) any { return s.QuantityOut }},
+	{"shot_time", func(s Shot) any { return s.ShotTime }},
+	{"water_temperature", func(s Shot) any { return s.WaterTemperature }},
+	{"rating", func(s Shot) any { return s.Rating }},
+	{"is_too_bitter", func(s Shot) any { return s.IsTooBitter }},
+	{"is_too_sour", func(s Shot) any { return s.IsTooSour }},
+	{"comparison_with_previous_result", func(s Shot) any { return s.ComparisonWithPreviousResult }},
+	{"additional_notes", func(s Shot) any { return s.AdditionalNotes }},
+}
+
+// ParseShotIds parses a comma separated list of shot ids to compare. It
+// returns ErrShotCompareIdsAreInvalid unless there are between 2 and
+// MaxComparedShots distinct positive ids.
+func ParseShotIds(list string) ([]int, error) {
+	var ids []int
+	seen := make(map[int]bool)
+	for _, field := range strings.Split(list, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || id <= 0 || seen[id] {
+			return nil, errors.ErrShotCompareIdsAreInvalid
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) < 2 || len(ids) > MaxComparedShots {
+		return nil, errors.ErrShotCompareIdsAreInvalid
+	}
+	return ids, nil
+}
+
+// CompareShots gets the shots ids and compares them with the first one.
+func (s *ShotService) CompareShots(ctx context.Context, ids []int) (*Comparison, error) {
+	shots := make([]Shot, len(ids))
+	for i, id := range ids {
+		shot, err := s.GetShotById(ctx, id)
+		if err != nil {
+			msg := "could not get shot to compare"
+			zerolog.Ctx(ctx).Err(err).Msg(msg)
+			return nil, fmt.Errorf("%s: %w", msg, err)
+		}
+		shots[i] = *shot
+	}
+
+	return Compare(shots), nil
+}
+
+// Compare sets shots side by side, computing the deltas of every shot with
+// the first one and which parameters differ between them.
+func Compare(shots []Shot) *Comparison {
+	c := &Comparison{Shots: shots, Deltas: make([]Delta, len(shots)), Differences: []string{}}
+	if len(shots) == 0 {
+		return c
+	}
+
+	ref := shots[0]
+	for i, s := range shots {
+		c.Deltas[i] = Delta{
+			ShotId:           s.Id,
+			GrindSetting:     s.GrindSetting - ref.GrindSetting,
+			QuantityIn:       roundDelta(s.QuantityIn - ref.QuantityIn),
+			QuantityOut:      roundDelta(s.QuantityOut - ref.QuantityOut),
+			ShotTime:         s.ShotTime - ref.ShotTime,
+			WaterTemperature: roundDelta(s.WaterTemperature - ref.WaterTemperature),
+			Rating:           roundDelta(s.Rating - ref.Rating),
+		}
+	}
+
+	for _, p := range comparedParameters {
+		for _, s := range shots[1:] {
+			if p.value(s) != p.value(ref) {
+				c.Differences = append(c.Differences, p.name)
+				break
+			}
+		}
+	}
+	return c
+}
+
+// roundDelta drops the float noise of a subtraction, the compared values
+// having at most two decimals.
+func roundDelta(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,93 @@
+package shot
+
+import (
+	"context"
+	stderrors "errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+)
+
+func TestParseShotIds(t *testing.T) {
+	tests := []struct {
+		name    string
+		list    string
+		want    []int
+		wantErr bool
+	}{
+		{name: "two ids", list: "3,1", want: []int{3, 1}},
+		{name: "spaces", list: " 1, 2 ,3", want: []int{1, 2, 3}},
+		{name: "as many ids as fit", list: "1,2,3,4,5,6", want: []int{1, 2, 3, 4, 5, 6}},
+		{name: "single id", list: "1", wantErr: true},
+		{name: "too many ids", list: "1,2,3,4,5,6,7", wantErr: true},
+		{name: "duplicate id", list: "1,2,1", wantErr: true},
+		{name: "not a number", list: "1,abc", wantErr: true},
+		{name: "not positive", list: "0,1", wantErr: true},
+		{name: "empty", list: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseShotIds(tt.list)
+			if tt.wantErr {
+				if !stderrors.Is(err, errors.ErrShotCompareIdsAreInvalid) {
+					t.Fatalf("ParseShotIds(%q) error = %v, want %v", tt.list, err, errors.ErrShotCompareIdsAreInvalid)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseShotIds(%q) = %v, %v, want %v", tt.list, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	reference := Shot{
+		Id: 4, Sheet: &sheet.Sheet{Id: 1}, Beans: &bean.Bean{Id: 2}, GrindSetting: 12, QuantityIn: 18, QuantityOut: 36.4,
+		ShotTime: 28 * time.Second, WaterTemperature: 93, Rating: 8, AdditionalNotes: "Sweet",
+	}
+	attempt := reference
+	attempt.Id, attempt.GrindSetting, attempt.QuantityIn, attempt.QuantityOut = 9, 10, 18.2, 38
+	attempt.ShotTime, attempt.Rating, attempt.IsTooBitter = 33500*time.Millisecond, 6.5, true
+
+	got := Compare([]Shot{reference, attempt})
+
+	wantDeltas := []Delta{
+		{ShotId: 4},
+		{ShotId: 9, GrindSetting: -2, QuantityIn: 0.2, QuantityOut: 1.6, ShotTime: 5500 * time.Millisecond, Rating: -1.5},
+	}
+	if !reflect.DeepEqual(got.Deltas, wantDeltas) {
+		t.Errorf("Compare() deltas = %+v, want %+v", got.Deltas, wantDeltas)
+	}
+	wantDifferences := []string{"grind_setting", "quantity_in", "quantity_out", "shot_time", "rating", "is_too_bitter"}
+	if !reflect.DeepEqual(got.Differences, wantDifferences) {
+		t.Errorf("Compare() differences = %v, want %v", got.Differences, wantDifferences)
+	}
+	if len(got.Shots) != 2 || got.Shots[0].Id != 4 {
+		t.Errorf("Compare() shots = %+v, want the shots in order", got.Shots)
+	}
+}
+
+func TestShotServiceCompareShots(t *testing.T) {
+	s := New(&MockShotRepository{})
+
+	got, err := s.CompareShots(context.Background(), []int{3, 1})
+	if err != nil {
+		t.Fatalf("CompareShots() error = %v", err)
+	}
+	if len(got.Shots) != 2 || got.Shots[0].Id != 3 || got.Shots[1].Id != 1 {
+		t.Errorf("CompareShots() shots = %+v, want shots 3 and 1", got.Shots)
+	}
+	if !reflect.DeepEqual(got.Differences, []string{"water_temperature"}) {
+		t.Errorf("CompareShots() differences = %v, want the water temperature", got.Differences)
+	}
+
+	if _, err := s.CompareShots(context.Background(), []int{1, 2}); !stderrors.Is(err, errors.ErrShotDoesNotExist) {
+		t.Errorf("CompareShots() error = %v, want %v", err, errors.ErrShotDoesNotExist)
+	}
+}
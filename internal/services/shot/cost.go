@@ -0,0 +1,14 @@
+package shot
+
+import "math"
+
+// CostPerShot returns the cost of pulling a shot with quantityIn grams of
+// coffee from a bag of bagWeight grams bought at price, rounded to the cent.
+// It returns false when the beans have no price or bag weight recorded, so
+// an unknown cost is never mistaken for a free shot.
+func CostPerShot(price, bagWeight, quantityIn float64) (float64, bool) {
+	if price <= 0 || bagWeight <= 0 {
+		return 0, false
+	}
+	return math.Round(price/bagWeight*quantityIn*100) / 100, true
+}
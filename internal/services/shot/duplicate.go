@@ -0,0 +1,126 @@
+package shot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sqlshot "github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+	"github.com/rs/zerolog"
+)
+
+// Overrides are the parameters of a duplicated shot which are not copied
+// from the shot it is pulled again from. Nil fields are left as duplicated.
+type Overrides struct {
+	SheetId                      *int
+	BeansId                      *int
+	GrindSetting                 *int
+	QuantityIn                   *float64
+	QuantityOut                  *float64
+	ShotTime                     *time.Duration
+	WaterTemperature             *float64
+	Rating                       *float64
+	IsTooBitter                  *bool
+	IsTooSour                    *bool
+	ComparisonWithPreviousResult *sqlshot.ComparisonWithPreviousResult
+	AdditionalNotes              *string
+}
+
+// Again returns a new shot pulled like from: with the same sheet, beans,
+// grind setting, quantities, shot time and water temperature. Its result is
+// left to be tasted: it is not rated, neither too bitter nor too sour, and
+// its comparison with the previous result is unknown.
+func Again(from Shot) *Shot {
+	s := &Shot{
+		GrindSetting:                 from.GrindSetting,
+		QuantityIn:                   from.QuantityIn,
+		QuantityOut:                  from.QuantityOut,
+		ShotTime:                     from.ShotTime,
+		WaterTemperature:             from.WaterTemperature,
+		ComparisonWithPreviousResult: sqlshot.Unknown,
+	}
+	if from.Sheet != nil {
+		s.Sheet = &sheet.Sheet{Id: from.Sheet.Id}
+	}
+	if from.Beans != nil {
+		s.Beans = &bean.Bean{Id: from.Beans.Id}
+	}
+	return s
+}
+
+// Apply sets the overridden parameters of s.
+func (o Overrides) Apply(s *Shot) {
+	if o.SheetId != nil {
+		s.Sheet = &sheet.Sheet{Id: *o.SheetId}
+	}
+	if o.BeansId != nil {
+		s.Beans = &bean.Bean{Id: *o.BeansId}
+	}
+	if o.GrindSetting != nil {
+		s.GrindSetting = *o.GrindSetting
+	}
+	if o.QuantityIn != nil {
+		s.QuantityIn = *o.QuantityIn
+	}
+	if o.QuantityOut != nil {
+		s.QuantityOut = *o.QuantityOut
+	}
+	if o.ShotTime != nil {
+		s.ShotTime = *o.ShotTime
+	}
+	if o.WaterTemperature != nil {
+		s.WaterTemperature = *o.WaterTemperature
+	}
+	if o.Rating != nil {
+		s.Rating = *o.Rating
+	}
+	if o.IsTooBitter != nil {
+		s.IsTooBitter = *o.IsTooBitter
+	}
+	if o.IsTooSour != nil {
+		s.IsTooSour = *o.IsTooSour
+	}
+	if o.ComparisonWithPreviousResult != nil {
+		s.ComparisonWithPreviousResult = *o.ComparisonWithPreviousResult
+	}
+	if o.AdditionalNotes != nil {
+		s.AdditionalNotes = *o.AdditionalNotes
+	}
+}
+
+// Latest returns the latest pulled of shots, the one with the highest id
+// among the latest created, or nil without shots.
+func Latest(shots []Shot) *Shot {
+	var latest *Shot
+	for i, s := range shots {
+		if latest == nil || createdAfter(s, *latest) {
+			latest = &shots[i]
+		}
+	}
+	return latest
+}
+
+func createdAfter(a, b Shot) bool {
+	if a.CreatedAt != nil && b.CreatedAt != nil && !a.CreatedAt.Equal(*b.CreatedAt) {
+		return a.CreatedAt.After(*b.CreatedAt)
+	}
+	return a.Id > b.Id
+}
+
+// DuplicateShot creates a new shot pulled again from the shot id (see
+// Again), with the given overrides. It is validated like any created shot.
+func (s *ShotService) DuplicateShot(ctx context.Context, id int, overrides Overrides) (*Shot, error) {
+	from, err := s.GetShotById(ctx, id)
+	if err != nil {
+		msg := "could not get shot to duplicate"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	shot := Again(*from)
+	overrides.Apply(shot)
+
+	return s.CreateShot(ctx, shot)
+}
@@ -0,0 +1,98 @@
+package shot
+
+import (
+	"context"
+	stderrors "errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	sqlshot "github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+)
+
+func TestAgain(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+	from := Shot{
+		Id:                           7,
+		Sheet:                        &sheet.Sheet{Id: 1, Name: "Morning"},
+		Beans:                        &bean.Bean{Id: 2, Name: "Ethiopia"},
+		GrindSetting:                 12,
+		QuantityIn:                   18,
+		QuantityOut:                  36,
+		ShotTime:                     28 * time.Second,
+		WaterTemperature:             94,
+		Rating:                       8.5,
+		IsTooBitter:                  true,
+		ComparisonWithPreviousResult: sqlshot.Better,
+		AdditionalNotes:              "great shot",
+		CreatedAt:                    &created,
+	}
+
+	want := &Shot{
+		Sheet:                        &sheet.Sheet{Id: 1},
+		Beans:                        &bean.Bean{Id: 2},
+		GrindSetting:                 12,
+		QuantityIn:                   18,
+		QuantityOut:                  36,
+		ShotTime:                     28 * time.Second,
+		WaterTemperature:             94,
+		ComparisonWithPreviousResult: sqlshot.Unknown,
+	}
+	if got := Again(from); !reflect.DeepEqual(got, want) {
+		t.Errorf("Again() = %+v, want %+v", got, want)
+	}
+}
+
+func TestOverridesApply(t *testing.T) {
+	grind, rating, notes := 10, 9.0, "sweeter"
+	s := &Shot{Sheet: &sheet.Sheet{Id: 1}, Beans: &bean.Bean{Id: 2}, GrindSetting: 12, QuantityIn: 18}
+
+	Overrides{GrindSetting: &grind, Rating: &rating, AdditionalNotes: &notes}.Apply(s)
+
+	want := &Shot{Sheet: &sheet.Sheet{Id: 1}, Beans: &bean.Bean{Id: 2}, GrindSetting: 10, QuantityIn: 18, Rating: 9, AdditionalNotes: "sweeter"}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("Apply() = %+v, want %+v", s, want)
+	}
+}
+
+func TestLatest(t *testing.T) {
+	early := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := early.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		shots  []Shot
+		wantId int
+	}{
+		{name: "latest created", shots: []Shot{{Id: 5, CreatedAt: &late}, {Id: 9, CreatedAt: &early}}, wantId: 5},
+		{name: "highest id among the latest created", shots: []Shot{{Id: 4, CreatedAt: &late}, {Id: 6, CreatedAt: &late}, {Id: 9, CreatedAt: &early}}, wantId: 6},
+		{name: "highest id without creation times", shots: []Shot{{Id: 3}, {Id: 2}}, wantId: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Latest(tt.shots); got == nil || got.Id != tt.wantId {
+				t.Errorf("Latest() = %+v, want shot %d", got, tt.wantId)
+			}
+		})
+	}
+
+	if got := Latest(nil); got != nil {
+		t.Errorf("Latest(nil) = %+v, want nil", got)
+	}
+}
+
+func TestShotServiceDuplicateShot(t *testing.T) {
+	s := New(&MockShotRepository{})
+
+	if _, err := s.DuplicateShot(context.Background(), 2, Overrides{}); !stderrors.Is(err, errors.ErrShotDoesNotExist) {
+		t.Errorf("DuplicateShot() error = %v, want %v", err, errors.ErrShotDoesNotExist)
+	}
+
+	rating := 11.0
+	if _, err := s.DuplicateShot(context.Background(), 3, Overrides{Rating: &rating}); !stderrors.Is(err, errors.ErrShotRatingOutOfRange) {
+		t.Errorf("DuplicateShot() error = %v, want %v", err, errors.ErrShotRatingOutOfRange)
+	}
+}
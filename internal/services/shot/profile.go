@@ -0,0 +1,136 @@
+package shot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	sqlshot "github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/rs/zerolog"
+)
+
+// MaxProfileSamples is the maximum number of samples of a shot profile: over
+// 16 minutes of telemetry at 10 samples per second.
+const MaxProfileSamples = 10000
+
+// Profile is the telemetry recorded by the machine during a shot, such as
+// exported by Decent, Gaggiuino or flow-profiling levers.
+//
+// swagger:model
+type Profile struct {
+	// The id of the shot the profile belongs to
+	ShotId int `json:"shot_id"`
+
+	// The samples of the profile, ordered by time
+	Samples []ProfileSample `json:"samples"`
+}
+
+// ProfileSample is one reading of a shot profile. The readings the machine
+// does not measure are null.
+//
+// swagger:model
+type ProfileSample struct {
+	// The time of the sample, in seconds since the start of the shot, with a
+	// millisecond precision
+	Time float64 `json:"t"`
+
+	// The pressure, in bar
+	Pressure *float64 `json:"pressure"`
+
+	// The flow, in milliliters per second
+	Flow *float64 `json:"flow"`
+
+	// The weight in the cup, in grams
+	Weight *float64 `json:"weight"`
+
+	// The temperature, in degrees
+	Temperature *float64 `json:"temperature"`
+}
+
+// SQLToProfile converts the samples of the shot id to a Profile.
+func SQLToProfile(id int, samples []sqlshot.ShotProfileSample) *Profile {
+	p := &Profile{ShotId: id, Samples: make([]ProfileSample, len(samples))}
+	for i, s := range samples {
+		p.Samples[i] = ProfileSample{
+			Time:        float64(s.ElapsedTime) / 1000,
+			Pressure:    s.Pressure,
+			Flow:        s.Flow,
+			Weight:      s.Weight,
+			Temperature: s.Temperature,
+		}
+	}
+	return p
+}
+
+// ProfileToSQL converts the samples of a Profile to their SQL representation.
+func ProfileToSQL(p *Profile) []sqlshot.ShotProfileSample {
+	samples := make([]sqlshot.ShotProfileSample, len(p.Samples))
+	for i, s := range p.Samples {
+		samples[i] = sqlshot.ShotProfileSample{
+			ShotId:      p.ShotId,
+			ElapsedTime: int(SecondsToDuration(s.Time).Milliseconds()),
+			Pressure:    s.Pressure,
+			Flow:        s.Flow,
+			Weight:      s.Weight,
+			Temperature: s.Temperature,
+		}
+	}
+	return samples
+}
+
+func (s *ShotService) GetShotProfileById(ctx context.Context, id int) (*Profile, error) {
+	samples, err := s.repository.GetShotProfileById(ctx, id)
+	if err != nil {
+		msg := "could not get shot profile by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToProfile(id, samples), nil
+}
+
+// UpdateShotProfileById replaces the profile of the shot id. A profile without
+// samples removes the profile of the shot.
+func (s *ShotService) UpdateShotProfileById(ctx context.Context, id int, profile *Profile) (*Profile, error) {
+	if profile == nil {
+		profile = &Profile{}
+	}
+	profile.ShotId = id
+	if err := validateProfile(profile); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.UpdateShotProfileById(ctx, id, ProfileToSQL(profile)); err != nil {
+		msg := "could not update shot profile by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return s.GetShotProfileById(ctx, id)
+}
+
+// validateProfile rejects a profile with too many samples, samples out of
+// the shot time range or sharing the same millisecond, and negative
+// readings. The samples are sorted by time in place.
+func validateProfile(p *Profile) error {
+	if len(p.Samples) > MaxProfileSamples {
+		return errors.ErrShotProfileHasTooManySamples
+	}
+
+	sort.SliceStable(p.Samples, func(i, j int) bool {
+		return p.Samples[i].Time < p.Samples[j].Time
+	})
+	for i, sample := range p.Samples {
+		t := SecondsToDuration(sample.Time)
+		if t < 0 || t > MaxShotTime || (i > 0 && t == SecondsToDuration(p.Samples[i-1].Time)) {
+			return errors.ErrShotProfileIsInvalid
+		}
+		for _, v := range []*float64{sample.Pressure, sample.Flow, sample.Weight, sample.Temperature} {
+			if v != nil && *v < 0 {
+				return errors.ErrShotProfileIsInvalid
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package shot
+
+import (
+	"context"
+	stderrors "errors"
+	"reflect"
+	"testing"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+)
+
+func reading(v float64) *float64 { return &v }
+
+func TestShotServiceUpdateShotProfileById(t *testing.T) {
+	repo := &MockShotRepository{}
+	s := New(repo)
+
+	got, err := s.UpdateShotProfileById(context.Background(), 1, &Profile{Samples: []ProfileSample{
+		{Time: 0.5, Pressure: reading(8.9), Flow: reading(2.1), Weight: reading(1.4)},
+		{Time: 0, Pressure: reading(0.2), Temperature: reading(93.4)},
+		{Time: 1.2504, Pressure: reading(9), Weight: reading(3.5)},
+	}})
+	if err != nil {
+		t.Fatalf("UpdateShotProfileById() error = %v", err)
+	}
+
+	want := &Profile{ShotId: 1, Samples: []ProfileSample{
+		{Time: 0, Pressure: reading(0.2), Temperature: reading(93.4)},
+		{Time: 0.5, Pressure: reading(8.9), Flow: reading(2.1), Weight: reading(1.4)},
+		{Time: 1.25, Pressure: reading(9), Weight: reading(3.5)},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UpdateShotProfileById() = %+v, want %+v", got, want)
+	}
+	if repo.profile[2].ElapsedTime != 1250 || repo.profile[2].ShotId != 1 {
+		t.Errorf("stored sample = %+v, want shot 1 at 1250ms", repo.profile[2])
+	}
+}
+
+func TestShotServiceUpdateShotProfileById_Errors(t *testing.T) {
+	tooMany := make([]ProfileSample, MaxProfileSamples+1)
+	for i := range tooMany {
+		tooMany[i].Time = float64(i) / 10
+	}
+	tests := []struct {
+		name    string
+		id      int
+		samples []ProfileSample
+		wantErr error
+	}{
+		{name: "duplicate millisecond", id: 1, samples: []ProfileSample{{Time: 1.0001}, {Time: 1}}, wantErr: errors.ErrShotProfileIsInvalid},
+		{name: "negative time", id: 1, samples: []ProfileSample{{Time: -0.5}}, wantErr: errors.ErrShotProfileIsInvalid},
+		{name: "time after the longest shot", id: 1, samples: []ProfileSample{{Time: 3600.5}}, wantErr: errors.ErrShotProfileIsInvalid},
+		{name: "negative reading", id: 1, samples: []ProfileSample{{Time: 1, Flow: reading(-0.1)}}, wantErr: errors.ErrShotProfileIsInvalid},
+		{name: "too many samples", id: 1, samples: tooMany, wantErr: errors.ErrShotProfileHasTooManySamples},
+		{name: "missing shot", id: 2, samples: []ProfileSample{{Time: 1}}, wantErr: errors.ErrShotDoesNotExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&MockShotRepository{})
+
+			_, err := s.UpdateShotProfileById(context.Background(), tt.id, &Profile{Samples: tt.samples})
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Errorf("UpdateShotProfileById() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestShotServiceGetShotProfileById(t *testing.T) {
+	s := New(&MockShotRepository{})
+
+	got, err := s.GetShotProfileById(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetShotProfileById() error = %v", err)
+	}
+	if got.ShotId != 1 || got.Samples == nil || len(got.Samples) != 0 {
+		t.Errorf("GetShotProfileById() = %+v, want an empty profile of shot 1", got)
+	}
+
+	if _, err := s.GetShotProfileById(context.Background(), 2); !stderrors.Is(err, errors.ErrShotDoesNotExist) {
+		t.Errorf("GetShotProfileById() error = %v, want %v", err, errors.ErrShotDoesNotExist)
+	}
+}
@@ -22,6 +22,9 @@ import (
 // The result of a shot can be rated and compared to the previous shot.
 // It can also be too bitter or too sour.
 //
+// Its cost is derived from the price and bag weight of its beans, and is null
+// when the beans have no price recorded.
+//
 // Not a swagger:model: it is never returned directly (rest.ShotResponse
 // carries the wire shape via swagger:allOf), and its ShotTime field would
 // otherwise generate a dead "Duration" (nanosecond int64) definition that
@@ -40,6 +43,7 @@ type Shot struct {
 	IsTooSour                    bool                                 `json:"is_too_sour"`
 	ComparisonWithPreviousResult sqlshot.ComparisonWithPreviousResult `json:"comparison_with_previous_result"`
 	AdditionalNotes              string                               `json:"additional_notes"`
+	CostPerShot                  *float64                             `json:"cost_per_shot"`
 	CreatedAt                    *time.Time                           `json:"created_at"`
 	UpdatedAt                    *time.Time                           `json:"updated_at"`
 }
@@ -71,6 +75,12 @@ func SQLToShot(shot *sqlshot.Shot) *Shot {
 	s.CreatedAt = shot.CreatedAt
 	s.UpdatedAt = shot.UpdatedAt
 
+	if shot.Beans != nil {
+		if cost, ok := CostPerShot(shot.Beans.Price, shot.Beans.BagWeight, shot.QuantityIn); ok {
+			s.CostPerShot = &cost
+		}
+	}
+
 	return s
 }
 
@@ -109,6 +119,10 @@ type Service interface {
 	GetShotsBySheetId(ctx context.Context, sheetId int) ([]Shot, error)
 	UpdateShotById(ctx context.Context, id int, shot *Shot) (*Shot, error)
 	DeleteShotById(ctx context.Context, id int) error
+	GetShotProfileById(ctx context.Context, id int) (*Profile, error)
+	UpdateShotProfileById(ctx context.Context, id int, profile *Profile) (*Profile, error)
+	CompareShots(ctx context.Context, ids []int) (*Comparison, error)
+	DuplicateShot(ctx context.Context, id int, overrides Overrides) (*Shot, error)
 	Ping(ctx context.Context) error
 }
 
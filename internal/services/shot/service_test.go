@@ -21,7 +21,9 @@ var (
 type IsErrorCtxKey string
 type IsEmptyCtxKey string
 
-type MockShotRepository struct{}
+type MockShotRepository struct {
+	profile []sql.ShotProfileSample
+}
 
 func (m *MockShotRepository) CreateShot(ctx context.Context, shot *sql.Shot) (int, error) {
 	switch shot.Id {
@@ -116,6 +118,31 @@ func (m *MockShotRepository) DeleteShotById(ctx context.Context, id int) error {
 	return nil
 }
 
+func (m *MockShotRepository) GetShotProfileById(ctx context.Context, id int) ([]sql.ShotProfileSample, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return nil, fmt.Errorf("mock error")
+	}
+
+	if id == 2 {
+		return nil, errors.ErrShotDoesNotExist
+	}
+
+	return append([]sql.ShotProfileSample{}, m.profile...), nil
+}
+
+func (m *MockShotRepository) UpdateShotProfileById(ctx context.Context, id int, samples []sql.ShotProfileSample) error {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return fmt.Errorf("mock error")
+	}
+
+	if id == 2 {
+		return errors.ErrShotDoesNotExist
+	}
+
+	m.profile = samples
+	return nil
+}
+
 func (m *MockShotRepository) Ping(ctx context.Context) error {
 	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
 		return fmt.Errorf("mock error")
@@ -199,6 +226,20 @@ func TestSQLToShot(t *testing.T) {
 				UpdatedAt:                    nil,
 			},
 		},
+		{
+			name: "Beans with a price",
+			args: args{&sql.Shot{
+				Id:         2,
+				Beans:      &sql.Beans{Id: 1, Name: "beans01", Price: 15, Currency: "EUR", BagWeight: 250},
+				QuantityIn: 18.0,
+			}},
+			want: &Shot{
+				Id:          2,
+				Beans:       &svcbeans.Bean{Id: 1, Name: "beans01", Price: 15, Currency: "EUR", BagWeight: 250},
+				QuantityIn:  18.0,
+				CostPerShot: func() *float64 { c := 1.08; return &c }(),
+			},
+		},
 		{
 			name: "Nil",
 			args: args{nil},
@@ -723,3 +764,27 @@ func TestShotService_Ping(t *testing.T) {
 		})
 	}
 }
+
+func TestCostPerShot(t *testing.T) {
+	tests := []struct {
+		name       string
+		price      float64
+		bagWeight  float64
+		quantityIn float64
+		want       float64
+		wantOk     bool
+	}{
+		{name: "Rounded to the cent", price: 15, bagWeight: 250, quantityIn: 18, want: 1.08, wantOk: true},
+		{name: "Double shot", price: 12.5, bagWeight: 1000, quantityIn: 21, want: 0.26, wantOk: true},
+		{name: "No price", price: 0, bagWeight: 250, quantityIn: 18},
+		{name: "No bag weight", price: 15, bagWeight: 0, quantityIn: 18},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CostPerShot(tt.price, tt.bagWeight, tt.quantityIn)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("CostPerShot() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,399 @@
+package spreadsheet
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+)
+
+// roastDateLayout is the layout of the roast dates, as in the API.
+const roastDateLayout = "2006-01-02"
+
+var (
+	sheetColumns   = []string{"id", "name", "created_at", "updated_at"}
+	roasterColumns = []string{"id", "name", "created_at", "updated_at"}
+	beansColumns   = []string{"id", "name", "roaster_id", "roaster", "roast_date", "roast_level", "green_coffee_id", "green_weight", "price", "currency", "bag_weight", "created_at", "updated_at"}
+	shotColumns    = []string{"id", "sheet_id", "sheet", "beans_id", "beans", "roaster", "grind_setting", "quantity_in", "quantity_out", "shot_time", "water_temperature", "rating", "is_too_bitter", "is_too_sour", "comparison_with_previous_result", "additional_notes", "cost_per_shot", "created_at", "updated_at"}
+)
+
+func (s *SpreadsheetService) sheetRecords(ctx context.Context) ([][]string, error) {
+	sheets, err := s.sheets.GetAllSheets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records := [][]string{sheetColumns}
+	for _, sh := range sheets {
+		records = append(records, []string{
+			strconv.Itoa(sh.Id), text(sh.Name), formatTime(sh.CreatedAt), formatTime(sh.UpdatedAt),
+		})
+	}
+	return records, nil
+}
+
+func (s *SpreadsheetService) roasterRecords(ctx context.Context) ([][]string, error) {
+	roasters, err := s.roasters.GetAllRoasters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records := [][]string{roasterColumns}
+	for _, r := range roasters {
+		records = append(records, []string{
+			strconv.Itoa(r.Id), text(r.Name), formatTime(r.CreatedAt), formatTime(r.UpdatedAt),
+		})
+	}
+	return records, nil
+}
+
+func (s *SpreadsheetService) beansRecords(ctx context.Context) ([][]string, error) {
+	beans, err := s.beans.GetAllBeans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records := [][]string{beansColumns}
+	for _, b := range beans {
+		roasterId, roasterName := "", ""
+		if b.Roaster != nil {
+			roasterId, roasterName = strconv.Itoa(b.Roaster.Id), b.Roaster.Name
+		}
+		roastDate := ""
+		if b.RoastDate != nil {
+			roastDate = b.RoastDate.Format(roastDateLayout)
+		}
+		greenCoffeeId := ""
+		if b.GreenCoffeeId != nil {
+			greenCoffeeId = strconv.Itoa(*b.GreenCoffeeId)
+		}
+		records = append(records, []string{
+			strconv.Itoa(b.Id), text(b.Name), roasterId, text(roasterName), roastDate,
+			strconv.Itoa(int(b.RoastLevel)), greenCoffeeId, formatFloat(b.GreenWeight),
+			formatFloat(b.Price), b.Currency, formatFloat(b.BagWeight),
+			formatTime(b.CreatedAt), formatTime(b.UpdatedAt),
+		})
+	}
+	return records, nil
+}
+
+func (s *SpreadsheetService) shotRecords(ctx context.Context) ([][]string, error) {
+	shots, err := s.shots.GetAllShots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records := [][]string{shotColumns}
+	for _, sh := range shots {
+		sheetId, sheetName := "", ""
+		if sh.Sheet != nil {
+			sheetId, sheetName = strconv.Itoa(sh.Sheet.Id), sh.Sheet.Name
+		}
+		beansId, beansName, roasterName := "", "", ""
+		if sh.Beans != nil {
+			beansId, beansName = strconv.Itoa(sh.Beans.Id), sh.Beans.Name
+			if sh.Beans.Roaster != nil {
+				roasterName = sh.Beans.Roaster.Name
+			}
+		}
+		costPerShot := ""
+		if sh.CostPerShot != nil {
+			costPerShot = formatFloat(*sh.CostPerShot)
+		}
+		records = append(records, []string{
+			strconv.Itoa(sh.Id), sheetId, text(sheetName), beansId, text(beansName), text(roasterName),
+			strconv.Itoa(sh.GrindSetting), formatFloat(sh.QuantityIn), formatFloat(sh.QuantityOut),
+			formatFloat(float64(sh.ShotTime.Milliseconds()) / 1000), formatFloat(sh.WaterTemperature),
+			formatFloat(sh.Rating), strconv.FormatBool(sh.IsTooBitter), strconv.FormatBool(sh.IsTooSour),
+			strconv.Itoa(int(sh.ComparisonWithPreviousResult)), text(sh.AdditionalNotes), costPerShot,
+			formatTime(sh.CreatedAt), formatTime(sh.UpdatedAt),
+		})
+	}
+	return records, nil
+}
+
+// beansCreator returns the creator of beans, looking their roaster up by name
+// among the existing roasters unless the roaster_id column is set.
+func (s *SpreadsheetService) beansCreator(ctx context.Context) (creator, error) {
+	roasters, err := s.roasters.GetAllRoasters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roasterIds := make(map[string]int, len(roasters))
+	for _, r := range roasters {
+		roasterIds[r.Name] = r.Id
+	}
+
+	return func(ctx context.Context, rec record) (int, error) {
+		roasterId, err := rec.reference("roaster", rec.get("roaster"), roasterIds)
+		if err != nil {
+			return 0, err
+		}
+		b := &bean.Bean{Name: rec.get("name"), Roaster: &roaster.Roaster{Id: roasterId}, Currency: rec.get("currency")}
+		if b.RoastDate, err = rec.date("roast_date"); err != nil {
+			return 0, err
+		}
+		roastLevel, err := rec.int("roast_level")
+		if err != nil {
+			return 0, err
+		}
+		if roastLevel < 0 || roastLevel > math.MaxUint8 {
+			return 0, domainerrors.ErrBeansRoastLevelOutOfRange
+		}
+		b.RoastLevel = sql.RoastLevel(roastLevel)
+		if b.GreenCoffeeId, err = rec.optionalInt("green_coffee_id"); err != nil {
+			return 0, err
+		}
+		if b.GreenWeight, err = rec.float("green_weight"); err != nil {
+			return 0, err
+		}
+		if b.Price, err = rec.float("price"); err != nil {
+			return 0, err
+		}
+		if b.BagWeight, err = rec.float("bag_weight"); err != nil {
+			return 0, err
+		}
+
+		created, err := s.beans.CreateBean(ctx, b)
+		if err != nil {
+			return 0, err
+		}
+		return created.Id, nil
+	}, nil
+}
+
+// shotCreator returns the creator of shots, looking their sheet and beans up
+// by name among the existing ones unless the sheet_id and beans_id columns are
+// set. Beans are looked up by name and roaster when the roaster column is set,
+// by name alone otherwise.
+func (s *SpreadsheetService) shotCreator(ctx context.Context) (creator, error) {
+	sheets, err := s.sheets.GetAllSheets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sheetIds := make(map[string]int, len(sheets))
+	for _, sh := range sheets {
+		sheetIds[sh.Name] = sh.Id
+	}
+
+	beans, err := s.beans.GetAllBeans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	beansIds := make(map[string]int, 2*len(beans))
+	for _, b := range beans {
+		roasterName := ""
+		if b.Roaster != nil {
+			roasterName = b.Roaster.Name
+		}
+		beansIds[beansKey(b.Name, roasterName)] = b.Id
+		if _, ok := beansIds[b.Name]; ok {
+			beansIds[b.Name] = ambiguous
+		} else {
+			beansIds[b.Name] = b.Id
+		}
+	}
+
+	return func(ctx context.Context, rec record) (int, error) {
+		sheetId, err := rec.reference("sheet", rec.get("sheet"), sheetIds)
+		if err != nil {
+			return 0, err
+		}
+		key := rec.get("beans")
+		if roasterName := rec.get("roaster"); roasterName != "" {
+			key = beansKey(key, roasterName)
+		}
+		beansId, err := rec.reference("beans", key, beansIds)
+		if err != nil {
+			return 0, err
+		}
+
+		sh := &shot.Shot{
+			Sheet:           &sheet.Sheet{Id: sheetId},
+			Beans:           &bean.Bean{Id: beansId},
+			AdditionalNotes: rec.get("additional_notes"),
+		}
+		if sh.GrindSetting, err = rec.int("grind_setting"); err != nil {
+			return 0, err
+		}
+		if sh.QuantityIn, err = rec.float("quantity_in"); err != nil {
+			return 0, err
+		}
+		if sh.QuantityOut, err = rec.float("quantity_out"); err != nil {
+			return 0, err
+		}
+		shotTime, err := rec.float("shot_time")
+		if err != nil {
+			return 0, err
+		}
+		sh.ShotTime = shot.SecondsToDuration(shotTime)
+		if sh.WaterTemperature, err = rec.float("water_temperature"); err != nil {
+			return 0, err
+		}
+		if sh.Rating, err = rec.float("rating"); err != nil {
+			return 0, err
+		}
+		if sh.IsTooBitter, err = rec.bool("is_too_bitter"); err != nil {
+			return 0, err
+		}
+		if sh.IsTooSour, err = rec.bool("is_too_sour"); err != nil {
+			return 0, err
+		}
+		comparison, err := rec.int("comparison_with_previous_result")
+		if err != nil {
+			return 0, err
+		}
+		if comparison < 0 || comparison > math.MaxUint8 {
+			return 0, domainerrors.ErrShotComparisonWithPreviousResultOutOfRange
+		}
+		sh.ComparisonWithPreviousResult = sql.ComparisonWithPreviousResult(comparison)
+
+		created, err := s.shots.CreateShot(ctx, sh)
+		if err != nil {
+			return 0, err
+		}
+		return created.Id, nil
+	}, nil
+}
+
+// ambiguous is the id of the names shared by several beans, which must be
+// told apart by their roaster.
+const ambiguous = -1
+
+func beansKey(name, roasterName string) string {
+	return name + "\x00" + roasterName
+}
+
+// record is a row of a CSV file, whose fields are looked up by the name of
+// their column.
+type record struct {
+	line    int
+	columns map[string]int
+	fields  []string
+}
+
+// get returns the field of the column, or an empty string when the file has
+// no such column.
+func (r record) get(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.fields) {
+		return ""
+	}
+	return untext(strings.TrimSpace(r.fields[i]))
+}
+
+func (r record) int(column string) (int, error) {
+	value := r.get(column)
+	if value == "" {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s must be an integer", domainerrors.ErrCSVRowIsInvalid, column)
+	}
+	return i, nil
+}
+
+func (r record) optionalInt(column string) (*int, error) {
+	if r.get(column) == "" {
+		return nil, nil
+	}
+	i, err := r.int(column)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+func (r record) float(column string) (float64, error) {
+	value := r.get(column)
+	if value == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%w: %s must be a number", domainerrors.ErrCSVRowIsInvalid, column)
+	}
+	return f, nil
+}
+
+func (r record) bool(column string) (bool, error) {
+	value := r.get(column)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s must be true or false", domainerrors.ErrCSVRowIsInvalid, column)
+	}
+	return b, nil
+}
+
+// date parses the field of the column as a date, or as an RFC 3339 time for
+// the files edited by spreadsheets turning dates into times.
+func (r record) date(column string) (*time.Time, error) {
+	value := r.get(column)
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{roastDateLayout, time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s must be a date such as 2006-01-02", domainerrors.ErrCSVRowIsInvalid, column)
+}
+
+// reference returns the id of the record referenced in the column: the field
+// of its _id column when set, otherwise the id of the record found in ids by
+// key.
+func (r record) reference(column, key string, ids map[string]int) (int, error) {
+	if r.get(column+"_id") != "" {
+		return r.int(column + "_id")
+	}
+	name := r.get(column)
+	if name == "" {
+		return 0, fmt.Errorf("%w: %s_id or %s is required", domainerrors.ErrCSVRowIsInvalid, column, column)
+	}
+	id, ok := ids[key]
+	switch {
+	case !ok:
+		return 0, fmt.Errorf("%w: no %s named %q", domainerrors.ErrCSVRowIsInvalid, column, name)
+	case id == ambiguous:
+		return 0, fmt.Errorf("%w: several %s are named %q, set %s_id", domainerrors.ErrCSVRowIsInvalid, column, name, column)
+	}
+	return id, nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// text escapes a text field starting like a formula with a quote, so that
+// spreadsheets display it instead of evaluating it.
+func text(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// untext reverses text.
+func untext(s string) string {
+	if len(s) > 1 && s[0] == '\'' && strings.ContainsRune("=+-@\t\r", rune(s[1])) {
+		return s[1:]
+	}
+	return s
+}
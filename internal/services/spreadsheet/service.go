@@ -0,0 +1,273 @@
+// Package spreadsheet exports the sheets, roasters, beans and shots as CSV
+// files, one flattened record per row, and imports such files back. Rows are
+// imported through the services creating each record, so that they are
+// validated as if they were created through the API.
+package spreadsheet
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/rs/zerolog"
+)
+
+// Resource is the kind of records of a CSV file.
+type Resource string
+
+const (
+	ResourceSheets   Resource = "sheets"
+	ResourceRoasters Resource = "roasters"
+	ResourceBeans    Resource = "beans"
+	ResourceShots    Resource = "shots"
+)
+
+// Row is the outcome of the import of a row of a CSV file.
+//
+// swagger:model CSVImportRow
+type Row struct {
+	// The line of the row in the file, the header being line 1
+	Line int `json:"line"`
+
+	// The id of the record created from the row
+	Id *int `json:"id,omitempty"`
+
+	// Why the row could not be imported
+	Error string `json:"error,omitempty"`
+}
+
+// Report is the outcome of the import of a CSV file
+//
+// swagger:model CSVImportReport
+type Report struct {
+	// The number of rows imported
+	Imported int `json:"imported"`
+
+	// The number of rows which could not be imported
+	Failed int `json:"failed"`
+
+	// The outcome of every row, in the order of the file
+	Rows []Row `json:"rows"`
+}
+
+// rowErrors are the errors about a row rather than about the database: the
+// row is reported as failed and the import goes on.
+var rowErrors = []error{
+	domainerrors.ErrCSVRowIsInvalid,
+	domainerrors.ErrSheetAlreadyExists,
+	domainerrors.ErrSheetDoesNotExist,
+	domainerrors.ErrSheetNameIsEmpty,
+	domainerrors.ErrRoasterAlreadyExists,
+	domainerrors.ErrRoasterDoesNotExist,
+	domainerrors.ErrRoasterNameIsEmpty,
+	domainerrors.ErrBeansAlreadyExists,
+	domainerrors.ErrBeansDoesNotExist,
+	domainerrors.ErrBeansForeignKeyConstraint,
+	domainerrors.ErrBeansNameIsEmpty,
+	domainerrors.ErrBeansRoastLevelOutOfRange,
+	domainerrors.ErrBeansGreenWeightOutOfRange,
+	domainerrors.ErrBeansPriceOutOfRange,
+	domainerrors.ErrBeansBagWeightOutOfRange,
+	domainerrors.ErrBeansCurrencyIsInvalid,
+	domainerrors.ErrGreenCoffeeDoesNotExist,
+	domainerrors.ErrShotAlreadyExists,
+	domainerrors.ErrShotRatingOutOfRange,
+	domainerrors.ErrShotComparisonWithPreviousResultOutOfRange,
+	domainerrors.ErrShotTimeOutOfRange,
+	domainerrors.ErrShotForeignKeyConstraint,
+}
+
+// add records the outcome of the import of the row at line as the record id.
+// It returns err when it is not about the row but about the database, which
+// stops the import.
+func (r *Report) add(line, id int, err error) error {
+	switch {
+	case err == nil:
+		r.Imported++
+		r.Rows = append(r.Rows, Row{Line: line, Id: &id})
+	case isRowError(err):
+		r.Failed++
+		r.Rows = append(r.Rows, Row{Line: line, Error: err.Error()})
+	default:
+		return err
+	}
+	return nil
+}
+
+func isRowError(err error) bool {
+	for _, target := range rowErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+type Service interface {
+	Export(ctx context.Context, resource Resource, w io.Writer) error
+	Import(ctx context.Context, resource Resource, r io.Reader) (*Report, error)
+}
+
+type SpreadsheetService struct {
+	sheets   sheet.Service
+	roasters roaster.Service
+	beans    bean.Service
+	shots    shot.Service
+}
+
+func New(sheets sheet.Service, roasters roaster.Service, beans bean.Service, shots shot.Service) *SpreadsheetService {
+	return &SpreadsheetService{
+		sheets:   sheets,
+		roasters: roasters,
+		beans:    beans,
+		shots:    shots,
+	}
+}
+
+// Export writes every record of resource to w as CSV, after a header row
+// naming the columns. Nothing is written when the records cannot be read.
+func (s *SpreadsheetService) Export(ctx context.Context, resource Resource, w io.Writer) error {
+	var records [][]string
+	var err error
+	switch resource {
+	case ResourceSheets:
+		records, err = s.sheetRecords(ctx)
+	case ResourceRoasters:
+		records, err = s.roasterRecords(ctx)
+	case ResourceBeans:
+		records, err = s.beansRecords(ctx)
+	case ResourceShots:
+		records, err = s.shotRecords(ctx)
+	default:
+		err = fmt.Errorf("unknown resource %q", resource)
+	}
+	if err != nil {
+		msg := fmt.Sprintf("could not export %s", resource)
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(records); err != nil {
+		msg := fmt.Sprintf("could not write %s", resource)
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// Import creates a record of resource from every row of the CSV file read
+// from r. Columns are matched by the name given in the header row, in any
+// order, and the columns of the export which cannot be set, such as the ids
+// and dates of the records, are ignored. Rows which cannot be imported are
+// reported as failed without stopping the import.
+func (s *SpreadsheetService) Import(ctx context.Context, resource Resource, r io.Reader) (*Report, error) {
+	required := "name"
+	if resource == ResourceShots {
+		required = ""
+	}
+	records, err := readRecords(r, required)
+	if err != nil {
+		msg := fmt.Sprintf("could not read %s", resource)
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	create, err := s.creator(ctx, resource)
+	if err != nil {
+		msg := fmt.Sprintf("could not import %s", resource)
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	report := &Report{Rows: []Row{}}
+	for _, rec := range records {
+		id, err := create(ctx, rec)
+		if err := report.add(rec.line, id, err); err != nil {
+			msg := fmt.Sprintf("could not import %s", resource)
+			zerolog.Ctx(ctx).Err(err).Int("line", rec.line).Msg(msg)
+			return nil, fmt.Errorf("%s: %w", msg, err)
+		}
+	}
+	return report, nil
+}
+
+// creator creates a record from rec and returns its id.
+type creator func(ctx context.Context, rec record) (int, error)
+
+// creator returns the creator of the records of resource.
+func (s *SpreadsheetService) creator(ctx context.Context, resource Resource) (creator, error) {
+	switch resource {
+	case ResourceSheets:
+		return s.createSheet, nil
+	case ResourceRoasters:
+		return s.createRoaster, nil
+	case ResourceBeans:
+		return s.beansCreator(ctx)
+	case ResourceShots:
+		return s.shotCreator(ctx)
+	default:
+		return nil, fmt.Errorf("unknown resource %q", resource)
+	}
+}
+
+// readRecords reads every row of the CSV file read from r before any record
+// is created, so that a malformed file imports nothing. The header row must
+// name the required column, unless empty.
+func readRecords(r io.Reader, required string) ([]record, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainerrors.ErrCSVIsInvalid, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns[required]; required != "" && !ok {
+		return nil, fmt.Errorf("%w: missing %s column", domainerrors.ErrCSVIsInvalid, required)
+	}
+
+	var records []record
+	for {
+		fields, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domainerrors.ErrCSVIsInvalid, err)
+		}
+		line, _ := cr.FieldPos(0)
+		records = append(records, record{line: line, columns: columns, fields: fields})
+	}
+	return records, nil
+}
+
+func (s *SpreadsheetService) createSheet(ctx context.Context, rec record) (int, error) {
+	sheet, err := s.sheets.CreateSheetByName(ctx, rec.get("name"))
+	if err != nil {
+		return 0, err
+	}
+	return sheet.Id, nil
+}
+
+func (s *SpreadsheetService) createRoaster(ctx context.Context, rec record) (int, error) {
+	roaster, err := s.roasters.CreateRoasterByName(ctx, rec.get("name"))
+	if err != nil {
+		return 0, err
+	}
+	return roaster.Id, nil
+}
@@ -0,0 +1,417 @@
+package spreadsheet
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	domainerrors "github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+)
+
+// MockServices is an in-memory store of sheets, roasters, beans and shots
+// implementing their services, with the validation the import relies on.
+type MockServices struct {
+	nextId   int
+	sheets   []sheet.Sheet
+	roasters []roaster.Roaster
+	beans    []bean.Bean
+	shots    []shot.Shot
+	err      error
+}
+
+func (m *MockServices) id() int {
+	m.nextId++
+	return m.nextId
+}
+
+func (m *MockServices) CreateSheet(ctx context.Context, created *sheet.Sheet) (*sheet.Sheet, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if created.Name == "" {
+		return nil, domainerrors.ErrSheetNameIsEmpty
+	}
+	for _, s := range m.sheets {
+		if s.Name == created.Name {
+			return nil, domainerrors.ErrSheetAlreadyExists
+		}
+	}
+	m.sheets = append(m.sheets, sheet.Sheet{Id: m.id(), Name: created.Name, IsTemplate: created.IsTemplate})
+	return &m.sheets[len(m.sheets)-1], nil
+}
+func (m *MockServices) CreateSheetByName(ctx context.Context, name string) (*sheet.Sheet, error) {
+	return m.CreateSheet(ctx, &sheet.Sheet{Name: name})
+}
+
+func (m *MockServices) GetSheetById(ctx context.Context, id int) (*sheet.Sheet, error) {
+	return nil, nil
+}
+
+func (m *MockServices) GetAllSheets(ctx context.Context) ([]sheet.Sheet, error) {
+	return m.sheets, m.err
+}
+
+func (m *MockServices) UpdateSheetById(ctx context.Context, id int, s *sheet.Sheet) (*sheet.Sheet, error) {
+	return nil, nil
+}
+
+func (m *MockServices) DeleteSheetById(ctx context.Context, id int) error { return nil }
+
+func (m *MockServices) CloneSheet(ctx context.Context, id int, name string, copyBestShot bool) (*sheet.Sheet, error) {
+	return nil, nil
+}
+
+func (m *MockServices) CreateRoasterByName(ctx context.Context, name string) (*roaster.Roaster, error) {
+	if name == "" {
+		return nil, domainerrors.ErrRoasterNameIsEmpty
+	}
+	m.roasters = append(m.roasters, roaster.Roaster{Id: m.id(), Name: name})
+	return &m.roasters[len(m.roasters)-1], nil
+}
+
+func (m *MockServices) GetRoasterById(ctx context.Context, id int) (*roaster.Roaster, error) {
+	return nil, nil
+}
+
+func (m *MockServices) GetAllRoasters(ctx context.Context) ([]roaster.Roaster, error) {
+	return m.roasters, m.err
+}
+
+func (m *MockServices) UpdateRoasterById(ctx context.Context, id int, r *roaster.Roaster) (*roaster.Roaster, error) {
+	return nil, nil
+}
+
+func (m *MockServices) DeleteRoasterById(ctx context.Context, id int) error { return nil }
+
+func (m *MockServices) CreateBean(ctx context.Context, b *bean.Bean) (*bean.Bean, error) {
+	if b.Name == "" {
+		return nil, domainerrors.ErrBeansNameIsEmpty
+	}
+	if !b.RoastLevel.IsValid() {
+		return nil, domainerrors.ErrBeansRoastLevelOutOfRange
+	}
+	created := *b
+	created.Id = m.id()
+	m.beans = append(m.beans, created)
+	return &created, nil
+}
+
+func (m *MockServices) GetBeanById(ctx context.Context, id int) (*bean.Bean, error) {
+	return nil, nil
+}
+
+func (m *MockServices) GetAllBeans(ctx context.Context) ([]bean.Bean, error) {
+	return m.beans, m.err
+}
+
+func (m *MockServices) UpdateBeanById(ctx context.Context, id int, b *bean.Bean) (*bean.Bean, error) {
+	return nil, nil
+}
+
+func (m *MockServices) DeleteBeanById(ctx context.Context, id int) error { return nil }
+
+func (m *MockServices) CreateShot(ctx context.Context, s *shot.Shot) (*shot.Shot, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if !(s.Rating >= 0.0 && s.Rating <= 10.0) {
+		return nil, domainerrors.ErrShotRatingOutOfRange
+	}
+	if s.ShotTime < 0 || s.ShotTime > shot.MaxShotTime {
+		return nil, domainerrors.ErrShotTimeOutOfRange
+	}
+	created := *s
+	created.Id = m.id()
+	m.shots = append(m.shots, created)
+	return &created, nil
+}
+
+func (m *MockServices) GetShotById(ctx context.Context, id int) (*shot.Shot, error) {
+	return nil, nil
+}
+
+func (m *MockServices) GetAllShots(ctx context.Context) ([]shot.Shot, error) {
+	return m.shots, m.err
+}
+
+func (m *MockServices) GetShotsBySheetId(ctx context.Context, sheetId int) ([]shot.Shot, error) {
+	return nil, nil
+}
+
+func (m *MockServices) UpdateShotById(ctx context.Context, id int, s *shot.Shot) (*shot.Shot, error) {
+	return nil, nil
+}
+
+func (m *MockServices) DeleteShotById(ctx context.Context, id int) error { return nil }
+
+func (m *MockServices) GetShotProfileById(ctx context.Context, id int) (*shot.Profile, error) {
+	return nil, nil
+}
+
+func (m *MockServices) UpdateShotProfileById(ctx context.Context, id int, profile *shot.Profile) (*shot.Profile, error) {
+	return nil, nil
+}
+
+func (m *MockServices) CompareShots(ctx context.Context, ids []int) (*shot.Comparison, error) {
+	return nil, nil
+}
+
+func (m *MockServices) DuplicateShot(ctx context.Context, id int, overrides shot.Overrides) (*shot.Shot, error) {
+	return nil, nil
+}
+
+func (m *MockServices) Ping(ctx context.Context) error { return nil }
+
+func newTestService(m *MockServices) *SpreadsheetService {
+	return New(m, m, m, m)
+}
+
+func intPtr(i int) *int { return &i }
+
+func TestExport(t *testing.T) {
+	createdAt := time.Date(2026, 10, 18, 8, 15, 0, 0, time.UTC)
+	roastDate := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	cost := 0.54
+	squareMile := &roaster.Roaster{Id: 2, Name: "Square Mile"}
+	redBrick := &bean.Bean{Id: 3, Name: "Red Brick", Roaster: squareMile}
+	m := &MockServices{
+		sheets:   []sheet.Sheet{{Id: 1, Name: "Espresso", CreatedAt: &createdAt, UpdatedAt: &createdAt}},
+		roasters: []roaster.Roaster{*squareMile},
+		beans: []bean.Bean{{
+			Id: 3, Name: "Red Brick", Roaster: squareMile, RoastDate: &roastDate, RoastLevel: sql.RoastLevelMedium,
+			Price: 18, Currency: "GBP", BagWeight: 350,
+		}},
+		shots: []shot.Shot{{
+			Id: 4, Sheet: &sheet.Sheet{Id: 1, Name: "Espresso"}, Beans: redBrick, GrindSetting: 12,
+			QuantityIn: 18, QuantityOut: 36.5, ShotTime: 27500 * time.Millisecond, WaterTemperature: 93,
+			Rating: 8.5, IsTooSour: true, ComparisonWithPreviousResult: sql.Better,
+			AdditionalNotes: "=1+1, \"fruity\"", CostPerShot: &cost,
+		}},
+	}
+
+	tests := []struct {
+		resource Resource
+		want     string
+	}{
+		{
+			resource: ResourceSheets,
+			want: "id,name,created_at,updated_at\n" +
+				"1,Espresso,2026-10-18T08:15:00Z,2026-10-18T08:15:00Z\n",
+		},
+		{
+			resource: ResourceRoasters,
+			want: "id,name,created_at,updated_at\n" +
+				"2,Square Mile,,\n",
+		},
+		{
+			resource: ResourceBeans,
+			want: "id,name,roaster_id,roaster,roast_date,roast_level,green_coffee_id,green_weight,price,currency,bag_weight,created_at,updated_at\n" +
+				"3,Red Brick,2,Square Mile,2026-10-01,2,,0,18,GBP,350,,\n",
+		},
+		{
+			resource: ResourceShots,
+			want: "id,sheet_id,sheet,beans_id,beans,roaster,grind_setting,quantity_in,quantity_out,shot_time,water_temperature,rating,is_too_bitter,is_too_sour,comparison_with_previous_result,additional_notes,cost_per_shot,created_at,updated_at\n" +
+				"4,1,Espresso,3,Red Brick,Square Mile,12,18,36.5,27.5,93,8.5,false,true,2,\"'=1+1, \"\"fruity\"\"\",0.54,,\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.resource), func(t *testing.T) {
+			var buf strings.Builder
+			if err := newTestService(m).Export(context.Background(), tt.resource, &buf); err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Export() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportError(t *testing.T) {
+	m := &MockServices{err: errors.New("connection refused")}
+
+	var buf strings.Builder
+	if err := newTestService(m).Export(context.Background(), ResourceShots, &buf); err == nil {
+		t.Fatal("Export() error = nil, want an error")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Export() wrote %q, want nothing", buf.String())
+	}
+}
+
+func TestImportSheets(t *testing.T) {
+	m := &MockServices{nextId: 10, sheets: []sheet.Sheet{{Id: 1, Name: "Espresso"}}}
+	data := "\ufeffName,id\nFlat white,12\n,\nEspresso,1\n'=Cortado,\n"
+
+	report, err := newTestService(m).Import(context.Background(), ResourceSheets, strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	want := &Report{Imported: 2, Failed: 2, Rows: []Row{
+		{Line: 2, Id: intPtr(11)},
+		{Line: 3, Error: domainerrors.ErrSheetNameIsEmpty.Error()},
+		{Line: 4, Error: domainerrors.ErrSheetAlreadyExists.Error()},
+		{Line: 5, Id: intPtr(12)},
+	}}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("Import() = %+v, want %+v", report, want)
+	}
+	if got := m.sheets[2].Name; got != "=Cortado" {
+		t.Errorf("sheet name = %q, want the unescaped formula", got)
+	}
+}
+
+func TestImportBeans(t *testing.T) {
+	m := &MockServices{nextId: 10, roasters: []roaster.Roaster{{Id: 2, Name: "Square Mile"}}}
+	data := "name,roaster,roaster_id,roast_date,roast_level,price,currency,bag_weight\n" +
+		"Red Brick,Square Mile,,2026-10-01,2,18,GBP,350\n" +
+		"Sweetshop,,2,,0,,,\n" +
+		"Kochere,Unknown,,,,,,\n" +
+		"Gesha,Square Mile,,yesterday,,,,\n" +
+		"Decaf,Square Mile,,,9,,,\n" +
+		"Blend,Square Mile,,,,cheap,,\n"
+
+	report, err := newTestService(m).Import(context.Background(), ResourceBeans, strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	want := &Report{Imported: 2, Failed: 4, Rows: []Row{
+		{Line: 2, Id: intPtr(11)},
+		{Line: 3, Id: intPtr(12)},
+		{Line: 4, Error: `csv row is invalid: no roaster named "Unknown"`},
+		{Line: 5, Error: "csv row is invalid: roast_date must be a date such as 2006-01-02"},
+		{Line: 6, Error: domainerrors.ErrBeansRoastLevelOutOfRange.Error()},
+		{Line: 7, Error: "csv row is invalid: price must be a number"},
+	}}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("Import() = %+v, want %+v", report, want)
+	}
+
+	roastDate := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	wantBeans := bean.Bean{
+		Id: 11, Name: "Red Brick", Roaster: &roaster.Roaster{Id: 2}, RoastDate: &roastDate,
+		RoastLevel: sql.RoastLevelMedium, Price: 18, Currency: "GBP", BagWeight: 350,
+	}
+	if !reflect.DeepEqual(m.beans[0], wantBeans) {
+		t.Errorf("created beans = %+v, want %+v", m.beans[0], wantBeans)
+	}
+}
+
+func TestImportShots(t *testing.T) {
+	m := &MockServices{
+		nextId: 10,
+		sheets: []sheet.Sheet{{Id: 1, Name: "Espresso"}},
+		beans: []bean.Bean{
+			{Id: 3, Name: "Red Brick", Roaster: &roaster.Roaster{Id: 2, Name: "Square Mile"}},
+			{Id: 5, Name: "House", Roaster: &roaster.Roaster{Id: 2, Name: "Square Mile"}},
+			{Id: 6, Name: "House", Roaster: &roaster.Roaster{Id: 7, Name: "Origin"}},
+		},
+	}
+	data := "sheet,sheet_id,beans,roaster,beans_id,quantity_in,quantity_out,shot_time,rating,is_too_sour,comparison_with_previous_result,additional_notes\n" +
+		"Espresso,,Red Brick,,,18,36.5,27.5,8.5,true,2,fruity\n" +
+		",1,House,Origin,,18,40,30,,,,\n" +
+		"Espresso,,House,,,18,40,30,,,,\n" +
+		"Espresso,,,,5,18,40,30,11,,,\n" +
+		",,Red Brick,,,18,40,30,,,,\n" +
+		"Espresso,,Red Brick,,,18,40,30,,maybe,,\n"
+
+	report, err := newTestService(m).Import(context.Background(), ResourceShots, strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	want := &Report{Imported: 2, Failed: 4, Rows: []Row{
+		{Line: 2, Id: intPtr(11)},
+		{Line: 3, Id: intPtr(12)},
+		{Line: 4, Error: `csv row is invalid: several beans are named "House", set beans_id`},
+		{Line: 5, Error: domainerrors.ErrShotRatingOutOfRange.Error()},
+		{Line: 6, Error: "csv row is invalid: sheet_id or sheet is required"},
+		{Line: 7, Error: "csv row is invalid: is_too_sour must be true or false"},
+	}}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("Import() = %+v, want %+v", report, want)
+	}
+
+	wantShot := shot.Shot{
+		Id: 11, Sheet: &sheet.Sheet{Id: 1}, Beans: &bean.Bean{Id: 3}, QuantityIn: 18, QuantityOut: 36.5,
+		ShotTime: 27500 * time.Millisecond, Rating: 8.5, IsTooSour: true,
+		ComparisonWithPreviousResult: sql.Better, AdditionalNotes: "fruity",
+	}
+	if !reflect.DeepEqual(m.shots[0], wantShot) {
+		t.Errorf("created shot = %+v, want %+v", m.shots[0], wantShot)
+	}
+	if got := m.shots[1].Beans.Id; got != 6 {
+		t.Errorf("beans id = %d, want the House beans of Origin", got)
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource Resource
+		data     string
+		err      error
+		wantErr  error
+	}{
+		{name: "empty file", resource: ResourceSheets, data: "", wantErr: domainerrors.ErrCSVIsInvalid},
+		{name: "missing name column", resource: ResourceRoasters, data: "id\n1\n", wantErr: domainerrors.ErrCSVIsInvalid},
+		{name: "bare quote", resource: ResourceSheets, data: "name\nEspresso\nFlat \"white\n", wantErr: domainerrors.ErrCSVIsInvalid},
+		{name: "database error", resource: ResourceSheets, data: "name\nEspresso\n", err: errors.New("connection refused")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &MockServices{err: tt.err}
+
+			report, err := newTestService(m).Import(context.Background(), tt.resource, strings.NewReader(tt.data))
+			if err == nil {
+				t.Fatalf("Import() = %+v, want an error", report)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Import() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(m.sheets) != 0 || len(m.roasters) != 0 {
+				t.Errorf("Import() created %v and %v, want nothing", m.sheets, m.roasters)
+			}
+		})
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	squareMile := &roaster.Roaster{Id: 2, Name: "Square Mile"}
+	exported := &MockServices{
+		shots: []shot.Shot{{
+			Id: 4, Sheet: &sheet.Sheet{Id: 1, Name: "Espresso"}, Beans: &bean.Bean{Id: 3, Name: "Red Brick", Roaster: squareMile},
+			GrindSetting: 12, QuantityIn: 18, QuantityOut: 36.5, ShotTime: 27500 * time.Millisecond, WaterTemperature: 93,
+			Rating: 8.5, IsTooBitter: true, ComparisonWithPreviousResult: sql.Same, AdditionalNotes: "-ish, sour",
+		}},
+	}
+	var buf strings.Builder
+	if err := newTestService(exported).Export(context.Background(), ResourceShots, &buf); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	imported := &MockServices{nextId: 10}
+	report, err := newTestService(imported).Import(context.Background(), ResourceShots, strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if report.Imported != 1 {
+		t.Fatalf("Import() = %+v, want the shot imported", report)
+	}
+
+	want := exported.shots[0]
+	want.Id, want.Sheet, want.Beans = 11, &sheet.Sheet{Id: 1}, &bean.Bean{Id: 3}
+	if !reflect.DeepEqual(imported.shots[0], want) {
+		t.Errorf("imported shot = %+v, want %+v", imported.shots[0], want)
+	}
+}
@@ -0,0 +1,129 @@
+package sso
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/auth/oidc"
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// Service logs users in with an OpenID Connect provider, for the web UI, and
+// authenticates the JWT access tokens of the provider, for the REST API.
+//
+// An identity is mapped to the user linked to its subject. An identity whose
+// subject is not linked yet, but whose email is verified, is linked to the
+// user named after the email, unless that user is linked to another subject.
+type Service interface {
+	// AuthCodeURL returns the URL of the provider's login page.
+	AuthCodeURL(state, nonce, codeChallenge string) string
+
+	// Login exchanges the authorization code the provider redirected back
+	// with, and returns the user the ID token identifies.
+	Login(ctx context.Context, code, codeVerifier, nonce string) (*auth.User, error)
+
+	// AuthenticateToken returns the user a JWT access token identifies.
+	AuthenticateToken(ctx context.Context, token string) (*auth.User, error)
+}
+
+type SSOService struct {
+	provider *oidc.Provider
+	users    repository.UserRepository
+}
+
+var _ Service = (*SSOService)(nil)
+
+func New(provider *oidc.Provider, users repository.UserRepository) *SSOService {
+	return &SSOService{provider: provider, users: users}
+}
+
+func (s *SSOService) AuthCodeURL(state, nonce, codeChallenge string) string {
+	return s.provider.AuthCodeURL(state, nonce, codeChallenge)
+}
+
+func (s *SSOService) Login(ctx context.Context, code, codeVerifier, nonce string) (*auth.User, error) {
+	msg := "could not log in with oidc"
+
+	token, err := s.provider.Exchange(ctx, code, codeVerifier)
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+	claims, err := s.provider.VerifyIDToken(ctx, token.IDToken, nonce)
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Str("subject", claims.Subject).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+	return user, nil
+}
+
+func (s *SSOService) AuthenticateToken(ctx context.Context, token string) (*auth.User, error) {
+	msg := "could not authenticate oidc token"
+
+	claims, err := s.provider.VerifyAccessToken(ctx, token)
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Str("subject", claims.Subject).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+	return user, nil
+}
+
+// userFromClaims maps an identity to a user, linking the user named after a
+// verified email to the subject of the identity the first time. It returns
+// errors.ErrOIDCIdentityIsUnknown when no user matches.
+func (s *SSOService) userFromClaims(ctx context.Context, claims *oidc.Claims) (*auth.User, error) {
+	user, err := s.users.GetUserByOIDCSubject(ctx, claims.Subject)
+	if stderrors.Is(err, errors.ErrUserDoesNotExist) && claims.Email != "" && claims.EmailVerified {
+		user, err = s.linkUserByEmail(ctx, claims)
+	}
+	if stderrors.Is(err, errors.ErrUserDoesNotExist) {
+		err = errors.ErrOIDCIdentityIsUnknown
+	}
+	if err == nil && user.Disabled {
+		err = errors.ErrUserIsDisabled
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.User{Id: user.Id, Name: user.Name, Role: auth.Role(user.Role)}, nil
+}
+
+func (s *SSOService) linkUserByEmail(ctx context.Context, claims *oidc.Claims) (*sql.User, error) {
+	user, err := s.users.GetUserByName(ctx, claims.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := s.users.GetUserOIDCSubjectByName(ctx, user.Name)
+	if err != nil {
+		return nil, err
+	}
+	if subject != "" {
+		zerolog.Ctx(ctx).Warn().Str("user", user.Name).Msg("user is already linked to another oidc subject")
+		return nil, errors.ErrOIDCIdentityIsUnknown
+	}
+
+	if err := s.users.SetUserOIDCSubjectByName(ctx, user.Name, claims.Subject); err != nil {
+		return nil, err
+	}
+	zerolog.Ctx(ctx).Info().Str("user", user.Name).Str("subject", claims.Subject).Msg("linked user to oidc subject")
+	return user, nil
+}
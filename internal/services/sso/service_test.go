@@ -0,0 +1,184 @@
+package sso
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/lescactus/espressoapi-go/internal/auth/oidc"
+	"github.com/lescactus/espressoapi-go/internal/auth/oidc/oidctest"
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type MockUserRepository struct {
+	users    map[string]sql.User
+	subjects map[string]string
+}
+
+func newMockRepository() *MockUserRepository {
+	return &MockUserRepository{
+		users: map[string]sql.User{
+			"alice":             {Id: 4, Name: "alice"},
+			"bob@example.com":   {Id: 5, Name: "bob@example.com"},
+			"carol@example.com": {Id: 6, Name: "carol@example.com"},
+			"dave":              {Id: 7, Name: "dave", Disabled: true},
+		},
+		subjects: map[string]string{"alice": "sub-alice", "carol@example.com": "sub-carol", "dave": "sub-dave"},
+	}
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, user *sql.User) (int, error) {
+	return 0, fmt.Errorf("unexpected call")
+}
+
+func (m *MockUserRepository) GetUserById(ctx context.Context, id int) (*sql.User, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *MockUserRepository) GetUserByName(ctx context.Context, name string) (*sql.User, error) {
+	user, ok := m.users[name]
+	if !ok {
+		return nil, errors.ErrUserDoesNotExist
+	}
+	return &user, nil
+}
+
+func (m *MockUserRepository) GetAllUsers(ctx context.Context) ([]sql.User, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *MockUserRepository) DisableUserByName(ctx context.Context, name string) error {
+	return fmt.Errorf("unexpected call")
+}
+
+func (m *MockUserRepository) SetUserRoleByName(ctx context.Context, name, role string) error {
+	return fmt.Errorf("unexpected call")
+}
+
+func (m *MockUserRepository) SetUserPasswordHashByName(ctx context.Context, name, hash string) error {
+	return fmt.Errorf("unexpected call")
+}
+
+func (m *MockUserRepository) GetUserPasswordHashByName(ctx context.Context, name string) (string, error) {
+	return "", fmt.Errorf("unexpected call")
+}
+
+func (m *MockUserRepository) GetUserByOIDCSubject(ctx context.Context, subject string) (*sql.User, error) {
+	for name, s := range m.subjects {
+		if s == subject {
+			return m.GetUserByName(ctx, name)
+		}
+	}
+	return nil, errors.ErrUserDoesNotExist
+}
+
+func (m *MockUserRepository) SetUserOIDCSubjectByName(ctx context.Context, name, subject string) error {
+	if _, ok := m.users[name]; !ok {
+		return errors.ErrUserDoesNotExist
+	}
+	m.subjects[name] = subject
+	return nil
+}
+
+func (m *MockUserRepository) GetUserOIDCSubjectByName(ctx context.Context, name string) (string, error) {
+	if _, ok := m.users[name]; !ok {
+		return "", errors.ErrUserDoesNotExist
+	}
+	return m.subjects[name], nil
+}
+
+func (m *MockUserRepository) Ping(ctx context.Context) error { return nil }
+
+func newTestService(t *testing.T) (*SSOService, *oidctest.Provider, *MockUserRepository) {
+	t.Helper()
+	mock := oidctest.NewProvider(t, "espresso", "")
+	provider, err := oidc.New(context.Background(), oidc.Config{
+		IssuerURL:   mock.Issuer(),
+		ClientID:    mock.ClientID,
+		RedirectURL: "https://espresso.example.com/login/oidc/callback",
+	}, mock.Server.Client())
+	if err != nil {
+		t.Fatalf("oidc.New() error = %v", err)
+	}
+	repo := newMockRepository()
+	return New(provider, repo), mock, repo
+}
+
+func TestSSOServiceLogin(t *testing.T) {
+	tests := []struct {
+		name        string
+		identity    oidctest.Identity
+		wantUser    string
+		wantSubject string
+		wantErr     error
+	}{
+		{name: "Linked subject", identity: oidctest.Identity{Subject: "sub-alice"}, wantUser: "alice"},
+		{
+			name:     "Verified email links the user",
+			identity: oidctest.Identity{Subject: "sub-bob", Email: "bob@example.com", EmailVerified: true},
+			wantUser: "bob@example.com", wantSubject: "sub-bob",
+		},
+		{
+			name:     "Unverified email",
+			identity: oidctest.Identity{Subject: "sub-bob", Email: "bob@example.com"},
+			wantErr:  errors.ErrOIDCIdentityIsUnknown,
+		},
+		{
+			name:     "Email of a user linked to another subject",
+			identity: oidctest.Identity{Subject: "sub-mallory", Email: "carol@example.com", EmailVerified: true},
+			wantErr:  errors.ErrOIDCIdentityIsUnknown,
+		},
+		{name: "Unknown identity", identity: oidctest.Identity{Subject: "sub-erin"}, wantErr: errors.ErrOIDCIdentityIsUnknown},
+		{name: "Disabled user", identity: oidctest.Identity{Subject: "sub-dave"}, wantErr: errors.ErrUserIsDisabled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mock, repo := newTestService(t)
+			mock.SetIdentity(tt.identity)
+
+			verifier, _ := oidc.RandomString()
+			callback := mock.Authorize(t, s.AuthCodeURL("state", "nonce", oidc.CodeChallenge(verifier)))
+
+			got, err := s.Login(context.Background(), callback.Query().Get("code"), verifier, "nonce")
+			if tt.wantErr != nil {
+				if !stderrors.Is(err, tt.wantErr) {
+					t.Fatalf("SSOService.Login() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SSOService.Login() error = %v", err)
+			}
+			if got.Name != tt.wantUser {
+				t.Errorf("SSOService.Login() = %+v, want %s", got, tt.wantUser)
+			}
+			if tt.wantSubject != "" && repo.subjects[tt.wantUser] != tt.wantSubject {
+				t.Errorf("linked subject = %q, want %q", repo.subjects[tt.wantUser], tt.wantSubject)
+			}
+		})
+	}
+}
+
+func TestSSOServiceAuthenticateToken(t *testing.T) {
+	s, mock, _ := newTestService(t)
+
+	got, err := s.AuthenticateToken(context.Background(), mock.SignToken(mock.Claims("sub-alice")))
+	if err != nil {
+		t.Fatalf("SSOService.AuthenticateToken() error = %v", err)
+	}
+	if got.Id != 4 || got.Name != "alice" {
+		t.Errorf("SSOService.AuthenticateToken() = %+v, want alice (4)", got)
+	}
+
+	claims := mock.Claims("sub-alice")
+	claims["aud"] = "another-client"
+	if _, err := s.AuthenticateToken(context.Background(), mock.SignToken(claims)); !stderrors.Is(err, errors.ErrOIDCTokenIsInvalid) {
+		t.Errorf("SSOService.AuthenticateToken() error = %v, want %v", err, errors.ErrOIDCTokenIsInvalid)
+	}
+
+	if _, err := s.AuthenticateToken(context.Background(), mock.SignToken(mock.Claims("sub-erin"))); !stderrors.Is(err, errors.ErrOIDCIdentityIsUnknown) {
+		t.Errorf("SSOService.AuthenticateToken() error = %v, want %v", err, errors.ErrOIDCIdentityIsUnknown)
+	}
+}
@@ -0,0 +1,277 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	// The image is built from scratch, without a zoneinfo database.
+	_ "time/tzdata"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// MaxConsumptionDays is the longest range, in days, consumption is reported
+// over. It is also the default range, ending today.
+const MaxConsumptionDays = 366
+
+// now is swapped out in tests to pin today.
+var now = time.Now
+
+// Consumption
+//
+// Consumption is how many shots were pulled each day over a range of days,
+// how much coffee they used and how they were rated. Days are calendar days
+// in the requested time zone, and only the days with shots are listed.
+//
+// swagger:model
+type Consumption struct {
+	// The first day of the range
+	From time.Time `json:"from"`
+
+	// The last day of the range, inclusive
+	To time.Time `json:"to"`
+
+	// The IANA time zone the days are in
+	TimeZone string `json:"time_zone"`
+
+	// The days with shots, oldest first
+	Days []ConsumptionDay `json:"days"`
+
+	// The number of shots pulled over the range
+	Shots int `json:"shots"`
+
+	// The weight of coffee used over the range, in grams
+	CoffeeWeight float64 `json:"coffee_weight"`
+
+	// The average rating of the rated shots over the range, leaving out the
+	// shots rated 0. Null without rated shots.
+	AverageRating *float64 `json:"average_rating"`
+}
+
+// ConsumptionDay is what was pulled on one day.
+//
+// swagger:model
+type ConsumptionDay struct {
+	// The day
+	Date time.Time `json:"date"`
+
+	// The number of shots pulled
+	Shots int `json:"shots"`
+
+	// The weight of coffee used, in grams
+	CoffeeWeight float64 `json:"coffee_weight"`
+
+	// The average rating of the rated shots, leaving out the shots rated 0.
+	// 0 without rated shots.
+	AverageRating float64 `json:"average_rating"`
+}
+
+// ShotStats
+//
+// ShotStats aggregates the shots pulled with beans, with the beans of a
+// roaster, or in a sheet. Every field but the number of shots is null
+// without shots. The ratings leave out the shots rated 0, not rated yet, and
+// are null without rated shots.
+//
+// swagger:model
+type ShotStats struct {
+	// The number of shots
+	Shots int `json:"shots"`
+
+	// The average rating of the shots
+	AverageRating *float64 `json:"average_rating"`
+
+	// The median rating of the shots
+	MedianRating *float64 `json:"median_rating"`
+
+	// The best rating of the shots
+	MaxRating *float64 `json:"max_rating"`
+
+	// The id of the latest of the best rated shots
+	BestShotId *int `json:"best_shot_id"`
+
+	// The finest grind setting of the shots
+	MinGrindSetting *int `json:"min_grind_setting"`
+
+	// The coarsest grind setting of the shots
+	MaxGrindSetting *int `json:"max_grind_setting"`
+
+	// The average ratio of the coffee out to the coffee in
+	AverageRatio *float64 `json:"average_ratio"`
+
+	// The average shot time, in seconds
+	AverageShotTime *float64 `json:"average_shot_time"`
+
+	// The share of the shots too bitter, from 0 to 1
+	BitterShare *float64 `json:"bitter_share"`
+
+	// The share of the shots too sour, from 0 to 1
+	SourShare *float64 `json:"sour_share"`
+}
+
+type Service interface {
+	GetConsumption(ctx context.Context, from, to *time.Time, timeZone string) (*Consumption, error)
+	GetShotStats(ctx context.Context, subject sql.ShotStatsSubject, id int) (*ShotStats, error)
+	Ping(ctx context.Context) error
+}
+
+type StatsService struct {
+	repository repository.StatsRepository
+}
+
+var _ Service = (*StatsService)(nil)
+
+func New(repo repository.StatsRepository) *StatsService {
+	return &StatsService{repository: repo}
+}
+
+// GetConsumption reports the shots pulled each day between the from and to
+// days, both included, in timeZone. An empty timeZone defaults to UTC, a nil
+// to to today in timeZone, and a nil from to the day after one year before
+// to.
+func (s *StatsService) GetConsumption(ctx context.Context, from, to *time.Time, timeZone string) (*Consumption, error) {
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	loc, err := loadLocation(timeZone)
+	if err != nil {
+		msg := "could not get consumption"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	var last time.Time
+	if to != nil {
+		last = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	} else {
+		now := now().In(loc)
+		last = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	first := last.AddDate(-1, 0, 1)
+	if from != nil {
+		first = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	if first.After(last) {
+		err := errors.ErrStatsRangeIsInvalid
+		msg := "could not get consumption"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+	if int(last.Sub(first).Hours()/24)+1 > MaxConsumptionDays {
+		err := errors.ErrStatsRangeIsTooLong
+		msg := "could not get consumption"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	// The days start and end at midnight in the time zone, not in UTC.
+	start := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(last.Year(), last.Month(), last.Day()+1, 0, 0, 0, 0, loc)
+
+	days, err := s.repository.GetDailyConsumption(ctx, start, end, timeZone)
+	if err != nil {
+		msg := "could not get daily consumption"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	consumption := &Consumption{
+		From:     first,
+		To:       last,
+		TimeZone: timeZone,
+		Days:     make([]ConsumptionDay, 0, len(days)),
+	}
+	var ratings float64
+	var rated int
+	for _, d := range days {
+		consumption.Days = append(consumption.Days, ConsumptionDay{
+			Date:          time.Date(d.Day.Year(), d.Day.Month(), d.Day.Day(), 0, 0, 0, 0, time.UTC),
+			Shots:         d.Shots,
+			CoffeeWeight:  round(d.CoffeeWeight, 10),
+			AverageRating: round(d.AverageRating, 100),
+		})
+		consumption.Shots += d.Shots
+		consumption.CoffeeWeight += d.CoffeeWeight
+		ratings += d.AverageRating * float64(d.RatedShots)
+		rated += d.RatedShots
+	}
+	consumption.CoffeeWeight = round(consumption.CoffeeWeight, 10)
+	if rated > 0 {
+		rating := round(ratings/float64(rated), 100)
+		consumption.AverageRating = &rating
+	}
+
+	return consumption, nil
+}
+
+// GetShotStats aggregates the shots of the subject id: the beans, roaster
+// or sheet with this id.
+func (s *StatsService) GetShotStats(ctx context.Context, subject sql.ShotStatsSubject, id int) (*ShotStats, error) {
+	stats, err := s.repository.GetShotStats(ctx, subject, id)
+	if err != nil {
+		msg := "could not get shot stats"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	var averageShotTime *float64
+	if stats.AverageShotTimeMs != nil {
+		seconds := *stats.AverageShotTimeMs / 1000
+		averageShotTime = &seconds
+	}
+	return &ShotStats{
+		Shots:           stats.Shots,
+		AverageRating:   roundPtr(stats.AverageRating, 100),
+		MedianRating:    roundPtr(stats.MedianRating, 100),
+		MaxRating:       stats.MaxRating,
+		BestShotId:      stats.BestShotId,
+		MinGrindSetting: stats.MinGrindSetting,
+		MaxGrindSetting: stats.MaxGrindSetting,
+		AverageRatio:    roundPtr(stats.AverageRatio, 100),
+		AverageShotTime: roundPtr(averageShotTime, 10),
+		BitterShare:     roundPtr(stats.BitterShare, 1000),
+		SourShare:       roundPtr(stats.SourShare, 1000),
+	}, nil
+}
+
+func (s *StatsService) Ping(ctx context.Context) error {
+	if err := s.repository.Ping(ctx); err != nil {
+		msg := "could not ping database"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// loadLocation resolves an IANA time zone name. "Local" is refused, as the
+// database does not know the server's local time zone by that name.
+func loadLocation(name string) (*time.Location, error) {
+	if name == "Local" {
+		return nil, errors.ErrStatsTimeZoneIsInvalid
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.ErrStatsTimeZoneIsInvalid
+	}
+	return loc, nil
+}
+
+// round rounds v to the nearest 1/precision.
+func round(v, precision float64) float64 {
+	return math.Round(v*precision) / precision
+}
+
+// roundPtr rounds *v to the nearest 1/precision, keeping nil as is.
+func roundPtr(v *float64, precision float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	r := round(*v, precision)
+	return &r
+}
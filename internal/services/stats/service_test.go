@@ -0,0 +1,211 @@
+package stats
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type IsErrorCtxKey string
+
+type MockStatsRepository struct {
+	days     []sql.DailyConsumption
+	from, to time.Time
+	timeZone string
+
+	shotStats *sql.ShotStats
+	subject   sql.ShotStatsSubject
+	id        int
+}
+
+func (m *MockStatsRepository) GetDailyConsumption(ctx context.Context, from, to time.Time, timeZone string) ([]sql.DailyConsumption, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return nil, fmt.Errorf("mock error")
+	}
+	m.from, m.to, m.timeZone = from, to, timeZone
+	return m.days, nil
+}
+
+func (m *MockStatsRepository) GetShotStats(ctx context.Context, subject sql.ShotStatsSubject, id int) (*sql.ShotStats, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return nil, errors.ErrBeansDoesNotExist
+	}
+	m.subject, m.id = subject, id
+	return m.shotStats, nil
+}
+
+func (m *MockStatsRepository) Ping(ctx context.Context) error {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return fmt.Errorf("mock error")
+	}
+	return nil
+}
+
+func date(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func TestStatsServiceGetConsumption(t *testing.T) {
+	repo := &MockStatsRepository{days: []sql.DailyConsumption{
+		{Day: *date(2026, time.September, 2), Shots: 2, CoffeeWeight: 36, RatedShots: 2, AverageRating: 7.5},
+		{Day: *date(2026, time.September, 5), Shots: 1, CoffeeWeight: 18.5, RatedShots: 1, AverageRating: 9},
+		// A duplicated shot, not rated yet, is left out of the average.
+		{Day: *date(2026, time.September, 7), Shots: 1, CoffeeWeight: 18},
+	}}
+
+	got, err := New(repo).GetConsumption(context.Background(), date(2026, time.September, 1), date(2026, time.September, 30), "Europe/Paris")
+	if err != nil {
+		t.Fatalf("StatsService.GetConsumption() error = %v", err)
+	}
+
+	paris, _ := time.LoadLocation("Europe/Paris")
+	if !repo.from.Equal(time.Date(2026, time.September, 1, 0, 0, 0, 0, paris)) || !repo.to.Equal(time.Date(2026, time.October, 1, 0, 0, 0, 0, paris)) || repo.timeZone != "Europe/Paris" {
+		t.Errorf("GetDailyConsumption() got [%v, %v) in %q, want the days from midnight in Europe/Paris", repo.from, repo.to, repo.timeZone)
+	}
+
+	rating := 8.0
+	want := &Consumption{
+		From:     *date(2026, time.September, 1),
+		To:       *date(2026, time.September, 30),
+		TimeZone: "Europe/Paris",
+		Days: []ConsumptionDay{
+			{Date: *date(2026, time.September, 2), Shots: 2, CoffeeWeight: 36, AverageRating: 7.5},
+			{Date: *date(2026, time.September, 5), Shots: 1, CoffeeWeight: 18.5, AverageRating: 9},
+			{Date: *date(2026, time.September, 7), Shots: 1, CoffeeWeight: 18},
+		},
+		Shots:         4,
+		CoffeeWeight:  72.5,
+		AverageRating: &rating,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StatsService.GetConsumption() = %+v, want %+v", got, want)
+	}
+}
+
+func TestStatsServiceGetConsumptionDefaults(t *testing.T) {
+	repo := &MockStatsRepository{}
+	s := New(repo)
+	// Already the 19th in Tokyo, still the 18th in UTC.
+	now = func() time.Time { return time.Date(2026, time.October, 18, 20, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	got, err := s.GetConsumption(context.Background(), nil, nil, "Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("StatsService.GetConsumption() error = %v", err)
+	}
+	if !got.From.Equal(*date(2025, time.October, 20)) || !got.To.Equal(*date(2026, time.October, 19)) {
+		t.Errorf("StatsService.GetConsumption() range = [%v, %v], want the year up to today in Tokyo", got.From, got.To)
+	}
+	if got.AverageRating != nil || len(got.Days) != 0 {
+		t.Errorf("StatsService.GetConsumption() = %+v, want no days and no average rating", got)
+	}
+
+	got, err = s.GetConsumption(context.Background(), nil, nil, "")
+	if err != nil {
+		t.Fatalf("StatsService.GetConsumption() error = %v", err)
+	}
+	if got.TimeZone != "UTC" || !got.To.Equal(*date(2026, time.October, 18)) {
+		t.Errorf("StatsService.GetConsumption() = %+v, want today in UTC", got)
+	}
+}
+
+func TestStatsServiceGetConsumptionErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		from     *time.Time
+		to       *time.Time
+		timeZone string
+		wantErr  error
+	}{
+		{name: "Unknown time zone", ctx: context.Background(), timeZone: "Mars/Olympus_Mons", wantErr: errors.ErrStatsTimeZoneIsInvalid},
+		{name: "Local time zone", ctx: context.Background(), timeZone: "Local", wantErr: errors.ErrStatsTimeZoneIsInvalid},
+		{name: "From after to", ctx: context.Background(), from: date(2026, time.October, 2), to: date(2026, time.October, 1), wantErr: errors.ErrStatsRangeIsInvalid},
+		{name: "Range too long", ctx: context.Background(), from: date(2025, time.January, 1), to: date(2026, time.January, 2), wantErr: errors.ErrStatsRangeIsTooLong},
+		{name: "Repository error", ctx: context.WithValue(context.Background(), IsErrorCtxKey("isError"), true)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(&MockStatsRepository{}).GetConsumption(tt.ctx, tt.from, tt.to, tt.timeZone)
+			if err == nil {
+				t.Fatal("StatsService.GetConsumption() error = nil, want an error")
+			}
+			if tt.wantErr != nil && !stderrors.Is(err, tt.wantErr) {
+				t.Errorf("StatsService.GetConsumption() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStatsServicePing(t *testing.T) {
+	s := New(&MockStatsRepository{})
+	if err := s.Ping(context.Background()); err != nil {
+		t.Errorf("StatsService.Ping() error = %v", err)
+	}
+	if err := s.Ping(context.WithValue(context.Background(), IsErrorCtxKey("isError"), true)); err == nil {
+		t.Error("StatsService.Ping() error = nil, want an error")
+	}
+}
+
+func TestStatsServiceGetShotStats(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name  string
+		stats *sql.ShotStats
+		want  *ShotStats
+	}{
+		{
+			name: "shots are aggregated and rounded",
+			stats: &sql.ShotStats{
+				Shots: 3, AverageRating: ptr(7.666666), MedianRating: ptr(8), MaxRating: ptr(9), BestShotId: intPtr(12),
+				MinGrindSetting: intPtr(10), MaxGrindSetting: intPtr(14), AverageRatio: ptr(2.04444),
+				AverageShotTimeMs: ptr(28433.333), BitterShare: ptr(0.333333), SourShare: ptr(0),
+			},
+			want: &ShotStats{
+				Shots: 3, AverageRating: ptr(7.67), MedianRating: ptr(8), MaxRating: ptr(9), BestShotId: intPtr(12),
+				MinGrindSetting: intPtr(10), MaxGrindSetting: intPtr(14), AverageRatio: ptr(2.04),
+				AverageShotTime: ptr(28.4), BitterShare: ptr(0.333), SourShare: ptr(0),
+			},
+		},
+		{
+			name:  "no shots",
+			stats: &sql.ShotStats{},
+			want:  &ShotStats{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockStatsRepository{shotStats: tt.stats}
+
+			got, err := New(repo).GetShotStats(context.Background(), sql.ShotStatsOfRoaster, 4)
+			if err != nil {
+				t.Fatalf("StatsService.GetShotStats() error = %v", err)
+			}
+			if repo.subject != sql.ShotStatsOfRoaster || repo.id != 4 {
+				t.Errorf("GetShotStats() got %s id=%d, want roasters id=4", repo.subject, repo.id)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StatsService.GetShotStats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatsServiceGetShotStatsError(t *testing.T) {
+	ctx := context.WithValue(context.Background(), IsErrorCtxKey("isError"), true)
+
+	_, err := New(&MockStatsRepository{}).GetShotStats(ctx, sql.ShotStatsOfBeans, 4)
+	if !stderrors.Is(err, errors.ErrBeansDoesNotExist) {
+		t.Errorf("StatsService.GetShotStats() error = %v, want %v", err, errors.ErrBeansDoesNotExist)
+	}
+}
@@ -0,0 +1,215 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+	"github.com/lescactus/espressoapi-go/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// minPasswordLength is the minimum number of characters of a password.
+const minPasswordLength = 8
+
+// User is someone the sheets, roasters, beans and shots belong to.
+type User struct {
+	Id   int
+	Name string
+
+	// A disabled user is kept, along with what they own, but can no longer
+	// authenticate.
+	Disabled bool
+
+	// Role is what the user is allowed to do.
+	Role auth.Role
+
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
+
+// SQLToUser converts a sql.User object to a User object.
+// If the input user is nil, it returns nil.
+func SQLToUser(user *sql.User) *User {
+	if user == nil {
+		return nil
+	}
+
+	return &User{
+		Id:        user.Id,
+		Name:      user.Name,
+		Disabled:  user.Disabled,
+		Role:      auth.Role(user.Role),
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+type Service interface {
+	CreateUser(ctx context.Context, name string, role auth.Role) (*User, error)
+	GetUserById(ctx context.Context, id int) (*User, error)
+	GetUserByName(ctx context.Context, name string) (*User, error)
+	GetAllUsers(ctx context.Context) ([]User, error)
+	DisableUserByName(ctx context.Context, name string) error
+	SetUserRole(ctx context.Context, name string, role auth.Role) error
+	SetUserPassword(ctx context.Context, name, password string) error
+	LinkUserOIDCSubject(ctx context.Context, name, subject string) error
+	Ping(ctx context.Context) error
+}
+
+type UserService struct {
+	repository repository.UserRepository
+}
+
+var _ Service = (*UserService)(nil)
+
+func New(repo repository.UserRepository) *UserService {
+	return &UserService{repository: repo}
+}
+
+// CreateUser creates an enabled user with the given role. Surrounding spaces
+// are trimmed from the name.
+func (s *UserService) CreateUser(ctx context.Context, name string, role auth.Role) (*User, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		err := errors.ErrUserNameIsEmpty
+		msg := "could not create user"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+	if !role.IsValid() {
+		err := errors.ErrUserRoleIsInvalid
+		msg := "could not create user"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	id, err := s.repository.CreateUser(ctx, &sql.User{Name: name, Role: string(role)})
+	if err != nil {
+		msg := "could not create user"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return s.GetUserById(ctx, id)
+}
+
+func (s *UserService) GetUserById(ctx context.Context, id int) (*User, error) {
+	user, err := s.repository.GetUserById(ctx, id)
+	if err != nil {
+		msg := "could not get user by id"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToUser(user), nil
+}
+
+func (s *UserService) GetUserByName(ctx context.Context, name string) (*User, error) {
+	user, err := s.repository.GetUserByName(ctx, strings.TrimSpace(name))
+	if err != nil {
+		msg := "could not get user by name"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return SQLToUser(user), nil
+}
+
+func (s *UserService) GetAllUsers(ctx context.Context) ([]User, error) {
+	sqlUsers, err := s.repository.GetAllUsers(ctx)
+	if err != nil {
+		msg := "could not get all users"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	users := make([]User, len(sqlUsers))
+	for i, v := range sqlUsers {
+		users[i] = *SQLToUser(&v)
+	}
+
+	return users, nil
+}
+
+func (s *UserService) DisableUserByName(ctx context.Context, name string) error {
+	if err := s.repository.DisableUserByName(ctx, strings.TrimSpace(name)); err != nil {
+		msg := "could not disable user"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// SetUserRole changes what a user is allowed to do. It applies to the
+// sessions and api keys of the user from their next request.
+func (s *UserService) SetUserRole(ctx context.Context, name string, role auth.Role) error {
+	msg := "could not set user role"
+
+	if !role.IsValid() {
+		err := errors.ErrUserRoleIsInvalid
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+
+	if err := s.repository.SetUserRoleByName(ctx, strings.TrimSpace(name), string(role)); err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// SetUserPassword sets the password a user logs in to the web UI with,
+// replacing any previous one.
+func (s *UserService) SetUserPassword(ctx context.Context, name, password string) error {
+	msg := "could not set user password"
+
+	if utf8.RuneCountInString(password) < minPasswordLength {
+		err := errors.ErrUserPasswordIsTooShort
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err == nil {
+		err = s.repository.SetUserPasswordHashByName(ctx, strings.TrimSpace(name), hash)
+	}
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// LinkUserOIDCSubject links a user to the subject of an OpenID Connect
+// identity, replacing any previous one, so they can log in with the provider.
+func (s *UserService) LinkUserOIDCSubject(ctx context.Context, name, subject string) error {
+	msg := "could not link user to oidc subject"
+
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		err := errors.ErrOIDCSubjectIsEmpty
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+
+	if err := s.repository.SetUserOIDCSubjectByName(ctx, strings.TrimSpace(name), subject); err != nil {
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+func (s *UserService) Ping(ctx context.Context) error {
+	if err := s.repository.Ping(ctx); err != nil {
+		msg := "could not ping database"
+		zerolog.Ctx(ctx).Err(err).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package user
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/errors"
+	"github.com/lescactus/espressoapi-go/internal/models/sql"
+)
+
+type IsErrorCtxKey string
+
+type MockUserRepository struct {
+	users     map[int]sql.User
+	passwords map[string]string
+	subjects  map[string]string
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, user *sql.User) (int, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return 0, fmt.Errorf("mock error")
+	}
+	for _, u := range m.users {
+		if u.Name == user.Name {
+			return 0, errors.ErrUserAlreadyExists
+		}
+	}
+	user.Id = len(m.users) + 1
+	m.users[user.Id] = *user
+	return user.Id, nil
+}
+
+func (m *MockUserRepository) GetUserById(ctx context.Context, id int) (*sql.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, errors.ErrUserDoesNotExist
+	}
+	return &user, nil
+}
+
+func (m *MockUserRepository) GetUserByName(ctx context.Context, name string) (*sql.User, error) {
+	for _, u := range m.users {
+		if u.Name == name {
+			return &u, nil
+		}
+	}
+	return nil, errors.ErrUserDoesNotExist
+}
+
+func (m *MockUserRepository) GetAllUsers(ctx context.Context) ([]sql.User, error) {
+	if isError := ctx.Value(IsErrorCtxKey("isError")); isError == true {
+		return nil, fmt.Errorf("mock error")
+	}
+	users := make([]sql.User, 0, len(m.users))
+	for id := 1; id <= len(m.users); id++ {
+		users = append(users, m.users[id])
+	}
+	return users, nil
+}
+
+func (m *MockUserRepository) DisableUserByName(ctx context.Context, name string) error {
+	for id, u := range m.users {
+		if u.Name == name {
+			u.Disabled = true
+			m.users[id] = u
+			return nil
+		}
+	}
+	return errors.ErrUserDoesNotExist
+}
+
+func (m *MockUserRepository) SetUserRoleByName(ctx context.Context, name, role string) error {
+	for id, u := range m.users {
+		if u.Name == name {
+			u.Role = role
+			m.users[id] = u
+			return nil
+		}
+	}
+	return errors.ErrUserDoesNotExist
+}
+
+func (m *MockUserRepository) SetUserPasswordHashByName(ctx context.Context, name, hash string) error {
+	if _, err := m.GetUserByName(ctx, name); err != nil {
+		return err
+	}
+	m.passwords[name] = hash
+	return nil
+}
+
+func (m *MockUserRepository) GetUserPasswordHashByName(ctx context.Context, name string) (string, error) {
+	if _, err := m.GetUserByName(ctx, name); err != nil {
+		return "", err
+	}
+	return m.passwords[name], nil
+}
+
+func (m *MockUserRepository) GetUserByOIDCSubject(ctx context.Context, subject string) (*sql.User, error) {
+	for name, s := range m.subjects {
+		if s == subject {
+			return m.GetUserByName(ctx, name)
+		}
+	}
+	return nil, errors.ErrUserDoesNotExist
+}
+
+func (m *MockUserRepository) SetUserOIDCSubjectByName(ctx context.Context, name, subject string) error {
+	if _, err := m.GetUserByName(ctx, name); err != nil {
+		return err
+	}
+	for n, s := range m.subjects {
+		if s == subject && n != name {
+			return errors.ErrUserAlreadyExists
+		}
+	}
+	m.subjects[name] = subject
+	return nil
+}
+
+func (m *MockUserRepository) GetUserOIDCSubjectByName(ctx context.Context, name string) (string, error) {
+	if _, err := m.GetUserByName(ctx, name); err != nil {
+		return "", err
+	}
+	return m.subjects[name], nil
+}
+
+func (m *MockUserRepository) Ping(ctx context.Context) error { return nil }
+
+func TestUserServiceCreateUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		userName string
+		role     auth.Role
+		wantErr  error
+	}{
+		{name: "Valid", userName: " alice ", role: auth.RoleBarista},
+		{name: "Empty name", userName: "  ", role: auth.RoleBarista, wantErr: errors.ErrUserNameIsEmpty},
+		{name: "Invalid role", userName: "alice", role: "owner", wantErr: errors.ErrUserRoleIsInvalid},
+		{name: "Duplicate", userName: "bob", role: auth.RoleViewer, wantErr: errors.ErrUserAlreadyExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&MockUserRepository{users: map[int]sql.User{1: {Id: 1, Name: "bob"}}})
+			got, err := s.CreateUser(context.Background(), tt.userName, tt.role)
+			if tt.wantErr != nil {
+				if !stderrors.Is(err, tt.wantErr) {
+					t.Fatalf("UserService.CreateUser() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UserService.CreateUser() error = %v", err)
+			}
+			if got.Id != 2 || got.Name != "alice" || got.Disabled || got.Role != tt.role {
+				t.Errorf("UserService.CreateUser() = %+v, want enabled %s alice with id 2", got, tt.role)
+			}
+		})
+	}
+}
+
+func TestUserServiceDisableUserByName(t *testing.T) {
+	repo := &MockUserRepository{users: map[int]sql.User{1: {Id: 1, Name: "bob"}}}
+	s := New(repo)
+
+	if err := s.DisableUserByName(context.Background(), "bob"); err != nil {
+		t.Fatalf("UserService.DisableUserByName() error = %v", err)
+	}
+	got, err := s.GetUserByName(context.Background(), "bob")
+	if err != nil || !got.Disabled {
+		t.Errorf("UserService.GetUserByName() = %+v, %v, want bob disabled", got, err)
+	}
+
+	if err := s.DisableUserByName(context.Background(), "carol"); !stderrors.Is(err, errors.ErrUserDoesNotExist) {
+		t.Errorf("UserService.DisableUserByName() error = %v, want %v", err, errors.ErrUserDoesNotExist)
+	}
+}
+
+func TestUserServiceSetUserRole(t *testing.T) {
+	repo := &MockUserRepository{users: map[int]sql.User{1: {Id: 1, Name: "bob", Role: "barista"}}}
+	s := New(repo)
+
+	if err := s.SetUserRole(context.Background(), " bob ", auth.RoleViewer); err != nil {
+		t.Fatalf("UserService.SetUserRole() error = %v", err)
+	}
+	got, err := s.GetUserByName(context.Background(), "bob")
+	if err != nil || got.Role != auth.RoleViewer {
+		t.Errorf("UserService.GetUserByName() = %+v, %v, want bob viewer", got, err)
+	}
+
+	if err := s.SetUserRole(context.Background(), "bob", "owner"); !stderrors.Is(err, errors.ErrUserRoleIsInvalid) {
+		t.Errorf("UserService.SetUserRole() error = %v, want %v", err, errors.ErrUserRoleIsInvalid)
+	}
+	if err := s.SetUserRole(context.Background(), "carol", auth.RoleAdmin); !stderrors.Is(err, errors.ErrUserDoesNotExist) {
+		t.Errorf("UserService.SetUserRole() error = %v, want %v", err, errors.ErrUserDoesNotExist)
+	}
+}
+
+func TestUserServiceGetAllUsers(t *testing.T) {
+	s := New(&MockUserRepository{users: map[int]sql.User{1: {Id: 1, Name: "bob"}, 2: {Id: 2, Name: "alice", Disabled: true}}})
+
+	got, err := s.GetAllUsers(context.Background())
+	if err != nil {
+		t.Fatalf("UserService.GetAllUsers() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Name != "bob" || !got[1].Disabled {
+		t.Errorf("UserService.GetAllUsers() = %+v, want bob then disabled alice", got)
+	}
+
+	if _, err := s.GetAllUsers(context.WithValue(context.Background(), IsErrorCtxKey("isError"), true)); err == nil {
+		t.Error("UserService.GetAllUsers() error = nil, want an error")
+	}
+}
+
+func TestUserServiceSetUserPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     string
+		password string
+		wantErr  error
+	}{
+		{name: "Valid", user: " alice ", password: "correct horse"},
+		{name: "Too short", user: "alice", password: "short", wantErr: errors.ErrUserPasswordIsTooShort},
+		{name: "Unknown user", user: "carol", password: "correct horse", wantErr: errors.ErrUserDoesNotExist},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockUserRepository{users: map[int]sql.User{1: {Id: 1, Name: "alice"}}, passwords: map[string]string{}}
+			err := New(repo).SetUserPassword(context.Background(), tt.user, tt.password)
+			if tt.wantErr != nil {
+				if !stderrors.Is(err, tt.wantErr) {
+					t.Fatalf("UserService.SetUserPassword() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UserService.SetUserPassword() error = %v", err)
+			}
+			if hash := repo.passwords["alice"]; !auth.CheckPassword(hash, tt.password) {
+				t.Errorf("stored hash %q does not match the password", hash)
+			}
+		})
+	}
+}
+
+func TestUserServiceLinkUserOIDCSubject(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    string
+		subject string
+		wantErr error
+	}{
+		{name: "Valid", user: " alice ", subject: " sub-alice "},
+		{name: "Empty subject", user: "alice", subject: " ", wantErr: errors.ErrOIDCSubjectIsEmpty},
+		{name: "Subject of another user", user: "alice", subject: "sub-bob", wantErr: errors.ErrUserAlreadyExists},
+		{name: "Unknown user", user: "carol", subject: "sub-carol", wantErr: errors.ErrUserDoesNotExist},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockUserRepository{
+				users:    map[int]sql.User{1: {Id: 1, Name: "alice"}, 2: {Id: 2, Name: "bob"}},
+				subjects: map[string]string{"bob": "sub-bob"},
+			}
+			err := New(repo).LinkUserOIDCSubject(context.Background(), tt.user, tt.subject)
+			if tt.wantErr != nil {
+				if !stderrors.Is(err, tt.wantErr) {
+					t.Fatalf("UserService.LinkUserOIDCSubject() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UserService.LinkUserOIDCSubject() error = %v", err)
+			}
+			if got := repo.subjects["alice"]; got != "sub-alice" {
+				t.Errorf("linked subject = %q, want sub-alice", got)
+			}
+		})
+	}
+}
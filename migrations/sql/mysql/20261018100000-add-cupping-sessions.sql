@@ -0,0 +1,41 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `cupping_sessions` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `session_date` DATE NOT NULL,
+    `participants` VARCHAR(511) NOT NULL DEFAULT '',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`)
+);
+CREATE TABLE IF NOT EXISTS `cupping_scores` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `session_id` INT NOT NULL,
+    `beans_id` INT NOT NULL,
+    `fragrance` DOUBLE NOT NULL,
+    `flavor` DOUBLE NOT NULL,
+    `aftertaste` DOUBLE NOT NULL,
+    `acidity` DOUBLE NOT NULL,
+    `body` DOUBLE NOT NULL,
+    `balance` DOUBLE NOT NULL,
+    `uniformity` DOUBLE NOT NULL,
+    `clean_cup` DOUBLE NOT NULL,
+    `sweetness` DOUBLE NOT NULL,
+    `overall` DOUBLE NOT NULL,
+    `notes` VARCHAR(511) NOT NULL DEFAULT '',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `uq_cupping_scores_session_beans` UNIQUE (`session_id`, `beans_id`),
+    FOREIGN KEY (session_id) REFERENCES cupping_sessions(id) ON DELETE CASCADE,
+    FOREIGN KEY (beans_id) REFERENCES beans(id),
+    CONSTRAINT chk_cupping_scores_range CHECK (
+        fragrance BETWEEN 0 AND 10 AND flavor BETWEEN 0 AND 10 AND aftertaste BETWEEN 0 AND 10 AND
+        acidity BETWEEN 0 AND 10 AND body BETWEEN 0 AND 10 AND balance BETWEEN 0 AND 10 AND
+        uniformity BETWEEN 0 AND 10 AND clean_cup BETWEEN 0 AND 10 AND sweetness BETWEEN 0 AND 10 AND
+        overall BETWEEN 0 AND 10
+    )
+);
+
+-- +migrate Down
+DROP TABLE cupping_scores;
+DROP TABLE cupping_sessions;
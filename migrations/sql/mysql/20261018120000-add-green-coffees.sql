@@ -0,0 +1,39 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `green_coffees` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `origin` VARCHAR(255) NOT NULL,
+    `supplier` VARCHAR(255) NOT NULL DEFAULT '',
+    `purchase_weight` DOUBLE NOT NULL,
+    `price` DOUBLE NOT NULL DEFAULT 0,
+    `arrival_date` DATE,
+    `moisture` DOUBLE NOT NULL DEFAULT 0,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT chk_green_coffees_purchase_weight CHECK (purchase_weight > 0),
+    CONSTRAINT chk_green_coffees_price CHECK (price >= 0),
+    CONSTRAINT chk_green_coffees_moisture CHECK (moisture BETWEEN 0 AND 100)
+);
+
+ALTER TABLE roast_batches
+    ADD COLUMN `green_coffee_id` INT,
+    ADD CONSTRAINT fk_roast_batches_green_coffee FOREIGN KEY (green_coffee_id) REFERENCES green_coffees(id);
+
+ALTER TABLE beans
+    ADD COLUMN `green_coffee_id` INT,
+    ADD COLUMN `green_weight` DOUBLE NOT NULL DEFAULT 0,
+    ADD CONSTRAINT fk_beans_green_coffee FOREIGN KEY (green_coffee_id) REFERENCES green_coffees(id),
+    ADD CONSTRAINT chk_beans_green_weight CHECK (green_weight >= 0);
+
+-- +migrate Down
+ALTER TABLE beans
+    DROP CHECK chk_beans_green_weight,
+    DROP FOREIGN KEY fk_beans_green_coffee,
+    DROP COLUMN green_weight,
+    DROP COLUMN green_coffee_id;
+
+ALTER TABLE roast_batches
+    DROP FOREIGN KEY fk_roast_batches_green_coffee,
+    DROP COLUMN green_coffee_id;
+
+DROP TABLE green_coffees;
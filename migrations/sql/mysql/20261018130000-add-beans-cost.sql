@@ -0,0 +1,15 @@
+-- +migrate Up
+ALTER TABLE beans
+    ADD COLUMN `price` DOUBLE NOT NULL DEFAULT 0,
+    ADD COLUMN `currency` VARCHAR(3) NOT NULL DEFAULT '',
+    ADD COLUMN `bag_weight` DOUBLE NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_beans_price CHECK (price >= 0),
+    ADD CONSTRAINT chk_beans_bag_weight CHECK (bag_weight >= 0);
+
+-- +migrate Down
+ALTER TABLE beans
+    DROP CHECK chk_beans_bag_weight,
+    DROP CHECK chk_beans_price,
+    DROP COLUMN bag_weight,
+    DROP COLUMN currency,
+    DROP COLUMN price;
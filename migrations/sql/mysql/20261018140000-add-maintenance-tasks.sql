@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `maintenance_tasks` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `type` VARCHAR(32) NOT NULL,
+    `equipment` VARCHAR(16) NOT NULL DEFAULT '',
+    `equipment_name` VARCHAR(255) NOT NULL DEFAULT '',
+    `interval_shots` INT,
+    `interval_days` INT,
+    `last_done_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT chk_maintenance_tasks_type CHECK (`type` IN ('backflush', 'descale', 'burr_change', 'gasket_change', 'water_filter', 'grinder_cleaning', 'other')),
+    CONSTRAINT chk_maintenance_tasks_equipment CHECK (`equipment` IN ('', 'machine', 'grinder')),
+    CONSTRAINT chk_maintenance_tasks_interval CHECK (
+        (interval_shots IS NOT NULL OR interval_days IS NOT NULL)
+        AND (interval_shots IS NULL OR interval_shots > 0)
+        AND (interval_days IS NULL OR interval_days > 0)
+    )
+);
+
+-- +migrate Down
+DROP TABLE maintenance_tasks;
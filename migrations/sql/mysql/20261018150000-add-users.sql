@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `users` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `name` VARCHAR(255) NOT NULL,
+    `disabled` BOOLEAN NOT NULL DEFAULT FALSE,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `name` (`name`)
+);
+
+-- Rows created before users existed, or from the CLI, have no owner.
+ALTER TABLE `sheets` ADD COLUMN `owner_id` INT NULL, ADD CONSTRAINT `fk_sheets_owner` FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`);
+ALTER TABLE `roasters` ADD COLUMN `owner_id` INT NULL, ADD CONSTRAINT `fk_roasters_owner` FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`);
+ALTER TABLE `beans` ADD COLUMN `owner_id` INT NULL, ADD CONSTRAINT `fk_beans_owner` FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`);
+ALTER TABLE `shots` ADD COLUMN `owner_id` INT NULL, ADD CONSTRAINT `fk_shots_owner` FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`);
+
+-- +migrate Down
+ALTER TABLE `shots` DROP FOREIGN KEY `fk_shots_owner`, DROP COLUMN `owner_id`;
+ALTER TABLE `beans` DROP FOREIGN KEY `fk_beans_owner`, DROP COLUMN `owner_id`;
+ALTER TABLE `roasters` DROP FOREIGN KEY `fk_roasters_owner`, DROP COLUMN `owner_id`;
+ALTER TABLE `sheets` DROP FOREIGN KEY `fk_sheets_owner`, DROP COLUMN `owner_id`;
+DROP TABLE users;
@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `api_keys` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `user_id` INT NOT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `prefix` VARCHAR(16) NOT NULL,
+    `hash` CHAR(64) NOT NULL,
+    `scope` VARCHAR(16) NOT NULL,
+    `last_used_at` TIMESTAMP NULL,
+    `revoked_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `hash` (`hash`),
+    CONSTRAINT fk_api_keys_user FOREIGN KEY (`user_id`) REFERENCES `users` (`id`),
+    CONSTRAINT chk_api_keys_scope CHECK (`scope` IN ('read', 'write'))
+);
+
+-- +migrate Down
+DROP TABLE api_keys;
@@ -0,0 +1,19 @@
+-- +migrate Up
+ALTER TABLE `users` ADD COLUMN `password_hash` VARCHAR(255) NULL AFTER `name`;
+
+CREATE TABLE IF NOT EXISTS `sessions` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `user_id` INT NOT NULL,
+    `token_hash` CHAR(64) NOT NULL,
+    `csrf_token` VARCHAR(64) NOT NULL,
+    `expires_at` TIMESTAMP NOT NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `token_hash` (`token_hash`),
+    KEY `idx_sessions_expires_at` (`expires_at`),
+    CONSTRAINT fk_sessions_user FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE sessions;
+ALTER TABLE `users` DROP COLUMN `password_hash`;
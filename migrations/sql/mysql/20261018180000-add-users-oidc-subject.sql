@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE `users` ADD COLUMN `oidc_subject` VARCHAR(255) NULL AFTER `password_hash`;
+ALTER TABLE `users` ADD UNIQUE KEY `oidc_subject` (`oidc_subject`);
+
+-- +migrate Down
+ALTER TABLE `users` DROP INDEX `oidc_subject`;
+ALTER TABLE `users` DROP COLUMN `oidc_subject`;
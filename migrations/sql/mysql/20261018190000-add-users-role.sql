@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE `users` ADD COLUMN `role` VARCHAR(16) NOT NULL DEFAULT 'barista' AFTER `disabled`;
+ALTER TABLE `users` ADD CONSTRAINT chk_users_role CHECK (`role` IN ('viewer', 'barista', 'admin'));
+-- Users created before roles existed keep full access.
+UPDATE `users` SET `role` = 'admin';
+
+-- +migrate Down
+ALTER TABLE `users` DROP CHECK chk_users_role;
+ALTER TABLE `users` DROP COLUMN `role`;
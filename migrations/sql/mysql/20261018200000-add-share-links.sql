@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `share_links` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `sheet_id` INT NOT NULL,
+    `owner_id` INT NULL,
+    `expires_at` TIMESTAMP NOT NULL,
+    `revoked_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_share_links_sheet_id` (`sheet_id`),
+    CONSTRAINT fk_share_links_sheet FOREIGN KEY (`sheet_id`) REFERENCES `sheets` (`id`) ON DELETE CASCADE,
+    CONSTRAINT fk_share_links_owner FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`)
+);
+
+-- +migrate Down
+DROP TABLE share_links;
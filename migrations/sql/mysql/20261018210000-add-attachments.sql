@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `attachments` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `shot_id` INT NULL,
+    `beans_id` INT NULL,
+    `owner_id` INT NULL,
+    `filename` VARCHAR(255) NOT NULL,
+    `content_type` VARCHAR(64) NOT NULL,
+    `size` BIGINT NOT NULL,
+    `width` INT NOT NULL,
+    `height` INT NOT NULL,
+    `blob_key` VARCHAR(255) NOT NULL,
+    `thumbnail_key` VARCHAR(255) NOT NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_attachments_shot_id` (`shot_id`),
+    KEY `idx_attachments_beans_id` (`beans_id`),
+    CONSTRAINT fk_attachments_shot FOREIGN KEY (`shot_id`) REFERENCES `shots` (`id`) ON DELETE CASCADE,
+    CONSTRAINT fk_attachments_beans FOREIGN KEY (`beans_id`) REFERENCES `beans` (`id`) ON DELETE CASCADE,
+    CONSTRAINT fk_attachments_owner FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`)
+);
+
+-- +migrate Down
+DROP TABLE attachments;
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `shot_imports` (
+    `shot_id` INT NOT NULL,
+    `source` VARCHAR(32) NOT NULL,
+    `external_id` VARCHAR(255) NOT NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`shot_id`),
+    KEY `idx_shot_imports_source_external_id` (`source`, `external_id`),
+    FOREIGN KEY (shot_id) REFERENCES shots(id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE shot_imports;
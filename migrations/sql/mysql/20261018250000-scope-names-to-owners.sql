@@ -0,0 +1,9 @@
+-- +migrate Up
+-- Sheet and roaster names are unique per owner. The rows without an owner
+-- share one, so their names stay unique when authentication is disabled.
+ALTER TABLE `sheets` DROP INDEX `name`, ADD UNIQUE INDEX `uq_sheets_owner_name` ((COALESCE(`owner_id`, 0)), `name`);
+ALTER TABLE `roasters` DROP INDEX `name`, ADD UNIQUE INDEX `uq_roasters_owner_name` ((COALESCE(`owner_id`, 0)), `name`);
+
+-- +migrate Down
+ALTER TABLE `roasters` DROP INDEX `uq_roasters_owner_name`, ADD UNIQUE INDEX `name` (`name`);
+ALTER TABLE `sheets` DROP INDEX `uq_sheets_owner_name`, ADD UNIQUE INDEX `name` (`name`);
@@ -0,0 +1,14 @@
+-- +migrate Up
+-- Rows created before these records were scoped, or from the CLI, have no owner.
+ALTER TABLE `cupping_sessions` ADD COLUMN `owner_id` INT NULL, ADD CONSTRAINT `fk_cupping_sessions_owner` FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`);
+ALTER TABLE `cupping_scores` ADD COLUMN `owner_id` INT NULL, ADD CONSTRAINT `fk_cupping_scores_owner` FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`);
+ALTER TABLE `green_coffees` ADD COLUMN `owner_id` INT NULL, ADD CONSTRAINT `fk_green_coffees_owner` FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`);
+ALTER TABLE `roast_batches` ADD COLUMN `owner_id` INT NULL, ADD CONSTRAINT `fk_roast_batches_owner` FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`);
+ALTER TABLE `maintenance_tasks` ADD COLUMN `owner_id` INT NULL, ADD CONSTRAINT `fk_maintenance_tasks_owner` FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`);
+
+-- +migrate Down
+ALTER TABLE `maintenance_tasks` DROP FOREIGN KEY `fk_maintenance_tasks_owner`, DROP COLUMN `owner_id`;
+ALTER TABLE `roast_batches` DROP FOREIGN KEY `fk_roast_batches_owner`, DROP COLUMN `owner_id`;
+ALTER TABLE `green_coffees` DROP FOREIGN KEY `fk_green_coffees_owner`, DROP COLUMN `owner_id`;
+ALTER TABLE `cupping_scores` DROP FOREIGN KEY `fk_cupping_scores_owner`, DROP COLUMN `owner_id`;
+ALTER TABLE `cupping_sessions` DROP FOREIGN KEY `fk_cupping_sessions_owner`, DROP COLUMN `owner_id`;
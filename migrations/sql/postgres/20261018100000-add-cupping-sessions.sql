@@ -0,0 +1,45 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "cupping_sessions" (
+    "id" SERIAL PRIMARY KEY,
+    "session_date" DATE NOT NULL,
+    "participants" VARCHAR(511) NOT NULL DEFAULT '',
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    "updated_at" TIMESTAMP WITH TIME ZONE -- updated by trigger
+);
+CREATE TRIGGER update_updated_at_cupping_sessions BEFORE
+UPDATE ON cupping_sessions FOR EACH ROW EXECUTE PROCEDURE update_updated_at();
+CREATE TABLE IF NOT EXISTS "cupping_scores" (
+    "id" SERIAL PRIMARY KEY,
+    "session_id" INT NOT NULL,
+    "beans_id" INT NOT NULL,
+    "fragrance" DECIMAL NOT NULL,
+    "flavor" DECIMAL NOT NULL,
+    "aftertaste" DECIMAL NOT NULL,
+    "acidity" DECIMAL NOT NULL,
+    "body" DECIMAL NOT NULL,
+    "balance" DECIMAL NOT NULL,
+    "uniformity" DECIMAL NOT NULL,
+    "clean_cup" DECIMAL NOT NULL,
+    "sweetness" DECIMAL NOT NULL,
+    "overall" DECIMAL NOT NULL,
+    "notes" VARCHAR(511) NOT NULL DEFAULT '',
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    "updated_at" TIMESTAMP WITH TIME ZONE, -- updated by trigger
+    CONSTRAINT uq_cupping_scores_session_beans UNIQUE (session_id, beans_id),
+    FOREIGN KEY (session_id) REFERENCES cupping_sessions(id) ON DELETE CASCADE,
+    FOREIGN KEY (beans_id) REFERENCES beans(id),
+    CONSTRAINT chk_cupping_scores_range CHECK (
+        fragrance BETWEEN 0 AND 10 AND flavor BETWEEN 0 AND 10 AND aftertaste BETWEEN 0 AND 10 AND
+        acidity BETWEEN 0 AND 10 AND body BETWEEN 0 AND 10 AND balance BETWEEN 0 AND 10 AND
+        uniformity BETWEEN 0 AND 10 AND clean_cup BETWEEN 0 AND 10 AND sweetness BETWEEN 0 AND 10 AND
+        overall BETWEEN 0 AND 10
+    )
+);
+CREATE TRIGGER update_updated_at_cupping_scores BEFORE
+UPDATE ON cupping_scores FOR EACH ROW EXECUTE PROCEDURE update_updated_at();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS update_updated_at_cupping_scores ON cupping_scores;
+DROP TRIGGER IF EXISTS update_updated_at_cupping_sessions ON cupping_sessions;
+DROP TABLE IF EXISTS cupping_scores;
+DROP TABLE IF EXISTS cupping_sessions;
@@ -0,0 +1,35 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "roast_batches" (
+    "id" SERIAL PRIMARY KEY,
+    "green_coffee" VARCHAR(255) NOT NULL,
+    "roast_date" DATE NOT NULL,
+    "roast_level" SMALLINT NOT NULL,
+    "green_weight" DECIMAL NOT NULL,
+    "roasted_weight" DECIMAL NOT NULL,
+    "charge_temperature" DECIMAL NOT NULL DEFAULT 0,
+    "first_crack_time" INT NOT NULL DEFAULT 0,
+    "development_time" INT NOT NULL DEFAULT 0,
+    "end_temperature" DECIMAL NOT NULL DEFAULT 0,
+    "beans_id" INT,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    "updated_at" TIMESTAMP WITH TIME ZONE, -- updated by trigger
+    FOREIGN KEY (beans_id) REFERENCES beans(id) ON DELETE SET NULL,
+    CONSTRAINT chk_roast_batches_roast_level CHECK (roast_level BETWEEN 0 AND 4),
+    CONSTRAINT chk_roast_batches_weights CHECK (green_weight > 0 AND roasted_weight >= 0 AND roasted_weight <= green_weight),
+    CONSTRAINT chk_roast_batches_times CHECK (first_crack_time >= 0 AND development_time >= 0)
+);
+CREATE TRIGGER update_updated_at_roast_batches BEFORE
+UPDATE ON roast_batches FOR EACH ROW EXECUTE PROCEDURE update_updated_at();
+CREATE TABLE IF NOT EXISTS "roast_curve_points" (
+    "roast_batch_id" INT NOT NULL,
+    "elapsed_time" INT NOT NULL,
+    "temperature" DECIMAL NOT NULL,
+    PRIMARY KEY (roast_batch_id, elapsed_time),
+    FOREIGN KEY (roast_batch_id) REFERENCES roast_batches(id) ON DELETE CASCADE,
+    CONSTRAINT chk_roast_curve_points_elapsed_time CHECK (elapsed_time >= 0)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS roast_curve_points;
+DROP TRIGGER IF EXISTS update_updated_at_roast_batches ON roast_batches;
+DROP TABLE IF EXISTS roast_batches;
@@ -0,0 +1,41 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "green_coffees" (
+    "id" SERIAL PRIMARY KEY,
+    "origin" VARCHAR(255) NOT NULL,
+    "supplier" VARCHAR(255) NOT NULL DEFAULT '',
+    "purchase_weight" DECIMAL NOT NULL,
+    "price" DECIMAL NOT NULL DEFAULT 0,
+    "arrival_date" DATE,
+    "moisture" DECIMAL NOT NULL DEFAULT 0,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    "updated_at" TIMESTAMP WITH TIME ZONE, -- updated by trigger
+    CONSTRAINT chk_green_coffees_purchase_weight CHECK (purchase_weight > 0),
+    CONSTRAINT chk_green_coffees_price CHECK (price >= 0),
+    CONSTRAINT chk_green_coffees_moisture CHECK (moisture BETWEEN 0 AND 100)
+);
+CREATE TRIGGER update_updated_at_green_coffees BEFORE
+UPDATE ON green_coffees FOR EACH ROW EXECUTE PROCEDURE update_updated_at();
+
+ALTER TABLE roast_batches
+    ADD COLUMN "green_coffee_id" INT,
+    ADD CONSTRAINT fk_roast_batches_green_coffee FOREIGN KEY (green_coffee_id) REFERENCES green_coffees(id);
+
+ALTER TABLE beans
+    ADD COLUMN "green_coffee_id" INT,
+    ADD COLUMN "green_weight" DECIMAL NOT NULL DEFAULT 0,
+    ADD CONSTRAINT fk_beans_green_coffee FOREIGN KEY (green_coffee_id) REFERENCES green_coffees(id),
+    ADD CONSTRAINT chk_beans_green_weight CHECK (green_weight >= 0);
+
+-- +migrate Down
+ALTER TABLE beans
+    DROP CONSTRAINT IF EXISTS chk_beans_green_weight,
+    DROP CONSTRAINT IF EXISTS fk_beans_green_coffee,
+    DROP COLUMN IF EXISTS green_weight,
+    DROP COLUMN IF EXISTS green_coffee_id;
+
+ALTER TABLE roast_batches
+    DROP CONSTRAINT IF EXISTS fk_roast_batches_green_coffee,
+    DROP COLUMN IF EXISTS green_coffee_id;
+
+DROP TRIGGER IF EXISTS update_updated_at_green_coffees ON green_coffees;
+DROP TABLE IF EXISTS green_coffees;
@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "maintenance_tasks" (
+    "id" SERIAL PRIMARY KEY,
+    "type" VARCHAR(32) NOT NULL,
+    "equipment" VARCHAR(16) NOT NULL DEFAULT '',
+    "equipment_name" VARCHAR(255) NOT NULL DEFAULT '',
+    "interval_shots" INT,
+    "interval_days" INT,
+    "last_done_at" TIMESTAMP WITH TIME ZONE,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    "updated_at" TIMESTAMP WITH TIME ZONE, -- updated by trigger
+    CONSTRAINT chk_maintenance_tasks_type CHECK ("type" IN ('backflush', 'descale', 'burr_change', 'gasket_change', 'water_filter', 'grinder_cleaning', 'other')),
+    CONSTRAINT chk_maintenance_tasks_equipment CHECK ("equipment" IN ('', 'machine', 'grinder')),
+    CONSTRAINT chk_maintenance_tasks_interval CHECK (
+        (interval_shots IS NOT NULL OR interval_days IS NOT NULL)
+        AND (interval_shots IS NULL OR interval_shots > 0)
+        AND (interval_days IS NULL OR interval_days > 0)
+    )
+);
+CREATE TRIGGER update_updated_at_maintenance_tasks BEFORE
+UPDATE ON maintenance_tasks FOR EACH ROW EXECUTE PROCEDURE update_updated_at();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS update_updated_at_maintenance_tasks ON maintenance_tasks;
+DROP TABLE IF EXISTS maintenance_tasks;
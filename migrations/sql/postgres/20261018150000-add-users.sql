@@ -0,0 +1,28 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" SERIAL PRIMARY KEY,
+    "name" VARCHAR(255) NOT NULL UNIQUE,
+    "disabled" BOOLEAN NOT NULL DEFAULT FALSE,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    "updated_at" TIMESTAMP WITH TIME ZONE -- updated by trigger
+);
+CREATE TRIGGER update_updated_at_users BEFORE
+UPDATE ON users FOR EACH ROW EXECUTE PROCEDURE update_updated_at();
+
+-- Rows created before users existed, or from the CLI, have no owner.
+ALTER TABLE "sheets" ADD COLUMN "owner_id" INT CONSTRAINT fk_sheets_owner REFERENCES users (id);
+ALTER TABLE "roasters" ADD COLUMN "owner_id" INT CONSTRAINT fk_roasters_owner REFERENCES users (id);
+ALTER TABLE "beans" ADD COLUMN "owner_id" INT CONSTRAINT fk_beans_owner REFERENCES users (id);
+ALTER TABLE "shots" ADD COLUMN "owner_id" INT CONSTRAINT fk_shots_owner REFERENCES users (id);
+CREATE INDEX idx_sheets_owner_id ON sheets (owner_id);
+CREATE INDEX idx_roasters_owner_id ON roasters (owner_id);
+CREATE INDEX idx_beans_owner_id ON beans (owner_id);
+CREATE INDEX idx_shots_owner_id ON shots (owner_id);
+
+-- +migrate Down
+ALTER TABLE "shots" DROP COLUMN IF EXISTS "owner_id";
+ALTER TABLE "beans" DROP COLUMN IF EXISTS "owner_id";
+ALTER TABLE "roasters" DROP COLUMN IF EXISTS "owner_id";
+ALTER TABLE "sheets" DROP COLUMN IF EXISTS "owner_id";
+DROP TRIGGER IF EXISTS update_updated_at_users ON users;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "api_keys" (
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INT NOT NULL CONSTRAINT fk_api_keys_user REFERENCES users (id),
+    "name" VARCHAR(255) NOT NULL,
+    "prefix" VARCHAR(16) NOT NULL,
+    "hash" CHAR(64) NOT NULL UNIQUE,
+    "scope" VARCHAR(16) NOT NULL,
+    "last_used_at" TIMESTAMP WITH TIME ZONE,
+    "revoked_at" TIMESTAMP WITH TIME ZONE,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    "updated_at" TIMESTAMP WITH TIME ZONE, -- updated by trigger
+    CONSTRAINT chk_api_keys_scope CHECK ("scope" IN ('read', 'write'))
+);
+CREATE TRIGGER update_updated_at_api_keys BEFORE
+UPDATE ON api_keys FOR EACH ROW EXECUTE PROCEDURE update_updated_at();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS update_updated_at_api_keys ON api_keys;
+DROP TABLE IF EXISTS api_keys;
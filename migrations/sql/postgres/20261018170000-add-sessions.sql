@@ -0,0 +1,16 @@
+-- +migrate Up
+ALTER TABLE "users" ADD COLUMN "password_hash" VARCHAR(255);
+
+CREATE TABLE IF NOT EXISTS "sessions" (
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INT NOT NULL CONSTRAINT fk_sessions_user REFERENCES users (id) ON DELETE CASCADE,
+    "token_hash" CHAR(64) NOT NULL UNIQUE,
+    "csrf_token" VARCHAR(64) NOT NULL,
+    "expires_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_expires_at ON sessions (expires_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS sessions;
+ALTER TABLE "users" DROP COLUMN IF EXISTS "password_hash";
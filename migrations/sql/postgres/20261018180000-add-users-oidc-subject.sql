@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "users" ADD COLUMN "oidc_subject" VARCHAR(255) UNIQUE;
+
+-- +migrate Down
+ALTER TABLE "users" DROP COLUMN IF EXISTS "oidc_subject";
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE "users" ADD COLUMN "role" VARCHAR(16) NOT NULL DEFAULT 'barista';
+ALTER TABLE "users" ADD CONSTRAINT chk_users_role CHECK ("role" IN ('viewer', 'barista', 'admin'));
+-- Users created before roles existed keep full access.
+UPDATE "users" SET "role" = 'admin';
+
+-- +migrate Down
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS chk_users_role;
+ALTER TABLE "users" DROP COLUMN IF EXISTS "role";
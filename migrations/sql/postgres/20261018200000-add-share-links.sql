@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "share_links" (
+    "id" SERIAL PRIMARY KEY,
+    "sheet_id" INT NOT NULL CONSTRAINT fk_share_links_sheet REFERENCES sheets (id) ON DELETE CASCADE,
+    "owner_id" INT CONSTRAINT fk_share_links_owner REFERENCES users (id),
+    "expires_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "revoked_at" TIMESTAMP WITH TIME ZONE,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_share_links_sheet_id ON share_links (sheet_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS share_links;
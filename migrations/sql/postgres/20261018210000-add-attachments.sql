@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "attachments" (
+    "id" SERIAL PRIMARY KEY,
+    "shot_id" INT CONSTRAINT fk_attachments_shot REFERENCES shots (id) ON DELETE CASCADE,
+    "beans_id" INT CONSTRAINT fk_attachments_beans REFERENCES beans (id) ON DELETE CASCADE,
+    "owner_id" INT CONSTRAINT fk_attachments_owner REFERENCES users (id),
+    "filename" VARCHAR(255) NOT NULL,
+    "content_type" VARCHAR(64) NOT NULL,
+    "size" BIGINT NOT NULL,
+    "width" INT NOT NULL,
+    "height" INT NOT NULL,
+    "blob_key" VARCHAR(255) NOT NULL,
+    "thumbnail_key" VARCHAR(255) NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_attachments_shot_id ON attachments (shot_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_beans_id ON attachments (beans_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS attachments;
@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "shot_profile_samples" (
+    "shot_id" INT NOT NULL,
+    "elapsed_time" INT NOT NULL,
+    "pressure" DECIMAL,
+    "flow" DECIMAL,
+    "weight" DECIMAL,
+    "temperature" DECIMAL,
+    PRIMARY KEY (shot_id, elapsed_time),
+    FOREIGN KEY (shot_id) REFERENCES shots(id) ON DELETE CASCADE,
+    CONSTRAINT chk_shot_profile_samples_elapsed_time CHECK (elapsed_time >= 0)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS shot_profile_samples;
@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "shot_imports" (
+    "shot_id" INT PRIMARY KEY CONSTRAINT fk_shot_imports_shot REFERENCES shots (id) ON DELETE CASCADE,
+    "source" VARCHAR(32) NOT NULL,
+    "external_id" VARCHAR(255) NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_shot_imports_source_external_id ON shot_imports (source, external_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS shot_imports;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE sheets
+    ADD COLUMN "is_template" BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE sheets
+    DROP COLUMN IF EXISTS is_template;
@@ -0,0 +1,13 @@
+-- +migrate Up
+-- Sheet and roaster names are unique per owner. The rows without an owner
+-- share one, so their names stay unique when authentication is disabled.
+ALTER TABLE "sheets" DROP CONSTRAINT IF EXISTS sheets_name_key;
+ALTER TABLE "roasters" DROP CONSTRAINT IF EXISTS roasters_name_key;
+CREATE UNIQUE INDEX uq_sheets_owner_name ON sheets (COALESCE(owner_id, 0), name);
+CREATE UNIQUE INDEX uq_roasters_owner_name ON roasters (COALESCE(owner_id, 0), name);
+
+-- +migrate Down
+DROP INDEX IF EXISTS uq_roasters_owner_name;
+DROP INDEX IF EXISTS uq_sheets_owner_name;
+ALTER TABLE "roasters" ADD CONSTRAINT roasters_name_key UNIQUE (name);
+ALTER TABLE "sheets" ADD CONSTRAINT sheets_name_key UNIQUE (name);
@@ -0,0 +1,19 @@
+-- +migrate Up
+-- Rows created before these records were scoped, or from the CLI, have no owner.
+ALTER TABLE "cupping_sessions" ADD COLUMN "owner_id" INT CONSTRAINT fk_cupping_sessions_owner REFERENCES users (id);
+ALTER TABLE "cupping_scores" ADD COLUMN "owner_id" INT CONSTRAINT fk_cupping_scores_owner REFERENCES users (id);
+ALTER TABLE "green_coffees" ADD COLUMN "owner_id" INT CONSTRAINT fk_green_coffees_owner REFERENCES users (id);
+ALTER TABLE "roast_batches" ADD COLUMN "owner_id" INT CONSTRAINT fk_roast_batches_owner REFERENCES users (id);
+ALTER TABLE "maintenance_tasks" ADD COLUMN "owner_id" INT CONSTRAINT fk_maintenance_tasks_owner REFERENCES users (id);
+CREATE INDEX idx_cupping_sessions_owner_id ON cupping_sessions (owner_id);
+CREATE INDEX idx_cupping_scores_owner_id ON cupping_scores (owner_id);
+CREATE INDEX idx_green_coffees_owner_id ON green_coffees (owner_id);
+CREATE INDEX idx_roast_batches_owner_id ON roast_batches (owner_id);
+CREATE INDEX idx_maintenance_tasks_owner_id ON maintenance_tasks (owner_id);
+
+-- +migrate Down
+ALTER TABLE "maintenance_tasks" DROP COLUMN IF EXISTS "owner_id";
+ALTER TABLE "roast_batches" DROP COLUMN IF EXISTS "owner_id";
+ALTER TABLE "green_coffees" DROP COLUMN IF EXISTS "owner_id";
+ALTER TABLE "cupping_scores" DROP COLUMN IF EXISTS "owner_id";
+ALTER TABLE "cupping_sessions" DROP COLUMN IF EXISTS "owner_id";
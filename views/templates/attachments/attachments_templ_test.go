@@ -0,0 +1,64 @@
+package attachments
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ"
+	"github.com/lescactus/espressoapi-go/internal/auth"
+	"github.com/lescactus/espressoapi-go/internal/services/attachment"
+)
+
+func renderAs(t *testing.T, role auth.Role, c templ.Component) string {
+	t.Helper()
+	ctx := auth.NewContext(context.Background(), &auth.User{Id: 1, Name: "alice", Role: role})
+	var b strings.Builder
+	if err := c.Render(ctx, &b); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return b.String()
+}
+
+func TestGallery_ShowsThumbnailsLinkingToPhotos(t *testing.T) {
+	items := []attachment.Attachment{{Id: 4, Filename: "crema.png"}, {Id: 9, Filename: "puck.jpg"}}
+
+	html := renderAs(t, auth.RoleAdmin, Gallery(ShotUploadPath(5), items))
+
+	for _, want := range []string{
+		`href="/attachments/content/4"`,
+		`src="/attachments/thumbnail/4"`,
+		`src="/attachments/thumbnail/9"`,
+		`alt="puck.jpg"`,
+		`hx-post="/shots/attachments/5"`,
+		`hx-delete="/attachments/delete/9"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected %s in the gallery, got: %s", want, html)
+		}
+	}
+	if strings.Contains(html, "No photo yet.") {
+		t.Errorf("expected no empty state with photos, got: %s", html)
+	}
+}
+
+func TestGallery_EmptyStateOffersUpload(t *testing.T) {
+	html := renderAs(t, auth.RoleBarista, Gallery(BeansUploadPath(2), nil))
+
+	if !strings.Contains(html, "No photo yet.") || !strings.Contains(html, `hx-post="/beans/attachments/2"`) {
+		t.Errorf("expected the empty state and the upload form, got: %s", html)
+	}
+}
+
+func TestGallery_HidesActionsTheRoleCannotPerform(t *testing.T) {
+	items := []attachment.Attachment{{Id: 4, Filename: "crema.png"}}
+
+	html := renderAs(t, auth.RoleViewer, Gallery(ShotUploadPath(5), items))
+
+	if !strings.Contains(html, `src="/attachments/thumbnail/4"`) {
+		t.Errorf("expected viewers to see the thumbnails, got: %s", html)
+	}
+	if strings.Contains(html, "hx-post") || strings.Contains(html, "hx-delete") {
+		t.Errorf("expected viewers to not be offered upload or delete, got: %s", html)
+	}
+}
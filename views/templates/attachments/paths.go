@@ -0,0 +1,13 @@
+package attachments
+
+import "strconv"
+
+func contentPath(id int) string   { return "/attachments/content/" + strconv.Itoa(id) }
+func thumbnailPath(id int) string { return "/attachments/thumbnail/" + strconv.Itoa(id) }
+func deletePath(id int) string    { return "/attachments/delete/" + strconv.Itoa(id) }
+
+// ShotUploadPath is the path uploading a photo of the shot id.
+func ShotUploadPath(id int) string { return "/shots/attachments/" + strconv.Itoa(id) }
+
+// BeansUploadPath is the path uploading a photo of the beans id.
+func BeansUploadPath(id int) string { return "/beans/attachments/" + strconv.Itoa(id) }
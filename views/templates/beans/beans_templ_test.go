@@ -9,7 +9,9 @@ import (
 	"github.com/a-h/templ"
 	"github.com/lescactus/espressoapi-go/internal/models/sql"
 	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/greencoffee"
 	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
 )
 
 func render(t *testing.T, c templ.Component) string {
@@ -53,7 +55,7 @@ func TestRow_RoasterCellShowsNameWithoutID(t *testing.T) {
 }
 
 func TestRowPage_IncludesDialogTargetForEditLink(t *testing.T) {
-	html := render(t, RowPage(testBean()))
+	html := render(t, RowPage(testBean(), nil, stats.ShotStats{}))
 
 	if !strings.Contains(html, "<html") || !strings.Contains(html, "<table") || !strings.Contains(html, "Ethiopia Yirgacheffe") {
 		t.Errorf("expected a full page with a one-row table, got: %s", html)
@@ -81,7 +83,7 @@ func TestRow_OOBModes(t *testing.T) {
 }
 
 func TestForm_EmptyRoastersDisablesSubmitAndShowsHint(t *testing.T) {
-	html := render(t, Form(FormState{}, nil, true, "", ""))
+	html := render(t, Form(FormState{}, nil, nil, true, "", ""))
 
 	if !strings.Contains(html, "Create one first") {
 		t.Errorf("expected a hint to create a roaster first, got: %s", html)
@@ -93,7 +95,7 @@ func TestForm_EmptyRoastersDisablesSubmitAndShowsHint(t *testing.T) {
 
 func TestForm_EditModeShowsReadOnlyMetadata(t *testing.T) {
 	state := FormState{ID: 9, Name: "Ethiopia", RoasterID: "3", RoastLevel: "2"}
-	html := render(t, Form(state, []roaster.Roaster{{Id: 3, Name: "Blue Bottle"}}, false, "2026-01-02 03:04", "2026-01-05 06:07"))
+	html := render(t, Form(state, []roaster.Roaster{{Id: 3, Name: "Blue Bottle"}}, nil, false, "2026-01-02 03:04", "2026-01-05 06:07"))
 
 	if strings.Contains(html, `name="id"`) || strings.Contains(html, `name="created_at"`) {
 		t.Errorf("expected id/created_at to not be editable inputs, got: %s", html)
@@ -108,7 +110,7 @@ func TestForm_EditModeShowsReadOnlyMetadata(t *testing.T) {
 
 func TestForm_ShowsInlineFieldErrors(t *testing.T) {
 	state := FormState{Name: "", Errors: map[string]string{"name": "Beans name must not be empty."}}
-	html := render(t, Form(state, []roaster.Roaster{{Id: 1, Name: "Roaster"}}, true, "", ""))
+	html := render(t, Form(state, []roaster.Roaster{{Id: 1, Name: "Roaster"}}, nil, true, "", ""))
 
 	if !strings.Contains(html, "Beans name must not be empty.") {
 		t.Errorf("expected inline error message, got: %s", html)
@@ -117,3 +119,26 @@ func TestForm_ShowsInlineFieldErrors(t *testing.T) {
 		t.Errorf("expected aria-invalid on the errored field, got: %s", html)
 	}
 }
+
+func TestForm_PreselectsGreenCoffeeWithRemainingStock(t *testing.T) {
+	state := FormState{RoasterID: "1", GreenCoffeeID: "4", GreenWeight: "250"}
+	greenCoffees := []greencoffee.GreenCoffee{{Id: 4, Origin: "Ethiopia Guji", RemainingWeight: 1.75}}
+	html := render(t, Form(state, []roaster.Roaster{{Id: 1, Name: "Roaster"}}, greenCoffees, true, "", ""))
+
+	for _, want := range []string{`<option value="">None</option>`, `<option value="4" selected>Ethiopia Guji (1.75 kg left)</option>`, `value="250"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected form to contain %q, got: %s", want, html)
+		}
+	}
+}
+
+func TestForm_ShowsCostFieldsAndErrors(t *testing.T) {
+	state := FormState{RoasterID: "1", Price: "15.5", Currency: "EURO", BagWeight: "250", Errors: map[string]string{"currency": "Currency must be a three-letter code, such as EUR."}}
+	html := render(t, Form(state, []roaster.Roaster{{Id: 1, Name: "Roaster"}}, nil, true, "", ""))
+
+	for _, want := range []string{`name="price"`, `value="15.5"`, `name="currency"`, `value="EURO"`, `name="bag_weight"`, `value="250"`, "Currency must be a three-letter code, such as EUR."} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected form to contain %q, got: %s", want, html)
+		}
+	}
+}
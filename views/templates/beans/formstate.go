@@ -9,14 +9,24 @@ import "strconv"
 // validation errors so invalid input can be redisplayed after a 400/409
 // response. FormError holds an error not tied to any single field (a
 // malformed or oversized request body, or an unexpected failure).
+//
+// GreenCoffeeID and GreenWeight record the green coffee bought beans were
+// roasted from, for home roasters tracking their stock; both are optional.
+// Price, Currency and BagWeight record what a bag cost, so the cost of each
+// shot can be derived; they are optional too.
 type FormState struct {
-	ID         int
-	Name       string
-	RoasterID  string
-	RoastDate  string
-	RoastLevel string
-	Errors     map[string]string
-	FormError  string
+	ID            int
+	Name          string
+	RoasterID     string
+	RoastDate     string
+	RoastLevel    string
+	GreenCoffeeID string
+	GreenWeight   string
+	Price         string
+	Currency      string
+	BagWeight     string
+	Errors        map[string]string
+	FormError     string
 }
 
 func (s FormState) fieldError(field string) string {
@@ -29,3 +39,4 @@ func (s FormState) fieldError(field string) string {
 func rowElementID(id int) string { return "bean-row-" + strconv.Itoa(id) }
 func updatePath(id int) string   { return "/beans/update/" + strconv.Itoa(id) }
 func deletePath(id int) string   { return "/beans/delete/" + strconv.Itoa(id) }
+func cuppingsPath(id int) string { return "/beans/cuppings/" + strconv.Itoa(id) }
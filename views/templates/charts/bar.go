@@ -0,0 +1,83 @@
+package charts
+
+import "strconv"
+
+// Bar chart geometry, in SVG user units. Each bar is a row, with its label
+// on the left and its value on the right of the plot area.
+const (
+	barLabelWidth = 200
+	barValueWidth = 100
+	barHeight     = 20
+	barSpacing    = 8
+	barMargin     = 4
+)
+
+// BarChart is a horizontal bar chart to render.
+type BarChart struct {
+	// ID is the id of the svg element.
+	ID string
+	// Title describes the chart to assistive technologies and is shown under
+	// it.
+	Title string
+	Bars  []Bar
+}
+
+// Bar is a bar of a chart, drawn in its order. Bars are as long as their
+// value relative to the largest value of the same Scale: values of
+// different scales, such as amounts in different currencies, do not
+// compare.
+type Bar struct {
+	Label string
+	Value float64
+	// ValueLabel renders the value next to the bar.
+	ValueLabel string
+	Scale      string
+	Color      string
+}
+
+// plotBar is a bar laid out in its row.
+type plotBar struct {
+	Label string
+	Value string
+	Color string
+	Y     float64
+	TextY float64
+	Width float64
+}
+
+// barPlot is the precomputed geometry of a bar chart, as tall as its rows.
+type barPlot struct {
+	ViewBox string
+	BarX    float64
+	ValueX  float64
+	Bars    []plotBar
+}
+
+func newBarPlot(c BarChart) barPlot {
+	largest := make(map[string]float64)
+	for _, b := range c.Bars {
+		largest[b.Scale] = max(largest[b.Scale], b.Value)
+	}
+
+	plotWidth := float64(chartWidth - barLabelWidth - barValueWidth)
+	p := barPlot{
+		Bars:   make([]plotBar, len(c.Bars)),
+		BarX:   barLabelWidth,
+		ValueX: chartWidth - barValueWidth + barMargin,
+	}
+	for i, b := range c.Bars {
+		y := float64(barMargin + i*(barHeight+barSpacing))
+		width := 0.0
+		if largest[b.Scale] > 0 {
+			width = b.Value / largest[b.Scale] * plotWidth
+		}
+		color := b.Color
+		if color == "" {
+			color = defaultColor
+		}
+		p.Bars[i] = plotBar{Label: b.Label, Value: b.ValueLabel, Color: color, Y: y, TextY: y + barHeight - 5, Width: width}
+	}
+	height := 2*barMargin + len(c.Bars)*(barHeight+barSpacing)
+	p.ViewBox = "0 0 " + strconv.Itoa(chartWidth) + " " + strconv.Itoa(height)
+	return p
+}
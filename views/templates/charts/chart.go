@@ -0,0 +1,311 @@
+// Package charts renders scatter, line and bar charts as inline SVG,
+// server-side and without any script. Every scatter or line chart is a set of
+// series of points, scaled into a plot area with round tick values on both
+// axes.
+package charts
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Chart geometry, in SVG user units. The plot area is inset by the margins
+// to leave room for the tick labels, the axis labels and the legend.
+const (
+	chartWidth   = 640
+	chartHeight  = 320
+	marginLeft   = 64
+	marginRight  = 16
+	marginTop    = 16
+	marginBottom = 48
+	legendHeight = 20
+	axisWidth    = 56
+	tickCount    = 5
+)
+
+// defaultColor is the color of the series without one.
+const defaultColor = "var(--pico-primary)"
+
+// Chart is a chart to render: its axes and its series.
+type Chart struct {
+	// ID is the id of the svg element.
+	ID string
+	// Title describes the chart to assistive technologies and is shown under
+	// it.
+	Title  string
+	XLabel string
+	YLabel string
+	// IntegerX keeps the ticks of the x axis on whole numbers, for counts and
+	// settings.
+	IntegerX bool
+	// FormatX renders the ticks of the x axis, such as durations, instead of
+	// plain numbers.
+	FormatX func(float64) string
+	// ZeroX and ZeroY start the x and y axes at zero rather than at the
+	// lowest point, for times and amounts.
+	ZeroX bool
+	ZeroY bool
+	// RightAxes are extra y axes on the right of the plot area, for series
+	// whose values do not compare with the others'.
+	RightAxes []Axis
+	// Guides mark values of the x axis with a dashed vertical line. Those
+	// outside the range of the points are left out.
+	Guides []Guide
+	Series []Series
+}
+
+// Series is a set of points drawn alike. A legend names the series when a
+// chart has several.
+type Series struct {
+	Name   string
+	Color  string
+	Points []Point
+	// Axis is the y axis the series is plotted against: 0 for the left one,
+	// and i for RightAxes[i-1].
+	Axis int
+	// HidePoints draws the line of a line chart only, without a marker per
+	// point, for dense readings such as curves.
+	HidePoints bool
+}
+
+// Axis is a y axis on the right of the plot area, drawn in the color of its
+// series.
+type Axis struct {
+	Label string
+	Color string
+	Zero  bool
+}
+
+// Guide is a labelled value of the x axis, such as an event of a curve.
+type Guide struct {
+	X     float64
+	Label string
+}
+
+// Point is a point of a series. Title is shown when hovering the point, and
+// Label next to it. A highlighted point is drawn larger than the others.
+type Point struct {
+	X         float64
+	Y         float64
+	Title     string
+	Label     string
+	Highlight bool
+}
+
+// Empty reports whether the chart has no point to draw.
+func (c Chart) Empty() bool {
+	for _, s := range c.Series {
+		if len(s.Points) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// tick is a round value of an axis, at its position in the plot area.
+type tick struct {
+	Pos   float64
+	Label string
+}
+
+// marker is a point scaled into the plot area.
+type marker struct {
+	X         float64
+	Y         float64
+	Title     string
+	Label     string
+	Highlight bool
+}
+
+// plotSeries is a series scaled into the plot area: its markers, and the
+// polyline joining them for line charts.
+type plotSeries struct {
+	Name       string
+	Color      string
+	Points     string
+	Markers    []marker
+	HidePoints bool
+	LegendX    float64
+}
+
+// plotAxis is a right axis at its position, with its ticks.
+type plotAxis struct {
+	X      float64
+	Color  string
+	Label  string
+	LabelX float64
+	Ticks  []tick
+}
+
+// plot is the precomputed geometry of a chart.
+type plot struct {
+	ViewBox string
+	Left    float64
+	Right   float64
+	Top     float64
+	Bottom  float64
+	XTicks  []tick
+	YTicks  []tick
+	XLabelX float64
+	XLabelY float64
+	YLabelX float64
+	YLabelY float64
+	Legend  bool
+	Axes    []plotAxis
+	Guides  []marker
+	Series  []plotSeries
+}
+
+// newPlot scales the series of c into the plot area. Every axis spans the
+// range of the points plotted against it, widened to round tick values.
+func newPlot(c Chart) plot {
+	p := plot{
+		ViewBox: "0 0 " + strconv.Itoa(chartWidth) + " " + strconv.Itoa(chartHeight),
+		Left:    marginLeft,
+		Right:   float64(chartWidth - marginRight - len(c.RightAxes)*axisWidth),
+		Top:     marginTop,
+		Bottom:  chartHeight - marginBottom,
+		Legend:  len(c.Series) > 1,
+	}
+	if p.Legend {
+		p.Top += legendHeight
+	}
+	p.XLabelX, p.XLabelY = (p.Left+p.Right)/2, chartHeight-8
+	p.YLabelX, p.YLabelY = 16, (p.Top+p.Bottom)/2
+
+	// Ranges of the x axis, then of each y axis, the left one first.
+	ranges := make([][2]float64, 2+len(c.RightAxes))
+	for i := range ranges {
+		ranges[i] = [2]float64{math.Inf(1), math.Inf(-1)}
+	}
+	extend := func(i int, v float64) {
+		ranges[i] = [2]float64{math.Min(ranges[i][0], v), math.Max(ranges[i][1], v)}
+	}
+	for _, s := range c.Series {
+		for _, pt := range s.Points {
+			extend(0, pt.X)
+			extend(1+s.Axis, pt.Y)
+		}
+	}
+	pointsMin, pointsMax := ranges[0][0], ranges[0][1]
+	zero := []bool{c.ZeroX, c.ZeroY}
+	for _, a := range c.RightAxes {
+		zero = append(zero, a.Zero)
+	}
+	for i := range ranges {
+		if math.IsInf(ranges[i][0], 1) {
+			ranges[i] = [2]float64{0, 1}
+		}
+		if zero[i] {
+			ranges[i][0] = math.Min(ranges[i][0], 0)
+		}
+	}
+
+	formatX := c.FormatX
+	if formatX == nil {
+		formatX = formatTick
+	}
+	xMin, xMax, xTicks := niceTicks(ranges[0][0], ranges[0][1], c.IntegerX)
+	x := func(v float64) float64 { return p.Left + (v-xMin)/(xMax-xMin)*(p.Right-p.Left) }
+	for _, v := range xTicks {
+		p.XTicks = append(p.XTicks, tick{Pos: x(v), Label: formatX(v)})
+	}
+
+	ys := make([]func(float64) float64, len(ranges)-1)
+	for i := range ys {
+		yMin, yMax, yTicks := niceTicks(ranges[i+1][0], ranges[i+1][1], false)
+		y := func(v float64) float64 { return p.Bottom - (v-yMin)/(yMax-yMin)*(p.Bottom-p.Top) }
+		ys[i] = y
+		ticks := make([]tick, len(yTicks))
+		for j, v := range yTicks {
+			ticks[j] = tick{Pos: y(v), Label: formatTick(v)}
+		}
+		if i == 0 {
+			p.YTicks = ticks
+			continue
+		}
+		a := c.RightAxes[i-1]
+		axis := plotAxis{X: p.Right + float64((i-1)*axisWidth), Color: a.Color, Label: a.Label, Ticks: ticks}
+		if axis.Color == "" {
+			axis.Color = "currentColor"
+		}
+		axis.LabelX = axis.X + axisWidth - 8
+		p.Axes = append(p.Axes, axis)
+	}
+
+	for _, g := range c.Guides {
+		if g.X >= pointsMin && g.X <= pointsMax {
+			p.Guides = append(p.Guides, marker{X: x(g.X), Label: g.Label})
+		}
+	}
+
+	legendX := p.Left
+	for _, s := range c.Series {
+		ps := plotSeries{Name: s.Name, Color: s.Color, HidePoints: s.HidePoints, LegendX: legendX}
+		if ps.Color == "" {
+			ps.Color = defaultColor
+		}
+		y := ys[s.Axis]
+		coords := make([]string, len(s.Points))
+		for i, pt := range s.Points {
+			m := marker{X: x(pt.X), Y: y(pt.Y), Title: pt.Title, Label: pt.Label, Highlight: pt.Highlight}
+			ps.Markers = append(ps.Markers, m)
+			coords[i] = formatCoord(m.X) + "," + formatCoord(m.Y)
+		}
+		ps.Points = strings.Join(coords, " ")
+		p.Series = append(p.Series, ps)
+		legendX += float64(24 + 8*len(s.Name))
+	}
+	return p
+}
+
+// niceTicks returns the bounds of an axis spanning lo to hi, widened to round
+// values, and the round values between them, about tickCount of them. An
+// empty range is widened around its single value.
+func niceTicks(lo, hi float64, integer bool) (float64, float64, []float64) {
+	if hi <= lo {
+		lo, hi = lo-1, hi+1
+	}
+	step := niceStep((hi - lo) / (tickCount - 1))
+	if integer {
+		step = math.Max(1, math.Round(step))
+	}
+	lo, hi = math.Floor(lo/step)*step, math.Ceil(hi/step)*step
+
+	var ticks []float64
+	for i := 0; ; i++ {
+		v := lo + float64(i)*step
+		if v > hi+step/2 {
+			break
+		}
+		ticks = append(ticks, v)
+	}
+	return lo, hi, ticks
+}
+
+// niceStep rounds raw up to 1, 2 or 5 times a power of ten.
+func niceStep(raw float64) float64 {
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	switch f := raw / magnitude; {
+	case f <= 1:
+		return magnitude
+	case f <= 2:
+		return 2 * magnitude
+	case f <= 5:
+		return 5 * magnitude
+	default:
+		return 10 * magnitude
+	}
+}
+
+// formatTick renders a tick value with the fewest digits needed, dropping
+// the float noise of the tick arithmetic.
+func formatTick(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e6)/1e6, 'f', -1, 64)
+}
+
+// formatCoord renders an SVG coordinate with at most one decimal.
+func formatCoord(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64)
+}
@@ -0,0 +1,170 @@
+package charts
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ"
+)
+
+func render(t *testing.T, c templ.Component) string {
+	t.Helper()
+	var b strings.Builder
+	if err := c.Render(context.Background(), &b); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return b.String()
+}
+
+func testChart() Chart {
+	return Chart{
+		ID:       "test-chart",
+		Title:    "Rating over the shots",
+		XLabel:   "Shot",
+		YLabel:   "Rating",
+		IntegerX: true,
+		Series: []Series{{Points: []Point{
+			{X: 1, Y: 6.5, Title: "Shot #1"},
+			{X: 2, Y: 8, Title: "Shot #2", Label: "Best", Highlight: true},
+			{X: 3, Y: 7, Title: "Shot #3"},
+		}}},
+	}
+}
+
+func TestNiceTicks(t *testing.T) {
+	tests := []struct {
+		name           string
+		lo, hi         float64
+		integer        bool
+		wantLo, wantHi float64
+		wantTicks      []float64
+	}{
+		{name: "widened to round values", lo: 6.5, hi: 8, wantLo: 6.5, wantHi: 8, wantTicks: []float64{6.5, 7, 7.5, 8}},
+		{name: "coarser step on a wide range", lo: 12, hi: 47, wantLo: 10, wantHi: 50, wantTicks: []float64{10, 20, 30, 40, 50}},
+		{name: "whole numbers", lo: 1, hi: 3, integer: true, wantLo: 1, wantHi: 3, wantTicks: []float64{1, 2, 3}},
+		{name: "single value", lo: 14, hi: 14, integer: true, wantLo: 13, wantHi: 15, wantTicks: []float64{13, 14, 15}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi, ticks := niceTicks(tt.lo, tt.hi, tt.integer)
+			if lo != tt.wantLo || hi != tt.wantHi || !reflect.DeepEqual(ticks, tt.wantTicks) {
+				t.Errorf("niceTicks(%v, %v, %t) = %v, %v, %v, want %v, %v, %v", tt.lo, tt.hi, tt.integer, lo, hi, ticks, tt.wantLo, tt.wantHi, tt.wantTicks)
+			}
+		})
+	}
+}
+
+func TestNewPlot_ScalesPointsIntoThePlotArea(t *testing.T) {
+	p := newPlot(testChart())
+
+	markers := p.Series[0].Markers
+	if markers[0].X != p.Left || markers[2].X != p.Right {
+		t.Errorf("expected the first and last shots on the edges of the plot, got %v and %v", markers[0].X, markers[2].X)
+	}
+	if markers[0].Y != p.Bottom || markers[1].Y != p.Top {
+		t.Errorf("expected the lowest and highest ratings on the edges of the plot, got %v and %v", markers[0].Y, markers[1].Y)
+	}
+	if p.Legend || p.Series[0].Color != defaultColor {
+		t.Errorf("expected a single series in the default color without legend, got %+v", p)
+	}
+}
+
+func TestLine_JoinsPointsAndHighlights(t *testing.T) {
+	html := render(t, Line(testChart()))
+
+	for _, want := range []string{
+		`id="test-chart"`, `aria-label="Rating over the shots"`, "<polyline", `class="highlight"`,
+		"<title>Shot #2</title>", ">Best</text>", ">Shot</text>", ">Rating</text>", ">7.5</text>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected %q in:\n%s", want, html)
+		}
+	}
+	if n := strings.Count(html, "<circle"); n != 3 {
+		t.Errorf("expected a marker per point, got %d", n)
+	}
+}
+
+func TestScatter_DoesNotJoinPoints(t *testing.T) {
+	c := testChart()
+	c.Series = append(c.Series, Series{Name: "Other", Color: "#43a047", Points: []Point{{X: 2, Y: 7}}})
+	html := render(t, Scatter(c))
+
+	if strings.Contains(html, "<polyline") {
+		t.Errorf("expected no line on a scatter chart, got:\n%s", html)
+	}
+	if !strings.Contains(html, ">Other</text>") || !strings.Contains(html, `fill="#43a047"`) {
+		t.Errorf("expected a legend with several series, got:\n%s", html)
+	}
+}
+
+func TestNewPlot_RightAxesAndGuides(t *testing.T) {
+	c := Chart{
+		ZeroX: true, ZeroY: true,
+		RightAxes: []Axis{{Label: "Weight (g)", Color: "#8d6e63", Zero: true}},
+		Guides:    []Guide{{X: 10, Label: "Start"}, {X: 40, Label: "Past the points"}},
+		Series: []Series{
+			{Name: "Pressure", Points: []Point{{X: 5, Y: 2}, {X: 30, Y: 9}}},
+			{Name: "Weight", Axis: 1, Points: []Point{{X: 5, Y: 4}, {X: 30, Y: 40}}},
+		},
+	}
+	p := newPlot(c)
+
+	if len(p.Axes) != 1 || p.Axes[0].X != p.Right || p.Right != chartWidth-marginRight-axisWidth {
+		t.Fatalf("expected a right axis on the right of the plot area, got %+v", p.Axes)
+	}
+	if got := p.Axes[0].Ticks[len(p.Axes[0].Ticks)-1].Label; got != "40" {
+		t.Errorf("expected the right axis to span the weights, got a last tick of %q", got)
+	}
+	if p.XTicks[0].Label != "0" || p.YTicks[0].Label != "0" {
+		t.Errorf("expected the axes from zero, got %q and %q", p.XTicks[0].Label, p.YTicks[0].Label)
+	}
+	if w := p.Series[1].Markers[1]; w.Y != p.Top {
+		t.Errorf("expected the heaviest weight on top of the plot, got %v", w.Y)
+	}
+	if len(p.Guides) != 1 || p.Guides[0].Label != "Start" {
+		t.Errorf("expected the guides within the points only, got %+v", p.Guides)
+	}
+}
+
+func TestLine_HidesPointsOfDenseSeries(t *testing.T) {
+	c := testChart()
+	c.Series[0].HidePoints = true
+	html := render(t, Line(c))
+
+	if !strings.Contains(html, "<polyline") || strings.Contains(html, "<circle") {
+		t.Errorf("expected the line without markers, got:\n%s", html)
+	}
+	if html := render(t, Scatter(c)); strings.Count(html, "<circle") != 3 {
+		t.Errorf("expected the markers of a scatter chart to be kept, got:\n%s", html)
+	}
+}
+
+func TestBars_ScalesBarsPerScale(t *testing.T) {
+	c := BarChart{ID: "test-bars", Title: "Spend", Bars: []Bar{
+		{Label: "Alpha", Value: 2.16, ValueLabel: "2.16 EUR", Scale: "EUR"},
+		{Label: "Beta", Value: 1.06, ValueLabel: "1.06 USD", Scale: "USD"},
+		{Label: "Beta", Value: 0.8, ValueLabel: "0.80 EUR", Scale: "EUR"},
+	}}
+	p := newBarPlot(c)
+
+	if p.Bars[0].Width != 340 || p.Bars[1].Width != 340 {
+		t.Errorf("expected the largest value of each scale to fill the plot, got %v and %v", p.Bars[0].Width, p.Bars[1].Width)
+	}
+	if want := 0.8 / 2.16 * 340; p.Bars[2].Width != want {
+		t.Errorf("expected the bars of a scale to share it, got %v, want %v", p.Bars[2].Width, want)
+	}
+	if p.ViewBox != "0 0 640 92" {
+		t.Errorf("expected the chart to grow with the bars, got viewBox %q", p.ViewBox)
+	}
+
+	html := render(t, Bars(c))
+	for _, want := range []string{`id="test-bars"`, ">Alpha</text>", ">0.80 EUR</text>", "<figcaption>Spend</figcaption>"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected %q in:\n%s", want, html)
+		}
+	}
+}
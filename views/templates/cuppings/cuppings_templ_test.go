@@ -0,0 +1,112 @@
+package cuppings
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/lescactus/espressoapi-go/internal/services/bean"
+	"github.com/lescactus/espressoapi-go/internal/services/cupping"
+	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+)
+
+func render(t *testing.T, c templ.Component) string {
+	t.Helper()
+	var b strings.Builder
+	if err := c.Render(context.Background(), &b); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return b.String()
+}
+
+func testSession() cupping.CuppingSession {
+	created := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+	sessionDate := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	return cupping.CuppingSession{
+		Id:           4,
+		SessionDate:  &sessionDate,
+		Participants: []string{"Alice", "Bob"},
+		CreatedAt:    &created,
+	}
+}
+
+func testScore() cupping.CuppingScore {
+	score := cupping.CuppingScore{
+		Id:        7,
+		SessionId: 4,
+		Beans:     &bean.Bean{Id: 9, Name: "Ethiopia Yirgacheffe", Roaster: &roaster.Roaster{Id: 3, Name: "Blue Bottle"}},
+		Fragrance: 8, Flavor: 8.25, Aftertaste: 7.75, Acidity: 8, Body: 7.5,
+		Balance: 8, Uniformity: 10, CleanCup: 10, Sweetness: 10, Overall: 8,
+	}
+	score.Total = score.ComputeTotal()
+	return score
+}
+
+func TestRow_ShowsDateParticipantsAndDetailLink(t *testing.T) {
+	html := render(t, Row(testSession(), ""))
+
+	for _, want := range []string{"2026-01-02", "Alice, Bob", `href="/cuppings/get/4"`, "Edit", "Delete"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected row to contain %q, got: %s", want, html)
+		}
+	}
+}
+
+func TestRow_OOBModes(t *testing.T) {
+	insert := render(t, Row(testSession(), "insert"))
+	if !strings.Contains(insert, `hx-swap-oob="beforeend:#cuppings-tbody"`) {
+		t.Errorf("expected insert oob attribute, got: %s", insert)
+	}
+
+	score := render(t, ScoreRow(testScore(), "insert"))
+	if !strings.Contains(score, `hx-swap-oob="beforeend:#cupping-scores-tbody"`) {
+		t.Errorf("expected score insert oob attribute, got: %s", score)
+	}
+}
+
+func TestDetail_ShowsScoreSheetWithTotals(t *testing.T) {
+	s := testSession()
+	s.Scores = []cupping.CuppingScore{testScore()}
+	html := render(t, Detail(s, nil))
+
+	for _, want := range []string{"Cupping of 2026-01-02", "Ethiopia Yirgacheffe", "Blue Bottle", "8.25", "85.50", `id="cupping-score-dialog"`, "/cuppings/scores/add?session_id=4"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected detail page to contain %q, got: %s", want, html)
+		}
+	}
+}
+
+func TestScoreForm_RendersAllAttributesWithRange(t *testing.T) {
+	state := ScoreFormState{SessionID: 4, Attributes: map[string]string{"flavor": "8.25"}}
+	html := render(t, ScoreForm(state, []bean.Bean{{Id: 9, Name: "Ethiopia"}}, true, "", ""))
+
+	for _, attr := range ScoreAttributes {
+		if !strings.Contains(html, `name="`+attr.Field+`"`) {
+			t.Errorf("expected an input for %q, got: %s", attr.Field, html)
+		}
+	}
+	if !strings.Contains(html, `min="0"`) || !strings.Contains(html, `max="10"`) || !strings.Contains(html, `value="8.25"`) {
+		t.Errorf("expected bounded inputs with the submitted value, got: %s", html)
+	}
+	if !strings.Contains(html, `name="session_id" value="4"`) {
+		t.Errorf("expected the session to be carried by a hidden field, got: %s", html)
+	}
+}
+
+func TestScoreForm_EmptyBeansDisablesSubmitAndShowsHint(t *testing.T) {
+	html := render(t, ScoreForm(ScoreFormState{SessionID: 4}, nil, true, "", ""))
+
+	if !strings.Contains(html, "Create some first") || !strings.Contains(html, "disabled") {
+		t.Errorf("expected a disabled submit and a hint to create beans first, got: %s", html)
+	}
+}
+
+func TestBeansScores_EmptyState(t *testing.T) {
+	html := render(t, BeansScores(bean.Bean{Id: 9, Name: "Ethiopia"}, nil))
+
+	if !strings.Contains(html, "have not been cupped yet") {
+		t.Errorf("expected an empty state message, got: %s", html)
+	}
+}
@@ -0,0 +1,24 @@
+package cuppings
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateOnly renders t as "YYYY-MM-DD" UTC, or "" if t is nil.
+func dateOnly(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02")
+}
+
+// formatScore renders a score with two decimals, the SCA form's precision.
+func formatScore(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func joinParticipants(participants []string) string {
+	return strings.Join(participants, ", ")
+}
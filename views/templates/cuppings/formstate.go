@@ -0,0 +1,82 @@
+// Package cuppings renders the cupping sessions list, the session detail
+// page with its SCA score sheet, and their dialog add/edit forms. It is
+// imported into internal/controllers/web as viewcuppings to avoid clashing
+// with the services/cupping package.
+package cuppings
+
+import "strconv"
+
+// SessionFormState carries a cupping session add/edit form's submitted
+// values and per-field validation errors so invalid input can be
+// redisplayed after a 400 response. Participants is the raw,
+// comma-separated input.
+type SessionFormState struct {
+	ID           int
+	SessionDate  string
+	Participants string
+	Errors       map[string]string
+	FormError    string
+}
+
+func (s SessionFormState) fieldError(field string) string {
+	if s.Errors == nil {
+		return ""
+	}
+	return s.Errors[field]
+}
+
+// ScoreFormState carries a cupping score add/edit form's submitted values
+// and per-field validation errors. Attributes is keyed by the
+// ScoreAttributes field names.
+type ScoreFormState struct {
+	ID         int
+	SessionID  int
+	BeansID    string
+	Attributes map[string]string
+	Notes      string
+	Errors     map[string]string
+	FormError  string
+}
+
+func (s ScoreFormState) fieldError(field string) string {
+	if s.Errors == nil {
+		return ""
+	}
+	return s.Errors[field]
+}
+
+func (s ScoreFormState) attribute(field string) string {
+	if s.Attributes == nil {
+		return ""
+	}
+	return s.Attributes[field]
+}
+
+// ScoreAttribute is one attribute row of the SCA cupping form: Field is
+// the form input name, Label its human-facing name.
+type ScoreAttribute struct {
+	Field string
+	Label string
+}
+
+// ScoreAttributes lists the SCA cupping form attributes in form order.
+var ScoreAttributes = []ScoreAttribute{
+	{"fragrance", "Fragrance/Aroma"},
+	{"flavor", "Flavor"},
+	{"aftertaste", "Aftertaste"},
+	{"acidity", "Acidity"},
+	{"body", "Body"},
+	{"balance", "Balance"},
+	{"uniformity", "Uniformity"},
+	{"clean_cup", "Clean cup"},
+	{"sweetness", "Sweetness"},
+	{"overall", "Overall"},
+}
+
+func rowElementID(id int) string      { return "cupping-row-" + strconv.Itoa(id) }
+func getPath(id int) string           { return "/cuppings/get/" + strconv.Itoa(id) }
+func updatePath(id int) string        { return "/cuppings/update/" + strconv.Itoa(id) }
+func deletePath(id int) string        { return "/cuppings/delete/" + strconv.Itoa(id) }
+func scoreRowElementID(id int) string { return "cupping-score-row-" + strconv.Itoa(id) }
+func scoreUpdatePath(id int) string   { return "/cuppings/scores/update/" + strconv.Itoa(id) }
+func scoreDeletePath(id int) string   { return "/cuppings/scores/delete/" + strconv.Itoa(id) }
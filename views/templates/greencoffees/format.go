@@ -0,0 +1,30 @@
+package greencoffees
+
+import (
+	"strconv"
+	"time"
+)
+
+// dateOnly renders t as "YYYY-MM-DD" UTC, or "" if t is nil.
+func dateOnly(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02")
+}
+
+// formatNumber renders v with the fewest digits needed, so that 5 stays "5"
+// and 1.25 stays "1.25".
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// formatPrice renders a price with two decimals.
+func formatPrice(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// formatPercent renders a percentage with one decimal.
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64) + " %"
+}
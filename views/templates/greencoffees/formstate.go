@@ -0,0 +1,33 @@
+// Package greencoffees renders the green coffee inventory list, with each
+// purchase's remaining stock and cost per kilogram, and the dialog add/edit
+// form. It is imported into internal/controllers/web as viewgreencoffees to
+// avoid clashing with the services/greencoffee package.
+package greencoffees
+
+import "strconv"
+
+// FormState carries a green coffee add/edit form's submitted values and
+// per-field validation errors so invalid input can be redisplayed after a
+// 400 response.
+type FormState struct {
+	ID             int
+	Origin         string
+	Supplier       string
+	PurchaseWeight string
+	Price          string
+	ArrivalDate    string
+	Moisture       string
+	Errors         map[string]string
+	FormError      string
+}
+
+func (s FormState) fieldError(field string) string {
+	if s.Errors == nil {
+		return ""
+	}
+	return s.Errors[field]
+}
+
+func rowElementID(id int) string { return "green-coffee-row-" + strconv.Itoa(id) }
+func updatePath(id int) string   { return "/green_coffees/update/" + strconv.Itoa(id) }
+func deletePath(id int) string   { return "/green_coffees/delete/" + strconv.Itoa(id) }
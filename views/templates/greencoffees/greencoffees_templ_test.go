@@ -0,0 +1,55 @@
+package greencoffees
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/lescactus/espressoapi-go/internal/services/greencoffee"
+)
+
+func render(t *testing.T, c templ.Component) string {
+	t.Helper()
+	var b strings.Builder
+	if err := c.Render(context.Background(), &b); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return b.String()
+}
+
+func testGreenCoffee() greencoffee.GreenCoffee {
+	arrivalDate := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	return greencoffee.GreenCoffee{
+		Id: 2, Origin: "Ethiopia Guji", Supplier: "Green Traders", PurchaseWeight: 3, Price: 50,
+		ArrivalDate: &arrivalDate, Moisture: 10.5, RemainingWeight: 1.75, CostPerKilogram: 16.67,
+	}
+}
+
+func TestRow_RendersStockAndCost(t *testing.T) {
+	html := render(t, Row(testGreenCoffee(), ""))
+	for _, want := range []string{`id="green-coffee-row-2"`, "2026-09-01", "<td>1.75</td>", "<td>16.67</td>", "<td>50.00</td>", "10.5 %", `hx-delete="/green_coffees/delete/2"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected row to contain %q, got: %s", want, html)
+		}
+	}
+}
+
+func TestRow_OOBInsertTargetsTableBody(t *testing.T) {
+	html := render(t, Row(testGreenCoffee(), "insert"))
+	if !strings.Contains(html, `hx-swap-oob="beforeend:#green-coffees-tbody"`) {
+		t.Errorf("expected an out-of-band insert into the table body, got: %s", html)
+	}
+}
+
+func TestForm_ShowsFieldErrors(t *testing.T) {
+	state := FormState{ID: 2, Origin: "", Errors: map[string]string{"origin": "Origin is required."}}
+
+	html := render(t, Form(state, false, "2026-09-01", "2026-09-02"))
+	for _, want := range []string{`hx-put="/green_coffees/update/2"`, "Origin is required.", `aria-invalid="true"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected form to contain %q, got: %s", want, html)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Package login renders the web UI's login page. It is imported into
+// internal/controllers/web as viewlogin.
+package login
+
+// FormState carries the login form's submitted values so a failed login
+// redisplays them. The password is never redisplayed. Next is the local path
+// to go back to once logged in, and Error why the login failed.
+//
+// SSOURL is the link to log in with the OpenID Connect provider, empty when
+// single sign-on is not configured, and SSOError why it failed.
+type FormState struct {
+	Name  string
+	Next  string
+	Error string
+
+	SSOURL   string
+	SSOError string
+}
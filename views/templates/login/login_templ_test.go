@@ -0,0 +1,58 @@
+package login
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPage_RedisplaysNameAndErrorButNotPassword(t *testing.T) {
+	var b strings.Builder
+	state := FormState{Name: "alice", Next: "/shots", Error: "Invalid user name or password."}
+	if err := Page(state).Render(context.Background(), &b); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	html := b.String()
+
+	for _, want := range []string{
+		`action="/login"`,
+		`name="name" value="alice"`,
+		`name="next" value="/shots"`,
+		`aria-invalid="true"`,
+		"Invalid user name or password.",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected %q in rendered HTML, got: %s", want, html)
+		}
+	}
+	if strings.Contains(html, `name="password" value=`) {
+		t.Errorf("expected the password not to be redisplayed, got: %s", html)
+	}
+}
+
+func TestPage_SingleSignOnLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    FormState
+		wantLink bool
+	}{
+		{name: "not configured", state: FormState{Next: "/"}},
+		{name: "configured", state: FormState{Next: "/", SSOURL: "/login/oidc?next=%2Fshots", SSOError: "Single sign-on failed."}, wantLink: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := Page(tt.state).Render(context.Background(), &b); err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			html := b.String()
+
+			if got := strings.Contains(html, `href="/login/oidc?next=%2Fshots"`); got != tt.wantLink {
+				t.Errorf("single sign-on link rendered = %v, want %v: %s", got, tt.wantLink, html)
+			}
+			if got := strings.Contains(html, "Single sign-on failed."); got != tt.wantLink {
+				t.Errorf("single sign-on error rendered = %v, want %v: %s", got, tt.wantLink, html)
+			}
+		})
+	}
+}
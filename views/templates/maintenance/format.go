@@ -0,0 +1,101 @@
+package maintenance
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/services/maintenance"
+)
+
+// dateOnly renders t as "YYYY-MM-DD" UTC, or "Never" if t is nil.
+func dateOnly(t *time.Time) string {
+	if t == nil {
+		return "Never"
+	}
+	return t.UTC().Format("2006-01-02")
+}
+
+// formatInterval renders a task's intervals, such as "200 shots or 30 days".
+func formatInterval(task maintenance.MaintenanceTask) string {
+	var shots, days string
+	if task.IntervalShots != nil {
+		shots = plural(*task.IntervalShots, "shot")
+	}
+	if task.IntervalDays != nil {
+		days = plural(*task.IntervalDays, "day")
+	}
+	switch {
+	case shots != "" && days != "":
+		return shots + " or " + days
+	case shots != "":
+		return shots
+	}
+	return days
+}
+
+// formatEquipment renders the equipment a task is for, such as
+// "Grinder: Niche Zero", or "" when it is for none in particular.
+func formatEquipment(task maintenance.MaintenanceTask) string {
+	var kind string
+	switch task.Equipment {
+	case maintenance.EquipmentMachine:
+		kind = "Machine"
+	case maintenance.EquipmentGrinder:
+		kind = "Grinder"
+	}
+	switch {
+	case kind != "" && task.EquipmentName != "":
+		return kind + ": " + task.EquipmentName
+	case kind != "":
+		return kind
+	}
+	return task.EquipmentName
+}
+
+// Status renders how far a task is from being due, such as "Due in 50 shots"
+// or "Overdue by 14 shots", using whichever interval comes first.
+func Status(task maintenance.MaintenanceTask) string {
+	remaining, unit := nextRemaining(task)
+	switch {
+	case unit == "":
+		return ""
+	case remaining < 0:
+		return "Overdue by " + plural(-remaining, unit)
+	case remaining == 0:
+		return "Due now"
+	}
+	return "Due in " + plural(remaining, unit)
+}
+
+// nextRemaining returns the smallest of a task's remaining shots and days,
+// with its unit, or an empty unit when the task has no interval.
+func nextRemaining(task maintenance.MaintenanceTask) (int, string) {
+	switch {
+	case task.ShotsRemaining != nil && task.DaysRemaining != nil:
+		if *task.DaysRemaining < *task.ShotsRemaining {
+			return *task.DaysRemaining, "day"
+		}
+		return *task.ShotsRemaining, "shot"
+	case task.ShotsRemaining != nil:
+		return *task.ShotsRemaining, "shot"
+	case task.DaysRemaining != nil:
+		return *task.DaysRemaining, "day"
+	}
+	return 0, ""
+}
+
+// Title renders a task's type with its equipment name when it has one, such
+// as "Backflush (Linea Mini)".
+func Title(task maintenance.MaintenanceTask) string {
+	if task.EquipmentName == "" {
+		return task.Type.Label()
+	}
+	return task.Type.Label() + " (" + task.EquipmentName + ")"
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return strconv.Itoa(n) + " " + unit + "s"
+}
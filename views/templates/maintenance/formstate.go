@@ -0,0 +1,34 @@
+// Package maintenance renders the equipment maintenance task list, with how
+// far each task is from being due, and the dialog add/edit form. It is
+// imported into internal/controllers/web as viewmaintenance to avoid
+// clashing with the services/maintenance package.
+package maintenance
+
+import "strconv"
+
+// FormState carries a maintenance task add/edit form's submitted values and
+// per-field validation errors so invalid input can be redisplayed after a
+// 400 response.
+type FormState struct {
+	ID            int
+	Type          string
+	Equipment     string
+	EquipmentName string
+	IntervalShots string
+	IntervalDays  string
+	LastDoneAt    string
+	Errors        map[string]string
+	FormError     string
+}
+
+func (s FormState) fieldError(field string) string {
+	if s.Errors == nil {
+		return ""
+	}
+	return s.Errors[field]
+}
+
+func rowElementID(id int) string { return "maintenance-task-row-" + strconv.Itoa(id) }
+func updatePath(id int) string   { return "/maintenance/update/" + strconv.Itoa(id) }
+func deletePath(id int) string   { return "/maintenance/delete/" + strconv.Itoa(id) }
+func donePath(id int) string     { return "/maintenance/done/" + strconv.Itoa(id) }
@@ -0,0 +1,71 @@
+package maintenance
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/lescactus/espressoapi-go/internal/services/maintenance"
+)
+
+func render(t *testing.T, c templ.Component) string {
+	t.Helper()
+	var b strings.Builder
+	if err := c.Render(context.Background(), &b); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return b.String()
+}
+
+func intPtr(v int) *int { return &v }
+
+func testMaintenanceTask() maintenance.MaintenanceTask {
+	lastDoneAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	return maintenance.MaintenanceTask{
+		Id: 3, Type: maintenance.TaskTypeBackflush, Equipment: maintenance.EquipmentMachine, EquipmentName: "Linea Mini",
+		IntervalShots: intPtr(200), IntervalDays: intPtr(30), LastDoneAt: &lastDoneAt, ShotsSinceDone: 214, DaysSinceDone: 17,
+		ShotsRemaining: intPtr(-14), DaysRemaining: intPtr(13), Due: true,
+	}
+}
+
+func TestRow_RendersOverdueStatusAndActions(t *testing.T) {
+	html := render(t, Row(testMaintenanceTask(), ""))
+	for _, want := range []string{`id="maintenance-task-row-3"`, "Backflush", "Machine: Linea Mini", "200 shots or 30 days", "2026-10-01", "<td>214</td>", "<mark>Overdue by 14 shots</mark>", `hx-post="/maintenance/done/3"`, `hx-delete="/maintenance/delete/3"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected row to contain %q, got: %s", want, html)
+		}
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		task maintenance.MaintenanceTask
+		want string
+	}{
+		{"shots first", maintenance.MaintenanceTask{ShotsRemaining: intPtr(50), DaysRemaining: intPtr(60)}, "Due in 50 shots"},
+		{"days first", maintenance.MaintenanceTask{ShotsRemaining: intPtr(50), DaysRemaining: intPtr(1)}, "Due in 1 day"},
+		{"due now", maintenance.MaintenanceTask{DaysRemaining: intPtr(0)}, "Due now"},
+		{"overdue", maintenance.MaintenanceTask{ShotsRemaining: intPtr(-1)}, "Overdue by 1 shot"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Status(tt.task); got != tt.want {
+				t.Errorf("Status() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForm_SelectsTypeAndShowsFieldErrors(t *testing.T) {
+	state := FormState{ID: 3, Type: "descale", Equipment: "machine", Errors: map[string]string{"interval_shots": "Give a number of shots, of days or both."}}
+
+	html := render(t, Form(state, false, "2026-10-01", "2026-10-02"))
+	for _, want := range []string{`hx-put="/maintenance/update/3"`, `<option value="descale" selected>`, `<option value="machine" selected>`, "Give a number of shots, of days or both.", `aria-invalid="true"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected form to contain %q, got: %s", want, html)
+		}
+	}
+}
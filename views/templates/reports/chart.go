@@ -0,0 +1,17 @@
+package reports
+
+import (
+	"github.com/lescactus/espressoapi-go/internal/services/report"
+	"github.com/lescactus/espressoapi-go/views/templates/charts"
+)
+
+// spendChart is the bar chart of the spend of each group, in the groups'
+// order. Bars are scaled against the largest spend in the same currency,
+// since amounts in different currencies cannot be compared.
+func spendChart(groups []report.SpendGroup) charts.BarChart {
+	c := charts.BarChart{ID: "spend-chart", Title: "Spend per group", Bars: make([]charts.Bar, len(groups))}
+	for i, g := range groups {
+		c.Bars[i] = charts.Bar{Label: g.Label, Value: g.Spend, ValueLabel: formatMoney(g.Spend, g.Currency), Scale: g.Currency}
+	}
+	return c
+}
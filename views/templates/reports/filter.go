@@ -0,0 +1,21 @@
+// Package reports renders the spend report page: its range and grouping
+// filter, a bar chart and a table of the spend per group. It is imported into
+// internal/controllers/web as viewreports to avoid clashing with the
+// services/report package.
+package reports
+
+// Filter carries the spend report's submitted query values, as typed, so
+// they are redisplayed with the report. Error holds why the report could not
+// be computed from them (an invalid date or range).
+type Filter struct {
+	From    string
+	To      string
+	GroupBy string
+	Error   string
+}
+
+var groupByOptions = []struct{ Value, Label string }{
+	{"month", "Month"},
+	{"roaster", "Roaster"},
+	{"beans", "Beans"},
+}
@@ -0,0 +1,19 @@
+package reports
+
+import "strconv"
+
+// formatNumber renders v with the fewest digits needed, so that 36 stays
+// "36" and 38.5 stays "38.5".
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// formatMoney renders an amount with two decimals, followed by its currency
+// when it has one.
+func formatMoney(v float64, currency string) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	if currency != "" {
+		s += " " + currency
+	}
+	return s
+}
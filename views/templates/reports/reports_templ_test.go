@@ -0,0 +1,88 @@
+package reports
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ"
+	"github.com/lescactus/espressoapi-go/internal/services/report"
+)
+
+func render(t *testing.T, c templ.Component) string {
+	t.Helper()
+	var b strings.Builder
+	if err := c.Render(context.Background(), &b); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return b.String()
+}
+
+func testSpendReport() report.SpendReport {
+	return report.SpendReport{
+		GroupBy: report.GroupByRoaster,
+		Groups: []report.SpendGroup{
+			{Key: "1", Label: "Alpha", SpendTotal: report.SpendTotal{Currency: "EUR", Shots: 2, CoffeeWeight: 36, Spend: 2.16}},
+			{Key: "2", Label: "Beta", SpendTotal: report.SpendTotal{Currency: "USD", Shots: 1, CoffeeWeight: 18, Spend: 1.06}},
+			{Key: "2", Label: "Beta", SpendTotal: report.SpendTotal{Currency: "EUR", Shots: 1, CoffeeWeight: 20, Spend: 0.8}},
+		},
+		Totals: []report.SpendTotal{
+			{Currency: "EUR", Shots: 3, CoffeeWeight: 56, Spend: 2.96},
+			{Currency: "USD", Shots: 1, CoffeeWeight: 18, Spend: 1.06},
+		},
+	}
+}
+
+func TestSpendChart_ScalesBarsPerCurrency(t *testing.T) {
+	c := spendChart(testSpendReport().Groups)
+
+	if len(c.Bars) != 3 {
+		t.Fatalf("expected one bar per group, got %d", len(c.Bars))
+	}
+	if c.Bars[0].Scale != "EUR" || c.Bars[1].Scale != "USD" || c.Bars[2].Scale != "EUR" {
+		t.Errorf("expected the bars scaled per currency, got %+v", c.Bars)
+	}
+	if c.Bars[0].ValueLabel != "2.16 EUR" || c.Bars[0].Value != 2.16 {
+		t.Errorf("expected the spend as value, got %+v", c.Bars[0])
+	}
+}
+
+func TestTable_RendersGroupsAndTotalsPerCurrency(t *testing.T) {
+	html := render(t, Table(testSpendReport()))
+
+	for _, want := range []string{"<td>Alpha</td>", "<td>2.16 EUR</td>", "<td>1.06 USD</td>", "<th>2.96 EUR</th>", "<th>56</th>"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected table to contain %q, got: %s", want, html)
+		}
+	}
+}
+
+func TestPage_KeepsFilterAndShowsChart(t *testing.T) {
+	spend := testSpendReport()
+	html := render(t, Page(Filter{From: "2026-09-01", To: "2026-09-30", GroupBy: "roaster"}, &spend))
+
+	for _, want := range []string{`value="2026-09-01"`, `value="2026-09-30"`, `<option value="roaster" selected>Roaster</option>`, `id="spend-chart"`, `id="spend-table"`, `aria-current="page">Reports`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected page to contain %q, got: %s", want, html)
+		}
+	}
+}
+
+func TestPage_FilterErrorHidesReport(t *testing.T) {
+	html := render(t, Page(Filter{From: "2026-10-02", To: "2026-10-01", Error: "The from date must not be after the to date."}, nil))
+
+	if !strings.Contains(html, "The from date must not be after the to date.") {
+		t.Errorf("expected the filter error, got: %s", html)
+	}
+	if strings.Contains(html, `id="spend-chart"`) {
+		t.Errorf("expected no chart without a report, got: %s", html)
+	}
+}
+
+func TestPage_EmptyReportExplainsMissingPrices(t *testing.T) {
+	html := render(t, Page(Filter{GroupBy: "month"}, &report.SpendReport{GroupBy: report.GroupByMonth}))
+
+	if !strings.Contains(html, "Record the price and bag weight of your beans") {
+		t.Errorf("expected a hint about recording prices, got: %s", html)
+	}
+}
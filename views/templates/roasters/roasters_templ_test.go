@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/a-h/templ"
+	"github.com/lescactus/espressoapi-go/internal/auth"
 	"github.com/lescactus/espressoapi-go/internal/services/roaster"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
 )
 
 func render(t *testing.T, c templ.Component) string {
@@ -38,6 +40,36 @@ func TestRow_ShowsAllColumnsAndActions(t *testing.T) {
 	}
 }
 
+func TestRow_HidesActionsTheRoleCannotPerform(t *testing.T) {
+	tests := []struct {
+		role       auth.Role
+		wantEdit   bool
+		wantDelete bool
+	}{
+		{role: auth.RoleViewer},
+		{role: auth.RoleBarista, wantEdit: true},
+		{role: auth.RoleAdmin, wantEdit: true, wantDelete: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			ctx := auth.NewContext(context.Background(), &auth.User{Id: 1, Name: "alice", Role: tt.role})
+			var b strings.Builder
+			if err := Row(testRoaster()).Render(ctx, &b); err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			html := b.String()
+
+			if got := strings.Contains(html, ">Edit</a>"); got != tt.wantEdit {
+				t.Errorf("Edit link shown = %t, want %t", got, tt.wantEdit)
+			}
+			if got := strings.Contains(html, ">Delete</a>"); got != tt.wantDelete {
+				t.Errorf("Delete link shown = %t, want %t", got, tt.wantDelete)
+			}
+		})
+	}
+}
+
 func TestEditRow_MetadataIsReadOnly(t *testing.T) {
 	html := render(t, EditRow(FormState{ID: 7, Name: "Blue Bottle"}, "2026-01-02 03:04", "2026-01-05 06:07"))
 
@@ -50,7 +82,7 @@ func TestEditRow_MetadataIsReadOnly(t *testing.T) {
 }
 
 func TestRowPage_WrapsRowInAOneRowTable(t *testing.T) {
-	html := render(t, RowPage(testRoaster()))
+	html := render(t, RowPage(testRoaster(), stats.ShotStats{}))
 
 	if !strings.Contains(html, "<html") || !strings.Contains(html, "<table") || !strings.Contains(html, "Blue Bottle") {
 		t.Errorf("expected a full page with a one-row table, got: %s", html)
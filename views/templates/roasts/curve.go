@@ -0,0 +1,32 @@
+package roasts
+
+import (
+	"math"
+
+	"github.com/lescactus/espressoapi-go/internal/services/roastbatch"
+	"github.com/lescactus/espressoapi-go/views/templates/charts"
+)
+
+// curveChart is the line chart of the roast curve of b, with a guide at
+// first crack. Time is plotted in minutes, for ticks on round minutes; the
+// temperature axis spans the readings' range so the turning point and the
+// climb are both visible.
+func curveChart(b roastbatch.RoastBatch) charts.Chart {
+	c := charts.Chart{
+		ID:      "roast-curve",
+		Title:   "Roast curve of " + b.GreenCoffee,
+		XLabel:  "Time",
+		YLabel:  "Temperature (°)",
+		FormatX: func(minutes float64) string { return formatElapsed(int(math.Round(minutes * 60))) },
+		ZeroX:   true,
+	}
+	series := charts.Series{HidePoints: true}
+	for _, p := range b.CurvePoints {
+		series.Points = append(series.Points, charts.Point{X: float64(p.Time) / 60, Y: p.Temperature})
+	}
+	c.Series = []charts.Series{series}
+	if b.FirstCrackTime > 0 {
+		c.Guides = []charts.Guide{{X: float64(b.FirstCrackTime) / 60, Label: "First crack"}}
+	}
+	return c
+}
@@ -0,0 +1,50 @@
+package roasts
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/services/roastbatch"
+)
+
+// dateOnly renders t as "YYYY-MM-DD" UTC, or "" if t is nil.
+func dateOnly(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02")
+}
+
+// formatNumber renders v with the fewest digits needed, so that 250 stays
+// "250" and 212.5 stays "212.5".
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// formatPercent renders a percentage with one decimal.
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64) + " %"
+}
+
+// formatElapsed renders a number of seconds as "m:ss".
+func formatElapsed(seconds int) string {
+	return strconv.Itoa(seconds/60) + ":" + leftPad(strconv.Itoa(seconds%60))
+}
+
+func leftPad(s string) string {
+	if len(s) < 2 {
+		return "0" + s
+	}
+	return s
+}
+
+// FormatCurve renders curve points as the form's textarea input, one
+// "time,temperature" point per line.
+func FormatCurve(points []roastbatch.CurvePoint) string {
+	lines := make([]string, len(points))
+	for i, p := range points {
+		lines[i] = strconv.Itoa(p.Time) + "," + formatNumber(p.Temperature)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,43 @@
+// Package roasts renders the home roast batches list, the batch detail page
+// with its roast curve, and the dialog add/edit form. It is imported into
+// internal/controllers/web as viewroasts to avoid clashing with the
+// services/roastbatch package.
+package roasts
+
+import "strconv"
+
+// FormState carries a roast batch add/edit form's submitted values and
+// per-field validation errors so invalid input can be redisplayed after a
+// 400 response. Curve is the raw textarea input, one "time,temperature"
+// point per line. GreenCoffeeID optionally links the batch to the green
+// coffee purchase it drew on.
+type FormState struct {
+	ID                int
+	GreenCoffee       string
+	GreenCoffeeID     string
+	RoastDate         string
+	RoastLevel        string
+	GreenWeight       string
+	RoastedWeight     string
+	ChargeTemperature string
+	FirstCrackTime    string
+	DevelopmentTime   string
+	EndTemperature    string
+	Curve             string
+	Errors            map[string]string
+	FormError         string
+}
+
+func (s FormState) fieldError(field string) string {
+	if s.Errors == nil {
+		return ""
+	}
+	return s.Errors[field]
+}
+
+func rowElementID(id int) string { return "roast-row-" + strconv.Itoa(id) }
+func getPath(id int) string      { return "/roasts/get/" + strconv.Itoa(id) }
+func updatePath(id int) string   { return "/roasts/update/" + strconv.Itoa(id) }
+func deletePath(id int) string   { return "/roasts/delete/" + strconv.Itoa(id) }
+func beansPath(id int) string    { return "/roasts/beans/" + strconv.Itoa(id) }
+func beansGetPath(id int) string { return "/beans/get/" + strconv.Itoa(id) }
@@ -0,0 +1,17 @@
+package roasts
+
+import "github.com/a-h/templ"
+
+// rowOOBAttrs returns the out-of-band swap attributes for a row rendered
+// after a dialog create ("insert", appended into tbodyID) or edit
+// ("replace", swapped by id).
+func rowOOBAttrs(oobMode, tbodyID string) templ.Attributes {
+	attrs := templ.Attributes{}
+	switch oobMode {
+	case "insert":
+		attrs["hx-swap-oob"] = "beforeend:#" + tbodyID
+	case "replace":
+		attrs["hx-swap-oob"] = "true"
+	}
+	return attrs
+}
@@ -0,0 +1,98 @@
+package roasts
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/lescactus/espressoapi-go/internal/services/greencoffee"
+	"github.com/lescactus/espressoapi-go/internal/services/roastbatch"
+)
+
+func render(t *testing.T, c templ.Component) string {
+	t.Helper()
+	var b strings.Builder
+	if err := c.Render(context.Background(), &b); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return b.String()
+}
+
+func testBatch() roastbatch.RoastBatch {
+	roastDate := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	return roastbatch.RoastBatch{
+		Id: 3, GreenCoffee: "Ethiopia Guji", RoastDate: &roastDate,
+		GreenWeight: 250, RoastedWeight: 215, WeightLoss: 14, FirstCrackTime: 300, DevelopmentTime: 75,
+		CurvePoints: []roastbatch.CurvePoint{{Time: 0, Temperature: 200}, {Time: 60, Temperature: 100}, {Time: 600, Temperature: 220}},
+	}
+}
+
+func TestCurveChart_PlotsMinutesWithFirstCrack(t *testing.T) {
+	c := curveChart(testBatch())
+
+	if len(c.Series) != 1 || len(c.Series[0].Points) != 3 || !c.Series[0].HidePoints {
+		t.Fatalf("expected a single curve without markers, got %+v", c.Series)
+	}
+	if p := c.Series[0].Points[2]; p.X != 10 || p.Y != 220 {
+		t.Errorf("expected the last reading at 10 minutes and 220°, got %+v", p)
+	}
+	if len(c.Guides) != 1 || c.Guides[0].X != 5 || c.Guides[0].Label != "First crack" {
+		t.Errorf("expected a first crack guide at 5 minutes, got %+v", c.Guides)
+	}
+	if got := c.FormatX(2.5); got != "2:30" {
+		t.Errorf("FormatX(2.5) = %q, want 2:30", got)
+	}
+}
+
+func TestCurveChart_NoGuideWithoutFirstCrack(t *testing.T) {
+	b := testBatch()
+	b.FirstCrackTime = 0
+	if c := curveChart(b); len(c.Guides) != 0 {
+		t.Errorf("expected no first crack guide when it was not recorded, got %+v", c.Guides)
+	}
+}
+
+func TestDetail_RendersCurveOrPlaceholder(t *testing.T) {
+	html := render(t, Detail(testBatch()))
+	for _, want := range []string{`<svg id="roast-curve"`, `class="guide"`, ">First crack</text>", ">10:00</text>", "14.0 %", "5:00", "1:15", "Create beans"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected detail to contain %q, got: %s", want, html)
+		}
+	}
+
+	b := testBatch()
+	b.CurvePoints = nil
+	html = render(t, Detail(b))
+	if strings.Contains(html, `id="roast-curve"`) || !strings.Contains(html, "No roast curve recorded") {
+		t.Errorf("expected a placeholder without curve, got: %s", html)
+	}
+}
+
+func TestBeansSection_LinksGeneratedBeans(t *testing.T) {
+	b := testBatch()
+	beansID := 9
+	b.BeansId = &beansID
+
+	html := render(t, BeansSection(b))
+	if !strings.Contains(html, `href="/beans/get/9"`) || strings.Contains(html, "Create beans") {
+		t.Errorf("expected a link to the generated beans only, got: %s", html)
+	}
+}
+
+func TestFormatCurve_RoundTripsFormInput(t *testing.T) {
+	if got := FormatCurve(testBatch().CurvePoints); got != "0,200\n60,100\n600,220" {
+		t.Errorf("FormatCurve() = %q", got)
+	}
+}
+
+func TestForm_PreselectsGreenCoffeeFromStock(t *testing.T) {
+	state := FormState{GreenCoffee: "Ethiopia Guji", GreenCoffeeID: "4"}
+	greenCoffees := []greencoffee.GreenCoffee{{Id: 4, Origin: "Ethiopia Guji", RemainingWeight: -0.5}}
+
+	html := render(t, Form(state, greenCoffees, true, "", ""))
+	if !strings.Contains(html, `<option value="4" selected>Ethiopia Guji (-0.5 kg left)</option>`) {
+		t.Errorf("expected the green coffee to be pre-selected, got: %s", html)
+	}
+}
@@ -14,7 +14,8 @@ import templruntime "github.com/a-h/templ/runtime"
 
 // Layout wraps page content in the site skeleton: head/CDN links, nav, the
 // alerts region, the page content, and the footer. active identifies the
-// current nav item (e.g. "sheets") for aria-current.
+// current nav item (e.g. "sheets") for aria-current. Within a session, every
+// htmx request sends the session's CSRF token.
 func Layout(title string, active string) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
@@ -36,20 +37,38 @@ func Layout(title string, active string) templ.Component {
 			templ_7745c5c3_Var1 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!doctype html><html lang=\"en\"><head><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1\"><title>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!doctype html><html lang=\"en\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var2 string
-		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(title)
+		templ_7745c5c3_Err = head(title).Render(ctx, templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/templates/shared/layout.templ`, Line: 14, Col: 17}
+			return templ_7745c5c3_Err
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "<body")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, " - espressoapi-go</title><link rel=\"stylesheet\" href=\"https://cdn.jsdelivr.net/npm/@picocss/pico@2.1.1/css/pico.min.css\" integrity=\"sha384-L1dWfspMTHU/ApYnFiMz2QID/PlP1xCW9visvBdbEkOLkSSWsP6ZJWhPw6apiXxU\" crossorigin=\"anonymous\"><script src=\"https://cdn.jsdelivr.net/npm/htmx.org@2.0.10/dist/htmx.min.js\" integrity=\"sha384-H5SrcfygHmAuTDZphMHqBJLc3FhssKjG7w/CeCpFReSfwBWDTKpkzPP8c+cLsK+V\" crossorigin=\"anonymous\"></script><style>\n\t\t\t\thtml { height: 100%; }\n\t\t\t\tbody { display: flex; flex-direction: column; min-height: 100vh; }\n\t\t\t\tbody > main.container { flex: 1 0 auto; }\n\t\t\t\tbody > footer.container { flex-shrink: 0; text-align: center; }\n\t\t\t\t.card-grid { display: grid; grid-template-columns: repeat(auto-fit, minmax(220px, 280px)); justify-content: center; gap: 1rem; }\n\t\t\t\t.card-grid article { margin-bottom: 0; }\n\t\t\t\t.table-scroll { overflow-x: auto; }\n\t\t\t\t.table-scroll table { width: max-content; min-width: 100%; }\n\t\t\t\t.table-scroll th { position: relative; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }\n\t\t\t\t.col-resizer { position: absolute; top: 0; right: 0; width: 6px; height: 100%; cursor: col-resize; user-select: none; touch-action: none; }\n\t\t\t\t.col-resizer:hover, .col-resizer.is-resizing { background: var(--pico-primary); opacity: 0.5; }\n\t\t\t\tdialog article > header { display: flex; align-items: center; justify-content: space-between; gap: 1rem; }\n\t\t\t\t.dialog-close-btn { background: none; border: none; padding: 0; margin: 0; font-size: 1.5rem; line-height: 1; cursor: pointer; color: var(--pico-secondary); }\n\t\t\t\t.dialog-close-btn:hover { color: var(--pico-primary); }\n\t\t\t\t.footer-icon { vertical-align: text-bottom; }\n\t\t\t\t#alerts { position: fixed; top: 1rem; right: 1rem; z-index: 100; display: flex; flex-direction: column; gap: 0.5rem; max-width: 24rem; }\n\t\t\t\t#alerts .alert-success, #alerts .alert-error { margin: 0; padding: 0.75rem 1rem; border-radius: var(--pico-border-radius); }\n\t\t\t\t#alerts .alert-error { background: var(--pico-del-color); color: var(--pico-contrast); }\n\t\t\t\t#alerts .alert-success { background: var(--pico-ins-color); color: var(--pico-contrast); }\n\t\t\t</style></head><body>")
+		if headers := csrfHeaders(ctx); headers != "" {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, " hx-headers=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var2 string
+			templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.ResolveAttributeValue(headers)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/templates/shared/layout.templ`, Line: 15, Col: 24}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ_7745c5c3_Var2)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, ">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -57,7 +76,7 @@ func Layout(title string, active string) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "<main class=\"container\"><div id=\"alerts\"></div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "<main class=\"container\"><div id=\"alerts\"></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -65,7 +84,66 @@ func Layout(title string, active string) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</main>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</main>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = Footer().Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = scripts().Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "</body></html>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+// PublicLayout wraps page content in the site skeleton without the nav, for
+// the pages anyone can open without a session, such as shared sheets.
+func PublicLayout(title string) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var3 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var3 == nil {
+			templ_7745c5c3_Var3 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "<!doctype html><html lang=\"en\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = head(title).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "<body><main class=\"container\"><div id=\"alerts\"></div>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templ_7745c5c3_Var3.Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "</main>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -73,7 +151,84 @@ func Layout(title string, active string) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "<script>\n\t\t\t\t// Dark mode: default to prefers-color-scheme, override via nav toggle, persist in localStorage.\n\t\t\t\t(function () {\n\t\t\t\t\tvar stored = localStorage.getItem(\"theme\");\n\t\t\t\t\tif (stored) {\n\t\t\t\t\t\tdocument.documentElement.setAttribute(\"data-theme\", stored);\n\t\t\t\t\t}\n\t\t\t\t\tdocument.addEventListener(\"click\", function (evt) {\n\t\t\t\t\t\tvar toggle = evt.target.closest(\"[data-theme-toggle]\");\n\t\t\t\t\t\tif (!toggle) return;\n\t\t\t\t\t\tvar current = document.documentElement.getAttribute(\"data-theme\") === \"dark\" ? \"dark\" : \"light\";\n\t\t\t\t\t\tvar next = current === \"dark\" ? \"light\" : \"dark\";\n\t\t\t\t\t\tdocument.documentElement.setAttribute(\"data-theme\", next);\n\t\t\t\t\t\tlocalStorage.setItem(\"theme\", next);\n\t\t\t\t\t});\n\t\t\t\t})();\n\n\t\t\t\t// Auto-dismiss success/error alerts a few seconds after they are inserted.\n\t\t\t\t(function () {\n\t\t\t\t\tfunction scheduleDismiss(el) {\n\t\t\t\t\t\tsetTimeout(function () {\n\t\t\t\t\t\t\tif (el && el.parentNode) el.parentNode.removeChild(el);\n\t\t\t\t\t\t}, 5000);\n\t\t\t\t\t}\n\t\t\t\t\tnew MutationObserver(function (mutations) {\n\t\t\t\t\t\tmutations.forEach(function (m) {\n\t\t\t\t\t\t\tm.addedNodes.forEach(function (n) {\n\t\t\t\t\t\t\t\tif (n.nodeType === 1 && n.matches && n.matches(\".alert-success\")) scheduleDismiss(n);\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t});\n\t\t\t\t\t}).observe(document.getElementById(\"alerts\"), { childList: true });\n\t\t\t\t})();\n\n\t\t\t\t// Let expected HTML error responses (validation/domain errors) swap normally;\n\t\t\t\t// htmx's default behavior already only blocks swaps for network errors, so this\n\t\t\t\t// listener only needs to ensure our declared 4xx statuses are treated as swappable.\n\t\t\t\tdocument.body.addEventListener(\"htmx:beforeSwap\", function (evt) {\n\t\t\t\t\tvar status = evt.detail.xhr.status;\n\t\t\t\t\tif (status === 400 || status === 404 || status === 409 || status === 413 || status === 415) {\n\t\t\t\t\t\tevt.detail.shouldSwap = true;\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\t// Shared <dialog> controller for bean/shot add/edit forms.\n\t\t\t\tfunction openDialog(dialog) {\n\t\t\t\t\tif (dialog && typeof dialog.showModal === \"function\" && !dialog.hasAttribute(\"data-modal-open\")) {\n\t\t\t\t\t\tdialog.setAttribute(\"data-modal-open\", \"true\");\n\t\t\t\t\t\tdialog.showModal();\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t\t// The native \"close\" event fires for every way a <dialog> stops being\n\t\t\t\t// open, including Escape (which the handlers below never see since\n\t\t\t\t// they only run on close()/backdrop-click/data-dialog-close), so this\n\t\t\t\t// is the one place data-modal-open is cleared - no path can leave it\n\t\t\t\t// set once the dialog is actually closed. \"close\" doesn't bubble, so\n\t\t\t\t// this listener must use the capture phase.\n\t\t\t\tdocument.addEventListener(\"close\", function (evt) {\n\t\t\t\t\tif (evt.target.tagName === \"DIALOG\") evt.target.removeAttribute(\"data-modal-open\");\n\t\t\t\t}, true);\n\t\t\t\tdocument.body.addEventListener(\"htmx:afterSwap\", function (evt) {\n\t\t\t\t\t// Only a GET request loads a fresh add/edit form into the dialog and\n\t\t\t\t\t// should open it. A create/update POST/PUT also targets the dialog\n\t\t\t\t\t// (even when it sets HX-Reswap: none on success, which skips the\n\t\t\t\t\t// actual swap but still fires this event) and must never reopen it\n\t\t\t\t\t// after the dialog-close listener below has just closed it.\n\t\t\t\t\tif (evt.detail.requestConfig && evt.detail.requestConfig.verb !== \"get\") return;\n\t\t\t\t\tvar dialog = evt.target.querySelector ? evt.target.querySelector(\"dialog[open]\") : null;\n\t\t\t\t\tif (evt.target.matches && evt.target.matches(\"dialog\")) dialog = evt.target;\n\t\t\t\t\topenDialog(dialog);\n\t\t\t\t});\n\t\t\t\t// A direct GET to an add/edit route renders its full-page fallback\n\t\t\t\t// with the dialog already populated (see e.g. web.AddBeanForm); open\n\t\t\t\t// it once on load. A dialog left empty by the normal list page (no\n\t\t\t\t// child content) is untouched.\n\t\t\t\tdocument.querySelectorAll(\"dialog\").forEach(function (dialog) {\n\t\t\t\t\tif (dialog.firstElementChild) openDialog(dialog);\n\t\t\t\t});\n\t\t\t\tdocument.body.addEventListener(\"dialog-close\", function () {\n\t\t\t\t\tvar dialog = document.querySelector(\"dialog[open]\");\n\t\t\t\t\tif (dialog) dialog.close();\n\t\t\t\t});\n\t\t\t\tdocument.addEventListener(\"click\", function (evt) {\n\t\t\t\t\tvar closer = evt.target.closest(\"[data-dialog-close]\");\n\t\t\t\t\tif (!closer) return;\n\t\t\t\t\tvar dialog = evt.target.closest(\"dialog\");\n\t\t\t\t\tif (dialog) dialog.close();\n\t\t\t\t});\n\t\t\t\t// Clicking the backdrop closes the dialog. Pico centers the dialog's\n\t\t\t\t// content (the <article>) with flexbox, so the <dialog> element's own\n\t\t\t\t// box covers the full viewport; a click event's target is the <dialog>\n\t\t\t\t// itself only when it lands outside that centered content.\n\t\t\t\tdocument.addEventListener(\"click\", function (evt) {\n\t\t\t\t\tif (evt.target.tagName !== \"DIALOG\") return;\n\t\t\t\t\tevt.target.close();\n\t\t\t\t});\n\n\t\t\t\t// Draggable column resizing for every table under .table-scroll. Handles\n\t\t\t\t// are added on demand (idempotent) so this also covers tables swapped in\n\t\t\t\t// later by htmx (e.g. re-sorting, which replaces the whole <table>).\n\t\t\t\t(function () {\n\t\t\t\t\tfunction addResizeHandles() {\n\t\t\t\t\t\tdocument.querySelectorAll(\".table-scroll table\").forEach(function (table) {\n\t\t\t\t\t\t\tif (table.hasAttribute(\"data-resizable\")) return;\n\t\t\t\t\t\t\tvar ths = table.querySelectorAll(\"thead tr th\");\n\t\t\t\t\t\t\t// Pin each column's current auto-computed width, and give the table\n\t\t\t\t\t\t\t// itself an explicit pixel width (their sum), before switching to a\n\t\t\t\t\t\t\t// fixed layout. table-layout: fixed ignores a <th>'s inline width\n\t\t\t\t\t\t\t// when the table's own width is an intrinsic keyword (max-content),\n\t\t\t\t\t\t\t// so this must be a definite length for per-column resizing to work.\n\t\t\t\t\t\t\tvar total = 0;\n\t\t\t\t\t\t\tths.forEach(function (th) {\n\t\t\t\t\t\t\t\tvar width = th.offsetWidth;\n\t\t\t\t\t\t\t\tth.style.width = width + \"px\";\n\t\t\t\t\t\t\t\ttotal += width;\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t\ttable.style.width = total + \"px\";\n\t\t\t\t\t\t\ttable.style.tableLayout = \"fixed\";\n\t\t\t\t\t\t\ttable.setAttribute(\"data-resizable\", \"true\");\n\t\t\t\t\t\t\tths.forEach(function (th) {\n\t\t\t\t\t\t\t\tif (th.querySelector(\".col-resizer\")) return;\n\t\t\t\t\t\t\t\tvar handle = document.createElement(\"span\");\n\t\t\t\t\t\t\t\thandle.className = \"col-resizer\";\n\t\t\t\t\t\t\t\tth.appendChild(handle);\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t});\n\t\t\t\t\t}\n\t\t\t\t\taddResizeHandles();\n\t\t\t\t\tdocument.body.addEventListener(\"htmx:afterSwap\", addResizeHandles);\n\n\t\t\t\t\tvar resizing = null;\n\t\t\t\t\tdocument.addEventListener(\"mousedown\", function (evt) {\n\t\t\t\t\t\tvar handle = evt.target.closest(\".col-resizer\");\n\t\t\t\t\t\tif (!handle) return;\n\t\t\t\t\t\tevt.preventDefault();\n\t\t\t\t\t\tvar th = handle.parentElement;\n\t\t\t\t\t\tvar table = th.closest(\"table\");\n\t\t\t\t\t\tresizing = { th: th, table: table, startX: evt.clientX, startWidth: th.offsetWidth, startTableWidth: table.offsetWidth };\n\t\t\t\t\t\thandle.classList.add(\"is-resizing\");\n\t\t\t\t\t});\n\t\t\t\t\tdocument.addEventListener(\"mousemove\", function (evt) {\n\t\t\t\t\t\tif (!resizing) return;\n\t\t\t\t\t\tvar width = Math.max(40, resizing.startWidth + (evt.clientX - resizing.startX));\n\t\t\t\t\t\tresizing.th.style.width = width + \"px\";\n\t\t\t\t\t\t// Keep the table's own (definite, fixed-layout) width in sync so the\n\t\t\t\t\t\t// browser doesn't redistribute the resized column's space among\n\t\t\t\t\t\t// the others to make the total add back up.\n\t\t\t\t\t\tresizing.table.style.width = (resizing.startTableWidth + (width - resizing.startWidth)) + \"px\";\n\t\t\t\t\t});\n\t\t\t\t\tdocument.addEventListener(\"mouseup\", function () {\n\t\t\t\t\t\tif (!resizing) return;\n\t\t\t\t\t\tvar handle = resizing.th.querySelector(\".col-resizer\");\n\t\t\t\t\t\tif (handle) handle.classList.remove(\"is-resizing\");\n\t\t\t\t\t\tresizing = null;\n\t\t\t\t\t});\n\t\t\t\t})();\n\t\t\t</script></body></html>")
+		templ_7745c5c3_Err = scripts().Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, "</body></html>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+// head renders the <head> of every page: metadata, CDN links and styles.
+func head(title string) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var4 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var4 == nil {
+			templ_7745c5c3_Var4 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 13, "<head><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1\"><title>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var5 string
+		templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(title)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/templates/shared/layout.templ`, Line: 51, Col: 16}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, " - espressoapi-go</title><link rel=\"stylesheet\" href=\"https://cdn.jsdelivr.net/npm/@picocss/pico@2.1.1/css/pico.min.css\" integrity=\"sha384-L1dWfspMTHU/ApYnFiMz2QID/PlP1xCW9visvBdbEkOLkSSWsP6ZJWhPw6apiXxU\" crossorigin=\"anonymous\"><script src=\"https://cdn.jsdelivr.net/npm/htmx.org@2.0.10/dist/htmx.min.js\" integrity=\"sha384-H5SrcfygHmAuTDZphMHqBJLc3FhssKjG7w/CeCpFReSfwBWDTKpkzPP8c+cLsK+V\" crossorigin=\"anonymous\"></script><style>\n\t\t\thtml { height: 100%; }\n\t\t\tbody { display: flex; flex-direction: column; min-height: 100vh; }\n\t\t\tbody > main.container { flex: 1 0 auto; }\n\t\t\tbody > footer.container { flex-shrink: 0; text-align: center; }\n\t\t\t.card-grid { display: grid; grid-template-columns: repeat(auto-fit, minmax(220px, 280px)); justify-content: center; gap: 1rem; }\n\t\t\t.card-grid article { margin-bottom: 0; }\n\t\t\t.table-scroll { overflow-x: auto; }\n\t\t\t.table-scroll table { width: max-content; min-width: 100%; }\n\t\t\t.table-scroll th { position: relative; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }\n\t\t\t.col-resizer { position: absolute; top: 0; right: 0; width: 6px; height: 100%; cursor: col-resize; user-select: none; touch-action: none; }\n\t\t\t.col-resizer:hover, .col-resizer.is-resizing { background: var(--pico-primary); opacity: 0.5; }\n\t\t\tdialog article > header { display: flex; align-items: center; justify-content: space-between; gap: 1rem; }\n\t\t\t.dialog-close-btn { background: none; border: none; padding: 0; margin: 0; font-size: 1.5rem; line-height: 1; cursor: pointer; color: var(--pico-secondary); }\n\t\t\t.dialog-close-btn:hover { color: var(--pico-primary); }\n\t\t\t.footer-icon { vertical-align: text-bottom; }\n\t\t\t#alerts { position: fixed; top: 1rem; right: 1rem; z-index: 100; display: flex; flex-direction: column; gap: 0.5rem; max-width: 24rem; }\n\t\t\t#alerts .alert-success, #alerts .alert-error { margin: 0; padding: 0.75rem 1rem; border-radius: var(--pico-border-radius); }\n\t\t\t#alerts .alert-error { background: var(--pico-del-color); color: var(--pico-contrast); }\n\t\t\t#alerts .alert-success { background: var(--pico-ins-color); color: var(--pico-contrast); }\n\t\t\t.alert-warning { border-left: 0.25rem solid var(--pico-mark-background-color); }\n\t\t\t.alert-warning ul { margin-bottom: 0.5rem; }\n\t\t\t.gallery { display: grid; grid-template-columns: repeat(auto-fill, minmax(160px, 1fr)); gap: 1rem; margin-bottom: 1rem; }\n\t\t\t.gallery figure { margin: 0; }\n\t\t\t.gallery img { width: 100%; aspect-ratio: 1; object-fit: cover; border-radius: var(--pico-border-radius); }\n\t\t\t#shots-compare { display: inline-block; }\n\t\t\t#shots-comparison tr.differs > th { color: var(--pico-primary); }\n\t\t\t.gallery figcaption { display: flex; justify-content: space-between; gap: 0.5rem; overflow-wrap: anywhere; }\n\t\t</style></head>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+// scripts renders the scripts of every page, loaded at the end of its <body>.
+func scripts() templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var6 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var6 == nil {
+			templ_7745c5c3_Var6 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, "<script>\n\t\t// Dark mode: default to prefers-color-scheme, override via nav toggle, persist in localStorage.\n\t\t(function () {\n\t\t\tvar stored = localStorage.getItem(\"theme\");\n\t\t\tif (stored) {\n\t\t\t\tdocument.documentElement.setAttribute(\"data-theme\", stored);\n\t\t\t}\n\t\t\tdocument.addEventListener(\"click\", function (evt) {\n\t\t\t\tvar toggle = evt.target.closest(\"[data-theme-toggle]\");\n\t\t\t\tif (!toggle) return;\n\t\t\t\tvar current = document.documentElement.getAttribute(\"data-theme\") === \"dark\" ? \"dark\" : \"light\";\n\t\t\t\tvar next = current === \"dark\" ? \"light\" : \"dark\";\n\t\t\t\tdocument.documentElement.setAttribute(\"data-theme\", next);\n\t\t\t\tlocalStorage.setItem(\"theme\", next);\n\t\t\t});\n\t\t})();\n\n\t\t// Auto-dismiss success/error alerts a few seconds after they are inserted.\n\t\t(function () {\n\t\t\tfunction scheduleDismiss(el) {\n\t\t\t\tsetTimeout(function () {\n\t\t\t\t\tif (el && el.parentNode) el.parentNode.removeChild(el);\n\t\t\t\t}, 5000);\n\t\t\t}\n\t\t\tnew MutationObserver(function (mutations) {\n\t\t\t\tmutations.forEach(function (m) {\n\t\t\t\t\tm.addedNodes.forEach(function (n) {\n\t\t\t\t\t\tif (n.nodeType === 1 && n.matches && n.matches(\".alert-success\")) scheduleDismiss(n);\n\t\t\t\t\t});\n\t\t\t\t});\n\t\t\t}).observe(document.getElementById(\"alerts\"), { childList: true });\n\t\t})();\n\n\t\t// Let expected HTML error responses (validation/domain errors) swap normally;\n\t\t// htmx's default behavior already only blocks swaps for network errors, so this\n\t\t// listener only needs to ensure our declared 4xx statuses are treated as swappable.\n\t\tdocument.body.addEventListener(\"htmx:beforeSwap\", function (evt) {\n\t\t\tvar status = evt.detail.xhr.status;\n\t\t\tif (status === 400 || status === 403 || status === 404 || status === 409 || status === 413 || status === 415) {\n\t\t\t\tevt.detail.shouldSwap = true;\n\t\t\t}\n\t\t});\n\n\t\t// Shared <dialog> controller for bean/shot add/edit forms.\n\t\tfunction openDialog(dialog) {\n\t\t\tif (dialog && typeof dialog.showModal === \"function\" && !dialog.hasAttribute(\"data-modal-open\")) {\n\t\t\t\tdialog.setAttribute(\"data-modal-open\", \"true\");\n\t\t\t\tdialog.showModal();\n\t\t\t}\n\t\t}\n\t\t// The native \"close\" event fires for every way a <dialog> stops being\n\t\t// open, including Escape (which the handlers below never see since\n\t\t// they only run on close()/backdrop-click/data-dialog-close), so this\n\t\t// is the one place data-modal-open is cleared - no path can leave it\n\t\t// set once the dialog is actually closed. \"close\" doesn't bubble, so\n\t\t// this listener must use the capture phase.\n\t\tdocument.addEventListener(\"close\", function (evt) {\n\t\t\tif (evt.target.tagName === \"DIALOG\") evt.target.removeAttribute(\"data-modal-open\");\n\t\t}, true);\n\t\tdocument.body.addEventListener(\"htmx:afterSwap\", function (evt) {\n\t\t\t// Only a GET request loads a fresh add/edit form into the dialog and\n\t\t\t// should open it. A create/update POST/PUT also targets the dialog\n\t\t\t// (even when it sets HX-Reswap: none on success, which skips the\n\t\t\t// actual swap but still fires this event) and must never reopen it\n\t\t\t// after the dialog-close listener below has just closed it.\n\t\t\tif (evt.detail.requestConfig && evt.detail.requestConfig.verb !== \"get\") return;\n\t\t\tvar dialog = evt.target.querySelector ? evt.target.querySelector(\"dialog[open]\") : null;\n\t\t\tif (evt.target.matches && evt.target.matches(\"dialog\")) dialog = evt.target;\n\t\t\topenDialog(dialog);\n\t\t});\n\t\t// A direct GET to an add/edit route renders its full-page fallback\n\t\t// with the dialog already populated (see e.g. web.AddBeanForm); open\n\t\t// it once on load. A dialog left empty by the normal list page (no\n\t\t// child content) is untouched.\n\t\tdocument.querySelectorAll(\"dialog\").forEach(function (dialog) {\n\t\t\tif (dialog.firstElementChild) openDialog(dialog);\n\t\t});\n\t\tdocument.body.addEventListener(\"dialog-close\", function () {\n\t\t\tvar dialog = document.querySelector(\"dialog[open]\");\n\t\t\tif (dialog) dialog.close();\n\t\t});\n\t\tdocument.addEventListener(\"click\", function (evt) {\n\t\t\tvar closer = evt.target.closest(\"[data-dialog-close]\");\n\t\t\tif (!closer) return;\n\t\t\tvar dialog = evt.target.closest(\"dialog\");\n\t\t\tif (dialog) dialog.close();\n\t\t});\n\t\t// Clicking the backdrop closes the dialog. Pico centers the dialog's\n\t\t// content (the <article>) with flexbox, so the <dialog> element's own\n\t\t// box covers the full viewport; a click event's target is the <dialog>\n\t\t// itself only when it lands outside that centered content.\n\t\tdocument.addEventListener(\"click\", function (evt) {\n\t\t\tif (evt.target.tagName !== \"DIALOG\") return;\n\t\t\tevt.target.close();\n\t\t});\n\n\t\t// Draggable column resizing for every table under .table-scroll. Handles\n\t\t// are added on demand (idempotent) so this also covers tables swapped in\n\t\t// later by htmx (e.g. re-sorting, which replaces the whole <table>).\n\t\t(function () {\n\t\t\tfunction addResizeHandles() {\n\t\t\t\tdocument.querySelectorAll(\".table-scroll table\").forEach(function (table) {\n\t\t\t\t\tif (table.hasAttribute(\"data-resizable\")) return;\n\t\t\t\t\tvar ths = table.querySelectorAll(\"thead tr th\");\n\t\t\t\t\t// Pin each column's current auto-computed width, and give the table\n\t\t\t\t\t// itself an explicit pixel width (their sum), before switching to a\n\t\t\t\t\t// fixed layout. table-layout: fixed ignores a <th>'s inline width\n\t\t\t\t\t// when the table's own width is an intrinsic keyword (max-content),\n\t\t\t\t\t// so this must be a definite length for per-column resizing to work.\n\t\t\t\t\tvar total = 0;\n\t\t\t\t\tths.forEach(function (th) {\n\t\t\t\t\t\tvar width = th.offsetWidth;\n\t\t\t\t\t\tth.style.width = width + \"px\";\n\t\t\t\t\t\ttotal += width;\n\t\t\t\t\t});\n\t\t\t\t\ttable.style.width = total + \"px\";\n\t\t\t\t\ttable.style.tableLayout = \"fixed\";\n\t\t\t\t\ttable.setAttribute(\"data-resizable\", \"true\");\n\t\t\t\t\tths.forEach(function (th) {\n\t\t\t\t\t\tif (th.querySelector(\".col-resizer\")) return;\n\t\t\t\t\t\tvar handle = document.createElement(\"span\");\n\t\t\t\t\t\thandle.className = \"col-resizer\";\n\t\t\t\t\t\tth.appendChild(handle);\n\t\t\t\t\t});\n\t\t\t\t});\n\t\t\t}\n\t\t\taddResizeHandles();\n\t\t\tdocument.body.addEventListener(\"htmx:afterSwap\", addResizeHandles);\n\n\t\t\tvar resizing = null;\n\t\t\tdocument.addEventListener(\"mousedown\", function (evt) {\n\t\t\t\tvar handle = evt.target.closest(\".col-resizer\");\n\t\t\t\tif (!handle) return;\n\t\t\t\tevt.preventDefault();\n\t\t\t\tvar th = handle.parentElement;\n\t\t\t\tvar table = th.closest(\"table\");\n\t\t\t\tresizing = { th: th, table: table, startX: evt.clientX, startWidth: th.offsetWidth, startTableWidth: table.offsetWidth };\n\t\t\t\thandle.classList.add(\"is-resizing\");\n\t\t\t});\n\t\t\tdocument.addEventListener(\"mousemove\", function (evt) {\n\t\t\t\tif (!resizing) return;\n\t\t\t\tvar width = Math.max(40, resizing.startWidth + (evt.clientX - resizing.startX));\n\t\t\t\tresizing.th.style.width = width + \"px\";\n\t\t\t\t// Keep the table's own (definite, fixed-layout) width in sync so the\n\t\t\t\t// browser doesn't redistribute the resized column's space among\n\t\t\t\t// the others to make the total add back up.\n\t\t\t\tresizing.table.style.width = (resizing.startTableWidth + (width - resizing.startWidth)) + \"px\";\n\t\t\t});\n\t\t\tdocument.addEventListener(\"mouseup\", function () {\n\t\t\t\tif (!resizing) return;\n\t\t\t\tvar handle = resizing.th.querySelector(\".col-resizer\");\n\t\t\t\tif (handle) handle.classList.remove(\"is-resizing\");\n\t\t\t\tresizing = null;\n\t\t\t});\n\t\t})();\n\t</script>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/a-h/templ"
+	"github.com/lescactus/espressoapi-go/internal/auth"
 )
 
 func render(t *testing.T, c templ.Component) string {
@@ -49,3 +50,23 @@ func TestNotFoundPage_Renders404(t *testing.T) {
 		t.Errorf("expected 404 page content, got: %s", html)
 	}
 }
+
+func TestLayout_SendsCSRFTokenWithinSession(t *testing.T) {
+	if html := render(t, Layout("Sheets", "sheets")); strings.Contains(html, "hx-headers") {
+		t.Errorf("expected no hx-headers outside of a session, got: %s", html)
+	}
+
+	ctx := auth.NewCSRFContext(auth.NewContext(context.Background(), &auth.User{Id: 4, Name: "alice"}), "tok3n")
+	var b strings.Builder
+	if err := Layout("Sheets", "sheets").Render(ctx, &b); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	html := b.String()
+
+	if !strings.Contains(html, `hx-headers="{&#34;X-CSRF-Token&#34;:&#34;tok3n&#34;}"`) {
+		t.Errorf("expected the CSRF token in hx-headers, got: %s", html)
+	}
+	if !strings.Contains(html, `hx-post="/logout"`) || !strings.Contains(html, `title="Logged in as alice"`) {
+		t.Errorf("expected a log out button for alice, got: %s", html)
+	}
+}
@@ -0,0 +1,58 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/lescactus/espressoapi-go/internal/auth"
+)
+
+// CSRFHeader is the request header htmx sends the session's CSRF token in.
+const CSRFHeader = "X-CSRF-Token"
+
+// csrfHeaders returns the hx-headers value sending the CSRF token of the
+// request's session with every htmx request, or "" outside of a session.
+func csrfHeaders(ctx context.Context) string {
+	token, ok := auth.CSRFTokenFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	headers, _ := json.Marshal(map[string]string{CSRFHeader: token})
+	return string(headers)
+}
+
+// userName returns the name of the user the request is authenticated as, or
+// "" for an unauthenticated request.
+func userName(ctx context.Context) string {
+	if user, ok := auth.FromContext(ctx); ok {
+		return user.Name
+	}
+	return ""
+}
+
+// readOnlyKey is the context key marking a request whose views are read-only.
+type readOnlyKey struct{}
+
+// WithReadOnly returns a copy of ctx in which views are rendered read-only,
+// without the buttons of any action but reading, such as for a sheet opened
+// from a share link.
+func WithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+// IsReadOnly reports whether views are rendered read-only in ctx.
+func IsReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyKey{}).(bool)
+	return readOnly
+}
+
+// Can reports whether the user the request is authenticated as may perform
+// action on resource, so that views only show the buttons of allowed actions.
+// Unauthenticated requests are allowed everything, as by the handlers, unless
+// they are read-only.
+func Can(ctx context.Context, resource auth.Resource, action auth.Action) bool {
+	if IsReadOnly(ctx) {
+		return action == auth.ActionRead
+	}
+	return auth.Allowed(ctx, resource, action)
+}
@@ -0,0 +1,56 @@
+package sheets
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/lescactus/espressoapi-go/views/templates/charts"
+)
+
+// dialInCharts returns the charts of the dial-in of a sheet from its shots,
+// in the order they were pulled: the rating of each shot in turn, the shot
+// time against the grind setting, and the ratio against the rating. The best
+// shot is highlighted on every chart.
+func dialInCharts(shots []shot.Shot, bestShotId *int) (rating, time, ratio charts.Chart) {
+	rating = charts.Chart{ID: "sheet-rating-chart", Title: "Rating over the shots", XLabel: "Shot", YLabel: "Rating", IntegerX: true}
+	time = charts.Chart{ID: "sheet-time-chart", Title: "Shot time against grind setting", XLabel: "Grind setting", YLabel: "Shot time (s)", IntegerX: true}
+	ratio = charts.Chart{ID: "sheet-ratio-chart", Title: "Ratio against rating", XLabel: "Rating", YLabel: "Ratio (out / in)"}
+
+	var ratings, times, ratios charts.Series
+	for i, s := range shots {
+		best := bestShotId != nil && s.Id == *bestShotId
+		seconds := s.ShotTime.Seconds()
+		point := func(x, y float64) charts.Point {
+			p := charts.Point{X: x, Y: y, Title: shotTitle(s, seconds), Highlight: best}
+			if best {
+				p.Label = "Best"
+			}
+			return p
+		}
+		ratings.Points = append(ratings.Points, point(float64(i+1), s.Rating))
+		times.Points = append(times.Points, point(float64(s.GrindSetting), seconds))
+		if s.QuantityIn > 0 {
+			ratios.Points = append(ratios.Points, point(s.Rating, s.QuantityOut/s.QuantityIn))
+		}
+	}
+	rating.Series = []charts.Series{ratings}
+	time.Series = []charts.Series{times}
+	ratio.Series = []charts.Series{ratios}
+	return rating, time, ratio
+}
+
+// shotTitle describes a shot when hovering its points.
+func shotTitle(s shot.Shot, seconds float64) string {
+	title := "Shot #" + strconv.Itoa(s.Id) + ": grind " + strconv.Itoa(s.GrindSetting) +
+		", " + formatDecimal(seconds) + " s"
+	if s.QuantityIn > 0 {
+		title += ", 1:" + formatDecimal(s.QuantityOut/s.QuantityIn)
+	}
+	return title + ", rated " + formatDecimal(s.Rating)
+}
+
+// formatDecimal renders v with at most two decimals.
+func formatDecimal(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
@@ -3,18 +3,45 @@
 // clashing with the services/sheet package.
 package sheets
 
-import "strconv"
+import (
+	"strconv"
+
+	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+)
 
 // FormState carries a sheet add/edit form's submitted values and any
 // validation error so invalid input can be redisplayed after a 400/409
-// response.
+// response. TemplateID and CopyBestShot are only submitted by the add form,
+// to start the new sheet from a template, and IsTemplate by the edit forms.
 type FormState struct {
-	ID    int
-	Name  string
-	Error string
+	ID           int
+	Name         string
+	IsTemplate   bool
+	TemplateID   string
+	CopyBestShot bool
+	Error        string
+}
+
+// Templates returns the sheets of sheets flagged as templates, the ones the
+// add form can start a new sheet from.
+func Templates(sheets []sheet.Sheet) []sheet.Sheet {
+	templates := make([]sheet.Sheet, 0)
+	for _, s := range sheets {
+		if s.IsTemplate {
+			templates = append(templates, s)
+		}
+	}
+	return templates
 }
 
 func rowElementID(id int) string { return "sheet-row-" + strconv.Itoa(id) }
 func updatePath(id int) string   { return "/sheets/update/" + strconv.Itoa(id) }
 func deletePath(id int) string   { return "/sheets/delete/" + strconv.Itoa(id) }
 func getPath(id int) string      { return "/sheets/get/" + strconv.Itoa(id) }
+func sharePath(id int) string    { return "/sheets/share/" + strconv.Itoa(id) }
+
+func revokeShareLinkPath(id int) string { return "/share_links/delete/" + strconv.Itoa(id) }
+
+// shareURL returns the absolute URL of the page showing the sheet shared with
+// token, baseURL being the scheme and host the web UI is served on.
+func shareURL(baseURL, token string) string { return baseURL + "/share/" + token }
@@ -7,7 +7,10 @@ import (
 	"time"
 
 	"github.com/a-h/templ"
+	"github.com/lescactus/espressoapi-go/internal/services/share"
 	"github.com/lescactus/espressoapi-go/internal/services/sheet"
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
 )
 
 func render(t *testing.T, c templ.Component) string {
@@ -123,6 +126,26 @@ func TestDetailHeaderEdit_OmitsUpdatedWhenEmpty(t *testing.T) {
 	}
 }
 
+func TestEditForms_KeepTheTemplateFlag(t *testing.T) {
+	state := FormState{ID: 42, Name: "Double shot", IsTemplate: true}
+	for name, html := range map[string]string{
+		"row":    render(t, EditRow(state, "2026-01-02 03:04", "")),
+		"header": render(t, DetailHeaderEdit(state, "2026-01-02 03:04", "")),
+	} {
+		if !strings.Contains(html, `name="is_template" value="true" checked`) {
+			t.Errorf("%s: expected the template checkbox checked, got: %s", name, html)
+		}
+	}
+}
+
+func TestAddRow_OmitsStartFromWithoutTemplates(t *testing.T) {
+	html := render(t, AddRow(FormState{}, nil))
+
+	if strings.Contains(html, "template_id") || strings.Contains(html, "copy_best_shot") {
+		t.Errorf("expected no starting point without templates, got: %s", html)
+	}
+}
+
 func TestTable_ShowsSortIndicatorOnActiveColumn(t *testing.T) {
 	html := render(t, Table([]sheet.Sheet{testSheet()}, "name", "asc", false))
 
@@ -131,8 +154,16 @@ func TestTable_ShowsSortIndicatorOnActiveColumn(t *testing.T) {
 	}
 }
 
+func TestPage_LinksTheCSVExport(t *testing.T) {
+	html := render(t, Page([]sheet.Sheet{testSheet()}, "name", "asc", false))
+
+	if !strings.Contains(html, `href="/sheets/export" download>Export CSV</a>`) {
+		t.Errorf("expected an Export CSV button, got: %s", html)
+	}
+}
+
 func TestHome_EmptyStateShowsCallToAction(t *testing.T) {
-	html := render(t, Home(nil))
+	html := render(t, Home(nil, nil))
 
 	if !strings.Contains(html, "Add your first sheet") {
 		t.Errorf("expected empty-state call to action, got: %s", html)
@@ -140,7 +171,7 @@ func TestHome_EmptyStateShowsCallToAction(t *testing.T) {
 }
 
 func TestHome_CardsOmitCreatedTimestamp(t *testing.T) {
-	html := render(t, Home([]sheet.Sheet{testSheet()}))
+	html := render(t, Home([]sheet.Sheet{testSheet()}, nil))
 
 	if !strings.Contains(html, "Double shot") {
 		t.Fatalf("expected the sheet card to render, got: %s", html)
@@ -150,8 +181,21 @@ func TestHome_CardsOmitCreatedTimestamp(t *testing.T) {
 	}
 }
 
+func TestHome_ShowsMaintenanceBannerOnlyWhenOverdue(t *testing.T) {
+	html := render(t, Home([]sheet.Sheet{testSheet()}, []string{"Backflush (Linea Mini) is overdue by 14 shots."}))
+	for _, want := range []string{`class="alert-warning"`, "Backflush (Linea Mini) is overdue by 14 shots.", `href="/maintenance"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected home page to contain %q, got: %s", want, html)
+		}
+	}
+
+	if html := render(t, Home([]sheet.Sheet{testSheet()}, nil)); strings.Contains(html, `class="alert-warning"`) {
+		t.Errorf("expected no maintenance banner without overdue tasks, got: %s", html)
+	}
+}
+
 func TestDetail_IncludesShotsCRUDSection(t *testing.T) {
-	html := render(t, Detail(testSheet(), nil))
+	html := render(t, Detail(testSheet(), nil, stats.ShotStats{}, nil, ""))
 
 	if !strings.Contains(html, `id="shot-dialog"`) {
 		t.Errorf("expected the shot dialog target to be present, got: %s", html)
@@ -160,3 +204,64 @@ func TestDetail_IncludesShotsCRUDSection(t *testing.T) {
 		t.Errorf("expected the add-shot link to lock the current sheet, got: %s", html)
 	}
 }
+
+func TestShareSection_ListsLinksWithActions(t *testing.T) {
+	links := []share.ShareLink{{Id: 7, SheetId: 42, Token: "7.1792281600.sig", ExpiresAt: time.Date(2026, 10, 25, 8, 0, 0, 0, time.UTC)}}
+	html := render(t, ShareSection(42, links, "https://espresso.example.com"))
+
+	for _, want := range []string{
+		`href="https://espresso.example.com/share/7.1792281600.sig"`, "Expires at 2026-10-25 08:00",
+		`hx-delete="/share_links/delete/7"`, `hx-post="/sheets/share/42"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected share section to contain %q, got: %s", want, html)
+		}
+	}
+
+	if html := render(t, ShareSection(42, nil, "")); !strings.Contains(html, "No active share link.") {
+		t.Errorf("expected an empty state without links, got: %s", html)
+	}
+}
+
+func TestShared_IsReadOnly(t *testing.T) {
+	html := render(t, Shared(testSheet(), []shot.Shot{{Id: 9}}))
+
+	if !strings.Contains(html, "Double shot") || !strings.Contains(html, `id="shot-row-9"`) {
+		t.Errorf("expected the sheet and its shots, got: %s", html)
+	}
+	for _, unwanted := range []string{"<nav", "hx-delete", "hx-put", "hx-post", "/shots/add", `name="ids"`, `aria-label="Compare"`} {
+		if strings.Contains(html, unwanted) {
+			t.Errorf("expected the shared page not to contain %q, got: %s", unwanted, html)
+		}
+	}
+}
+
+func TestDialInCharts_HighlightsBestShot(t *testing.T) {
+	shots := []shot.Shot{
+		{Id: 3, GrindSetting: 12, QuantityIn: 18, QuantityOut: 30, ShotTime: 22 * time.Second, Rating: 5},
+		{Id: 5, GrindSetting: 10, QuantityIn: 18, QuantityOut: 36, ShotTime: 28500 * time.Millisecond, Rating: 8.5},
+		{Id: 8, GrindSetting: 9, QuantityIn: 0, QuantityOut: 40, ShotTime: 33 * time.Second, Rating: 6},
+	}
+	best := 5
+
+	rating, shotTime, ratio := dialInCharts(shots, &best)
+	if got := rating.Series[0].Points[1]; got.X != 2 || got.Y != 8.5 || !got.Highlight || got.Label != "Best" {
+		t.Errorf("expected the second shot highlighted on the rating chart, got %+v", got)
+	}
+	if got := shotTime.Series[0].Points[0]; got.X != 12 || got.Y != 22 || got.Highlight {
+		t.Errorf("expected the time of the first shot against its grind setting, got %+v", got)
+	}
+	if got := ratio.Series[0].Points; len(got) != 2 || got[1].X != 8.5 || got[1].Y != 2 {
+		t.Errorf("expected the ratio of the shots with a dose against their rating, got %+v", got)
+	}
+
+	html := render(t, DialInCharts(shots, &best))
+	for _, want := range []string{`id="sheet-rating-chart"`, `id="sheet-time-chart"`, `id="sheet-ratio-chart"`, "<title>Shot #5: grind 10, 28.5 s, 1:2, rated 8.5</title>"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected %q in:\n%s", want, html)
+		}
+	}
+	if html := render(t, DialInCharts(nil, nil)); html != "" {
+		t.Errorf("expected no charts without shots, got: %s", html)
+	}
+}
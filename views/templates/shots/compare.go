@@ -0,0 +1,114 @@
+package shots
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/lescactus/espressoapi-go/views/templates/shared"
+)
+
+// CompareFormID is the id of the form the selection checkboxes of the shots
+// tables belong to, submitting the selected ids to /shots/compare.
+const CompareFormID = "shots-compare"
+
+// compareCell is the value of a parameter for one shot, with its difference
+// with the reference shot when the parameter has one.
+type compareCell struct {
+	Value   string
+	Delta   string
+	Differs bool
+}
+
+// compareRow is a parameter of the compared shots, differing when it is not
+// the same for every shot.
+type compareRow struct {
+	Label   string
+	Differs bool
+	Cells   []compareCell
+}
+
+// compareParameter describes a row of the comparison: the field name
+// reported in shot.Comparison.Differences, how to render the value of a
+// shot, and how to render its delta, if any.
+type compareParameter struct {
+	field string
+	label string
+	value func(shot.Shot) string
+	delta func(shot.Delta) string
+}
+
+var compareParameters = []compareParameter{
+	{field: "sheet", label: "Sheet", value: func(s shot.Shot) string {
+		if s.Sheet == nil {
+			return ""
+		}
+		return s.Sheet.Name
+	}},
+	{field: "beans", label: "Beans", value: func(s shot.Shot) string {
+		if s.Beans == nil {
+			return ""
+		}
+		if s.Beans.Roaster != nil {
+			return s.Beans.Name + " (" + s.Beans.Roaster.Name + ")"
+		}
+		return s.Beans.Name
+	}},
+	{field: "grind_setting", label: "Grind", value: func(s shot.Shot) string { return strconv.Itoa(s.GrindSetting) },
+		delta: func(d shot.Delta) string { return signed(float64(d.GrindSetting)) }},
+	{field: "quantity_in", label: "In (g)", value: func(s shot.Shot) string { return strconv.FormatFloat(s.QuantityIn, 'f', 1, 64) },
+		delta: func(d shot.Delta) string { return signed(d.QuantityIn) }},
+	{field: "quantity_out", label: "Out (g)", value: func(s shot.Shot) string { return strconv.FormatFloat(s.QuantityOut, 'f', 1, 64) },
+		delta: func(d shot.Delta) string { return signed(d.QuantityOut) }},
+	{field: "shot_time", label: "Time", value: func(s shot.Shot) string { return secondsDisplay(s.ShotTime) },
+		delta: func(d shot.Delta) string { return signed(d.ShotTime.Seconds()) + " s" }},
+	{field: "water_temperature", label: "Temp", value: func(s shot.Shot) string { return strconv.FormatFloat(s.WaterTemperature, 'f', 1, 64) },
+		delta: func(d shot.Delta) string { return signed(d.WaterTemperature) }},
+	{field: "rating", label: "Rating", value: func(s shot.Shot) string { return strconv.FormatFloat(s.Rating, 'f', 1, 64) },
+		delta: func(d shot.Delta) string { return signed(d.Rating) }},
+	{field: "is_too_bitter", label: "Bitter", value: func(s shot.Shot) string { return boolLabel(s.IsTooBitter) }},
+	{field: "is_too_sour", label: "Sour", value: func(s shot.Shot) string { return boolLabel(s.IsTooSour) }},
+	{field: "comparison_with_previous_result", label: "Comparison", value: func(s shot.Shot) string { return s.ComparisonWithPreviousResult.String() }},
+	{field: "additional_notes", label: "Notes", value: func(s shot.Shot) string { return s.AdditionalNotes }},
+	{label: "Created", value: func(s shot.Shot) string { return shared.FormatTimestamp(s.CreatedAt) }},
+}
+
+// newCompareRows lays out the comparison as a row per parameter and a column
+// per shot. The deltas are left out of the reference shot's column.
+func newCompareRows(c shot.Comparison) []compareRow {
+	differs := make(map[string]bool, len(c.Differences))
+	for _, field := range c.Differences {
+		differs[field] = true
+	}
+
+	rows := make([]compareRow, len(compareParameters))
+	for i, p := range compareParameters {
+		row := compareRow{Label: p.label, Differs: differs[p.field]}
+		for j, s := range c.Shots {
+			cell := compareCell{Value: p.value(s)}
+			if j > 0 {
+				cell.Differs = row.Differs && cell.Value != p.value(c.Shots[0])
+				if p.delta != nil && j < len(c.Deltas) {
+					cell.Delta = p.delta(c.Deltas[j])
+				}
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// signed renders a difference with its sign, at most two decimals.
+func signed(v float64) string {
+	v = math.Round(v*100) / 100
+	s := strconv.FormatFloat(math.Abs(v), 'f', -1, 64)
+	switch {
+	case v > 0:
+		return "+" + s
+	case v < 0:
+		return "-" + s
+	default:
+		return "±" + s
+	}
+}
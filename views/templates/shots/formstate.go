@@ -52,6 +52,7 @@ func (s FormState) fieldError(field string) string {
 func rowElementID(id int) string { return "shot-row-" + strconv.Itoa(id) }
 func updatePath(id int) string   { return "/shots/update/" + strconv.Itoa(id) }
 func deletePath(id int) string   { return "/shots/delete/" + strconv.Itoa(id) }
+func againPath(id int) string    { return "/shots/again/" + strconv.Itoa(id) }
 
 // editPath is the Edit link's target: it carries view_context=sheet-shots
 // when the row is rendered without the Sheet column, so the edit dialog
@@ -62,3 +63,12 @@ func editPath(id int, showSheetColumn bool) string {
 	}
 	return updatePath(id) + "?view_context=" + ViewContextSheetShots
 }
+
+// pullAgainPath is the Pull again link's target, carrying view_context like
+// editPath so that the sheet stays locked on the sheet detail page.
+func pullAgainPath(id int, showSheetColumn bool) string {
+	if showSheetColumn {
+		return againPath(id)
+	}
+	return againPath(id) + "?view_context=" + ViewContextSheetShots
+}
@@ -0,0 +1,67 @@
+package shots
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lescactus/espressoapi-go/internal/services/shot"
+	"github.com/lescactus/espressoapi-go/views/templates/charts"
+)
+
+// profileChannel describes one reading of the samples: how to read it, its
+// label, color and the axis it is plotted against.
+type profileChannel struct {
+	Name  string
+	Unit  string
+	Color string
+	// Right plots the channel against an axis of its own on the right,
+	// starting at zero when Zero is set.
+	Right bool
+	Zero  bool
+	value func(shot.ProfileSample) *float64
+}
+
+// profileChannels are plotted in this order. Pressure and flow share the
+// left axis since both range over a few units; weight and temperature get an
+// axis of their own on the right. The temperature axis spans the readings'
+// range so its small variations stay visible.
+var profileChannels = []profileChannel{
+	{Name: "Pressure", Unit: "bar", Color: "#1e88e5", value: func(s shot.ProfileSample) *float64 { return s.Pressure }},
+	{Name: "Flow", Unit: "ml/s", Color: "#43a047", value: func(s shot.ProfileSample) *float64 { return s.Flow }},
+	{Name: "Weight", Unit: "g", Color: "#8d6e63", Right: true, Zero: true, value: func(s shot.ProfileSample) *float64 { return s.Weight }},
+	{Name: "Temperature", Unit: "°", Color: "#e53935", Right: true, value: func(s shot.ProfileSample) *float64 { return s.Temperature }},
+}
+
+// profileChart is the line chart of a shot profile, one curve per recorded
+// reading. Channels without any reading are left out along with their axis,
+// and every axis is labelled with the names of its channels.
+func profileChart(p shot.Profile) charts.Chart {
+	c := charts.Chart{
+		ID:     "shot-profile",
+		Title:  "Profile of shot #" + strconv.Itoa(p.ShotId),
+		XLabel: "Time (s)",
+		ZeroX:  true,
+		ZeroY:  true,
+	}
+	var left []string
+	for _, ch := range profileChannels {
+		s := charts.Series{Name: ch.Name + " (" + ch.Unit + ")", Color: ch.Color, HidePoints: true}
+		for _, sample := range p.Samples {
+			if v := ch.value(sample); v != nil {
+				s.Points = append(s.Points, charts.Point{X: sample.Time, Y: *v})
+			}
+		}
+		if len(s.Points) == 0 {
+			continue
+		}
+		if ch.Right {
+			c.RightAxes = append(c.RightAxes, charts.Axis{Label: s.Name, Color: ch.Color, Zero: ch.Zero})
+			s.Axis = len(c.RightAxes)
+		} else {
+			left = append(left, s.Name)
+		}
+		c.Series = append(c.Series, s)
+	}
+	c.YLabel = strings.Join(left, " · ")
+	return c
+}
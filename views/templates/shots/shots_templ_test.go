@@ -83,7 +83,7 @@ func TestRow_SheetCellLinksNameWithoutIDAndBeansCellShowsIDAndName(t *testing.T)
 }
 
 func TestRowPage_IncludesDialogTargetForEditLink(t *testing.T) {
-	html := render(t, RowPage(testShot()))
+	html := render(t, RowPage(testShot(), shot.Profile{}, nil))
 
 	if !strings.Contains(html, "<html") || !strings.Contains(html, "<table") {
 		t.Errorf("expected a full page with a one-row table, got: %s", html)
@@ -108,6 +108,18 @@ func TestRow_EditLinkCarriesViewContextOnlyWhenSheetColumnHidden(t *testing.T) {
 	}
 }
 
+func TestRow_PullAgainLinkOpensPrefilledAddForm(t *testing.T) {
+	withSheetColumn := render(t, Row(testShot(), true, ""))
+	if !strings.Contains(withSheetColumn, `hx-get="/shots/again/5" hx-target="#shot-dialog"`) {
+		t.Errorf("expected a Pull again link opening the shot dialog, got: %s", withSheetColumn)
+	}
+
+	withoutSheetColumn := render(t, Row(testShot(), false, ""))
+	if !strings.Contains(withoutSheetColumn, `hx-get="/shots/again/5?view_context=sheet-shots"`) {
+		t.Errorf("expected the Pull again link to carry view_context=sheet-shots, got: %s", withoutSheetColumn)
+	}
+}
+
 func TestRow_DeleteConfirmUsesShotID(t *testing.T) {
 	html := render(t, Row(testShot(), true, ""))
 	if !strings.Contains(html, `hx-confirm="Are you sure you want to delete shot #5?"`) {
@@ -173,3 +185,95 @@ func TestForm_ViewContextOmittedWhenUnset(t *testing.T) {
 		t.Errorf("expected no view_context field when unset, got: %s", html)
 	}
 }
+
+func ptr(v float64) *float64 { return &v }
+
+func TestRowPage_ProfileSection(t *testing.T) {
+	empty := render(t, RowPage(testShot(), shot.Profile{ShotId: 5}, nil))
+	if !strings.Contains(empty, "No profile recorded for this shot.") || strings.Contains(empty, `id="shot-profile"`) {
+		t.Errorf("expected no chart for a shot without a profile, got: %s", empty)
+	}
+
+	profile := shot.Profile{ShotId: 5, Samples: []shot.ProfileSample{
+		{Time: 0, Pressure: ptr(0), Weight: ptr(0)},
+		{Time: 12.5, Pressure: ptr(9), Weight: ptr(18)},
+		{Time: 25, Pressure: ptr(6), Weight: ptr(36)},
+	}}
+	html := render(t, RowPage(testShot(), profile, nil))
+	if !strings.Contains(html, `id="shot-profile"`) {
+		t.Fatalf("expected the profile chart, got: %s", html)
+	}
+	for _, want := range []string{"Pressure (bar)", "Weight (g)", "Time (s)", ">40</text>", `class="axis"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected the chart to contain %q, got: %s", want, html)
+		}
+	}
+	for _, unwanted := range []string{"Flow (ml/s)", "Temperature"} {
+		if strings.Contains(html, unwanted) {
+			t.Errorf("expected the chart to skip the unrecorded %q, got: %s", unwanted, html)
+		}
+	}
+}
+
+func TestProfileChart_AxesPerRecordedReading(t *testing.T) {
+	samples := []shot.ProfileSample{
+		{Time: 0, Pressure: ptr(2), Flow: ptr(0), Weight: ptr(0), Temperature: ptr(92.4)},
+		{Time: 30, Pressure: ptr(8.6), Flow: ptr(2), Weight: ptr(40), Temperature: ptr(93.6)},
+	}
+	c := profileChart(shot.Profile{ShotId: 5, Samples: samples})
+	if len(c.Series) != 4 {
+		t.Fatalf("expected 4 series, got %d", len(c.Series))
+	}
+	if len(c.RightAxes) != 2 {
+		t.Fatalf("expected 2 right axes, got %d", len(c.RightAxes))
+	}
+	if c.YLabel != "Pressure (bar) · Flow (ml/s)" {
+		t.Errorf("expected the left axis labelled with its channels, got %q", c.YLabel)
+	}
+	if !c.ZeroY || !c.RightAxes[0].Zero || c.RightAxes[1].Zero {
+		t.Errorf("expected the left and weight axes from zero and the temperature one spanning the readings, got %+v", c.RightAxes)
+	}
+	for i, want := range []int{0, 0, 1, 2} {
+		if c.Series[i].Axis != want {
+			t.Errorf("expected %s against axis %d, got %d", c.Series[i].Name, want, c.Series[i].Axis)
+		}
+	}
+
+	c = profileChart(shot.Profile{Samples: []shot.ProfileSample{{Time: 0, Pressure: ptr(2), Temperature: ptr(92.4)}}})
+	if len(c.RightAxes) != 1 || c.RightAxes[0].Label != "Temperature (°)" || c.Series[1].Axis != 1 {
+		t.Errorf("expected the temperature on the first right axis without weight, got %+v", c)
+	}
+}
+
+func TestRow_SelectsShotForComparison(t *testing.T) {
+	html := render(t, Row(testShot(), true, ""))
+
+	if !strings.Contains(html, `type="checkbox" name="ids" value="5" form="shots-compare"`) {
+		t.Errorf("expected a checkbox submitting the shot id to the compare form, got: %s", html)
+	}
+}
+
+func TestComparePage_HighlightsDifferencesWithDeltas(t *testing.T) {
+	other := testShot()
+	other.Id = 7
+	other.GrindSetting = 10
+	other.ShotTime = 31 * time.Second
+	other.Rating = 7
+
+	html := render(t, ComparePage(*shot.Compare([]shot.Shot{testShot(), other})))
+
+	for _, want := range []string{
+		`href="/shots/get/5"`, `href="/shots/get/7"`,
+		`<tr class="differs"><th scope="row">Grind</th><td>12 </td><td><mark>10</mark> <small>(-2)</small></td>`,
+		`<mark>31.0 s</mark> <small>(+2.5 s)</small>`,
+		`<mark>7.0</mark> <small>(-1.5)</small>`,
+		`<tr class=""><th scope="row">In (g)</th><td>18.0 </td><td>18.0 <small>(±0)</small></td>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected the comparison to contain %q, got: %s", want, html)
+		}
+	}
+	if strings.Contains(html, "<mark>Morning</mark>") {
+		t.Errorf("expected the shared sheet not to be highlighted, got: %s", html)
+	}
+}
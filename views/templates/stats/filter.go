@@ -0,0 +1,16 @@
+// Package stats renders the consumption statistics page: its range and time
+// zone filter, a calendar heatmap of the shots pulled each day and a summary
+// of the range, and the statistics of the shots of beans, a roaster or a
+// sheet shown on their pages. It is imported into internal/controllers/web as viewstats to
+// avoid clashing with the services/stats package.
+package stats
+
+// Filter carries the consumption page's submitted query values, as typed, so
+// they are redisplayed with the statistics. Error holds why the statistics
+// could not be computed from them (an invalid date, range or time zone).
+type Filter struct {
+	From  string
+	To    string
+	TZ    string
+	Error string
+}
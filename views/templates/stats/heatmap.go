@@ -0,0 +1,121 @@
+package stats
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
+)
+
+// Heatmap geometry, in SVG user units. Each column is a week starting on
+// Sunday and each row a day of the week, with the month names above and
+// some weekday names on the left.
+const (
+	cellSize      = 11
+	cellGap       = 2
+	heatmapLeft   = 30
+	heatmapTop    = 16
+	heatmapLevels = 4
+)
+
+// cell is the geometry of one day's square.
+type cell struct {
+	X     int
+	Y     int
+	Level int
+	Date  string
+	Title string
+}
+
+// axisLabel is a month or weekday name and where it is drawn.
+type axisLabel struct {
+	X     int
+	Y     int
+	Label string
+}
+
+// heatmap is the precomputed geometry of a consumption calendar heatmap.
+type heatmap struct {
+	Cells    []cell
+	Months   []axisLabel
+	Weekdays []axisLabel
+	ViewBox  string
+}
+
+// newHeatmap lays out one cell per day of the range, shaded by the number of
+// shots pulled on it relative to the busiest day.
+func newHeatmap(c stats.Consumption) heatmap {
+	byDate := make(map[string]stats.ConsumptionDay, len(c.Days))
+	maxShots := 0
+	for _, d := range c.Days {
+		byDate[d.Date.Format(time.DateOnly)] = d
+		maxShots = max(maxShots, d.Shots)
+	}
+
+	start := c.From.AddDate(0, 0, -int(c.From.Weekday()))
+	h := heatmap{}
+	weeks := 0
+	for day := c.From; !day.After(c.To); day = day.AddDate(0, 0, 1) {
+		week := int(day.Sub(start).Hours() / 24 / 7)
+		weeks = week + 1
+		x := heatmapLeft + week*(cellSize+cellGap)
+
+		date := day.Format(time.DateOnly)
+		d, pulled := byDate[date]
+		level := 0
+		title := "No shots on " + day.Format("Monday 2 January 2006")
+		if pulled {
+			level = int(math.Ceil(float64(d.Shots) / float64(maxShots) * heatmapLevels))
+			title = shotsLabel(d.Shots) + ", " + formatNumber(d.CoffeeWeight) + " g"
+			// The average rating is 0 when none of the shots is rated yet.
+			if d.AverageRating > 0 {
+				title += ", rated " + formatNumber(d.AverageRating)
+			}
+			title += " on " + day.Format("Monday 2 January 2006")
+		}
+		h.Cells = append(h.Cells, cell{
+			X:     x,
+			Y:     heatmapTop + int(day.Weekday())*(cellSize+cellGap),
+			Level: level,
+			Date:  date,
+			Title: title,
+		})
+
+		// A month is named above the week of its first day, and the first
+		// partial month only when its name has room before the next one.
+		if day.Day() == 1 || (day.Equal(c.From) && day.Day() <= 14) {
+			h.Months = append(h.Months, axisLabel{X: x, Y: heatmapTop - 5, Label: day.Format("Jan")})
+		}
+	}
+	for _, wd := range []time.Weekday{time.Monday, time.Wednesday, time.Friday} {
+		h.Weekdays = append(h.Weekdays, axisLabel{
+			X:     heatmapLeft - 4,
+			Y:     heatmapTop + int(wd)*(cellSize+cellGap) + cellSize - 2,
+			Label: wd.String()[:3],
+		})
+	}
+
+	width := heatmapLeft + weeks*(cellSize+cellGap)
+	height := heatmapTop + 7*(cellSize+cellGap)
+	h.ViewBox = "0 0 " + strconv.Itoa(width) + " " + strconv.Itoa(height)
+	return h
+}
+
+// levelOpacity is how strongly a cell of the given level is shaded.
+func levelOpacity(level int) string {
+	return strconv.FormatFloat(float64(level)/heatmapLevels, 'f', -1, 64)
+}
+
+// shotsLabel renders a number of shots, singular or plural.
+func shotsLabel(n int) string {
+	if n == 1 {
+		return "1 shot"
+	}
+	return strconv.Itoa(n) + " shots"
+}
+
+// formatNumber renders v with the fewest digits needed.
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,36 @@
+package stats
+
+import (
+	"math"
+	"strconv"
+)
+
+// formatOptional renders v with the fewest digits needed, or a dash when
+// there is no value.
+func formatOptional(v *float64) string {
+	if v == nil {
+		return "–"
+	}
+	return formatNumber(*v)
+}
+
+// formatShare renders a share from 0 to 1 as a percentage.
+func formatShare(v *float64) string {
+	if v == nil {
+		return "–"
+	}
+	return formatNumber(math.Round(*v*1000)/10) + " %"
+}
+
+// grindRange renders the finest and coarsest grind settings, once when they
+// are the same.
+func grindRange(lowest, highest *int) string {
+	switch {
+	case lowest == nil || highest == nil:
+		return "–"
+	case *lowest == *highest:
+		return strconv.Itoa(*lowest)
+	default:
+		return strconv.Itoa(*lowest) + " – " + strconv.Itoa(*highest)
+	}
+}
@@ -0,0 +1,136 @@
+package stats
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/lescactus/espressoapi-go/internal/services/stats"
+)
+
+func render(t *testing.T, c templ.Component) string {
+	t.Helper()
+	var b strings.Builder
+	if err := c.Render(context.Background(), &b); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return b.String()
+}
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2026, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func testConsumption() stats.Consumption {
+	rating := 8.0
+	return stats.Consumption{
+		// From a Thursday to a Saturday, three weeks later.
+		From:     day(time.September, 24),
+		To:       day(time.October, 17),
+		TimeZone: "UTC",
+		Days: []stats.ConsumptionDay{
+			{Date: day(time.September, 24), Shots: 4, CoffeeWeight: 72, AverageRating: 8.5},
+			{Date: day(time.October, 1), Shots: 1, CoffeeWeight: 18, AverageRating: 6},
+			{Date: day(time.October, 3), Shots: 1, CoffeeWeight: 18},
+		},
+		Shots:         6,
+		CoffeeWeight:  108,
+		AverageRating: &rating,
+	}
+}
+
+func TestNewHeatmap_LaysOutWeeksFromSunday(t *testing.T) {
+	h := newHeatmap(testConsumption())
+
+	if len(h.Cells) != 24 {
+		t.Fatalf("expected one cell per day of the range, got %d", len(h.Cells))
+	}
+	first, last := h.Cells[0], h.Cells[len(h.Cells)-1]
+	if first.X != heatmapLeft || first.Y != heatmapTop+4*(cellSize+cellGap) {
+		t.Errorf("expected the first Thursday in the first column, fifth row, got (%d, %d)", first.X, first.Y)
+	}
+	if last.X != heatmapLeft+3*(cellSize+cellGap) || last.Y != heatmapTop+6*(cellSize+cellGap) {
+		t.Errorf("expected the last Saturday in the fourth column, last row, got (%d, %d)", last.X, last.Y)
+	}
+	if first.Level != 4 || h.Cells[7].Level != 1 || h.Cells[1].Level != 0 {
+		t.Errorf("expected levels relative to the busiest day, got %d, %d and %d", first.Level, h.Cells[7].Level, h.Cells[1].Level)
+	}
+	if h.Cells[7].Title != "1 shot, 18 g, rated 6 on Thursday 1 October 2026" {
+		t.Errorf("unexpected title %q", h.Cells[7].Title)
+	}
+	if h.Cells[9].Title != "1 shot, 18 g on Saturday 3 October 2026" {
+		t.Errorf("expected no rating on a day without rated shots, got title %q", h.Cells[9].Title)
+	}
+	if len(h.Months) != 1 || h.Months[0].Label != "Oct" {
+		t.Errorf("expected only October to be named, as September has no room, got %+v", h.Months)
+	}
+	if h.ViewBox != "0 0 82 107" {
+		t.Errorf("expected the heatmap to grow with the weeks, got viewBox %q", h.ViewBox)
+	}
+}
+
+func TestPage_RendersHeatmapAndSummary(t *testing.T) {
+	c := testConsumption()
+	html := render(t, Page(Filter{TZ: "UTC"}, &c))
+
+	for _, want := range []string{`id="consumption-heatmap"`, `data-date="2026-09-24" data-level="4"`, "<title>No shots on Friday 25 September 2026</title>", `id="consumption-summary"`, "<td>108</td>", `aria-current="page">Stats</a>`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected page to contain %q, got: %s", want, html)
+		}
+	}
+}
+
+func TestPage_RendersFilterError(t *testing.T) {
+	html := render(t, Page(Filter{TZ: "Mars/Olympus_Mons", Error: "Time zone must be an IANA name, such as Europe/Paris."}, nil))
+
+	if !strings.Contains(html, `role="alert"`) || !strings.Contains(html, `value="Mars/Olympus_Mons"`) {
+		t.Errorf("expected the error and the filter as typed, got: %s", html)
+	}
+	if strings.Contains(html, "consumption-heatmap") {
+		t.Errorf("expected no heatmap without statistics, got: %s", html)
+	}
+}
+
+func TestShotStats(t *testing.T) {
+	rating, median, best := 7.25, 7.5, 9.0
+	ratio, shotTime, bitter, sour := 2.05, 27.5, 0.25, 0.125
+	id, finest, coarsest := 31, 12, 16
+	html := render(t, ShotStats(stats.ShotStats{
+		Shots:           8,
+		AverageRating:   &rating,
+		MedianRating:    &median,
+		MaxRating:       &best,
+		BestShotId:      &id,
+		MinGrindSetting: &finest,
+		MaxGrindSetting: &coarsest,
+		AverageRatio:    &ratio,
+		AverageShotTime: &shotTime,
+		BitterShare:     &bitter,
+		SourShare:       &sour,
+	}))
+
+	for _, want := range []string{
+		`<td>8</td>`,
+		`<td>7.25 / 7.5 / 9</td>`,
+		`<a href="/shots/get/31">#31</a>`,
+		`<td>12 – 16</td>`,
+		`<td>1:2.05</td>`,
+		`<td>27.5</td>`,
+		`<td>25 %</td>`,
+		`<td>12.5 %</td>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected %q in:\n%s", want, html)
+		}
+	}
+}
+
+func TestShotStats_WithoutShots(t *testing.T) {
+	html := render(t, ShotStats(stats.ShotStats{}))
+
+	if !strings.Contains(html, "No shots yet.") || strings.Contains(html, "<table>") {
+		t.Errorf("expected only the empty message, got:\n%s", html)
+	}
+}